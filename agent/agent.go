@@ -163,6 +163,11 @@ const (
 
 	MgoStatsEnabled = "MGO_STATS_ENABLED"
 
+	// FailedNetworkTemplateHash is the hash of the network template that
+	// the machiner last failed to apply, so that it isn't applied again
+	// until the template changes.
+	FailedNetworkTemplateHash = "FAILED_NETWORK_TEMPLATE_HASH"
+
 	// LoggingOverride will set the logging for this agent to the value
 	// specified. Model configuration will be ignored and this value takes
 	// precidence for the agent.
@@ -41,6 +41,9 @@ func (c *Client) ListOperations(arg params.OperationQueryArgs) (params.Operation
 	if v := c.BestAPIVersion(); v < 6 {
 		return results, errors.Errorf("ListOperations not supported by this version (%d) of Juju", v)
 	}
+	if v := c.BestAPIVersion(); v < 8 && len(arg.Schedules) > 0 {
+		return results, errors.Errorf("filtering operations by schedule not supported by this version (%d) of Juju", v)
+	}
 	err := c.facade.FacadeCall("ListOperations", arg, &results)
 	if params.ErrCode(err) == params.CodeNotFound {
 		err = nil
@@ -92,6 +95,17 @@ func (c *Client) EnqueueOperation(arg params.Actions) (params.EnqueuedActions, e
 	return results, err
 }
 
+// EnqueueRollingOperation takes a list of Actions and queues them up to be
+// executed as an operation whose tasks are started a few at a time.
+func (c *Client) EnqueueRollingOperation(arg params.RollingActions) (params.EnqueuedActions, error) {
+	results := params.EnqueuedActions{}
+	if v := c.BestAPIVersion(); v < 8 {
+		return results, errors.Errorf("EnqueueRollingOperation not supported by this version (%d) of Juju", v)
+	}
+	err := c.facade.FacadeCall("EnqueueRollingOperation", arg, &results)
+	return results, err
+}
+
 // Cancel attempts to cancel a queued up Action from running.
 func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	results := params.ActionResults{}
@@ -134,13 +148,30 @@ func (c *Client) WatchActionProgress(actionId string) (watcher.StringsWatcher, e
 	if v := c.BestAPIVersion(); v < 5 {
 		return nil, errors.Errorf("WatchActionProgress not supported by this version (%d) of Juju", v)
 	}
+	return c.watchActionLogs("WatchActionsProgress", actionId)
+}
+
+// WatchActionOutput returns a watcher that reports on action log messages
+// and on chunks of output written by the action. The result strings are
+// json formatted core.actions.ActionMessage objects; output messages have
+// their Stream set. A NotSupported error is returned if the controller
+// cannot stream action output.
+func (c *Client) WatchActionOutput(actionId string) (watcher.StringsWatcher, error) {
+	if v := c.BestAPIVersion(); v < 8 {
+		return nil, errors.NewNotSupported(nil,
+			fmt.Sprintf("WatchActionOutput not supported by this version (%d) of Juju", v))
+	}
+	return c.watchActionLogs("WatchActionsOutput", actionId)
+}
+
+func (c *Client) watchActionLogs(facadeMethod, actionId string) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
 		Entities: []params.Entity{
 			{Tag: names.NewActionTag(actionId).String()},
 		},
 	}
-	err := c.facade.FacadeCall("WatchActionsProgress", args, &results)
+	err := c.facade.FacadeCall(facadeMethod, args, &results)
 	if err != nil {
 		return nil, err
 	}
@@ -6,6 +6,7 @@ package action_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -163,6 +164,60 @@ func (s *actionSuite) TestWatchActionProgress(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *actionSuite) TestWatchActionOutput(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "WatchActionsOutput")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{
+						Tag: "action-666",
+					}},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+				*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+					Results: []params.StringsWatchResult{{
+						Error: &params.Error{Message: "FAIL"},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := action.NewClient(apiCaller)
+	w, err := client.WatchActionOutput("666")
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *actionSuite) TestWatchActionOutputNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.WatchActionOutput("666")
+	c.Assert(err, gc.ErrorMatches, `WatchActionOutput not supported by this version \(7\) of Juju`)
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
 func (s *actionSuite) TestWatchActionProgressArity(c *gc.C) {
 	apiCaller := basetesting.BestVersionCaller{
 		APICallerFunc: basetesting.APICallerFunc(
@@ -352,6 +407,51 @@ func (s *actionSuite) TestEnqueueOperation(c *gc.C) {
 	})
 }
 
+func (s *actionSuite) TestEnqueueRollingOperation(c *gc.C) {
+	args := params.RollingActions{
+		Actions: []params.Action{{
+			Receiver: "application-mysql",
+			Name:     "restart",
+		}},
+		MaxParallel:   2,
+		StopOnFailure: 1,
+		LeaderOrder:   "first",
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "EnqueueRollingOperation")
+				c.Assert(a, jc.DeepEquals, args)
+				c.Assert(result, gc.FitsTypeOf, &params.EnqueuedActions{})
+				*(result.(*params.EnqueuedActions)) = params.EnqueuedActions{
+					OperationTag: "operation-1",
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := action.NewClient(apiCaller)
+	result, err := client.EnqueueRollingOperation(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EnqueuedActions{
+		OperationTag: "operation-1",
+	})
+}
+
+func (s *actionSuite) TestEnqueueRollingOperationNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 7,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.EnqueueRollingOperation(params.RollingActions{})
+	c.Assert(err, gc.ErrorMatches, "EnqueueRollingOperation not supported by this version \\(7\\) of Juju")
+}
+
 func (s *actionSuite) TestEnqueueOperationNotSupported(c *gc.C) {
 	apiCaller := basetesting.BestVersionCaller{
 		APICallerFunc: basetesting.APICallerFunc(
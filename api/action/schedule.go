@@ -0,0 +1,62 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddActionSchedules stores schedules which run an action
+// as a new operation each time their cron expression is due.
+func (c *Client) AddActionSchedules(args []params.AddActionSchedule) ([]params.ActionScheduleResult, error) {
+	if v := c.BestAPIVersion(); v < 8 {
+		return nil, errors.Errorf("AddActionSchedules not supported by this version (%d) of Juju", v)
+	}
+	var results params.ActionScheduleResults
+	err := c.facade.FacadeCall("AddActionSchedules", params.ActionScheduleArgs{Schedules: args}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(args) {
+		return nil, errors.Errorf("expected %d results, got %d", len(args), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ListActionSchedules returns all the action schedules in the model.
+func (c *Client) ListActionSchedules() ([]params.ActionSchedule, error) {
+	if v := c.BestAPIVersion(); v < 8 {
+		return nil, errors.Errorf("ListActionSchedules not supported by this version (%d) of Juju", v)
+	}
+	var results params.ActionScheduleResults
+	if err := c.facade.FacadeCall("ListActionSchedules", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	schedules := make([]params.ActionSchedule, 0, len(results.Results))
+	for _, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Trace(result.Error)
+		}
+		schedules = append(schedules, *result.Schedule)
+	}
+	return schedules, nil
+}
+
+// RemoveActionSchedules removes the action schedules with the given ids.
+func (c *Client) RemoveActionSchedules(ids []string) ([]params.ErrorResult, error) {
+	if v := c.BestAPIVersion(); v < 8 {
+		return nil, errors.Errorf("RemoveActionSchedules not supported by this version (%d) of Juju", v)
+	}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("RemoveActionSchedules", params.ActionScheduleIds{Ids: ids}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d results, got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type scheduleSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) TestAddActionSchedules(c *gc.C) {
+	args := []params.AddActionSchedule{{
+		Receiver: "mysql",
+		Name:     "backup",
+		Cron:     "0 3 * * *",
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "AddActionSchedules")
+				c.Assert(a, jc.DeepEquals, params.ActionScheduleArgs{Schedules: args})
+				*(result.(*params.ActionScheduleResults)) = params.ActionScheduleResults{
+					Results: []params.ActionScheduleResult{{
+						Schedule: &params.ActionSchedule{Id: "1", Receiver: "mysql"},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := action.NewClient(apiCaller)
+	results, err := client.AddActionSchedules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ActionScheduleResult{{
+		Schedule: &params.ActionSchedule{Id: "1", Receiver: "mysql"},
+	}})
+}
+
+func (s *scheduleSuite) TestListActionSchedules(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "ListActionSchedules")
+				*(result.(*params.ActionScheduleResults)) = params.ActionScheduleResults{
+					Results: []params.ActionScheduleResult{{
+						Schedule: &params.ActionSchedule{Id: "1"},
+					}, {
+						Schedule: &params.ActionSchedule{Id: "2"},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := action.NewClient(apiCaller)
+	schedules, err := client.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, jc.DeepEquals, []params.ActionSchedule{{Id: "1"}, {Id: "2"}})
+}
+
+func (s *scheduleSuite) TestRemoveActionSchedules(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "RemoveActionSchedules")
+				c.Assert(a, jc.DeepEquals, params.ActionScheduleIds{Ids: []string{"1", "2"}})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{}, {
+						Error: &params.Error{Message: "boom"},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := action.NewClient(apiCaller)
+	results, err := client.RemoveActionSchedules([]string{"1", "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[1].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *scheduleSuite) TestActionSchedulesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.AddActionSchedules(nil)
+	c.Assert(err, gc.ErrorMatches, "AddActionSchedules not supported by this version \\(7\\) of Juju")
+	_, err = client.ListActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "ListActionSchedules not supported by this version \\(7\\) of Juju")
+	_, err = client.RemoveActionSchedules(nil)
+	c.Assert(err, gc.ErrorMatches, "RemoveActionSchedules not supported by this version \\(7\\) of Juju")
+	_, err = client.ListOperations(params.OperationQueryArgs{Schedules: []string{"1"}})
+	c.Assert(err, gc.ErrorMatches, "filtering operations by schedule not supported by this version \\(7\\) of Juju")
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const facadeName = "ActionScheduler"
+
+// Client provides access to the ActionScheduler API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client-side ActionScheduler facade.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, facadeName)}
+}
+
+// WatchActionSchedules returns a watcher which triggers when
+// action schedules are added, fired or removed.
+func (c *Client) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// ActionSchedules returns all the action schedules in the model.
+func (c *Client) ActionSchedules() ([]params.ActionSchedule, error) {
+	var results params.ActionScheduleResults
+	if err := c.facade.FacadeCall("ActionSchedules", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	schedules := make([]params.ActionSchedule, 0, len(results.Results))
+	for _, result := range results.Results {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		schedules = append(schedules, *result.Schedule)
+	}
+	return schedules, nil
+}
+
+// FireActionSchedule enqueues an operation for the action
+// schedule with the given id, recording it as run at now.
+func (c *Client) FireActionSchedule(id string, now time.Time) error {
+	args := params.FireActionSchedules{
+		Schedules: []params.FireActionSchedule{{Id: id, Time: now}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("FireActionSchedules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,74 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestActionSchedules(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "ActionSchedules",
+		Results: params.ActionScheduleResults{
+			Results: []params.ActionScheduleResult{{
+				Schedule: &params.ActionSchedule{Id: "1", Cron: "@daily"},
+			}},
+		},
+	})
+	client := actionscheduler.NewClient(caller)
+	schedules, err := client.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, jc.DeepEquals, []params.ActionSchedule{{Id: "1", Cron: "@daily"}})
+}
+
+func (s *ClientSuite) TestFireActionSchedule(c *gc.C) {
+	now := time.Date(2021, 3, 1, 3, 0, 0, 0, time.UTC)
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "FireActionSchedules",
+		Args: params.FireActionSchedules{
+			Schedules: []params.FireActionSchedule{{Id: "1", Time: now}},
+		},
+		Results: params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		},
+	})
+	client := actionscheduler.NewClient(caller)
+	err := client.FireActionSchedule("1", now)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestWatchActionSchedulesError(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "WatchActionSchedules",
+		Results: params.NotifyWatchResult{
+			Error: &params.Error{Message: "boom"},
+		},
+	})
+	client := actionscheduler.NewClient(caller)
+	_, err := client.WatchActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
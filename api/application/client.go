@@ -873,6 +873,21 @@ func (c *Client) Expose(application string, exposedEndpoints map[string]params.E
 	return c.facade.FacadeCall("Expose", args, nil)
 }
 
+// ExposeIngress exposes a k8s application through an ingress resource
+// routing the ingress hostname to the application's opened ports. The
+// exposedEndpoints argument is interpreted as it is for Expose.
+func (c *Client) ExposeIngress(application string, exposedEndpoints map[string]params.ExposedEndpoint, ingress params.ExposedIngress) error {
+	if c.BestAPIVersion() < 14 {
+		return errors.NotSupportedf("exposing an ingress on this juju controller")
+	}
+	args := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposedEndpoints,
+		Ingress:          &ingress,
+	}
+	return c.facade.FacadeCall("Expose", args, nil)
+}
+
 func hasGranularExposeParameters(exposedEndpoints map[string]params.ExposedEndpoint) bool {
 	if len(exposedEndpoints) == 0 { // empty list; using non-granular expose like pre 2.9 juju
 		return false
@@ -1125,6 +1140,37 @@ func (c *Client) ResolveUnitErrors(units []string, all, retry bool) error {
 	return errors.Trace(results.Combine())
 }
 
+// PauseUnits pauses hook execution on the given units. The unit agents
+// finish any operation already under way but start no new ones until
+// the units are resumed.
+func (c *Client) PauseUnits(units []string) error {
+	return c.setUnitsPaused("PauseUnits", units)
+}
+
+// ResumeUnits resumes hook execution on the given units.
+func (c *Client) ResumeUnits(units []string) error {
+	return c.setUnitsPaused("ResumeUnits", units)
+}
+
+func (c *Client) setUnitsPaused(facadeMethod string, units []string) error {
+	if c.BestAPIVersion() < 14 {
+		return errors.NotSupportedf("%s on this juju controller", facadeMethod)
+	}
+	entities := make([]params.Entity, len(units))
+	for i, unit := range units {
+		if !names.IsValidUnit(unit) {
+			return errors.NotValidf("unit name %q", unit)
+		}
+		entities[i].Tag = names.NewUnitTag(unit).String()
+	}
+	results := new(params.ErrorResults)
+	err := c.facade.FacadeCall(facadeMethod, params.Entities{Entities: entities}, results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.Combine())
+}
+
 func validateApplicationScale(scale, scaleChange int) error {
 	if scale < 0 && scaleChange == 0 {
 		return errors.NotValidf("scale < 0")
@@ -37,7 +37,7 @@ type applicationSuite struct {
 var _ = gc.Suite(&applicationSuite{})
 
 func newClient(f basetesting.APICallerFunc) *application.Client {
-	return newClientWithVersion(f, 13)
+	return newClientWithVersion(f, 14)
 }
 
 func newClientWithVersion(f basetesting.APICallerFunc, version int) *application.Client {
@@ -1344,6 +1344,52 @@ func (s *applicationSuite) TestResolveUnitErrors(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestPauseUnits(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "PauseUnits")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{
+				{Tag: "unit-mysql-0"},
+				{Tag: "unit-mysql-1"},
+			},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := client.PauseUnits([]string{"mysql/0", "mysql/1"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestResumeUnits(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "ResumeUnits")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 1)
+		return nil
+	})
+	err := client.ResumeUnits([]string{"mysql/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestPauseUnitsNotSupported(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	}, 13)
+	err := client.PauseUnits([]string{"mysql/0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestResolveUnitErrorsUnitsAll(c *gc.C) {
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
 		c.Fail()
@@ -1905,6 +1951,39 @@ func (s *applicationSuite) TestExposeVersionChecks(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestExposeIngress(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "gitlab",
+			Ingress: &params.ExposedIngress{
+				Hostname:  "gitlab.example.com",
+				Path:      "/",
+				TLSSecret: "gitlab-tls",
+			},
+		})
+		return nil
+	})
+	err := client.ExposeIngress("gitlab", nil, params.ExposedIngress{
+		Hostname:  "gitlab.example.com",
+		Path:      "/",
+		TLSSecret: "gitlab-tls",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeIngressNotSupported(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	}, 13)
+	err := client.ExposeIngress("gitlab", nil, params.ExposedIngress{Hostname: "gitlab.example.com"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestUnexposeVersionChecks(c *gc.C) {
 	specs := []struct {
 		descr            string
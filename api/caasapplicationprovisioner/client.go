@@ -14,6 +14,7 @@ import (
 	charmscommon "github.com/juju/juju/api/common/charms"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/life"
@@ -119,6 +120,8 @@ type ProvisioningInfo struct {
 	ImageRepo            string
 	CharmModifiedVersion int
 	CharmURL             *charm.URL
+	Autoscale            *caas.AutoscalePolicy
+	Availability         *caas.AvailabilityPolicy
 }
 
 // ProvisioningInfo returns the info needed to provision an operator for an application.
@@ -166,6 +169,26 @@ func (c *Client) ProvisioningInfo(applicationName string) (ProvisioningInfo, err
 		})
 	}
 
+	if r.Autoscale != nil {
+		info.Autoscale = &caas.AutoscalePolicy{
+			MinUnits:     r.Autoscale.MinUnits,
+			MaxUnits:     r.Autoscale.MaxUnits,
+			TargetCPU:    r.Autoscale.TargetCPU,
+			TargetMemory: r.Autoscale.TargetMemory,
+			Metric:       r.Autoscale.Metric,
+			MetricTarget: r.Autoscale.MetricTarget,
+		}
+	}
+
+	if r.Availability != nil {
+		info.Availability = &caas.AvailabilityPolicy{
+			MaxUnavailable: r.Availability.MaxUnavailableUnits,
+		}
+		for _, t := range r.Availability.SpreadAcross {
+			info.Availability.SpreadAcross = append(info.Availability.SpreadAcross, caas.Topology(t))
+		}
+	}
+
 	if r.CharmURL != "" {
 		charmURL, err := charm.ParseURL(r.CharmURL)
 		if err != nil {
@@ -352,3 +375,21 @@ func (c *Client) UpdateUnits(arg params.UpdateApplicationUnits) (*params.UpdateA
 func (c *Client) WatchApplication(appName string) (watcher.NotifyWatcher, error) {
 	return common.Watch(c.facade, "Watch", names.NewApplicationTag(appName))
 }
+
+// WatchApplicationConfig returns a NotifyWatcher that notifies of
+// changes to the config of the application.
+func (c *Client) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return nil, errors.NotSupportedf("WatchApplicationConfig for CAASApplicationProvisioner facade v%v", apiVersion)
+	}
+	return common.Watch(c.facade, "WatchApplicationConfig", names.NewApplicationTag(appName))
+}
+
+// WatchApplicationConstraints returns a NotifyWatcher that notifies of
+// changes to the constraints of the application.
+func (c *Client) WatchApplicationConstraints(appName string) (watcher.NotifyWatcher, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return nil, errors.NotSupportedf("WatchApplicationConstraints for CAASApplicationProvisioner facade v%v", apiVersion)
+	}
+	return common.Watch(c.facade, "WatchApplicationConstraints", names.NewApplicationTag(appName))
+}
@@ -15,6 +15,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/caasapplicationprovisioner"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/status"
@@ -27,7 +28,11 @@ type provisionerSuite struct {
 var _ = gc.Suite(&provisionerSuite{})
 
 func newClient(f basetesting.APICallerFunc) *caasapplicationprovisioner.Client {
-	return caasapplicationprovisioner.NewClient(basetesting.BestVersionCaller{f, 1})
+	return newClientWithVersion(f, 1)
+}
+
+func newClientWithVersion(f basetesting.APICallerFunc, version int) *caasapplicationprovisioner.Client {
+	return caasapplicationprovisioner.NewClient(basetesting.BestVersionCaller{f, version})
 }
 
 func (s *provisionerSuite) TestWatchApplications(c *gc.C) {
@@ -154,6 +159,15 @@ func (s *provisionerSuite) TestProvisioningInfo(c *gc.C) {
 				ImageRepo:            "jujuqa",
 				CharmModifiedVersion: 1,
 				CharmURL:             "cs:~test/charm-1",
+				Autoscale: &params.KubernetesAutoscaleParams{
+					MinUnits:  1,
+					MaxUnits:  3,
+					TargetCPU: 80,
+				},
+				Availability: &params.KubernetesAvailabilityParams{
+					MaxUnavailableUnits: 1,
+					SpreadAcross:        []string{"zone"},
+				},
 			}}}
 		return nil
 	})
@@ -168,6 +182,15 @@ func (s *provisionerSuite) TestProvisioningInfo(c *gc.C) {
 		ImageRepo:            "jujuqa",
 		CharmModifiedVersion: 1,
 		CharmURL:             &charm.URL{Schema: "cs", User: "test", Name: "charm", Revision: 1},
+		Autoscale: &caas.AutoscalePolicy{
+			MinUnits:  1,
+			MaxUnits:  3,
+			TargetCPU: 80,
+		},
+		Availability: &caas.AvailabilityPolicy{
+			MaxUnavailable: 1,
+			SpreadAcross:   []caas.Topology{caas.TopologyZone},
+		},
 	})
 }
 
@@ -403,3 +426,71 @@ func (s *provisionerSuite) TestWatchApplication(c *gc.C) {
 	c.Assert(watcher, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
+
+func (s *provisionerSuite) TestWatchApplicationConfig(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASApplicationProvisioner")
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchApplicationConfig")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	}, 2)
+	watcher, err := client.WatchApplicationConfig("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestWatchApplicationConfigV1NotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	_, err := client.WatchApplicationConfig("gitlab")
+	c.Assert(err, gc.ErrorMatches, "WatchApplicationConfig for CAASApplicationProvisioner facade v1 not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *provisionerSuite) TestWatchApplicationConstraints(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASApplicationProvisioner")
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchApplicationConstraints")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	}, 2)
+	watcher, err := client.WatchApplicationConstraints("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestWatchApplicationConstraintsV1NotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	_, err := client.WatchApplicationConstraints("gitlab")
+	c.Assert(err, gc.ErrorMatches, "WatchApplicationConstraints for CAASApplicationProvisioner facade v1 not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,40 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasevents
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the CAAS events API endpoint.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a client used to access the CAAS events API.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{
+		facade: base.NewFacadeCaller(caller, "CAASEvents"),
+	}
+}
+
+// RecordEvents records the cloud events in the event history of
+// the applications and units they're about.
+func (c *Client) RecordEvents(events []params.CAASEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	var results params.ErrorResults
+	args := params.CAASEvents{Events: events}
+	if err := c.facade.FacadeCall("RecordEvents", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(events) {
+		return errors.Errorf("expected %d results, got %d", len(events), len(results.Results))
+	}
+	return results.Combine()
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasevents_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/caasevents"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type eventsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&eventsSuite{})
+
+func (s *eventsSuite) TestRecordEvents(c *gc.C) {
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	events := []params.CAASEvent{{
+		ApplicationTag: "application-gitlab",
+		ProviderId:     "gitlab-0",
+		Object:         "Pod/gitlab-0",
+		Warning:        true,
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		Time:           now,
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASEvents")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RecordEvents")
+		c.Check(arg, jc.DeepEquals, params.CAASEvents{Events: events})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := caasevents.NewClient(apiCaller)
+	err := client.RecordEvents(events)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *eventsSuite) TestRecordEventsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "bang"}}},
+		}
+		return nil
+	})
+	client := caasevents.NewClient(apiCaller)
+	err := client.RecordEvents([]params.CAASEvent{{ApplicationTag: "application-gitlab"}})
+	c.Assert(err, gc.ErrorMatches, "bang")
+}
+
+func (s *eventsSuite) TestRecordEventsNoEvents(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	client := caasevents.NewClient(apiCaller)
+	err := client.RecordEvents(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -13,9 +13,11 @@ import (
 	charmscommon "github.com/juju/juju/api/common/charms"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 )
 
 // Client allows access to the CAAS firewaller API endpoint.
@@ -35,6 +37,7 @@ func NewClientLegacy(caller base.APICaller) *Client {
 type ClientEmbedded struct {
 	*Client
 	*charmscommon.CharmsClient
+	*common.ModelWatcher
 }
 
 // NewClientEmbedded returns a client used to access the CAAS unit provisioner API.
@@ -47,6 +50,7 @@ func NewClientEmbedded(caller base.APICaller) *ClientEmbedded {
 			facade: facadeCaller,
 		},
 		CharmsClient: charmsClient,
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
 	}
 }
 
@@ -103,6 +107,115 @@ func (c *ClientEmbedded) ApplicationCharmInfo(appName string) (*charmscommon.Cha
 	return c.CharmsClient.CharmInfo(url.String())
 }
 
+// RelatedApplications returns the names of the applications in the
+// model which are related to the specified application.
+func (c *ClientEmbedded) RelatedApplications(appName string) ([]string, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return nil, errors.NotSupportedf("RelatedApplications for CAASFirewallerEmbedded facade v%v", apiVersion)
+	}
+	return c.applicationStrings("RelatedApplications", appName)
+}
+
+// ExposedCIDRs returns the CIDRs which may access the specified
+// application, or none if the application is not exposed.
+func (c *ClientEmbedded) ExposedCIDRs(appName string) ([]string, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return nil, errors.NotSupportedf("ExposedCIDRs for CAASFirewallerEmbedded facade v%v", apiVersion)
+	}
+	return c.applicationStrings("ExposedCIDRs", appName)
+}
+
+// ExposedIngress returns the ingress used to expose the specified
+// application, or nil if the application is not exposed through one.
+func (c *ClientEmbedded) ExposedIngress(appName string) (*caas.Ingress, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return nil, errors.NotSupportedf("ExposedIngress for CAASFirewallerEmbedded facade v%v", apiVersion)
+	}
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.ExposedIngressResults
+	if err := c.facade.FacadeCall("ExposedIngress", entities(appTag), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if err := result.Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	if result.Result == nil {
+		return nil, nil
+	}
+	return &caas.Ingress{
+		Hostname:  result.Result.Hostname,
+		Path:      result.Result.Path,
+		TLSSecret: result.Result.TLSSecret,
+		Class:     result.Result.Class,
+	}, nil
+}
+
+func (c *ClientEmbedded) applicationStrings(method, appName string) ([]string, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.StringsResults
+	if err := c.facade.FacadeCall(method, entities(appTag), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// WatchRelations returns a StringsWatcher that notifies of changes
+// to the relations of the specified application.
+func (c *ClientEmbedded) WatchRelations(appName string) (watcher.StringsWatcher, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return nil, errors.NotSupportedf("WatchRelations for CAASFirewallerEmbedded facade v%v", apiVersion)
+	}
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.StringsWatchResults
+	if err := c.facade.FacadeCall("WatchRelations", entities(appTag), &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if err := result.Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// WatchForModelConfigChanges returns a NotifyWatcher that notifies
+// of changes to the model config.
+func (c *ClientEmbedded) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return nil, errors.NotSupportedf("WatchForModelConfigChanges for CAASFirewallerEmbedded facade v%v", apiVersion)
+	}
+	return c.ModelWatcher.WatchForModelConfigChanges()
+}
+
+// ModelConfig returns the current model config.
+func (c *ClientEmbedded) ModelConfig() (*config.Config, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return nil, errors.NotSupportedf("ModelConfig for CAASFirewallerEmbedded facade v%v", apiVersion)
+	}
+	return c.ModelWatcher.ModelConfig()
+}
+
 func applicationTag(application string) (names.ApplicationTag, error) {
 	if !names.IsValidApplication(application) {
 		return names.ApplicationTag{}, errors.NotValidf("application name %q", application)
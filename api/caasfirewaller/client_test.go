@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/caasfirewaller"
 	apicommoncharms "github.com/juju/juju/api/common/charms"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
@@ -127,6 +128,136 @@ func (s *firewallerEmbeddedSuite) TestApplicationCharmInfo(c *gc.C) {
 	})
 }
 
+func (s *firewallerEmbeddedSuite) TestRelatedApplications(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, s.objType)
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RelatedApplications")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsResults{})
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{
+				Result: []string{"mariadb"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClientEmbedded(basetesting.BestVersionCaller{apiCaller, 2})
+	related, err := client.RelatedApplications("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(related, jc.DeepEquals, []string{"mariadb"})
+}
+
+func (s *firewallerEmbeddedSuite) TestExposedCIDRsNotFound(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "ExposedCIDRs")
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{
+				Error: &params.Error{Code: params.CodeNotFound, Message: "bletch"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClientEmbedded(basetesting.BestVersionCaller{apiCaller, 2})
+	_, err := client.ExposedCIDRs("gitlab")
+	c.Assert(err, gc.ErrorMatches, "bletch")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *firewallerEmbeddedSuite) TestExposedIngress(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, s.objType)
+		c.Check(request, gc.Equals, "ExposedIngress")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-gitlab"}},
+		})
+		*(result.(*params.ExposedIngressResults)) = params.ExposedIngressResults{
+			Results: []params.ExposedIngressResult{{
+				Result: &params.ExposedIngress{
+					Hostname:  "gitlab.example.com",
+					TLSSecret: "gitlab-tls",
+				},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClientEmbedded(basetesting.BestVersionCaller{apiCaller, 2})
+	ingress, err := client.ExposedIngress("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ingress, jc.DeepEquals, &caas.Ingress{
+		Hostname:  "gitlab.example.com",
+		TLSSecret: "gitlab-tls",
+	})
+}
+
+func (s *firewallerEmbeddedSuite) TestExposedIngressNone(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ExposedIngressResults)) = params.ExposedIngressResults{
+			Results: []params.ExposedIngressResult{{}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClientEmbedded(basetesting.BestVersionCaller{apiCaller, 2})
+	ingress, err := client.ExposedIngress("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ingress, gc.IsNil)
+}
+
+func (s *firewallerEmbeddedSuite) TestWatchRelations(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, s.objType)
+		c.Check(request, gc.Equals, "WatchRelations")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+		*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+			Results: []params.StringsWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClientEmbedded(basetesting.BestVersionCaller{apiCaller, 2})
+	watcher, err := client.WatchRelations("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *firewallerEmbeddedSuite) TestV1NotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	client := caasfirewaller.NewClientEmbedded(basetesting.BestVersionCaller{apiCaller, 1})
+
+	_, err := client.RelatedApplications("gitlab")
+	c.Check(err, gc.ErrorMatches, "RelatedApplications for CAASFirewallerEmbedded facade v1 not supported")
+	_, err = client.ExposedCIDRs("gitlab")
+	c.Check(err, gc.ErrorMatches, "ExposedCIDRs for CAASFirewallerEmbedded facade v1 not supported")
+	_, err = client.ExposedIngress("gitlab")
+	c.Check(err, gc.ErrorMatches, "ExposedIngress for CAASFirewallerEmbedded facade v1 not supported")
+	_, err = client.WatchRelations("gitlab")
+	c.Check(err, gc.ErrorMatches, "WatchRelations for CAASFirewallerEmbedded facade v1 not supported")
+	_, err = client.WatchForModelConfigChanges()
+	c.Check(err, gc.ErrorMatches, "WatchForModelConfigChanges for CAASFirewallerEmbedded facade v1 not supported")
+	_, err = client.ModelConfig()
+	c.Check(err, gc.ErrorMatches, "ModelConfig for CAASFirewallerEmbedded facade v1 not supported")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *firewallerBaseSuite) TestIsExposed(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		c.Check(objType, gc.Equals, s.objType)
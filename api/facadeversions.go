@@ -11,14 +11,15 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       7,
+	"Action":                       8,
 	"ActionPruner":                 1,
+	"ActionScheduler":              1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  13,
+	"Application":                  14,
 	"ApplicationOffers":            3,
 	"ApplicationScaler":            1,
 	"Backups":                      3,
@@ -27,9 +28,10 @@ var facadeVersions = map[string]int{
 	"CAASAgent":                    1,
 	"CAASAdmission":                1,
 	"CAASApplication":              1,
-	"CAASApplicationProvisioner":   1,
+	"CAASApplicationProvisioner":   2,
+	"CAASEvents":                   1,
 	"CAASFirewaller":               1,
-	"CAASFirewallerEmbedded":       1,
+	"CAASFirewallerEmbedded":       2,
 	"CAASModelOperator":            1,
 	"CAASOperator":                 1,
 	"CAASOperatorProvisioner":      1,
@@ -53,10 +55,10 @@ var facadeVersions = map[string]int{
 	"FanConfigurer":                1,
 	"FilesystemAttachmentsWatcher": 2,
 	"Firewaller":                   6,
-	"FirewallRules":                1,
+	"FirewallRules":                2,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
-	"ImageManager":                 2,
+	"ImageManager":                 3,
 	"ImageMetadata":                3,
 	"ImageMetadataManager":         1,
 	"InstanceMutater":              2,
@@ -80,7 +82,7 @@ var facadeVersions = map[string]int{
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
-	"ModelConfig":                  2,
+	"ModelConfig":                  3,
 	"ModelGeneration":              4,
 	"ModelManager":                 9,
 	"ModelSummaryWatcher":          1,
@@ -111,7 +113,7 @@ var facadeVersions = map[string]int{
 	"Subnets":                      4,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       17,
+	"Uniter":                       18,
 	"Upgrader":                     1,
 	"UpgradeSeries":                3,
 	"UpgradeSteps":                 2,
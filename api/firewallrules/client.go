@@ -5,6 +5,7 @@ package firewallrules
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -52,3 +53,44 @@ func (c *Client) ListFirewallRules() ([]params.FirewallRule, error) {
 	}
 	return results.Rules, nil
 }
+
+// ApplicationFirewall returns, for each machine hosting units of the
+// application, the ingress rules expected by juju and those reported
+// by the provider.
+func (c *Client) ApplicationFirewall(application string) ([]params.MachineFirewall, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("showing application firewalls")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ApplicationFirewallResults
+	if err := c.facade.FacadeCall("ApplicationFirewalls", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Machines, nil
+}
+
+// ReconcileApplicationFirewall opens and closes ports on the machines
+// hosting units of the application, so that the ingress rules reported
+// by the provider match those expected by juju.
+func (c *Client) ReconcileApplicationFirewall(application string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("reconciling application firewalls")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ReconcileApplicationFirewalls", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -141,3 +141,107 @@ func (s *FirewallRulesSuite) TestListError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, "fail")
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *FirewallRulesSuite) TestApplicationFirewall(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ApplicationFirewalls")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-mysql"}},
+			})
+			if results, ok := result.(*params.ApplicationFirewallResults); ok {
+				results.Results = []params.ApplicationFirewallResult{{
+					Machines: []params.MachineFirewall{{
+						MachineTag: "machine-0",
+						InstanceId: "inst-0",
+					}},
+				}}
+			}
+			return nil
+		},
+	}
+
+	client := firewallrules.NewClient(apiCaller)
+	machines, err := client.ApplicationFirewall("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, []params.MachineFirewall{{
+		MachineTag: "machine-0",
+		InstanceId: "inst-0",
+	}})
+}
+
+func (s *FirewallRulesSuite) TestApplicationFirewallError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			if results, ok := result.(*params.ApplicationFirewallResults); ok {
+				results.Results = []params.ApplicationFirewallResult{{
+					Error: apiservererrors.ServerError(errors.NotSupportedf(`firewall mode "global"`)),
+				}}
+			}
+			return nil
+		},
+	}
+
+	client := firewallrules.NewClient(apiCaller)
+	_, err := client.ApplicationFirewall("mysql")
+	c.Assert(err, gc.ErrorMatches, `firewall mode "global" not supported`)
+}
+
+func (s *FirewallRulesSuite) TestApplicationFirewallNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 1,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fail()
+			return nil
+		},
+	}
+
+	client := firewallrules.NewClient(apiCaller)
+	_, err := client.ApplicationFirewall("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.ReconcileApplicationFirewall("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *FirewallRulesSuite) TestReconcileApplicationFirewall(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ReconcileApplicationFirewalls")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-mysql"}},
+			})
+			if results, ok := result.(*params.ErrorResults); ok {
+				results.Results = []params.ErrorResult{{
+					Error: apiservererrors.ServerError(errors.New("fail"))}}
+			}
+			return nil
+		},
+	}
+
+	client := firewallrules.NewClient(apiCaller)
+	err := client.ReconcileApplicationFirewall("mysql")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
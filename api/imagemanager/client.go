@@ -4,6 +4,8 @@
 package imagemanager
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 )
@@ -46,3 +48,22 @@ func (c *Client) DeleteImage(kind, series, arch string) error {
 	}
 	return results.OneError()
 }
+
+// CacheImage fetches the specified image into the controller's image
+// cache, replacing any cached copy.
+func (c *Client) CacheImage(kind, series, arch string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("caching images")
+	}
+	p := params.ImageFilterParams{
+		Images: []params.ImageSpec{
+			{Kind: kind, Series: series, Arch: arch},
+		},
+	}
+	results := new(params.ErrorResults)
+	err := c.facade.FacadeCall("CacheImages", p, results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
@@ -123,3 +123,68 @@ func (s *imagemanagerSuite) TestDeleteImageError(c *gc.C) {
 	err := im.DeleteImage("lxc", "trusty", "amd64")
 	c.Check(err, gc.ErrorMatches, "the devil made me do it")
 }
+
+func (s *imagemanagerSuite) TestCacheImage(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ImageManager")
+			c.Check(version, gc.Equals, 3)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CacheImages")
+			c.Check(arg, gc.DeepEquals, params.ImageFilterParams{
+				Images: []params.ImageSpec{{
+					Kind:   "lxd",
+					Series: "focal",
+					Arch:   "amd64",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: nil,
+				}},
+			}
+			callCount++
+			return nil
+		}),
+	}
+
+	im := imagemanager.NewClient(apiCaller)
+	err := im.CacheImage("lxd", "focal", "amd64")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *imagemanagerSuite) TestCacheImageError(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "no matching image found"},
+				}},
+			}
+			return nil
+		}),
+	}
+
+	im := imagemanager.NewClient(apiCaller)
+	err := im.CacheImage("lxd", "focal", "amd64")
+	c.Check(err, gc.ErrorMatches, "no matching image found")
+}
+
+func (s *imagemanagerSuite) TestCacheImageNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		}),
+	}
+
+	im := imagemanager.NewClient(apiCaller)
+	err := im.CacheImage("lxd", "focal", "amd64")
+	c.Check(err, gc.ErrorMatches, "caching images not supported")
+}
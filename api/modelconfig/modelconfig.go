@@ -91,6 +91,30 @@ func (c *Client) SLALevel() (string, error) {
 	return result.Result, nil
 }
 
+// SetImageRegistryCredential adds or replaces the credential the model's
+// workloads use to pull images from a private registry.
+func (c *Client) SetImageRegistryCredential(registry, username, password string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("SetImageRegistryCredential on v%d facade", c.BestAPIVersion())
+	}
+	args := params.ImageRegistryCredential{
+		Registry: registry,
+		Username: username,
+		Password: password,
+	}
+	return c.facade.FacadeCall("SetImageRegistryCredential", args, nil)
+}
+
+// RemoveImageRegistryCredential removes the credential the model's
+// workloads use to pull images from a private registry.
+func (c *Client) RemoveImageRegistryCredential(registry string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("RemoveImageRegistryCredential on v%d facade", c.BestAPIVersion())
+	}
+	args := params.ImageRegistryCredential{Registry: registry}
+	return c.facade.FacadeCall("RemoveImageRegistryCredential", args, nil)
+}
+
 // Sequences returns all sequence names and next values.
 func (c *Client) Sequences() (map[string]int, error) {
 	if c.BestAPIVersion() < 2 {
@@ -220,3 +220,66 @@ func (s *modelconfigSuite) TestSequences(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(sequences, jc.DeepEquals, map[string]int{"foo": 5, "bar": 2})
 }
+
+func (s *modelconfigSuite) TestSetImageRegistryCredential(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "SetImageRegistryCredential")
+				c.Check(a, jc.DeepEquals, params.ImageRegistryCredential{
+					Registry: "registry.internal",
+					Username: "fred",
+					Password: "secret",
+				})
+				called = true
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.SetImageRegistryCredential("registry.internal", "fred", "secret")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelconfigSuite) TestSetImageRegistryCredentialV2(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				c.Errorf("shouldn't be called")
+				return nil
+			},
+		), 2}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.SetImageRegistryCredential("registry.internal", "fred", "secret")
+	c.Assert(err, gc.ErrorMatches, "SetImageRegistryCredential on v2 facade not supported")
+}
+
+func (s *modelconfigSuite) TestRemoveImageRegistryCredential(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RemoveImageRegistryCredential")
+				c.Check(a, jc.DeepEquals, params.ImageRegistryCredential{Registry: "registry.internal"})
+				called = true
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.RemoveImageRegistryCredential("registry.internal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
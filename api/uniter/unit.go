@@ -25,6 +25,7 @@ type Unit struct {
 	life         life.Value
 	resolvedMode params.ResolvedMode
 	providerID   string
+	paused       bool
 }
 
 // Tag returns the unit's tag.
@@ -57,6 +58,11 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// Paused reports whether hook execution has been paused for the unit.
+func (u *Unit) Paused() bool {
+	return u.paused
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -80,6 +86,7 @@ func (u *Unit) Refresh() error {
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
 	u.providerID = result.ProviderID
+	u.paused = result.Paused
 	return nil
 }
 
@@ -655,6 +662,24 @@ func (u *Unit) LogActionMessage(tag names.ActionTag, message string) error {
 	return result.OneError()
 }
 
+// LogActionOutput records a chunk of output written by the specified action
+// to its "stdout" or "stderr" stream.
+func (u *Unit) LogActionOutput(tag names.ActionTag, stream, data string) error {
+	if u.st.facade.BestAPIVersion() < 18 {
+		return errors.NotImplementedf("LogActionOutput() (need V18+)")
+	}
+
+	var result params.ErrorResults
+	args := params.ActionOutputParams{
+		Output: []params.ActionOutput{{Tag: tag.String(), Stream: stream, Data: data}},
+	}
+	err := u.st.facade.FacadeCall("LogActionsOutput", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // UpgradeSeriesStatus returns the upgrade series status of a unit from remote state
 func (u *Unit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	res, err := u.st.UpgradeSeriesUnitStatus()
@@ -199,6 +199,7 @@ func (s *unitSuite) TestRefresh(c *gc.C) {
 				Life:       life.Dying,
 				Resolved:   params.ResolvedRetryHooks,
 				ProviderID: "666",
+				Paused:     true,
 			}},
 		}
 		return nil
@@ -210,6 +211,7 @@ func (s *unitSuite) TestRefresh(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(unit.Life(), gc.Equals, life.Dying)
 	c.Assert(unit.Resolved(), gc.Equals, params.ResolvedRetryHooks)
+	c.Assert(unit.Paused(), jc.IsTrue)
 	c.Assert(unit.Life(), gc.Equals, life.Dying)
 }
 
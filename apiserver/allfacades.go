@@ -74,9 +74,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
 	"github.com/juju/juju/apiserver/facades/controller/caasapplicationprovisioner"
+	"github.com/juju/juju/apiserver/facades/controller/caasevents"
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/apiserver/facades/controller/caasmodeloperator"
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorprovisioner"
@@ -134,7 +136,9 @@ func AllFacades() *facade.Registry {
 	}
 
 	reg("Action", 7, action.NewActionAPIV7)
+	reg("Action", 8, action.NewActionAPIV8)
 	reg("ActionPruner", 1, actionpruner.NewAPI)
+	reg("ActionScheduler", 1, actionscheduler.NewFacade)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
@@ -155,6 +159,7 @@ func AllFacades() *facade.Registry {
 	reg("Application", 11, application.NewFacadeV11) // Get call returns the endpoint bindings
 	reg("Application", 12, application.NewFacadeV12) // Adds UnitsInfo()
 	reg("Application", 13, application.NewFacadeV13) // Adds CharmOrigin to Deploy
+	reg("Application", 14, application.NewFacadeV14) // Adds PauseUnits and ResumeUnits
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
@@ -184,8 +189,10 @@ func AllFacades() *facade.Registry {
 
 	// CAAS related facades.
 	// Move these to the correct place above once the feature flag disappears.
+	reg("CAASEvents", 1, caasevents.NewStateFacade)
 	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacadeLegacy)
-	reg("CAASFirewallerEmbedded", 1, caasfirewaller.NewStateFacadeEmbedded)
+	reg("CAASFirewallerEmbedded", 1, caasfirewaller.NewStateFacadeEmbeddedV1)
+	reg("CAASFirewallerEmbedded", 2, caasfirewaller.NewStateFacadeEmbedded) // Adds RelatedApplications, ExposedCIDRs, ExposedIngress, WatchRelations and model config watching
 	reg("CAASOperator", 1, caasoperator.NewStateFacade)
 	reg("CAASAdmission", 1, caasadmission.NewStateFacade)
 	reg("CAASAgent", 1, caasagent.NewStateFacade)
@@ -194,7 +201,8 @@ func AllFacades() *facade.Registry {
 	reg("CAASOperatorUpgrader", 1, caasoperatorupgrader.NewStateCAASOperatorUpgraderAPI)
 	reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacade)
 	reg("CAASApplication", 1, caasapplication.NewStateFacade)
-	reg("CAASApplicationProvisioner", 1, caasapplicationprovisioner.NewStateCAASApplicationProvisionerAPI)
+	reg("CAASApplicationProvisioner", 1, caasapplicationprovisioner.NewStateCAASApplicationProvisionerAPIV1)
+	reg("CAASApplicationProvisioner", 2, caasapplicationprovisioner.NewStateCAASApplicationProvisionerAPI) // Adds WatchApplicationConfig and WatchApplicationConstraints
 
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
@@ -218,10 +226,12 @@ func AllFacades() *facade.Registry {
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
 	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6)
-	reg("FirewallRules", 1, firewallrules.NewFacade)
+	reg("FirewallRules", 1, firewallrules.NewFacadeV1)
+	reg("FirewallRules", 2, firewallrules.NewFacade)
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
-	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
+	reg("ImageManager", 2, imagemanager.NewImageManagerAPIV2)
+	reg("ImageManager", 3, imagemanager.NewImageManagerAPI)
 	reg("ImageMetadata", 3, imagemetadata.NewAPI)
 
 	reg("ImageMetadataManager", 1, imagemetadatamanager.NewAPI)
@@ -267,6 +277,7 @@ func AllFacades() *facade.Registry {
 
 	reg("ModelConfig", 1, modelconfig.NewFacadeV1)
 	reg("ModelConfig", 2, modelconfig.NewFacadeV2)
+	reg("ModelConfig", 3, modelconfig.NewFacadeV3)
 	reg("ModelGeneration", 1, modelgeneration.NewModelGenerationFacade)
 	reg("ModelGeneration", 2, modelgeneration.NewModelGenerationFacadeV2)
 	reg("ModelGeneration", 3, modelgeneration.NewModelGenerationFacadeV3)
@@ -350,7 +361,8 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 14, uniter.NewUniterAPIV14)
 	reg("Uniter", 15, uniter.NewUniterAPIV15)
 	reg("Uniter", 16, uniter.NewUniterAPIV16)
-	reg("Uniter", 17, uniter.NewUniterAPI)
+	reg("Uniter", 17, uniter.NewUniterAPIV17)
+	reg("Uniter", 18, uniter.NewUniterAPI)
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 
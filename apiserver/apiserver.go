@@ -626,6 +626,9 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	modelToolsDownloadHandler := &toolsDownloadHandler{
 		ctxt: httpCtxt,
 	}
+	modelImagesDownloadHandler := &imagesDownloadHandler{
+		ctxt: httpCtxt,
+	}
 	resourcesHandler := &ResourcesHandler{
 		StateAuthFunc: func(req *http.Request, tagKinds ...string) (ResourcesBackend, state.PoolHelper, names.Tag, error) {
 			st, entity, err := httpCtxt.stateForRequestAuthenticatedTag(req, tagKinds...)
@@ -751,6 +754,10 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		pattern:         modelRoutePrefix + "/tools/:version",
 		handler:         modelToolsDownloadHandler,
 		unauthenticated: true,
+	}, {
+		pattern: modelRoutePrefix + "/images/:kind/:series/:arch",
+		methods: []string{"GET"},
+		handler: modelImagesDownloadHandler,
 	}, {
 		pattern: modelRoutePrefix + "/applications/:application/resources/:resource",
 		handler: resourcesHandler,
@@ -815,6 +822,10 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		pattern:         "/tools/:version",
 		handler:         modelToolsDownloadHandler,
 		unauthenticated: true,
+	}, {
+		pattern: "/images/:kind/:series/:arch",
+		methods: []string{"GET"},
+		handler: modelImagesDownloadHandler,
 	}, {
 		pattern: "/log",
 		handler: debugLogHandler,
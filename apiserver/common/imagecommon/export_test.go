@@ -0,0 +1,25 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package imagecommon
+
+var (
+	WriteLXDImageArchive  = &writeLXDImageArchive
+	FindLXDImage          = &findLXDImage
+	UpstreamCheckInterval = &upstreamCheckInterval
+)
+
+// ResetImageCacheEntries forgets when the image sources were last checked
+// for each image.
+func ResetImageCacheEntries() {
+	imageCacheMutex.Lock()
+	defer imageCacheMutex.Unlock()
+	imageCacheEntries = make(map[string]*imageCacheEntry)
+}
+
+// ImageCacheEntryCount returns the number of images with a cache entry.
+func ImageCacheEntryCount() int {
+	imageCacheMutex.Lock()
+	defer imageCacheMutex.Unlock()
+	return len(imageCacheEntries)
+}
@@ -0,0 +1,256 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package imagecommon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/container/lxd"
+	corearch "github.com/juju/juju/core/arch"
+	coreseries "github.com/juju/juju/core/series"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state/imagestorage"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.common.imagecommon")
+
+// writeLXDImageArchive and findLXDImage are patched in tests.
+var (
+	writeLXDImageArchive = lxd.WriteImageArchive
+	findLXDImage         = lxd.FindImage
+)
+
+// upstreamCheckInterval is how often the image sources are checked for a
+// newer version of a cached image.
+var upstreamCheckInterval = 6 * time.Hour
+
+// imageCacheEntry serialises the caching of a single image, so that
+// concurrent requests for an image that is not yet stored result in a
+// single download, without holding up requests for other images.
+type imageCacheEntry struct {
+	sync.Mutex
+
+	// refs counts the requests holding or waiting for the entry.
+	// It is guarded by imageCacheMutex.
+	refs int
+
+	// checked is when the image sources were last checked for a newer
+	// version of the image.
+	checked time.Time
+}
+
+var (
+	imageCacheMutex   sync.Mutex
+	imageCacheEntries = make(map[string]*imageCacheEntry)
+)
+
+// lockImageCacheEntry returns the locked cache entry for the image of the
+// supplied model, series and architecture.
+// The entry must be released with unlockImageCacheEntry.
+func lockImageCacheEntry(modelUUID, series, arch string) *imageCacheEntry {
+	key := modelUUID + ":" + series + ":" + arch
+	imageCacheMutex.Lock()
+	pruneImageCacheEntries()
+	entry, ok := imageCacheEntries[key]
+	if !ok {
+		entry = &imageCacheEntry{}
+		imageCacheEntries[key] = entry
+	}
+	entry.refs++
+	imageCacheMutex.Unlock()
+	entry.Lock()
+	return entry
+}
+
+// unlockImageCacheEntry releases an entry returned by lockImageCacheEntry.
+func unlockImageCacheEntry(entry *imageCacheEntry) {
+	entry.Unlock()
+	imageCacheMutex.Lock()
+	entry.refs--
+	imageCacheMutex.Unlock()
+}
+
+// pruneImageCacheEntries removes the entries that are not in use and whose
+// image sources are due to be checked again, as they record nothing that
+// would change how the next request for the image is handled.
+// This stops the entries of removed models and unused images from
+// accumulating. It must be called with imageCacheMutex held.
+func pruneImageCacheEntries() {
+	for key, entry := range imageCacheEntries {
+		if entry.refs == 0 && time.Since(entry.checked) >= upstreamCheckInterval {
+			delete(imageCacheEntries, key)
+		}
+	}
+}
+
+// ValidateLXDImage returns an error satisfying errors.IsNotValid if
+// the supplied series or architecture is not one for which Juju supports
+// LXD container images.
+func ValidateLXDImage(cfg *config.Config, series, arch string) error {
+	if !corearch.AllArches().Contains(arch) {
+		return errors.NotValidf("architecture %q", arch)
+	}
+	supported, err := coreseries.WorkloadSeries(time.Now(), series, cfg.ContainerImageStream())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !supported.Contains(series) {
+		return errors.NotValidf("series %q", series)
+	}
+	return nil
+}
+
+// CacheLXDImage ensures that the LXD container image for the supplied series
+// and architecture is held in the image storage, fetching it from the image
+// sources of the model configuration if it is not.
+// A cached image is fetched again if it came from a different image stream
+// than the one configured, or if the image sources have a newer version of
+// it; should that fail, the cached image is kept.
+// The metadata of the stored image is returned.
+func CacheLXDImage(
+	stor imagestorage.Storage, modelUUID string, cfg *config.Config, series, arch string,
+) (*imagestorage.Metadata, error) {
+	if err := ValidateLXDImage(cfg, series, arch); err != nil {
+		return nil, errors.Trace(err)
+	}
+	entry := lockImageCacheEntry(modelUUID, series, arch)
+	defer unlockImageCacheEntry(entry)
+
+	metadata, rc, err := stor.Image(lxd.ImageCacheKind, series, arch)
+	if errors.IsNotFound(err) {
+		return fetchLXDImage(stor, modelUUID, cfg, series, arch)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_ = rc.Close()
+
+	stream := cfg.ContainerImageStream()
+	if metadata.Stream != stream {
+		logger.Infof("LXD image for %s/%s cached from stream %q, not %q", series, arch, metadata.Stream, stream)
+	} else {
+		if time.Since(entry.checked) < upstreamCheckInterval {
+			return metadata, nil
+		}
+		entry.checked = time.Now()
+		changed, err := upstreamLXDImageChanged(metadata, cfg, series, arch)
+		if err != nil {
+			logger.Warningf("cannot check for a newer LXD image for %s/%s: %v", series, arch, err)
+			return metadata, nil
+		}
+		if !changed {
+			return metadata, nil
+		}
+		logger.Infof("newer LXD image available for %s/%s", series, arch)
+	}
+
+	fetched, err := fetchLXDImage(stor, modelUUID, cfg, series, arch)
+	if err != nil {
+		logger.Warningf("keeping cached LXD image for %s/%s: %v", series, arch, err)
+		return metadata, nil
+	}
+	return fetched, nil
+}
+
+// RefreshLXDImage fetches the LXD container image for the supplied series
+// and architecture from the image sources of the model configuration,
+// replacing any copy held in the image storage.
+// The metadata of the stored image is returned.
+func RefreshLXDImage(
+	stor imagestorage.Storage, modelUUID string, cfg *config.Config, series, arch string,
+) (*imagestorage.Metadata, error) {
+	if err := ValidateLXDImage(cfg, series, arch); err != nil {
+		return nil, errors.Trace(err)
+	}
+	entry := lockImageCacheEntry(modelUUID, series, arch)
+	defer unlockImageCacheEntry(entry)
+
+	metadata, err := fetchLXDImage(stor, modelUUID, cfg, series, arch)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	entry.checked = time.Now()
+	return metadata, nil
+}
+
+// upstreamLXDImageChanged reports whether the image sources of the model
+// configuration hold a different image than the cached one.
+func upstreamLXDImageChanged(
+	metadata *imagestorage.Metadata, cfg *config.Config, series, arch string,
+) (bool, error) {
+	sources, err := lxdImageSources(cfg)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	image, _, err := findLXDImage(series, arch, sources)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return image.Fingerprint != metadata.Fingerprint, nil
+}
+
+func lxdImageSources(cfg *config.Config) ([]lxd.ServerSpec, error) {
+	imageMetadataURL, _ := cfg.ContainerImageMetadataURL()
+	sources, err := lxd.ImageSources(imageMetadataURL, cfg.ContainerImageStream())
+	return sources, errors.Trace(err)
+}
+
+// fetchLXDImage fetches the LXD container image for the supplied series
+// and architecture and stores it in the image storage.
+func fetchLXDImage(
+	stor imagestorage.Storage, modelUUID string, cfg *config.Config, series, arch string,
+) (*imagestorage.Metadata, error) {
+	sources, err := lxdImageSources(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	f, err := ioutil.TempFile("", "juju-lxd-image")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	logger.Infof("fetching LXD image for %s/%s", series, arch)
+	hash := sha256.New()
+	image, source, err := writeLXDImageArchive(series, arch, sources, io.MultiWriter(f, hash))
+	if err != nil {
+		return nil, errors.Annotatef(err, "fetching LXD image for %s/%s", series, arch)
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	metadata := &imagestorage.Metadata{
+		ModelUUID:   modelUUID,
+		Kind:        lxd.ImageCacheKind,
+		Series:      series,
+		Arch:        arch,
+		Size:        size,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		SourceURL:   source,
+		Fingerprint: image.Fingerprint,
+		Stream:      cfg.ContainerImageStream(),
+	}
+	logger.Debugf("caching LXD image %q from %q (%d bytes)", image.Fingerprint, source, size)
+	if err := stor.AddImage(f, metadata); err != nil {
+		return nil, errors.Annotate(err, "caching LXD image")
+	}
+	return metadata, nil
+}
@@ -0,0 +1,328 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package imagecommon_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	lxdapi "github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common/imagecommon"
+	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/state/imagestorage"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type imageCacheSuite struct {
+	coretesting.BaseSuite
+
+	stor *mockImageStorage
+}
+
+var _ = gc.Suite(&imageCacheSuite{})
+
+func (s *imageCacheSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.stor = &mockImageStorage{Stub: &testing.Stub{}}
+	imagecommon.ResetImageCacheEntries()
+	s.AddCleanup(func(*gc.C) { imagecommon.ResetImageCacheEntries() })
+}
+
+func (s *imageCacheSuite) patchWriteArchive(c *gc.C, err error) *[]lxd.ServerSpec {
+	var sources []lxd.ServerSpec
+	s.PatchValue(imagecommon.WriteLXDImageArchive, func(series, arch string, srcs []lxd.ServerSpec, w io.Writer) (*lxdapi.Image, string, error) {
+		c.Check(series, gc.Equals, "focal")
+		c.Check(arch, gc.Equals, "amd64")
+		sources = srcs
+		if err != nil {
+			return nil, "", err
+		}
+		_, err := w.Write([]byte("archive"))
+		c.Assert(err, jc.ErrorIsNil)
+		return &lxdapi.Image{Fingerprint: "fingerprint"}, "https://cloud-images.ubuntu.com/releases", nil
+	})
+	return &sources
+}
+
+func (s *imageCacheSuite) patchFindImage(c *gc.C, fingerprint string, err error) *int {
+	var calls int
+	s.PatchValue(imagecommon.FindLXDImage, func(series, arch string, srcs []lxd.ServerSpec) (*lxdapi.Image, string, error) {
+		c.Check(series, gc.Equals, "focal")
+		c.Check(arch, gc.Equals, "amd64")
+		calls++
+		if err != nil {
+			return nil, "", err
+		}
+		return &lxdapi.Image{Fingerprint: fingerprint}, "https://cloud-images.ubuntu.com/releases", nil
+	})
+	return &calls
+}
+
+func (s *imageCacheSuite) cachedMetadata() *imagestorage.Metadata {
+	return &imagestorage.Metadata{
+		Kind:        "lxd",
+		Series:      "focal",
+		Arch:        "amd64",
+		Fingerprint: "old-fingerprint",
+		Stream:      "released",
+	}
+}
+
+func (s *imageCacheSuite) TestCacheLXDImage(c *gc.C) {
+	sources := s.patchWriteArchive(c, nil)
+
+	metadata, err := imagecommon.CacheLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "focal", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metadata, jc.DeepEquals, &imagestorage.Metadata{
+		ModelUUID: coretesting.ModelTag.Id(),
+		Kind:      "lxd",
+		Series:    "focal",
+		Arch:      "amd64",
+		Size:      7,
+		// SHA256 of "archive".
+		SHA256:    "0eb3e36bfb24dcd9bb1d1bece1531216b59539a8fde17ee80224af0653c92aa3",
+		SourceURL: "https://cloud-images.ubuntu.com/releases",
+
+		Fingerprint: "fingerprint",
+		Stream:      "released",
+	})
+	c.Check(*sources, gc.DeepEquals, []lxd.ServerSpec{lxd.CloudImagesRemote, lxd.CloudImagesDailyRemote})
+	s.stor.CheckCallNames(c, "Image", "AddImage")
+	c.Check(s.stor.added, gc.Equals, "archive")
+}
+
+func (s *imageCacheSuite) TestCacheLXDImageAlreadyCached(c *gc.C) {
+	s.patchWriteArchive(c, errors.New("should not be called"))
+	calls := s.patchFindImage(c, "old-fingerprint", nil)
+	s.stor.metadata = s.cachedMetadata()
+
+	for i := 0; i < 2; i++ {
+		metadata, err := imagecommon.CacheLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "focal", "amd64")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(metadata, gc.Equals, s.stor.metadata)
+	}
+	s.stor.CheckCallNames(c, "Image", "Image")
+	s.stor.CheckCall(c, 0, "Image", "lxd", "focal", "amd64")
+	// The image sources are only checked once within the interval.
+	c.Check(*calls, gc.Equals, 1)
+}
+
+func (s *imageCacheSuite) TestCacheLXDImageUpstreamChanged(c *gc.C) {
+	s.patchWriteArchive(c, nil)
+	calls := s.patchFindImage(c, "fingerprint", nil)
+	s.stor.metadata = s.cachedMetadata()
+
+	metadata, err := imagecommon.CacheLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "focal", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metadata.Fingerprint, gc.Equals, "fingerprint")
+	c.Check(*calls, gc.Equals, 1)
+	s.stor.CheckCallNames(c, "Image", "AddImage")
+	c.Check(s.stor.added, gc.Equals, "archive")
+}
+
+func (s *imageCacheSuite) TestCacheLXDImageUpstreamCheckedAfterInterval(c *gc.C) {
+	s.PatchValue(imagecommon.UpstreamCheckInterval, time.Duration(0))
+	s.patchWriteArchive(c, errors.New("should not be called"))
+	calls := s.patchFindImage(c, "old-fingerprint", nil)
+	s.stor.metadata = s.cachedMetadata()
+
+	for i := 0; i < 2; i++ {
+		_, err := imagecommon.CacheLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "focal", "amd64")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Check(*calls, gc.Equals, 2)
+}
+
+func (s *imageCacheSuite) TestCacheLXDImageStreamChanged(c *gc.C) {
+	s.patchWriteArchive(c, nil)
+	calls := s.patchFindImage(c, "old-fingerprint", nil)
+	s.stor.metadata = s.cachedMetadata()
+	cfg, err := testConfig(c).Apply(map[string]interface{}{"container-image-stream": "daily"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	metadata, err := imagecommon.CacheLXDImage(s.stor, coretesting.ModelTag.Id(), cfg, "focal", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metadata.Stream, gc.Equals, "daily")
+	c.Check(*calls, gc.Equals, 0)
+	s.stor.CheckCallNames(c, "Image", "AddImage")
+}
+
+func (s *imageCacheSuite) TestCacheLXDImageUpstreamCheckFails(c *gc.C) {
+	s.patchWriteArchive(c, errors.New("should not be called"))
+	s.patchFindImage(c, "", errors.New("no route to host"))
+	s.stor.metadata = s.cachedMetadata()
+
+	metadata, err := imagecommon.CacheLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "focal", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metadata, gc.Equals, s.stor.metadata)
+	s.stor.CheckCallNames(c, "Image")
+}
+
+func (s *imageCacheSuite) TestCacheLXDImageRefetchFails(c *gc.C) {
+	s.patchWriteArchive(c, errors.New("connection reset"))
+	s.patchFindImage(c, "fingerprint", nil)
+	s.stor.metadata = s.cachedMetadata()
+
+	metadata, err := imagecommon.CacheLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "focal", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metadata, gc.Equals, s.stor.metadata)
+	s.stor.CheckCallNames(c, "Image")
+}
+
+func (s *imageCacheSuite) TestRefreshLXDImage(c *gc.C) {
+	s.patchWriteArchive(c, nil)
+	calls := s.patchFindImage(c, "old-fingerprint", nil)
+	s.stor.metadata = s.cachedMetadata()
+
+	metadata, err := imagecommon.RefreshLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "focal", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metadata.Fingerprint, gc.Equals, "fingerprint")
+	c.Check(*calls, gc.Equals, 0)
+	s.stor.CheckCallNames(c, "AddImage")
+	c.Check(s.stor.added, gc.Equals, "archive")
+}
+
+func (s *imageCacheSuite) TestRefreshLXDImageFetchError(c *gc.C) {
+	s.patchWriteArchive(c, errors.New("no matching image found"))
+	s.stor.metadata = s.cachedMetadata()
+
+	_, err := imagecommon.RefreshLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "focal", "amd64")
+	c.Assert(err, gc.ErrorMatches, "fetching LXD image for focal/amd64: no matching image found")
+	s.stor.CheckNoCalls(c)
+}
+
+func (s *imageCacheSuite) TestCacheLXDImageDoesNotBlockOtherImages(c *gc.C) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s.PatchValue(imagecommon.WriteLXDImageArchive, func(series, arch string, srcs []lxd.ServerSpec, w io.Writer) (*lxdapi.Image, string, error) {
+		if series == "focal" {
+			close(started)
+			<-release
+		}
+		_, err := w.Write([]byte("archive"))
+		c.Check(err, jc.ErrorIsNil)
+		return &lxdapi.Image{Fingerprint: "fingerprint"}, "https://cloud-images.ubuntu.com/releases", nil
+	})
+	stor := &lockedImageStorage{stor: s.stor}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := imagecommon.CacheLXDImage(stor, coretesting.ModelTag.Id(), testConfig(c), "focal", "amd64")
+		done <- err
+	}()
+	select {
+	case <-started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for download to start")
+	}
+
+	// Another image can be cached while the first is being downloaded.
+	_, err := imagecommon.CacheLXDImage(stor, coretesting.ModelTag.Id(), testConfig(c), "bionic", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+
+	close(release)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for download to finish")
+	}
+}
+
+func (s *imageCacheSuite) TestCacheLXDImageFetchError(c *gc.C) {
+	s.patchWriteArchive(c, errors.New("no matching image found"))
+
+	_, err := imagecommon.CacheLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "focal", "amd64")
+	c.Assert(err, gc.ErrorMatches, "fetching LXD image for focal/amd64: no matching image found")
+	s.stor.CheckCallNames(c, "Image")
+}
+
+func (s *imageCacheSuite) TestCacheLXDImageInvalid(c *gc.C) {
+	s.patchWriteArchive(c, errors.New("should not be called"))
+
+	_, err := imagecommon.CacheLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "focal", "z80")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `architecture "z80" not valid`)
+	_, err = imagecommon.CacheLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "../../etc", "amd64")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `series "../../etc" not valid`)
+	_, err = imagecommon.RefreshLXDImage(s.stor, coretesting.ModelTag.Id(), testConfig(c), "warty", "amd64")
+	c.Assert(err, gc.ErrorMatches, `series "warty" not valid`)
+
+	s.stor.CheckNoCalls(c)
+	c.Check(imagecommon.ImageCacheEntryCount(), gc.Equals, 0)
+}
+
+func (s *imageCacheSuite) TestImageCacheEntriesPruned(c *gc.C) {
+	s.patchWriteArchive(c, errors.New("should not be called"))
+	s.patchFindImage(c, "old-fingerprint", nil)
+	s.stor.metadata = s.cachedMetadata()
+
+	// Entries are kept while the image sources need not be checked again.
+	for _, modelUUID := range []string{"model-1", "model-2"} {
+		_, err := imagecommon.CacheLXDImage(s.stor, modelUUID, testConfig(c), "focal", "amd64")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Check(imagecommon.ImageCacheEntryCount(), gc.Equals, 2)
+
+	// Once the check is due, unused entries are removed.
+	s.PatchValue(imagecommon.UpstreamCheckInterval, time.Duration(0))
+	_, err := imagecommon.CacheLXDImage(s.stor, "model-3", testConfig(c), "focal", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imagecommon.ImageCacheEntryCount(), gc.Equals, 1)
+}
+
+type mockImageStorage struct {
+	imagestorage.Storage
+	*testing.Stub
+
+	metadata *imagestorage.Metadata
+	added    string
+}
+
+func (m *mockImageStorage) Image(kind, series, arch string) (*imagestorage.Metadata, io.ReadCloser, error) {
+	m.MethodCall(m, "Image", kind, series, arch)
+	if m.metadata == nil {
+		return nil, nil, errors.NotFoundf("%v image metadata", kind)
+	}
+	return m.metadata, ioutil.NopCloser(&bytes.Buffer{}), m.NextErr()
+}
+
+func (m *mockImageStorage) AddImage(r io.Reader, metadata *imagestorage.Metadata) error {
+	m.MethodCall(m, "AddImage", r, metadata)
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	m.added = string(data)
+	return m.NextErr()
+}
+
+// lockedImageStorage serialises access to a mockImageStorage, which is
+// not safe for concurrent use.
+type lockedImageStorage struct {
+	imagestorage.Storage
+	mu   sync.Mutex
+	stor *mockImageStorage
+}
+
+func (l *lockedImageStorage) Image(kind, series, arch string) (*imagestorage.Metadata, io.ReadCloser, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stor.Image(kind, series, arch)
+}
+
+func (l *lockedImageStorage) AddImage(r io.Reader, metadata *imagestorage.Metadata) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stor.AddImage(r, metadata)
+}
@@ -4,6 +4,8 @@
 package provisioner
 
 import (
+	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/juju/collections/set"
@@ -452,6 +454,12 @@ func (api *ProvisionerAPI) ContainerManagerConfig(args params.ContainerManagerCo
 	case instance.LXD:
 		cfg[config.LXDSnapChannel] = mConfig.LXDSnapChannel()
 		// TODO(jam): DefaultMTU needs to be handled here
+
+		// Hosts acquire container images through the controller,
+		// which caches them on first use.
+		if err := api.addImageCacheConfig(cfg); err != nil {
+			return result, errors.Trace(err)
+		}
 	}
 
 	if url, set := mConfig.ContainerImageMetadataURL(); set {
@@ -463,6 +471,34 @@ func (api *ProvisionerAPI) ContainerManagerConfig(args params.ContainerManagerCo
 	return result, nil
 }
 
+// addImageCacheConfig adds to the container manager configuration the
+// URLs of the controller image cache for the model and the CA certificate
+// with which to verify them.
+func (api *ProvisionerAPI) addImageCacheConfig(cfg map[string]string) error {
+	addrs, err := api.APIAddresses()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(addrs.Result) == 0 {
+		return nil
+	}
+	controllerCfg, err := api.st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	caCert, ok := controllerCfg.CACert()
+	if !ok {
+		return nil
+	}
+	urls := make([]string, len(addrs.Result))
+	for i, addr := range addrs.Result {
+		urls[i] = fmt.Sprintf("https://%s/model/%s", addr, api.st.ModelUUID())
+	}
+	cfg[container.ConfigImageCacheURLs] = strings.Join(urls, " ")
+	cfg[container.ConfigImageCacheCACert] = caCert
+	return nil
+}
+
 // ContainerConfig returns information from the model config that is
 // needed for container cloud-init.
 func (api *ProvisionerAPI) ContainerConfig() (params.ContainerConfig, error) {
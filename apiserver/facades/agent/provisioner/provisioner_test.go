@@ -5,6 +5,7 @@ package provisioner_test
 
 import (
 	"fmt"
+	"strings"
 	stdtesting "testing"
 	"time"
 
@@ -1884,6 +1885,17 @@ func (s *withImageMetadataSuite) SetUpTest(c *gc.C) {
 
 func (s *withImageMetadataSuite) TestContainerManagerConfigImageMetadata(c *gc.C) {
 	cfg := s.getManagerConfig(c, instance.LXD)
+
+	// The image cache is served by the controller for the model.
+	urls := strings.Fields(cfg[container.ConfigImageCacheURLs])
+	c.Assert(urls, gc.Not(gc.HasLen), 0)
+	for _, url := range urls {
+		c.Check(url, gc.Matches, "https://.*/model/"+coretesting.ModelTag.Id())
+	}
+	c.Check(cfg[container.ConfigImageCacheCACert], gc.Equals, coretesting.CACert)
+	delete(cfg, container.ConfigImageCacheURLs)
+	delete(cfg, container.ConfigImageCacheCACert)
+
 	c.Assert(cfg, jc.DeepEquals, map[string]string{
 		container.ConfigModelUUID:           coretesting.ModelTag.Id(),
 		config.ContainerImageStreamKey:      "daily",
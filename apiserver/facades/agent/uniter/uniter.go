@@ -42,9 +42,8 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 // TODO (manadart 2020-10-21): Remove the ModelUUID method
 // from the next version of this facade.
 
-// UniterAPI implements the latest version (v17) of the Uniter API, which
-// augments the payload of the CommitHookChanges API call and introduces
-// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+// UniterAPI implements the latest version (v18) of the Uniter API, which
+// adds LogActionsOutput.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -83,10 +82,17 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV17 implements version (v17) of the Uniter API, which
+// augments the payload of the CommitHookChanges API call and introduces
+// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+type UniterAPIV17 struct {
+	UniterAPI
+}
+
 // UniterAPIV16 implements version (v16) of the Uniter API, which adds
 // LXDProfileAPIV2.
 type UniterAPIV16 struct {
-	UniterAPI
+	UniterAPIV17
 }
 
 // UniterAPIV15 implements version (v15) of the Uniter API, which adds
@@ -262,14 +268,25 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV17 creates an instance of the V17 uniter API.
+func NewUniterAPIV17(context facade.Context) (*UniterAPIV17, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV17{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV16 creates an instance of the V16 uniter API.
 func NewUniterAPIV16(context facade.Context) (*UniterAPIV16, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV17(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV16{
-		UniterAPI: *uniterAPI,
+		UniterAPIV17: *uniterAPI,
 	}, nil
 }
 
@@ -416,6 +433,9 @@ func NewUniterAPIV4(context facade.Context) (*UniterAPIV4, error) {
 // OpenedMachinePortRangesByEndpoint is not available in V16 of the API.
 func (u *UniterAPIV16) OpenedMachinePortRangesByEndpoint(_ struct{}) {}
 
+// LogActionsOutput is not available in V17 of the API.
+func (u *UniterAPIV17) LogActionsOutput(_ struct{}) {}
+
 // OpenedMachinePortRangesByEndpoint returns the port ranges opened by each
 // unit on the provided machines grouped by application endpoint.
 func (u *UniterAPI) OpenedMachinePortRangesByEndpoint(args params.Entities) (params.OpenMachinePortRangesByEndpointResults, error) {
@@ -1441,6 +1461,36 @@ func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.
 	return result, nil
 }
 
+// LogActionsOutput records chunks of output written by the specified
+// actions. It is not available in V16 of the API.
+func (u *UniterAPI) LogActionsOutput(args params.ActionOutputParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+
+	oneActionOutput := func(output params.ActionOutput) error {
+		action, err := actionFn(output.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return action.LogOutput(output.Stream, output.Data)
+	}
+
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Output)),
+	}
+	for i, output := range args.Output {
+		result.Results[i].Error = apiservererrors.ServerError(oneActionOutput(output))
+	}
+	return result, nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -1584,6 +1634,7 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 			if unit, err = u.getUnit(tag); err == nil {
 				result.Results[i].Life = life.Value(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				result.Results[i].Paused = unit.Paused()
 
 				var err1 error
 				result.Results[i].ProviderID, err1 = u.getProviderID(unit)
@@ -4,6 +4,7 @@
 package uniter_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider"
 	k8stesting "github.com/juju/juju/caas/kubernetes/provider/testing"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/actions"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/life"
@@ -1191,6 +1193,48 @@ func (s *uniterSuite) TestLogActionMessage(c *gc.C) {
 	c.Assert(messages[0].Timestamp(), gc.NotNil)
 }
 
+func (s *uniterSuite) TestLogActionOutput(c *gc.C) {
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	wrongAction, err := s.mysqlUnit.AddAction(operationID, "fakeaction", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionOutputParams{Output: []params.ActionOutput{
+		{Tag: anAction.Tag().String(), Stream: "stdout", Data: "hello\n"},
+		{Tag: anAction.Tag().String(), Stream: "stdin", Data: "hello\n"},
+		{Tag: wrongAction.Tag().String(), Stream: "stdout", Data: "world\n"},
+	}}
+	result, err := s.uniter.LogActionsOutput(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `action output stream "stdin" not valid`}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	w := s.State.WatchActionOutput(anAction.Id())
+	defer statetesting.AssertStop(c, w)
+	s.State.StartSync()
+	select {
+	case changes := <-w.Changes():
+		c.Assert(changes, gc.HasLen, 1)
+		var msg actions.ActionMessage
+		err = json.Unmarshal([]byte(changes[0]), &msg)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(msg.Message, gc.Equals, "hello\n")
+		c.Assert(msg.Stream, gc.Equals, "stdout")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("watcher did not send change")
+	}
+}
+
 func (s *uniterSuite) TestLogActionMessageAborting(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
@@ -3804,6 +3848,8 @@ func (s *uniterSuite) TestV5RelationById(c *gc.C) {
 }
 
 func (s *uniterSuite) TestRefresh(c *gc.C) {
+	err := s.wordpressUnit.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
 	args := params.Entities{
 		Entities: []params.Entity{
 			{s.wordpressUnit.Tag().String()},
@@ -3815,7 +3861,7 @@ func (s *uniterSuite) TestRefresh(c *gc.C) {
 	}
 	expect := params.UnitRefreshResults{
 		Results: []params.UnitRefreshResult{
-			{Life: life.Alive, Resolved: params.ResolvedNone},
+			{Life: life.Alive, Resolved: params.ResolvedNone, Paused: true},
 			{Error: apiservertesting.ErrUnauthorized},
 			{Error: apiservertesting.ErrUnauthorized},
 			{Error: apiservertesting.ErrUnauthorized},
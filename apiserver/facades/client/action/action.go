@@ -27,18 +27,47 @@ type ActionAPI struct {
 
 // APIv7 provides the Action API facade for version 7.
 type APIv7 struct {
+	*APIv8
+}
+
+// APIv8 provides the Action API facade for version 8.
+type APIv8 struct {
 	*ActionAPI
 }
 
 // NewActionAPIV7 returns an initialized ActionAPI for version 7.
 func NewActionAPIV7(ctx facade.Context) (*APIv7, error) {
-	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	api, err := NewActionAPIV8(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv7{api}, nil
 }
 
+// NewActionAPIV8 returns an initialized ActionAPI for version 8.
+func NewActionAPIV8(ctx facade.Context) (*APIv8, error) {
+	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv8{api}, nil
+}
+
+// AddActionSchedules isn't on the v7 API.
+func (*APIv7) AddActionSchedules(_, _ struct{}) {}
+
+// ListActionSchedules isn't on the v7 API.
+func (*APIv7) ListActionSchedules(_, _ struct{}) {}
+
+// RemoveActionSchedules isn't on the v7 API.
+func (*APIv7) RemoveActionSchedules(_, _ struct{}) {}
+
+// EnqueueRollingOperation isn't on the v7 API.
+func (*APIv7) EnqueueRollingOperation(_, _ struct{}) {}
+
+// WatchActionsOutput isn't on the v7 API.
+func (*APIv7) WatchActionsOutput(_, _ struct{}) {}
+
 func newActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
@@ -220,6 +249,21 @@ func (a *ActionAPI) ApplicationsCharmsActions(args params.Entities) (params.Appl
 
 // WatchActionsProgress creates a watcher that reports on action log messages.
 func (api *ActionAPI) WatchActionsProgress(actions params.Entities) (params.StringsWatchResults, error) {
+	return api.watchActionLogs(actions, api.state.WatchActionLogs)
+}
+
+// WatchActionsOutput creates a watcher that reports on action log messages
+// and on chunks of output as they are written by the actions.
+func (api *ActionAPI) WatchActionsOutput(actions params.Entities) (params.StringsWatchResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+	return api.watchActionLogs(actions, api.state.WatchActionOutput)
+}
+
+func (api *ActionAPI) watchActionLogs(
+	actions params.Entities, watch func(actionId string) state.StringsWatcher,
+) (params.StringsWatchResults, error) {
 	results := params.StringsWatchResults{
 		Results: make([]params.StringsWatchResult, len(actions.Entities)),
 	}
@@ -230,7 +274,7 @@ func (api *ActionAPI) WatchActionsProgress(actions params.Entities) (params.Stri
 			continue
 		}
 
-		w := api.state.WatchActionLogs(actionTag.Id())
+		w := watch(actionTag.Id())
 		// Consume the initial event.
 		changes, ok := <-w.Changes()
 		if !ok {
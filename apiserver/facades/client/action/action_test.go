@@ -591,3 +591,51 @@ func (s *actionSuite) TestWatchActionProgress(c *gc.C) {
 	wc.AssertChange(string(expected))
 	wc.AssertNoChange()
 }
+
+func (s *actionSuite) TestWatchActionOutput(c *gc.C) {
+	unit, err := s.State.Unit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	assertReadyToTest(c, unit)
+
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	added, err := unit.AddAction(operationID, "fakeaction", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.action.WatchActionsOutput(
+		params.Entities{Entities: []params.Entity{{Tag: "action-2"}}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Results, gc.HasLen, 1)
+	c.Assert(w.Results[0].Error, gc.IsNil)
+	c.Assert(w.Results[0].Changes, gc.HasLen, 0)
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	// Log a message and check the watcher result.
+	added, err = added.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = added.LogOutput(state.ActionStdout, "hello\n")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.State.StartSync()
+	select {
+	case changes := <-resource.(state.StringsWatcher).Changes():
+		c.Assert(changes, gc.HasLen, 1)
+		var msg actions.ActionMessage
+		err = json.Unmarshal([]byte(changes[0]), &msg)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(msg.Message, gc.Equals, "hello\n")
+		c.Assert(msg.Stream, gc.Equals, "stdout")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("watcher did not send change")
+	}
+	wc.AssertNoChange()
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/state"
 )
 
@@ -32,29 +33,70 @@ func (a *ActionAPI) EnqueueOperation(arg params.Actions) (params.EnqueuedActions
 	return results, nil
 }
 
-func (a *ActionAPI) enqueue(arg params.Actions) (string, params.ActionResults, error) {
+// EnqueueRollingOperation queues up actions to be executed as an
+// operation whose tasks are started a few at a time. Receivers may be
+// applications, in which case a task is queued for each of the
+// application's units.
+func (a *ActionAPI) EnqueueRollingOperation(arg params.RollingActions) (params.EnqueuedActions, error) {
 	if err := a.checkCanWrite(); err != nil {
-		return "", params.ActionResults{}, errors.Trace(err)
+		return params.EnqueuedActions{}, errors.Trace(err)
+	}
+	leaderOrder := actions.LeaderOrder(arg.LeaderOrder)
+	if err := leaderOrder.Validate(); err != nil {
+		return params.EnqueuedActions{}, errors.Trace(err)
 	}
 
-	var leaders map[string]string
-	getLeader := func(appName string) (string, error) {
-		if leaders == nil {
-			var err error
-			leaders, err = a.state.ApplicationLeaders()
-			if err != nil {
-				return "", err
-			}
+	tasks, response := a.resolveTasks(arg.Actions, true)
+	taskArgs := make([]state.RollingTaskArgs, len(tasks))
+	for i, task := range tasks {
+		taskArgs[i] = state.RollingTaskArgs{
+			Receiver:       task.receiver,
+			Name:           task.action.Name,
+			Parameters:     task.action.Parameters,
+			Parallel:       task.action.Parallel,
+			ExecutionGroup: task.action.ExecutionGroup,
+			Leader:         task.leader,
 		}
-		if leader, ok := leaders[appName]; ok {
-			return leader, nil
+	}
+	operationID, taskResults, err := a.model.EnqueueRollingOperation(operationSummary(arg.Actions), state.RolloutArgs{
+		MaxParallel:   arg.MaxParallel,
+		FailureBudget: arg.StopOnFailure,
+		LeaderOrder:   leaderOrder,
+	}, taskArgs)
+	if err != nil {
+		return params.EnqueuedActions{}, errors.Annotate(err, "creating operation for actions")
+	}
+	for i, task := range tasks {
+		if err := taskResults[i].Error; err != nil {
+			response.Results[task.result].Error = apiservererrors.ServerError(err)
+			continue
 		}
-		return "", errors.Errorf("could not determine leader for %q", appName)
+		response.Results[task.result] = common.MakeActionResult(task.receiver.Tag(), taskResults[i].Action)
 	}
+	return params.EnqueuedActions{
+		OperationTag: names.NewOperationTag(operationID).String(),
+		Actions:      response.Results,
+	}, nil
+}
 
+func (a *ActionAPI) enqueue(arg params.Actions) (string, params.ActionResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return "", params.ActionResults{}, errors.Trace(err)
+	}
+
+	operationID, err := a.model.EnqueueOperation(operationSummary(arg.Actions))
+	if err != nil {
+		return "", params.ActionResults{}, errors.Annotate(err, "creating operation for actions")
+	}
+	response := a.addTasks(operationID, arg.Actions)
+	return operationID, response, nil
+}
+
+// operationSummary describes an operation running the given actions.
+func operationSummary(actions []params.Action) string {
 	var operationName string
 	var receivers []string
-	for _, a := range arg.Actions {
+	for _, a := range actions {
 		if a.Receiver != "" {
 			receivers = append(receivers, a.Receiver)
 		}
@@ -66,40 +108,125 @@ func (a *ActionAPI) enqueue(arg params.Actions) (string, params.ActionResults, e
 			operationName = "multiple actions"
 		}
 	}
-	summary := fmt.Sprintf("%v run on %v", operationName, strings.Join(receivers, ","))
-	operationID, err := a.model.EnqueueOperation(summary)
-	if err != nil {
-		return "", params.ActionResults{}, errors.Annotate(err, "creating operation for actions")
+	return fmt.Sprintf("%v run on %v", operationName, strings.Join(receivers, ","))
+}
+
+// resolvedTask records the receiver of a task to be added to
+// an operation, and the index of the task's result.
+type resolvedTask struct {
+	receiver state.ActionReceiver
+	action   params.Action
+	leader   bool
+	result   int
+}
+
+// addTasks adds a task to the operation for each of the actions.
+// The results hold one entry per action.
+func (a *ActionAPI) addTasks(operationID string, actions []params.Action) params.ActionResults {
+	tasks, response := a.resolveTasks(actions, false)
+	for _, task := range tasks {
+		enqueued, err := task.receiver.AddAction(operationID, task.action.Name, task.action.Parameters, task.action.Parallel, task.action.ExecutionGroup)
+		if err != nil {
+			response.Results[task.result].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		response.Results[task.result] = common.MakeActionResult(task.receiver.Tag(), enqueued)
 	}
+	return response
+}
 
+// resolveTasks returns the receivers of the tasks to be added for each
+// of the actions, expanding application receivers to all of their units
+// if expandApplications is set.
+// The results hold one entry per action, or per unit for expanded
+// application receivers; the entries of receivers which could not be
+// resolved hold the error.
+func (a *ActionAPI) resolveTasks(actions []params.Action, expandApplications bool) ([]resolvedTask, params.ActionResults) {
+	var leaders map[string]string
+	getLeader := func(appName string) (string, error) {
+		if leaders == nil {
+			var err error
+			leaders, err = a.state.ApplicationLeaders()
+			if err != nil {
+				return "", err
+			}
+		}
+		if leader, ok := leaders[appName]; ok {
+			return leader, nil
+		}
+		return "", errors.Errorf("could not determine leader for %q", appName)
+	}
+
+	var tasks []resolvedTask
+	var response params.ActionResults
 	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
-	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Actions))}
-	for i, action := range arg.Actions {
-		currentResult := &response.Results[i]
-		actionReceiver := action.Receiver
-		if strings.HasSuffix(actionReceiver, "leader") {
-			app := strings.Split(actionReceiver, "/")[0]
-			receiverName, err := getLeader(app)
+	for _, action := range actions {
+		actionReceivers := []string{action.Receiver}
+		if appTag, err := names.ParseApplicationTag(action.Receiver); err == nil && expandApplications {
+			unitTags, err := a.applicationUnitTags(appTag.Id())
 			if err != nil {
-				currentResult.Error = apiservererrors.ServerError(err)
+				response.Results = append(response.Results, params.ActionResult{
+					Error: apiservererrors.ServerError(err),
+				})
 				continue
 			}
-			actionReceiver = names.NewUnitTag(receiverName).String()
-		}
-		receiver, err := tagToActionReceiver(actionReceiver)
-		if err != nil {
-			currentResult.Error = apiservererrors.ServerError(err)
-			continue
+			actionReceivers = unitTags
 		}
-		enqueued, err := receiver.AddAction(operationID, action.Name, action.Parameters, action.Parallel, action.ExecutionGroup)
-		if err != nil {
-			currentResult.Error = apiservererrors.ServerError(err)
-			continue
+		for _, actionReceiver := range actionReceivers {
+			var currentResult params.ActionResult
+			if strings.HasSuffix(actionReceiver, "leader") {
+				app := strings.Split(actionReceiver, "/")[0]
+				receiverName, err := getLeader(app)
+				if err != nil {
+					currentResult.Error = apiservererrors.ServerError(err)
+					response.Results = append(response.Results, currentResult)
+					continue
+				}
+				actionReceiver = names.NewUnitTag(receiverName).String()
+			}
+			receiver, err := tagToActionReceiver(actionReceiver)
+			if err != nil {
+				currentResult.Error = apiservererrors.ServerError(err)
+				response.Results = append(response.Results, currentResult)
+				continue
+			}
+
+			task := resolvedTask{
+				receiver: receiver,
+				action:   action,
+				result:   len(response.Results),
+			}
+			if unitTag, ok := receiver.Tag().(names.UnitTag); ok {
+				appName, _ := names.UnitApplication(unitTag.Id())
+				if leader, err := getLeader(appName); err == nil && leader == unitTag.Id() {
+					task.leader = true
+				}
+			}
+			tasks = append(tasks, task)
+			response.Results = append(response.Results, currentResult)
 		}
+	}
+	return tasks, response
+}
 
-		response.Results[i] = common.MakeActionResult(receiver.Tag(), enqueued)
+// applicationUnitTags returns the tags of all units of the application.
+func (a *ActionAPI) applicationUnitTags(appName string) ([]string, error) {
+	app, err := a.state.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
-	return operationID, response, nil
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(units) == 0 {
+		return nil, errors.Errorf("application %q has no units", appName)
+	}
+	tags := make([]string, len(units))
+	for i, u := range units {
+		tags[i] = u.Tag().String()
+	}
+	return tags, nil
 }
 
 // ListOperations fetches the called actions for specified apps/units.
@@ -153,7 +280,7 @@ func (a *ActionAPI) ListOperations(arg params.OperationQueryArgs) (params.Operat
 	if arg.Offset != nil {
 		offset = *arg.Offset
 	}
-	summaryResults, truncated, err := a.model.ListOperations(arg.ActionNames, receiverTags, actionStatus, offset, limit)
+	summaryResults, truncated, err := a.model.ListOperations(arg.ActionNames, receiverTags, actionStatus, arg.Schedules, offset, limit)
 	if err != nil {
 		return params.OperationResults{}, errors.Trace(err)
 	}
@@ -164,13 +291,16 @@ func (a *ActionAPI) ListOperations(arg params.OperationQueryArgs) (params.Operat
 	}
 	for i, r := range summaryResults {
 		result.Results[i] = params.OperationResult{
-			OperationTag: r.Operation.Tag().String(),
-			Summary:      r.Operation.Summary(),
-			Enqueued:     r.Operation.Enqueued(),
-			Started:      r.Operation.Started(),
-			Completed:    r.Operation.Completed(),
-			Status:       string(r.Operation.Status()),
-			Actions:      make([]params.ActionResult, len(r.Actions)),
+			OperationTag:  r.Operation.Tag().String(),
+			Summary:       r.Operation.Summary(),
+			Enqueued:      r.Operation.Enqueued(),
+			Started:       r.Operation.Started(),
+			Completed:     r.Operation.Completed(),
+			Status:        string(r.Operation.Status()),
+			ScheduleId:    r.Operation.ScheduleId(),
+			MaxParallel:   r.Operation.MaxParallel(),
+			StopOnFailure: r.Operation.FailureBudget(),
+			Actions:       make([]params.ActionResult, len(r.Actions)),
 		}
 		for j, a := range r.Actions {
 			receiver, err := names.ActionReceiverTag(a.Receiver())
@@ -206,13 +336,16 @@ func (a *ActionAPI) Operations(arg params.Entities) (params.OperationResults, er
 		}
 
 		results.Results[i] = params.OperationResult{
-			OperationTag: op.Operation.Tag().String(),
-			Summary:      op.Operation.Summary(),
-			Enqueued:     op.Operation.Enqueued(),
-			Started:      op.Operation.Started(),
-			Completed:    op.Operation.Completed(),
-			Status:       string(op.Operation.Status()),
-			Actions:      make([]params.ActionResult, len(op.Actions)),
+			OperationTag:  op.Operation.Tag().String(),
+			Summary:       op.Operation.Summary(),
+			Enqueued:      op.Operation.Enqueued(),
+			Started:       op.Operation.Started(),
+			Completed:     op.Operation.Completed(),
+			Status:        string(op.Operation.Status()),
+			ScheduleId:    op.Operation.ScheduleId(),
+			MaxParallel:   op.Operation.MaxParallel(),
+			StopOnFailure: op.Operation.FailureBudget(),
+			Actions:       make([]params.ActionResult, len(op.Actions)),
 		}
 		for j, a := range op.Actions {
 			receiver, err := names.ActionReceiverTag(a.Receiver())
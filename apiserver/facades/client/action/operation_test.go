@@ -5,6 +5,7 @@ package action_test
 
 import (
 	"strconv"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/kr/pretty"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
 )
 
 type operationSuite struct {
@@ -330,3 +332,57 @@ func (s *operationSuite) TestOperations(c *gc.C) {
 	c.Assert(action.Tag, gc.Equals, "action-5")
 	c.Assert(result.Actions[3].Status, gc.Equals, "pending")
 }
+
+func (s *operationSuite) TestEnqueueRollingOperation(c *gc.C) {
+	claimer, err := s.LeaseManager.Claimer("application-leadership", s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	err = claimer.Claim("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpressUnit2 := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.wordpress,
+		Machine:     s.machine0,
+	})
+
+	r, err := s.action.EnqueueRollingOperation(params.RollingActions{
+		Actions: []params.Action{
+			{Receiver: s.wordpress.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{}},
+		},
+		MaxParallel:   1,
+		StopOnFailure: 1,
+		LeaderOrder:   "last",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Actions, gc.HasLen, 2)
+	receivers := []string{r.Actions[0].Action.Receiver, r.Actions[1].Action.Receiver}
+	c.Assert(receivers, jc.SameContents, []string{s.wordpressUnit.Tag().String(), wordpressUnit2.Tag().String()})
+
+	operations, err := s.action.Operations(params.Entities{
+		Entities: []params.Entity{{Tag: r.OperationTag}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operations.Results, gc.HasLen, 1)
+	c.Assert(operations.Results[0].MaxParallel, gc.Equals, 1)
+	c.Assert(operations.Results[0].StopOnFailure, gc.Equals, 1)
+
+	// The leader runs last, so only the other unit's task is started.
+	pending, err := s.wordpressUnit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 1)
+	_, message := pending[0].Results()
+	c.Assert(message, gc.Equals, "waiting for earlier tasks of the operation")
+	pending, err = wordpressUnit2.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 1)
+	_, message = pending[0].Results()
+	c.Assert(message, gc.Equals, "")
+}
+
+func (s *operationSuite) TestEnqueueRollingOperationBadLeaderOrder(c *gc.C) {
+	_, err := s.action.EnqueueRollingOperation(params.RollingActions{
+		Actions: []params.Action{
+			{Receiver: s.wordpress.Tag().String(), Name: "fakeaction"},
+		},
+		LeaderOrder: "middle",
+	})
+	c.Assert(err, gc.ErrorMatches, `leader order "middle" not valid`)
+}
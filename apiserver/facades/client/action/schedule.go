@@ -0,0 +1,97 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state"
+)
+
+// AddActionSchedules stores schedules which run an action
+// as a new operation each time their cron expression is due.
+func (a *ActionAPI) AddActionSchedules(args params.ActionScheduleArgs) (params.ActionScheduleResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		schedule, err := a.model.AddActionSchedule(state.ActionScheduleArgs{
+			Receiver:        arg.Receiver,
+			Name:            arg.Name,
+			Parameters:      arg.Parameters,
+			Cron:            arg.Cron,
+			MissedRunPolicy: actions.MissedRunPolicy(arg.MissedRunPolicy),
+		})
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Schedule = makeActionSchedule(schedule)
+	}
+	return results, nil
+}
+
+// ListActionSchedules returns all the action schedules in the model.
+func (a *ActionAPI) ListActionSchedules() (params.ActionScheduleResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	schedules, err := a.model.AllActionSchedules()
+	if err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		results.Results[i].Schedule = makeActionSchedule(schedule)
+	}
+	return results, nil
+}
+
+// RemoveActionSchedules removes the action schedules with the given ids.
+// Operations already fired by the schedules are not affected.
+func (a *ActionAPI) RemoveActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		if err := a.model.RemoveActionSchedule(id); err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
+func makeActionSchedule(schedule *state.ActionSchedule) *params.ActionSchedule {
+	return &params.ActionSchedule{
+		Id:              schedule.Id(),
+		Receiver:        schedule.Receiver(),
+		Name:            schedule.Name(),
+		Parameters:      schedule.Parameters(),
+		Cron:            schedule.Cron(),
+		MissedRunPolicy: string(schedule.MissedRunPolicy()),
+		Created:         schedule.Created(),
+		LastRun:         schedule.LastRun(),
+		LastOperation:   schedule.LastOperation(),
+	}
+}
@@ -111,6 +111,12 @@ type APIv12 struct {
 // It adds CharmOrigin. The ApplicationsInfo call populates the exposed
 // endpoints field in its response entries.
 type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+// It adds the PauseUnits and ResumeUnits methods.
+type APIv14 struct {
 	*APIBase
 }
 
@@ -226,16 +232,25 @@ func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
 }
 
 func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV14(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv13{api}, nil
 }
 
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
+	Application(string, caas.DeploymentType) caas.Application
 }
 
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
@@ -497,7 +512,7 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		return AddTrustSchemaAndDefaults(machineReplacementFields, machineReplacementDefaults)
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -1517,7 +1532,15 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if api.modelType == state.ModelTypeCAAS {
+	if args.Ingress != nil {
+		if api.modelType != state.ModelTypeCAAS {
+			return errors.NotSupportedf("exposing an ingress for a non k8s application")
+		}
+		if args.Ingress.Hostname == "" {
+			return errors.NotValidf("ingress without hostname")
+		}
+	}
+	if api.modelType == state.ModelTypeCAAS && args.Ingress == nil {
 		appConfig, err := app.ApplicationConfig()
 		if err != nil {
 			return errors.Trace(err)
@@ -1545,7 +1568,17 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 		}
 	}
 
-	if err = app.MergeExposeSettings(mappedExposeParams); err != nil {
+	if args.Ingress == nil {
+		err = app.MergeExposeSettings(mappedExposeParams)
+	} else {
+		err = app.MergeExposeSettingsWithIngress(mappedExposeParams, &state.ExposedIngress{
+			Hostname:  args.Ingress.Hostname,
+			Path:      args.Ingress.Path,
+			TLSSecret: args.Ingress.TLSSecret,
+			Class:     args.Ingress.Class,
+		})
+	}
+	if err != nil {
 		return apiservererrors.ServerError(err)
 	}
 	return nil
@@ -2751,6 +2784,51 @@ func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResul
 	return result, nil
 }
 
+// PauseUnits isn't on the v13 API.
+func (u *APIv13) PauseUnits(_, _ struct{}) {}
+
+// ResumeUnits isn't on the v13 API.
+func (u *APIv13) ResumeUnits(_, _ struct{}) {}
+
+// PauseUnits pauses hook execution on the specified units. The unit
+// agents finish any operation already under way but start no new ones
+// until the units are resumed.
+func (api *APIBase) PauseUnits(args params.Entities) (params.ErrorResults, error) {
+	return api.setUnitsPaused(args, true)
+}
+
+// ResumeUnits resumes hook execution on the specified units.
+func (api *APIBase) ResumeUnits(args params.Entities) (params.ErrorResults, error) {
+	return api.setUnitsPaused(args, false)
+}
+
+func (api *APIBase) setUnitsPaused(args params.Entities, paused bool) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		err = unit.SetPaused(paused)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
 // ApplicationInfo isn't on the v8 API.
 func (u *APIv8) ApplicationInfo(_, _ struct{}) {}
 
@@ -2799,6 +2877,15 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 			continue
 		}
 
+		var availability *params.ApplicationAvailability
+		if api.modelType == state.ModelTypeCAAS {
+			availability, err = api.applicationAvailability(app)
+			if err != nil {
+				out[i].Error = apiservererrors.ServerError(err)
+				continue
+			}
+		}
+
 		out[i].Result = &params.ApplicationResult{
 			Tag:              tag.String(),
 			Charm:            details.Charm,
@@ -2810,11 +2897,57 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 			Remote:           app.IsRemote(),
 			EndpointBindings: bindingsMap,
 			ExposedEndpoints: exposedEndpoints,
+			Availability:     availability,
 		}
 	}
 	return params.ApplicationInfoResults{out}, nil
 }
 
+// applicationAvailability returns the availability policy of a caas
+// application along with the status of its disruption budget, or nil
+// if the application has no availability policy. The status of the
+// budget is reported as unknown if it can't be retrieved.
+func (api *APIBase) applicationAvailability(app Application) (*params.ApplicationAvailability, error) {
+	cfg, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	policy, err := caas.AvailabilityPolicyFromConfig(cfg)
+	if err != nil || policy == nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.ApplicationAvailability{
+		MaxUnavailableUnits: policy.MaxUnavailable,
+	}
+	for _, t := range policy.SpreadAcross {
+		result.SpreadAcross = append(result.SpreadAcross, string(t))
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	deploymentType := caas.DeploymentStateful
+	if d := ch.Meta().Deployment; d != nil && d.DeploymentType != "" {
+		deploymentType = caas.DeploymentType(d.DeploymentType)
+	}
+	st, err := api.caasBroker.Application(app.Name(), deploymentType).State()
+	if errors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		// The policy is still worth reporting when the
+		// cluster can't be asked about the budget.
+		logger.Warningf("getting state of application %q: %v", app.Name(), err)
+		result.Unknown = true
+		return result, nil
+	}
+	if st.DisruptionBudget != nil {
+		result.DisruptionsAllowed = st.DisruptionBudget.DisruptionsAllowed
+		result.CurrentHealthy = st.DisruptionBudget.CurrentHealthy
+		result.DesiredHealthy = st.DisruptionBudget.DesiredHealthy
+	}
+	return result, nil
+}
+
 func (api *APIBase) mapExposedEndpointsFromState(exposedEndpoints map[string]state.ExposedEndpoint) (map[string]params.ExposedEndpoint, error) {
 	if len(exposedEndpoints) == 0 {
 		return nil, nil
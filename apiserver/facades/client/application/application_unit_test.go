@@ -35,6 +35,7 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
@@ -62,7 +63,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv13
+	api          *application.APIv14
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -93,7 +94,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv13{api}
+	s.api = &application.APIv14{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -385,7 +386,7 @@ func (s *ApplicationSuite) TestUpdateCAASApplicationSettings(c *gc.C) {
 		ApplicationName: "postgresql",
 		SettingsYAML:    "postgresql:\n  stringOption: bar\n  juju-external-hostname: foo",
 	}
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	err := api.Update(args)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -463,7 +464,7 @@ func (s *ApplicationSuite) TestUpdateCAASApplicationSettingsInIAASModelTriggersE
 		ApplicationName: "postgresql",
 		SettingsYAML:    "postgresql:\n  stringOption: bar\n  juju-external-hostname: foo",
 	}
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	err := api.Update(args)
 	c.Assert(err, gc.ErrorMatches, `.*unknown option "juju-external-hostname"`, gc.Commentf("expected to get an error when attempting to set CAAS-specific app setting in IAAS model"))
 }
@@ -1850,7 +1851,7 @@ func (s *ApplicationSuite) TestSetApplicationConfigEmptyUsesMaster(c *gc.C) {
 
 func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -1886,7 +1887,7 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 
 func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -1955,7 +1956,7 @@ func (s *ApplicationSuite) TestSetConfigBranch(c *gc.C) {
 
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	_, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{})
 	c.Assert(err, gc.ErrorMatches, "blocked")
 	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
@@ -1964,7 +1965,7 @@ func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 
 func (s *ApplicationSuite) TestSetApplicationConfigPermissionDenied(c *gc.C) {
 	s.setAPIUser(c, names.NewUserTag("fred"))
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	_, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -2073,6 +2074,40 @@ func (s *ApplicationSuite) TestResolveUnitErrorsPermissionDenied(c *gc.C) {
 	s.application.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestPauseUnits(c *gc.C) {
+	result, err := s.api.PauseUnits(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-postgresql-0"}, {Tag: "application-postgresql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.CheckCallNames(c, "SetPaused")
+	unit.CheckCall(c, 0, "SetPaused", true)
+}
+
+func (s *ApplicationSuite) TestResumeUnits(c *gc.C) {
+	result, err := s.api.ResumeUnits(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.CheckCallNames(c, "SetPaused")
+	unit.CheckCall(c, 0, "SetPaused", false)
+}
+
+func (s *ApplicationSuite) TestPauseUnitsPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.PauseUnits(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *ApplicationSuite) TestCAASExposeWithoutHostname(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	err := s.api.Expose(params.ApplicationExpose{
@@ -2094,6 +2129,40 @@ func (s *ApplicationSuite) TestCAASExposeWithHostname(c *gc.C) {
 	app.CheckCallNames(c, "ApplicationConfig", "MergeExposeSettings")
 }
 
+func (s *ApplicationSuite) TestCAASExposeWithIngress(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		Ingress: &params.ExposedIngress{
+			Hostname:  "postgresql.example.com",
+			Path:      "/db",
+			TLSSecret: "postgresql-tls",
+			Class:     "traefik",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app.CheckCallNames(c, "MergeExposeSettingsWithIngress")
+	app.CheckCall(c, 0, "MergeExposeSettingsWithIngress", map[string]state.ExposedEndpoint{
+		"": {
+			ExposeToCIDRs: []string{firewall.AllNetworksIPV4CIDR, firewall.AllNetworksIPV6CIDR},
+		},
+	}, &state.ExposedIngress{
+		Hostname:  "postgresql.example.com",
+		Path:      "/db",
+		TLSSecret: "postgresql-tls",
+		Class:     "traefik",
+	})
+}
+
+func (s *ApplicationSuite) TestExposeWithIngressNotSupportedForIAAS(c *gc.C) {
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		Ingress:         &params.ExposedIngress{Hostname: "postgresql.example.com"},
+	})
+	c.Assert(err, gc.ErrorMatches, "exposing an ingress for a non k8s application not supported")
+}
+
 func (s *ApplicationSuite) TestApplicationsInfoOne(c *gc.C) {
 	entities := []params.Entity{{Tag: "application-postgresql"}}
 	result, err := s.api.ApplicationsInfo(params.Entities{entities})
@@ -2114,6 +2183,73 @@ func (s *ApplicationSuite) TestApplicationsInfoOne(c *gc.C) {
 	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "IsPrincipal", "IsExposed", "IsRemote")
 }
 
+func (s *ApplicationSuite) TestApplicationsInfoCAASAvailability(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		caas.JujuMaxUnavailableUnitsKey: 1,
+		caas.JujuSpreadUnitsKey:         "zone",
+	}
+	s.caasBroker.appState = caas.ApplicationState{
+		DesiredReplicas: 3,
+		DisruptionBudget: &caas.DisruptionBudgetStatus{
+			DisruptionsAllowed: 1,
+			CurrentHealthy:     3,
+			DesiredHealthy:     2,
+		},
+	}
+
+	entities := []params.Entity{{Tag: "application-postgresql"}}
+	result, err := s.api.ApplicationsInfo(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(entities))
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.Availability, gc.DeepEquals, &params.ApplicationAvailability{
+		MaxUnavailableUnits: 1,
+		SpreadAcross:        []string{"zone"},
+		DisruptionsAllowed:  1,
+		CurrentHealthy:      3,
+		DesiredHealthy:      2,
+	})
+	s.caasBroker.CheckCall(c, 0, "Application", "postgresql", caas.DeploymentStateful)
+}
+
+func (s *ApplicationSuite) TestApplicationsInfoCAASAvailabilityDeploymentType(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		caas.JujuMaxUnavailableUnitsKey: 1,
+	}
+	app.charm.meta.Deployment = &charm.Deployment{DeploymentType: charm.DeploymentStateless}
+
+	entities := []params.Entity{{Tag: "application-postgresql"}}
+	result, err := s.api.ApplicationsInfo(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	s.caasBroker.CheckCall(c, 0, "Application", "postgresql", caas.DeploymentStateless)
+}
+
+func (s *ApplicationSuite) TestApplicationsInfoCAASAvailabilityBrokerError(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		caas.JujuMaxUnavailableUnitsKey: 1,
+	}
+	s.caasBroker.SetErrors(errors.New("cluster unreachable"))
+
+	entities := []params.Entity{{Tag: "application-postgresql"}}
+	result, err := s.api.ApplicationsInfo(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.Availability, gc.DeepEquals, &params.ApplicationAvailability{
+		MaxUnavailableUnits: 1,
+		Unknown:             true,
+	})
+}
+
 func (s *ApplicationSuite) TestApplicationsInfoOneWithExposedEndpoints(c *gc.C) {
 	s.backend.spaceInfos = network.SpaceInfos{
 		{
@@ -83,6 +83,7 @@ type Application interface {
 	DestroyOperation() *state.DestroyApplicationOperation
 	EndpointBindings() (Bindings, error)
 	ExposedEndpoints() map[string]state.ExposedEndpoint
+	ExposedIngress() *state.ExposedIngress
 	Endpoints() ([]state.Endpoint, error)
 	IsExposed() bool
 	IsPrincipal() bool
@@ -91,6 +92,7 @@ type Application interface {
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
 	MergeExposeSettings(map[string]state.ExposedEndpoint) error
+	MergeExposeSettingsWithIngress(map[string]state.ExposedEndpoint, *state.ExposedIngress) error
 	UnsetExposeSettings([]string) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
@@ -171,6 +173,7 @@ type Unit interface {
 	IsPrincipal() bool
 	Life() state.Life
 	Resolve(retryHooks bool) error
+	SetPaused(paused bool) error
 	AgentTools() (*tools.Tools, error)
 
 	AssignedMachineId() (string, error)
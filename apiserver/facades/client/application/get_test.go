@@ -31,7 +31,7 @@ import (
 type getSuite struct {
 	jujutesting.JujuConnSuite
 
-	applicationAPI *application.APIv13
+	applicationAPI *application.APIv14
 	authorizer     apiservertesting.FakeAuthorizer
 }
 
@@ -63,7 +63,7 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv13{api}
+	s.applicationAPI = &application.APIv14{api}
 }
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
@@ -77,7 +77,7 @@ func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
 							&application.APIv10{
 								&application.APIv11{
 									&application.APIv12{
-										s.applicationAPI,
+										&application.APIv13{s.applicationAPI},
 									},
 								},
 							},
@@ -115,7 +115,7 @@ func (s *getSuite) TestClientApplicationGetSmokeTestV5(c *gc.C) {
 						&application.APIv10{
 							&application.APIv11{
 								&application.APIv12{
-									s.applicationAPI,
+									&application.APIv13{s.applicationAPI},
 								},
 							},
 						},
@@ -162,6 +162,13 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmokeTest(c *gc.C) {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"replace-lost-machines": map[string]interface{}{
+				"default":     false,
+				"description": "Are machines hosting this application replaced when their instances are lost",
+				"source":      "default",
+				"type":        environschema.Tbool,
+				"value":       false,
+			},
 			"trust": map[string]interface{}{
 				"default":     false,
 				"description": "Does this application have access to trusted credentials",
@@ -261,7 +268,7 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 				&application.APIv11{
 					&application.APIv12{
 						&application.APIv13{
-							api,
+							&application.APIv14{api},
 						},
 					},
 				},
@@ -344,6 +351,13 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"replace-lost-machines": map[string]interface{}{
+				"value":       false,
+				"default":     false,
+				"description": "Are machines hosting this application replaced when their instances are lost",
+				"source":      "default",
+				"type":        "bool",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -406,6 +420,13 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"replace-lost-machines": map[string]interface{}{
+				"value":       false,
+				"default":     false,
+				"description": "Are machines hosting this application replaced when their instances are lost",
+				"source":      "default",
+				"type":        "bool",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -426,6 +447,13 @@ var getTests = []struct {
 		CharmConfig: map[string]interface{}{},
 		Series:      "quantal",
 		ApplicationConfig: map[string]interface{}{
+			"replace-lost-machines": map[string]interface{}{
+				"value":       false,
+				"default":     false,
+				"description": "Are machines hosting this application replaced when their instances are lost",
+				"source":      "default",
+				"type":        "bool",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
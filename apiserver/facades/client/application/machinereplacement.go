@@ -0,0 +1,23 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var machineReplacementFields = environschema.Fields{
+	application.ReplaceLostMachinesConfigOptionName: {
+		Description: "Are machines hosting this application replaced when their instances are lost",
+		Type:        environschema.Tbool,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+var machineReplacementDefaults = schema.Defaults{
+	application.ReplaceLostMachinesConfigOptionName: false,
+}
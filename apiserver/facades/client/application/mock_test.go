@@ -92,6 +92,7 @@ type mockApplication struct {
 	curl             *charm.URL
 	endpoints        []state.Endpoint
 	exposedEndpoints map[string]state.ExposedEndpoint
+	exposedIngress   *state.ExposedIngress
 	name             string
 	scale            int
 	subordinate      bool
@@ -244,11 +245,21 @@ func (a *mockApplication) MergeExposeSettings(exposedEndpoints map[string]state.
 	return a.NextErr()
 }
 
+func (a *mockApplication) MergeExposeSettingsWithIngress(exposedEndpoints map[string]state.ExposedEndpoint, ingress *state.ExposedIngress) error {
+	a.MethodCall(a, "MergeExposeSettingsWithIngress", exposedEndpoints, ingress)
+	return a.NextErr()
+}
+
 func (a *mockApplication) UnsetExposeSettings(exposedEndpoints []string) error {
 	a.MethodCall(a, "UnsetExposeSettings", exposedEndpoints)
 	return a.NextErr()
 }
 
+func (a *mockApplication) ExposedIngress() *state.ExposedIngress {
+	a.MethodCall(a, "ExposedIngress")
+	return a.exposedIngress
+}
+
 func (a *mockApplication) IsExposed() bool {
 	a.MethodCall(a, "IsExposed")
 	return a.exposed
@@ -977,6 +988,11 @@ func (u *mockUnit) Resolve(retryHooks bool) error {
 	return u.NextErr()
 }
 
+func (u *mockUnit) SetPaused(paused bool) error {
+	u.MethodCall(u, "SetPaused", paused)
+	return u.NextErr()
+}
+
 func (u *mockUnit) AssignedMachineId() (string, error) {
 	u.MethodCall(u, "AssignedMachineId")
 	return u.machineId, u.NextErr()
@@ -1159,6 +1175,7 @@ type mockCaasBroker struct {
 	jtesting.Stub
 	caas.StorageValidator
 	caas.ClusterVersionGetter
+	appState caas.ApplicationState
 }
 
 func (m *mockCaasBroker) ValidateStorageClass(config map[string]interface{}) error {
@@ -1172,6 +1189,21 @@ func (m *mockCaasBroker) Version() (*version.Number, error) {
 	return &ver, nil
 }
 
+func (m *mockCaasBroker) Application(name string, deploymentType caas.DeploymentType) caas.Application {
+	m.MethodCall(m, "Application", name, deploymentType)
+	return &mockCaasApplication{broker: m}
+}
+
+type mockCaasApplication struct {
+	caas.Application
+	broker *mockCaasBroker
+}
+
+func (a *mockCaasApplication) State() (caas.ApplicationState, error) {
+	a.broker.MethodCall(a, "State")
+	return a.broker.appState, a.broker.NextErr()
+}
+
 type mockGeneration struct {
 	jtesting.Stub
 }
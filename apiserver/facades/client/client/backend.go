@@ -102,6 +102,7 @@ type Unit interface {
 	PrivateAddress() (network.SpaceAddress, error)
 	Resolve(retryHooks bool) error
 	AgentHistory() status.StatusHistoryGetter
+	EventHistory(status.StatusHistoryFilter) ([]status.StatusInfo, error)
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
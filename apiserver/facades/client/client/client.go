@@ -170,7 +170,8 @@ func newFacade(ctx facade.Context) (*Client, error) {
 	blockChecker := common.NewBlockChecker(st)
 	backend := modelconfig.NewStateBackend(model)
 	// The modelConfigAPI exposed here is V1.
-	modelConfigAPI, err := modelconfig.NewModelConfigAPI(backend, authorizer)
+	modelConfigAPI, err := modelconfig.NewModelConfigAPI(
+		backend, authorizer, modelconfig.RegistryCredentialManagerForModel(model))
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -188,7 +189,7 @@ func newFacade(ctx facade.Context) (*Client, error) {
 	return NewClient(
 		&stateShim{st, model, nil},
 		&poolShim{ctx.StatePool()},
-		&modelconfig.ModelConfigAPIV1{modelConfigAPI},
+		&modelconfig.ModelConfigAPIV1{&modelconfig.ModelConfigAPIV2{modelConfigAPI}},
 		resources,
 		authorizer,
 		presence,
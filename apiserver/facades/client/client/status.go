@@ -77,6 +77,13 @@ func (c *Client) unitStatusHistory(unitTag names.UnitTag, filter status.StatusHi
 		}
 		statuses = append(statuses, agentStatusFromStatusInfo(agentStatuses, status.KindUnitAgent)...)
 	}
+	if kind == status.KindUnit || kind == status.KindK8sEvent {
+		events, err := unit.EventHistory(filter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		statuses = append(statuses, agentStatusFromStatusInfo(events, status.KindK8sEvent)...)
+	}
 
 	sort.Sort(byTime(statuses))
 	if kind == status.KindUnit && filter.Size > 0 {
@@ -88,6 +95,34 @@ func (c *Client) unitStatusHistory(unitTag names.UnitTag, filter status.StatusHi
 	return statuses, nil
 }
 
+// applicationStatusHistory returns a list of status history entries
+// for an application and the events reported for it by the cloud.
+func (c *Client) applicationStatusHistory(appTag names.ApplicationTag, filter status.StatusHistoryFilter, kind status.HistoryKind) ([]params.DetailedStatus, error) {
+	app, err := c.api.stateAccessor.Application(appTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	statuses := []params.DetailedStatus{}
+	if kind == status.KindApplication {
+		appStatuses, err := app.StatusHistory(filter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		statuses = agentStatusFromStatusInfo(appStatuses, status.KindApplication)
+	}
+	events, err := app.EventHistory(filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	statuses = append(statuses, agentStatusFromStatusInfo(events, status.KindK8sEvent)...)
+
+	sort.Sort(byTime(statuses))
+	if filter.Size > 0 && len(statuses) > filter.Size {
+		statuses = statuses[len(statuses)-filter.Size:]
+	}
+	return statuses, nil
+}
+
 // machineStatusHistory returns status history for the given machine.
 func (c *Client) machineStatusHistory(machineTag names.MachineTag, filter status.StatusHistoryFilter, kind status.HistoryKind) ([]params.DetailedStatus, error) {
 	machine, err := c.api.stateAccessor.Machine(machineTag.Id())
@@ -146,6 +181,21 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 			if u, err = names.ParseUnitTag(request.Tag); err == nil {
 				hist, err = c.unitStatusHistory(u, filter, kind)
 			}
+		case status.KindApplication:
+			var a names.ApplicationTag
+			if a, err = names.ParseApplicationTag(request.Tag); err == nil {
+				hist, err = c.applicationStatusHistory(a, filter, kind)
+			}
+		case status.KindK8sEvent:
+			// Events are recorded for both units and applications.
+			if u, uErr := names.ParseUnitTag(request.Tag); uErr == nil {
+				hist, err = c.unitStatusHistory(u, filter, kind)
+			} else {
+				var a names.ApplicationTag
+				if a, err = names.ParseApplicationTag(request.Tag); err == nil {
+					hist, err = c.applicationStatusHistory(a, filter, kind)
+				}
+			}
 		default:
 			var m names.MachineTag
 			if m, err = names.ParseMachineTag(request.Tag); err == nil {
@@ -1271,6 +1321,11 @@ func (context *statusContext) processApplication(application *state.Application)
 		} else {
 			logger.Debugf("no service details for %v: %v", application.Name(), err)
 		}
+		// An application exposed through an ingress is reached via
+		// the ingress hostname rather than the service address.
+		if ingress := application.ExposedIngress(); application.IsExposed() && ingress != nil {
+			processedStatus.PublicAddress = ingress.Hostname
+		}
 		processedStatus.Scale = application.GetScale()
 	}
 	processedStatus.EndpointBindings = context.allAppsUnitsCharmBindings.endpointBindings[application.Name()]
@@ -1060,6 +1060,19 @@ func (s *CAASStatusSuite) TestStatusWorkloadVersionSetByCharm(c *gc.C) {
 	c.Assert(status.Applications[s.app.Name()].WorkloadVersion, gc.Equals, "666")
 }
 
+func (s *CAASStatusSuite) TestStatusExposedIngressPublicAddress(c *gc.C) {
+	client := s.APIState.Client()
+	err := s.app.MergeExposeSettingsWithIngress(nil, &state.ExposedIngress{Hostname: "gitlab.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Applications, gc.HasLen, 1)
+	appStatus := status.Applications[s.app.Name()]
+	c.Assert(appStatus.Exposed, jc.IsTrue)
+	c.Assert(appStatus.PublicAddress, gc.Equals, "gitlab.example.com")
+}
+
 type filteringBranchesSuite struct {
 	baseSuite
 
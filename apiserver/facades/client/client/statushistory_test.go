@@ -226,10 +226,92 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestStatusHistoryK8sEventsOnly(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.Active,
+			Message: "running",
+		},
+	})
+	s.st.eventHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  "warning",
+			Message: "BackOff: Back-off restarting failed container",
+		},
+		{
+			Status:  "normal",
+			Message: "Pulled: Successfully pulled image",
+		},
+	})
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "unit-unit-0",
+			Kind:   status.KindK8sEvent.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.IsNil)
+	checkStatusInfo(c, h.Results[0].History.Statuses, reverseStatusInfo(s.st.eventHistory))
+	for _, st := range h.Results[0].History.Statuses {
+		c.Check(st.Kind, gc.Equals, status.KindK8sEvent.String())
+	}
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryCombinedWithK8sEvents(c *gc.C) {
+	at := func(seconds int64) *time.Time {
+		t := time.Unix(seconds, 0)
+		return &t
+	}
+	s.st.unitHistory = []status.StatusInfo{{
+		Status:  status.Maintenance,
+		Message: "working",
+		Since:   at(1000),
+	}}
+	s.st.agentHistory = []status.StatusInfo{{
+		Status: status.Idle,
+		Since:  at(997),
+	}}
+	s.st.eventHistory = []status.StatusInfo{{
+		Status:  "warning",
+		Message: "BackOff: Back-off restarting failed container",
+		Since:   at(999),
+	}, {
+		Status:  "normal",
+		Message: "Pulled: Successfully pulled image",
+		Since:   at(998),
+	}}
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "unit-unit-0",
+			Kind:   status.KindUnit.String(),
+			Filter: params.StatusHistoryFilter{Size: 3},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.IsNil)
+	expected := []status.StatusInfo{
+		s.st.eventHistory[1],
+		s.st.eventHistory[0],
+		s.st.unitHistory[0],
+	}
+	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryK8sEventsBadTag(c *gc.C) {
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "machine-0",
+			Kind:   status.KindK8sEvent.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.ErrorMatches, `fetching status history for "machine-0": "machine-0" is not a valid application tag`)
+}
+
 type mockState struct {
 	client.Backend
 	unitHistory  []status.StatusInfo
 	agentHistory []status.StatusInfo
+	eventHistory []status.StatusInfo
 }
 
 func (m *mockState) ModelUUID() string {
@@ -251,12 +333,14 @@ func (m *mockState) Unit(name string) (client.Unit, error) {
 	return &mockUnit{
 		status: m.unitHistory,
 		agent:  &mockUnitAgent{m.agentHistory},
+		events: m.eventHistory,
 	}, nil
 }
 
 type mockUnit struct {
 	status statuses
 	agent  *mockUnitAgent
+	events statuses
 	client.Unit
 }
 
@@ -264,6 +348,10 @@ func (m *mockUnit) StatusHistory(filter status.StatusHistoryFilter) ([]status.St
 	return m.status.StatusHistory(filter)
 }
 
+func (m *mockUnit) EventHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	return m.events.StatusHistory(filter)
+}
+
 func (m *mockUnit) AgentHistory() status.StatusHistoryGetter {
 	return m.agent
 }
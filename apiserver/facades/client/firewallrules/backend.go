@@ -4,9 +4,14 @@
 package firewallrules
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
+	"github.com/juju/naturalsort"
 
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -15,10 +20,42 @@ import (
 // with the same names.
 type Backend interface {
 	ModelTag() names.ModelTag
+	ModelConfig() (*config.Config, error)
+	AllSpaceInfos() (network.SpaceInfos, error)
+	Application(string) (Application, error)
+	Machine(string) (Machine, error)
 	SaveFirewallRule(state.FirewallRule) error
 	ListFirewallRules() ([]*state.FirewallRule, error)
 }
 
+// Application defines the application functionality required by the
+// firewallrules facade.
+type Application interface {
+	Name() string
+	IsExposed() bool
+	ExposedEndpoints() map[string]state.ExposedEndpoint
+
+	// UnitMachineIds returns the ids of the machines to which the
+	// application's units are assigned.
+	UnitMachineIds() ([]string, error)
+
+	// RelationIngressCIDRs returns the source CIDRs that require
+	// ingress to the application for its active cross-model relations.
+	RelationIngressCIDRs() ([]string, error)
+}
+
+// Machine defines the machine functionality required by the
+// firewallrules facade.
+type Machine interface {
+	Id() string
+	IsContainer() bool
+	InstanceId() (instance.Id, error)
+
+	// OpenedPortRanges returns the port ranges opened on the machine,
+	// grouped by unit name and endpoint.
+	OpenedPortRanges() (map[string]network.GroupedPortRanges, error)
+}
+
 // BlockChecker defines the block-checking functionality required by
 // the firewallrules facade. This is implemented by
 // apiserver/common.BlockChecker.
@@ -57,3 +94,87 @@ func (s stateShim) ListFirewallRules() ([]*state.FirewallRule, error) {
 	api := state.NewFirewallRules(s.State)
 	return api.AllRules()
 }
+
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationShim{Application: app, st: s.State}, nil
+}
+
+func (s stateShim) Machine(id string) (Machine, error) {
+	m, err := s.State.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machineShim{m}, nil
+}
+
+type applicationShim struct {
+	*state.Application
+	st *state.State
+}
+
+func (a applicationShim) UnitMachineIds() ([]string, error) {
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := set.NewStrings()
+	for _, u := range units {
+		id, err := u.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids.Add(id)
+	}
+	result := ids.Values()
+	naturalsort.Sort(result)
+	return result, nil
+}
+
+func (a applicationShim) RelationIngressCIDRs() ([]string, error) {
+	relations, err := a.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ingress := state.NewRelationIngressNetworks(a.st)
+	cidrs := set.NewStrings()
+	for _, rel := range relations {
+		if rel.Suspended() {
+			continue
+		}
+		if _, isCrossModel, err := rel.RemoteApplication(); err != nil {
+			return nil, errors.Trace(err)
+		} else if !isCrossModel {
+			continue
+		}
+		networks, err := ingress.Networks(rel.Tag().Id())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs = cidrs.Union(set.NewStrings(networks.CIDRS()...))
+	}
+	return cidrs.SortedValues(), nil
+}
+
+type machineShim struct {
+	*state.Machine
+}
+
+func (m machineShim) OpenedPortRanges() (map[string]network.GroupedPortRanges, error) {
+	machinePortRanges, err := m.Machine.OpenedPortRanges()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]network.GroupedPortRanges)
+	for unitName, unitPortRanges := range machinePortRanges.ByUnit() {
+		result[unitName] = unitPortRanges.ByEndpoint()
+	}
+	return result, nil
+}
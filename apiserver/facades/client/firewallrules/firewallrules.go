@@ -4,6 +4,7 @@
 package firewallrules
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -12,47 +13,85 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.firewallrules")
 
-// API provides the firewallrules facade APIs for v1.
+// API provides the firewallrules facade APIs for v2.
 type API struct {
-	backend    Backend
-	authorizer facade.Authorizer
-	check      BlockChecker
+	backend     Backend
+	authorizer  facade.Authorizer
+	check       BlockChecker
+	newEnviron  func() (environs.Environ, error)
+	callContext context.ProviderCallContext
+}
+
+// APIv1 provides the firewallrules facade APIs for v1.
+type APIv1 struct {
+	*API
 }
 
 // NewFacade provides the signature required for facade registration.
 func NewFacade(ctx facade.Context) (*API, error) {
-	backend, err := NewStateBackend(ctx.State())
+	st := ctx.State()
+	backend, err := NewStateBackend(st)
 	if err != nil {
 		return nil, errors.Annotate(err, "getting state")
 	}
-	blockChecker := common.NewBlockChecker(ctx.State())
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	newEnviron := func() (environs.Environ, error) {
+		return stateenvirons.GetNewEnvironFunc(environs.New)(model)
+	}
+	blockChecker := common.NewBlockChecker(st)
 	return NewAPI(
 		backend,
 		ctx.Auth(),
 		blockChecker,
+		newEnviron,
+		context.CallContext(st),
 	)
 }
 
+// NewFacadeV1 provides the signature required for facade registration
+// of the v1 API.
+func NewFacadeV1(ctx facade.Context) (*APIv1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
 // NewAPI returns a new firewallrules API facade.
 func NewAPI(
 	backend Backend,
 	authorizer facade.Authorizer,
 	blockChecker BlockChecker,
+	newEnviron func() (environs.Environ, error),
+	callContext context.ProviderCallContext,
 ) (*API, error) {
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
 	}
 	return &API{
-		backend:    backend,
-		authorizer: authorizer,
-		check:      blockChecker,
+		backend:     backend,
+		authorizer:  authorizer,
+		check:       blockChecker,
+		newEnviron:  newEnviron,
+		callContext: callContext,
 	}, nil
 }
 
@@ -115,3 +154,321 @@ func (api *API) ListFirewallRules() (params.ListFirewallRulesResults, error) {
 	}
 	return listResults, nil
 }
+
+// ApplicationFirewalls returns, for each machine hosting units of the
+// specified applications, the ingress rules that juju expects to be
+// applied to the machine along with those reported by the provider.
+func (api *API) ApplicationFirewalls(args params.Entities) (params.ApplicationFirewallResults, error) {
+	var results params.ApplicationFirewallResults
+	if err := api.checkCanRead(); err != nil {
+		return results, errors.Trace(err)
+	}
+	fw, err := api.newModelFirewall()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+
+	results.Results = make([]params.ApplicationFirewallResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		machines, err := fw.applicationMachines(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		for _, m := range machines {
+			result := params.MachineFirewall{
+				MachineTag: names.NewMachineTag(m.Id()).String(),
+			}
+			mf, err := fw.firewallForMachine(m)
+			if mf != nil {
+				result.InstanceId = string(mf.instanceId)
+				result.ExpectedRules = params.FromIngressRules(mf.expected)
+				result.ProviderRules = params.FromIngressRules(mf.provider)
+			}
+			result.Error = apiservererrors.ServerError(err)
+			results.Results[i].Machines = append(results.Results[i].Machines, result)
+		}
+	}
+	return results, nil
+}
+
+// ReconcileApplicationFirewalls opens and closes ports on the instances
+// hosting units of the specified applications, so that the ingress rules
+// reported by the provider match those that juju expects.
+func (api *API) ReconcileApplicationFirewalls(args params.Entities) (params.ErrorResults, error) {
+	var results params.ErrorResults
+	if err := api.checkAdmin(); err != nil {
+		return results, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	fw, err := api.newModelFirewall()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+
+	results.Results = make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		err := fw.reconcileApplication(entity.Tag)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// modelFirewall computes the ingress rules that the firewaller worker
+// applies to the instances of a model, and compares them with those
+// reported by the provider.
+type modelFirewall struct {
+	backend            Backend
+	environ            environs.Environ
+	callContext        context.ProviderCallContext
+	spaceInfos         network.SpaceInfos
+	ipv6CIDRSupport    bool
+	offerWhitelist     func() ([]string, error)
+	applicationsByName map[string]Application
+}
+
+// machineFirewall holds the expected and provider ingress rules of a
+// single machine.
+type machineFirewall struct {
+	instanceId instance.Id
+	instance   instances.InstanceFirewaller
+	expected   firewall.IngressRules
+	provider   firewall.IngressRules
+}
+
+func (api *API) newModelFirewall() (*modelFirewall, error) {
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Only per-instance firewalls can be attributed to the machines
+	// hosting an application's units.
+	if mode := cfg.FirewallMode(); mode != config.FwInstance {
+		return nil, errors.NotSupportedf("firewall mode %q", mode)
+	}
+	env, err := api.newEnviron()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spaceInfos, err := api.backend.AllSpaceInfos()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ipv6CIDRSupport bool
+	if featQuerier, ok := env.(environs.FirewallFeatureQuerier); ok {
+		if ipv6CIDRSupport, err = featQuerier.SupportsRulesWithIPV6CIDRs(api.callContext); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &modelFirewall{
+		backend:         api.backend,
+		environ:         env,
+		callContext:     api.callContext,
+		spaceInfos:      spaceInfos,
+		ipv6CIDRSupport: ipv6CIDRSupport,
+		offerWhitelist: func() ([]string, error) {
+			rules, err := api.backend.ListFirewallRules()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, rule := range rules {
+				if rule.WellKnownService() == firewall.JujuApplicationOfferRule {
+					return rule.WhitelistCIDRs(), nil
+				}
+			}
+			return nil, nil
+		},
+		applicationsByName: make(map[string]Application),
+	}, nil
+}
+
+func (fw *modelFirewall) application(name string) (Application, error) {
+	if app, ok := fw.applicationsByName[name]; ok {
+		return app, nil
+	}
+	app, err := fw.backend.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	fw.applicationsByName[name] = app
+	return app, nil
+}
+
+// applicationMachines returns the machines hosting units of the
+// application with the given tag. Containers are omitted, as their
+// ports are not managed by the firewaller.
+func (fw *modelFirewall) applicationMachines(tagString string) ([]Machine, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, apiservererrors.ErrPerm
+	}
+	app, err := fw.application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids, err := app.UnitMachineIds()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var machines []Machine
+	for _, id := range ids {
+		m, err := fw.backend.Machine(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m.IsContainer() {
+			continue
+		}
+		machines = append(machines, m)
+	}
+	return machines, nil
+}
+
+// firewallForMachine returns the ingress rules expected for the machine
+// and those reported by the provider for its instance. If the provider
+// rules cannot be retrieved, the result holds the expected rules only.
+func (fw *modelFirewall) firewallForMachine(m Machine) (*machineFirewall, error) {
+	expected, err := fw.expectedRules(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &machineFirewall{expected: expected}
+
+	if result.instanceId, err = m.InstanceId(); err != nil {
+		return result, errors.Trace(err)
+	}
+	insts, err := fw.environ.Instances(fw.callContext, []instance.Id{result.instanceId})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	instFirewaller, ok := insts[0].(instances.InstanceFirewaller)
+	if !ok {
+		return result, errors.NotSupportedf("firewalls of instance %q", result.instanceId)
+	}
+	result.instance = instFirewaller
+	provider, err := instFirewaller.IngressRules(fw.callContext, m.Id())
+	if err != nil {
+		return result, errors.Annotatef(err, "cannot get ingress rules of instance %q", result.instanceId)
+	}
+	result.provider = provider.MergeByPortRange()
+	return result, nil
+}
+
+// expectedRules returns the ingress rules required by the port ranges
+// opened by all of the units on the machine, in the same way as the
+// firewaller worker computes them.
+func (fw *modelFirewall) expectedRules(m Machine) (firewall.IngressRules, error) {
+	openedPortRanges, err := m.OpenedPortRanges()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rules firewall.IngressRules
+	for unitName, unitPortRanges := range openedPortRanges {
+		if len(unitPortRanges) == 0 {
+			continue
+		}
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := fw.application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		if app.IsExposed() {
+			rules = append(rules, firewall.ExposedIngressRules(unitPortRanges, fw.exposedCIDRs(app))...)
+			continue
+		}
+
+		// Not exposed, so add any ingress rules required by remote relations.
+		relationCIDRs, err := app.RelationIngressCIDRs()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		srcCIDRs, err := firewall.ConsolidateRelationCIDRs(set.NewStrings(relationCIDRs...), fw.offerWhitelist)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if srcCIDRs.IsEmpty() {
+			continue
+		}
+		for _, portRange := range unitPortRanges.UniquePortRanges() {
+			rules = append(rules, firewall.NewIngressRule(portRange, srcCIDRs.Values()...))
+		}
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !fw.ipv6CIDRSupport {
+		rules = rules.RemoveCIDRsMatchingAddressType(network.IPv6Address)
+	}
+	return rules.MergeByPortRange(), nil
+}
+
+// exposedCIDRs returns the source CIDRs for each exposed endpoint of the
+// application, resolving any spaces to the CIDRs of their subnets.
+func (fw *modelFirewall) exposedCIDRs(app Application) map[string]set.Strings {
+	exposedCIDRs := make(map[string]set.Strings)
+	for endpoint, exposeDetails := range app.ExposedEndpoints() {
+		srcCIDRs := set.NewStrings(exposeDetails.ExposeToCIDRs...)
+		for _, spaceID := range exposeDetails.ExposeToSpaceIDs {
+			sp := fw.spaceInfos.GetByID(spaceID)
+			if sp == nil {
+				logger.Warningf("exposed endpoint references unknown space ID %q", spaceID)
+				continue
+			}
+			for _, subnet := range sp.Subnets {
+				srcCIDRs.Add(subnet.CIDR)
+			}
+		}
+		exposedCIDRs[endpoint] = srcCIDRs
+	}
+	return exposedCIDRs
+}
+
+// reconcileApplication opens and closes ports on the instances of the
+// machines hosting units of the application with the given tag, so
+// that their ingress rules match the expected ones.
+func (fw *modelFirewall) reconcileApplication(tagString string) error {
+	machines, err := fw.applicationMachines(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		mf, err := fw.firewallForMachine(m)
+		if errors.IsNotProvisioned(err) {
+			// The firewaller will apply the rules once the
+			// machine is provisioned.
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "machine %s", m.Id())
+		}
+		toOpen, toClose := mf.provider.Diff(mf.expected)
+		if len(toOpen) > 0 {
+			logger.Infof("opening ports on machine %s: %v", m.Id(), toOpen)
+			if err := mf.instance.OpenPorts(fw.callContext, m.Id(), toOpen); err != nil {
+				return errors.Annotatef(err, "opening ports on machine %s", m.Id())
+			}
+		}
+		if len(toClose) > 0 {
+			logger.Infof("closing ports on machine %s: %v", m.Id(), toClose)
+			if err := mf.instance.ClosePorts(fw.callContext, m.Id(), toClose); err != nil {
+				return errors.Annotatef(err, "closing ports on machine %s", m.Id())
+			}
+		}
+	}
+	return nil
+}
+
+// Mask the new methods from the V1 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+
+// ApplicationFirewalls isn't on the V1 API.
+func (api *APIv1) ApplicationFirewalls(_, _ struct{}) {}
+
+// ReconcileApplicationFirewalls isn't on the V1 API.
+func (api *APIv1) ReconcileApplicationFirewalls(_, _ struct{}) {}
@@ -13,7 +13,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -24,6 +28,8 @@ type FirewallRulesSuite struct {
 	backend mockBackend
 
 	blockChecker mockBlockChecker
+	environ      mockEnviron
+	instance     mockInstance
 	authorizer   apiservertesting.FakeAuthorizer
 	api          *firewallrules.API
 }
@@ -32,10 +38,18 @@ var _ = gc.Suite(&FirewallRulesSuite{})
 
 func (s *FirewallRulesSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	s.authorizer.Tag = user
+	s.newAPI(c)
+}
+
+func (s *FirewallRulesSuite) newAPI(c *gc.C) {
 	api, err := firewallrules.NewAPI(
 		&s.backend,
 		s.authorizer,
 		&s.blockChecker,
+		func() (environs.Environ, error) {
+			return &s.environ, nil
+		},
+		context.NewCloudCallContext(),
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
@@ -50,15 +64,59 @@ func (s *FirewallRulesSuite) SetUpTest(c *gc.C) {
 	s.backend = mockBackend{
 		modelUUID: coretesting.ModelTag.Id(),
 		rules:     make(map[string]state.FirewallRule),
+		config:    coretesting.ModelConfig(c),
+		spaceInfos: network.SpaceInfos{{
+			ID:      "1",
+			Name:    "dmz",
+			Subnets: network.SubnetInfos{{CIDR: "192.168.0.0/24"}},
+		}},
+		applications: map[string]*mockApplication{
+			"mysql": {
+				name:    "mysql",
+				exposed: true,
+				exposedEndpoints: map[string]state.ExposedEndpoint{
+					"":         {ExposeToCIDRs: []string{"0.0.0.0/0", "::/0"}},
+					"db-admin": {ExposeToSpaceIDs: []string{"1"}},
+				},
+				machineIds: []string{"0", "0/lxd/0"},
+			},
+			"wordpress": {
+				name:          "wordpress",
+				machineIds:    []string{"0"},
+				relationCIDRs: []string{"10.0.0.0/24"},
+			},
+		},
+		machines: map[string]*mockMachine{
+			"0": {
+				id:         "0",
+				instanceId: "inst-0",
+				openedPortRanges: map[string]network.GroupedPortRanges{
+					"mysql/0": {
+						"":         {network.MustParsePortRange("3306/tcp")},
+						"db-admin": {network.MustParsePortRange("8080/tcp")},
+					},
+					"wordpress/0": {
+						"": {network.MustParsePortRange("80/tcp")},
+					},
+				},
+			},
+			"0/lxd/0": {
+				id:        "0/lxd/0",
+				container: true,
+			},
+		},
 	}
 	s.blockChecker = mockBlockChecker{}
-	api, err := firewallrules.NewAPI(
-		&s.backend,
-		s.authorizer,
-		&s.blockChecker,
-	)
-	c.Assert(err, jc.ErrorIsNil)
-	s.api = api
+	s.instance = mockInstance{
+		rules: firewall.IngressRules{
+			firewall.NewIngressRule(network.MustParsePortRange("22/tcp"), "0.0.0.0/0"),
+			firewall.NewIngressRule(network.MustParsePortRange("3306/tcp"), "0.0.0.0/0"),
+		},
+	}
+	s.environ = mockEnviron{
+		instances: map[instance.Id]*mockInstance{"inst-0": &s.instance},
+	}
+	s.newAPI(c)
 }
 
 func (s *FirewallRulesSuite) TearDownTest(c *gc.C) {
@@ -118,3 +176,111 @@ func (s *FirewallRulesSuite) TestListFirewallRulesPermission(c *gc.C) {
 	_, err := s.api.ListFirewallRules()
 	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
 }
+
+func (s *FirewallRulesSuite) TestApplicationFirewalls(c *gc.C) {
+	result, err := s.api.ApplicationFirewalls(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}, {Tag: "application-foo"}, {Tag: "unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0], jc.DeepEquals, params.ApplicationFirewallResult{
+		Machines: []params.MachineFirewall{{
+			MachineTag: "machine-0",
+			InstanceId: "inst-0",
+			ExpectedRules: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				SourceCIDRs: []string{"10.0.0.0/24"},
+			}, {
+				PortRange:   params.PortRange{FromPort: 3306, ToPort: 3306, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0", "192.168.0.0/24"},
+			}, {
+				PortRange:   params.PortRange{FromPort: 8080, ToPort: 8080, Protocol: "tcp"},
+				SourceCIDRs: []string{"192.168.0.0/24"},
+			}},
+			ProviderRules: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}, {
+				PortRange:   params.PortRange{FromPort: 3306, ToPort: 3306, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}},
+		}},
+	})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "foo" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, "permission denied")
+	s.instance.CheckCall(c, 0, "IngressRules", "0")
+}
+
+func (s *FirewallRulesSuite) TestApplicationFirewallsNotProvisioned(c *gc.C) {
+	s.backend.machines["0"].instanceId = ""
+	result, err := s.api.ApplicationFirewalls(params.Entities{
+		Entities: []params.Entity{{Tag: "application-wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Machines, gc.HasLen, 1)
+	machine := result.Results[0].Machines[0]
+	c.Assert(machine.ExpectedRules, gc.HasLen, 3)
+	c.Assert(machine.ProviderRules, gc.HasLen, 0)
+	c.Assert(machine.Error, gc.ErrorMatches, "machine 0 not provisioned")
+}
+
+func (s *FirewallRulesSuite) TestApplicationFirewallsGlobalMode(c *gc.C) {
+	s.backend.config = coretesting.CustomModelConfig(c, coretesting.Attrs{"firewall-mode": "global"})
+	_, err := s.api.ApplicationFirewalls(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `firewall mode "global" not supported`)
+}
+
+func (s *FirewallRulesSuite) TestApplicationFirewallsPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("mary"))
+	_, err := s.api.ApplicationFirewalls(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+}
+
+func (s *FirewallRulesSuite) TestReconcileApplicationFirewalls(c *gc.C) {
+	result, err := s.api.ReconcileApplicationFirewalls(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{Error: nil}}})
+	s.instance.CheckCallNames(c, "IngressRules", "OpenPorts", "ClosePorts")
+	s.instance.CheckCall(c, 1, "OpenPorts", "0", firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/24"),
+		firewall.NewIngressRule(network.MustParsePortRange("3306/tcp"), "192.168.0.0/24"),
+		firewall.NewIngressRule(network.MustParsePortRange("8080/tcp"), "192.168.0.0/24"),
+	})
+	s.instance.CheckCall(c, 2, "ClosePorts", "0", firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("22/tcp"), "0.0.0.0/0"),
+	})
+}
+
+func (s *FirewallRulesSuite) TestReconcileApplicationFirewallsError(c *gc.C) {
+	s.instance.SetErrors(nil, errors.New("boom"))
+	result, err := s.api.ReconcileApplicationFirewalls(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "opening ports on machine 0: boom")
+}
+
+func (s *FirewallRulesSuite) TestReconcileApplicationFirewallsPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("mary"))
+	_, err := s.api.ReconcileApplicationFirewalls(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+	s.instance.CheckNoCalls(c)
+}
+
+func (s *FirewallRulesSuite) TestReconcileApplicationFirewallsBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.ReconcileApplicationFirewalls(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.instance.CheckNoCalls(c)
+}
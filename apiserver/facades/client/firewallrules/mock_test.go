@@ -4,11 +4,18 @@
 package firewallrules_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jtesting "github.com/juju/testing"
 
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/state"
 )
 
@@ -16,8 +23,12 @@ type mockBackend struct {
 	jtesting.Stub
 	firewallrules.Backend
 
-	modelUUID string
-	rules     map[string]state.FirewallRule
+	modelUUID    string
+	rules        map[string]state.FirewallRule
+	config       *config.Config
+	spaceInfos   network.SpaceInfos
+	applications map[string]*mockApplication
+	machines     map[string]*mockMachine
 }
 
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
@@ -46,6 +57,139 @@ func (m *mockBackend) ListFirewallRules() ([]*state.FirewallRule, error) {
 	return frls, nil
 }
 
+func (m *mockBackend) ModelConfig() (*config.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	return m.config, m.NextErr()
+}
+
+func (m *mockBackend) AllSpaceInfos() (network.SpaceInfos, error) {
+	m.MethodCall(m, "AllSpaceInfos")
+	return m.spaceInfos, m.NextErr()
+}
+
+func (m *mockBackend) Application(name string) (firewallrules.Application, error) {
+	m.MethodCall(m, "Application", name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	app, ok := m.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+func (m *mockBackend) Machine(id string) (firewallrules.Machine, error) {
+	m.MethodCall(m, "Machine", id)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	machine, ok := m.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	return machine, nil
+}
+
+type mockApplication struct {
+	firewallrules.Application
+
+	name             string
+	exposed          bool
+	exposedEndpoints map[string]state.ExposedEndpoint
+	machineIds       []string
+	relationCIDRs    []string
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) IsExposed() bool {
+	return a.exposed
+}
+
+func (a *mockApplication) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	return a.exposedEndpoints
+}
+
+func (a *mockApplication) UnitMachineIds() ([]string, error) {
+	return a.machineIds, nil
+}
+
+func (a *mockApplication) RelationIngressCIDRs() ([]string, error) {
+	return a.relationCIDRs, nil
+}
+
+type mockMachine struct {
+	firewallrules.Machine
+
+	id               string
+	container        bool
+	instanceId       instance.Id
+	openedPortRanges map[string]network.GroupedPortRanges
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) IsContainer() bool {
+	return m.container
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %v", m.id)
+	}
+	return m.instanceId, nil
+}
+
+func (m *mockMachine) OpenedPortRanges() (map[string]network.GroupedPortRanges, error) {
+	return m.openedPortRanges, nil
+}
+
+type mockEnviron struct {
+	environs.Environ
+	jtesting.Stub
+
+	instances map[instance.Id]*mockInstance
+}
+
+func (e *mockEnviron) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
+	e.MethodCall(e, "Instances", ids)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	result := make([]instances.Instance, len(ids))
+	for i, id := range ids {
+		result[i] = e.instances[id]
+	}
+	return result, nil
+}
+
+type mockInstance struct {
+	instances.Instance
+	jtesting.Stub
+
+	rules firewall.IngressRules
+}
+
+func (i *mockInstance) IngressRules(ctx context.ProviderCallContext, machineId string) (firewall.IngressRules, error) {
+	i.MethodCall(i, "IngressRules", machineId)
+	return i.rules, i.NextErr()
+}
+
+func (i *mockInstance) OpenPorts(ctx context.ProviderCallContext, machineId string, rules firewall.IngressRules) error {
+	i.MethodCall(i, "OpenPorts", machineId, rules)
+	return i.NextErr()
+}
+
+func (i *mockInstance) ClosePorts(ctx context.ProviderCallContext, machineId string, rules firewall.IngressRules) error {
+	i.MethodCall(i, "ClosePorts", machineId, rules)
+	return i.NextErr()
+}
+
 type mockBlockChecker struct {
 	jtesting.Stub
 }
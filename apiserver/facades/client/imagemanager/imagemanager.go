@@ -8,9 +8,11 @@ import (
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/imagecommon"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/container/lxd"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/imagestorage"
@@ -22,6 +24,7 @@ var logger = loggo.GetLogger("juju.apiserver.imagemanager")
 type ImageManager interface {
 	ListImages(arg params.ImageFilterParams) (params.ListImageResult, error)
 	DeleteImages(arg params.ImageFilterParams) (params.ErrorResults, error)
+	CacheImages(arg params.ImageFilterParams) (params.ErrorResults, error)
 }
 
 // ImageManagerAPI implements the ImageManager interface and is the concrete
@@ -33,12 +36,29 @@ type ImageManagerAPI struct {
 	check      *common.BlockChecker
 }
 
+// ImageManagerAPIV2 provides the image manager API facade for version 2.
+type ImageManagerAPIV2 struct {
+	*ImageManagerAPI
+}
+
 var _ ImageManager = (*ImageManagerAPI)(nil)
 
 var getState = func(st *state.State) stateInterface {
 	return stateShim{st}
 }
 
+var refreshLXDImage = imagecommon.RefreshLXDImage
+
+// NewImageManagerAPIV2 creates a new server-side imagemanager API end point
+// for version 2 of the facade.
+func NewImageManagerAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ImageManagerAPIV2, error) {
+	api, err := NewImageManagerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ImageManagerAPIV2{api}, nil
+}
+
 // NewImageManagerAPI creates a new server-side imagemanager API end point.
 func NewImageManagerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ImageManagerAPI, error) {
 	// Only clients can access the image manager service.
@@ -134,3 +154,53 @@ func (api *ImageManagerAPI) DeleteImages(arg params.ImageFilterParams) (params.E
 	}
 	return result, nil
 }
+
+// CacheImages fetches the images matching the specified filters into the
+// image storage, replacing any that are already cached, so that they can be
+// served to the model's machines. Only LXD container images are supported.
+func (api *ImageManagerAPI) CacheImages(arg params.ImageFilterParams) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !admin {
+		return result, apiservererrors.ServerError(apiservererrors.ErrPerm)
+	}
+
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	cfg, err := api.state.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ErrorResult, len(arg.Images))
+	stor := api.state.ImageStorage()
+	for i, imageSpec := range arg.Images {
+		if imageSpec.Kind != lxd.ImageCacheKind {
+			result.Results[i].Error = apiservererrors.ServerError(
+				errors.NotSupportedf("caching of %q images", imageSpec.Kind))
+			continue
+		}
+		if imageSpec.Series == "" || imageSpec.Arch == "" {
+			result.Results[i].Error = apiservererrors.ServerError(
+				errors.NotValidf("image without series or architecture"))
+			continue
+		}
+		logger.Infof("caching %s image for %s/%s", imageSpec.Kind, imageSpec.Series, imageSpec.Arch)
+		_, err := refreshLXDImage(stor, api.state.ModelUUID(), cfg, imageSpec.Series, imageSpec.Arch)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// Mask the new methods from the V2 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+
+// CacheImages did not exist prior to v3.
+func (*ImageManagerAPIV2) CacheImages(_, _ struct{}) {}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/imagemanager"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state/imagestorage"
 )
@@ -184,3 +186,59 @@ func (s *imageManagerSuite) TestBlockDeleteImages(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	rdr.Close()
 }
+
+func (s *imageManagerSuite) TestCacheImages(c *gc.C) {
+	var cached []string
+	s.PatchValue(imagemanager.RefreshLXDImage, func(
+		stor imagestorage.Storage, modelUUID string, cfg *config.Config, series, arch string,
+	) (*imagestorage.Metadata, error) {
+		c.Check(modelUUID, gc.Equals, s.State.ModelUUID())
+		c.Check(cfg.ContainerImageStream(), gc.Equals, "released")
+		cached = append(cached, series+"/"+arch)
+		if series == "bionic" {
+			return nil, errors.New("no matching image found")
+		}
+		return &imagestorage.Metadata{Kind: "lxd", Series: series, Arch: arch}, nil
+	})
+
+	args := params.ImageFilterParams{
+		Images: []params.ImageSpec{
+			{Kind: "lxd", Series: "focal", Arch: "amd64"},
+			{Kind: "lxd", Series: "bionic", Arch: "amd64"},
+			{Kind: "kvm", Series: "focal", Arch: "amd64"},
+			{Kind: "lxd", Series: "focal"},
+		},
+	}
+	results, err := s.imagemanager.CacheImages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: nil},
+			{Error: &params.Error{Message: "no matching image found"}},
+			{Error: &params.Error{Message: `caching of "kvm" images not supported`, Code: params.CodeNotSupported}},
+			{Error: &params.Error{Message: "image without series or architecture not valid"}},
+		},
+	})
+	c.Assert(cached, jc.DeepEquals, []string{"focal/amd64", "bionic/amd64"})
+}
+
+func (s *imageManagerSuite) TestCacheImagesRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	anAuthoriser := s.authoriser
+	anAuthoriser.Tag = user.UserTag()
+	endPoint, err := imagemanager.NewImageManagerAPI(s.State, s.resources, anAuthoriser)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endPoint.CacheImages(params.ImageFilterParams{
+		Images: []params.ImageSpec{{Kind: "lxd", Series: "focal", Arch: "amd64"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *imageManagerSuite) TestBlockCacheImages(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockCacheImages")
+	_, err := s.imagemanager.CacheImages(params.ImageFilterParams{
+		Images: []params.ImageSpec{{Kind: "lxd", Series: "focal", Arch: "amd64"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockCacheImages")
+}
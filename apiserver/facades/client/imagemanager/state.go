@@ -4,8 +4,10 @@
 package imagemanager
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/imagestorage"
 )
@@ -13,6 +15,8 @@ import (
 type stateInterface interface {
 	ImageStorage() imagestorage.Storage
 	ControllerTag() names.ControllerTag
+	ModelUUID() string
+	ModelConfig() (*config.Config, error)
 }
 
 type stateShim struct {
@@ -22,3 +26,11 @@ type stateShim struct {
 func (s stateShim) ImageStorage() imagestorage.Storage {
 	return s.State.ImageStorage()
 }
+
+func (s stateShim) ModelConfig() (*config.Config, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return model.ModelConfig()
+}
@@ -4,6 +4,7 @@
 package modelconfig
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
@@ -11,20 +12,32 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
-// NewFacade is used for API registration.
-func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+// NewFacadeV3 is used for API registration.
+func NewFacadeV3(ctx facade.Context) (*ModelConfigAPIV3, error) {
 	auth := ctx.Auth()
 
 	model, err := ctx.State().Model()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return NewModelConfigAPI(NewStateBackend(model), auth)
+	return NewModelConfigAPI(NewStateBackend(model), auth, RegistryCredentialManagerForModel(model))
+}
+
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+	api, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV2{api}, nil
 }
 
 // NewFacadeV1 is used for API registration.
@@ -36,35 +49,73 @@ func NewFacadeV1(ctx facade.Context) (*ModelConfigAPIV1, error) {
 	return &ModelConfigAPIV1{api}, nil
 }
 
+// RegistryCredentialManagerFunc returns the manager of the model's
+// private registry credentials.
+type RegistryCredentialManagerFunc func() (caas.ImageRegistryCredentialManager, error)
+
+// RegistryCredentialManagerForModel returns a RegistryCredentialManagerFunc
+// which opens the broker of the model when the credentials are managed.
+func RegistryCredentialManagerForModel(model *state.Model) RegistryCredentialManagerFunc {
+	return func() (caas.ImageRegistryCredentialManager, error) {
+		if model.Type() != state.ModelTypeCAAS {
+			return nil, errors.NotSupportedf("registry credentials on %s models", model.Type())
+		}
+		broker, err := stateenvirons.GetNewCAASBrokerFunc(caas.New)(model)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting caas client")
+		}
+		manager, ok := broker.(caas.ImageRegistryCredentialManager)
+		if !ok {
+			return nil, errors.NotSupportedf("registry credentials on this cloud")
+		}
+		return manager, nil
+	}
+}
+
+// redactedAttrs are the model config attributes only shown to
+// users who can change the model config.
+var redactedAttrs = set.NewStrings(
+	k8sprovider.ImageRegistryCredentialsKey,
+)
+
 // ModelConfigAPI provides the base implementation of the methods
-// for the V2 and V1 api calls.
+// for the V3, V2 and V1 api calls.
 type ModelConfigAPI struct {
-	backend Backend
-	auth    facade.Authorizer
-	check   *common.BlockChecker
+	backend             Backend
+	auth                facade.Authorizer
+	check               *common.BlockChecker
+	registryCredentials RegistryCredentialManagerFunc
 }
 
-// ModelConfigAPIV2 is currently the latest.
-type ModelConfigAPIV2 struct {
+// ModelConfigAPIV3 is currently the latest.
+type ModelConfigAPIV3 struct {
 	*ModelConfigAPI
 }
 
+// ModelConfigAPIV2 hides V3 functionality
+type ModelConfigAPIV2 struct {
+	*ModelConfigAPIV3
+}
+
 // ModelConfigAPIV1 hides V2 functionality
 type ModelConfigAPIV1 struct {
 	*ModelConfigAPIV2
 }
 
 // NewModelConfigAPI creates a new instance of the ModelConfig Facade.
-func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV2, error) {
+func NewModelConfigAPI(
+	backend Backend, authorizer facade.Authorizer, registryCredentials RegistryCredentialManagerFunc,
+) (*ModelConfigAPIV3, error) {
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
 	}
 	client := &ModelConfigAPI{
-		backend: backend,
-		auth:    authorizer,
-		check:   common.NewBlockChecker(backend),
+		backend:             backend,
+		auth:                authorizer,
+		check:               common.NewBlockChecker(backend),
+		registryCredentials: registryCredentials,
 	}
-	return &ModelConfigAPIV2{client}, nil
+	return &ModelConfigAPIV3{client}, nil
 }
 
 func (c *ModelConfigAPI) checkCanWrite() error {
@@ -117,6 +168,7 @@ func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 		return result, errors.Trace(err)
 	}
 
+	canWrite := c.checkCanWrite() == nil || c.isControllerAdmin() == nil
 	result.Config = make(map[string]params.ConfigValue)
 	for attr, val := range values {
 		// Authorized keys are able to be listed using
@@ -125,6 +177,9 @@ func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 		if attr == config.AuthorizedKeysKey {
 			continue
 		}
+		if !canWrite && redactedAttrs.Contains(attr) {
+			continue
+		}
 		result.Config[attr] = params.ConfigValue{
 			Value:  val.Value,
 			Source: val.Source,
@@ -267,6 +322,61 @@ func (c *ModelConfigAPI) SLALevel() (params.StringResult, error) {
 	return result, nil
 }
 
+// SetImageRegistryCredential adds or replaces the credential the model's
+// workloads use to pull images from a private registry. The credentials
+// are handed to the cloud, and only a reference to them is recorded in
+// the model config.
+func (c *ModelConfigAPI) SetImageRegistryCredential(args params.ImageRegistryCredential) error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	if args.Registry == "" {
+		return errors.NotValidf("empty registry")
+	}
+	if args.Username == "" || args.Password == "" {
+		return errors.NotValidf("missing username or password for %q", args.Registry)
+	}
+	manager, err := c.registryCredentials()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ref, err := manager.SetImageRegistryCredential(args.Registry, args.Username, args.Password)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.setRegistryCredentialsRef(ref)
+}
+
+// RemoveImageRegistryCredential removes the credential the model's
+// workloads use to pull images from a private registry.
+func (c *ModelConfigAPI) RemoveImageRegistryCredential(args params.ImageRegistryCredential) error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	manager, err := c.registryCredentials()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ref, err := manager.RemoveImageRegistryCredential(args.Registry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.setRegistryCredentialsRef(ref)
+}
+
+func (c *ModelConfigAPI) setRegistryCredentialsRef(ref string) error {
+	if ref == "" {
+		return c.backend.UpdateModelConfig(nil, []string{k8sprovider.ImageRegistryCredentialsKey})
+	}
+	return c.backend.UpdateModelConfig(map[string]interface{}{k8sprovider.ImageRegistryCredentialsKey: ref}, nil)
+}
+
 // Sequences returns the model's sequence names and next values.
 func (c *ModelConfigAPI) Sequences() (params.ModelSequencesResult, error) {
 	result := params.ModelSequencesResult{}
@@ -283,6 +393,16 @@ func (c *ModelConfigAPI) Sequences() (params.ModelSequencesResult, error) {
 	return result, nil
 }
 
+// Mask the new methods from the V2 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+
+// SetImageRegistryCredential isn't on the V2 API.
+func (a *ModelConfigAPIV2) SetImageRegistryCredential(_, _ struct{}) {}
+
+// RemoveImageRegistryCredential isn't on the V2 API.
+func (a *ModelConfigAPIV2) RemoveImageRegistryCredential(_, _ struct{}) {}
+
 // Mask the new methods from the V1 API. The API reflection code in
 // rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
 // removes the method as far as the RPC machinery is concerned.
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/dummy"
@@ -24,7 +25,8 @@ type modelconfigSuite struct {
 	gitjujutesting.IsolationSuite
 	backend    *mockBackend
 	authorizer apiservertesting.FakeAuthorizer
-	api        *modelconfig.ModelConfigAPIV2
+	registry   *mockRegistryCredentials
+	api        *modelconfig.ModelConfigAPIV3
 }
 
 var _ = gc.Suite(&modelconfigSuite{})
@@ -44,8 +46,11 @@ func (s *modelconfigSuite) SetUpTest(c *gc.C) {
 			"charm-hub-url":   {"http://meshuggah.rocks", "model"},
 		},
 	}
+	s.registry = &mockRegistryCredentials{creds: make(map[string]string)}
 	var err error
-	s.api, err = modelconfig.NewModelConfigAPI(s.backend, &s.authorizer)
+	s.api, err = modelconfig.NewModelConfigAPI(s.backend, &s.authorizer, func() (caas.ImageRegistryCredentialManager, error) {
+		return s.registry, nil
+	})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -230,6 +235,102 @@ func (s *modelconfigSuite) TestSetSupportCredentals(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelGetRedactsRegistryCredentialsForReadOnlyUsers(c *gc.C) {
+	s.backend.cfg["image-registry-credentials"] = config.ConfigValue{"juju-image-registry-credentials", "model"}
+
+	result, err := s.api.ModelGet()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config["image-registry-credentials"].Value, gc.Equals, "juju-image-registry-credentials")
+
+	s.authorizer.Tag = names.NewUserTag("read")
+	result, err = s.api.ModelGet()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := result.Config["image-registry-credentials"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *modelconfigSuite) TestSetImageRegistryCredential(c *gc.C) {
+	err := s.api.SetImageRegistryCredential(params.ImageRegistryCredential{
+		Registry: "registry.internal",
+		Username: "fred",
+		Password: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.registry.creds, jc.DeepEquals, map[string]string{"registry.internal": "fred:secret"})
+	// Only the reference to the credentials is in the model config.
+	s.assertConfigValue(c, "image-registry-credentials", "juju-image-registry-credentials")
+}
+
+func (s *modelconfigSuite) TestSetImageRegistryCredentialMissingPassword(c *gc.C) {
+	err := s.api.SetImageRegistryCredential(params.ImageRegistryCredential{
+		Registry: "registry.internal",
+		Username: "fred",
+	})
+	c.Assert(err, gc.ErrorMatches, `missing username or password for "registry.internal" not valid`)
+	c.Assert(s.registry.creds, gc.HasLen, 0)
+}
+
+func (s *modelconfigSuite) TestSetImageRegistryCredentialReadOnly(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	err := s.api.SetImageRegistryCredential(params.ImageRegistryCredential{
+		Registry: "registry.internal",
+		Username: "fred",
+		Password: "secret",
+	})
+	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
+	c.Assert(s.registry.creds, gc.HasLen, 0)
+}
+
+func (s *modelconfigSuite) TestBlockSetImageRegistryCredential(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockSetImageRegistryCredential")
+	err := s.api.SetImageRegistryCredential(params.ImageRegistryCredential{
+		Registry: "registry.internal",
+		Username: "fred",
+		Password: "secret",
+	})
+	s.assertBlocked(c, err, "TestBlockSetImageRegistryCredential")
+}
+
+func (s *modelconfigSuite) TestRemoveImageRegistryCredential(c *gc.C) {
+	s.registry.creds["registry.internal"] = "fred:secret"
+	s.registry.creds["quay.io"] = "mary:hidden"
+	s.backend.cfg["image-registry-credentials"] = config.ConfigValue{"juju-image-registry-credentials", "model"}
+
+	err := s.api.RemoveImageRegistryCredential(params.ImageRegistryCredential{Registry: "quay.io"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigValue(c, "image-registry-credentials", "juju-image-registry-credentials")
+
+	err = s.api.RemoveImageRegistryCredential(params.ImageRegistryCredential{Registry: "registry.internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.registry.creds, gc.HasLen, 0)
+	s.assertConfigValueMissing(c, "image-registry-credentials")
+}
+
+func (s *modelconfigSuite) TestRemoveImageRegistryCredentialNotFound(c *gc.C) {
+	err := s.api.RemoveImageRegistryCredential(params.ImageRegistryCredential{Registry: "registry.internal"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type mockRegistryCredentials struct {
+	creds map[string]string
+}
+
+func (m *mockRegistryCredentials) SetImageRegistryCredential(registry, username, password string) (string, error) {
+	m.creds[registry] = username + ":" + password
+	return "juju-image-registry-credentials", nil
+}
+
+func (m *mockRegistryCredentials) RemoveImageRegistryCredential(registry string) (string, error) {
+	if _, ok := m.creds[registry]; !ok {
+		return "", errors.NotFoundf("credentials for registry %q", registry)
+	}
+	delete(m.creds, registry)
+	if len(m.creds) == 0 {
+		return "", nil
+	}
+	return "juju-image-registry-credentials", nil
+}
+
 type mockBackend struct {
 	cfg config.ConfigValues
 	old *config.Config
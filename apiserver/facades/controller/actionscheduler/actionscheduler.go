@@ -0,0 +1,100 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler implements the API used by the
+// action scheduler worker to fire scheduled actions.
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/watcher"
+)
+
+// API implements the API used by the action scheduler worker.
+type API struct {
+	st        State
+	resources facade.Resources
+}
+
+// NewFacade creates a new instance of the ActionScheduler API.
+func NewFacade(ctx facade.Context) (*API, error) {
+	m, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(stateShim{m}, ctx.Resources(), ctx.Auth())
+}
+
+// NewAPI creates a new instance of the ActionScheduler API
+// from the given dependencies.
+func NewAPI(st State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		st:        st,
+		resources: resources,
+	}, nil
+}
+
+// WatchActionSchedules returns a watcher which triggers when
+// action schedules are added, fired or removed.
+func (api *API) WatchActionSchedules() (params.NotifyWatchResult, error) {
+	w := api.st.WatchActionSchedules()
+	if _, ok := <-w.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(w),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: apiservererrors.ServerError(watcher.EnsureErr(w)),
+	}, nil
+}
+
+// ActionSchedules returns all the action schedules in the model.
+func (api *API) ActionSchedules() (params.ActionScheduleResults, error) {
+	schedules, err := api.st.AllActionSchedules()
+	if err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		results.Results[i].Schedule = &params.ActionSchedule{
+			Id:              schedule.Id(),
+			Receiver:        schedule.Receiver(),
+			Name:            schedule.Name(),
+			Parameters:      schedule.Parameters(),
+			Cron:            schedule.Cron(),
+			MissedRunPolicy: string(schedule.MissedRunPolicy()),
+			Created:         schedule.Created(),
+			LastRun:         schedule.LastRun(),
+			LastOperation:   schedule.LastOperation(),
+		}
+	}
+	return results, nil
+}
+
+// FireActionSchedules enqueues an operation for each of the
+// given action schedules.
+func (api *API) FireActionSchedules(args params.FireActionSchedules) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		schedule, err := api.st.ActionSchedule(arg.Id)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if _, _, err := schedule.Fire(arg.Time); err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return results, nil
+}
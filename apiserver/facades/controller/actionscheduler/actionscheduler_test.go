@@ -0,0 +1,150 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionSchedulerSuite struct {
+	coretesting.BaseSuite
+
+	st        *mockState
+	resources *common.Resources
+	api       *actionscheduler.API
+}
+
+var _ = gc.Suite(&ActionSchedulerSuite{})
+
+func (s *ActionSchedulerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.st = &mockState{
+		Stub: &testing.Stub{},
+		schedules: []*mockActionSchedule{{
+			id:       "1",
+			receiver: "mysql",
+			name:     "backup",
+			cron:     "0 3 * * *",
+			created:  time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+
+	var err error
+	s.api, err = actionscheduler.NewAPI(s.st, s.resources, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSchedulerSuite) TestNewAPIRequiresController(c *gc.C) {
+	_, err := actionscheduler.NewAPI(s.st, s.resources, apiservertesting.FakeAuthorizer{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ActionSchedulerSuite) TestWatchActionSchedules(c *gc.C) {
+	result, err := s.api.WatchActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	s.st.CheckCallNames(c, "WatchActionSchedules")
+}
+
+func (s *ActionSchedulerSuite) TestActionSchedules(c *gc.C) {
+	results, err := s.api.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ActionScheduleResults{
+		Results: []params.ActionScheduleResult{{
+			Schedule: &params.ActionSchedule{
+				Id:              "1",
+				Receiver:        "mysql",
+				Name:            "backup",
+				Cron:            "0 3 * * *",
+				MissedRunPolicy: "skip",
+				Created:         time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		}},
+	})
+}
+
+func (s *ActionSchedulerSuite) TestFireActionSchedules(c *gc.C) {
+	now := time.Date(2021, 3, 2, 3, 0, 0, 0, time.UTC)
+	results, err := s.api.FireActionSchedules(params.FireActionSchedules{
+		Schedules: []params.FireActionSchedule{{Id: "1", Time: now}, {Id: "2", Time: now}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `action schedule "2" not found`)
+	c.Assert(s.st.schedules[0].fired, jc.DeepEquals, []time.Time{now})
+}
+
+type mockState struct {
+	*testing.Stub
+	schedules []*mockActionSchedule
+}
+
+func (st *mockState) WatchActionSchedules() state.NotifyWatcher {
+	st.MethodCall(st, "WatchActionSchedules")
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+	return statetesting.NewMockNotifyWatcher(ch)
+}
+
+func (st *mockState) AllActionSchedules() ([]actionscheduler.ActionSchedule, error) {
+	st.MethodCall(st, "AllActionSchedules")
+	results := make([]actionscheduler.ActionSchedule, len(st.schedules))
+	for i, schedule := range st.schedules {
+		results[i] = schedule
+	}
+	return results, st.NextErr()
+}
+
+func (st *mockState) ActionSchedule(id string) (actionscheduler.ActionSchedule, error) {
+	st.MethodCall(st, "ActionSchedule", id)
+	for _, schedule := range st.schedules {
+		if schedule.id == id {
+			return schedule, st.NextErr()
+		}
+	}
+	return nil, errors.NotFoundf("action schedule %q", id)
+}
+
+type mockActionSchedule struct {
+	id, receiver, name, cron string
+	created                  time.Time
+	fired                    []time.Time
+}
+
+func (s *mockActionSchedule) Id() string                         { return s.id }
+func (s *mockActionSchedule) Receiver() string                   { return s.receiver }
+func (s *mockActionSchedule) Name() string                       { return s.name }
+func (s *mockActionSchedule) Parameters() map[string]interface{} { return nil }
+func (s *mockActionSchedule) Cron() string                       { return s.cron }
+func (s *mockActionSchedule) Created() time.Time                 { return s.created }
+func (s *mockActionSchedule) LastRun() time.Time                 { return time.Time{} }
+func (s *mockActionSchedule) LastOperation() string              { return "" }
+
+func (s *mockActionSchedule) MissedRunPolicy() actions.MissedRunPolicy {
+	return actions.MissedRunSkip
+}
+
+func (s *mockActionSchedule) Fire(now time.Time) (string, []state.Action, error) {
+	s.fired = append(s.fired, now)
+	return "1", nil, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state"
+)
+
+// State provides the subset of model state required by the
+// action scheduler facade.
+type State interface {
+	WatchActionSchedules() state.NotifyWatcher
+	AllActionSchedules() ([]ActionSchedule, error)
+	ActionSchedule(id string) (ActionSchedule, error)
+}
+
+// ActionSchedule provides the subset of action schedule
+// functionality required by the action scheduler facade.
+type ActionSchedule interface {
+	Id() string
+	Receiver() string
+	Name() string
+	Parameters() map[string]interface{}
+	Cron() string
+	MissedRunPolicy() actions.MissedRunPolicy
+	Created() time.Time
+	LastRun() time.Time
+	LastOperation() string
+	Fire(now time.Time) (string, []state.Action, error)
+}
+
+type stateShim struct {
+	*state.Model
+}
+
+func (s stateShim) AllActionSchedules() ([]ActionSchedule, error) {
+	schedules, err := s.Model.AllActionSchedules()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]ActionSchedule, len(schedules))
+	for i, schedule := range schedules {
+		results[i] = schedule
+	}
+	return results, nil
+}
+
+func (s stateShim) ActionSchedule(id string) (ActionSchedule, error) {
+	return s.Model.ActionSchedule(id)
+}
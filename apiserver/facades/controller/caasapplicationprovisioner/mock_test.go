@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasapplicationprovisioner"
 	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/resources"
@@ -191,6 +192,10 @@ type mockApplication struct {
 	storageConstraints   map[string]state.StorageConstraints
 	deviceConstraints    map[string]state.DeviceConstraints
 	charmModifiedVersion int
+	config               application.ConfigAttributes
+	configWatcher        *mockNotifyWatcher
+	constraintsWatcher   *mockNotifyWatcher
+	scale                int
 }
 
 func (a *mockApplication) Tag() names.Tag {
@@ -291,6 +296,30 @@ func (a *mockApplication) CharmURL() (curl *charm.URL, force bool) {
 	return a.charm.URL(), false
 }
 
+func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
+	a.MethodCall(a, "ApplicationConfig")
+	return a.config, a.NextErr()
+}
+
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	a.MethodCall(a, "WatchApplicationConfig")
+	return a.configWatcher
+}
+
+func (a *mockApplication) WatchConstraints() state.NotifyWatcher {
+	a.MethodCall(a, "WatchConstraints")
+	return a.constraintsWatcher
+}
+
+func (a *mockApplication) SetScale(scale int, generation int64, force bool) error {
+	a.MethodCall(a, "SetScale", scale, generation, force)
+	if err := a.NextErr(); err != nil {
+		return err
+	}
+	a.scale = scale
+	return nil
+}
+
 type mockCharm struct {
 	meta *charm.Meta
 	url  *charm.URL
@@ -342,6 +371,25 @@ func (w *mockStringsWatcher) Changes() <-chan []string {
 	return w.changes
 }
 
+type mockNotifyWatcher struct {
+	mockWatcher
+	changes chan struct{}
+}
+
+func newMockNotifyWatcher() *mockNotifyWatcher {
+	w := &mockNotifyWatcher{changes: make(chan struct{}, 1)}
+	w.Tomb.Go(func() error {
+		<-w.Tomb.Dying()
+		return nil
+	})
+	return w
+}
+
+func (w *mockNotifyWatcher) Changes() <-chan struct{} {
+	w.MethodCall(w, "Changes")
+	return w.changes
+}
+
 type mockUnit struct {
 	testing.Stub
 	life                state.Life
@@ -38,6 +38,7 @@ import (
 	"github.com/juju/juju/state"
 	stateerrors "github.com/juju/juju/state/errors"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/version"
@@ -54,6 +55,12 @@ type APIGroup struct {
 	*API
 }
 
+// APIGroupV1 is the v1 CAASApplicationProvisioner API, which lacks
+// WatchApplicationConfig and WatchApplicationConstraints.
+type APIGroupV1 struct {
+	*APIGroup
+}
+
 type API struct {
 	auth      facade.Authorizer
 	resources facade.Resources
@@ -119,6 +126,16 @@ func NewStateCAASApplicationProvisionerAPI(ctx facade.Context) (*APIGroup, error
 	return apiGroup, nil
 }
 
+// NewStateCAASApplicationProvisionerAPIV1 provides the signature required
+// for registration of the v1 facade.
+func NewStateCAASApplicationProvisionerAPIV1(ctx facade.Context) (*APIGroupV1, error) {
+	api, err := NewStateCAASApplicationProvisionerAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIGroupV1{api}, nil
+}
+
 // NewCAASApplicationProvisionerAPI returns a new CAAS operator provisioner API facade.
 func NewCAASApplicationProvisionerAPI(
 	ctrlSt CAASApplicationControllerState,
@@ -232,6 +249,14 @@ func (a *API) provisioningInfo(appName names.ApplicationTag) (*params.CAASApplic
 	}
 	caCert, _ := cfg.CACert()
 	charmURL, _ := app.CharmURL()
+	autoscale, err := a.autoscaleParams(app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	availability, err := a.availabilityParams(app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return &params.CAASApplicationProvisioningInfo{
 		ImagePath:            imagePath,
 		Version:              vers,
@@ -245,9 +270,128 @@ func (a *API) provisioningInfo(appName names.ApplicationTag) (*params.CAASApplic
 		ImageRepo:            cfg.CAASImageRepo(),
 		CharmModifiedVersion: app.CharmModifiedVersion(),
 		CharmURL:             charmURL.String(),
+		Autoscale:            autoscale,
+		Availability:         availability,
 	}, nil
 }
 
+// autoscaleParams returns the autoscale policy defined by the
+// application config, or nil if the application is not autoscaled.
+func (a *API) autoscaleParams(app Application) (*params.KubernetesAutoscaleParams, error) {
+	cfg, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	policy, err := caas.AutoscalePolicyFromConfig(cfg)
+	if err != nil || policy == nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.KubernetesAutoscaleParams{
+		MinUnits:     policy.MinUnits,
+		MaxUnits:     policy.MaxUnits,
+		TargetCPU:    policy.TargetCPU,
+		TargetMemory: policy.TargetMemory,
+		Metric:       policy.Metric,
+		MetricTarget: policy.MetricTarget,
+	}, nil
+}
+
+// availabilityParams returns the availability policy defined by the
+// application config, or nil if the application has none.
+func (a *API) availabilityParams(app Application) (*params.KubernetesAvailabilityParams, error) {
+	cfg, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	policy, err := caas.AvailabilityPolicyFromConfig(cfg)
+	if err != nil || policy == nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.KubernetesAvailabilityParams{
+		MaxUnavailableUnits: policy.MaxUnavailable,
+	}
+	for _, t := range policy.SpreadAcross {
+		result.SpreadAcross = append(result.SpreadAcross, string(t))
+	}
+	return result, nil
+}
+
+// WatchApplicationConfig starts a NotifyWatcher to watch changes
+// to the config of each given application.
+func (a *API) WatchApplicationConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := a.watchApplicationConfig(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (a *API) watchApplicationConfig(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := a.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	if _, ok := <-w.Changes(); ok {
+		return a.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// WatchApplicationConstraints starts a NotifyWatcher to watch changes
+// to the constraints of each given application.
+func (a *API) WatchApplicationConstraints(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := a.watchApplicationConstraints(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (a *API) watchApplicationConstraints(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := a.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchConstraints()
+	if _, ok := <-w.Changes(); ok {
+		return a.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// Mask the new methods from the V1 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+
+// WatchApplicationConfig did not exist prior to v2.
+func (*APIGroupV1) WatchApplicationConfig(_, _ struct{}) {}
+
+// WatchApplicationConstraints did not exist prior to v2.
+func (*APIGroupV1) WatchApplicationConstraints(_, _ struct{}) {}
+
 // SetOperatorStatus sets the status of each given entity.
 func (a *API) SetOperatorStatus(args params.SetStatus) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -773,6 +917,14 @@ func (a *API) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs) (pa
 				continue
 			}
 		}
+		if appUpdate.Scale != nil {
+			// The scale of an autoscaled application is decided by the
+			// substrate, so record it rather than fight over it.
+			if err := app.SetScale(*appUpdate.Scale, 0, true); err != nil {
+				result.Results[i].Error = apiservererrors.ServerError(err)
+				continue
+			}
+		}
 		appUnitInfo, err := a.updateUnitsFromCloud(app, appUpdate.Units)
 		if err != nil {
 			// Mask any not found errors as the worker (caller) treats them specially
@@ -22,6 +22,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasapplicationprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
@@ -114,6 +116,112 @@ func (s *CAASApplicationProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 	})
 }
 
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoAutoscale(c *gc.C) {
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url: &charm.URL{
+				Schema:   "cs",
+				Name:     "gitlab",
+				Revision: -1,
+			},
+		},
+		config: application.ConfigAttributes{
+			caas.JujuAutoscaleMinUnitsKey:  2,
+			caas.JujuAutoscaleMaxUnitsKey:  5,
+			caas.JujuAutoscaleTargetCPUKey: 80,
+		},
+	}
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{"application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Autoscale, gc.DeepEquals, &params.KubernetesAutoscaleParams{
+		MinUnits:  2,
+		MaxUnits:  5,
+		TargetCPU: 80,
+	})
+}
+
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoAvailability(c *gc.C) {
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url: &charm.URL{
+				Schema:   "cs",
+				Name:     "gitlab",
+				Revision: -1,
+			},
+		},
+		config: application.ConfigAttributes{
+			caas.JujuMaxUnavailableUnitsKey: 1,
+			caas.JujuSpreadUnitsKey:         "zone,node",
+		},
+	}
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{"application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Availability, gc.DeepEquals, &params.KubernetesAvailabilityParams{
+		MaxUnavailableUnits: 1,
+		SpreadAcross:        []string{"zone", "node"},
+	})
+}
+
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoInvalidAutoscale(c *gc.C) {
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url: &charm.URL{
+				Schema:   "cs",
+				Name:     "gitlab",
+				Revision: -1,
+			},
+		},
+		config: application.ConfigAttributes{
+			caas.JujuAutoscaleMaxUnitsKey: 5,
+		},
+	}
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{"application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "autoscale policy without a target not valid")
+}
+
+func (s *CAASApplicationProvisionerSuite) TestWatchApplicationConfig(c *gc.C) {
+	s.st.app = &mockApplication{
+		life:          state.Alive,
+		configWatcher: newMockNotifyWatcher(),
+	}
+	s.st.app.configWatcher.changes <- struct{}{}
+
+	result, err := s.api.WatchApplicationConfig(params.Entities{Entities: []params.Entity{{"application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].NotifyWatcherId, gc.Equals, "1")
+	s.st.app.CheckCallNames(c, "WatchApplicationConfig")
+
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.app.configWatcher)
+}
+
+func (s *CAASApplicationProvisionerSuite) TestWatchApplicationConstraints(c *gc.C) {
+	s.st.app = &mockApplication{
+		life:               state.Alive,
+		constraintsWatcher: newMockNotifyWatcher(),
+	}
+	s.st.app.constraintsWatcher.changes <- struct{}{}
+
+	result, err := s.api.WatchApplicationConstraints(params.Entities{Entities: []params.Entity{{"application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].NotifyWatcherId, gc.Equals, "1")
+	s.st.app.CheckCallNames(c, "WatchConstraints")
+
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.app.constraintsWatcher)
+}
+
 func (s *CAASApplicationProvisionerSuite) TestSetOperatorStatus(c *gc.C) {
 	s.st.app = &mockApplication{
 		life: state.Alive,
@@ -820,6 +928,25 @@ func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsWithoutStor
 	s.st.model.CheckCall(c, 0, "Containers", []string{"gitlab-0", "gitlab-1"})
 }
 
+func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsAutoscaled(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag:  names.NewApplicationTag("gitlab"),
+		life: state.Alive,
+	}
+
+	scale := 3
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Scale: &scale},
+		},
+	}
+	results, err := s.api.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(s.st.app.scale, gc.Equals, 3)
+	s.st.app.CheckCall(c, 1, "SetScale", 3, int64(0), true)
+}
+
 func strPtr(s string) *string {
 	return &s
 }
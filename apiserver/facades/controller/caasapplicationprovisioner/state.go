@@ -11,6 +11,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -58,6 +59,10 @@ type Application interface {
 	SetStatus(statusInfo status.StatusInfo) error
 	CharmModifiedVersion() int
 	CharmURL() (curl *charm.URL, force bool)
+	ApplicationConfig() (application.ConfigAttributes, error)
+	WatchApplicationConfig() state.NotifyWatcher
+	WatchConstraints() state.NotifyWatcher
+	SetScale(scale int, generation int64, force bool) error
 }
 
 type Charm interface {
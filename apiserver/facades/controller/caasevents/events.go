@@ -0,0 +1,109 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasevents
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.caasevents")
+
+const (
+	// eventNormal is the status recorded for events which
+	// report routine activity.
+	eventNormal status.Status = "normal"
+
+	// eventWarning is the status recorded for events which
+	// report a problem.
+	eventWarning status.Status = "warning"
+)
+
+// Facade records the events the cloud reports for the model's
+// applications and units in their event history.
+type Facade struct {
+	state CAASEventsState
+}
+
+// NewStateFacade provides the signature required for facade registration.
+func NewStateFacade(ctx facade.Context) (*Facade, error) {
+	model, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	caasModel, err := model.CAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newFacade(ctx.Auth(), &stateShim{State: ctx.State(), model: caasModel})
+}
+
+func newFacade(authorizer facade.Authorizer, st CAASEventsState) (*Facade, error) {
+	if !authorizer.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &Facade{state: st}, nil
+}
+
+// RecordEvents records each event in the event history of the unit
+// it's about, or of the application if it's not about a known unit.
+func (f *Facade) RecordEvents(args params.CAASEvents) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Events)),
+	}
+	for i, event := range args.Events {
+		if err := f.recordEvent(event); err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
+func (f *Facade) recordEvent(event params.CAASEvent) error {
+	appTag, err := names.ParseApplicationTag(event.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	entity, err := f.eventEntity(appTag.Id(), event.ProviderId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	eventStatus := eventNormal
+	if event.Warning {
+		eventStatus = eventWarning
+	}
+	return entity.RecordEvent(status.StatusInfo{
+		Status:  eventStatus,
+		Message: fmt.Sprintf("%s: %s", event.Reason, event.Message),
+		Data: map[string]interface{}{
+			"object": event.Object,
+			"reason": event.Reason,
+		},
+		Since: &event.Time,
+	})
+}
+
+// eventEntity returns the unit with the provider id, or the
+// application if there's no such unit yet.
+func (f *Facade) eventEntity(appName, providerId string) (Entity, error) {
+	if providerId != "" {
+		containers, err := f.state.Containers(providerId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(containers) > 0 {
+			return f.state.Unit(containers[0].Unit())
+		}
+		logger.Debugf("no unit of %q with provider id %q, recording event for the application", appName, providerId)
+	}
+	return f.state.Application(appName)
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasevents_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/controller/caasevents"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type eventsSuite struct {
+	coretesting.BaseSuite
+
+	st         *mockState
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *caasevents.Facade
+}
+
+var _ = gc.Suite(&eventsSuite{})
+
+func (s *eventsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.st = &mockState{
+		entities: map[string]*mockEntity{
+			"gitlab":   {},
+			"gitlab/0": {},
+		},
+		containers: map[string]string{"gitlab-0": "gitlab/0"},
+	}
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Controller: true,
+	}
+	facade, err := caasevents.NewFacadeForTest(s.authorizer, s.st)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *eventsSuite) TestPermission(c *gc.C) {
+	s.authorizer = &apiservertesting.FakeAuthorizer{}
+	_, err := caasevents.NewFacadeForTest(s.authorizer, s.st)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *eventsSuite) TestRecordEvents(c *gc.C) {
+	now := time.Now()
+	results, err := s.facade.RecordEvents(params.CAASEvents{
+		Events: []params.CAASEvent{{
+			ApplicationTag: "application-gitlab",
+			ProviderId:     "gitlab-0",
+			Object:         "Pod/gitlab-0",
+			Warning:        true,
+			Reason:         "Failed",
+			Message:        `Failed to pull image "gitlab/gitlab-ce"`,
+			Time:           now,
+		}, {
+			ApplicationTag: "application-gitlab",
+			Object:         "StatefulSet/gitlab",
+			Reason:         "SuccessfulCreate",
+			Message:        "create Pod gitlab-1 in StatefulSet gitlab successful",
+			Time:           now,
+		}, {
+			// A pod without a unit yet.
+			ApplicationTag: "application-gitlab",
+			ProviderId:     "gitlab-1",
+			Object:         "Pod/gitlab-1",
+			Warning:        true,
+			Reason:         "FailedScheduling",
+			Message:        "0/1 nodes are available: 1 Insufficient memory.",
+			Time:           now,
+		}, {
+			ApplicationTag: "application-mysql",
+			Object:         "StatefulSet/mysql",
+			Reason:         "SuccessfulCreate",
+			Time:           now,
+		}, {
+			ApplicationTag: "unit-gitlab-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{},
+			{Error: &params.Error{Code: params.CodeNotFound, Message: `"mysql" not found`}},
+			{Error: &params.Error{Message: `"unit-gitlab-0" is not a valid application tag`}},
+		},
+	})
+	s.st.CheckCallNames(c, "Containers", "Unit", "Application", "Containers", "Application", "Application")
+
+	c.Assert(s.st.entities["gitlab/0"].events, jc.DeepEquals, []status.StatusInfo{{
+		Status:  "warning",
+		Message: `Failed: Failed to pull image "gitlab/gitlab-ce"`,
+		Data:    map[string]interface{}{"object": "Pod/gitlab-0", "reason": "Failed"},
+		Since:   &now,
+	}})
+	c.Assert(s.st.entities["gitlab"].events, jc.DeepEquals, []status.StatusInfo{{
+		Status:  "normal",
+		Message: "SuccessfulCreate: create Pod gitlab-1 in StatefulSet gitlab successful",
+		Data:    map[string]interface{}{"object": "StatefulSet/gitlab", "reason": "SuccessfulCreate"},
+		Since:   &now,
+	}, {
+		Status:  "warning",
+		Message: "FailedScheduling: 0/1 nodes are available: 1 Insufficient memory.",
+		Data:    map[string]interface{}{"object": "Pod/gitlab-1", "reason": "FailedScheduling"},
+		Since:   &now,
+	}})
+}
+
+func (s *eventsSuite) TestRecordEventsError(c *gc.C) {
+	s.st.entities["gitlab"].SetErrors(errors.New("boom"))
+	results, err := s.facade.RecordEvents(params.CAASEvents{
+		Events: []params.CAASEvent{{
+			ApplicationTag: "application-gitlab",
+			Reason:         "Killing",
+			Time:           time.Now(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "boom")
+}
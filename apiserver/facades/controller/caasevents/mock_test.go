@@ -0,0 +1,87 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasevents_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/apiserver/facades/controller/caasevents"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type mockState struct {
+	testing.Stub
+
+	entities   map[string]*mockEntity
+	containers map[string]string
+}
+
+func (st *mockState) entity(name string) (caasevents.Entity, error) {
+	if e, ok := st.entities[name]; ok {
+		return e, nil
+	}
+	return nil, errors.NotFoundf("%q", name)
+}
+
+func (st *mockState) Application(name string) (caasevents.Entity, error) {
+	st.MethodCall(st, "Application", name)
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	return st.entity(name)
+}
+
+func (st *mockState) Unit(name string) (caasevents.Entity, error) {
+	st.MethodCall(st, "Unit", name)
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	return st.entity(name)
+}
+
+func (st *mockState) Containers(providerIds ...string) ([]state.CloudContainer, error) {
+	st.MethodCall(st, "Containers", providerIds)
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	var result []state.CloudContainer
+	for _, id := range providerIds {
+		if unit, ok := st.containers[id]; ok {
+			result = append(result, &mockContainer{unit: unit, providerId: id})
+		}
+	}
+	return result, nil
+}
+
+type mockEntity struct {
+	testing.Stub
+
+	events []status.StatusInfo
+}
+
+func (e *mockEntity) RecordEvent(event status.StatusInfo) error {
+	e.MethodCall(e, "RecordEvent", event)
+	if err := e.NextErr(); err != nil {
+		return err
+	}
+	e.events = append(e.events, event)
+	return nil
+}
+
+type mockContainer struct {
+	state.CloudContainer
+
+	unit       string
+	providerId string
+}
+
+func (c *mockContainer) Unit() string {
+	return c.unit
+}
+
+func (c *mockContainer) ProviderId() string {
+	return c.providerId
+}
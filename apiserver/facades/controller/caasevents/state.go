@@ -0,0 +1,40 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasevents
+
+import (
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// CAASEventsState provides the subset of global state
+// required by the CAAS events facade.
+type CAASEventsState interface {
+	Application(string) (Entity, error)
+	Unit(string) (Entity, error)
+	Containers(providerIds ...string) ([]state.CloudContainer, error)
+}
+
+// Entity provides the subset of application and unit state
+// required by the CAAS events facade.
+type Entity interface {
+	RecordEvent(status.StatusInfo) error
+}
+
+type stateShim struct {
+	*state.State
+	model *state.CAASModel
+}
+
+func (s *stateShim) Application(name string) (Entity, error) {
+	return s.State.Application(name)
+}
+
+func (s *stateShim) Unit(name string) (Entity, error) {
+	return s.State.Unit(name)
+}
+
+func (s *stateShim) Containers(providerIds ...string) ([]state.CloudContainer, error) {
+	return s.model.Containers(providerIds...)
+}
@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -12,6 +13,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
@@ -121,10 +123,17 @@ func (f *Facade) getApplicationConfig(tagString string) (map[string]interface{},
 type FacadeEmbedded struct {
 	*Facade
 	*charmscommon.CharmsAPI
+	*common.ModelWatcher
 
 	accessModel common.GetAuthFunc
 }
 
+// FacadeEmbeddedV1 is the v1 CAASFirewallerEmbedded API, which lacks
+// the network policy, exposed ingress and model config methods.
+type FacadeEmbeddedV1 struct {
+	*FacadeEmbedded
+}
+
 // NewStateFacadeEmbedded provides the signature required for facade registration.
 func NewStateFacadeEmbedded(ctx facade.Context) (*FacadeEmbedded, error) {
 	authorizer := ctx.Auth()
@@ -133,20 +142,36 @@ func NewStateFacadeEmbedded(ctx facade.Context) (*FacadeEmbedded, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	model, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	appWatcherFacade := common.NewApplicationWatcherFacadeFromState(ctx.State(), resources, common.ApplicationFilterCAASEmbedded)
 	return newFacadeEmbedded(
 		resources,
 		authorizer,
 		&stateShim{ctx.State()},
+		model,
 		appWatcherFacade,
 		commonCharmsAPI,
 	)
 }
 
+// NewStateFacadeEmbeddedV1 provides the signature required for
+// registration of the v1 facade.
+func NewStateFacadeEmbeddedV1(ctx facade.Context) (*FacadeEmbeddedV1, error) {
+	api, err := NewStateFacadeEmbedded(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeEmbeddedV1{api}, nil
+}
+
 func newFacadeEmbedded(
 	resources facade.Resources,
 	authorizer facade.Authorizer,
 	st CAASFirewallerState,
+	model state.ModelAccessor,
 	applicationWatcherFacade *common.ApplicationWatcherFacade,
 	commonCharmsAPI *charmscommon.CharmsAPI,
 ) (*FacadeEmbedded, error) {
@@ -156,8 +181,9 @@ func newFacadeEmbedded(
 	accessApplication := common.AuthFuncForTagKind(names.ApplicationTagKind)
 
 	return &FacadeEmbedded{
-		CharmsAPI:   commonCharmsAPI,
-		accessModel: common.AuthFuncForTagKind(names.ModelTagKind),
+		CharmsAPI:    commonCharmsAPI,
+		ModelWatcher: common.NewModelWatcher(model, resources, authorizer),
+		accessModel:  common.AuthFuncForTagKind(names.ModelTagKind),
 		Facade: &Facade{
 			LifeGetter: common.NewLifeGetter(
 				st, common.AuthAny(
@@ -237,6 +263,132 @@ func (f *FacadeEmbedded) ApplicationCharmURLs(args params.Entities) (params.Stri
 	return res, nil
 }
 
+// RelatedApplications returns the names of the applications in the
+// model related to each of the given applications.
+func (f *FacadeEmbedded) RelatedApplications(args params.Entities) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		app, err := f.application(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		related, err := app.RelatedApplications()
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = related
+	}
+	return results, nil
+}
+
+// ExposedCIDRs returns the CIDRs which may access each of the given
+// applications. Unexposed applications have no CIDRs.
+func (f *FacadeEmbedded) ExposedCIDRs(args params.Entities) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		app, err := f.application(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !app.IsExposed() {
+			continue
+		}
+		cidrs := set.NewStrings()
+		for _, exposed := range app.ExposedEndpoints() {
+			cidrs = cidrs.Union(set.NewStrings(exposed.ExposeToCIDRs...))
+		}
+		results.Results[i].Result = cidrs.SortedValues()
+	}
+	return results, nil
+}
+
+// ExposedIngress returns the ingress used to expose each of the given
+// applications. Applications exposed without an ingress have no result.
+func (f *FacadeEmbedded) ExposedIngress(args params.Entities) (params.ExposedIngressResults, error) {
+	results := params.ExposedIngressResults{
+		Results: make([]params.ExposedIngressResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		app, err := f.application(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		ingress := app.ExposedIngress()
+		if !app.IsExposed() || ingress == nil {
+			continue
+		}
+		results.Results[i].Result = &params.ExposedIngress{
+			Hostname:  ingress.Hostname,
+			Path:      ingress.Path,
+			TLSSecret: ingress.TLSSecret,
+			Class:     ingress.Class,
+		}
+	}
+	return results, nil
+}
+
+// WatchRelations returns a new StringsWatcher for the relations of each
+// of the given applications.
+func (f *FacadeEmbedded) WatchRelations(args params.Entities) (params.StringsWatchResults, error) {
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		app, err := f.application(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		w := app.WatchRelations()
+		// Consume the initial event and forward it to the result.
+		if changes, ok := <-w.Changes(); ok {
+			results.Results[i].StringsWatcherId = f.resources.Register(w)
+			results.Results[i].Changes = changes
+		} else {
+			results.Results[i].Error = apiservererrors.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return results, nil
+}
+
+// Mask the new methods from the V1 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+
+// RelatedApplications did not exist prior to v2.
+func (*FacadeEmbeddedV1) RelatedApplications(_, _ struct{}) {}
+
+// ExposedCIDRs did not exist prior to v2.
+func (*FacadeEmbeddedV1) ExposedCIDRs(_, _ struct{}) {}
+
+// ExposedIngress did not exist prior to v2.
+func (*FacadeEmbeddedV1) ExposedIngress(_, _ struct{}) {}
+
+// WatchRelations did not exist prior to v2.
+func (*FacadeEmbeddedV1) WatchRelations(_, _ struct{}) {}
+
+// WatchForModelConfigChanges did not exist prior to v2.
+func (*FacadeEmbeddedV1) WatchForModelConfigChanges(_, _ struct{}) {}
+
+// ModelConfig did not exist prior to v2.
+func (*FacadeEmbeddedV1) ModelConfig(_, _ struct{}) {}
+
+func (f *FacadeEmbedded) application(tagString string) (Application, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.state.Application(tag.Id())
+}
+
 func (f *FacadeEmbedded) watchOneModelOpenedPorts(tag names.Tag) (string, []string, error) {
 	// NOTE: tag is ignored, as there is only one model in the
 	// state DB. Once this changes, change the code below accordingly.
@@ -84,7 +84,7 @@ var _ = gc.Suite(&firewallerEmbeddedSuite{
 			commonCharmsAPI, err := charmscommon.NewCharmsAPI(st, authorizer)
 			c.Assert(err, jc.ErrorIsNil)
 			return caasfirewaller.NewFacadeEmbeddedForTest(
-				resources, authorizer, st,
+				resources, authorizer, st, st,
 				common.NewApplicationWatcherFacade(firewallerStateToAppWatcherState(st), resources, common.ApplicationFilterCAASEmbedded),
 				commonCharmsAPI,
 			)
@@ -140,6 +140,113 @@ func (s *firewallerEmbeddedSuite) TestApplicationCharmURLs(c *gc.C) {
 	c.Assert(result.Result, gc.Equals, "cs:gitlab")
 }
 
+func (s *firewallerEmbeddedSuite) TestRelatedApplications(c *gc.C) {
+	s.st.application.related = []string{"mariadb", "redis"}
+	results, err := s.facade.RelatedApplications(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"mariadb", "redis"},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+}
+
+func (s *firewallerEmbeddedSuite) TestExposedCIDRs(c *gc.C) {
+	s.st.application.exposed = true
+	s.st.application.exposedEndpoints = map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"website": {
+			ExposeToSpaceIDs: []string{"42"},
+			ExposeToCIDRs:    []string{"10.0.0.0/24", "192.168.0.0/24"},
+		},
+	}
+	results, err := s.facade.ExposedCIDRs(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"10.0.0.0/24", "192.168.0.0/24"},
+		}},
+	})
+}
+
+func (s *firewallerEmbeddedSuite) TestExposedCIDRsNotExposed(c *gc.C) {
+	s.st.application.exposedEndpoints = map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	}
+	results, err := s.facade.ExposedCIDRs(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{}},
+	})
+}
+
+func (s *firewallerEmbeddedSuite) TestExposedIngress(c *gc.C) {
+	s.st.application.exposed = true
+	s.st.application.exposedIngress = &state.ExposedIngress{
+		Hostname:  "gitlab.example.com",
+		Path:      "/",
+		TLSSecret: "gitlab-tls",
+		Class:     "nginx",
+	}
+	results, err := s.facade.ExposedIngress(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ExposedIngressResults{
+		Results: []params.ExposedIngressResult{{
+			Result: &params.ExposedIngress{
+				Hostname:  "gitlab.example.com",
+				Path:      "/",
+				TLSSecret: "gitlab-tls",
+				Class:     "nginx",
+			},
+		}},
+	})
+}
+
+func (s *firewallerEmbeddedSuite) TestExposedIngressNotExposed(c *gc.C) {
+	s.st.application.exposedIngress = &state.ExposedIngress{
+		Hostname: "gitlab.example.com",
+	}
+	results, err := s.facade.ExposedIngress(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ExposedIngressResults{
+		Results: []params.ExposedIngressResult{{}},
+	})
+}
+
+func (s *firewallerEmbeddedSuite) TestWatchRelations(c *gc.C) {
+	relationsChanges := make(chan []string, 1)
+	s.st.application.relationsWatcher = statetesting.NewMockStringsWatcher(relationsChanges)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.relationsWatcher) })
+	relationsChanges <- []string{"gitlab:db mariadb:db"}
+
+	results, err := s.facade.WatchRelations(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Changes, jc.DeepEquals, []string{"gitlab:db mariadb:db"})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.st.application.relationsWatcher)
+}
+
 type facadeCommon interface {
 	IsExposed(args params.Entities) (params.BoolResults, error)
 	ApplicationsConfig(args params.Entities) (params.ApplicationGetConfigResults, error)
@@ -152,6 +259,10 @@ type facadeEmbedded interface {
 	facadeCommon
 	WatchOpenedPorts(args params.Entities) (params.StringsWatchResults, error)
 	ApplicationCharmURLs(args params.Entities) (params.StringResults, error)
+	RelatedApplications(args params.Entities) (params.StringsResults, error)
+	ExposedCIDRs(args params.Entities) (params.StringsResults, error)
+	ExposedIngress(args params.Entities) (params.ExposedIngressResults, error)
+	WatchRelations(args params.Entities) (params.StringsWatchResults, error)
 }
 
 func (s *firewallerBaseSuite) SetUpTest(c *gc.C) {
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -21,6 +22,17 @@ type mockState struct {
 	applicationsWatcher *statetesting.MockStringsWatcher
 	openPortsWatcher    *statetesting.MockStringsWatcher
 	appExposedWatcher   *statetesting.MockNotifyWatcher
+	modelConfigWatcher  *statetesting.MockNotifyWatcher
+}
+
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	st.MethodCall(st, "ModelConfig")
+	return nil, st.NextErr()
+}
+
+func (st *mockState) WatchForModelConfigChanges() state.NotifyWatcher {
+	st.MethodCall(st, "WatchForModelConfigChanges")
+	return st.modelConfigWatcher
 }
 
 func (st *mockState) WatchApplications() state.StringsWatcher {
@@ -67,9 +79,13 @@ func (st *mockState) Model() (*state.Model, error) {
 
 type mockApplication struct {
 	testing.Stub
-	life    state.Life
-	exposed bool
-	watcher state.NotifyWatcher
+	life             state.Life
+	exposed          bool
+	exposedEndpoints map[string]state.ExposedEndpoint
+	exposedIngress   *state.ExposedIngress
+	related          []string
+	watcher          state.NotifyWatcher
+	relationsWatcher *statetesting.MockStringsWatcher
 
 	charm mockAppWatcherCharm
 }
@@ -88,6 +104,26 @@ func (a *mockApplication) IsExposed() bool {
 	return a.exposed
 }
 
+func (a *mockApplication) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	a.MethodCall(a, "ExposedEndpoints")
+	return a.exposedEndpoints
+}
+
+func (a *mockApplication) ExposedIngress() *state.ExposedIngress {
+	a.MethodCall(a, "ExposedIngress")
+	return a.exposedIngress
+}
+
+func (a *mockApplication) RelatedApplications() ([]string, error) {
+	a.MethodCall(a, "RelatedApplications")
+	return a.related, a.NextErr()
+}
+
+func (a *mockApplication) WatchRelations() state.StringsWatcher {
+	a.MethodCall(a, "WatchRelations")
+	return a.relationsWatcher
+}
+
 func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig")
 	return application.ConfigAttributes{"foo": "bar"}, a.NextErr()
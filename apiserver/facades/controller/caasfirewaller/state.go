@@ -5,6 +5,8 @@ package caasfirewaller
 
 import (
 	"github.com/juju/charm/v9"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/core/application"
@@ -25,8 +27,12 @@ type CAASFirewallerState interface {
 // required by the CAAS operator facade.
 type Application interface {
 	IsExposed() bool
+	ExposedEndpoints() map[string]state.ExposedEndpoint
+	ExposedIngress() *state.ExposedIngress
 	ApplicationConfig() (application.ConfigAttributes, error)
 	Watch() state.NotifyWatcher
+	WatchRelations() state.StringsWatcher
+	RelatedApplications() ([]string, error)
 	Charm() (ch Charm, force bool, err error)
 }
 
@@ -54,3 +60,26 @@ type applicationShim struct {
 func (a *applicationShim) Charm() (Charm, bool, error) {
 	return a.Application.Charm()
 }
+
+// RelatedApplications returns the names of the applications in the
+// model which are related to the application.
+func (a *applicationShim) RelatedApplications() ([]string, error) {
+	rels, err := a.Application.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	related := set.NewStrings()
+	for _, rel := range rels {
+		if _, isCrossModel, err := rel.RemoteApplication(); err != nil {
+			return nil, errors.Trace(err)
+		} else if isCrossModel {
+			continue
+		}
+		for _, ep := range rel.Endpoints() {
+			if ep.ApplicationName != a.Name() {
+				related.Add(ep.ApplicationName)
+			}
+		}
+	}
+	return related.SortedValues(), nil
+}
@@ -5,6 +5,7 @@ package instancepoller
 
 import (
 	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -13,6 +14,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
@@ -360,6 +362,15 @@ func (a *InstancePollerAPI) SetInstanceStatus(args params.SetStatus) (params.Err
 	}
 	for i, arg := range args.Entities {
 		machine, err := a.getOneMachine(arg.Tag, canAccess)
+		if err == nil {
+			// Replace the machine before recording that its instance
+			// has gone, so that the instance poller tries again if
+			// the replacement fails.
+			switch instStatus := status.Status(arg.Status); instStatus {
+			case status.Interrupted, status.Terminated:
+				err = a.maybeReplaceMachine(machine, instStatus)
+			}
+		}
 		if err == nil {
 			now := a.clock.Now()
 			s := status.StatusInfo{
@@ -381,6 +392,72 @@ func (a *InstancePollerAPI) SetInstanceStatus(args params.SetStatus) (params.Err
 	return result, nil
 }
 
+// maybeReplaceMachine replaces the given machine, whose instance has
+// been lost or whose spot or preemptible instance has been reclaimed by
+// the cloud, if the model or all of the applications with units on the
+// machine are configured to do so. Machines which cannot be replaced,
+// such as controllers and container hosts, are left alone.
+func (a *InstancePollerAPI) maybeReplaceMachine(machine StateMachine, instStatus status.Status) error {
+	if machine.Life() != state.Alive {
+		// The machine is already being replaced or removed.
+		return nil
+	}
+	cfg, err := a.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	replace := cfg.ReplaceLostMachines() ||
+		(instStatus == status.Interrupted && cfg.ReplaceInterruptedMachines())
+	if !replace {
+		if replace, err = a.applicationsReplaceMachine(machine); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if !replace {
+		return nil
+	}
+	id := machine.Tag().Id()
+	if err := machine.CanReplace(); errors.IsNotSupported(err) {
+		logger.Warningf("instance of machine %s %s, not replacing: %v", id, instStatus, err)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	replacement, err := a.st.ReplaceMachine(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("instance of machine %s %s, replaced by machine %s", id, instStatus, replacement.Tag().Id())
+	return nil
+}
+
+// applicationsReplaceMachine reports whether the machine hosts principal
+// units and each of their applications has opted in to machine
+// replacement.
+func (a *InstancePollerAPI) applicationsReplaceMachine(machine StateMachine) (bool, error) {
+	appNames := set.NewStrings()
+	for _, unitName := range machine.Principals() {
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		appNames.Add(appName)
+	}
+	if appNames.IsEmpty() {
+		return false, nil
+	}
+	for _, appName := range appNames.SortedValues() {
+		appConfig, err := a.st.ApplicationConfig(appName)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if !appConfig.GetBool(application.ReplaceLostMachinesConfigOptionName, false) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // AreManuallyProvisioned returns whether each given entity is
 // manually provisioned or not. Only machine tags are accepted.
 func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params.BoolResults, error) {
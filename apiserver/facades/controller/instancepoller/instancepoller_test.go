@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -693,6 +694,184 @@ func (s *InstancePollerSuite) TestSetInstanceStatusFailure(c *gc.C) {
 	s.st.CheckMachineCall(c, 3, "3")
 }
 
+func (s *InstancePollerSuite) TestSetInstanceStatusInterrupted(c *gc.C) {
+	s.st.SetConfig(c, jujutesting.ModelConfig(c))
+	s.st.SetMachineInfo(c, machineInfo{id: "1", life: state.Alive})
+
+	result, err := s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "interrupted", Info: "instance preempted"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+
+	// Neither the model nor any application opts in to replacement.
+	s.st.CheckCallNames(c, "Machine", "Life", "ModelConfig", "Principals", "SetInstanceStatus")
+}
+
+func (s *InstancePollerSuite) TestSetInstanceStatusInterruptedReplacesMachine(c *gc.C) {
+	modelConfig, err := jujutesting.ModelConfig(c).Apply(map[string]interface{}{
+		"replace-interrupted-machines": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.SetConfig(c, modelConfig)
+	s.st.SetMachineInfo(c, machineInfo{id: "1", life: state.Alive})
+
+	args := params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "interrupted", Info: "instance preempted"},
+		}}
+	result, err := s.api.SetInstanceStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+
+	s.st.CheckCallNames(c, "Machine", "Life", "ModelConfig", "CanReplace", "ReplaceMachine", "SetInstanceStatus")
+	s.st.CheckCall(c, 4, "ReplaceMachine", "1")
+
+	// Once replaced, the machine is not replaced again.
+	s.st.ResetCalls()
+	result, err = s.api.SetInstanceStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+	s.st.CheckCallNames(c, "Machine", "Life", "SetInstanceStatus")
+}
+
+func (s *InstancePollerSuite) TestSetInstanceStatusTerminatedReplacesMachine(c *gc.C) {
+	modelConfig, err := jujutesting.ModelConfig(c).Apply(map[string]interface{}{
+		"replace-lost-machines": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.SetConfig(c, modelConfig)
+	s.st.SetMachineInfo(c, machineInfo{id: "1", life: state.Alive})
+
+	result, err := s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "terminated", Info: "instance not found by the provider"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+
+	s.st.CheckCallNames(c, "Machine", "Life", "ModelConfig", "CanReplace", "ReplaceMachine", "SetInstanceStatus")
+	s.st.CheckCall(c, 4, "ReplaceMachine", "1")
+}
+
+func (s *InstancePollerSuite) TestSetInstanceStatusTerminatedControllerNotReplaced(c *gc.C) {
+	s.assertSetInstanceStatusTerminatedNotReplaced(c, machineInfo{
+		id:           "1",
+		life:         state.Alive,
+		isController: true,
+	})
+}
+
+func (s *InstancePollerSuite) TestSetInstanceStatusTerminatedContainerHostNotReplaced(c *gc.C) {
+	s.assertSetInstanceStatusTerminatedNotReplaced(c, machineInfo{
+		id:         "1",
+		life:       state.Alive,
+		containers: []string{"1/lxd/0"},
+	})
+}
+
+func (s *InstancePollerSuite) TestSetInstanceStatusTerminatedManualMachineNotReplaced(c *gc.C) {
+	s.assertSetInstanceStatusTerminatedNotReplaced(c, machineInfo{
+		id:       "1",
+		life:     state.Alive,
+		isManual: true,
+	})
+}
+
+func (s *InstancePollerSuite) assertSetInstanceStatusTerminatedNotReplaced(c *gc.C, info machineInfo) {
+	modelConfig, err := jujutesting.ModelConfig(c).Apply(map[string]interface{}{
+		"replace-lost-machines": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.SetConfig(c, modelConfig)
+	s.st.SetMachineInfo(c, info)
+
+	result, err := s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "terminated", Info: "instance not found by the provider"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+
+	// The machine can't be replaced, but its instance status is still
+	// recorded so the instance poller doesn't try again.
+	now := s.clock.Now()
+	s.st.CheckCallNames(c, "Machine", "Life", "ModelConfig", "CanReplace", "SetInstanceStatus")
+	s.st.CheckCall(c, 4, "SetInstanceStatus", status.StatusInfo{
+		Status:  status.Terminated,
+		Message: "instance not found by the provider",
+		Since:   &now,
+	})
+}
+
+func (s *InstancePollerSuite) TestSetInstanceStatusReplaceMachineFailure(c *gc.C) {
+	modelConfig, err := jujutesting.ModelConfig(c).Apply(map[string]interface{}{
+		"replace-lost-machines": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.SetConfig(c, modelConfig)
+	s.st.SetMachineInfo(c, machineInfo{id: "1", life: state.Alive})
+	s.st.SetErrors(
+		nil,                // Machine("1")
+		nil,                // Life()
+		nil,                // ModelConfig()
+		nil,                // CanReplace()
+		errors.New("boom"), // ReplaceMachine("1")
+	)
+
+	result, err := s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "terminated", Info: "instance not found by the provider"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{Error: apiservertesting.ServerError("boom")},
+	}})
+
+	// The instance status isn't recorded, so the replacement is
+	// tried again when the instance is next polled.
+	s.st.CheckCallNames(c, "Machine", "Life", "ModelConfig", "CanReplace", "ReplaceMachine")
+}
+
+func (s *InstancePollerSuite) TestSetInstanceStatusTerminatedApplicationsOptIn(c *gc.C) {
+	s.st.SetConfig(c, jujutesting.ModelConfig(c))
+	s.st.SetMachineInfo(c, machineInfo{
+		id:         "1",
+		life:       state.Alive,
+		principals: []string{"mysql/0", "wordpress/0", "wordpress/1"},
+	})
+	s.st.SetApplicationConfig("mysql", application.ConfigAttributes{"replace-lost-machines": true})
+	s.st.SetApplicationConfig("wordpress", application.ConfigAttributes{"replace-lost-machines": false})
+
+	args := params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "terminated", Info: "instance not found by the provider"},
+		}}
+	result, err := s.api.SetInstanceStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+
+	// Not every application on the machine opts in.
+	s.st.CheckCallNames(c, "Machine", "Life", "ModelConfig", "Principals",
+		"ApplicationConfig", "ApplicationConfig", "SetInstanceStatus")
+	s.st.CheckCall(c, 4, "ApplicationConfig", "mysql")
+	s.st.CheckCall(c, 5, "ApplicationConfig", "wordpress")
+
+	s.st.SetApplicationConfig("wordpress", application.ConfigAttributes{"replace-lost-machines": true})
+	s.st.ResetCalls()
+	result, err = s.api.SetInstanceStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+	s.st.CheckCallNames(c, "Machine", "Life", "ModelConfig", "Principals",
+		"ApplicationConfig", "ApplicationConfig", "CanReplace", "ReplaceMachine", "SetInstanceStatus")
+	s.st.CheckCall(c, 7, "ReplaceMachine", "1")
+}
+
 func (s *InstancePollerSuite) TestAreManuallyProvisionedSuccess(c *gc.C) {
 	s.st.SetMachineInfo(c, machineInfo{id: "1", isManual: true})
 	s.st.SetMachineInfo(c, machineInfo{id: "2", isManual: false})
@@ -7,7 +7,9 @@
 package instancepoller_test
 
 import (
+	"fmt"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -37,8 +40,9 @@ type mockState struct {
 	configWatchers   []*mockConfigWatcher
 	machinesWatchers []*mockMachinesWatcher
 
-	config   *config.Config
-	machines map[string]*mockMachine
+	config    *config.Config
+	machines  map[string]*mockMachine
+	appConfig map[string]application.ConfigAttributes
 
 	spaceInfos network.SpaceInfos
 }
@@ -234,6 +238,63 @@ func (m *mockState) Machine(id string) (instancepoller.StateMachine, error) {
 	return machine, nil
 }
 
+// ReplaceMachine implements StateInterface.
+func (m *mockState) ReplaceMachine(id string) (instancepoller.StateMachine, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "ReplaceMachine", id)
+
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	machine, found := m.machines[id]
+	if !found {
+		return nil, errors.NotFoundf("machine %s", id)
+	}
+	machine.life = state.Dying
+
+	newId := strconv.Itoa(len(m.machines))
+	for _, found := m.machines[newId]; found; _, found = m.machines[newId] {
+		newId += "0"
+	}
+	replacement := &mockMachine{
+		Stub:        m.Stub,
+		machineInfo: machineInfo{id: newId},
+	}
+	m.machines[newId] = replacement
+	return replacement, nil
+}
+
+// ApplicationConfig implements StateInterface.
+func (m *mockState) ApplicationConfig(name string) (application.ConfigAttributes, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "ApplicationConfig", name)
+
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	appConfig, found := m.appConfig[name]
+	if !found {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return appConfig, nil
+}
+
+// SetApplicationConfig records the configuration of the named
+// application.
+func (m *mockState) SetApplicationConfig(name string, appConfig application.ConfigAttributes) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.appConfig == nil {
+		m.appConfig = make(map[string]application.ConfigAttributes)
+	}
+	m.appConfig[name] = appConfig
+}
+
 // AllSpaceInfos implements network.AllSpaceInfos.
 // This method never throws an error.
 func (m *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
@@ -271,6 +332,9 @@ type machineInfo struct {
 	providerAddresses []network.SpaceAddress
 	life              state.Life
 	isManual          bool
+	isController      bool
+	containers        []string
+	principals        []string
 
 	linkLayerDevices []networkingcommon.LinkLayerDevice
 	addresses        []networkingcommon.LinkLayerAddress
@@ -296,6 +360,14 @@ func (m *mockMachine) Id() string {
 	return m.id
 }
 
+// Tag implements StateMachine.
+func (m *mockMachine) Tag() names.Tag {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return names.NewMachineTag(m.id)
+}
+
 // InstanceId implements StateMachine.
 func (m *mockMachine) InstanceId() (instance.Id, error) {
 	m.mu.Lock()
@@ -394,6 +466,15 @@ func (m *mockMachine) Life() state.Life {
 	return m.life
 }
 
+// Principals implements StateMachine.
+func (m *mockMachine) Principals() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "Principals")
+	return m.principals
+}
+
 // IsManual implements StateMachine.
 func (m *mockMachine) IsManual() (bool, error) {
 	m.mu.Lock()
@@ -403,6 +484,27 @@ func (m *mockMachine) IsManual() (bool, error) {
 	return m.isManual, m.NextErr()
 }
 
+// CanReplace implements StateMachine.
+func (m *mockMachine) CanReplace() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "CanReplace")
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	if m.isController {
+		return errors.NewNotSupported(nil, "machine is a controller")
+	}
+	if m.isManual {
+		return errors.NewNotSupported(nil, "machine is manually provisioned")
+	}
+	if len(m.containers) > 0 {
+		return errors.NewNotSupported(nil, fmt.Sprintf("machine hosts containers %v", m.containers))
+	}
+	return nil
+}
+
 // Status implements StateMachine.
 func (m *mockMachine) Status() (status.StatusInfo, error) {
 	m.mu.Lock()
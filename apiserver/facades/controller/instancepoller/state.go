@@ -5,6 +5,7 @@ package instancepoller
 
 import (
 	"github.com/juju/juju/apiserver/common/networkingcommon"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -27,6 +28,8 @@ type StateMachine interface {
 	Life() state.Life
 	Status() (status.StatusInfo, error)
 	IsManual() (bool, error)
+	Principals() []string
+	CanReplace() error
 }
 
 type StateInterface interface {
@@ -37,6 +40,14 @@ type StateInterface interface {
 
 	Machine(id string) (StateMachine, error)
 
+	// ReplaceMachine adds a machine, with new units of the same
+	// applications, to take over from the machine with the given id.
+	ReplaceMachine(id string) (StateMachine, error)
+
+	// ApplicationConfig returns the configuration of the named
+	// application.
+	ApplicationConfig(name string) (application.ConfigAttributes, error)
+
 	// ApplyOperation applies a given ModelOperation to the model.
 	ApplyOperation(state.ModelOperation) error
 }
@@ -89,6 +100,23 @@ func (s stateShim) Machine(id string) (StateMachine, error) {
 	return machineShim{m}, nil
 }
 
+func (s stateShim) ReplaceMachine(id string) (StateMachine, error) {
+	m, err := s.State.ReplaceMachine(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return machineShim{m}, nil
+}
+
+func (s stateShim) ApplicationConfig(name string) (application.ConfigAttributes, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, err
+	}
+	return app.ApplicationConfig()
+}
+
 var getState = func(st *state.State, m *state.Model) StateInterface {
 	return stateShim{st, m}
 }
@@ -0,0 +1,108 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common/imagecommon"
+	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/imagestorage"
+)
+
+var cacheLXDImage = imagecommon.CacheLXDImage
+
+// imagesDownloadHandler handles container image download through HTTPS
+// in the API server, serving the images cached in the model's image storage
+// to the machine agents of the model.
+type imagesDownloadHandler struct {
+	ctxt httpContext
+}
+
+func (h *imagesDownloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	st, _, err := h.ctxt.stateForRequestAuthenticatedTag(r, names.MachineTagKind)
+	if err != nil {
+		if err := sendError(w, err); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+	defer st.Release()
+
+	switch r.Method {
+	case "GET":
+		metadata, reader, err := h.getImageForRequest(r, st.State)
+		if err != nil {
+			logger.Errorf("GET(%s) failed: %v", r.URL, err)
+			if err := sendError(w, err); err != nil {
+				logger.Errorf("%v", err)
+			}
+			return
+		}
+		defer reader.Close()
+		if err := h.sendImage(w, reader, metadata); err != nil {
+			logger.Errorf("%v", err)
+		}
+	default:
+		if err := sendError(w, errors.MethodNotAllowedf("unsupported method: %q", r.Method)); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+}
+
+// getImageForRequest retrieves the image archive from the image storage,
+// based on the input HTTP request.
+// Images that are not yet cached, or that have been superseded in the
+// image sources of the model, are fetched and stored for subsequent
+// requests.
+func (h *imagesDownloadHandler) getImageForRequest(
+	r *http.Request, st *state.State,
+) (*imagestorage.Metadata, io.ReadCloser, error) {
+	query := r.URL.Query()
+	kind, series, arch := query.Get(":kind"), query.Get(":series"), query.Get(":arch")
+	if kind != lxd.ImageCacheKind {
+		return nil, nil, errors.BadRequestf("unsupported image kind %q", kind)
+	}
+	logger.Debugf("request for %s image: %s/%s", kind, series, arch)
+
+	stor := st.ImageStorage()
+	model, err := st.Model()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	// Reject images Juju does not support before the cache is consulted,
+	// so that requests cannot make the controller fetch arbitrary images.
+	if err := imagecommon.ValidateLXDImage(cfg, series, arch); err != nil {
+		return nil, nil, errors.NewBadRequest(err, "")
+	}
+	if _, err := cacheLXDImage(stor, model.UUID(), cfg, series, arch); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	metadata, reader, err := stor.Image(kind, series, arch)
+	return metadata, reader, errors.Trace(err)
+}
+
+// sendImage streams the image archive to the client.
+func (h *imagesDownloadHandler) sendImage(w http.ResponseWriter, reader io.Reader, metadata *imagestorage.Metadata) error {
+	logger.Tracef("sending %d bytes", metadata.Size)
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Length", strconv.FormatInt(metadata.Size, 10))
+
+	if _, err := io.Copy(w, reader); err != nil {
+		// Having begun writing, it is too late to send an error response here.
+		return errors.Annotatef(err, "failed to send image")
+	}
+	return nil
+}
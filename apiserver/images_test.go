@@ -0,0 +1,137 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/params"
+	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state/imagestorage"
+	"github.com/juju/juju/testing/factory"
+)
+
+type imagesSuite struct {
+	apiserverBaseSuite
+
+	machineTag string
+	password   string
+}
+
+var _ = gc.Suite(&imagesSuite{})
+
+const imagesNonce = "fake_nonce"
+
+func (s *imagesSuite) SetUpTest(c *gc.C) {
+	s.apiserverBaseSuite.SetUpTest(c)
+	machine, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Nonce: imagesNonce,
+	})
+	s.machineTag = machine.Tag().String()
+	s.password = password
+}
+
+func (s *imagesSuite) imageURL(kind, series, arch string) string {
+	return s.URL(fmt.Sprintf("/model/%s/images/%s/%s/%s", s.State.ModelUUID(), kind, series, arch), nil).String()
+}
+
+// sendImageRequest requests an image as the model's machine agent.
+func (s *imagesSuite) sendImageRequest(c *gc.C, kind, series, arch string) *http.Response {
+	return apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Tag:      s.machineTag,
+		Password: s.password,
+		Nonce:    imagesNonce,
+		Method:   "GET",
+		URL:      s.imageURL(kind, series, arch),
+	})
+}
+
+func (s *imagesSuite) patchCacheLXDImage(c *gc.C) *[]string {
+	var cached []string
+	s.PatchValue(apiserver.CacheLXDImage, func(
+		stor imagestorage.Storage, modelUUID string, cfg *config.Config, series, arch string,
+	) (*imagestorage.Metadata, error) {
+		c.Check(modelUUID, gc.Equals, s.State.ModelUUID())
+		cached = append(cached, series+"/"+arch)
+		return nil, nil
+	})
+	return &cached
+}
+
+func (s *imagesSuite) TestDownloadCachedImage(c *gc.C) {
+	cached := s.patchCacheLXDImage(c)
+	err := s.State.ImageStorage().AddImage(strings.NewReader("archive"), &imagestorage.Metadata{
+		ModelUUID: s.State.ModelUUID(),
+		Kind:      "lxd",
+		Series:    "focal",
+		Arch:      "amd64",
+		Size:      7,
+		SHA256:    "0eb3e36bfb24dcd9bb1d1bece1531216b59539a8fde17ee80224af0653c92aa3",
+		SourceURL: "https://cloud-images.ubuntu.com/releases",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.sendImageRequest(c, "lxd", "focal", "amd64")
+	body := apitesting.AssertResponse(c, resp, http.StatusOK, "application/x-tar")
+	c.Assert(string(body), gc.Equals, "archive")
+	// The cache is consulted so that superseded images are refreshed.
+	c.Assert(*cached, jc.DeepEquals, []string{"focal/amd64"})
+}
+
+func (s *imagesSuite) TestDownloadUnsupportedKind(c *gc.C) {
+	resp := s.sendImageRequest(c, "kvm", "focal", "amd64")
+	body := apitesting.AssertResponse(c, resp, http.StatusBadRequest, params.ContentTypeJSON)
+	c.Assert(string(body), jc.Contains, `unsupported image kind \"kvm\"`)
+}
+
+func (s *imagesSuite) TestDownloadInvalidImage(c *gc.C) {
+	cached := s.patchCacheLXDImage(c)
+
+	resp := s.sendImageRequest(c, "lxd", "warty", "amd64")
+	body := apitesting.AssertResponse(c, resp, http.StatusBadRequest, params.ContentTypeJSON)
+	c.Assert(string(body), jc.Contains, `series \"warty\" not valid`)
+
+	resp = s.sendImageRequest(c, "lxd", "focal", "z80")
+	body = apitesting.AssertResponse(c, resp, http.StatusBadRequest, params.ContentTypeJSON)
+	c.Assert(string(body), jc.Contains, `architecture \"z80\" not valid`)
+
+	// Invalid images are rejected before the cache is consulted.
+	c.Assert(*cached, gc.HasLen, 0)
+}
+
+func (s *imagesSuite) TestDownloadRequiresAuthentication(c *gc.C) {
+	cached := s.patchCacheLXDImage(c)
+
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.imageURL("lxd", "focal", "amd64"),
+	})
+	defer resp.Body.Close()
+
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusUnauthorized)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(body), gc.Equals, "authentication failed: no credentials provided\n")
+	c.Assert(*cached, gc.HasLen, 0)
+}
+
+func (s *imagesSuite) TestDownloadRequiresMachineAgent(c *gc.C) {
+	cached := s.patchCacheLXDImage(c)
+
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.imageURL("lxd", "focal", "amd64"),
+	})
+	body := apitesting.AssertResponse(c, resp, http.StatusInternalServerError, params.ContentTypeJSON)
+	c.Assert(string(body), jc.Contains, "tag kind user not valid")
+	c.Assert(*cached, gc.HasLen, 0)
+}
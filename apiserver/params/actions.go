@@ -40,6 +40,24 @@ type Actions struct {
 	Actions []Action `json:"actions,omitempty"`
 }
 
+// RollingActions describes actions to be run as an operation
+// whose tasks are started a few at a time.
+type RollingActions struct {
+	Actions []Action `json:"actions"`
+
+	// MaxParallel is the maximum number of tasks which
+	// may run at once, or 0 if there is no limit.
+	MaxParallel int `json:"max-parallel,omitempty"`
+
+	// StopOnFailure is the number of failed tasks after which
+	// no more tasks are started, or 0 if there is no limit.
+	StopOnFailure int `json:"stop-on-failure,omitempty"`
+
+	// LeaderOrder is "first" or "last" to run the task on an
+	// application's leader on its own before or after the rest.
+	LeaderOrder string `json:"leader-order,omitempty"`
+}
+
 // Action describes an Action that will be or has been queued up.
 type Action struct {
 	Tag            string                 `json:"tag"`
@@ -113,6 +131,7 @@ type OperationQueryArgs struct {
 	Machines     []string `json:"machines,omitempty"`
 	ActionNames  []string `json:"actions,omitempty"`
 	Status       []string `json:"status,omitempty"`
+	Schedules    []string `json:"schedules,omitempty"`
 
 	// These attributes are used to support client side
 	// batching of results.
@@ -128,14 +147,17 @@ type OperationResults struct {
 
 // OperationResult describes an Operation that will be or has been completed.
 type OperationResult struct {
-	OperationTag string         `json:"operation"`
-	Summary      string         `json:"summary"`
-	Enqueued     time.Time      `json:"enqueued,omitempty"`
-	Started      time.Time      `json:"started,omitempty"`
-	Completed    time.Time      `json:"completed,omitempty"`
-	Status       string         `json:"status,omitempty"`
-	Actions      []ActionResult `json:"actions,omitempty"`
-	Error        *Error         `json:"error,omitempty"`
+	OperationTag  string         `json:"operation"`
+	Summary       string         `json:"summary"`
+	Enqueued      time.Time      `json:"enqueued,omitempty"`
+	Started       time.Time      `json:"started,omitempty"`
+	Completed     time.Time      `json:"completed,omitempty"`
+	Status        string         `json:"status,omitempty"`
+	ScheduleId    string         `json:"schedule-id,omitempty"`
+	MaxParallel   int            `json:"max-parallel,omitempty"`
+	StopOnFailure int            `json:"stop-on-failure,omitempty"`
+	Actions       []ActionResult `json:"actions,omitempty"`
+	Error         *Error         `json:"error,omitempty"`
 }
 
 // ActionExecutionResults holds a slice of ActionExecutionResult for a
@@ -186,3 +208,75 @@ type ActionPruneArgs struct {
 type ActionMessageParams struct {
 	Messages []EntityString `json:"messages"`
 }
+
+// ActionOutputParams holds the arguments for
+// logging chunks of output written by some actions.
+type ActionOutputParams struct {
+	Output []ActionOutput `json:"output"`
+}
+
+// ActionOutput holds a chunk of output written by an action
+// to either its "stdout" or "stderr" stream.
+type ActionOutput struct {
+	Tag    string `json:"tag"`
+	Stream string `json:"stream"`
+	Data   string `json:"data"`
+}
+
+// ActionScheduleArgs holds the arguments for adding action schedules.
+type ActionScheduleArgs struct {
+	Schedules []AddActionSchedule `json:"schedules"`
+}
+
+// AddActionSchedule describes an action to be run each time the cron
+// expression is due. The receiver is an application name, unit name
+// or <application>/leader.
+type AddActionSchedule struct {
+	Receiver        string                 `json:"receiver"`
+	Name            string                 `json:"name"`
+	Parameters      map[string]interface{} `json:"parameters,omitempty"`
+	Cron            string                 `json:"cron"`
+	MissedRunPolicy string                 `json:"missed-run-policy,omitempty"`
+}
+
+// ActionScheduleIds holds the ids of action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ActionSchedule describes a stored action schedule.
+type ActionSchedule struct {
+	Id              string                 `json:"id"`
+	Receiver        string                 `json:"receiver"`
+	Name            string                 `json:"name"`
+	Parameters      map[string]interface{} `json:"parameters,omitempty"`
+	Cron            string                 `json:"cron"`
+	MissedRunPolicy string                 `json:"missed-run-policy"`
+	Created         time.Time              `json:"created"`
+	LastRun         time.Time              `json:"last-run,omitempty"`
+	LastOperation   string                 `json:"last-operation,omitempty"`
+}
+
+// ActionScheduleResults holds a slice of ActionScheduleResult
+// for bulk requests.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results,omitempty"`
+}
+
+// ActionScheduleResult holds an action schedule or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// FireActionSchedules holds the arguments for firing action schedules.
+type FireActionSchedules struct {
+	Schedules []FireActionSchedule `json:"schedules"`
+}
+
+// FireActionSchedule identifies an action schedule
+// to fire and the time it is fired at.
+type FireActionSchedule struct {
+	Id   string    `json:"id"`
+	Time time.Time `json:"time"`
+}
@@ -268,6 +268,30 @@ type ApplicationExpose struct {
 	// with pre 2.9 clients, if this field is empty, all opened ports
 	// for the application will be exposed to 0.0.0.0/0.
 	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+
+	// Ingress, if set, exposes a k8s application through an ingress
+	// resource routing Hostname to the application's opened ports.
+	Ingress *ExposedIngress `json:"ingress,omitempty"`
+}
+
+// ExposedIngress describes the ingress used to expose a k8s application.
+type ExposedIngress struct {
+	Hostname  string `json:"hostname"`
+	Path      string `json:"path,omitempty"`
+	TLSSecret string `json:"tls-secret,omitempty"`
+	Class     string `json:"class,omitempty"`
+}
+
+// ExposedIngressResult holds the ingress used to expose an application,
+// or an error.
+type ExposedIngressResult struct {
+	Result *ExposedIngress `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// ExposedIngressResults holds the results of an ExposedIngress call.
+type ExposedIngressResults struct {
+	Results []ExposedIngressResult `json:"results"`
 }
 
 // ExposedEndpoint describes the spaces and/or CIDRs that should be able to
@@ -579,6 +603,19 @@ type ApplicationResult struct {
 	Remote           bool                       `json:"remote"`
 	EndpointBindings map[string]string          `json:"endpoint-bindings,omitempty"`
 	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+	Availability     *ApplicationAvailability   `json:"availability,omitempty"`
+}
+
+// ApplicationAvailability holds the availability policy of a caas
+// application and the status of its disruption budget. Unknown is set
+// when the status of the budget could not be retrieved.
+type ApplicationAvailability struct {
+	MaxUnavailableUnits int      `json:"max-unavailable-units,omitempty"`
+	SpreadAcross        []string `json:"spread-across,omitempty"`
+	DisruptionsAllowed  int      `json:"disruptions-allowed"`
+	CurrentHealthy      int      `json:"current-healthy"`
+	DesiredHealthy      int      `json:"desired-healthy"`
+	Unknown             bool     `json:"unknown,omitempty"`
 }
 
 // ApplicationInfoResults holds an application info result or a retrieval error.
@@ -4,6 +4,8 @@
 package params
 
 import (
+	"time"
+
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/version"
 )
@@ -34,20 +36,40 @@ type CAASApplicationProvisioningInfoResults struct {
 
 // CAASApplicationProvisioningInfo holds info needed to provision a caas application.
 type CAASApplicationProvisioningInfo struct {
-	ImagePath            string                       `json:"image-path"`
-	Version              version.Number               `json:"version"`
-	APIAddresses         []string                     `json:"api-addresses"`
-	CACert               string                       `json:"ca-cert"`
-	Constraints          constraints.Value            `json:"constraints"`
-	Tags                 map[string]string            `json:"tags,omitempty"`
-	Filesystems          []KubernetesFilesystemParams `json:"filesystems,omitempty"`
-	Volumes              []KubernetesVolumeParams     `json:"volumes,omitempty"`
-	Devices              []KubernetesDeviceParams     `json:"devices,omitempty"`
-	Series               string                       `json:"series,omitempty"`
-	ImageRepo            string                       `json:"image-repo,omitempty"`
-	CharmModifiedVersion int                          `json:"charm-modified-version,omitempty"`
-	CharmURL             string                       `json:"charm-url,omitempty"`
-	Error                *Error                       `json:"error,omitempty"`
+	ImagePath            string                        `json:"image-path"`
+	Version              version.Number                `json:"version"`
+	APIAddresses         []string                      `json:"api-addresses"`
+	CACert               string                        `json:"ca-cert"`
+	Constraints          constraints.Value             `json:"constraints"`
+	Tags                 map[string]string             `json:"tags,omitempty"`
+	Filesystems          []KubernetesFilesystemParams  `json:"filesystems,omitempty"`
+	Volumes              []KubernetesVolumeParams      `json:"volumes,omitempty"`
+	Devices              []KubernetesDeviceParams      `json:"devices,omitempty"`
+	Series               string                        `json:"series,omitempty"`
+	ImageRepo            string                        `json:"image-repo,omitempty"`
+	CharmModifiedVersion int                           `json:"charm-modified-version,omitempty"`
+	CharmURL             string                        `json:"charm-url,omitempty"`
+	Autoscale            *KubernetesAutoscaleParams    `json:"autoscale,omitempty"`
+	Availability         *KubernetesAvailabilityParams `json:"availability,omitempty"`
+	Error                *Error                        `json:"error,omitempty"`
+}
+
+// KubernetesAutoscaleParams holds the policy used to scale a caas
+// application horizontally.
+type KubernetesAutoscaleParams struct {
+	MinUnits     int    `json:"min-units"`
+	MaxUnits     int    `json:"max-units"`
+	TargetCPU    int    `json:"target-cpu,omitempty"`
+	TargetMemory int    `json:"target-memory,omitempty"`
+	Metric       string `json:"metric,omitempty"`
+	MetricTarget string `json:"metric-target,omitempty"`
+}
+
+// KubernetesAvailabilityParams holds the policy used to keep a caas
+// application available during voluntary disruptions.
+type KubernetesAvailabilityParams struct {
+	MaxUnavailableUnits int      `json:"max-unavailable-units,omitempty"`
+	SpreadAcross        []string `json:"spread-across,omitempty"`
 }
 
 // CAASApplicationGarbageCollectArg holds info needed to cleanup units that have
@@ -93,3 +115,21 @@ type CAASApplicationOCIResourceResult struct {
 type CAASApplicationOCIResources struct {
 	Images map[string]DockerImageInfo `json:"images"`
 }
+
+// CAASEvent holds an event the cloud reported for an application
+// or one of its units.
+type CAASEvent struct {
+	ApplicationTag string    `json:"application-tag"`
+	ProviderId     string    `json:"provider-id,omitempty"`
+	Object         string    `json:"object"`
+	Warning        bool      `json:"warning,omitempty"`
+	Reason         string    `json:"reason"`
+	Message        string    `json:"message"`
+	Time           time.Time `json:"time"`
+}
+
+// CAASEvents holds the events to record for the model's applications
+// and units.
+type CAASEvents struct {
+	Events []CAASEvent `json:"events"`
+}
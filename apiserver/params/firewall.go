@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/errors"
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/network/firewall"
+)
 
 // FirewallRuleArgs holds the parameters for updating
 // one or more firewall rules.
@@ -60,3 +64,67 @@ func (v KnownServiceValue) Validate() error {
 	}
 	return errors.NotValidf("known service %q", v)
 }
+
+// IngressRule represents a rule allowing access to a port range from a
+// set of source CIDRs.
+type IngressRule struct {
+	PortRange   PortRange `json:"port-range"`
+	SourceCIDRs []string  `json:"source-cidrs"`
+}
+
+// FromIngressRules is a convenience helper to create parameters out of
+// the firewall type, here for IngressRules.
+func FromIngressRules(rules firewall.IngressRules) []IngressRule {
+	result := make([]IngressRule, len(rules))
+	for i, rule := range rules {
+		result[i] = IngressRule{
+			PortRange:   FromNetworkPortRange(rule.PortRange),
+			SourceCIDRs: rule.SourceCIDRs.SortedValues(),
+		}
+	}
+	return result
+}
+
+// ToIngressRules is a convenience helper to return the parameters as
+// the firewall type, here for IngressRules.
+func ToIngressRules(rules []IngressRule) firewall.IngressRules {
+	result := make(firewall.IngressRules, len(rules))
+	for i, rule := range rules {
+		result[i] = firewall.NewIngressRule(rule.PortRange.NetworkPortRange(), rule.SourceCIDRs...)
+	}
+	return result
+}
+
+// ApplicationFirewallResults holds the firewall rules of the machines
+// hosting a number of applications.
+type ApplicationFirewallResults struct {
+	Results []ApplicationFirewallResult `json:"results"`
+}
+
+// ApplicationFirewallResult holds the firewall rules of the machines
+// hosting the units of an application.
+type ApplicationFirewallResult struct {
+	// Machines holds the firewall rules of each machine.
+	Machines []MachineFirewall `json:"machines,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// MachineFirewall holds the ingress rules that juju expects to be
+// applied to a machine, along with those reported by the provider.
+type MachineFirewall struct {
+	// MachineTag is the tag of the machine.
+	MachineTag string `json:"machine-tag"`
+
+	// InstanceId is the provider id of the machine's instance.
+	InstanceId string `json:"instance-id,omitempty"`
+
+	// ExpectedRules are the ingress rules required by the units
+	// deployed to the machine.
+	ExpectedRules []IngressRule `json:"expected-rules"`
+
+	// ProviderRules are the ingress rules applied by the provider.
+	ProviderRules []IngressRule `json:"provider-rules"`
+
+	Error *Error `json:"error,omitempty"`
+}
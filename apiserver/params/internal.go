@@ -975,6 +975,7 @@ type UnitRefreshResult struct {
 	Resolved   ResolvedMode
 	Error      *Error
 	ProviderID string `json:"provider-id,omitempty"`
+	Paused     bool   `json:"paused,omitempty"`
 }
 
 // UnitRefreshResults holds the results for any API call which ends
@@ -93,6 +93,15 @@ type ModelSLA struct {
 	Credentials []byte `json:"creds"`
 }
 
+// ImageRegistryCredential contains the arguments for the
+// SetImageRegistryCredential and RemoveImageRegistryCredential
+// client API calls.
+type ImageRegistryCredential struct {
+	Registry string `json:"registry"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
 // SetModelDefaults contains the arguments for SetModelDefaults
 // client API call.
 type SetModelDefaults struct {
@@ -72,6 +72,7 @@ var commonModelFacadeNames = set.NewStrings(
 var caasModelFacadeNames = set.NewStrings(
 	"CAASAdmission",
 	"CAASAgent",
+	"CAASEvents",
 	"CAASFirewaller",
 	"CAASModelOperator",
 	"CAASOperator",
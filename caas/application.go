@@ -4,12 +4,14 @@
 package caas
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/version"
 
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/storage"
 )
 
@@ -26,6 +28,8 @@ type Application interface {
 	Units() ([]Unit, error)
 
 	ServiceInterface
+	NetworkPolicyInterface
+	IngressInterface
 }
 
 // ServicePort represents service ports mapping from service to units.
@@ -50,10 +54,81 @@ type ServiceInterface interface {
 	UpdatePorts(ports []ServicePort, updateContainerPorts bool) error
 }
 
+// NetworkPolicy describes the ingress allowed to the units of an
+// application.
+type NetworkPolicy struct {
+	// RelatedApplications are the applications whose units may
+	// connect to the application's units.
+	RelatedApplications []string
+	// IngressCIDRs are the address ranges which may connect to
+	// the application's units.
+	IngressCIDRs []string
+}
+
+// NetworkPoliciesKey is the model config attribute used to enable
+// network policies which only allow ingress to each application
+// from related applications and exposed CIDRs.
+const NetworkPoliciesKey = "network-policies"
+
+// NetworkPoliciesEnabled returns whether the model config enables
+// network policies for applications.
+func NetworkPoliciesEnabled(cfg *config.Config) bool {
+	enabled, _ := cfg.AllAttrs()[NetworkPoliciesKey].(bool)
+	return enabled
+}
+
+// NetworkPolicyInterface provides the API to restrict ingress to an
+// application.
+type NetworkPolicyInterface interface {
+	// UpdateNetworkPolicy restricts ingress to the application's units
+	// to that allowed by the policy. A nil policy removes any restriction.
+	UpdateNetworkPolicy(*NetworkPolicy) error
+}
+
+// Ingress describes how an application is exposed outside of the cluster.
+type Ingress struct {
+	// Hostname is the external hostname routed to the application.
+	Hostname string
+	// Path is the HTTP path routed to the application.
+	Path string
+	// TLSSecret is the name of the secret holding the TLS certificate
+	// for Hostname. No TLS is configured when empty.
+	TLSSecret string
+	// Class is the ingress class used to provision the ingress. The
+	// cluster default is used when empty.
+	Class string
+}
+
+// IngressInterface provides the API to expose an application through
+// an ingress.
+type IngressInterface interface {
+	// UpdateIngress routes the ingress to the application's opened
+	// ports. A nil ingress removes any ingress.
+	UpdateIngress(*Ingress) error
+}
+
 // ApplicationState represents the application state.
 type ApplicationState struct {
 	DesiredReplicas int
 	Replicas        []string
+	// Autoscaled is true when the replica count of the application
+	// is managed by an autoscaler rather than by Juju.
+	Autoscaled bool
+	// DisruptionBudget is the status of the application's disruption
+	// budget, or nil if it has none.
+	DisruptionBudget *DisruptionBudgetStatus
+}
+
+// DisruptionBudgetStatus represents the status of the budget limiting
+// the voluntary disruption of an application's units.
+type DisruptionBudgetStatus struct {
+	// DisruptionsAllowed is the number of units which may currently
+	// be disrupted.
+	DisruptionsAllowed int
+	// CurrentHealthy is the number of healthy units.
+	CurrentHealthy int
+	// DesiredHealthy is the minimum number of healthy units required.
+	DesiredHealthy int
 }
 
 // ApplicationConfig is the config passed to the application units.
@@ -96,6 +171,87 @@ type ApplicationConfig struct {
 
 	// Devices is a set of parameters for Devices that is required.
 	Devices []devices.KubernetesDeviceParams
+
+	// Autoscale is the policy used to scale the application
+	// horizontally, or nil if the application is not autoscaled.
+	Autoscale *AutoscalePolicy
+
+	// Availability is the policy used to keep the application's units
+	// available, or nil if there is none.
+	Availability *AvailabilityPolicy
+}
+
+// AutoscalePolicy describes how an application is scaled horizontally
+// by the substrate.
+type AutoscalePolicy struct {
+	// MinUnits is the minimum number of units to scale down to.
+	MinUnits int
+	// MaxUnits is the maximum number of units to scale up to.
+	MaxUnits int
+
+	// TargetCPU is the target average CPU utilisation, as a percentage.
+	TargetCPU int
+	// TargetMemory is the target average memory utilisation, as a percentage.
+	TargetMemory int
+
+	// Metric is the name of a custom per unit metric to scale on.
+	Metric string
+	// MetricTarget is the target average value of the custom metric.
+	MetricTarget string
+}
+
+// Validate returns an error if the policy is not valid.
+func (p AutoscalePolicy) Validate() error {
+	if p.MinUnits < 1 {
+		return errors.NotValidf("autoscale min units %d", p.MinUnits)
+	}
+	if p.MaxUnits < p.MinUnits {
+		return errors.NotValidf("autoscale max units %d less than min units %d", p.MaxUnits, p.MinUnits)
+	}
+	if p.TargetCPU < 0 || p.TargetMemory < 0 {
+		return errors.NotValidf("negative autoscale target")
+	}
+	if p.TargetCPU == 0 && p.TargetMemory == 0 && p.Metric == "" {
+		return errors.NotValidf("autoscale policy without a target")
+	}
+	return nil
+}
+
+// Topology is a failure domain the units of an application can be
+// spread across.
+type Topology string
+
+const (
+	// TopologyNode spreads units across nodes.
+	TopologyNode Topology = "node"
+	// TopologyZone spreads units across availability zones.
+	TopologyZone Topology = "zone"
+)
+
+// AvailabilityPolicy describes how the units of an application are kept
+// available while the substrate is disrupted, such as when nodes are
+// drained.
+type AvailabilityPolicy struct {
+	// MaxUnavailable is the maximum number of units which may be
+	// unavailable at once during voluntary disruptions, or 0 for no limit.
+	MaxUnavailable int
+	// SpreadAcross are the topologies units are spread evenly across.
+	SpreadAcross []Topology
+}
+
+// Validate returns an error if the policy is not valid.
+func (p AvailabilityPolicy) Validate() error {
+	if p.MaxUnavailable < 0 {
+		return errors.NotValidf("max unavailable units %d", p.MaxUnavailable)
+	}
+	for _, t := range p.SpreadAcross {
+		switch t {
+		case TopologyNode, TopologyZone:
+		default:
+			return errors.NotValidf("spread topology %q", t)
+		}
+	}
+	return nil
 }
 
 // ContainerConfig describes a container that is deployed alonside the uniter/charm container.
@@ -0,0 +1,25 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/testing"
+)
+
+type applicationSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&applicationSuite{})
+
+func (s *applicationSuite) TestNetworkPoliciesEnabled(c *gc.C) {
+	c.Assert(caas.NetworkPoliciesEnabled(testing.ModelConfig(c)), jc.IsFalse)
+	c.Assert(caas.NetworkPoliciesEnabled(testing.CustomModelConfig(c, testing.Attrs{
+		caas.NetworkPoliciesKey: true,
+	})), jc.IsTrue)
+}
@@ -4,6 +4,9 @@
 package caas
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
@@ -18,6 +21,36 @@ const (
 
 	// JujuDefaultApplicationPath is the default value for juju-application-path.
 	JujuDefaultApplicationPath = "/"
+
+	// JujuAutoscaleMinUnitsKey specifies the minimum number of units
+	// an autoscaled application is scaled down to.
+	JujuAutoscaleMinUnitsKey = "juju-autoscale-min-units"
+
+	// JujuAutoscaleMaxUnitsKey specifies the maximum number of units
+	// an autoscaled application is scaled up to. Autoscaling is only
+	// enabled when this is set.
+	JujuAutoscaleMaxUnitsKey = "juju-autoscale-max-units"
+
+	// JujuAutoscaleTargetCPUKey specifies the target average CPU
+	// utilisation, as a percentage of the requested CPU.
+	JujuAutoscaleTargetCPUKey = "juju-autoscale-target-cpu"
+
+	// JujuAutoscaleTargetMemoryKey specifies the target average memory
+	// utilisation, as a percentage of the requested memory.
+	JujuAutoscaleTargetMemoryKey = "juju-autoscale-target-memory"
+
+	// JujuAutoscaleMetricKey specifies a custom per unit metric to scale
+	// on, in the form <metric>=<target average value>.
+	JujuAutoscaleMetricKey = "juju-autoscale-metric"
+
+	// JujuMaxUnavailableUnitsKey specifies the maximum number of units
+	// which may be unavailable at once during voluntary disruptions,
+	// such as a node being drained.
+	JujuMaxUnavailableUnitsKey = "juju-max-unavailable-units"
+
+	// JujuSpreadUnitsKey specifies a comma separated list of the
+	// topologies, "node" or "zone", units are spread evenly across.
+	JujuSpreadUnitsKey = "juju-spread-units"
 )
 
 var configFields = environschema.Fields{
@@ -31,6 +64,41 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	JujuAutoscaleMinUnitsKey: {
+		Description: "the minimum number of units of an autoscaled application",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	JujuAutoscaleMaxUnitsKey: {
+		Description: "the maximum number of units of an autoscaled application, setting this enables autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	JujuAutoscaleTargetCPUKey: {
+		Description: "the target average CPU utilisation of an autoscaled application, as a percentage",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	JujuAutoscaleTargetMemoryKey: {
+		Description: "the target average memory utilisation of an autoscaled application, as a percentage",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	JujuAutoscaleMetricKey: {
+		Description: "a custom metric to autoscale an application on, as <metric>=<target average value>",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	JujuMaxUnavailableUnitsKey: {
+		Description: "the maximum number of units which may be unavailable at once during voluntary disruptions",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	JujuSpreadUnitsKey: {
+		Description: "a comma separated list of the topologies (node, zone) to spread units across",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
 
 // ConfigSchema returns the valid fields for a CAAS application config.
@@ -69,3 +137,87 @@ func ConfigDefaults(providerDefaults schema.Defaults) schema.Defaults {
 	}
 	return defaults
 }
+
+// AutoscalePolicyFromConfig returns the autoscale policy defined by the
+// given application config, or nil if autoscaling is not enabled.
+func AutoscalePolicyFromConfig(attrs map[string]interface{}) (*AutoscalePolicy, error) {
+	maxUnits, err := intConfigValue(attrs, JujuAutoscaleMaxUnitsKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if maxUnits == 0 {
+		return nil, nil
+	}
+	policy := &AutoscalePolicy{MaxUnits: maxUnits}
+	if policy.MinUnits, err = intConfigValue(attrs, JujuAutoscaleMinUnitsKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if policy.MinUnits == 0 {
+		policy.MinUnits = 1
+	}
+	if policy.TargetCPU, err = intConfigValue(attrs, JujuAutoscaleTargetCPUKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if policy.TargetMemory, err = intConfigValue(attrs, JujuAutoscaleTargetMemoryKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if metric, _ := attrs[JujuAutoscaleMetricKey].(string); metric != "" {
+		parts := strings.SplitN(metric, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.NotValidf("%s %q, expected <metric>=<target>", JujuAutoscaleMetricKey, metric)
+		}
+		policy.Metric = parts[0]
+		policy.MetricTarget = parts[1]
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return policy, nil
+}
+
+// AvailabilityPolicyFromConfig returns the availability policy defined
+// by the given application config, or nil if none is defined.
+func AvailabilityPolicyFromConfig(attrs map[string]interface{}) (*AvailabilityPolicy, error) {
+	maxUnavailable, err := intConfigValue(attrs, JujuMaxUnavailableUnitsKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var spread []Topology
+	if value, _ := attrs[JujuSpreadUnitsKey].(string); value != "" {
+		for _, t := range strings.Split(value, ",") {
+			spread = append(spread, Topology(strings.TrimSpace(t)))
+		}
+	}
+	if maxUnavailable == 0 && len(spread) == 0 {
+		return nil, nil
+	}
+	policy := &AvailabilityPolicy{
+		MaxUnavailable: maxUnavailable,
+		SpreadAcross:   spread,
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return policy, nil
+}
+
+func intConfigValue(attrs map[string]interface{}, key string) (int, error) {
+	switch v := attrs[key].(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	case string:
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, errors.NotValidf("%s %q", key, v)
+		}
+		return i, nil
+	default:
+		return 0, errors.NotValidf("%s %v", key, v)
+	}
+}
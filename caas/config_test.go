@@ -24,6 +24,41 @@ var baseFields = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	caas.JujuAutoscaleMinUnitsKey: {
+		Description: "the minimum number of units of an autoscaled application",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	caas.JujuAutoscaleMaxUnitsKey: {
+		Description: "the maximum number of units of an autoscaled application, setting this enables autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	caas.JujuAutoscaleTargetCPUKey: {
+		Description: "the target average CPU utilisation of an autoscaled application, as a percentage",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	caas.JujuAutoscaleTargetMemoryKey: {
+		Description: "the target average memory utilisation of an autoscaled application, as a percentage",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	caas.JujuAutoscaleMetricKey: {
+		Description: "a custom metric to autoscale an application on, as <metric>=<target average value>",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	caas.JujuMaxUnavailableUnitsKey: {
+		Description: "the maximum number of units which may be unavailable at once during voluntary disruptions",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	caas.JujuSpreadUnitsKey: {
+		Description: "a comma separated list of the topologies (node, zone) to spread units across",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
 
 var baseDefaults = schema.Defaults{
@@ -93,3 +128,87 @@ func (s *ConfigSuite) TestConfigSchemaProviderDefaults(c *gc.C) {
 	}
 	c.Assert(defaults, jc.DeepEquals, expectedDefaults)
 }
+
+func (s *ConfigSuite) TestAutoscalePolicyFromConfigDisabled(c *gc.C) {
+	policy, err := caas.AutoscalePolicyFromConfig(map[string]interface{}{
+		caas.JujuAutoscaleTargetCPUKey: 80,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.IsNil)
+}
+
+func (s *ConfigSuite) TestAutoscalePolicyFromConfig(c *gc.C) {
+	policy, err := caas.AutoscalePolicyFromConfig(map[string]interface{}{
+		caas.JujuAutoscaleMaxUnitsKey:     int64(5),
+		caas.JujuAutoscaleTargetCPUKey:    80,
+		caas.JujuAutoscaleTargetMemoryKey: "70",
+		caas.JujuAutoscaleMetricKey:       "requests-per-second=100",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, &caas.AutoscalePolicy{
+		MinUnits:     1,
+		MaxUnits:     5,
+		TargetCPU:    80,
+		TargetMemory: 70,
+		Metric:       "requests-per-second",
+		MetricTarget: "100",
+	})
+}
+
+func (s *ConfigSuite) TestAutoscalePolicyFromConfigInvalid(c *gc.C) {
+	for i, t := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{
+			caas.JujuAutoscaleMaxUnitsKey: 5,
+		},
+		err: "autoscale policy without a target not valid",
+	}, {
+		attrs: map[string]interface{}{
+			caas.JujuAutoscaleMinUnitsKey:  3,
+			caas.JujuAutoscaleMaxUnitsKey:  2,
+			caas.JujuAutoscaleTargetCPUKey: 80,
+		},
+		err: "autoscale max units 2 less than min units 3 not valid",
+	}, {
+		attrs: map[string]interface{}{
+			caas.JujuAutoscaleMaxUnitsKey: 2,
+			caas.JujuAutoscaleMetricKey:   "requests",
+		},
+		err: `juju-autoscale-metric "requests", expected <metric>=<target> not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := caas.AutoscalePolicyFromConfig(t.attrs)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ConfigSuite) TestAvailabilityPolicyFromConfigNone(c *gc.C) {
+	policy, err := caas.AvailabilityPolicyFromConfig(map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.IsNil)
+}
+
+func (s *ConfigSuite) TestAvailabilityPolicyFromConfig(c *gc.C) {
+	policy, err := caas.AvailabilityPolicyFromConfig(map[string]interface{}{
+		caas.JujuMaxUnavailableUnitsKey: int64(1),
+		caas.JujuSpreadUnitsKey:         "zone, node",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, &caas.AvailabilityPolicy{
+		MaxUnavailable: 1,
+		SpreadAcross:   []caas.Topology{caas.TopologyZone, caas.TopologyNode},
+	})
+}
+
+func (s *ConfigSuite) TestAvailabilityPolicyFromConfigInvalid(c *gc.C) {
+	_, err := caas.AvailabilityPolicyFromConfig(map[string]interface{}{
+		caas.JujuSpreadUnitsKey: "rack",
+	})
+	c.Assert(err, gc.ErrorMatches, `spread topology "rack" not valid`)
+	_, err = caas.AvailabilityPolicyFromConfig(map[string]interface{}{
+		caas.JujuMaxUnavailableUnitsKey: -1,
+	})
+	c.Assert(err, gc.ErrorMatches, `max unavailable units -1 not valid`)
+}
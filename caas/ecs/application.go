@@ -15,6 +15,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -22,6 +24,7 @@ import (
 	"github.com/kr/pretty"
 
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/ecs/constants"
 	"github.com/juju/juju/core/paths"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
@@ -41,9 +44,24 @@ type app struct {
 	modelName      string
 	deploymentType caas.DeploymentType
 	client         ecsiface.ECSAPI
+	elbClient      elbv2iface.ELBV2API
+	efsClient      efsiface.EFSAPI
+	expose         exposeConfig
 	clock          clock.Clock
 }
 
+// exposeConfig holds the model config used to expose
+// applications through an application load balancer.
+type exposeConfig struct {
+	listenerARN string
+	vpcID       string
+	targetPort  int
+}
+
+func (c exposeConfig) enabled() bool {
+	return c.listenerARN != ""
+}
+
 func newApplication(
 	name string,
 	clusterName string,
@@ -52,8 +70,11 @@ func newApplication(
 	modelName string,
 	deploymentType caas.DeploymentType,
 	client ecsiface.ECSAPI,
+	elbClient elbv2iface.ELBV2API,
+	efsClient efsiface.EFSAPI,
+	expose exposeConfig,
 	clock clock.Clock,
-) caas.Application {
+) *app {
 	return &app{
 		name:           name,
 		clusterName:    clusterName,
@@ -62,6 +83,9 @@ func newApplication(
 		modelName:      modelName,
 		deploymentType: deploymentType,
 		client:         client,
+		elbClient:      elbClient,
+		efsClient:      efsClient,
+		expose:         expose,
 		clock:          clock,
 	}
 }
@@ -84,12 +108,31 @@ func (a *app) resourceName() string {
 
 // Delete deletes the specified application.
 func (a *app) Delete() error {
+	// The listener rule must go before the target group it forwards to.
+	if err := a.UpdateIngress(nil); err != nil {
+		return errors.Trace(err)
+	}
+	// Find the EFS access points before the service is gone.
+	var accessPoints []string
+	def, err := a.currentTaskDefinition()
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if def != nil {
+		accessPoints = taskAccessPoints(def)
+	}
 	if err := a.deleteService(); err != nil {
 		return errors.Trace(err)
 	}
 	if err := a.deleteTaskDefinitions(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := a.deleteTargetGroup(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := a.deleteAccessPoints(accessPoints); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -190,23 +233,30 @@ func (a *app) handleFileSystems(filesystems []jujustorage.KubernetesFilesystemPa
 		}
 		volNames.Add(fs.StorageName)
 
-		ebsCfg, err := newEbsConfig(fs.Attributes)
-		if err != nil {
-			// This should never happen because it's been validated `storageProvider.ValidateConfig`.
-			return nil, nil, errors.NotValidf("storage attribute for %q", fs.StorageName)
-		}
-		vol := &ecs.Volume{
-			Name: aws.String(a.volumeName(fs.StorageName)),
-			DockerVolumeConfiguration: &ecs.DockerVolumeConfiguration{
-				Scope:         aws.String("shared"),
-				Autoprovision: aws.Bool(true),
-				Driver:        aws.String(ebsCfg.driver),
-				Labels:        a.labels(fs.ResourceTags),
-				DriverOpts: map[string]*string{
-					"volumetype": aws.String(ebsCfg.volumeType),
-					"size":       aws.String(strconv.FormatUint(fs.Size/1024, 10)), // unit of size here should be `Gi`
+		var vol *ecs.Volume
+		if fs.Provider == constants.EFSStorageProviderType {
+			if vol, err = a.efsVolume(fs); err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+		} else {
+			ebsCfg, err := newEbsConfig(fs.Attributes)
+			if err != nil {
+				// This should never happen because it's been validated `storageProvider.ValidateConfig`.
+				return nil, nil, errors.NotValidf("storage attribute for %q", fs.StorageName)
+			}
+			vol = &ecs.Volume{
+				Name: aws.String(a.volumeName(fs.StorageName)),
+				DockerVolumeConfiguration: &ecs.DockerVolumeConfiguration{
+					Scope:         aws.String("shared"),
+					Autoprovision: aws.Bool(true),
+					Driver:        aws.String(ebsCfg.driver),
+					Labels:        a.labels(fs.ResourceTags),
+					DriverOpts: map[string]*string{
+						"volumetype": aws.String(ebsCfg.volumeType),
+						"size":       aws.String(strconv.FormatUint(fs.Size/1024, 10)), // unit of size here should be `Gi`
+					},
 				},
-			},
+			}
 		}
 		vols[idx] = vol
 
@@ -449,6 +499,18 @@ func (a *app) applicationTaskDefinition(config caas.ApplicationConfig) (*ecs.Reg
 			SourceVolume:  volume.Name,
 		})
 	}
+	if a.expose.enabled() && len(containers) > 0 {
+		// The load balancer forwards to the first workload container. No host
+		// port is set so that ECS assigns one dynamically for each task.
+		for _, def := range input.ContainerDefinitions {
+			if aws.StringValue(def.Name) == containers[0].Name {
+				def.PortMappings = []*ecs.PortMapping{{
+					ContainerPort: aws.Int64(int64(a.expose.targetPort)),
+					Protocol:      aws.String(ecs.TransportProtocolTcp),
+				}}
+			}
+		}
+	}
 	input.ContainerDefinitions = append(input.ContainerDefinitions, charmContainerDefinition)
 	return input, nil
 }
@@ -460,12 +522,28 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	taskDefinitionID := fmt.Sprintf(
+	var loadBalancers []*ecs.LoadBalancer
+	if containerName, ok := a.exposedContainerName(result.TaskDefinition); ok {
+		targetGroupARN, err := a.ensureTargetGroup()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		loadBalancers = []*ecs.LoadBalancer{{
+			ContainerName:  aws.String(containerName),
+			ContainerPort:  aws.Int64(int64(a.expose.targetPort)),
+			TargetGroupArn: aws.String(targetGroupARN),
+		}}
+	}
+	return errors.Trace(a.ensureECSService(taskDefinitionID(result.TaskDefinition), loadBalancers))
+}
+
+// taskDefinitionID returns the "family:revision" of the task definition.
+func taskDefinitionID(def *ecs.TaskDefinition) string {
+	return fmt.Sprintf(
 		"%s:%s",
-		aws.StringValue(result.TaskDefinition.Family),
-		strconv.FormatInt(aws.Int64Value(result.TaskDefinition.Revision), 10),
+		aws.StringValue(def.Family),
+		strconv.FormatInt(aws.Int64Value(def.Revision), 10),
 	)
-	return errors.Trace(a.ensureECSService(taskDefinitionID))
 }
 
 // Exists indicates if the application for the specified
@@ -586,6 +664,12 @@ func (a *app) UpdateService(param caas.ServiceParam) error {
 	return nil
 }
 
+// UpdateNetworkPolicy restricts ingress to the application's units.
+func (a *app) UpdateNetworkPolicy(policy *caas.NetworkPolicy) error {
+	// TODO(ecs)
+	return nil
+}
+
 func errorOrFailures(err error, failures []*ecs.Failure) error {
 	if err != nil {
 		return errors.Trace(err)
@@ -638,6 +722,28 @@ func (a *app) WatchReplicas() (watcher.NotifyWatcher, error) {
 	return newNotifyWatcher(a.name, a.clock, func() (bool, error) { return false, nil })
 }
 
+// currentTaskDefinition returns the task definition used by the
+// application's service.
+func (a *app) currentTaskDefinition() (*ecs.TaskDefinition, error) {
+	services, err := a.client.DescribeServices(&ecs.DescribeServicesInput{
+		Cluster:  aws.String(a.clusterName),
+		Services: []*string{aws.String(a.resourceName())},
+	})
+	if err = a.handleErr(err); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(services.Services) == 0 || aws.StringValue(services.Services[0].Status) == "INACTIVE" {
+		return nil, errors.NotFoundf("service %q", a.resourceName())
+	}
+	result, err := a.client.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: services.Services[0].TaskDefinition,
+	})
+	if err = a.handleErr(err); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.TaskDefinition, nil
+}
+
 func (a *app) registerTaskDefinition(config caas.ApplicationConfig) (*ecs.RegisterTaskDefinitionOutput, error) {
 	input, err := a.applicationTaskDefinition(config)
 	if err != nil {
@@ -652,7 +758,7 @@ func (a *app) registerTaskDefinition(config caas.ApplicationConfig) (*ecs.Regist
 	return result, nil
 }
 
-func (a *app) ensureECSService(taskDefinitionID string) (err error) {
+func (a *app) ensureECSService(taskDefinitionID string, loadBalancers []*ecs.LoadBalancer) (err error) {
 	updateInput := &ecs.UpdateServiceInput{
 		Cluster:        aws.String(a.clusterName),
 		DesiredCount:   aws.Int64(1),
@@ -662,12 +768,20 @@ func (a *app) ensureECSService(taskDefinitionID string) (err error) {
 	result, err := a.client.UpdateService(updateInput)
 	logger.Tracef("ensuring service updating %q err: %v result: %s", taskDefinitionID, err, pretty.Sprint(result))
 	err = a.handleErr(err)
+	if err == nil && len(loadBalancers) > 0 && result.Service != nil && len(result.Service.LoadBalancers) == 0 {
+		// ECS only allows load balancers to be set when the service is created.
+		logger.Warningf(
+			"service %q was created before %q was configured, redeploy %q to expose it",
+			a.resourceName(), ALBListenerARNKey, a.name,
+		)
+	}
 	if errors.IsNotFound(err) {
 		createInput := &ecs.CreateServiceInput{
 			Cluster:        aws.String(a.clusterName),
 			DesiredCount:   aws.Int64(1),
 			ServiceName:    aws.String(a.resourceName()),
 			TaskDefinition: aws.String(taskDefinitionID),
+			LoadBalancers:  loadBalancers,
 		}
 		var createResult *ecs.CreateServiceOutput
 		createResult, err = a.client.CreateService(createInput)
@@ -6,6 +6,7 @@ package ecs_test
 import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/efs"
 	"github.com/golang/mock/gomock"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -356,6 +357,30 @@ func (s *applicationSuite) TestDelete(c *gc.C) {
 	defer ctrl.Finish()
 
 	gomock.InOrder(
+		s.ecsClient.EXPECT().DescribeServices(&ecs.DescribeServicesInput{
+			Cluster:  aws.String("test-cluster"),
+			Services: []*string{aws.String("test-gitlab")},
+		}).Return(&ecs.DescribeServicesOutput{
+			Services: []*ecs.Service{{
+				Status:         aws.String("ACTIVE"),
+				TaskDefinition: aws.String("test-gitlab:3"),
+			}},
+		}, nil),
+		s.ecsClient.EXPECT().DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: aws.String("test-gitlab:3"),
+		}).Return(&ecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &ecs.TaskDefinition{
+				Volumes: []*ecs.Volume{{
+					Name: aws.String("gitlab-database"),
+					EfsVolumeConfiguration: &ecs.EFSVolumeConfiguration{
+						FileSystemId: aws.String("fs-12345678"),
+						AuthorizationConfig: &ecs.EFSAuthorizationConfig{
+							AccessPointId: aws.String("fsap-12345678"),
+						},
+					},
+				}},
+			},
+		}, nil),
 		s.ecsClient.EXPECT().DeleteService(&ecs.DeleteServiceInput{
 			Cluster: aws.String("test-cluster"),
 			Service: aws.String("test-gitlab"),
@@ -381,6 +406,9 @@ func (s *applicationSuite) TestDelete(c *gc.C) {
 		s.ecsClient.EXPECT().DeregisterTaskDefinition(&ecs.DeregisterTaskDefinitionInput{
 			TaskDefinition: aws.String("arn:aws:ecs:us-east-1:012345678910:task-definition/test-gitlab:3"),
 		}).Return(nil, nil),
+		s.efsClient.EXPECT().DeleteAccessPoint(&efs.DeleteAccessPointInput{
+			AccessPointId: aws.String("fsap-12345678"),
+		}).Return(nil, nil),
 	)
 
 	c.Assert(app.Delete(), jc.ErrorIsNil)
@@ -8,6 +8,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/golang/mock/gomock"
 	"github.com/juju/clock/testclock"
 	jc "github.com/juju/testing/checkers"
@@ -30,6 +32,8 @@ type baseSuite struct {
 	awsConfig *aws.Config
 
 	ecsClient *mocks.MockECSAPI
+	elbClient *mocks.MockELBV2API
+	efsClient *mocks.MockEFSAPI
 
 	clusterName string
 }
@@ -69,6 +73,8 @@ func (s *baseSuite) TearDownTest(c *gc.C) {
 	s.cfg = nil
 	s.awsConfig = nil
 	s.ecsClient = nil
+	s.elbClient = nil
+	s.efsClient = nil
 
 	s.BaseSuite.TearDownTest(c)
 }
@@ -77,6 +83,8 @@ func (s *baseSuite) setupController(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 
 	s.ecsClient = mocks.NewMockECSAPI(ctrl)
+	s.elbClient = mocks.NewMockELBV2API(ctrl)
+	s.efsClient = mocks.NewMockEFSAPI(ctrl)
 	s.clock = testclock.NewClock(time.Time{})
 
 	var err error
@@ -86,6 +94,12 @@ func (s *baseSuite) setupController(c *gc.C) *gomock.Controller {
 		func(*aws.Config) (ecsiface.ECSAPI, error) {
 			return s.ecsClient, nil
 		},
+		func(*aws.Config) (elbv2iface.ELBV2API, error) {
+			return s.elbClient, nil
+		},
+		func(*aws.Config) (efsiface.EFSAPI, error) {
+			return s.efsClient, nil
+		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	return ctrl
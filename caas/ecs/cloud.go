@@ -13,7 +13,40 @@ import (
 	"github.com/juju/juju/environs/config"
 )
 
-var configSchema = environschema.Fields{}
+const (
+	// ALBListenerARNKey is the model config key for the application load
+	// balancer listener used to expose applications.
+	ALBListenerARNKey = "alb-listener-arn"
+	// VPCIDKey is the model config key for the VPC of the load balancer
+	// target groups created for applications.
+	VPCIDKey = "vpc-id"
+	// ALBTargetPortKey is the model config key for the container port
+	// the load balancer forwards requests to.
+	ALBTargetPortKey = "alb-target-port"
+)
+
+var configSchema = environschema.Fields{
+	ALBListenerARNKey: {
+		Description: "The ARN of an application load balancer listener used to expose applications (optional). Applications cannot be exposed when not specified.",
+		Example:     "arn:aws:elasticloadbalancing:ap-southeast-2:123456789012:listener/app/my-alb/50dc6c495c0c9188/f2f7dc8efc522ab2",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+		Immutable:   true,
+	},
+	VPCIDKey: {
+		Description: "The ID of the VPC of the application load balancer. Required with alb-listener-arn.",
+		Example:     "vpc-a1b2c3d4",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+		Immutable:   true,
+	},
+	ALBTargetPortKey: {
+		Description: "The container port of the first workload container that the application load balancer forwards requests to.",
+		Type:        environschema.Tint,
+		Group:       environschema.AccountGroup,
+		Immutable:   true,
+	},
+}
 
 func providerConfigFields() (schema.Fields, error) {
 	fs, _, err := configSchema.ValidationSchema()
@@ -23,13 +56,29 @@ func providerConfigFields() (schema.Fields, error) {
 	return fs, nil
 }
 
-var providerConfigDefaults = schema.Defaults{}
+var providerConfigDefaults = schema.Defaults{
+	ALBListenerARNKey: "",
+	VPCIDKey:          "",
+	ALBTargetPortKey:  80,
+}
 
 type brokerConfig struct {
 	*config.Config
 	attrs map[string]interface{}
 }
 
+func (c *brokerConfig) albListenerARN() string {
+	return c.attrs[ALBListenerARNKey].(string)
+}
+
+func (c *brokerConfig) vpcID() string {
+	return c.attrs[VPCIDKey].(string)
+}
+
+func (c *brokerConfig) albTargetPort() int {
+	return c.attrs[ALBTargetPortKey].(int)
+}
+
 func (p environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
 	newCfg, err := validateConfig(cfg, old)
 	if err != nil {
@@ -85,7 +134,14 @@ func validateConfig(cfg, old *config.Config) (*brokerConfig, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &brokerConfig{Config: cfg, attrs: validated}, nil
+	newCfg := &brokerConfig{Config: cfg, attrs: validated}
+	if newCfg.albListenerARN() != "" && newCfg.vpcID() == "" {
+		return nil, errors.NotValidf("%q without %q", ALBListenerARNKey, VPCIDKey)
+	}
+	if port := newCfg.albTargetPort(); port <= 0 || port > 65535 {
+		return nil, errors.NotValidf("%q %d", ALBTargetPortKey, port)
+	}
+	return newCfg, nil
 }
 
 // FinalizeCloud is part of the environs.CloudFinalizer interface.
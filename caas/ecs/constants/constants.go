@@ -14,4 +14,8 @@ const (
 	// StorageProviderType defines the Juju storage type which can be used
 	// to provision storage on caas models.
 	StorageProviderType = storage.ProviderType("ecs")
+
+	// EFSStorageProviderType defines the Juju storage type backed by
+	// EFS access points.
+	EFSStorageProviderType = storage.ProviderType("ecs-efs")
 )
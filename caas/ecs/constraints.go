@@ -16,6 +16,13 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Spaces,
 	constraints.AllocatePublicIP,
+	constraints.MemRequest,
+	constraints.MemLimit,
+	constraints.CpuRequest,
+	constraints.CpuLimit,
+	constraints.EphemeralStorageLimit,
+	constraints.ContainerResources,
+	constraints.Spot,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -0,0 +1,187 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ecs
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/tags"
+	jujustorage "github.com/juju/juju/storage"
+)
+
+const (
+	// efsTagApplication is the access point tag for the application name.
+	efsTagApplication = "juju-application"
+	// efsTagStorage is the access point tag for the storage name.
+	efsTagStorage = "juju-storage"
+)
+
+// accessPointRoot returns the directory of the file system the
+// access point exposes to the application's tasks.
+func (a *app) accessPointRoot(storageName string) string {
+	return fmt.Sprintf("/juju/%s/%s/%s", a.modelUUID, a.name, storageName)
+}
+
+func (a *app) ownsAccessPoint(ap *efs.AccessPointDescription, storageName string) bool {
+	tagValues := make(map[string]string)
+	for _, t := range ap.Tags {
+		tagValues[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+	if tagValues[tags.JujuModel] != a.modelUUID || tagValues[efsTagApplication] != a.name {
+		return false
+	}
+	return tagValues[efsTagStorage] == storageName
+}
+
+// accessPoints returns the application's access points for the storage
+// on the file system.
+func (a *app) accessPoints(fileSystemID, storageName string) ([]*efs.AccessPointDescription, error) {
+	var (
+		out       []*efs.AccessPointDescription
+		nextToken *string
+	)
+	for {
+		result, err := a.efsClient.DescribeAccessPoints(&efs.DescribeAccessPointsInput{
+			FileSystemId: aws.String(fileSystemID),
+			NextToken:    nextToken,
+		})
+		if err = handleEFSErr(err); err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ap := range result.AccessPoints {
+			if a.ownsAccessPoint(ap, storageName) {
+				out = append(out, ap)
+			}
+		}
+		if aws.StringValue(result.NextToken) == "" {
+			return out, nil
+		}
+		nextToken = result.NextToken
+	}
+}
+
+func (a *app) efsTags(storageName string) (out []*efs.Tag) {
+	labels := a.labels(map[string]string{
+		efsTagApplication: a.name,
+		efsTagStorage:     storageName,
+		"Name":            a.volumeName(storageName),
+	})
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		out = append(out, &efs.Tag{Key: aws.String(k), Value: labels[k]})
+	}
+	return out
+}
+
+// ensureAccessPoint creates the access point for the storage if it
+// doesn't exist, and returns its ID.
+func (a *app) ensureAccessPoint(fileSystemID, storageName string) (string, error) {
+	existing, err := a.accessPoints(fileSystemID, storageName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(existing) > 0 {
+		return aws.StringValue(existing[0].AccessPointId), nil
+	}
+	result, err := a.efsClient.CreateAccessPoint(&efs.CreateAccessPointInput{
+		FileSystemId: aws.String(fileSystemID),
+		RootDirectory: &efs.RootDirectory{
+			Path: aws.String(a.accessPointRoot(storageName)),
+			CreationInfo: &efs.CreationInfo{
+				OwnerUid:    aws.Int64(0),
+				OwnerGid:    aws.Int64(0),
+				Permissions: aws.String("0755"),
+			},
+		},
+		Tags: a.efsTags(storageName),
+	})
+	if err = handleEFSErr(err); err != nil {
+		return "", errors.Annotatef(err, "creating access point for storage %q", storageName)
+	}
+	return aws.StringValue(result.AccessPointId), nil
+}
+
+// efsVolume returns the task volume for a filesystem from an EFS pool.
+func (a *app) efsVolume(fs jujustorage.KubernetesFilesystemParams) (*ecs.Volume, error) {
+	efsCfg, err := newEFSConfig(fs.Attributes)
+	if err != nil {
+		// This should never happen because it's been validated `efsStorageProvider.ValidateConfig`.
+		return nil, errors.NotValidf("storage attribute for %q", fs.StorageName)
+	}
+	accessPointID, err := a.ensureAccessPoint(efsCfg.fileSystemID, fs.StorageName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	iam := ecs.EFSAuthorizationConfigIAMDisabled
+	if efsCfg.iam {
+		iam = ecs.EFSAuthorizationConfigIAMEnabled
+	}
+	return &ecs.Volume{
+		Name: aws.String(a.volumeName(fs.StorageName)),
+		EfsVolumeConfiguration: &ecs.EFSVolumeConfiguration{
+			FileSystemId: aws.String(efsCfg.fileSystemID),
+			// Access points require encryption in transit.
+			TransitEncryption: aws.String(ecs.EFSTransitEncryptionEnabled),
+			AuthorizationConfig: &ecs.EFSAuthorizationConfig{
+				AccessPointId: aws.String(accessPointID),
+				Iam:           aws.String(iam),
+			},
+		},
+	}, nil
+}
+
+// taskAccessPoints returns the IDs of the access points used by the
+// volumes of the task definition.
+func taskAccessPoints(def *ecs.TaskDefinition) (out []string) {
+	for _, vol := range def.Volumes {
+		cfg := vol.EfsVolumeConfiguration
+		if cfg == nil || cfg.AuthorizationConfig == nil || cfg.AuthorizationConfig.AccessPointId == nil {
+			continue
+		}
+		out = append(out, aws.StringValue(cfg.AuthorizationConfig.AccessPointId))
+	}
+	return out
+}
+
+// deleteAccessPoints deletes the access points. The data
+// remains on the file system.
+func (a *app) deleteAccessPoints(ids []string) error {
+	for _, id := range ids {
+		_, err := a.efsClient.DeleteAccessPoint(&efs.DeleteAccessPointInput{
+			AccessPointId: aws.String(id),
+		})
+		if err = handleEFSErr(err); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting access point %q", id)
+		}
+	}
+	return nil
+}
+
+func handleEFSErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return err
+	}
+	switch aerr.Code() {
+	case efs.ErrCodeFileSystemNotFound, efs.ErrCodeAccessPointNotFound:
+		return errors.NewNotFound(err, aerr.Message())
+	case efs.ErrCodeBadRequest:
+		return errors.NewNotValid(err, aerr.Message())
+	}
+	return err
+}
@@ -0,0 +1,141 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ecs_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/ecs/constants"
+	coreresources "github.com/juju/juju/core/resources"
+	"github.com/juju/juju/storage"
+)
+
+type efsSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&efsSuite{})
+
+func (s *efsSuite) TestStorageProviderTypes(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	types, err := s.environ.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, []storage.ProviderType{"ecs", "ecs-efs"})
+
+	p, err := s.environ.StorageProvider(constants.EFSStorageProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.DefaultPools(), gc.HasLen, 0)
+}
+
+func (s *efsSuite) TestValidateConfig(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	p, err := s.environ.StorageProvider(constants.EFSStorageProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := storage.NewConfig("shared", constants.EFSStorageProviderType, map[string]interface{}{
+		"file-system-id": "fs-12345678",
+		"iam":            "true",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.ValidateConfig(cfg), jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("shared", constants.EFSStorageProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.ValidateConfig(cfg), gc.ErrorMatches, `validating EFS storage config for ecs: file-system-id: expected string, got nothing`)
+}
+
+func (s *efsSuite) TestEnsureWithEFSStorage(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+	app := s.environ.Application("gitlab", caas.DeploymentStateful)
+
+	gomock.InOrder(
+		s.efsClient.EXPECT().DescribeAccessPoints(&efs.DescribeAccessPointsInput{
+			FileSystemId: aws.String("fs-12345678"),
+		}).Return(&efs.DescribeAccessPointsOutput{
+			AccessPoints: []*efs.AccessPointDescription{{
+				// Another application's access point.
+				AccessPointId: aws.String("fsap-other"),
+				Tags: []*efs.Tag{
+					{Key: aws.String("juju-model-uuid"), Value: aws.String("deadbeef-0bad-400d-8000-4b1d0d06f00d")},
+					{Key: aws.String("juju-application"), Value: aws.String("mariadb")},
+					{Key: aws.String("juju-storage"), Value: aws.String("database")},
+				},
+			}},
+		}, nil),
+		s.efsClient.EXPECT().CreateAccessPoint(gomock.Any()).DoAndReturn(
+			func(input *efs.CreateAccessPointInput) (*efs.CreateAccessPointOutput, error) {
+				c.Assert(aws.StringValue(input.FileSystemId), gc.Equals, "fs-12345678")
+				c.Assert(aws.StringValue(input.RootDirectory.Path), gc.Equals,
+					"/juju/deadbeef-0bad-400d-8000-4b1d0d06f00d/gitlab/database")
+				c.Assert(input.Tags, jc.DeepEquals, []*efs.Tag{
+					{Key: aws.String("Name"), Value: aws.String("gitlab-database")},
+					{Key: aws.String("juju-application"), Value: aws.String("gitlab")},
+					{Key: aws.String("juju-controller-uuid"), Value: aws.String("deadbeef-1bad-500d-9000-4b1d0d06f00d")},
+					{Key: aws.String("juju-model-uuid"), Value: aws.String("deadbeef-0bad-400d-8000-4b1d0d06f00d")},
+					{Key: aws.String("juju-storage"), Value: aws.String("database")},
+				})
+				return &efs.CreateAccessPointOutput{AccessPointId: aws.String("fsap-12345678")}, nil
+			},
+		),
+		s.ecsClient.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+			func(input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+				c.Assert(input.Volumes[0], jc.DeepEquals, &ecs.Volume{
+					Name: aws.String("gitlab-database"),
+					EfsVolumeConfiguration: &ecs.EFSVolumeConfiguration{
+						FileSystemId:      aws.String("fs-12345678"),
+						TransitEncryption: aws.String("ENABLED"),
+						AuthorizationConfig: &ecs.EFSAuthorizationConfig{
+							AccessPointId: aws.String("fsap-12345678"),
+							Iam:           aws.String("DISABLED"),
+						},
+					},
+				})
+				return &ecs.RegisterTaskDefinitionOutput{
+					TaskDefinition: &ecs.TaskDefinition{
+						Family:   aws.String("test-gitlab"),
+						Revision: aws.Int64(2),
+					},
+				}, nil
+			},
+		),
+		s.ecsClient.EXPECT().UpdateService(&ecs.UpdateServiceInput{
+			Cluster:        aws.String("test-cluster"),
+			DesiredCount:   aws.Int64(1),
+			Service:        aws.String("test-gitlab"),
+			TaskDefinition: aws.String("test-gitlab:2"),
+		}).Return(&ecs.UpdateServiceOutput{}, nil),
+	)
+
+	c.Assert(app.Ensure(caas.ApplicationConfig{
+		AgentImagePath: "operator/image-path",
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+			Provider:    constants.EFSStorageProviderType,
+			Attributes:  map[string]interface{}{"file-system-id": "fs-12345678"},
+			Attachment: &storage.KubernetesFilesystemAttachmentParams{
+				Path: "/var/lib/gitlab",
+			},
+		}},
+		Containers: map[string]caas.ContainerConfig{
+			"gitlab": {
+				Name: "gitlab",
+				Image: coreresources.DockerImageDetails{
+					RegistryPath: "gitlab-image:latest",
+				},
+			},
+		},
+	}), jc.ErrorIsNil)
+}
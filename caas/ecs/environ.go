@@ -8,6 +8,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	jujuclock "github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -33,24 +35,37 @@ type environ struct {
 	modelUUID      string
 	controllerUUID string
 
-	lock           sync.Mutex
-	envCfgUnlocked *config.Config
-	awsCfgUnlocked *aws.Config
+	lock              sync.Mutex
+	envCfgUnlocked    *config.Config
+	brokerCfgUnlocked *brokerConfig
+	awsCfgUnlocked    *aws.Config
 
-	clientUnlocked ecsiface.ECSAPI
-	newECSClient   newECSClientFunc
+	clientUnlocked    ecsiface.ECSAPI
+	elbClientUnlocked elbv2iface.ELBV2API
+	efsClientUnlocked efsiface.EFSAPI
+	newECSClient      newECSClientFunc
+	newELBClient      newELBClientFunc
+	newEFSClient      newEFSClientFunc
 }
 
 type newECSClientFunc func(*aws.Config) (ecsiface.ECSAPI, error)
 
+type newELBClientFunc func(*aws.Config) (elbv2iface.ELBV2API, error)
+
+type newEFSClientFunc func(*aws.Config) (efsiface.EFSAPI, error)
+
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/ecs_mock.go github.com/aws/aws-sdk-go/service/ecs/ecsiface ECSAPI
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/elbv2_mock.go github.com/aws/aws-sdk-go/service/elbv2/elbv2iface ELBV2API
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/efs_mock.go github.com/aws/aws-sdk-go/service/efs/efsiface EFSAPI
 func newEnviron(
 	controllerUUID string,
 	clusterName string,
 	clock jujuclock.Clock,
 	envCfg *config.Config,
 	awsCfg *aws.Config,
-	newECSClient func(*aws.Config) (ecsiface.ECSAPI, error),
+	newECSClient newECSClientFunc,
+	newELBClient newELBClientFunc,
+	newEFSClient newEFSClientFunc,
 ) (_ *environ, err error) {
 	if controllerUUID == "" {
 		return nil, errors.NotValidf("controllerUUID is required")
@@ -69,21 +84,39 @@ func newEnviron(
 	}
 
 	env := &environ{
-		name:           envCfg.Name(),
-		clusterName:    clusterName,
-		clock:          clock,
-		modelUUID:      modelUUID,
-		controllerUUID: controllerUUID,
-		envCfgUnlocked: envCfg,
-		awsCfgUnlocked: awsCfg,
-		newECSClient:   newECSClient,
+		name:              envCfg.Name(),
+		clusterName:       clusterName,
+		clock:             clock,
+		modelUUID:         modelUUID,
+		controllerUUID:    controllerUUID,
+		envCfgUnlocked:    envCfg,
+		brokerCfgUnlocked: newCfg,
+		awsCfgUnlocked:    awsCfg,
+		newECSClient:      newECSClient,
+		newELBClient:      newELBClient,
+		newEFSClient:      newEFSClient,
 	}
-	if env.clientUnlocked, err = newECSClient(awsCfg); err != nil {
+	if err = env.newClients(); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return env, nil
 }
 
+// newClients creates the AWS API clients from the current AWS config.
+// The lock must be held by the caller if the environ is in use.
+func (env *environ) newClients() (err error) {
+	if env.clientUnlocked, err = env.newECSClient(env.awsCfgUnlocked); err != nil {
+		return errors.Trace(err)
+	}
+	if env.elbClientUnlocked, err = env.newELBClient(env.awsCfgUnlocked); err != nil {
+		return errors.Trace(err)
+	}
+	if env.efsClientUnlocked, err = env.newEFSClient(env.awsCfgUnlocked); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (env *environ) client() ecsiface.ECSAPI {
 	env.lock.Lock()
 	defer env.lock.Unlock()
@@ -91,6 +124,20 @@ func (env *environ) client() ecsiface.ECSAPI {
 	return client
 }
 
+func (env *environ) elbClient() elbv2iface.ELBV2API {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	client := env.elbClientUnlocked
+	return client
+}
+
+func (env *environ) efsClient() efsiface.EFSAPI {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	client := env.efsClientUnlocked
+	return client
+}
+
 // APIVersion returns the version info for the cluster.
 func (env *environ) APIVersion() (string, error) {
 	// TODO(ecs)
@@ -118,10 +165,7 @@ func (env *environ) SetCloudSpec(spec cloudspec.CloudSpec) (err error) {
 	if env.awsCfgUnlocked, err = cloudSpecToAWSConfig(spec); err != nil {
 		return errors.Annotate(err, "validating cloud spec")
 	}
-	if env.clientUnlocked, err = newECSClient(env.awsCfgUnlocked); err != nil {
-		return errors.Trace(err)
-	}
-	return nil
+	return errors.Trace(env.newClients())
 }
 
 // Config returns environ config.
@@ -142,6 +186,7 @@ func (env *environ) SetConfig(cfg *config.Config) error {
 	}
 	env.name = newCfg.Config.Name()
 	env.envCfgUnlocked = newCfg.Config
+	env.brokerCfgUnlocked = newCfg
 	return nil
 }
 
@@ -277,11 +322,27 @@ func (env *environ) AdoptResources(ctx envcontext.ProviderCallContext, controlle
 
 // Application returns an Application interface.
 func (env *environ) Application(name string, deploymentType caas.DeploymentType) caas.Application {
+	return env.application(name, deploymentType)
+}
+
+func (env *environ) application(name string, deploymentType caas.DeploymentType) *app {
 	return newApplication(
-		name, env.clusterName, env.controllerUUID, env.modelUUID, env.CurrentModel(), deploymentType, env.client(), env.clock,
+		name, env.clusterName, env.controllerUUID, env.modelUUID, env.CurrentModel(), deploymentType,
+		env.client(), env.elbClient(), env.efsClient(), env.exposeConfig(), env.clock,
 	)
 }
 
+func (env *environ) exposeConfig() exposeConfig {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	cfg := env.brokerCfgUnlocked
+	return exposeConfig{
+		listenerARN: cfg.albListenerARN(),
+		vpcID:       cfg.vpcID(),
+		targetPort:  cfg.albTargetPort(),
+	}
+}
+
 // DeleteOperator deletes the specified operator.
 func (env *environ) DeleteOperator(appName string) (err error) {
 	// TODO(ecs): remove from caas.Broker?
@@ -350,8 +411,3 @@ func (*environ) PrecheckInstance(ctx envcontext.ProviderCallContext, params envi
 	// TODO(ecs): remove from caas.Broker?
 	return nil
 }
-
-func (*environ) Upgrade(agentTag string, vers version.Number) error {
-	// TODO(ecs): remove from caas.Broker?
-	return nil
-}
@@ -0,0 +1,265 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ecs
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/caas"
+)
+
+// maxTargetGroupNameLength is the longest name ELB accepts for a target group.
+const maxTargetGroupNameLength = 32
+
+// targetGroupName returns the name of the load balancer target group for
+// the application. Target group names are unique per region and account,
+// so the model UUID is included.
+func (a *app) targetGroupName() string {
+	name := fmt.Sprintf("juju-%s-%s", a.modelUUID[:8], a.name)
+	if len(name) <= maxTargetGroupNameLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(a.name))
+	prefix := name[:maxTargetGroupNameLength-9]
+	return fmt.Sprintf("%s-%x", strings.TrimRight(prefix, "-"), sum[:4])
+}
+
+// exposedContainerName returns the name of the container the load balancer
+// forwards to, if the application can be exposed.
+func (a *app) exposedContainerName(def *ecs.TaskDefinition) (string, bool) {
+	if !a.expose.enabled() || def == nil {
+		return "", false
+	}
+	for _, c := range def.ContainerDefinitions {
+		if len(c.PortMappings) > 0 {
+			return aws.StringValue(c.Name), true
+		}
+	}
+	return "", false
+}
+
+func (a *app) elbTags() (out []*elbv2.Tag) {
+	labels := a.labels(nil)
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		out = append(out, &elbv2.Tag{Key: aws.String(k), Value: labels[k]})
+	}
+	return out
+}
+
+// getTargetGroupARN returns the ARN of the application's target group.
+func (a *app) getTargetGroupARN() (string, error) {
+	result, err := a.elbClient.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		Names: []*string{aws.String(a.targetGroupName())},
+	})
+	if err = handleELBErr(err); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(result.TargetGroups) == 0 {
+		return "", errors.NotFoundf("target group %q", a.targetGroupName())
+	}
+	return aws.StringValue(result.TargetGroups[0].TargetGroupArn), nil
+}
+
+// ensureTargetGroup creates the application's target group if it
+// doesn't exist, and returns its ARN.
+func (a *app) ensureTargetGroup() (string, error) {
+	arn, err := a.getTargetGroupARN()
+	if err == nil || !errors.IsNotFound(err) {
+		return arn, errors.Trace(err)
+	}
+	result, err := a.elbClient.CreateTargetGroup(&elbv2.CreateTargetGroupInput{
+		Name:       aws.String(a.targetGroupName()),
+		Protocol:   aws.String(elbv2.ProtocolEnumHttp),
+		Port:       aws.Int64(int64(a.expose.targetPort)),
+		VpcId:      aws.String(a.expose.vpcID),
+		TargetType: aws.String(elbv2.TargetTypeEnumInstance),
+		Tags:       a.elbTags(),
+	})
+	if err = handleELBErr(err); err != nil {
+		return "", errors.Annotatef(err, "creating target group for %q", a.name)
+	}
+	if len(result.TargetGroups) == 0 {
+		return "", errors.Errorf("no target group created for %q", a.name)
+	}
+	return aws.StringValue(result.TargetGroups[0].TargetGroupArn), nil
+}
+
+func (a *app) deleteTargetGroup() error {
+	if !a.expose.enabled() {
+		return nil
+	}
+	arn, err := a.getTargetGroupARN()
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = a.elbClient.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{
+		TargetGroupArn: aws.String(arn),
+	})
+	return errors.Trace(handleELBErr(err))
+}
+
+// listenerRules returns all the rules of the model's load balancer listener.
+func (a *app) listenerRules() ([]*elbv2.Rule, error) {
+	var (
+		rules  []*elbv2.Rule
+		marker *string
+	)
+	for {
+		result, err := a.elbClient.DescribeRules(&elbv2.DescribeRulesInput{
+			ListenerArn: aws.String(a.expose.listenerARN),
+			Marker:      marker,
+		})
+		if err = handleELBErr(err); err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, result.Rules...)
+		if aws.StringValue(result.NextMarker) == "" {
+			return rules, nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// findRule returns the rule forwarding to the target group, or nil.
+func findRule(rules []*elbv2.Rule, targetGroupARN string) *elbv2.Rule {
+	for _, rule := range rules {
+		for _, action := range rule.Actions {
+			if aws.StringValue(action.TargetGroupArn) == targetGroupARN {
+				return rule
+			}
+		}
+	}
+	return nil
+}
+
+// nextRulePriority returns a priority not used by any of the rules.
+func nextRulePriority(rules []*elbv2.Rule) int64 {
+	var highest int64
+	for _, rule := range rules {
+		// The default rule has priority "default".
+		p, err := strconv.ParseInt(aws.StringValue(rule.Priority), 10, 64)
+		if err == nil && p > highest {
+			highest = p
+		}
+	}
+	return highest + 1
+}
+
+func ingressRuleConditions(ingress *caas.Ingress) []*elbv2.RuleCondition {
+	conditions := []*elbv2.RuleCondition{{
+		Field: aws.String("host-header"),
+		HostHeaderConfig: &elbv2.HostHeaderConditionConfig{
+			Values: []*string{aws.String(ingress.Hostname)},
+		},
+	}}
+	if path := strings.TrimRight(ingress.Path, "/"); path != "" {
+		conditions = append(conditions, &elbv2.RuleCondition{
+			Field: aws.String("path-pattern"),
+			PathPatternConfig: &elbv2.PathPatternConditionConfig{
+				Values: []*string{aws.String(path), aws.String(path + "/*")},
+			},
+		})
+	}
+	return conditions
+}
+
+// UpdateIngress exposes the application through a rule on the model's
+// application load balancer listener, forwarding requests for the
+// ingress hostname and path to the application's target group.
+// A nil ingress removes the rule.
+func (a *app) UpdateIngress(ingress *caas.Ingress) error {
+	if !a.expose.enabled() {
+		if ingress == nil {
+			return nil
+		}
+		logger.Warningf("cannot expose %q without model config %q", a.name, ALBListenerARNKey)
+		return errors.NotFoundf("load balancer listener for %q", a.name)
+	}
+	targetGroupARN, err := a.getTargetGroupARN()
+	if errors.IsNotFound(err) && ingress == nil {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rules, err := a.listenerRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rule := findRule(rules, targetGroupARN)
+
+	if ingress == nil {
+		if rule == nil {
+			return nil
+		}
+		_, err = a.elbClient.DeleteRule(&elbv2.DeleteRuleInput{RuleArn: rule.RuleArn})
+		return errors.Trace(handleELBErr(err))
+	}
+
+	// TLS is terminated by the listener using its ACM certificates,
+	// and there is only one kind of load balancer.
+	if ingress.TLSSecret != "" {
+		logger.Warningf("ignoring TLS secret %q for %q, configure a certificate on the load balancer listener", ingress.TLSSecret, a.name)
+	}
+	if ingress.Class != "" {
+		logger.Warningf("ignoring ingress class %q for %q", ingress.Class, a.name)
+	}
+	conditions := ingressRuleConditions(ingress)
+	actions := []*elbv2.Action{{
+		Type:           aws.String(elbv2.ActionTypeEnumForward),
+		TargetGroupArn: aws.String(targetGroupARN),
+	}}
+	if rule != nil {
+		_, err = a.elbClient.ModifyRule(&elbv2.ModifyRuleInput{
+			RuleArn:    rule.RuleArn,
+			Conditions: conditions,
+			Actions:    actions,
+		})
+		return errors.Trace(handleELBErr(err))
+	}
+	_, err = a.elbClient.CreateRule(&elbv2.CreateRuleInput{
+		ListenerArn: aws.String(a.expose.listenerARN),
+		Priority:    aws.Int64(nextRulePriority(rules)),
+		Conditions:  conditions,
+		Actions:     actions,
+		Tags:        a.elbTags(),
+	})
+	return errors.Annotatef(handleELBErr(err), "creating load balancer rule for %q", a.name)
+}
+
+func handleELBErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return err
+	}
+	switch aerr.Code() {
+	case elbv2.ErrCodeTargetGroupNotFoundException, elbv2.ErrCodeListenerNotFoundException,
+		elbv2.ErrCodeRuleNotFoundException, elbv2.ErrCodeLoadBalancerNotFoundException:
+		return errors.NewNotFound(err, aerr.Message())
+	case elbv2.ErrCodeInvalidConfigurationRequestException:
+		return errors.NewNotValid(err, aerr.Message())
+	}
+	return err
+}
@@ -0,0 +1,240 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ecs_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	coreresources "github.com/juju/juju/core/resources"
+	"github.com/juju/juju/testing"
+)
+
+const (
+	listenerARN    = "arn:aws:elasticloadbalancing:ap-southeast-2:123456789012:listener/app/my-alb/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	targetGroupARN = "arn:aws:elasticloadbalancing:ap-southeast-2:123456789012:targetgroup/juju-deadbeef-gitlab/73e2d6bc24d8a067"
+)
+
+type exposeSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&exposeSuite{})
+
+func (s *exposeSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+
+	var err error
+	s.cfg, err = s.cfg.Apply(testing.Attrs{
+		"alb-listener-arn": listenerARN,
+		"vpc-id":           "vpc-a1b2c3d4",
+		"alb-target-port":  8080,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *exposeSuite) getApp(c *gc.C) (caas.Application, *gomock.Controller) {
+	ctrl := s.setupController(c)
+	return s.environ.Application("gitlab", caas.DeploymentStateless), ctrl
+}
+
+func (s *exposeSuite) expectTargetGroup() *gomock.Call {
+	return s.elbClient.EXPECT().DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		Names: []*string{aws.String("juju-deadbeef-gitlab")},
+	}).Return(&elbv2.DescribeTargetGroupsOutput{
+		TargetGroups: []*elbv2.TargetGroup{{
+			TargetGroupArn: aws.String(targetGroupARN),
+		}},
+	}, nil)
+}
+
+func (s *exposeSuite) TestEnsureCreatesServiceWithLoadBalancer(c *gc.C) {
+	app, ctrl := s.getApp(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.ecsClient.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+			func(input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+				// The first workload container maps the target port.
+				c.Assert(input.ContainerDefinitions, gc.HasLen, 3)
+				c.Assert(aws.StringValue(input.ContainerDefinitions[1].Name), gc.Equals, "gitlab")
+				c.Assert(input.ContainerDefinitions[1].PortMappings, jc.DeepEquals, []*ecs.PortMapping{{
+					ContainerPort: aws.Int64(8080),
+					Protocol:      aws.String("tcp"),
+				}})
+				return &ecs.RegisterTaskDefinitionOutput{
+					TaskDefinition: &ecs.TaskDefinition{
+						Family:               aws.String("test-gitlab"),
+						Revision:             aws.Int64(1),
+						ContainerDefinitions: input.ContainerDefinitions,
+					},
+				}, nil
+			},
+		),
+		s.elbClient.EXPECT().DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+			Names: []*string{aws.String("juju-deadbeef-gitlab")},
+		}).Return(nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "target group not found", nil)),
+		s.elbClient.EXPECT().CreateTargetGroup(gomock.Any()).DoAndReturn(
+			func(input *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
+				c.Assert(aws.StringValue(input.Name), gc.Equals, "juju-deadbeef-gitlab")
+				c.Assert(aws.Int64Value(input.Port), gc.Equals, int64(8080))
+				c.Assert(aws.StringValue(input.VpcId), gc.Equals, "vpc-a1b2c3d4")
+				c.Assert(aws.StringValue(input.TargetType), gc.Equals, "instance")
+				return &elbv2.CreateTargetGroupOutput{
+					TargetGroups: []*elbv2.TargetGroup{{
+						TargetGroupArn: aws.String(targetGroupARN),
+					}},
+				}, nil
+			},
+		),
+		s.ecsClient.EXPECT().UpdateService(gomock.Any()).Return(nil, &ecs.ServiceNotFoundException{}),
+		s.ecsClient.EXPECT().CreateService(&ecs.CreateServiceInput{
+			Cluster:        aws.String("test-cluster"),
+			DesiredCount:   aws.Int64(1),
+			ServiceName:    aws.String("test-gitlab"),
+			TaskDefinition: aws.String("test-gitlab:1"),
+			LoadBalancers: []*ecs.LoadBalancer{{
+				ContainerName:  aws.String("gitlab"),
+				ContainerPort:  aws.Int64(8080),
+				TargetGroupArn: aws.String(targetGroupARN),
+			}},
+		}).Return(nil, nil),
+	)
+
+	c.Assert(app.Ensure(caas.ApplicationConfig{
+		AgentImagePath: "operator/image-path",
+		Containers: map[string]caas.ContainerConfig{
+			"gitlab": {
+				Name: "gitlab",
+				Image: coreresources.DockerImageDetails{
+					RegistryPath: "gitlab-image:latest",
+				},
+			},
+		},
+	}), jc.ErrorIsNil)
+}
+
+func (s *exposeSuite) TestUpdateIngressCreatesRule(c *gc.C) {
+	app, ctrl := s.getApp(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.expectTargetGroup(),
+		s.elbClient.EXPECT().DescribeRules(&elbv2.DescribeRulesInput{
+			ListenerArn: aws.String(listenerARN),
+		}).Return(&elbv2.DescribeRulesOutput{
+			Rules: []*elbv2.Rule{
+				{Priority: aws.String("default")},
+				{Priority: aws.String("7"), RuleArn: aws.String("other-rule")},
+			},
+		}, nil),
+		s.elbClient.EXPECT().CreateRule(gomock.Any()).DoAndReturn(
+			func(input *elbv2.CreateRuleInput) (*elbv2.CreateRuleOutput, error) {
+				c.Assert(aws.StringValue(input.ListenerArn), gc.Equals, listenerARN)
+				c.Assert(aws.Int64Value(input.Priority), gc.Equals, int64(8))
+				c.Assert(input.Conditions, jc.DeepEquals, []*elbv2.RuleCondition{{
+					Field: aws.String("host-header"),
+					HostHeaderConfig: &elbv2.HostHeaderConditionConfig{
+						Values: []*string{aws.String("gitlab.example.com")},
+					},
+				}, {
+					Field: aws.String("path-pattern"),
+					PathPatternConfig: &elbv2.PathPatternConditionConfig{
+						Values: []*string{aws.String("/git"), aws.String("/git/*")},
+					},
+				}})
+				c.Assert(input.Actions, jc.DeepEquals, []*elbv2.Action{{
+					Type:           aws.String("forward"),
+					TargetGroupArn: aws.String(targetGroupARN),
+				}})
+				return &elbv2.CreateRuleOutput{}, nil
+			},
+		),
+	)
+
+	err := app.UpdateIngress(&caas.Ingress{
+		Hostname: "gitlab.example.com",
+		Path:     "/git/",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *exposeSuite) TestUpdateIngressModifiesRule(c *gc.C) {
+	app, ctrl := s.getApp(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.expectTargetGroup(),
+		s.elbClient.EXPECT().DescribeRules(&elbv2.DescribeRulesInput{
+			ListenerArn: aws.String(listenerARN),
+		}).Return(&elbv2.DescribeRulesOutput{
+			Rules: []*elbv2.Rule{{
+				Priority: aws.String("3"),
+				RuleArn:  aws.String("gitlab-rule"),
+				Actions: []*elbv2.Action{{
+					TargetGroupArn: aws.String(targetGroupARN),
+				}},
+			}},
+		}, nil),
+		s.elbClient.EXPECT().ModifyRule(&elbv2.ModifyRuleInput{
+			RuleArn: aws.String("gitlab-rule"),
+			Conditions: []*elbv2.RuleCondition{{
+				Field: aws.String("host-header"),
+				HostHeaderConfig: &elbv2.HostHeaderConditionConfig{
+					Values: []*string{aws.String("gitlab.example.com")},
+				},
+			}},
+			Actions: []*elbv2.Action{{
+				Type:           aws.String("forward"),
+				TargetGroupArn: aws.String(targetGroupARN),
+			}},
+		}).Return(&elbv2.ModifyRuleOutput{}, nil),
+	)
+
+	err := app.UpdateIngress(&caas.Ingress{Hostname: "gitlab.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *exposeSuite) TestUpdateIngressRemovesRule(c *gc.C) {
+	app, ctrl := s.getApp(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.expectTargetGroup(),
+		s.elbClient.EXPECT().DescribeRules(&elbv2.DescribeRulesInput{
+			ListenerArn: aws.String(listenerARN),
+		}).Return(&elbv2.DescribeRulesOutput{
+			Rules: []*elbv2.Rule{{
+				Priority: aws.String("3"),
+				RuleArn:  aws.String("gitlab-rule"),
+				Actions: []*elbv2.Action{{
+					TargetGroupArn: aws.String(targetGroupARN),
+				}},
+			}},
+		}, nil),
+		s.elbClient.EXPECT().DeleteRule(&elbv2.DeleteRuleInput{
+			RuleArn: aws.String("gitlab-rule"),
+		}).Return(&elbv2.DeleteRuleOutput{}, nil),
+	)
+
+	c.Assert(app.UpdateIngress(nil), jc.ErrorIsNil)
+}
+
+func (s *exposeSuite) TestUpdateIngressNotConfigured(c *gc.C) {
+	var err error
+	s.cfg, err = s.cfg.Remove([]string{"alb-listener-arn", "vpc-id"})
+	c.Assert(err, jc.ErrorIsNil)
+	app, ctrl := s.getApp(c)
+	defer ctrl.Finish()
+
+	c.Assert(app.UpdateIngress(nil), jc.ErrorIsNil)
+	err = app.UpdateIngress(&caas.Ingress{Hostname: "gitlab.example.com"})
+	c.Assert(err, gc.ErrorMatches, `load balancer listener for "gitlab" not found`)
+}
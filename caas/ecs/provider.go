@@ -51,7 +51,7 @@ func (p environProvider) Open(args environs.OpenParams) (caas.Broker, error) {
 		clusterName,
 		jujuclock.WallClock,
 		args.Config, awsCfg,
-		newECSClient,
+		newECSClient, newELBClient, newEFSClient,
 	)
 }
 
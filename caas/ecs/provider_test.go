@@ -132,5 +132,25 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 
 	validAttrs := validCfg.AllAttrs()
-	c.Assert(config.AllAttrs(), gc.DeepEquals, validAttrs)
+	c.Assert(coretesting.Attrs(config.AllAttrs()).Merge(coretesting.Attrs{
+		"alb-listener-arn": "",
+		"vpc-id":           "",
+		"alb-target-port":  80,
+	}), jc.DeepEquals, coretesting.Attrs(validAttrs))
+}
+
+func (s *providerSuite) TestValidateALBListenerRequiresVPC(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{
+		"alb-listener-arn": "arn:aws:elasticloadbalancing:ap-southeast-2:123456789012:listener/app/my-alb/50dc6c495c0c9188/f2f7dc8efc522ab2",
+	})
+	_, err := s.provider.Validate(config, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid ECS provider config: "alb-listener-arn" without "vpc-id" not valid`)
+}
+
+func (s *providerSuite) TestValidateALBTargetPort(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{
+		"alb-target-port": 70000,
+	})
+	_, err := s.provider.Validate(config, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid ECS provider config: "alb-target-port" 70000 not valid`)
 }
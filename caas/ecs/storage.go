@@ -64,17 +64,61 @@ func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
 	return ebsConfig, nil
 }
 
+const (
+	// EFSFileSystemIDKey is the config key for the ID of the EFS file system.
+	EFSFileSystemIDKey = "file-system-id"
+	// EFSIAMKey is the config key for whether the ECS task IAM role is
+	// used when mounting the file system.
+	EFSIAMKey = "iam"
+)
+
+var efsConfigFields = schema.Fields{
+	EFSFileSystemIDKey: schema.String(),
+	EFSIAMKey:          schema.Bool(),
+}
+
+var efsConfigChecker = schema.FieldMap(
+	efsConfigFields,
+	schema.Defaults{
+		EFSIAMKey: false,
+	},
+)
+
+type efsConfig struct {
+	fileSystemID string
+	iam          bool
+}
+
+func newEFSConfig(attrs map[string]interface{}) (*efsConfig, error) {
+	out, err := efsConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating EFS storage config for ecs")
+	}
+	coerced := out.(map[string]interface{})
+	efsConfig := &efsConfig{
+		fileSystemID: coerced[EFSFileSystemIDKey].(string),
+		iam:          coerced[EFSIAMKey].(bool),
+	}
+	if efsConfig.fileSystemID == "" {
+		return nil, errors.NotValidf("empty %q", EFSFileSystemIDKey)
+	}
+	return efsConfig, nil
+}
+
 // StorageProvider is defined on the jujustorage.ProviderRegistry interface.
 func (env *environ) StorageProvider(t jujustorage.ProviderType) (jujustorage.Provider, error) {
-	if t == constants.StorageProviderType {
+	switch t {
+	case constants.StorageProviderType:
 		return &storageProvider{env}, nil
+	case constants.EFSStorageProviderType:
+		return &efsStorageProvider{storageProvider{env}}, nil
 	}
 	return nil, errors.NotFoundf("storage provider %q", t)
 }
 
 // StorageProviderTypes is defined on the jujustorage.ProviderRegistry interface.
 func (*environ) StorageProviderTypes() ([]jujustorage.ProviderType, error) {
-	return []jujustorage.ProviderType{constants.StorageProviderType}, nil
+	return []jujustorage.ProviderType{constants.StorageProviderType, constants.EFSStorageProviderType}, nil
 }
 
 // ValidateStorageClass returns an error if the storage config is not valid.
@@ -140,6 +184,27 @@ func (g *storageProvider) FilesystemSource(providerConfig *jujustorage.Config) (
 	return nil, errors.NotSupportedf("filesystems")
 }
 
+// efsStorageProvider provisions storage from access points on an
+// existing EFS file system. The file system is shared by all the units
+// of an application and outlives them.
+type efsStorageProvider struct {
+	storageProvider
+}
+
+var _ jujustorage.Provider = (*efsStorageProvider)(nil)
+
+// ValidateConfig is defined on the jujustorage.Provider interface.
+func (g *efsStorageProvider) ValidateConfig(cfg *jujustorage.Config) error {
+	_, err := newEFSConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// DefaultPools is defined on the jujustorage.Provider interface.
+func (g *efsStorageProvider) DefaultPools() []*jujustorage.Config {
+	// There is no default pool because a file system ID is required.
+	return nil
+}
+
 type volumeSource struct {
 	client *environ
 }
@@ -0,0 +1,127 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ecs
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/cloudconfig/podcfg"
+	"github.com/juju/juju/environs/bootstrap"
+)
+
+const (
+	// controllerApplicationName is the name of the application
+	// whose service runs the controller in the controller model.
+	controllerApplicationName = "controller"
+
+	// apiServerContainerName is the container of the controller
+	// task running the controller agent.
+	apiServerContainerName = "api-server"
+)
+
+var (
+	// agentContainerNames are the containers of a task running the Juju agent.
+	agentContainerNames = []string{"charm-init", "charm"}
+
+	// controllerContainerNames are the containers of the controller
+	// task running the Juju agent.
+	controllerContainerNames = []string{apiServerContainerName}
+)
+
+// Upgrade sets the agent image of the specified agent to the new version.
+func (env *environ) Upgrade(agentTag string, vers version.Number) error {
+	tag, err := names.ParseTag(agentTag)
+	if err != nil {
+		return errors.Annotate(err, "parsing agent tag to upgrade")
+	}
+
+	logger.Infof("handling upgrade request for tag %q", tag)
+
+	switch tag.Kind() {
+	case names.MachineTagKind:
+		// The controller is upgraded by its controller agent tag.
+		return nil
+	case names.ControllerAgentTagKind:
+		return env.controllerApplication().upgrade(vers, controllerContainerNames)
+	case names.ApplicationTagKind:
+		return env.application(tag.Id(), caas.DeploymentStateful).upgrade(vers, agentContainerNames)
+	case names.ModelTagKind:
+		// There is no model operator on ECS.
+		return nil
+	}
+	return errors.NotImplementedf("ecs upgrade for agent tag %q", agentTag)
+}
+
+// controllerApplication returns the application whose service runs
+// the controller. It lives in the controller model whichever model
+// the environ is operating on.
+func (env *environ) controllerApplication() *app {
+	return newApplication(
+		controllerApplicationName, env.clusterName, env.controllerUUID, env.modelUUID,
+		bootstrap.ControllerModelName, caas.DeploymentStateful,
+		env.client(), env.elbClient(), env.efsClient(), env.exposeConfig(), env.clock,
+	)
+}
+
+// upgrade registers a new revision of the application's task definition
+// with the images of the named agent containers at the new version,
+// and rolls the service to it.
+func (a *app) upgrade(vers version.Number, containerNames []string) error {
+	def, err := a.currentTaskDefinition()
+	if err != nil {
+		return errors.Annotatef(err, "getting task definition to upgrade for %q", a.name)
+	}
+
+	upgraded := false
+	for _, c := range def.ContainerDefinitions {
+		for _, name := range containerNames {
+			if aws.StringValue(c.Name) != name {
+				continue
+			}
+			image := podcfg.RebuildOldOperatorImagePath(aws.StringValue(c.Image), vers)
+			if image != aws.StringValue(c.Image) {
+				c.Image = aws.String(image)
+				upgraded = true
+			}
+		}
+	}
+	if !upgraded {
+		logger.Debugf("agent of %q is already at %s", a.name, vers)
+		return nil
+	}
+
+	result, err := a.client.RegisterTaskDefinition(&ecs.RegisterTaskDefinitionInput{
+		Family:                  def.Family,
+		TaskRoleArn:             def.TaskRoleArn,
+		ExecutionRoleArn:        def.ExecutionRoleArn,
+		NetworkMode:             def.NetworkMode,
+		ContainerDefinitions:    def.ContainerDefinitions,
+		Volumes:                 def.Volumes,
+		PlacementConstraints:    def.PlacementConstraints,
+		RequiresCompatibilities: def.RequiresCompatibilities,
+		Cpu:                     def.Cpu,
+		Memory:                  def.Memory,
+		PidMode:                 def.PidMode,
+		IpcMode:                 def.IpcMode,
+		ProxyConfiguration:      def.ProxyConfiguration,
+		InferenceAccelerators:   def.InferenceAccelerators,
+	})
+	if err = a.handleErr(err); err != nil {
+		return errors.Annotatef(err, "registering task definition for %q to %s", a.name, vers)
+	}
+	_, err = a.client.UpdateService(&ecs.UpdateServiceInput{
+		Cluster:        aws.String(a.clusterName),
+		Service:        aws.String(a.resourceName()),
+		TaskDefinition: aws.String(taskDefinitionID(result.TaskDefinition)),
+	})
+	if err = a.handleErr(err); err != nil {
+		return errors.Annotatef(err, "updating service for %q to %s", a.name, vers)
+	}
+	return nil
+}
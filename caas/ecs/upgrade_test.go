@@ -0,0 +1,149 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ecs_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+)
+
+type upgradeSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&upgradeSuite{})
+
+func (s *upgradeSuite) TestUpgradeApplication(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	def := &ecs.TaskDefinition{
+		Family:   aws.String("test-gitlab"),
+		Revision: aws.Int64(3),
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{Name: aws.String("charm-init"), Image: aws.String("jujusolutions/jujud-operator:2.9.0")},
+			{Name: aws.String("gitlab"), Image: aws.String("gitlab-image:latest")},
+			{Name: aws.String("charm"), Image: aws.String("jujusolutions/jujud-operator:2.9.0")},
+		},
+	}
+	gomock.InOrder(
+		s.ecsClient.EXPECT().DescribeServices(&ecs.DescribeServicesInput{
+			Cluster:  aws.String("test-cluster"),
+			Services: []*string{aws.String("test-gitlab")},
+		}).Return(&ecs.DescribeServicesOutput{
+			Services: []*ecs.Service{{
+				Status:         aws.String("ACTIVE"),
+				TaskDefinition: aws.String("test-gitlab:3"),
+			}},
+		}, nil),
+		s.ecsClient.EXPECT().DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: aws.String("test-gitlab:3"),
+		}).Return(&ecs.DescribeTaskDefinitionOutput{TaskDefinition: def}, nil),
+		s.ecsClient.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+			func(input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+				c.Assert(aws.StringValue(input.Family), gc.Equals, "test-gitlab")
+				var images []string
+				for _, c := range input.ContainerDefinitions {
+					images = append(images, aws.StringValue(c.Image))
+				}
+				c.Assert(images, jc.DeepEquals, []string{
+					"jujusolutions/jujud-operator:2.9.1",
+					"gitlab-image:latest",
+					"jujusolutions/jujud-operator:2.9.1",
+				})
+				return &ecs.RegisterTaskDefinitionOutput{
+					TaskDefinition: &ecs.TaskDefinition{
+						Family:   aws.String("test-gitlab"),
+						Revision: aws.Int64(4),
+					},
+				}, nil
+			},
+		),
+		s.ecsClient.EXPECT().UpdateService(&ecs.UpdateServiceInput{
+			Cluster:        aws.String("test-cluster"),
+			Service:        aws.String("test-gitlab"),
+			TaskDefinition: aws.String("test-gitlab:4"),
+		}).Return(&ecs.UpdateServiceOutput{}, nil),
+	)
+
+	err := s.environ.Upgrade("application-gitlab", version.MustParse("2.9.1"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *upgradeSuite) TestUpgradeApplicationNotFound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.ecsClient.EXPECT().DescribeServices(gomock.Any()).Return(&ecs.DescribeServicesOutput{}, nil)
+
+	err := s.environ.Upgrade("application-gitlab", version.MustParse("2.9.1"))
+	c.Assert(err, gc.ErrorMatches, `getting task definition to upgrade for "gitlab": service "test-gitlab" not found`)
+}
+
+func (s *upgradeSuite) TestUpgradeController(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	def := &ecs.TaskDefinition{
+		Family:   aws.String("controller-controller"),
+		Revision: aws.Int64(1),
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{Name: aws.String("mongodb"), Image: aws.String("jujusolutions/juju-db:4.0")},
+			{Name: aws.String("api-server"), Image: aws.String("jujusolutions/jujud-operator:2.9.0")},
+		},
+	}
+	gomock.InOrder(
+		s.ecsClient.EXPECT().DescribeServices(&ecs.DescribeServicesInput{
+			Cluster:  aws.String("test-cluster"),
+			Services: []*string{aws.String("controller-controller")},
+		}).Return(&ecs.DescribeServicesOutput{
+			Services: []*ecs.Service{{
+				Status:         aws.String("ACTIVE"),
+				TaskDefinition: aws.String("controller-controller:1"),
+			}},
+		}, nil),
+		s.ecsClient.EXPECT().DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: aws.String("controller-controller:1"),
+		}).Return(&ecs.DescribeTaskDefinitionOutput{TaskDefinition: def}, nil),
+		s.ecsClient.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+			func(input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+				c.Assert(aws.StringValue(input.Family), gc.Equals, "controller-controller")
+				var images []string
+				for _, c := range input.ContainerDefinitions {
+					images = append(images, aws.StringValue(c.Image))
+				}
+				c.Assert(images, jc.DeepEquals, []string{
+					"jujusolutions/juju-db:4.0",
+					"jujusolutions/jujud-operator:2.9.1",
+				})
+				return &ecs.RegisterTaskDefinitionOutput{
+					TaskDefinition: &ecs.TaskDefinition{
+						Family:   aws.String("controller-controller"),
+						Revision: aws.Int64(2),
+					},
+				}, nil
+			},
+		),
+		s.ecsClient.EXPECT().UpdateService(&ecs.UpdateServiceInput{
+			Cluster:        aws.String("test-cluster"),
+			Service:        aws.String("controller-controller"),
+			TaskDefinition: aws.String("controller-controller:2"),
+		}).Return(&ecs.UpdateServiceOutput{}, nil),
+	)
+
+	err := s.environ.Upgrade("controller-0", version.MustParse("2.9.1"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *upgradeSuite) TestUpgradeMachineNoop(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	err := s.environ.Upgrade("machine-0", version.MustParse("2.9.1"))
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,50 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"time"
+
+	"github.com/juju/juju/core/watcher"
+)
+
+// Event is an event reported by the cloud for one of the
+// objects making up an application.
+type Event struct {
+	// Id uniquely identifies the event.
+	Id string
+
+	// Application is the name of the application the
+	// event is about.
+	Application string
+
+	// UnitId is the provider id of the unit the event is
+	// about, or empty if it's about the application.
+	UnitId string
+
+	// Object describes the cloud object the event is about,
+	// eg "Pod/mariadb-k8s-0".
+	Object string
+
+	// Warning is true if the event reports a problem.
+	Warning bool
+
+	Reason  string
+	Message string
+
+	// Time is when the event was last seen.
+	Time time.Time
+}
+
+// EventWatcher provides an API for watching the events the cloud
+// reports for the model's applications.
+type EventWatcher interface {
+	// WatchEvents returns a watcher which notifies when events
+	// are reported for objects in the model.
+	WatchEvents() (watcher.NotifyWatcher, error)
+
+	// Events returns the events reported for the model's
+	// applications which were last seen after since.
+	Events(since time.Time) ([]Event, error)
+}
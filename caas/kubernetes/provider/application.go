@@ -20,5 +20,6 @@ func (k *kubernetesClient) Application(name string, deploymentType caas.Deployme
 		k.newWatcher,
 		k.clock,
 		k.randomPrefix,
+		k.imageRegistry,
 	)
 }
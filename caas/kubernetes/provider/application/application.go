@@ -16,7 +16,11 @@ import (
 	"github.com/juju/loggo"
 	"github.com/kr/pretty"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -49,6 +53,10 @@ const (
 	agentProbePeriod       int32 = 10
 	agentProbeSuccess      int32 = 1
 	agentProbeFailure      int32 = 2
+
+	// placeholderPortName is the name of the port on the default
+	// service until the charm opens any ports.
+	placeholderPortName = "placeholder"
 )
 
 type app struct {
@@ -65,6 +73,9 @@ type app struct {
 	// randomPrefix generates an annotation for stateful sets.
 	randomPrefix k8sutils.RandomPrefixFunc
 
+	// imageRegistry returns how the model's workload images are pulled.
+	imageRegistry func() (k8sutils.ImageRegistry, error)
+
 	newApplier func() resources.Applier
 }
 
@@ -80,6 +91,7 @@ func NewApplication(
 	newWatcher k8swatcher.NewK8sWatcherFunc,
 	clock clock.Clock,
 	randomPrefix k8sutils.RandomPrefixFunc,
+	imageRegistry func() (k8sutils.ImageRegistry, error),
 ) caas.Application {
 	return newApplication(
 		name,
@@ -92,6 +104,7 @@ func NewApplication(
 		newWatcher,
 		clock,
 		randomPrefix,
+		imageRegistry,
 		resources.NewApplier,
 	)
 }
@@ -107,6 +120,7 @@ func newApplication(
 	newWatcher k8swatcher.NewK8sWatcherFunc,
 	clock clock.Clock,
 	randomPrefix k8sutils.RandomPrefixFunc,
+	imageRegistry func() (k8sutils.ImageRegistry, error),
 	newApplier func() resources.Applier,
 ) caas.Application {
 	return &app{
@@ -120,6 +134,7 @@ func newApplication(
 		newWatcher:     newWatcher,
 		clock:          clock,
 		randomPrefix:   randomPrefix,
+		imageRegistry:  imageRegistry,
 		newApplier:     newApplier,
 	}
 }
@@ -127,7 +142,7 @@ func newApplication(
 // Ensure creates or updates an application pod with the given application
 // name, agent path, and application config.
 func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
-	// TODO: add support `numUnits`, `Constraints` and `Devices`.
+	// TODO: add support `numUnits` and `Devices`.
 	// TODO: storage handling for deployment/daemonset enhancement.
 	defer func() {
 		if err != nil {
@@ -165,6 +180,14 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 	if err != nil {
 		return errors.Annotate(err, "generating application podspec")
 	}
+	registry, err := a.imageRegistry()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	registry.ApplyToPodSpec(podSpec)
+	if err := k8sutils.ApplyResourceConstraints(podSpec, config.Constraints, unitContainerName); err != nil {
+		return errors.Annotatef(err, "configuring resource constraints for %q", a.name)
+	}
 
 	var handleVolume handleVolumeFunc = func(v corev1.Volume, mountPath string, readOnly bool) (*corev1.VolumeMount, error) {
 		if err := storage.PushUniqueVolume(podSpec, v, false); err != nil {
@@ -382,9 +405,203 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 		return errors.NotSupportedf("unknown deployment type")
 	}
 
+	if err := a.configureAutoscaler(applier, config.Autoscale); err != nil {
+		return errors.Annotatef(err, "configuring autoscaler for %q", a.name)
+	}
+	if err := a.configureDisruptionBudget(applier, config.Availability); err != nil {
+		return errors.Annotatef(err, "configuring disruption budget for %q", a.name)
+	}
+
 	return applier.Run(context.Background(), a.client, false)
 }
 
+// configureAutoscaler ensures a horizontal pod autoscaler scales the
+// application according to the given policy. If there is no policy,
+// any autoscaler previously created by Juju is removed.
+func (a *app) configureAutoscaler(applier resources.Applier, policy *caas.AutoscalePolicy) error {
+	if policy == nil {
+		hpa, err := a.getAutoscaler()
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		applier.Delete(hpa)
+		return nil
+	}
+
+	var kind string
+	switch a.deploymentType {
+	case caas.DeploymentStateful:
+		kind = "StatefulSet"
+	case caas.DeploymentStateless:
+		kind = "Deployment"
+	default:
+		return errors.NotSupportedf("autoscaling %q applications", a.deploymentType)
+	}
+	metrics, err := autoscalerMetrics(*policy)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	applier.Apply(resources.NewHorizontalPodAutoscaler(a.name, a.namespace, &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: a.labels(),
+		},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       a.name,
+			},
+			MinReplicas: int32Ptr(int32(policy.MinUnits)),
+			MaxReplicas: int32(policy.MaxUnits),
+			Metrics:     metrics,
+		},
+	}))
+	return nil
+}
+
+func autoscalerMetrics(policy caas.AutoscalePolicy) ([]autoscalingv2beta2.MetricSpec, error) {
+	var metrics []autoscalingv2beta2.MetricSpec
+	resourceMetric := func(name corev1.ResourceName, utilization int) autoscalingv2beta2.MetricSpec {
+		return autoscalingv2beta2.MetricSpec{
+			Type: autoscalingv2beta2.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta2.ResourceMetricSource{
+				Name: name,
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:               autoscalingv2beta2.UtilizationMetricType,
+					AverageUtilization: int32Ptr(int32(utilization)),
+				},
+			},
+		}
+	}
+	if policy.TargetCPU > 0 {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, policy.TargetCPU))
+	}
+	if policy.TargetMemory > 0 {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, policy.TargetMemory))
+	}
+	if policy.Metric != "" {
+		target, err := resource.ParseQuantity(policy.MetricTarget)
+		if err != nil {
+			return nil, errors.NotValidf("target %q for metric %q", policy.MetricTarget, policy.Metric)
+		}
+		metrics = append(metrics, autoscalingv2beta2.MetricSpec{
+			Type: autoscalingv2beta2.PodsMetricSourceType,
+			Pods: &autoscalingv2beta2.PodsMetricSource{
+				Metric: autoscalingv2beta2.MetricIdentifier{Name: policy.Metric},
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:         autoscalingv2beta2.AverageValueMetricType,
+					AverageValue: &target,
+				},
+			},
+		})
+	}
+	return metrics, nil
+}
+
+// getAutoscaler returns the horizontal pod autoscaler created by Juju
+// for the application. Autoscalers created outside of Juju are never
+// returned, so they are not removed.
+func (a *app) getAutoscaler() (*resources.HorizontalPodAutoscaler, error) {
+	hpa := resources.NewHorizontalPodAutoscaler(a.name, a.namespace, nil)
+	if err := hpa.Get(context.Background(), a.client); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !a.labels().AsSelector().Matches(labels.Set(hpa.GetLabels())) {
+		return nil, errors.NotFoundf("autoscaler %q managed by juju", a.name)
+	}
+	return hpa, nil
+}
+
+// configureDisruptionBudget ensures a pod disruption budget limits the
+// number of units unavailable at once according to the given policy.
+// The budget is expressed as the maximum number of unavailable units so
+// it stays correct as the application is scaled.
+func (a *app) configureDisruptionBudget(applier resources.Applier, policy *caas.AvailabilityPolicy) error {
+	if policy == nil || policy.MaxUnavailable == 0 {
+		pdb, err := a.getDisruptionBudget()
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		applier.Delete(pdb)
+		return nil
+	}
+	maxUnavailable := intstr.FromInt(policy.MaxUnavailable)
+	applier.Apply(resources.NewPodDisruptionBudget(a.name, a.namespace, &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: a.labels(),
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: a.selectorLabels(),
+			},
+		},
+	}))
+	return nil
+}
+
+// getDisruptionBudget returns the pod disruption budget created by Juju
+// for the application.
+func (a *app) getDisruptionBudget() (*resources.PodDisruptionBudget, error) {
+	pdb := resources.NewPodDisruptionBudget(a.name, a.namespace, nil)
+	if err := pdb.Get(context.Background(), a.client); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !a.labels().AsSelector().Matches(labels.Set(pdb.GetLabels())) {
+		return nil, errors.NotFoundf("disruption budget %q managed by juju", a.name)
+	}
+	return pdb, nil
+}
+
+// topologyKeys maps the topologies units can be spread across to the
+// well known node labels identifying them.
+var topologyKeys = map[caas.Topology]string{
+	caas.TopologyNode: corev1.LabelHostname,
+	caas.TopologyZone: corev1.LabelZoneFailureDomainStable,
+}
+
+// topologySpreadConstraints returns the constraints used to spread the
+// application's pods evenly across the topologies in the given policy.
+// Pods are still scheduled when the spread cannot be satisfied, so that
+// small clusters can run the application.
+func (a *app) topologySpreadConstraints(policy *caas.AvailabilityPolicy) []corev1.TopologySpreadConstraint {
+	if policy == nil {
+		return nil
+	}
+	var constraints []corev1.TopologySpreadConstraint
+	for _, t := range policy.SpreadAcross {
+		constraints = append(constraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       topologyKeys[t],
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: a.selectorLabels(),
+			},
+		})
+	}
+	return constraints
+}
+
+// autoscaled returns true if any horizontal pod autoscaler, whether
+// created by Juju or not, targets the application.
+func (a *app) autoscaled(kind string) (bool, error) {
+	hpas, err := a.client.AutoscalingV2beta2().HorizontalPodAutoscalers(a.namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, hpa := range hpas.Items {
+		ref := hpa.Spec.ScaleTargetRef
+		if ref.Kind == kind && ref.Name == a.name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // Exists indicates if the application for the specified
 // application exists, and whether the application is terminating.
 func (a *app) Exists() (caas.DeploymentState, error) {
@@ -464,7 +681,7 @@ func (a *app) configureDefaultService(annotation annotations.Annotation) (err er
 			Selector: a.selectorLabels(),
 			Type:     corev1.ServiceTypeClusterIP,
 			Ports: []corev1.ServicePort{{
-				Name: "placeholder",
+				Name: placeholderPortName,
 				Port: 65535,
 			}},
 		},
@@ -536,6 +753,151 @@ func (a *app) UpdatePorts(ports []caas.ServicePort, updateContainerPorts bool) e
 	return errors.Trace(err)
 }
 
+// UpdateNetworkPolicy restricts ingress to the application's pods to
+// the pods of the application itself, the pods of related applications
+// and the given CIDRs. A nil policy removes the restriction.
+func (a *app) UpdateNetworkPolicy(policy *caas.NetworkPolicy) error {
+	applier := a.newApplier()
+	if policy == nil {
+		np, err := a.getNetworkPolicy()
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		applier.Delete(np)
+		return applier.Run(context.Background(), a.client, false)
+	}
+
+	peers := []networkingv1.NetworkPolicyPeer{{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: a.selectorLabels(),
+		},
+	}}
+	for _, appName := range policy.RelatedApplications {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: k8sutils.SelectorLabelsForApp(appName, a.legacyLabels),
+			},
+		})
+	}
+	for _, cidr := range policy.IngressCIDRs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+	applier.Apply(resources.NewNetworkPolicy(a.name, a.namespace, &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: a.labels(),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: a.selectorLabels(),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: peers,
+			}},
+		},
+	}))
+	return applier.Run(context.Background(), a.client, false)
+}
+
+// getNetworkPolicy returns the network policy created by Juju for the
+// application.
+func (a *app) getNetworkPolicy() (*resources.NetworkPolicy, error) {
+	np := resources.NewNetworkPolicy(a.name, a.namespace, nil)
+	if err := np.Get(context.Background(), a.client); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !a.labels().AsSelector().Matches(labels.Set(np.GetLabels())) {
+		return nil, errors.NotFoundf("network policy %q managed by juju", a.name)
+	}
+	return np, nil
+}
+
+// UpdateIngress routes the ingress hostname and path to the first port
+// opened on the application's service. A nil ingress removes the ingress.
+func (a *app) UpdateIngress(ingress *caas.Ingress) error {
+	applier := a.newApplier()
+	if ingress == nil {
+		ing, err := a.getIngress()
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		applier.Delete(ing)
+		return applier.Run(context.Background(), a.client, false)
+	}
+
+	svc, err := a.getService()
+	if err != nil {
+		return errors.Annotatef(err, "getting existing service %q", a.name)
+	}
+	var backendPort *corev1.ServicePort
+	for i, port := range svc.Spec.Ports {
+		if port.Name != placeholderPortName {
+			backendPort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if backendPort == nil {
+		return errors.NotFoundf("opened ports for application %q", a.name)
+	}
+
+	path := ingress.Path
+	if path == "" {
+		path = "/"
+	}
+	ingressAnnotations := annotations.New(nil)
+	if ingress.Class != "" {
+		ingressAnnotations.Add("kubernetes.io/ingress.class", ingress.Class)
+	}
+	spec := networkingv1beta1.IngressSpec{
+		Rules: []networkingv1beta1.IngressRule{{
+			Host: ingress.Hostname,
+			IngressRuleValue: networkingv1beta1.IngressRuleValue{
+				HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+					Paths: []networkingv1beta1.HTTPIngressPath{{
+						Path: path,
+						Backend: networkingv1beta1.IngressBackend{
+							ServiceName: svc.Name,
+							ServicePort: intstr.FromInt(int(backendPort.Port)),
+						},
+					}},
+				},
+			},
+		}},
+	}
+	if ingress.TLSSecret != "" {
+		spec.TLS = []networkingv1beta1.IngressTLS{{
+			Hosts:      []string{ingress.Hostname},
+			SecretName: ingress.TLSSecret,
+		}}
+	}
+	applier.Apply(resources.NewIngress(a.name, a.namespace, &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      a.labels(),
+			Annotations: ingressAnnotations,
+		},
+		Spec: spec,
+	}))
+	return applier.Run(context.Background(), a.client, false)
+}
+
+// getIngress returns the ingress created by Juju for the application.
+func (a *app) getIngress() (*resources.Ingress, error) {
+	ing := resources.NewIngress(a.name, a.namespace, nil)
+	if err := ing.Get(context.Background(), a.client); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !a.labels().AsSelector().Matches(labels.Set(ing.GetLabels())) {
+		return nil, errors.NotFoundf("ingress %q managed by juju", a.name)
+	}
+	return ing, nil
+}
+
 func convertContainerPort(p corev1.ServicePort) corev1.ContainerPort {
 	return corev1.ContainerPort{
 		Name:          p.Name,
@@ -684,6 +1046,26 @@ func (a *app) Delete() error {
 	default:
 		return errors.NotSupportedf("unknown deployment type")
 	}
+	if hpa, err := a.getAutoscaler(); err == nil {
+		applier.Delete(hpa)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if pdb, err := a.getDisruptionBudget(); err == nil {
+		applier.Delete(pdb)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if np, err := a.getNetworkPolicy(); err == nil {
+		applier.Delete(np)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if ing, err := a.getIngress(); err == nil {
+		applier.Delete(ing)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
 	applier.Delete(resources.NewService(a.name, a.namespace, nil))
 	applier.Delete(resources.NewSecret(a.secretName(), a.namespace, nil))
 	return applier.Run(context.Background(), a.client, false)
@@ -735,6 +1117,9 @@ func (a *app) State() (caas.ApplicationState, error) {
 			return caas.ApplicationState{}, errors.Errorf("missing replicas")
 		}
 		state.DesiredReplicas = int(*ss.Spec.Replicas)
+		if state.Autoscaled, err = a.autoscaled("StatefulSet"); err != nil {
+			return caas.ApplicationState{}, errors.Trace(err)
+		}
 	case caas.DeploymentStateless:
 		d := resources.NewDeployment(a.name, a.namespace, nil)
 		err := d.Get(context.Background(), a.client)
@@ -745,6 +1130,9 @@ func (a *app) State() (caas.ApplicationState, error) {
 			return caas.ApplicationState{}, errors.Errorf("missing replicas")
 		}
 		state.DesiredReplicas = int(*d.Spec.Replicas)
+		if state.Autoscaled, err = a.autoscaled("Deployment"); err != nil {
+			return caas.ApplicationState{}, errors.Trace(err)
+		}
 	case caas.DeploymentDaemon:
 		d := resources.NewDaemonSet(a.name, a.namespace, nil)
 		err := d.Get(context.Background(), a.client)
@@ -755,6 +1143,16 @@ func (a *app) State() (caas.ApplicationState, error) {
 	default:
 		return caas.ApplicationState{}, errors.NotSupportedf("unknown deployment type")
 	}
+	pdb, err := a.getDisruptionBudget()
+	if err == nil {
+		state.DisruptionBudget = &caas.DisruptionBudgetStatus{
+			DisruptionsAllowed: int(pdb.Status.DisruptionsAllowed),
+			CurrentHealthy:     int(pdb.Status.CurrentHealthy),
+			DesiredHealthy:     int(pdb.Status.DesiredHealthy),
+		}
+	} else if !errors.IsNotFound(err) {
+		return caas.ApplicationState{}, errors.Trace(err)
+	}
 	next := ""
 	for {
 		res, err := a.client.CoreV1().Pods(a.namespace).List(context.Background(), metav1.ListOptions{
@@ -1038,7 +1436,8 @@ func (a *app) applicationPodSpec(config caas.ApplicationConfig) (*corev1.PodSpec
 				},
 			},
 		}},
-		Containers: containerSpecs,
+		Containers:                containerSpecs,
+		TopologySpreadConstraints: a.topologySpreadConstraints(config.Availability),
 		Volumes: []corev1.Volume{
 			{
 				Name: charmVolumeName,
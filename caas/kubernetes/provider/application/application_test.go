@@ -17,7 +17,11 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,8 +34,10 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/caas/kubernetes/provider/resources"
 	resourcesmocks "github.com/juju/juju/caas/kubernetes/provider/resources/mocks"
+	k8sutils "github.com/juju/juju/caas/kubernetes/provider/utils"
 	k8swatcher "github.com/juju/juju/caas/kubernetes/provider/watcher"
 	k8swatchertest "github.com/juju/juju/caas/kubernetes/provider/watcher/test"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/paths"
 	coreresources "github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/status"
@@ -49,6 +55,8 @@ type applicationSuite struct {
 	k8sWatcherFn k8swatcher.NewK8sWatcherFunc
 	watchers     []k8swatcher.KubernetesNotifyWatcher
 	applier      *resourcesmocks.MockApplier
+
+	imageRegistry k8sutils.ImageRegistry
 }
 
 var _ = gc.Suite(&applicationSuite{})
@@ -67,6 +75,7 @@ func (s *applicationSuite) TearDownTest(c *gc.C) {
 	s.clock = nil
 	s.watchers = nil
 	s.applier = nil
+	s.imageRegistry = k8sutils.ImageRegistry{}
 
 	s.BaseSuite.TearDownTest(c)
 }
@@ -96,6 +105,9 @@ func (s *applicationSuite) getApp(c *gc.C, deploymentType caas.DeploymentType, m
 		func() (string, error) {
 			return "appuuid", nil
 		},
+		func() (k8sutils.ImageRegistry, error) {
+			return s.imageRegistry, nil
+		},
 		func() resources.Applier {
 			if mockApplier {
 				return s.applier
@@ -613,6 +625,223 @@ func (s *applicationSuite) TestEnsureDaemon(c *gc.C) {
 	)
 }
 
+func (s *applicationSuite) ensureAutoscaled(c *gc.C, app caas.Application, policy *caas.AutoscalePolicy) {
+	c.Assert(app.Ensure(
+		caas.ApplicationConfig{
+			AgentImagePath: "operator/image-path",
+			CharmBaseImage: coreresources.DockerImageDetails{
+				RegistryPath: "ubuntu:20.04",
+			},
+			Autoscale: policy,
+		},
+	), jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestEnsureAutoscaled(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	s.ensureAutoscaled(c, app, &caas.AutoscalePolicy{
+		MinUnits:     2,
+		MaxUnits:     5,
+		TargetCPU:    80,
+		Metric:       "requests-per-second",
+		MetricTarget: "100",
+	})
+
+	hpa, err := s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hpa.Labels, gc.DeepEquals, map[string]string{
+		"app.kubernetes.io/name":       "gitlab",
+		"app.kubernetes.io/managed-by": "juju",
+	})
+	target := k8sresource.MustParse("100")
+	c.Assert(hpa.Spec, gc.DeepEquals, autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+			Name:       "gitlab",
+		},
+		MinReplicas: application.Int32Ptr(2),
+		MaxReplicas: 5,
+		Metrics: []autoscalingv2beta2.MetricSpec{{
+			Type: autoscalingv2beta2.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:               autoscalingv2beta2.UtilizationMetricType,
+					AverageUtilization: application.Int32Ptr(80),
+				},
+			},
+		}, {
+			Type: autoscalingv2beta2.PodsMetricSourceType,
+			Pods: &autoscalingv2beta2.PodsMetricSource{
+				Metric: autoscalingv2beta2.MetricIdentifier{Name: "requests-per-second"},
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:         autoscalingv2beta2.AverageValueMetricType,
+					AverageValue: &target,
+				},
+			},
+		}},
+	})
+
+	// Removing the policy removes the autoscaler.
+	s.ensureAutoscaled(c, app, nil)
+	_, err = s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, gc.ErrorMatches, `horizontalpodautoscalers.autoscaling "gitlab" not found`)
+}
+
+func (s *applicationSuite) TestEnsureKeepsExternalAutoscaler(c *gc.C) {
+	_, err := s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Create(context.TODO(),
+		&autoscalingv2beta2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gitlab",
+				Namespace: "test",
+			},
+		}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	s.ensureAutoscaled(c, app, nil)
+	_, err = s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestEnsureImageRegistry(c *gc.C) {
+	s.imageRegistry = k8sutils.ImageRegistry{
+		Mirrors:     map[string]string{"docker.io": "registry.internal"},
+		PullSecrets: []string{k8sutils.ImageRegistrySecretName},
+	}
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	c.Assert(app.Ensure(caas.ApplicationConfig{
+		AgentImagePath: "operator/image-path",
+		CharmBaseImage: coreresources.DockerImageDetails{
+			RegistryPath: "ubuntu:20.04",
+		},
+	}), jc.ErrorIsNil)
+
+	ss, err := s.client.AppsV1().StatefulSets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := ss.Spec.Template.Spec
+	c.Assert(podSpec.ImagePullSecrets, gc.DeepEquals, []corev1.LocalObjectReference{
+		{Name: "juju-image-registry-credentials"},
+	})
+	c.Assert(podSpec.InitContainers[0].Image, gc.Equals, "registry.internal/operator/image-path")
+	c.Assert(podSpec.Containers[0].Image, gc.Equals, "registry.internal/library/ubuntu:20.04")
+}
+
+func (s *applicationSuite) TestEnsureResourceConstraints(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	c.Assert(app.Ensure(caas.ApplicationConfig{
+		AgentImagePath: "operator/image-path",
+		CharmBaseImage: coreresources.DockerImageDetails{
+			RegistryPath: "ubuntu:20.04",
+		},
+		Containers: map[string]caas.ContainerConfig{
+			"gitlab": {
+				Name: "gitlab",
+				Image: coreresources.DockerImageDetails{
+					RegistryPath: "gitlab-image:latest",
+				},
+			},
+		},
+		Constraints: constraints.MustParse("mem-request=256M cpu-request=100 container-resources=gitlab.mem-limit=1G"),
+	}), jc.ErrorIsNil)
+
+	ss, err := s.client.AppsV1().StatefulSets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	containers := ss.Spec.Template.Spec.Containers
+	c.Assert(containers, gc.HasLen, 2)
+	requests := corev1.ResourceList{
+		corev1.ResourceMemory: resource.MustParse("256Mi"),
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+	}
+	c.Assert(containers[0].Name, gc.Equals, "charm")
+	c.Assert(containers[0].Resources, jc.DeepEquals, corev1.ResourceRequirements{})
+	c.Assert(containers[1].Name, gc.Equals, "gitlab")
+	c.Assert(containers[1].Resources, jc.DeepEquals, corev1.ResourceRequirements{
+		Requests: requests,
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1024Mi"),
+		},
+	})
+
+	err = app.Ensure(caas.ApplicationConfig{
+		Constraints: constraints.MustParse("container-resources=redis.mem-limit=1G"),
+	})
+	c.Assert(err, gc.ErrorMatches, `configuring resource constraints for "gitlab": container "redis" in container-resources constraint not found`)
+}
+
+func (s *applicationSuite) TestEnsureDaemonAutoscaledNotSupported(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentDaemon, false)
+	err := app.Ensure(caas.ApplicationConfig{
+		Autoscale: &caas.AutoscalePolicy{MinUnits: 1, MaxUnits: 2, TargetCPU: 80},
+	})
+	c.Assert(err, gc.ErrorMatches, `configuring autoscaler for "gitlab": autoscaling "daemon" applications not supported`)
+}
+
+func (s *applicationSuite) TestEnsureAvailability(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	c.Assert(app.Ensure(caas.ApplicationConfig{
+		AgentImagePath: "operator/image-path",
+		CharmBaseImage: coreresources.DockerImageDetails{
+			RegistryPath: "ubuntu:20.04",
+		},
+		Availability: &caas.AvailabilityPolicy{
+			MaxUnavailable: 1,
+			SpreadAcross:   []caas.Topology{caas.TopologyZone, caas.TopologyNode},
+		},
+	}), jc.ErrorIsNil)
+
+	pdb, err := s.client.PolicyV1beta1().PodDisruptionBudgets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pdb.Labels, gc.DeepEquals, map[string]string{
+		"app.kubernetes.io/name":       "gitlab",
+		"app.kubernetes.io/managed-by": "juju",
+	})
+	maxUnavailable := intstr.FromInt(1)
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+	}
+	c.Assert(pdb.Spec, gc.DeepEquals, policyv1beta1.PodDisruptionBudgetSpec{
+		MaxUnavailable: &maxUnavailable,
+		Selector:       selector,
+	})
+
+	ss, err := s.client.AppsV1().StatefulSets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ss.Spec.Template.Spec.TopologySpreadConstraints, gc.DeepEquals, []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     selector,
+	}, {
+		MaxSkew:           1,
+		TopologyKey:       "kubernetes.io/hostname",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     selector,
+	}})
+
+	// Removing the policy removes the disruption budget.
+	s.ensureAutoscaled(c, app, nil)
+	_, err = s.client.PolicyV1beta1().PodDisruptionBudgets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, gc.ErrorMatches, `poddisruptionbudgets.policy "gitlab" not found`)
+}
+
+func (s *applicationSuite) TestEnsureKeepsExternalDisruptionBudget(c *gc.C) {
+	_, err := s.client.PolicyV1beta1().PodDisruptionBudgets("test").Create(context.TODO(),
+		&policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gitlab",
+				Namespace: "test",
+			},
+		}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	s.ensureAutoscaled(c, app, nil)
+	_, err = s.client.PolicyV1beta1().PodDisruptionBudgets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *applicationSuite) TestExistsNotsupported(c *gc.C) {
 	app, _ := s.getApp(c, "notsupported", false)
 	_, err := app.Exists()
@@ -904,6 +1133,92 @@ func (s *applicationSuite) TestStateStateful(c *gc.C) {
 	})
 }
 
+func (s *applicationSuite) TestStateStatefulAutoscaled(c *gc.C) {
+	app, ctrl := s.getApp(c, caas.DeploymentStateful, false)
+	defer ctrl.Finish()
+
+	_, err := s.client.AppsV1().StatefulSets("test").Create(context.TODO(),
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gitlab",
+				Namespace: "test",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: application.Int32Ptr(3),
+			},
+		}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	// The autoscaler need not be created by Juju.
+	_, err = s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Create(context.TODO(),
+		&autoscalingv2beta2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gitlab-hpa",
+				Namespace: "test",
+			},
+			Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+					APIVersion: "apps/v1",
+					Kind:       "StatefulSet",
+					Name:       "gitlab",
+				},
+				MaxReplicas: 5,
+			},
+		}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	appState, err := app.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appState, gc.DeepEquals, caas.ApplicationState{
+		DesiredReplicas: 3,
+		Autoscaled:      true,
+	})
+}
+
+func (s *applicationSuite) TestStateStatefulDisruptionBudget(c *gc.C) {
+	app, ctrl := s.getApp(c, caas.DeploymentStateful, false)
+	defer ctrl.Finish()
+
+	_, err := s.client.AppsV1().StatefulSets("test").Create(context.TODO(),
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gitlab",
+				Namespace: "test",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: application.Int32Ptr(3),
+			},
+		}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.client.PolicyV1beta1().PodDisruptionBudgets("test").Create(context.TODO(),
+		&policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gitlab",
+				Namespace: "test",
+				Labels: map[string]string{
+					"app.kubernetes.io/name":       "gitlab",
+					"app.kubernetes.io/managed-by": "juju",
+				},
+			},
+			Status: policyv1beta1.PodDisruptionBudgetStatus{
+				DisruptionsAllowed: 1,
+				CurrentHealthy:     3,
+				DesiredHealthy:     2,
+			},
+		}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	appState, err := app.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appState, gc.DeepEquals, caas.ApplicationState{
+		DesiredReplicas: 3,
+		DisruptionBudget: &caas.DisruptionBudgetStatus{
+			DisruptionsAllowed: 1,
+			CurrentHealthy:     3,
+			DesiredHealthy:     2,
+		},
+	})
+}
+
 func (s *applicationSuite) TestStateStateless(c *gc.C) {
 	s.assertState(c, caas.DeploymentStateless, func() int {
 		desiredReplicas := 10
@@ -1412,6 +1727,146 @@ func (s *applicationSuite) TestUpdatePortsDaemonUpdate(c *gc.C) {
 	}, false), jc.ErrorIsNil)
 }
 
+func (s *applicationSuite) TestUpdateNetworkPolicy(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	c.Assert(app.UpdateNetworkPolicy(&caas.NetworkPolicy{
+		RelatedApplications: []string{"mariadb"},
+		IngressCIDRs:        []string{"10.0.0.0/24"},
+	}), jc.ErrorIsNil)
+
+	np, err := s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(np.Labels, gc.DeepEquals, map[string]string{
+		"app.kubernetes.io/name":       "gitlab",
+		"app.kubernetes.io/managed-by": "juju",
+	})
+	c.Assert(np.Spec, gc.DeepEquals, networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{{
+			From: []networkingv1.NetworkPolicyPeer{{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+				},
+			}, {
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app.kubernetes.io/name": "mariadb"},
+				},
+			}, {
+				IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/24"},
+			}},
+		}},
+	})
+
+	// A nil policy removes the restriction.
+	c.Assert(app.UpdateNetworkPolicy(nil), jc.ErrorIsNil)
+	_, err = s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, gc.ErrorMatches, `networkpolicies.networking.k8s.io "gitlab" not found`)
+}
+
+func (s *applicationSuite) TestUpdateNetworkPolicyKeepsExternalPolicy(c *gc.C) {
+	_, err := s.client.NetworkingV1().NetworkPolicies("test").Create(context.TODO(),
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gitlab",
+				Namespace: "test",
+			},
+		}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	c.Assert(app.UpdateNetworkPolicy(nil), jc.ErrorIsNil)
+	_, err = s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestUpdateIngress(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	_, err := s.client.CoreV1().Services("test").Create(context.TODO(), &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gitlab",
+			Namespace: "test",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{
+				Name: "placeholder",
+				Port: 65535,
+			}},
+		},
+	}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ingress := &caas.Ingress{
+		Hostname:  "gitlab.example.com",
+		TLSSecret: "gitlab-tls",
+		Class:     "traefik",
+	}
+	// No ingress until the charm opens a port.
+	err = app.UpdateIngress(ingress)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	c.Assert(app.UpdatePorts([]caas.ServicePort{{
+		Name:       "http",
+		Port:       8080,
+		TargetPort: 80,
+		Protocol:   "TCP",
+	}}, false), jc.ErrorIsNil)
+	c.Assert(app.UpdateIngress(ingress), jc.ErrorIsNil)
+
+	ing, err := s.client.NetworkingV1beta1().Ingresses("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ing.Labels, gc.DeepEquals, map[string]string{
+		"app.kubernetes.io/name":       "gitlab",
+		"app.kubernetes.io/managed-by": "juju",
+	})
+	c.Assert(ing.Annotations, gc.DeepEquals, map[string]string{
+		"kubernetes.io/ingress.class": "traefik",
+	})
+	c.Assert(ing.Spec, gc.DeepEquals, networkingv1beta1.IngressSpec{
+		TLS: []networkingv1beta1.IngressTLS{{
+			Hosts:      []string{"gitlab.example.com"},
+			SecretName: "gitlab-tls",
+		}},
+		Rules: []networkingv1beta1.IngressRule{{
+			Host: "gitlab.example.com",
+			IngressRuleValue: networkingv1beta1.IngressRuleValue{
+				HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+					Paths: []networkingv1beta1.HTTPIngressPath{{
+						Path: "/",
+						Backend: networkingv1beta1.IngressBackend{
+							ServiceName: "gitlab",
+							ServicePort: intstr.FromInt(8080),
+						},
+					}},
+				},
+			},
+		}},
+	})
+
+	// A nil ingress removes it.
+	c.Assert(app.UpdateIngress(nil), jc.ErrorIsNil)
+	_, err = s.client.NetworkingV1beta1().Ingresses("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, gc.ErrorMatches, `ingresses.networking.k8s.io "gitlab" not found`)
+}
+
+func (s *applicationSuite) TestUpdateIngressKeepsExternalIngress(c *gc.C) {
+	_, err := s.client.NetworkingV1beta1().Ingresses("test").Create(context.TODO(),
+		&networkingv1beta1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gitlab",
+				Namespace: "test",
+			},
+		}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	c.Assert(app.UpdateIngress(nil), jc.ErrorIsNil)
+	_, err = s.client.NetworkingV1beta1().Ingresses("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *applicationSuite) TestUnits(c *gc.C) {
 	app, _ := s.getApp(c, caas.DeploymentStateful, false)
 
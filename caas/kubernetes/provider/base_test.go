@@ -25,6 +25,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/caas/kubernetes/provider/mocks"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
@@ -158,9 +159,12 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 
 	// init config for each test for easier changing config inside test.
 	cfg, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
-		config.NameKey:              "test",
-		provider.OperatorStorageKey: "",
-		provider.WorkloadStorageKey: "",
+		config.NameKey:                       "test",
+		provider.OperatorStorageKey:          "",
+		provider.WorkloadStorageKey:          "",
+		caas.NetworkPoliciesKey:              false,
+		provider.ImageRegistryMirrorsKey:     "",
+		provider.ImageRegistryCredentialsKey: "",
 	}))
 	c.Assert(err, jc.ErrorIsNil)
 	s.cfg = cfg
@@ -23,6 +23,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	k8swatcher "github.com/juju/juju/caas/kubernetes/provider/watcher"
 	k8swatchertest "github.com/juju/juju/caas/kubernetes/provider/watcher/test"
@@ -55,9 +56,12 @@ func (s *bootstrapSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
 	cfg, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
-		config.NameKey:              "controller-1",
-		provider.OperatorStorageKey: "",
-		provider.WorkloadStorageKey: "",
+		config.NameKey:                       "controller-1",
+		provider.OperatorStorageKey:          "",
+		provider.WorkloadStorageKey:          "",
+		caas.NetworkPoliciesKey:              false,
+		provider.ImageRegistryMirrorsKey:     "",
+		provider.ImageRegistryCredentialsKey: "",
 	}))
 	c.Assert(err, jc.ErrorIsNil)
 	s.cfg = cfg
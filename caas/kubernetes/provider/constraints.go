@@ -16,6 +16,7 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Spaces,
 	constraints.AllocatePublicIP,
+	constraints.Spot,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -23,5 +24,7 @@ var unsupportedConstraints = []string{
 func (k *kubernetesClient) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
 	validator := constraints.NewValidator()
 	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterConflicts([]string{constraints.Mem}, []string{constraints.MemLimit})
+	validator.RegisterConflicts([]string{constraints.CpuPower}, []string{constraints.CpuLimit})
 	return validator, nil
 }
@@ -85,3 +85,27 @@ func (s *ConstraintsSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
+
+func (s *ConstraintsSuite) TestConstraintsValidatorResourceRequirements(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	validator, err := s.broker.ConstraintsValidator(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("mem-request=1G mem-limit=2G cpu-request=250 cpu-limit=500 " +
+		"ephemeral-storage-limit=4G container-resources=charm.mem-limit=256M")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unsupported, gc.HasLen, 0)
+
+	_, err = validator.Validate(constraints.MustParse("mem=1G mem-limit=2G"))
+	c.Assert(err, gc.ErrorMatches, `ambiguous constraints: "mem" overlaps with "mem-limit"`)
+	_, err = validator.Validate(constraints.MustParse("cpu-power=100 cpu-limit=200"))
+	c.Assert(err, gc.ErrorMatches, `ambiguous constraints: "cpu-limit" overlaps with "cpu-power"`)
+
+	// Application limits replace the model's legacy equivalents.
+	merged, err := validator.Merge(constraints.MustParse("mem=1G"), constraints.MustParse("mem-limit=2G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(merged, jc.DeepEquals, constraints.MustParse("mem-limit=2G"))
+}
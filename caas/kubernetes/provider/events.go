@@ -5,13 +5,17 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/juju/errors"
 	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/informers"
 
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -71,3 +75,125 @@ func (k *kubernetesClient) watchEvents(objName string, objKind string) (watcher.
 	)
 	return k.newWatcher(factory.Core().V1().Events().Informer(), objName, k.clock)
 }
+
+var _ caas.EventWatcher = (*kubernetesClient)(nil)
+
+// WatchEvents returns a watcher which notifies when events are
+// reported for objects in the model's namespace.
+func (k *kubernetesClient) WatchEvents() (watcher.NotifyWatcher, error) {
+	factory := informers.NewSharedInformerFactoryWithOptions(k.client(), 0,
+		informers.WithNamespace(k.namespace),
+	)
+	return k.newWatcher(factory.Core().V1().Events().Informer(), k.namespace, k.clock)
+}
+
+// Events returns the events reported for the pods and workloads of
+// Juju applications which were last seen after since. Events for
+// objects which no longer exist are skipped, as there's no way to
+// tell which application they belonged to.
+func (k *kubernetesClient) Events(since time.Time) ([]caas.Event, error) {
+	eventList, err := k.client().CoreV1().Events(k.namespace).List(context.TODO(), v1.ListOptions{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	owners := make(map[string]*eventOwner)
+	var result []caas.Event
+	for _, event := range eventList.Items {
+		lastSeen := eventTime(event)
+		if !lastSeen.After(since) {
+			continue
+		}
+		obj := event.InvolvedObject
+		key := obj.Kind + "/" + obj.Name
+		owner, ok := owners[key]
+		if !ok {
+			if owner, err = k.eventOwner(obj.Kind, obj.Name); err != nil {
+				return nil, errors.Trace(err)
+			}
+			owners[key] = owner
+		}
+		if owner == nil {
+			continue
+		}
+		result = append(result, caas.Event{
+			Id:          string(event.UID),
+			Application: owner.appName,
+			UnitId:      owner.unitId,
+			Object:      key,
+			Warning:     event.Type == core.EventTypeWarning,
+			Reason:      event.Reason,
+			Message:     event.Message,
+			Time:        lastSeen,
+		})
+	}
+	return result, nil
+}
+
+// eventOwner identifies the application, and unit if any, which
+// owns the object an event was reported for.
+type eventOwner struct {
+	appName string
+	unitId  string
+}
+
+// eventOwner returns the owner of the specified object, or nil if
+// the object doesn't exist or isn't part of a Juju application.
+func (k *kubernetesClient) eventOwner(kind, name string) (*eventOwner, error) {
+	var (
+		obj    v1.Object
+		unitId string
+		err    error
+	)
+	opts := v1.GetOptions{}
+	switch kind {
+	case "Pod":
+		var pod *core.Pod
+		if pod, err = k.client().CoreV1().Pods(k.namespace).Get(context.TODO(), name, opts); err == nil {
+			obj, unitId = pod, providerID(pod)
+		}
+	case "StatefulSet":
+		obj, err = k.client().AppsV1().StatefulSets(k.namespace).Get(context.TODO(), name, opts)
+	case "Deployment":
+		obj, err = k.client().AppsV1().Deployments(k.namespace).Get(context.TODO(), name, opts)
+	case "DaemonSet":
+		obj, err = k.client().AppsV1().DaemonSets(k.namespace).Get(context.TODO(), name, opts)
+	default:
+		return nil, nil
+	}
+	if k8serrors.IsNotFound(err) {
+		logger.Tracef("%s %q for event not found", kind, name)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting %s %q for event", kind, name)
+	}
+	appName, ok := appNameForLabels(obj.GetLabels())
+	if !ok {
+		return nil, nil
+	}
+	return &eventOwner{appName: appName, unitId: unitId}, nil
+}
+
+// appNameForLabels returns the name of the Juju application
+// an object with the labels belongs to.
+func appNameForLabels(labels map[string]string) (string, bool) {
+	if appName, ok := labels[constants.LegacyLabelKubernetesAppName]; ok {
+		return appName, true
+	}
+	if labels[constants.LabelKubernetesAppManaged] != "juju" {
+		return "", false
+	}
+	appName, ok := labels[constants.LabelKubernetesAppName]
+	return appName, ok
+}
+
+// eventTime returns when the event was last seen.
+func eventTime(event core.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.FirstTimestamp.Time
+}
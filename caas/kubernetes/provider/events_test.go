@@ -0,0 +1,130 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/juju/juju/caas"
+)
+
+func (s *K8sBrokerSuite) TestEvents(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	since := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	later := since.Add(time.Minute)
+	event := func(uid, kind, name, eventType, reason string, at time.Time) core.Event {
+		return core.Event{
+			ObjectMeta:     v1.ObjectMeta{UID: k8stypes.UID("uid-" + uid)},
+			InvolvedObject: core.ObjectReference{Kind: kind, Name: name},
+			Type:           eventType,
+			Reason:         reason,
+			Message:        reason + " happened",
+			LastTimestamp:  v1.NewTime(at),
+		}
+	}
+	eventList := &core.EventList{Items: []core.Event{
+		event("1", "Pod", "gitlab-0", core.EventTypeWarning, "BackOff", later),
+		event("2", "Pod", "gitlab-0", core.EventTypeNormal, "Pulled", since),
+		event("3", "StatefulSet", "gitlab", core.EventTypeNormal, "SuccessfulCreate", later),
+		event("4", "Pod", "gone-0", core.EventTypeWarning, "Failed", later),
+		event("5", "Pod", "other-0", core.EventTypeWarning, "Failed", later),
+		event("6", "Node", "node-1", core.EventTypeWarning, "NodeNotReady", later),
+		event("7", "Pod", "gitlab-0", core.EventTypeNormal, "Started", later),
+	}}
+	gitlabLabels := map[string]string{
+		"app.kubernetes.io/managed-by": "juju",
+		"app.kubernetes.io/name":       "gitlab",
+	}
+	gitlabPod := &core.Pod{ObjectMeta: v1.ObjectMeta{
+		Name:            "gitlab-0",
+		Labels:          gitlabLabels,
+		OwnerReferences: []v1.OwnerReference{{Kind: "StatefulSet"}},
+	}}
+	otherPod := &core.Pod{ObjectMeta: v1.ObjectMeta{
+		Name:   "other-0",
+		Labels: map[string]string{"app.kubernetes.io/name": "other"},
+	}}
+	gitlabStatefulSet := &apps.StatefulSet{ObjectMeta: v1.ObjectMeta{
+		Name:   "gitlab",
+		Labels: gitlabLabels,
+	}}
+
+	gomock.InOrder(
+		s.mockEvents.EXPECT().List(gomock.Any(), v1.ListOptions{}).Return(eventList, nil),
+		s.mockPods.EXPECT().Get(gomock.Any(), "gitlab-0", v1.GetOptions{}).Return(gitlabPod, nil),
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "gitlab", v1.GetOptions{}).Return(gitlabStatefulSet, nil),
+		s.mockPods.EXPECT().Get(gomock.Any(), "gone-0", v1.GetOptions{}).
+			Return(nil, k8serrors.NewNotFound(schema.GroupResource{}, "gone-0")),
+		s.mockPods.EXPECT().Get(gomock.Any(), "other-0", v1.GetOptions{}).Return(otherPod, nil),
+	)
+
+	events, err := s.broker.Events(since)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, jc.DeepEquals, []caas.Event{{
+		Id:          "uid-1",
+		Application: "gitlab",
+		UnitId:      "gitlab-0",
+		Object:      "Pod/gitlab-0",
+		Warning:     true,
+		Reason:      "BackOff",
+		Message:     "BackOff happened",
+		Time:        later,
+	}, {
+		Id:          "uid-3",
+		Application: "gitlab",
+		Object:      "StatefulSet/gitlab",
+		Reason:      "SuccessfulCreate",
+		Message:     "SuccessfulCreate happened",
+		Time:        later,
+	}, {
+		Id:          "uid-7",
+		Application: "gitlab",
+		UnitId:      "gitlab-0",
+		Object:      "Pod/gitlab-0",
+		Reason:      "Started",
+		Message:     "Started happened",
+		Time:        later,
+	}})
+}
+
+func (s *K8sBrokerSuite) TestEventsLegacyLabels(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	since := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	eventList := &core.EventList{Items: []core.Event{{
+		ObjectMeta:     v1.ObjectMeta{UID: "uid-1"},
+		InvolvedObject: core.ObjectReference{Kind: "Deployment", Name: "juju-gitlab"},
+		Type:           core.EventTypeNormal,
+		Reason:         "ScalingReplicaSet",
+		EventTime:      v1.NewMicroTime(since.Add(time.Second)),
+	}}}
+	deployment := &apps.Deployment{ObjectMeta: v1.ObjectMeta{
+		Name:   "juju-gitlab",
+		Labels: map[string]string{"juju-app": "gitlab"},
+	}}
+	gomock.InOrder(
+		s.mockEvents.EXPECT().List(gomock.Any(), v1.ListOptions{}).Return(eventList, nil),
+		s.mockDeployments.EXPECT().Get(gomock.Any(), "juju-gitlab", v1.GetOptions{}).Return(deployment, nil),
+	)
+
+	events, err := s.broker.Events(since)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Application, gc.Equals, "gitlab")
+	c.Assert(events[0].Object, gc.Equals, "Deployment/juju-gitlab")
+	c.Assert(events[0].Time.Equal(since.Add(time.Second)), jc.IsTrue)
+}
@@ -0,0 +1,181 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package exec
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	debugContainerPrefix     = "juju-debug-"
+	debugContainerPollDelay  = time.Second
+	debugContainerStartTries = 120
+)
+
+// DebugParams holds all the necessary parameters for Debug.
+type DebugParams struct {
+	PodName string
+	// ContainerName is the container whose process namespace
+	// the debug container joins, the first workload container
+	// by default.
+	ContainerName string
+	Image         string
+	// Commands is the debug container's command, "sh" by default.
+	Commands []string
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	TTY    bool
+}
+
+func (dp *DebugParams) validate(podGetter typedcorev1.PodInterface) (err error) {
+	if dp.Image == "" {
+		return errors.NotValidf("empty image")
+	}
+	if len(dp.Commands) == 0 {
+		dp.Commands = []string{"sh"}
+	}
+	if dp.PodName, dp.ContainerName, err = getValidatedPodContainer(
+		podGetter, dp.PodName, dp.ContainerName, workloadContainerName,
+	); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Debug adds an ephemeral container running the debug image to a pod,
+// sharing the process namespace of the target container, and attaches
+// to it. Kubernetes doesn't allow ephemeral containers to be removed,
+// so the debug container's stdin is closed when the session ends which
+// terminates its command.
+func (c client) Debug(params DebugParams, cancel <-chan struct{}) error {
+	if err := params.validate(c.podGetter); err != nil {
+		return errors.Trace(err)
+	}
+	name, err := c.addDebugContainer(params)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = c.waitDebugContainerRunning(params.PodName, name, cancel); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.attach(name, params, cancel))
+}
+
+func (c client) addDebugContainer(params DebugParams) (string, error) {
+	ephemeral, err := c.podGetter.GetEphemeralContainers(context.TODO(), params.PodName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		// The pod exists, so it's the subresource which is missing.
+		return "", errors.NotSupportedf("ephemeral containers on this cluster")
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	name := debugContainerPrefix + randomString(5, utils.LowerAlpha)
+	ephemeral.EphemeralContainers = append(ephemeral.EphemeralContainers, core.EphemeralContainer{
+		EphemeralContainerCommon: core.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    params.Image,
+			Command:                  params.Commands,
+			ImagePullPolicy:          core.PullIfNotPresent,
+			TerminationMessagePolicy: core.TerminationMessageReadFile,
+			Stdin:                    true,
+			StdinOnce:                true,
+			TTY:                      params.TTY,
+		},
+		TargetContainerName: params.ContainerName,
+	})
+	logger.Debugf("adding debug container %q with image %q to pod %q", name, params.Image, params.PodName)
+	_, err = c.podGetter.UpdateEphemeralContainers(context.TODO(), params.PodName, ephemeral, metav1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		return "", errors.NotSupportedf("ephemeral containers on this cluster")
+	}
+	if err != nil {
+		return "", errors.Annotatef(err, "adding debug container to pod %q", params.PodName)
+	}
+	return name, nil
+}
+
+func (c client) waitDebugContainerRunning(podName, containerName string, cancel <-chan struct{}) error {
+	for i := 0; i < debugContainerStartTries; i++ {
+		pod, err := c.podGetter.Get(context.TODO(), podName, metav1.GetOptions{})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, status := range pod.Status.EphemeralContainerStatuses {
+			if status.Name != containerName {
+				continue
+			}
+			if status.State.Running != nil {
+				return nil
+			}
+			if t := status.State.Terminated; t != nil {
+				return errors.Errorf("debug container %q terminated: %s", containerName, t.Reason)
+			}
+			if w := status.State.Waiting; w != nil && w.Message != "" {
+				logger.Debugf("debug container %q waiting: %s", containerName, w.Message)
+			}
+		}
+		select {
+		case <-cancel:
+			return errors.Errorf("waiting for debug container %q cancelled", containerName)
+		case <-c.clock.After(debugContainerPollDelay):
+		}
+	}
+	return errors.Timeoutf("waiting for debug container %q to start", containerName)
+}
+
+func (c client) attach(containerName string, params DebugParams, cancel <-chan struct{}) error {
+	// A TTY merges stderr into stdout.
+	stderr := params.Stderr
+	if params.TTY {
+		stderr = nil
+	}
+	req := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(params.PodName).
+		Namespace(c.namespace).
+		SubResource("attach").
+		VersionedParams(&core.PodAttachOptions{
+			Container: containerName,
+			Stdin:     params.Stdin != nil,
+			Stdout:    params.Stdout != nil,
+			Stderr:    stderr != nil,
+			TTY:       params.TTY,
+		}, scheme.ParameterCodec)
+
+	executor, err := c.remoteCmdExecutorGetter("POST", req.URL())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- c.safeRun(ExecParams{
+			PodName:       params.PodName,
+			ContainerName: containerName,
+			Stdin:         params.Stdin,
+			Stdout:        params.Stdout,
+			Stderr:        stderr,
+			TTY:           params.TTY,
+		}, executor)
+	}()
+	select {
+	case err := <-errChan:
+		return errors.Trace(err)
+	case <-cancel:
+		// Disconnecting closes the debug container's stdin.
+		return errors.Errorf("debug session on pod %q cancelled", params.PodName)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package exec_test
+
+import (
+	"bytes"
+	"net/url"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/juju/juju/caas/kubernetes/provider/exec"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type debugSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&debugSuite{})
+
+func (s *debugSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.PatchValue(exec.RandomString, func(n int, validRunes []rune) string {
+		return "abcde"
+	})
+}
+
+func (s *debugSuite) runningPod() *core.Pod {
+	pod := &core.Pod{
+		Spec: core.PodSpec{
+			Containers: []core.Container{
+				{Name: "gitlab-container"},
+			},
+		},
+		Status: core.PodStatus{
+			Phase: core.PodRunning,
+			ContainerStatuses: []core.ContainerStatus{
+				{Name: "gitlab-container", State: core.ContainerState{Running: &core.ContainerStateRunning{}}},
+			},
+		},
+	}
+	pod.SetName("gitlab-k8s-0")
+	return pod
+}
+
+func (s *debugSuite) TestDebugParamsValidate(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	params := exec.DebugParams{PodName: "gitlab-k8s-0"}
+	c.Assert(params.Validate(s.mockPodGetter), gc.ErrorMatches, "empty image not valid")
+
+	s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).Return(s.runningPod(), nil)
+	params = exec.DebugParams{PodName: "gitlab-k8s-0", Image: "busybox"}
+	c.Assert(params.Validate(s.mockPodGetter), jc.ErrorIsNil)
+	c.Assert(params.ContainerName, gc.Equals, "gitlab-container")
+	c.Assert(params.Commands, jc.DeepEquals, []string{"sh"})
+}
+
+func (s *debugSuite) TestDebugParamsValidateSkipsCharmAndSidecars(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	pod := s.runningPod()
+	pod.SetAnnotations(map[string]string{"sidecar.juju.is/containers": "proxy"})
+	pod.Spec.Containers = []core.Container{{Name: "charm"}, {Name: "proxy"}, {Name: "gitlab-container"}}
+	pod.Status.ContainerStatuses = []core.ContainerStatus{
+		{Name: "charm", State: core.ContainerState{Running: &core.ContainerStateRunning{}}},
+		{Name: "proxy", State: core.ContainerState{Running: &core.ContainerStateRunning{}}},
+		{Name: "gitlab-container", State: core.ContainerState{Running: &core.ContainerStateRunning{}}},
+	}
+	s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).Return(pod, nil)
+	params := exec.DebugParams{PodName: "gitlab-k8s-0", Image: "busybox"}
+	c.Assert(params.Validate(s.mockPodGetter), jc.ErrorIsNil)
+	c.Assert(params.ContainerName, gc.Equals, "gitlab-container")
+}
+
+func (s *debugSuite) TestDebug(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	s.suiteMocks.EXPECT().RemoteCmdExecutorGetter(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(s.mockRemoteCmdExecutor, nil)
+
+	var stdin, stdout, stderr bytes.Buffer
+	params := exec.DebugParams{
+		PodName: "gitlab-k8s-0",
+		Image:   "busybox",
+		Stdin:   &stdin,
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+
+	startedPod := s.runningPod()
+	startedPod.Status.EphemeralContainerStatuses = []core.ContainerStatus{
+		{Name: "juju-debug-abcde", State: core.ContainerState{Running: &core.ContainerStateRunning{}}},
+	}
+	request := rest.NewRequestWithClient(
+		&url.URL{Path: "/path/"},
+		"",
+		rest.ClientContentConfig{GroupVersion: core.SchemeGroupVersion},
+		nil,
+	).Resource("pods").Name("gitlab-k8s-0").Namespace("test").
+		SubResource("attach").VersionedParams(
+		&core.PodAttachOptions{
+			Container: "juju-debug-abcde",
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	gomock.InOrder(
+		s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(s.runningPod(), nil),
+		s.mockPodGetter.EXPECT().GetEphemeralContainers(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(&core.EphemeralContainers{}, nil),
+		s.mockPodGetter.EXPECT().UpdateEphemeralContainers(gomock.Any(), "gitlab-k8s-0", &core.EphemeralContainers{
+			EphemeralContainers: []core.EphemeralContainer{{
+				EphemeralContainerCommon: core.EphemeralContainerCommon{
+					Name:                     "juju-debug-abcde",
+					Image:                    "busybox",
+					Command:                  []string{"sh"},
+					ImagePullPolicy:          core.PullIfNotPresent,
+					TerminationMessagePolicy: core.TerminationMessageReadFile,
+					Stdin:                    true,
+					StdinOnce:                true,
+				},
+				TargetContainerName: "gitlab-container",
+			}},
+		}, metav1.UpdateOptions{}).Return(&core.EphemeralContainers{}, nil),
+		s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(startedPod, nil),
+		s.restClient.EXPECT().Post().Return(request),
+		s.mockRemoteCmdExecutor.EXPECT().Stream(
+			remotecommand.StreamOptions{
+				Stdin:  &stdin,
+				Stdout: &stdout,
+				Stderr: &stderr,
+			},
+		).Return(nil),
+	)
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- s.execClient.Debug(params, make(chan struct{}))
+	}()
+
+	select {
+	case err := <-errChan:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for Debug return")
+	}
+}
+
+func (s *debugSuite) TestDebugWaitsForContainer(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	params := exec.DebugParams{PodName: "gitlab-k8s-0", Image: "busybox"}
+
+	pendingPod := s.runningPod()
+	pendingPod.Status.EphemeralContainerStatuses = []core.ContainerStatus{
+		{Name: "juju-debug-abcde", State: core.ContainerState{Waiting: &core.ContainerStateWaiting{Message: "pulling"}}},
+	}
+	failedPod := s.runningPod()
+	failedPod.Status.EphemeralContainerStatuses = []core.ContainerStatus{
+		{Name: "juju-debug-abcde", State: core.ContainerState{Terminated: &core.ContainerStateTerminated{Reason: "Error"}}},
+	}
+	gomock.InOrder(
+		s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(s.runningPod(), nil),
+		s.mockPodGetter.EXPECT().GetEphemeralContainers(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(&core.EphemeralContainers{}, nil),
+		s.mockPodGetter.EXPECT().UpdateEphemeralContainers(gomock.Any(), "gitlab-k8s-0", gomock.Any(), metav1.UpdateOptions{}).
+			Return(&core.EphemeralContainers{}, nil),
+		s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(pendingPod, nil),
+		s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(failedPod, nil),
+	)
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- s.execClient.Debug(params, make(chan struct{}))
+	}()
+
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case err := <-errChan:
+		c.Assert(err, gc.ErrorMatches, `debug container "juju-debug-abcde" terminated: Error`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for Debug return")
+	}
+}
+
+func (s *debugSuite) TestDebugEphemeralContainersNotSupported(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	params := exec.DebugParams{PodName: "gitlab-k8s-0", Image: "busybox"}
+	gomock.InOrder(
+		s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(s.runningPod(), nil),
+		s.mockPodGetter.EXPECT().GetEphemeralContainers(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(nil, k8serrors.NewNotFound(schema.GroupResource{}, "gitlab-k8s-0")),
+	)
+	err := s.execClient.Debug(params, make(chan struct{}))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "ephemeral containers on this cluster not supported")
+}
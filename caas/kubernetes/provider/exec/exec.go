@@ -14,6 +14,7 @@ import (
 	"time"
 
 	jujuclock "github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/v2"
@@ -41,6 +42,10 @@ const (
 	sigkillRetryDelay = 100 * time.Millisecond
 	gracefulKillDelay = 10 * time.Second
 	maxTries          = 10
+
+	// charmContainerName is the container running the charm
+	// in the pods of sidecar charms.
+	charmContainerName = "charm"
 )
 
 var randomString = utils.RandomString
@@ -56,11 +61,12 @@ type client struct {
 	clock     jujuclock.Clock
 }
 
-// Executor provides the API to exec or cp on a pod inside the cluster.
+// Executor provides the API to exec, cp or debug on a pod inside the cluster.
 type Executor interface {
 	Status(params StatusParams) (*Status, error)
 	Exec(params ExecParams, cancel <-chan struct{}) error
 	Copy(params CopyParams, cancel <-chan struct{}) error
+	Debug(params DebugParams, cancel <-chan struct{}) error
 	RawClient() kubernetes.Interface
 	NameSpace() string
 }
@@ -187,7 +193,7 @@ func (ep *ExecParams) validate(podGetter typedcorev1.PodInterface) (err error) {
 	}
 
 	if ep.PodName, ep.ContainerName, err = getValidatedPodContainer(
-		podGetter, ep.PodName, ep.ContainerName,
+		podGetter, ep.PodName, ep.ContainerName, firstContainerName,
 	); err != nil {
 		return errors.Trace(err)
 	}
@@ -428,8 +434,32 @@ func getValidatedPod(podGetter typedcorev1.PodInterface, podName string) (pod *c
 	return nil, errors.NotFoundf("pod %q", podName)
 }
 
+// firstContainerName returns the name of the first container of the pod.
+func firstContainerName(pod *core.Pod) string {
+	return pod.Spec.Containers[0].Name
+}
+
+// workloadContainerName returns the name of the first container of the pod
+// running the workload, skipping the charm container and any sidecars.
+// The first container is used if there are no others.
+func workloadContainerName(pod *core.Pod) string {
+	skip := set.NewStrings(charmContainerName)
+	if sidecars := pod.Annotations[k8sutils.AnnotationSidecarContainersKey()]; sidecars != "" {
+		skip = skip.Union(set.NewStrings(strings.Split(sidecars, ",")...))
+	}
+	for _, c := range pod.Spec.Containers {
+		if !skip.Contains(c.Name) {
+			return c.Name
+		}
+	}
+	return firstContainerName(pod)
+}
+
+// getValidatedPodContainer checks that the container of the pod exists and is
+// running. The container chosen by defaultContainer is used if none is given.
 func getValidatedPodContainer(
 	podGetter typedcorev1.PodInterface, podName, containerName string,
+	defaultContainer func(*core.Pod) string,
 ) (string, string, error) {
 	pod, err := getValidatedPod(podGetter, podName)
 	if err != nil {
@@ -467,8 +497,8 @@ func getValidatedPodContainer(
 			return "", "", errors.Trace(err)
 		}
 	} else {
-		containerName = pod.Spec.Containers[0].Name
-		logger.Debugf("choose first container %q to exec", containerName)
+		containerName = defaultContainer(pod)
+		logger.Debugf("choose container %q to exec", containerName)
 	}
 
 	matchContainerStatus := func(name string) (*core.ContainerStatus, error) {
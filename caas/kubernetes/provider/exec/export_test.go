@@ -52,3 +52,7 @@ func NewSizeQueueForTest(resizeChan chan remotecommand.TerminalSize, getSize Siz
 		nCh:        nCh,
 	}
 }
+
+func (dp *DebugParams) Validate(podGetter typedcorev1.PodInterface) error {
+	return dp.validate(podGetter)
+}
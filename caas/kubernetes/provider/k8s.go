@@ -765,7 +765,6 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 }
 
 func processConstraints(pod *core.PodSpec, appName string, cons constraints.Value) error {
-	// TODO(caas): Allow constraints to be set at the container level.
 	if mem := cons.Mem; mem != nil {
 		if err := configureConstraint(pod, "memory", fmt.Sprintf("%dMi", *mem)); err != nil {
 			return errors.Annotatef(err, "configuring memory constraint for %s", appName)
@@ -776,6 +775,9 @@ func processConstraints(pod *core.PodSpec, appName string, cons constraints.Valu
 			return errors.Annotatef(err, "configuring cpu constraint for %s", appName)
 		}
 	}
+	if err := utils.ApplyResourceConstraints(pod, cons); err != nil {
+		return errors.Annotatef(err, "configuring resource constraints for %s", appName)
+	}
 
 	// Translate tags to node affinity.
 	if cons.Tags != nil {
@@ -1076,6 +1078,12 @@ func (k *kubernetesClient) ensureService(
 		}
 		cleanups = append(cleanups, func() { _ = k.deleteSecret(imageSecretName, "") })
 	}
+	registry, err := k.imageRegistry()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	registry.ApplyToPodSpec(&workloadSpec.Pod.PodSpec)
+
 	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
 	// Defensively check to see if a stateful set is already used.
 	if params.Deployment.DeploymentType == "" {
@@ -1272,7 +1280,16 @@ func (k *kubernetesClient) configurePodFiles(
 	containers []specs.ContainerSpec,
 	cfgMapName configMapNameFunc,
 ) error {
-	for i, container := range containers {
+	for _, container := range containers {
+		if len(container.VolumeConfig) == 0 {
+			continue
+		}
+		// Init containers are held apart from the other containers
+		// in the pod spec, so find the k8s container by name.
+		podContainer := findContainer(&workloadSpec.Pod.PodSpec, container.Name)
+		if podContainer == nil {
+			return errors.NotFoundf("container %q", container.Name)
+		}
 		for _, fileSet := range container.VolumeConfig {
 			vol, err := k.fileSetToVolume(appName, annotations, workloadSpec, fileSet, cfgMapName)
 			if err != nil {
@@ -1281,7 +1298,7 @@ func (k *kubernetesClient) configurePodFiles(
 			if err = k8sstorage.PushUniqueVolume(&workloadSpec.Pod.PodSpec, vol, false); err != nil {
 				return errors.Trace(err)
 			}
-			workloadSpec.Pod.Containers[i].VolumeMounts = append(workloadSpec.Pod.Containers[i].VolumeMounts, core.VolumeMount{
+			podContainer.VolumeMounts = append(podContainer.VolumeMounts, core.VolumeMount{
 				// TODO(caas): add more config fields support(SubPath, ReadOnly, etc).
 				Name:      vol.Name,
 				MountPath: fileSet.MountPath,
@@ -1291,6 +1308,20 @@ func (k *kubernetesClient) configurePodFiles(
 	return nil
 }
 
+func findContainer(podSpec *core.PodSpec, name string) *core.Container {
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == name {
+			return &podSpec.Containers[i]
+		}
+	}
+	for i := range podSpec.InitContainers {
+		if podSpec.InitContainers[i].Name == name {
+			return &podSpec.InitContainers[i]
+		}
+	}
+	return nil
+}
+
 func (k *kubernetesClient) configureStorage(
 	appName string, legacy bool, uniquePrefix string,
 	filesystems []storage.KubernetesFilesystemParams,
@@ -2402,6 +2433,20 @@ func prepareWorkloadSpec(appName, deploymentName string, podSpec *specs.PodSpec,
 			spec.ServiceAccounts = append(spec.ServiceAccounts, &k8sResources.K8sRBACResources)
 		}
 	}
+	// Record the sidecars so tools working on the pod can tell
+	// them apart from the workload containers.
+	var sidecars []string
+	for _, c := range podSpec.Containers {
+		if c.Sidecar {
+			sidecars = append(sidecars, c.Name)
+		}
+	}
+	if len(sidecars) > 0 {
+		if spec.Pod.Annotations == nil {
+			spec.Pod.Annotations = k8sannotations.New(nil)
+		}
+		spec.Pod.Annotations.Add(utils.AnnotationSidecarContainersKey(), strings.Join(sidecars, ","))
+	}
 	return &spec, nil
 }
 
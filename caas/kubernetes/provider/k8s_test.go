@@ -517,6 +517,50 @@ func (s *K8sSuite) TestPrepareWorkloadSpecWithInitContainers(c *gc.C) {
 	})
 }
 
+func (s *K8sSuite) TestPrepareWorkloadSpecWithSidecarContainers(c *gc.C) {
+	podSpec := specs.PodSpec{}
+	podSpec.Containers = []specs.ContainerSpec{
+		{
+			Name:  "test",
+			Ports: []specs.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
+			Image: "juju/image",
+		}, {
+			Name:    "test-sidecar",
+			Sidecar: true,
+			Image:   "juju/image-sidecar",
+		},
+	}
+
+	spec, err := provider.PrepareWorkloadSpec("app-name", "app-name", &podSpec, "operator/image-path")
+	c.Assert(err, jc.ErrorIsNil)
+	defaultSecurityContext := &core.SecurityContext{
+		RunAsNonRoot:             boolPtr(false),
+		ReadOnlyRootFilesystem:   boolPtr(false),
+		AllowPrivilegeEscalation: boolPtr(true),
+	}
+	c.Assert(provider.Pod(spec), jc.DeepEquals, k8sspecs.PodSpecWithAnnotations{
+		Annotations: annotations.Annotation{"sidecar.juju.is/containers": "test-sidecar"},
+		PodSpec: core.PodSpec{
+			Containers: []core.Container{
+				{
+					Name:            "test",
+					Image:           "juju/image",
+					Ports:           []core.ContainerPort{{ContainerPort: int32(80), Protocol: core.ProtocolTCP}},
+					SecurityContext: defaultSecurityContext,
+					VolumeMounts:    dataVolumeMounts(),
+				}, {
+					Name:            "test-sidecar",
+					Image:           "juju/image-sidecar",
+					SecurityContext: defaultSecurityContext,
+					VolumeMounts:    dataVolumeMounts(),
+				},
+			},
+			InitContainers: initContainers(),
+			Volumes:        dataVolumes(),
+		},
+	})
+}
+
 func (s *K8sSuite) TestPrepareWorkloadSpec(c *gc.C) {
 
 	podSpec := specs.PodSpec{
@@ -1235,6 +1279,118 @@ func (s *K8sBrokerSuite) TestConfigurePodFiles(c *gc.C) {
 	})
 }
 
+func (s *K8sBrokerSuite) TestConfigurePodFilesV4VolumeSources(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	cfgMapName := func(n string) string { return n }
+
+	expirationSeconds := int64(3600)
+	basicPodSpec := getBasicPodspec()
+	basicPodSpec.Containers = []specs.ContainerSpec{{
+		Name:  "test",
+		Image: "juju/image",
+		VolumeConfig: []specs.FileSet{
+			{
+				Name:      "scratch",
+				MountPath: "/scratch",
+				VolumeSource: specs.VolumeSource{
+					Ephemeral: &specs.EphemeralVol{
+						Size: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		},
+	}, {
+		Name:    "test-sidecar",
+		Image:   "juju/image-sidecar",
+		Sidecar: true,
+		VolumeConfig: []specs.FileSet{
+			{
+				Name:      "vault-token",
+				MountPath: "/var/run/secrets/tokens",
+				VolumeSource: specs.VolumeSource{
+					ServiceAccountToken: &specs.ServiceAccountTokenVol{
+						Path:              "vault-token",
+						Audience:          "vault",
+						ExpirationSeconds: &expirationSeconds,
+					},
+				},
+			},
+		},
+	}}
+	workloadSpec, err := provider.PrepareWorkloadSpec("app-name", "app-name", basicPodSpec, "operator/image-path")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.broker.ConfigurePodFiles(
+		"app-name", nil, workloadSpec, basicPodSpec.Containers, cfgMapName,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	size := resource.MustParse("1Gi")
+	c.Assert(workloadSpec.Pod.Volumes, gc.DeepEquals, append(dataVolumes(), []core.Volume{
+		{
+			Name: "scratch",
+			VolumeSource: core.VolumeSource{
+				EmptyDir: &core.EmptyDirVolumeSource{
+					SizeLimit: &size,
+				},
+			},
+		},
+		{
+			Name: "vault-token",
+			VolumeSource: core.VolumeSource{
+				Projected: &core.ProjectedVolumeSource{
+					Sources: []core.VolumeProjection{{
+						ServiceAccountToken: &core.ServiceAccountTokenProjection{
+							Audience:          "vault",
+							ExpirationSeconds: &expirationSeconds,
+							Path:              "vault-token",
+						},
+					}},
+				},
+			},
+		},
+	}...))
+	c.Assert(workloadSpec.Pod.Containers[0].Name, gc.Equals, "test")
+	c.Assert(workloadSpec.Pod.Containers[0].VolumeMounts, gc.DeepEquals, append(dataVolumeMounts(),
+		core.VolumeMount{Name: "scratch", MountPath: "/scratch"},
+	))
+	c.Assert(workloadSpec.Pod.Containers[1].Name, gc.Equals, "test-sidecar")
+	c.Assert(workloadSpec.Pod.Containers[1].VolumeMounts, gc.DeepEquals, append(dataVolumeMounts(),
+		core.VolumeMount{Name: "vault-token", MountPath: "/var/run/secrets/tokens"},
+	))
+}
+
+func (s *K8sBrokerSuite) TestConfigurePodFilesEphemeralStorageClassNotSupported(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := getBasicPodspec()
+	basicPodSpec.Containers = []specs.ContainerSpec{{
+		Name:  "test",
+		Image: "juju/image",
+		VolumeConfig: []specs.FileSet{
+			{
+				Name:      "scratch",
+				MountPath: "/scratch",
+				VolumeSource: specs.VolumeSource{
+					Ephemeral: &specs.EphemeralVol{
+						StorageClassName: "fast",
+						Size:             resource.MustParse("1Gi"),
+					},
+				},
+			},
+		},
+	}}
+	workloadSpec, err := provider.PrepareWorkloadSpec("app-name", "app-name", basicPodSpec, "operator/image-path")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.broker.ConfigurePodFiles(
+		"app-name", nil, workloadSpec, basicPodSpec.Containers, func(n string) string { return n },
+	)
+	c.Assert(err, gc.ErrorMatches, `ephemeral volume "scratch" with storage class "fast" not supported`)
+}
+
 func (s *K8sBrokerSuite) TestAPIVersion(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -1265,6 +1421,86 @@ func (s *K8sBrokerSuite) TestSetConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) imageRegistrySecret(data string) *core.Secret {
+	return &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-image-registry-credentials",
+			Namespace: "test",
+			Labels: map[string]string{
+				"model.juju.is/name":           "test",
+				"app.kubernetes.io/managed-by": "juju",
+			},
+		},
+		Type: core.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			core.DockerConfigJsonKey: []byte(data),
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestSetImageRegistryCredential(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	secret := s.imageRegistrySecret(`{"auths":{"registry.internal":{"Username":"fred","Password":"secret","Email":""}}}`)
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Get(gomock.Any(), "juju-image-registry-credentials", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(gomock.Any(), secret, v1.CreateOptions{}).
+			Return(secret, nil),
+	)
+
+	ref, err := s.broker.SetImageRegistryCredential("registry.internal", "fred", "secret")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ref, gc.Equals, "juju-image-registry-credentials")
+}
+
+func (s *K8sBrokerSuite) TestSetImageRegistryCredentialKeepsOthers(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	existing := s.imageRegistrySecret(`{"auths":{"registry.internal":{"Username":"fred","Password":"secret","Email":""}}}`)
+	secret := s.imageRegistrySecret(`{"auths":{"quay.io":{"Username":"mary","Password":"hidden","Email":""},"registry.internal":{"Username":"fred","Password":"secret","Email":""}}}`)
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Get(gomock.Any(), "juju-image-registry-credentials", v1.GetOptions{}).
+			Return(existing, nil),
+		s.mockSecrets.EXPECT().Create(gomock.Any(), secret, v1.CreateOptions{}).
+			Return(secret, nil),
+	)
+
+	ref, err := s.broker.SetImageRegistryCredential("quay.io", "mary", "hidden")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ref, gc.Equals, "juju-image-registry-credentials")
+}
+
+func (s *K8sBrokerSuite) TestRemoveLastImageRegistryCredential(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	existing := s.imageRegistrySecret(`{"auths":{"registry.internal":{"Username":"fred","Password":"secret","Email":""}}}`)
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Get(gomock.Any(), "juju-image-registry-credentials", v1.GetOptions{}).
+			Return(existing, nil),
+		s.mockSecrets.EXPECT().Delete(gomock.Any(), "juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+	)
+
+	ref, err := s.broker.RemoveImageRegistryCredential("registry.internal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ref, gc.Equals, "")
+}
+
+func (s *K8sBrokerSuite) TestRemoveImageRegistryCredentialNotFound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockSecrets.EXPECT().Get(gomock.Any(), "juju-image-registry-credentials", v1.GetOptions{}).
+		Return(nil, s.k8sNotFoundError())
+
+	_, err := s.broker.RemoveImageRegistryCredential("registry.internal")
+	c.Assert(err, gc.ErrorMatches, `credentials for registry "registry.internal" not found`)
+}
+
 func (s *K8sBrokerSuite) TestBootstrapNoOperatorStorage(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -27,10 +27,13 @@ func fakeConfig(c *gc.C, attrs ...coretesting.Attrs) *config.Config {
 
 func fakeConfigAttrs(attrs ...coretesting.Attrs) coretesting.Attrs {
 	merged := coretesting.FakeConfig().Merge(coretesting.Attrs{
-		"type":             "kubernetes",
-		"uuid":             utils.MustNewUUID().String(),
-		"operator-storage": "",
-		"workload-storage": "",
+		"type":                       "kubernetes",
+		"uuid":                       utils.MustNewUUID().String(),
+		"operator-storage":           "",
+		"workload-storage":           "",
+		"network-policies":           false,
+		"image-registry-mirrors":     "",
+		"image-registry-credentials": "",
 	})
 	for _, attrs := range attrs {
 		merged = merged.Merge(attrs)
@@ -130,3 +133,29 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	validAttrs := validCfg.AllAttrs()
 	c.Assert(config.AllAttrs(), gc.DeepEquals, validAttrs)
 }
+
+func (s *providerSuite) TestImageRegistryConfig(c *gc.C) {
+	cfg := fakeConfig(c, coretesting.Attrs{
+		provider.ImageRegistryMirrorsKey:     "docker.io=registry.internal",
+		provider.ImageRegistryCredentialsKey: "juju-image-registry-credentials",
+	})
+	_, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mirrors, err := provider.ImageRegistryMirrors(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mirrors, jc.DeepEquals, map[string]string{"docker.io": "registry.internal"})
+	c.Assert(provider.ImageRegistryCredentialsSecret(cfg), gc.Equals, "juju-image-registry-credentials")
+}
+
+func (s *providerSuite) TestValidateInvalidImageRegistryConfig(c *gc.C) {
+	_, err := s.provider.Validate(fakeConfig(c, coretesting.Attrs{
+		provider.ImageRegistryMirrorsKey: "docker.io",
+	}), nil)
+	c.Assert(err, gc.ErrorMatches, `invalid k8s provider config: parsing "image-registry-mirrors": image registry mirror "docker.io", expected source=mirror not valid`)
+
+	_, err = s.provider.Validate(fakeConfig(c, coretesting.Attrs{
+		provider.ImageRegistryCredentialsKey: `{"registry.internal": {"username": "fred"}}`,
+	}), nil)
+	c.Assert(err, gc.ErrorMatches, `invalid k8s provider config: image-registry-credentials ".*": .* not valid`)
+}
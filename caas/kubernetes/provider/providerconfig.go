@@ -5,11 +5,16 @@ package provider
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/version"
 	"gopkg.in/juju/environschema.v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
 	"github.com/juju/juju/environs/config"
 )
 
@@ -21,6 +26,18 @@ const (
 	// OperatorStorageKey is the model config attribute used to specify
 	// the storage class for provisioning operator storage.
 	OperatorStorageKey = "operator-storage"
+
+	// ImageRegistryMirrorsKey is the model config attribute used to
+	// rewrite the registry of workload images, as a comma separated
+	// list of source=mirror rules.
+	ImageRegistryMirrorsKey = "image-registry-mirrors"
+
+	// ImageRegistryCredentialsKey is the model config attribute naming
+	// the image pull secret in the model's namespace which holds the
+	// credentials used by workload pods to pull images from private
+	// registries. The credentials themselves are never stored in the
+	// model config.
+	ImageRegistryCredentialsKey = "image-registry-credentials"
 )
 
 var (
@@ -47,6 +64,21 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	caas.NetworkPoliciesKey: {
+		Description: "Whether to restrict ingress to each application to its related applications and exposed CIDRs.",
+		Type:        environschema.Tbool,
+		Group:       environschema.AccountGroup,
+	},
+	ImageRegistryMirrorsKey: {
+		Description: "A comma separated list of source=mirror rules rewriting the registry of workload images, eg docker.io=registry.internal.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	ImageRegistryCredentialsKey: {
+		Description: "The name of the image pull secret holding the credentials used to pull workload images from private registries. Use juju add-registry-credential to change them.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
 }
 
 var providerConfigFields = func() schema.Fields {
@@ -58,8 +90,11 @@ var providerConfigFields = func() schema.Fields {
 }()
 
 var providerConfigDefaults = schema.Defaults{
-	WorkloadStorageKey: "",
-	OperatorStorageKey: "",
+	WorkloadStorageKey:          "",
+	OperatorStorageKey:          "",
+	caas.NetworkPoliciesKey:     false,
+	ImageRegistryMirrorsKey:     "",
+	ImageRegistryCredentialsKey: "",
 }
 
 type brokerConfig struct {
@@ -75,6 +110,22 @@ func (c *brokerConfig) operatorStorage() string {
 	return c.attrs[OperatorStorageKey].(string)
 }
 
+// ImageRegistryMirrors returns the registry mirror rules set in
+// the model config, keyed by the registry they replace.
+func ImageRegistryMirrors(cfg *config.Config) (map[string]string, error) {
+	value, _ := cfg.AllAttrs()[ImageRegistryMirrorsKey].(string)
+	mirrors, err := utils.ParseImageRegistryMirrors(value)
+	return mirrors, errors.Annotatef(err, "parsing %q", ImageRegistryMirrorsKey)
+}
+
+// ImageRegistryCredentialsSecret returns the name of the image pull
+// secret holding the private registry credentials set in the model
+// config, or an empty string if there are none.
+func ImageRegistryCredentialsSecret(cfg *config.Config) string {
+	value, _ := cfg.AllAttrs()[ImageRegistryCredentialsKey].(string)
+	return value
+}
+
 func (p kubernetesEnvironProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
 	newCfg, err := validateConfig(cfg, old)
 	if err != nil {
@@ -123,5 +174,13 @@ func validateConfig(cfg, old *config.Config) (*brokerConfig, error) {
 	}
 
 	bcfg := &brokerConfig{cfg, validated}
+	if _, err := ImageRegistryMirrors(cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if secretName := ImageRegistryCredentialsSecret(cfg); secretName != "" {
+		if msgs := validation.IsDNS1123Subdomain(secretName); len(msgs) > 0 {
+			return nil, errors.NotValidf("%s %q: %s", ImageRegistryCredentialsKey, secretName, strings.Join(msgs, ", "))
+		}
+	}
 	return bcfg, nil
 }
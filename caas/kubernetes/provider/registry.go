@@ -0,0 +1,120 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+)
+
+// imageRegistry returns how workload images are pulled according to
+// the model config.
+func (k *kubernetesClient) imageRegistry() (utils.ImageRegistry, error) {
+	cfg := k.Config()
+	mirrors, err := ImageRegistryMirrors(cfg)
+	if err != nil {
+		return utils.ImageRegistry{}, errors.Trace(err)
+	}
+	registry := utils.ImageRegistry{Mirrors: mirrors}
+	if secretName := ImageRegistryCredentialsSecret(cfg); secretName != "" {
+		registry.PullSecrets = []string{secretName}
+	}
+	return registry, nil
+}
+
+var _ caas.ImageRegistryCredentialManager = (*kubernetesClient)(nil)
+
+// SetImageRegistryCredential is part of the caas.ImageRegistryCredentialManager
+// interface. The credentials are held in an image pull secret in the model's
+// namespace, whose name is returned.
+func (k *kubernetesClient) SetImageRegistryCredential(registry, username, password string) (string, error) {
+	creds, err := k.imageRegistryCredentials()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	creds[registry] = DockerConfigEntry{
+		Username: username,
+		Password: password,
+	}
+	if err := k.ensureImageRegistrySecret(creds); err != nil {
+		return "", errors.Trace(err)
+	}
+	return utils.ImageRegistrySecretName, nil
+}
+
+// RemoveImageRegistryCredential is part of the caas.ImageRegistryCredentialManager
+// interface. The image pull secret is deleted along with the last credential.
+func (k *kubernetesClient) RemoveImageRegistryCredential(registry string) (string, error) {
+	creds, err := k.imageRegistryCredentials()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if _, ok := creds[registry]; !ok {
+		return "", errors.NotFoundf("credentials for registry %q", registry)
+	}
+	delete(creds, registry)
+	if len(creds) > 0 {
+		if err := k.ensureImageRegistrySecret(creds); err != nil {
+			return "", errors.Trace(err)
+		}
+		return utils.ImageRegistrySecretName, nil
+	}
+	logger.Debugf("removing image registry secret")
+	if err := k.deleteSecret(utils.ImageRegistrySecretName, ""); err != nil {
+		return "", errors.Annotate(err, "removing image registry secret")
+	}
+	return "", nil
+}
+
+// imageRegistryCredentials returns the private registry credentials
+// held in the model's image pull secret, keyed by registry.
+func (k *kubernetesClient) imageRegistryCredentials() (DockerConfig, error) {
+	secret, err := k.getSecret(utils.ImageRegistrySecretName)
+	if errors.IsNotFound(err) {
+		return make(DockerConfig), nil
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "getting image registry secret")
+	}
+	var config DockerConfigJSON
+	if err := json.Unmarshal(secret.Data[core.DockerConfigJsonKey], &config); err != nil {
+		return nil, errors.Annotate(err, "parsing image registry secret")
+	}
+	if config.Auths == nil {
+		config.Auths = make(DockerConfig)
+	}
+	return config.Auths, nil
+}
+
+// ensureImageRegistrySecret creates or updates the secret holding the
+// model's private registry credentials.
+func (k *kubernetesClient) ensureImageRegistrySecret(creds DockerConfig) error {
+	secretData, err := json.Marshal(DockerConfigJSON{Auths: creds})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	secret := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      utils.ImageRegistrySecretName,
+			Namespace: k.namespace,
+			Labels: utils.LabelsMerge(
+				utils.LabelsForModel(k.CurrentModel(), k.IsLegacyLabels()),
+				utils.LabelsJuju,
+			),
+		},
+		Type: core.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			core.DockerConfigJsonKey: secretData,
+		},
+	}
+	logger.Debugf("ensuring image registry secret for %d registries", len(creds))
+	_, err = k.ensureSecret(secret)
+	return errors.Annotate(err, "ensuring image registry secret")
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/core/status"
+)
+
+// HorizontalPodAutoscaler extends the k8s horizontal pod autoscaler.
+type HorizontalPodAutoscaler struct {
+	autoscalingv2beta2.HorizontalPodAutoscaler
+}
+
+// NewHorizontalPodAutoscaler creates a new horizontal pod autoscaler resource.
+func NewHorizontalPodAutoscaler(name string, namespace string, in *autoscalingv2beta2.HorizontalPodAutoscaler) *HorizontalPodAutoscaler {
+	if in == nil {
+		in = &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	}
+	in.SetName(name)
+	in.SetNamespace(namespace)
+	return &HorizontalPodAutoscaler{*in}
+}
+
+// Clone returns a copy of the resource.
+func (hpa *HorizontalPodAutoscaler) Clone() Resource {
+	clone := *hpa
+	return &clone
+}
+
+// Apply patches the resource change.
+func (hpa *HorizontalPodAutoscaler) Apply(ctx context.Context, client kubernetes.Interface) error {
+	api := client.AutoscalingV2beta2().HorizontalPodAutoscalers(hpa.Namespace)
+	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, &hpa.HorizontalPodAutoscaler)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	res, err := api.Patch(ctx, hpa.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{
+		FieldManager: JujuFieldManager,
+	})
+	if k8serrors.IsNotFound(err) {
+		res, err = api.Create(ctx, &hpa.HorizontalPodAutoscaler, metav1.CreateOptions{
+			FieldManager: JujuFieldManager,
+		})
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hpa.HorizontalPodAutoscaler = *res
+	return nil
+}
+
+// Get refreshes the resource.
+func (hpa *HorizontalPodAutoscaler) Get(ctx context.Context, client kubernetes.Interface) error {
+	api := client.AutoscalingV2beta2().HorizontalPodAutoscalers(hpa.Namespace)
+	res, err := api.Get(ctx, hpa.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NewNotFound(err, "k8s")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	hpa.HorizontalPodAutoscaler = *res
+	return nil
+}
+
+// Delete removes the resource.
+func (hpa *HorizontalPodAutoscaler) Delete(ctx context.Context, client kubernetes.Interface) error {
+	api := client.AutoscalingV2beta2().HorizontalPodAutoscalers(hpa.Namespace)
+	err := api.Delete(ctx, hpa.Name, metav1.DeleteOptions{
+		PropagationPolicy: k8sconstants.DefaultPropagationPolicy(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Events emitted by the resource.
+func (hpa *HorizontalPodAutoscaler) Events(ctx context.Context, client kubernetes.Interface) ([]corev1.Event, error) {
+	return ListEventsForObject(ctx, client, hpa.Namespace, hpa.Name, "HorizontalPodAutoscaler")
+}
+
+// ComputeStatus returns a juju status for the resource.
+func (hpa *HorizontalPodAutoscaler) ComputeStatus(ctx context.Context, client kubernetes.Interface, now time.Time) (string, status.Status, time.Time, error) {
+	if hpa.DeletionTimestamp != nil {
+		return "", status.Terminated, hpa.DeletionTimestamp.Time, nil
+	}
+	if hpa.Status.CurrentReplicas == hpa.Status.DesiredReplicas {
+		return "", status.Active, now, nil
+	}
+	return "", status.Waiting, now, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/core/status"
+)
+
+// Ingress extends the k8s ingress.
+type Ingress struct {
+	networkingv1beta1.Ingress
+}
+
+// NewIngress creates a new ingress resource.
+func NewIngress(name string, namespace string, in *networkingv1beta1.Ingress) *Ingress {
+	if in == nil {
+		in = &networkingv1beta1.Ingress{}
+	}
+	in.SetName(name)
+	in.SetNamespace(namespace)
+	return &Ingress{*in}
+}
+
+// Clone returns a copy of the resource.
+func (ig *Ingress) Clone() Resource {
+	clone := *ig
+	return &clone
+}
+
+// Apply patches the resource change.
+func (ig *Ingress) Apply(ctx context.Context, client kubernetes.Interface) error {
+	api := client.NetworkingV1beta1().Ingresses(ig.Namespace)
+	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, &ig.Ingress)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	res, err := api.Patch(ctx, ig.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{
+		FieldManager: JujuFieldManager,
+	})
+	if k8serrors.IsNotFound(err) {
+		res, err = api.Create(ctx, &ig.Ingress, metav1.CreateOptions{
+			FieldManager: JujuFieldManager,
+		})
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ig.Ingress = *res
+	return nil
+}
+
+// Get refreshes the resource.
+func (ig *Ingress) Get(ctx context.Context, client kubernetes.Interface) error {
+	api := client.NetworkingV1beta1().Ingresses(ig.Namespace)
+	res, err := api.Get(ctx, ig.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NewNotFound(err, "k8s")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	ig.Ingress = *res
+	return nil
+}
+
+// Delete removes the resource.
+func (ig *Ingress) Delete(ctx context.Context, client kubernetes.Interface) error {
+	api := client.NetworkingV1beta1().Ingresses(ig.Namespace)
+	err := api.Delete(ctx, ig.Name, metav1.DeleteOptions{
+		PropagationPolicy: k8sconstants.DefaultPropagationPolicy(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Events emitted by the resource.
+func (ig *Ingress) Events(ctx context.Context, client kubernetes.Interface) ([]corev1.Event, error) {
+	return ListEventsForObject(ctx, client, ig.Namespace, ig.Name, "Ingress")
+}
+
+// ComputeStatus returns a juju status for the resource.
+func (ig *Ingress) ComputeStatus(ctx context.Context, client kubernetes.Interface, now time.Time) (string, status.Status, time.Time, error) {
+	if ig.DeletionTimestamp != nil {
+		return "", status.Terminated, ig.DeletionTimestamp.Time, nil
+	}
+	return "", status.Active, now, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
+)
+
+type ingressSuite struct {
+	resourceSuite
+}
+
+var _ = gc.Suite(&ingressSuite{})
+
+func (s *ingressSuite) TestApply(c *gc.C) {
+	ig := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ig1",
+			Namespace: "test",
+		},
+		Spec: networkingv1beta1.IngressSpec{
+			Rules: []networkingv1beta1.IngressRule{{Host: "foo.example.com"}},
+		},
+	}
+	// Create.
+	igResource := resources.NewIngress("ig1", "test", ig)
+	c.Assert(igResource.Apply(context.TODO(), s.client), jc.ErrorIsNil)
+	result, err := s.client.NetworkingV1beta1().Ingresses("test").Get(context.TODO(), "ig1", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Spec.Rules, jc.DeepEquals, []networkingv1beta1.IngressRule{{Host: "foo.example.com"}})
+
+	// Update.
+	ig.Spec.TLS = []networkingv1beta1.IngressTLS{{SecretName: "foo-tls"}}
+	igResource = resources.NewIngress("ig1", "test", ig)
+	c.Assert(igResource.Apply(context.TODO(), s.client), jc.ErrorIsNil)
+
+	result, err = s.client.NetworkingV1beta1().Ingresses("test").Get(context.TODO(), "ig1", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.GetName(), gc.Equals, `ig1`)
+	c.Assert(result.GetNamespace(), gc.Equals, `test`)
+	c.Assert(result.Spec.TLS, gc.HasLen, 1)
+}
+
+func (s *ingressSuite) TestGet(c *gc.C) {
+	template := networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ig1",
+			Namespace: "test",
+		},
+	}
+	ig1 := template
+	ig1.SetAnnotations(map[string]string{"a": "b"})
+	_, err := s.client.NetworkingV1beta1().Ingresses("test").Create(context.TODO(), &ig1, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	igResource := resources.NewIngress("ig1", "test", &template)
+	c.Assert(len(igResource.GetAnnotations()), gc.Equals, 0)
+	err = igResource.Get(context.TODO(), s.client)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(igResource.GetName(), gc.Equals, `ig1`)
+	c.Assert(igResource.GetNamespace(), gc.Equals, `test`)
+	c.Assert(igResource.GetAnnotations(), gc.DeepEquals, map[string]string{"a": "b"})
+}
+
+func (s *ingressSuite) TestDelete(c *gc.C) {
+	ig := networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ig1",
+			Namespace: "test",
+		},
+	}
+	_, err := s.client.NetworkingV1beta1().Ingresses("test").Create(context.TODO(), &ig, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	igResource := resources.NewIngress("ig1", "test", &ig)
+	err = igResource.Delete(context.TODO(), s.client)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = igResource.Get(context.TODO(), s.client)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.client.NetworkingV1beta1().Ingresses("test").Get(context.TODO(), "ig1", metav1.GetOptions{})
+	c.Assert(err, jc.Satisfies, k8serrors.IsNotFound)
+
+	// Deleting a missing ingress is not an error.
+	err = igResource.Delete(context.TODO(), s.client)
+	c.Assert(err, jc.ErrorIsNil)
+}
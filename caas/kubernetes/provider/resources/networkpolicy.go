@@ -0,0 +1,105 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/core/status"
+)
+
+// NetworkPolicy extends the k8s network policy.
+type NetworkPolicy struct {
+	networkingv1.NetworkPolicy
+}
+
+// NewNetworkPolicy creates a new network policy resource.
+func NewNetworkPolicy(name string, namespace string, in *networkingv1.NetworkPolicy) *NetworkPolicy {
+	if in == nil {
+		in = &networkingv1.NetworkPolicy{}
+	}
+	in.SetName(name)
+	in.SetNamespace(namespace)
+	return &NetworkPolicy{*in}
+}
+
+// Clone returns a copy of the resource.
+func (np *NetworkPolicy) Clone() Resource {
+	clone := *np
+	return &clone
+}
+
+// Apply patches the resource change.
+func (np *NetworkPolicy) Apply(ctx context.Context, client kubernetes.Interface) error {
+	api := client.NetworkingV1().NetworkPolicies(np.Namespace)
+	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, &np.NetworkPolicy)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	res, err := api.Patch(ctx, np.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{
+		FieldManager: JujuFieldManager,
+	})
+	if k8serrors.IsNotFound(err) {
+		res, err = api.Create(ctx, &np.NetworkPolicy, metav1.CreateOptions{
+			FieldManager: JujuFieldManager,
+		})
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	np.NetworkPolicy = *res
+	return nil
+}
+
+// Get refreshes the resource.
+func (np *NetworkPolicy) Get(ctx context.Context, client kubernetes.Interface) error {
+	api := client.NetworkingV1().NetworkPolicies(np.Namespace)
+	res, err := api.Get(ctx, np.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NewNotFound(err, "k8s")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	np.NetworkPolicy = *res
+	return nil
+}
+
+// Delete removes the resource.
+func (np *NetworkPolicy) Delete(ctx context.Context, client kubernetes.Interface) error {
+	api := client.NetworkingV1().NetworkPolicies(np.Namespace)
+	err := api.Delete(ctx, np.Name, metav1.DeleteOptions{
+		PropagationPolicy: k8sconstants.DefaultPropagationPolicy(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Events emitted by the resource.
+func (np *NetworkPolicy) Events(ctx context.Context, client kubernetes.Interface) ([]corev1.Event, error) {
+	return ListEventsForObject(ctx, client, np.Namespace, np.Name, "NetworkPolicy")
+}
+
+// ComputeStatus returns a juju status for the resource.
+func (np *NetworkPolicy) ComputeStatus(ctx context.Context, client kubernetes.Interface, now time.Time) (string, status.Status, time.Time, error) {
+	if np.DeletionTimestamp != nil {
+		return "", status.Terminated, np.DeletionTimestamp.Time, nil
+	}
+	return "", status.Active, now, nil
+}
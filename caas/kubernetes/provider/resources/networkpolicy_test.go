@@ -0,0 +1,98 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
+)
+
+type networkPolicySuite struct {
+	resourceSuite
+}
+
+var _ = gc.Suite(&networkPolicySuite{})
+
+func (s *networkPolicySuite) TestApply(c *gc.C) {
+	np := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "np1",
+			Namespace: "test",
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	// Create.
+	npResource := resources.NewNetworkPolicy("np1", "test", np)
+	c.Assert(npResource.Apply(context.TODO(), s.client), jc.ErrorIsNil)
+	result, err := s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "np1", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Spec.PolicyTypes, jc.DeepEquals, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress})
+
+	// Update.
+	np.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{}}
+	npResource = resources.NewNetworkPolicy("np1", "test", np)
+	c.Assert(npResource.Apply(context.TODO(), s.client), jc.ErrorIsNil)
+
+	result, err = s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "np1", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.GetName(), gc.Equals, `np1`)
+	c.Assert(result.GetNamespace(), gc.Equals, `test`)
+	c.Assert(result.Spec.Ingress, gc.HasLen, 1)
+}
+
+func (s *networkPolicySuite) TestGet(c *gc.C) {
+	template := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "np1",
+			Namespace: "test",
+		},
+	}
+	np1 := template
+	np1.SetAnnotations(map[string]string{"a": "b"})
+	_, err := s.client.NetworkingV1().NetworkPolicies("test").Create(context.TODO(), &np1, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	npResource := resources.NewNetworkPolicy("np1", "test", &template)
+	c.Assert(len(npResource.GetAnnotations()), gc.Equals, 0)
+	err = npResource.Get(context.TODO(), s.client)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(npResource.GetName(), gc.Equals, `np1`)
+	c.Assert(npResource.GetNamespace(), gc.Equals, `test`)
+	c.Assert(npResource.GetAnnotations(), gc.DeepEquals, map[string]string{"a": "b"})
+}
+
+func (s *networkPolicySuite) TestDelete(c *gc.C) {
+	np := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "np1",
+			Namespace: "test",
+		},
+	}
+	_, err := s.client.NetworkingV1().NetworkPolicies("test").Create(context.TODO(), &np, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	npResource := resources.NewNetworkPolicy("np1", "test", &np)
+	err = npResource.Delete(context.TODO(), s.client)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = npResource.Get(context.TODO(), s.client)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "np1", metav1.GetOptions{})
+	c.Assert(err, jc.Satisfies, k8serrors.IsNotFound)
+
+	// Deleting a missing network policy is not an error.
+	err = npResource.Delete(context.TODO(), s.client)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -34,10 +34,10 @@ func (m *MockPodSpecConverter) EXPECT() *MockPodSpecConverterMockRecorder {
 }
 
 // ToLatest mocks base method
-func (m *MockPodSpecConverter) ToLatest() *specs.PodSpecV3 {
+func (m *MockPodSpecConverter) ToLatest() *specs.PodSpecV4 {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ToLatest")
-	ret0, _ := ret[0].(*specs.PodSpecV3)
+	ret0, _ := ret[0].(*specs.PodSpecV4)
 	return ret0
 }
 
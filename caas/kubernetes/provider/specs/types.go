@@ -21,7 +21,7 @@ var logger = loggo.GetLogger("juju.kubernetes.provider.specs")
 
 type (
 	// K8sPodSpec is the current k8s pod spec.
-	K8sPodSpec = K8sPodSpecV3
+	K8sPodSpec = K8sPodSpecV4
 )
 
 type k8sContainer struct {
@@ -52,6 +52,7 @@ func (c *k8sContainer) ToContainerSpec() specs.ContainerSpec {
 		ImageDetails:    c.ImageDetails,
 		Name:            c.Name,
 		Init:            c.Init,
+		Sidecar:         c.Sidecar,
 		Image:           c.Image,
 		Ports:           c.Ports,
 		Command:         c.Command,
@@ -114,7 +115,11 @@ func (ps PodSpec) IsEmpty() bool {
 		len(ps.ReadinessGates) == 0 &&
 		len(ps.Labels) == 0 &&
 		len(ps.Annotations) == 0 &&
-		ps.DNSPolicy == ""
+		ps.DNSPolicy == "" &&
+		!ps.HostNetwork &&
+		!ps.HostPID &&
+		ps.PriorityClassName == "" &&
+		ps.Priority == nil
 }
 
 type k8sContainers struct {
@@ -134,6 +139,17 @@ func (cs *k8sContainers) Validate() error {
 	return nil
 }
 
+// validateVersion returns an error if any container uses features not
+// supported by the pod spec version.
+func (cs *k8sContainers) validateVersion(ver specs.Version) error {
+	for _, c := range cs.Containers {
+		if err := c.ValidateVersion(ver); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 func validateLabels(labels map[string]string) error {
 	for k, v := range labels {
 		if errs := validation.IsQualifiedName(k); len(errs) != 0 {
@@ -202,6 +218,8 @@ type PodSpecConverter interface {
 
 func getParser(specVersion specs.Version) (parserType, error) {
 	switch specVersion {
+	case specs.Version4:
+		return parsePodSpecV4, nil
 	case specs.Version3:
 		return parsePodSpecV3, nil
 	case specs.Version2:
@@ -35,12 +35,15 @@ func (p podSpecV3) Validate() error {
 	if err := p.k8sContainers.Validate(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := p.k8sContainers.validateVersion(specs.Version3); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
 func (p podSpecV3) ToLatest() *specs.PodSpec {
-	pSpec := &specs.PodSpec{}
-	pSpec.Version = specs.CurrentVersion
+	pSpec := &specs.PodSpecV3{}
+	pSpec.Version = specs.Version3
 	for _, c := range p.Containers {
 		pSpec.Containers = append(pSpec.Containers, c.ToContainerSpec())
 	}
@@ -48,7 +51,7 @@ func (p podSpecV3) ToLatest() *specs.PodSpec {
 	pSpec.ConfigMaps = p.caaSSpecV3.ConfigMaps
 	pSpec.ServiceAccount = p.caaSSpecV3.ServiceAccount
 	pSpec.ProviderPod = &p.K8sPodSpecV3
-	return pSpec
+	return pSpec.ToV4()
 }
 
 // K8sServiceAccountSpec defines spec for referencing or creating additional RBAC resources.
@@ -0,0 +1,57 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/caas/specs"
+)
+
+type caaSSpecV4 = specs.PodSpecV4
+
+// K8sPodSpecV4 is unchanged from version 3; the new features of
+// version 4 are all defined on containers and volumes.
+type K8sPodSpecV4 = K8sPodSpecV3
+
+type podSpecV4 struct {
+	caaSSpecV4    `json:",inline" yaml:",inline"`
+	K8sPodSpecV4  `json:",inline" yaml:",inline"`
+	k8sContainers `json:",inline" yaml:",inline"`
+}
+
+// Validate is defined on ProviderPod.
+func (p podSpecV4) Validate() error {
+	if err := p.K8sPodSpecV4.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.k8sContainers.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (p podSpecV4) ToLatest() *specs.PodSpec {
+	pSpec := &specs.PodSpec{}
+	pSpec.Version = specs.CurrentVersion
+	for _, c := range p.Containers {
+		pSpec.Containers = append(pSpec.Containers, c.ToContainerSpec())
+	}
+	pSpec.Service = p.caaSSpecV4.Service
+	pSpec.ConfigMaps = p.caaSSpecV4.ConfigMaps
+	pSpec.ServiceAccount = p.caaSSpecV4.ServiceAccount
+	pSpec.ProviderPod = &p.K8sPodSpecV4
+	return pSpec
+}
+
+func parsePodSpecV4(in string) (_ PodSpecConverter, err error) {
+	var spec podSpecV4
+	decoder := newStrictYAMLOrJSONDecoder(strings.NewReader(in), len(in))
+	if err = decoder.Decode(&spec); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &spec, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/caas/specs"
+	"github.com/juju/juju/testing"
+)
+
+type v4SpecsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&v4SpecsSuite{})
+
+var version4Header = `
+version: 4
+`[1:]
+
+func (s *v4SpecsSuite) TestParse(c *gc.C) {
+	specStr := version4Header + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    volumeConfig:
+      - name: scratch
+        mountPath: /scratch
+        ephemeral:
+          size: 1Gi
+      - name: vault-token
+        mountPath: /var/run/secrets/tokens
+        serviceAccountToken:
+          path: vault-token
+          audience: vault
+          expirationSeconds: 7200
+  - name: envoy
+    image: envoy/latest
+    sidecar: true
+  - name: migrate
+    image: gitlab/latest
+    init: true
+`[1:]
+
+	spec, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Version, gc.Equals, specs.Version4)
+	c.Assert(spec.Containers, gc.HasLen, 3)
+
+	expirationSeconds := int64(7200)
+	c.Assert(spec.Containers[0].VolumeConfig, jc.DeepEquals, []specs.FileSet{
+		{
+			Name:      "scratch",
+			MountPath: "/scratch",
+			VolumeSource: specs.VolumeSource{
+				Ephemeral: &specs.EphemeralVol{
+					Size: resource.MustParse("1Gi"),
+				},
+			},
+		}, {
+			Name:      "vault-token",
+			MountPath: "/var/run/secrets/tokens",
+			VolumeSource: specs.VolumeSource{
+				ServiceAccountToken: &specs.ServiceAccountTokenVol{
+					Path:              "vault-token",
+					Audience:          "vault",
+					ExpirationSeconds: &expirationSeconds,
+				},
+			},
+		},
+	})
+	c.Assert(spec.Containers[1].Sidecar, jc.IsTrue)
+	c.Assert(spec.Containers[2].Init, jc.IsTrue)
+}
+
+func (s *v4SpecsSuite) TestValidateInitAndSidecar(c *gc.C) {
+	specStr := version4Header + `
+containers:
+  - name: envoy
+    image: envoy/latest
+    init: true
+    sidecar: true
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `container "envoy" both init and sidecar not valid`)
+}
+
+func (s *v4SpecsSuite) TestV4FeaturesRejectedByV3(c *gc.C) {
+	specStr := version3Header + `
+containers:
+  - name: envoy
+    image: envoy/latest
+    sidecar: true
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `container "envoy" requires pod spec version 4, but found 3`)
+
+	specStr = version3Header + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    volumeConfig:
+      - name: scratch
+        mountPath: /scratch
+        ephemeral:
+          size: 1Gi
+`[1:]
+
+	_, err = k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `container "gitlab" requires pod spec version 4, but found 3`)
+}
+
+func (s *v4SpecsSuite) TestV3UpgradedToLatest(c *gc.C) {
+	specStr := version3Header + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	spec, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Version, gc.Equals, specs.CurrentVersion)
+	c.Assert(spec.Containers[0].Sidecar, jc.IsFalse)
+}
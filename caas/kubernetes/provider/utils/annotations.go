@@ -124,6 +124,12 @@ func AnnotationDisableNameKey(legacy bool) string {
 	return annotationKey("model", "disable-prefix", false)
 }
 
+// AnnotationSidecarContainersKey returns the key used in annotations
+// to list the sidecar containers of a workload pod.
+func AnnotationSidecarContainersKey() string {
+	return annotationKey("sidecar", "containers", false)
+}
+
 // AnnotationKeyApplicationUUID is the key of annotation for recording pvc unique ID.
 func AnnotationKeyApplicationUUID(legacy bool) string {
 	if legacy {
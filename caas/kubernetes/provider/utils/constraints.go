@@ -0,0 +1,139 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utils
+
+import (
+	"fmt"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/juju/juju/core/constraints"
+)
+
+// ApplyResourceConstraints sets the resource requests and limits
+// described by cons on the workload containers of the pod spec. Any
+// values set by the constraints replace those already on the containers.
+// The mem and cpu-power constraints are used as limits when mem-limit and
+// cpu-limit are not specified. Containers named in agentContainers, such
+// as the charm container, are not workloads and are left untouched.
+func ApplyResourceConstraints(pod *core.PodSpec, cons constraints.Value, agentContainers ...string) error {
+	overrides, err := cons.ContainerResourceConstraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	agents := set.NewStrings(agentContainers...)
+	for name := range overrides {
+		if agents.Contains(name) || !hasContainer(pod, name) {
+			return errors.NotFoundf("container %q in %s constraint", name, constraints.ContainerResources)
+		}
+	}
+	for i := range pod.Containers {
+		container := &pod.Containers[i]
+		if agents.Contains(container.Name) {
+			continue
+		}
+		containerCons := overrides[container.Name]
+		if err := applyResourceConstraints(&container.Resources, cons, containerCons); err != nil {
+			return errors.Annotatef(err, "container %q", container.Name)
+		}
+	}
+	return nil
+}
+
+func hasContainer(pod *core.PodSpec, name string) bool {
+	for _, container := range pod.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceConstraint describes how a pair of request and limit
+// constraints map onto a kind of compute resource.
+type resourceConstraint struct {
+	name         core.ResourceName
+	format       string
+	request      func(constraints.Value) *uint64
+	limit        func(constraints.Value) *uint64
+	requestLabel string
+	limitLabel   string
+}
+
+var resourceConstraints = []resourceConstraint{{
+	name:   core.ResourceMemory,
+	format: "%dMi",
+	request: func(cons constraints.Value) *uint64 {
+		return cons.MemRequest
+	},
+	limit: func(cons constraints.Value) *uint64 {
+		if cons.MemLimit != nil {
+			return cons.MemLimit
+		}
+		return cons.Mem
+	},
+	requestLabel: constraints.MemRequest,
+	limitLabel:   constraints.MemLimit,
+}, {
+	name:   core.ResourceCPU,
+	format: "%dm",
+	request: func(cons constraints.Value) *uint64 {
+		return cons.CpuRequest
+	},
+	limit: func(cons constraints.Value) *uint64 {
+		if cons.CpuLimit != nil {
+			return cons.CpuLimit
+		}
+		return cons.CpuPower
+	},
+	requestLabel: constraints.CpuRequest,
+	limitLabel:   constraints.CpuLimit,
+}, {
+	name:   core.ResourceEphemeralStorage,
+	format: "%dMi",
+	request: func(cons constraints.Value) *uint64 {
+		return nil
+	},
+	limit: func(cons constraints.Value) *uint64 {
+		return cons.EphemeralStorageLimit
+	},
+	limitLabel: constraints.EphemeralStorageLimit,
+}}
+
+func applyResourceConstraints(resources *core.ResourceRequirements, cons, containerCons constraints.Value) error {
+	for _, rc := range resourceConstraints {
+		request := firstValue(rc.request(containerCons), rc.request(cons))
+		limit := firstValue(rc.limit(containerCons), rc.limit(cons))
+		if request > 0 && limit > 0 && request > limit {
+			return errors.NotValidf("%s %d greater than %s %d", rc.requestLabel, request, rc.limitLabel, limit)
+		}
+		if request > 0 {
+			if resources.Requests == nil {
+				resources.Requests = core.ResourceList{}
+			}
+			resources.Requests[rc.name] = resource.MustParse(fmt.Sprintf(rc.format, request))
+		}
+		if limit > 0 {
+			if resources.Limits == nil {
+				resources.Limits = core.ResourceList{}
+			}
+			resources.Limits[rc.name] = resource.MustParse(fmt.Sprintf(rc.format, limit))
+		}
+	}
+	return nil
+}
+
+// firstValue returns the first of the given values which is set,
+// or zero if none are.
+func firstValue(values ...*uint64) uint64 {
+	for _, v := range values {
+		if v != nil {
+			return *v
+		}
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utils_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/core/constraints"
+)
+
+type ConstraintsSuite struct{}
+
+var _ = gc.Suite(&ConstraintsSuite{})
+
+func (s *ConstraintsSuite) podSpec() *core.PodSpec {
+	return &core.PodSpec{
+		InitContainers: []core.Container{{Name: "charm-init"}},
+		Containers: []core.Container{{
+			Name: "charm",
+		}, {
+			Name: "nginx",
+			Resources: core.ResourceRequirements{
+				Limits: core.ResourceList{core.ResourceMemory: resource.MustParse("64Mi")},
+			},
+		}},
+	}
+}
+
+func (s *ConstraintsSuite) TestApplyResourceConstraints(c *gc.C) {
+	pod := s.podSpec()
+	cons := constraints.MustParse("mem-request=256M mem-limit=1G cpu-request=250 ephemeral-storage-limit=2G " +
+		"container-resources=nginx.mem-limit=512M,nginx.cpu-limit=500")
+	err := utils.ApplyResourceConstraints(pod, cons, "charm")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(pod.InitContainers[0].Resources, jc.DeepEquals, core.ResourceRequirements{})
+	c.Assert(pod.Containers[0].Resources, jc.DeepEquals, core.ResourceRequirements{})
+	c.Assert(pod.Containers[1].Resources, jc.DeepEquals, core.ResourceRequirements{
+		Requests: core.ResourceList{
+			core.ResourceMemory: resource.MustParse("256Mi"),
+			core.ResourceCPU:    resource.MustParse("250m"),
+		},
+		Limits: core.ResourceList{
+			core.ResourceMemory:           resource.MustParse("512Mi"),
+			core.ResourceCPU:              resource.MustParse("500m"),
+			core.ResourceEphemeralStorage: resource.MustParse("2048Mi"),
+		},
+	})
+}
+
+func (s *ConstraintsSuite) TestApplyResourceConstraintsLegacyLimits(c *gc.C) {
+	pod := s.podSpec()
+	err := utils.ApplyResourceConstraints(pod, constraints.MustParse("mem=2G cpu-power=100"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Containers[0].Resources, jc.DeepEquals, core.ResourceRequirements{
+		Limits: core.ResourceList{
+			core.ResourceMemory: resource.MustParse("2048Mi"),
+			core.ResourceCPU:    resource.MustParse("100m"),
+		},
+	})
+}
+
+func (s *ConstraintsSuite) TestApplyResourceConstraintsRequestGreaterThanLimit(c *gc.C) {
+	err := utils.ApplyResourceConstraints(s.podSpec(), constraints.MustParse("mem-limit=1G container-resources=nginx.mem-request=2G"))
+	c.Assert(err, gc.ErrorMatches, `container "nginx": mem-request 2048 greater than mem-limit 1024 not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ConstraintsSuite) TestApplyResourceConstraintsUnknownContainer(c *gc.C) {
+	err := utils.ApplyResourceConstraints(s.podSpec(), constraints.MustParse("container-resources=redis.mem-limit=1G"))
+	c.Assert(err, gc.ErrorMatches, `container "redis" in container-resources constraint not found`)
+}
+
+func (s *ConstraintsSuite) TestApplyResourceConstraintsAgentContainer(c *gc.C) {
+	err := utils.ApplyResourceConstraints(s.podSpec(), constraints.MustParse("container-resources=charm.mem-limit=1G"), "charm")
+	c.Assert(err, gc.ErrorMatches, `container "charm" in container-resources constraint not found`)
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+)
+
+// ImageRegistrySecretName is the name of the secret holding the
+// credentials for the model's private image registries.
+const ImageRegistrySecretName = "juju-image-registry-credentials"
+
+// ImageRegistry describes how the images of a model's workload pods
+// are pulled.
+type ImageRegistry struct {
+	// Mirrors maps registry domains, eg "docker.io", to the
+	// registry, and optional path, to pull their images from.
+	Mirrors map[string]string
+
+	// PullSecrets are the names of the secrets every workload pod
+	// uses to pull its images.
+	PullSecrets []string
+}
+
+// ParseImageRegistryMirrors parses a comma separated list of
+// source=mirror rules, eg "docker.io=registry.internal".
+func ParseImageRegistryMirrors(value string) (map[string]string, error) {
+	mirrors := make(map[string]string)
+	for _, rule := range strings.Split(value, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		parts := strings.Split(rule, "=")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.NotValidf("image registry mirror %q, expected source=mirror", rule)
+		}
+		source := strings.TrimSpace(parts[0])
+		mirror := strings.TrimSuffix(strings.TrimSpace(parts[1]), "/")
+		if _, ok := mirrors[source]; ok {
+			return nil, errors.NotValidf("duplicate image registry mirror for %q", source)
+		}
+		mirrors[source] = mirror
+	}
+	return mirrors, nil
+}
+
+// ImagePath returns the path to pull the image from, rewriting the
+// registry of the image if it has a mirror. Paths which can't be
+// parsed are returned unchanged.
+func (r ImageRegistry) ImagePath(imagePath string) string {
+	if len(r.Mirrors) == 0 || imagePath == "" {
+		return imagePath
+	}
+	named, err := reference.ParseNormalizedNamed(imagePath)
+	if err != nil {
+		return imagePath
+	}
+	domain := reference.Domain(named)
+	mirror, ok := r.Mirrors[domain]
+	if !ok {
+		return imagePath
+	}
+	return mirror + strings.TrimPrefix(named.String(), domain)
+}
+
+// ApplyToPodSpec rewrites the images of the pod's containers to any
+// mirrors and adds the registry's pull secrets to the pod.
+func (r ImageRegistry) ApplyToPodSpec(spec *core.PodSpec) {
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].Image = r.ImagePath(spec.InitContainers[i].Image)
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].Image = r.ImagePath(spec.Containers[i].Image)
+	}
+	existing := make(map[string]bool)
+	for _, s := range spec.ImagePullSecrets {
+		existing[s.Name] = true
+	}
+	secrets := append([]string(nil), r.PullSecrets...)
+	sort.Strings(secrets)
+	for _, name := range secrets {
+		if !existing[name] {
+			spec.ImagePullSecrets = append(spec.ImagePullSecrets, core.LocalObjectReference{Name: name})
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utils_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+)
+
+type RegistrySuite struct{}
+
+var _ = gc.Suite(&RegistrySuite{})
+
+func (s *RegistrySuite) TestParseImageRegistryMirrors(c *gc.C) {
+	mirrors, err := utils.ParseImageRegistryMirrors(" docker.io=registry.internal/, quay.io=registry.internal/quay,")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mirrors, jc.DeepEquals, map[string]string{
+		"docker.io": "registry.internal",
+		"quay.io":   "registry.internal/quay",
+	})
+
+	mirrors, err = utils.ParseImageRegistryMirrors("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mirrors, gc.HasLen, 0)
+}
+
+func (s *RegistrySuite) TestParseImageRegistryMirrorsInvalid(c *gc.C) {
+	for _, value := range []string{
+		"docker.io",
+		"docker.io=",
+		"=registry.internal",
+		"docker.io=a=b",
+	} {
+		_, err := utils.ParseImageRegistryMirrors(value)
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("value %q", value))
+	}
+	_, err := utils.ParseImageRegistryMirrors("docker.io=a,docker.io=b")
+	c.Assert(err, gc.ErrorMatches, `duplicate image registry mirror for "docker.io" not valid`)
+}
+
+func (s *RegistrySuite) TestImagePath(c *gc.C) {
+	registry := utils.ImageRegistry{
+		Mirrors: map[string]string{
+			"docker.io": "registry.internal",
+			"quay.io":   "registry.internal/quay",
+		},
+	}
+	for _, t := range []struct {
+		in, out string
+	}{
+		{"mariadb", "registry.internal/library/mariadb"},
+		{"mariadb:10.5", "registry.internal/library/mariadb:10.5"},
+		{"jujusolutions/jujud-operator:2.9.0", "registry.internal/jujusolutions/jujud-operator:2.9.0"},
+		{"docker.io/jujusolutions/jujud-operator", "registry.internal/jujusolutions/jujud-operator"},
+		{"quay.io/prometheus/node-exporter:v1.0.1", "registry.internal/quay/prometheus/node-exporter:v1.0.1"},
+		{"gcr.io/google-containers/pause:3.2", "gcr.io/google-containers/pause:3.2"},
+		{"Not A Valid/Image", "Not A Valid/Image"},
+		{"", ""},
+	} {
+		c.Check(registry.ImagePath(t.in), gc.Equals, t.out, gc.Commentf("image %q", t.in))
+	}
+	c.Check(utils.ImageRegistry{}.ImagePath("mariadb"), gc.Equals, "mariadb")
+}
+
+func (s *RegistrySuite) TestApplyToPodSpec(c *gc.C) {
+	registry := utils.ImageRegistry{
+		Mirrors:     map[string]string{"docker.io": "registry.internal"},
+		PullSecrets: []string{utils.ImageRegistrySecretName, "existing"},
+	}
+	spec := core.PodSpec{
+		InitContainers: []core.Container{{Name: "init", Image: "jujusolutions/jujud-operator:2.9.0"}},
+		Containers: []core.Container{
+			{Name: "mariadb", Image: "mariadb"},
+			{Name: "other", Image: "gcr.io/other"},
+		},
+		ImagePullSecrets: []core.LocalObjectReference{{Name: "existing"}},
+	}
+	registry.ApplyToPodSpec(&spec)
+	c.Assert(spec, jc.DeepEquals, core.PodSpec{
+		InitContainers: []core.Container{{Name: "init", Image: "registry.internal/jujusolutions/jujud-operator:2.9.0"}},
+		Containers: []core.Container{
+			{Name: "mariadb", Image: "registry.internal/library/mariadb"},
+			{Name: "other", Image: "gcr.io/other"},
+		},
+		ImagePullSecrets: []core.LocalObjectReference{
+			{Name: "existing"},
+			{Name: utils.ImageRegistrySecretName},
+		},
+	})
+}
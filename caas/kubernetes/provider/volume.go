@@ -476,6 +476,26 @@ func (k *kubernetesClient) fileSetToVolume(
 			DefaultMode: fileSet.Secret.DefaultMode,
 			Items:       fileRefsToVolItems(fileSet.Secret.Files),
 		}
+	} else if fileSet.Ephemeral != nil {
+		// Generic ephemeral volumes are not available in the k8s API we
+		// support, so fall back to a size limited emptyDir.
+		if fileSet.Ephemeral.StorageClassName != "" {
+			return vol, errors.NotSupportedf("ephemeral volume %q with storage class %q", fileSet.Name, fileSet.Ephemeral.StorageClassName)
+		}
+		size := fileSet.Ephemeral.Size
+		vol.EmptyDir = &core.EmptyDirVolumeSource{
+			SizeLimit: &size,
+		}
+	} else if fileSet.ServiceAccountToken != nil {
+		vol.Projected = &core.ProjectedVolumeSource{
+			Sources: []core.VolumeProjection{{
+				ServiceAccountToken: &core.ServiceAccountTokenProjection{
+					Audience:          fileSet.ServiceAccountToken.Audience,
+					ExpirationSeconds: fileSet.ServiceAccountToken.ExpirationSeconds,
+					Path:              fileSet.ServiceAccountToken.Path,
+				},
+			}},
+		}
 	} else {
 		// This should never happen because FileSet validation has been in k8s spec level.
 		return vol, errors.NotValidf("fileset %q is empty", fileSet.Name)
@@ -107,6 +107,34 @@ func (mr *MockApplicationMockRecorder) Units() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Units", reflect.TypeOf((*MockApplication)(nil).Units))
 }
 
+// UpdateIngress mocks base method
+func (m *MockApplication) UpdateIngress(arg0 *caas.Ingress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIngress", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIngress indicates an expected call of UpdateIngress
+func (mr *MockApplicationMockRecorder) UpdateIngress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIngress", reflect.TypeOf((*MockApplication)(nil).UpdateIngress), arg0)
+}
+
+// UpdateNetworkPolicy mocks base method
+func (m *MockApplication) UpdateNetworkPolicy(arg0 *caas.NetworkPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNetworkPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNetworkPolicy indicates an expected call of UpdateNetworkPolicy
+func (mr *MockApplicationMockRecorder) UpdateNetworkPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNetworkPolicy", reflect.TypeOf((*MockApplication)(nil).UpdateNetworkPolicy), arg0)
+}
+
 // UpdatePorts mocks base method
 func (m *MockApplication) UpdatePorts(arg0 []caas.ServicePort, arg1 bool) error {
 	m.ctrl.T.Helper()
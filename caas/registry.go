@@ -0,0 +1,21 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+// ImageRegistryCredentialManager provides an API for managing the
+// credentials a model's workloads use to pull images from private
+// registries. The credentials are held by the cloud, and the model
+// config only records a reference to them.
+type ImageRegistryCredentialManager interface {
+	// SetImageRegistryCredential adds or replaces the credential used
+	// to pull images from the registry, returning the reference to
+	// the credentials to record in the model config.
+	SetImageRegistryCredential(registry, username, password string) (string, error)
+
+	// RemoveImageRegistryCredential removes the credential used to
+	// pull images from the registry, returning the reference to the
+	// credentials to record in the model config, which is empty once
+	// there are none left.
+	RemoveImageRegistryCredential(registry string) (string, error)
+}
@@ -12,10 +12,10 @@ import (
 )
 
 // CurrentVersion is the latest version of pod spec.
-const CurrentVersion Version = Version3
+const CurrentVersion Version = Version4
 
 // PodSpec is the current version of pod spec.
-type PodSpec = PodSpecV3
+type PodSpec = PodSpecV4
 
 // ContainerPort defines a port on a container.
 type ContainerPort struct {
@@ -84,6 +84,11 @@ func (spec *ContainerSpecV2) Validate() error {
 type ContainerSpec struct {
 	Name string `json:"name" yaml:"name"`
 	Init bool   `json:"init,omitempty" yaml:"init,omitempty"`
+	// Sidecar marks a container which supports the workload, such as
+	// a proxy or log shipper, rather than running it. Sidecars are
+	// listed in the pod annotations and otherwise deployed like any
+	// other container. It requires pod spec version 4.
+	Sidecar bool `json:"sidecar,omitempty" yaml:"sidecar,omitempty"`
 	// Image is deprecated in preference to using ImageDetails.
 	Image        string          `json:"image,omitempty" yaml:"image,omitempty"`
 	ImageDetails ImageDetails    `json:"imageDetails" yaml:"imageDetails"`
@@ -115,6 +120,9 @@ func (spec *ContainerSpec) Validate() error {
 	if spec.Image == "" && spec.ImageDetails.ImagePath == "" {
 		return errors.New("spec image details is missing")
 	}
+	if spec.Init && spec.Sidecar {
+		return errors.NotValidf("container %q both init and sidecar", spec.Name)
+	}
 	for _, fs := range spec.VolumeConfig {
 		if err := fs.Validate(); err != nil {
 			return errors.Trace(err)
@@ -126,6 +134,31 @@ func (spec *ContainerSpec) Validate() error {
 	return nil
 }
 
+// MinVersion returns the earliest pod spec version supporting all the
+// features used by the container.
+func (spec *ContainerSpec) MinVersion() Version {
+	if spec.Sidecar {
+		return Version4
+	}
+	ver := VersionLegacy
+	for _, fs := range spec.VolumeConfig {
+		if v := fs.VolumeSource.minVersion(); v > ver {
+			ver = v
+		}
+	}
+	return ver
+}
+
+// ValidateVersion returns an error if the container uses features
+// not supported by the pod spec version.
+func (spec *ContainerSpec) ValidateVersion(ver Version) error {
+	if minVer := spec.MinVersion(); minVer > ver {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"container %q requires pod spec version %d, but found %d", spec.Name, minVer, ver))
+	}
+	return nil
+}
+
 // ScalePolicyType defines the policy for creating or terminating pods under a service.
 type ScalePolicyType string
 
@@ -370,6 +403,11 @@ func (spec *podSpecBase) Validate(ver Version) error {
 	if err := spec.caasContainers.Validate(); err != nil {
 		return errors.Trace(err)
 	}
+	for _, c := range spec.Containers {
+		if err := c.ValidateVersion(ver); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	if spec.ProviderPod != nil {
 		return spec.ProviderPod.Validate()
@@ -56,6 +56,12 @@ version: 3
 `[1:],
 			version: specs.Version(3),
 		},
+		{
+			strSpec: `
+version: 4
+`[1:],
+			version: specs.Version(4),
+		},
 	} {
 		c.Logf("#%d: testing GetVersion: %d", i, tc.version)
 		v, err := specs.GetVersion(tc.strSpec)
@@ -167,6 +173,15 @@ func (s *baseSuite) TestValidateContainerSpec(c *gc.C) {
 			},
 			errStr: `spec name is missing`,
 		},
+		{
+			spec: &specs.ContainerSpec{
+				Name:    "container1",
+				Image:   "gitlab",
+				Init:    true,
+				Sidecar: true,
+			},
+			errStr: `container "container1" both init and sidecar not valid`,
+		},
 		{
 			spec: &specs.ContainerSpec{
 				Name:  "container1",
@@ -214,6 +229,54 @@ func (s *baseSuite) TestValidatePodSpecBase(c *gc.C) {
 	c.Assert(minSpecs.Validate(specs.Version2), jc.ErrorIsNil)
 }
 
+func (s *baseSuite) TestValidateContainerSpecVersion(c *gc.C) {
+	spec := specs.ContainerSpec{
+		Name:    "gitlab-helper",
+		Image:   "gitlab-helper/latest",
+		Sidecar: true,
+	}
+	c.Assert(spec.MinVersion(), gc.Equals, specs.Version4)
+	c.Assert(spec.ValidateVersion(specs.Version4), jc.ErrorIsNil)
+	c.Assert(spec.ValidateVersion(specs.Version3), gc.ErrorMatches,
+		`container "gitlab-helper" requires pod spec version 4, but found 3`)
+
+	spec.Sidecar = false
+	c.Assert(spec.MinVersion(), gc.Equals, specs.VersionLegacy)
+	spec.VolumeConfig = []specs.FileSet{{
+		Name:      "token",
+		MountPath: "/var/run/secrets/tokens",
+		VolumeSource: specs.VolumeSource{
+			ServiceAccountToken: &specs.ServiceAccountTokenVol{Path: "vault-token"},
+		},
+	}}
+	c.Assert(spec.MinVersion(), gc.Equals, specs.Version4)
+}
+
+func (s *baseSuite) TestPodSpecV3ToV4(c *gc.C) {
+	v3 := specs.PodSpecV3{}
+	v3.Version = specs.Version3
+	v3.Containers = []specs.ContainerSpec{{
+		Name:  "gitlab-helper",
+		Image: "gitlab-helper/latest",
+	}}
+	v3.ServiceAccount = &specs.PrimeServiceAccountSpecV3{
+		ServiceAccountSpecV3: specs.ServiceAccountSpecV3{
+			Roles: []specs.Role{{
+				Rules: []specs.PolicyRule{{
+					APIGroups: []string{""},
+					Resources: []string{"pods"},
+					Verbs:     []string{"get"},
+				}},
+			}},
+		},
+	}
+	v4 := v3.ToV4()
+	c.Assert(v4.Version, gc.Equals, specs.Version4)
+	c.Assert(v4.Containers, jc.DeepEquals, v3.Containers)
+	c.Assert(v4.ServiceAccount, gc.Equals, v3.ServiceAccount)
+	c.Assert(v4.Validate(), jc.ErrorIsNil)
+}
+
 func (s *baseSuite) TestValidateCaaSContainers(c *gc.C) {
 	k8sSpec := specs.CaasContainers{}
 	fileSet1 := specs.FileSet{
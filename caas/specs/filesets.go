@@ -87,6 +87,10 @@ type VolumeSource struct {
 	EmptyDir  *EmptyDirVol    `json:"emptyDir" yaml:"emptyDir"`
 	ConfigMap *ResourceRefVol `json:"configMap" yaml:"configMap"`
 	Secret    *ResourceRefVol `json:"secret" yaml:"secret"`
+
+	// Ephemeral and ServiceAccountToken require pod spec version 4.
+	Ephemeral           *EphemeralVol           `json:"ephemeral,omitempty" yaml:"ephemeral,omitempty"`
+	ServiceAccountToken *ServiceAccountTokenVol `json:"serviceAccountToken,omitempty" yaml:"serviceAccountToken,omitempty"`
 }
 
 type validator interface {
@@ -128,6 +132,18 @@ func (vs VolumeSource) Validate(name string) error {
 			return errors.Trace(err)
 		}
 	}
+	if vs.Ephemeral != nil {
+		nonNilSource++
+		if err := vs.Ephemeral.Validate(name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if vs.ServiceAccountToken != nil {
+		nonNilSource++
+		if err := vs.ServiceAccountToken.Validate(name); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if nonNilSource == 0 {
 		return errors.NewNotValid(nil, fmt.Sprintf("file set %q requires volume source", name))
 	}
@@ -137,6 +153,14 @@ func (vs VolumeSource) Validate(name string) error {
 	return nil
 }
 
+// minVersion returns the earliest pod spec version supporting the volume source.
+func (vs VolumeSource) minVersion() Version {
+	if vs.Ephemeral != nil || vs.ServiceAccountToken != nil {
+		return Version4
+	}
+	return VersionLegacy
+}
+
 // File describes a file to mount into a pod.
 type File struct {
 	Path    string `json:"path" yaml:"path"`
@@ -217,3 +241,38 @@ func (rrv *ResourceRefVol) Validate(name string) error {
 	}
 	return nil
 }
+
+// EphemeralVol represents a volume which is created with a pod and
+// removed when the pod is deleted.
+type EphemeralVol struct {
+	StorageClassName string            `json:"storageClassName,omitempty" yaml:"storageClassName,omitempty"`
+	Size             resource.Quantity `json:"size" yaml:"size"`
+}
+
+// Validate validates EphemeralVol.
+func (ev *EphemeralVol) Validate(name string) error {
+	if ev.Size.IsZero() {
+		return errors.Errorf("Size is missing for %q", name)
+	}
+	return nil
+}
+
+// ServiceAccountTokenVol represents a projected service account token
+// mounted into a pod.
+type ServiceAccountTokenVol struct {
+	Path              string `json:"path" yaml:"path"`
+	Audience          string `json:"audience,omitempty" yaml:"audience,omitempty"`
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty" yaml:"expirationSeconds,omitempty"`
+}
+
+// Validate validates ServiceAccountTokenVol.
+func (satv *ServiceAccountTokenVol) Validate(name string) error {
+	if satv.Path == "" {
+		return errors.Errorf("Path is missing for %q", name)
+	}
+	// The kubelet refuses tokens valid for less than 10 minutes.
+	if satv.ExpirationSeconds != nil && *satv.ExpirationSeconds < 600 {
+		return errors.NotValidf("expirationSeconds %d for %q, minimum is 600", *satv.ExpirationSeconds, name)
+	}
+	return nil
+}
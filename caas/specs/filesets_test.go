@@ -269,6 +269,7 @@ type validateVolumeSourceTc struct {
 }
 
 func (s *typesSuite) TestValidateFileSetVolumeSource(c *gc.C) {
+	expirationSeconds := int64(60)
 	for i, tc := range []validateVolumeSourceTc{
 		{
 			spec: &specs.VolumeSource{
@@ -288,6 +289,27 @@ func (s *typesSuite) TestValidateFileSetVolumeSource(c *gc.C) {
 			},
 			errStr: `Name is missing for "fakeFileSet"`,
 		},
+		{
+			spec: &specs.VolumeSource{
+				Ephemeral: &specs.EphemeralVol{},
+			},
+			errStr: `Size is missing for "fakeFileSet"`,
+		},
+		{
+			spec: &specs.VolumeSource{
+				ServiceAccountToken: &specs.ServiceAccountTokenVol{},
+			},
+			errStr: `Path is missing for "fakeFileSet"`,
+		},
+		{
+			spec: &specs.VolumeSource{
+				ServiceAccountToken: &specs.ServiceAccountTokenVol{
+					Path:              "token",
+					ExpirationSeconds: &expirationSeconds,
+				},
+			},
+			errStr: `expirationSeconds 60 for "fakeFileSet", minimum is 600 not valid`,
+		},
 		{
 			spec:   &specs.File{},
 			errStr: `Path is missing for "fakeFileSet"`,
@@ -0,0 +1,40 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs
+
+import (
+	"github.com/juju/errors"
+)
+
+// PodSpecV4 defines the data values used to configure
+// a pod on the CAAS substrate for version 4.
+type PodSpecV4 struct {
+	podSpecBase    `json:",inline" yaml:",inline"`
+	ServiceAccount *PrimeServiceAccountSpecV3 `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+}
+
+// Version4 defines the version number for pod spec version 4.
+const Version4 Version = 4
+
+// Validate returns an error if the spec is not valid.
+func (spec *PodSpecV4) Validate() error {
+	if err := spec.podSpecBase.Validate(Version4); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.ServiceAccount != nil {
+		return errors.Trace(spec.ServiceAccount.Validate())
+	}
+	return nil
+}
+
+// ToV4 converts a version 3 pod spec to version 4. Version 4 is a
+// superset of version 3 so no information is lost.
+func (spec *PodSpecV3) ToV4() *PodSpecV4 {
+	out := &PodSpecV4{
+		podSpecBase:    spec.podSpecBase,
+		ServiceAccount: spec.ServiceAccount,
+	}
+	out.Version = Version4
+	return out
+}
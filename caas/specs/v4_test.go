@@ -0,0 +1,157 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/juju/juju/caas/specs"
+	"github.com/juju/juju/testing"
+)
+
+type v4Suite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&v4Suite{})
+
+var v4SpecStr = `
+version: 4
+omitServiceFrontend: true
+containers:
+  - name: gitlab
+    imageDetails:
+      imagePath: gitlab/latest
+    ports:
+      - containerPort: 80
+        protocol: TCP
+    volumeConfig:
+      - name: scratch
+        mountPath: /scratch
+        ephemeral:
+          storageClassName: fast
+          size: 1Gi
+  - name: vault-agent
+    sidecar: true
+    imageDetails:
+      imagePath: vault/latest
+    volumeConfig:
+      - name: vault-token
+        mountPath: /var/run/secrets/tokens
+        serviceAccountToken:
+          path: vault-token
+          audience: vault
+          expirationSeconds: 3600
+  - name: gitlab-init
+    init: true
+    imageDetails:
+      imagePath: gitlab-init/latest
+serviceAccount:
+  automountServiceAccountToken: true
+  roles:
+    - rules:
+        - apiGroups: [""]
+          resources: ["pods"]
+          verbs: ["get", "list"]
+`[1:]
+
+func decodeV4(c *gc.C, in string) *specs.PodSpecV4 {
+	var spec specs.PodSpecV4
+	decoder := k8syaml.NewYAMLOrJSONDecoder(strings.NewReader(in), len(in))
+	err := decoder.Decode(&spec)
+	c.Assert(err, jc.ErrorIsNil)
+	return &spec
+}
+
+func (s *v4Suite) TestDecode(c *gc.C) {
+	spec := decodeV4(c, v4SpecStr)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+
+	c.Assert(spec.Version, gc.Equals, specs.Version4)
+	c.Assert(spec.OmitServiceFrontend, jc.IsTrue)
+	c.Assert(spec.Containers, gc.HasLen, 3)
+
+	gitlab := spec.Containers[0]
+	c.Assert(gitlab.Name, gc.Equals, "gitlab")
+	c.Assert(gitlab.Sidecar, jc.IsFalse)
+	c.Assert(gitlab.VolumeConfig, gc.HasLen, 1)
+	ephemeral := gitlab.VolumeConfig[0].VolumeSource.Ephemeral
+	c.Assert(ephemeral, gc.NotNil)
+	c.Assert(ephemeral.StorageClassName, gc.Equals, "fast")
+	c.Assert(ephemeral.Size.Cmp(resource.MustParse("1Gi")), gc.Equals, 0)
+
+	vault := spec.Containers[1]
+	c.Assert(vault.Name, gc.Equals, "vault-agent")
+	c.Assert(vault.Sidecar, jc.IsTrue)
+	c.Assert(vault.VolumeConfig, gc.HasLen, 1)
+	expiration := int64(3600)
+	c.Assert(vault.VolumeConfig[0].VolumeSource.ServiceAccountToken, jc.DeepEquals, &specs.ServiceAccountTokenVol{
+		Path:              "vault-token",
+		Audience:          "vault",
+		ExpirationSeconds: &expiration,
+	})
+
+	c.Assert(spec.Containers[2].Name, gc.Equals, "gitlab-init")
+	c.Assert(spec.Containers[2].Init, jc.IsTrue)
+
+	c.Assert(spec.ServiceAccount, gc.NotNil)
+	c.Assert(spec.ServiceAccount.Roles, jc.DeepEquals, []specs.Role{{
+		Rules: []specs.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list"},
+		}},
+	}})
+}
+
+func (s *v4Suite) TestRoundTrip(c *gc.C) {
+	spec := decodeV4(c, v4SpecStr)
+
+	data, err := json.Marshal(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	out := decodeV4(c, string(data))
+	c.Assert(out.Validate(), jc.ErrorIsNil)
+
+	c.Assert(out, jc.DeepEquals, spec)
+	c.Assert(out.Containers[0].VolumeConfig[0].VolumeSource.Ephemeral.Size.String(), gc.Equals, "1Gi")
+}
+
+func (s *v4Suite) TestRoundTripFromV3(c *gc.C) {
+	v3 := specs.PodSpecV3{}
+	v3.Version = specs.Version3
+	v3.Containers = []specs.ContainerSpec{{
+		Name:         "gitlab",
+		ImageDetails: specs.ImageDetails{ImagePath: "gitlab/latest"},
+		Ports:        []specs.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
+	}}
+
+	data, err := json.Marshal(v3.ToV4())
+	c.Assert(err, jc.ErrorIsNil)
+	out := decodeV4(c, string(data))
+	c.Assert(out.Validate(), jc.ErrorIsNil)
+	c.Assert(out.Version, gc.Equals, specs.Version4)
+	c.Assert(out.Containers, jc.DeepEquals, v3.Containers)
+	c.Assert(out.ServiceAccount, gc.IsNil)
+}
+
+func (s *v4Suite) TestV4FeaturesRejectedByV3(c *gc.C) {
+	spec := decodeV4(c, v4SpecStr)
+
+	// Re-decoding the same spec as version 3 keeps the v4 only
+	// features, which validation must refuse.
+	data, err := json.Marshal(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	var v3 specs.PodSpecV3
+	decoder := k8syaml.NewYAMLOrJSONDecoder(strings.NewReader(string(data)), len(data))
+	err = decoder.Decode(&v3)
+	c.Assert(err, jc.ErrorIsNil)
+	v3.Version = specs.Version3
+	c.Assert(v3.Validate(), gc.ErrorMatches, `container "gitlab" requires pod spec version 4, but found 3`)
+}
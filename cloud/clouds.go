@@ -416,6 +416,7 @@ var defaultCloudDescription = map[string]string{
 	"azure-china": "Microsoft Azure China",
 	"rackspace":   "Rackspace Cloud",
 	"cloudsigma":  "CloudSigma Cloud",
+	"hetzner":     "Hetzner Cloud",
 	"lxd":         "LXD Container Hypervisor",
 	"maas":        "Metal As A Service",
 	"openstack":   "Openstack Cloud",
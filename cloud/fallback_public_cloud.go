@@ -405,6 +405,21 @@ clouds:
         endpoint: https://wdc.cloudsigma.com/api/2.0/
       zrh:
         endpoint: https://zrh.cloudsigma.com/api/2.0/
+  hetzner:
+    type: hetzner
+    description: Hetzner Cloud
+    auth-types: [ oauth2 ]
+    regions:
+      fsn1:
+        endpoint: https://api.hetzner.cloud/v1
+      nbg1:
+        endpoint: https://api.hetzner.cloud/v1
+      hel1:
+        endpoint: https://api.hetzner.cloud/v1
+      ash:
+        endpoint: https://api.hetzner.cloud/v1
+      hil:
+        endpoint: https://api.hetzner.cloud/v1
   oracle:
     type: oci
     description: Oracle Cloud Infrastructure
@@ -32,6 +32,10 @@ type APIClient interface {
 	// We return the ID of the overall operation and each individual task.
 	EnqueueOperation(params.Actions) (params.EnqueuedActions, error)
 
+	// EnqueueRollingOperation takes a list of Actions and queues them up to be
+	// executed as an operation whose tasks are started a few at a time.
+	EnqueueRollingOperation(params.RollingActions) (params.EnqueuedActions, error)
+
 	// Cancel attempts to cancel a queued up Action from running.
 	Cancel(params.Entities) (params.ActionResults, error)
 
@@ -51,6 +55,19 @@ type APIClient interface {
 
 	// WatchActionProgress reports on logged action progress messages.
 	WatchActionProgress(actionId string) (watcher.StringsWatcher, error)
+
+	// WatchActionOutput reports on logged action progress messages
+	// and on output written by the action as it runs.
+	WatchActionOutput(actionId string) (watcher.StringsWatcher, error)
+
+	// AddActionSchedules adds schedules which periodically run an action.
+	AddActionSchedules([]params.AddActionSchedule) ([]params.ActionScheduleResult, error)
+
+	// ListActionSchedules returns all the action schedules in the model.
+	ListActionSchedules() ([]params.ActionSchedule, error)
+
+	// RemoveActionSchedules removes the action schedules with the given ids.
+	RemoveActionSchedules(ids []string) ([]params.ErrorResult, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -23,6 +24,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/core/actions"
 	coreactions "github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/watcher"
@@ -46,6 +48,7 @@ type runCommandBase struct {
 	ActionCommandBase
 	api        APIClient
 	background bool
+	stream     bool
 	out        cmd.Output
 	utc        bool
 
@@ -66,6 +69,7 @@ func (c *runCommandBase) SetFlags(f *gnuflag.FlagSet) {
 	})
 
 	f.BoolVar(&c.background, "background", false, "Run the task in the background")
+	f.BoolVar(&c.stream, "stream", false, "Show the output of the task as it is written")
 	f.DurationVar(&c.wait, "wait", 0, "Maximum wait time for a task to complete")
 	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
 }
@@ -74,6 +78,9 @@ func (c *runCommandBase) Init(args []string) error {
 	if c.background && c.wait > 0 {
 		return errors.New("cannot specify both --wait and --background")
 	}
+	if c.background && c.stream {
+		return errors.New("cannot specify both --stream and --background")
+	}
 	if !c.background && c.wait == 0 {
 		c.wait = c.defaultWait
 		if c.wait == 0 {
@@ -172,7 +179,11 @@ func (c *runCommandBase) waitForTasks(ctx *cmd.Context, tasks []enqueuedAction,
 		if err != nil {
 			return err
 		}
-		logsWatcher, err = c.api.WatchActionProgress(actionTag.Id())
+		if c.stream {
+			logsWatcher, err = c.api.WatchActionOutput(actionTag.Id())
+		} else {
+			logsWatcher, err = c.api.WatchActionProgress(actionTag.Id())
+		}
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -509,6 +520,88 @@ func convertActionOutput(output map[string]interface{}) map[string]interface{} {
 	return values
 }
 
+// parseActionArgs splits key.key.key...=value command line arguments
+// into their key path and value, checking each key for validity.
+func parseActionArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0)
+	for _, arg := range args {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return nil, errors.Errorf("argument %q must be of the form key.key.key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		// check each key for validity
+		for _, key := range keySlice {
+			if valid := nameRule.MatchString(key); !valid {
+				return nil, errors.Errorf("key %q must start and end with lowercase alphanumeric, "+
+					"and contain only lowercase alphanumeric and hyphens", key)
+			}
+		}
+		result = append(result, append(keySlice, thisArg[1]))
+	}
+	return result, nil
+}
+
+// buildActionParams reads the optional params file and overlays the
+// explicit arguments parsed by parseActionArgs, returning the combined
+// action parameters. Params files with a .json extension are read as
+// JSON, anything else as YAML.
+func buildActionParams(ctx *cmd.Context, paramsFile cmd.FileVar, args [][]string, parseStrings bool) (map[string]interface{}, error) {
+	actionParams := map[string]interface{}{}
+	if paramsFile.Path != "" {
+		b, err := paramsFile.Read(ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		if strings.EqualFold(filepath.Ext(paramsFile.Path), ".json") {
+			err = json.Unmarshal(b, &actionParams)
+		} else {
+			err = yaml.Unmarshal(b, &actionParams)
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		conformantParams, err := common.ConformYAML(actionParams)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		betterParams, ok := conformantParams.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("params must contain a map with string keys")
+		}
+
+		actionParams = betterParams
+	}
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, actionParams)
+	}
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
+	}
+	return typedConformantParams, nil
+}
+
 // addValueToMap adds the given value to the map on which the method is run.
 // This allows us to merge maps such as {foo: {bar: baz}} and {foo: {baz: faz}}
 // into {foo: {bar: baz, baz: faz}}.
@@ -557,6 +650,10 @@ func decodeLogMessage(encodedMessage string, utc bool) (string, error) {
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	if actionMessage.Stream != "" {
+		// Output is shown as it was written, without a timestamp.
+		return strings.TrimSuffix(actionMessage.Message, "\n"), nil
+	}
 	return formatLogMessage(actionMessage, true, utc, true), nil
 }
 
@@ -96,6 +96,10 @@ the unit.
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+When the command is run on a single target, --stream shows any messages
+logged by the command as they happen. On k8s models, the output of the
+command is also shown as it is written.
+
 Since juju exec creates tasks, you can query for the status of commands
 started with juju run by calling "juju operations --machines <id>,... --actions juju-run".
 
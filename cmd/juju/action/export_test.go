@@ -18,6 +18,8 @@ import (
 var (
 	NewActionAPIClient = &newAPIClient
 	AddValueToMap      = addValueToMap
+	DecodeLogMessage   = decodeLogMessage
+	IsTerminal         = &isTerminal
 )
 
 type ShowOperationCommand struct {
@@ -179,3 +181,37 @@ func NewListOperationsCommandForTest(store jujuclient.ClientStore) (cmd.Command,
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &ListOperationsCommand{c}
 }
+
+type ScheduleCommand struct {
+	*scheduleCommand
+}
+
+func (c *ScheduleCommand) Receiver() string {
+	return c.receiver
+}
+
+func (c *ScheduleCommand) ActionName() string {
+	return c.actionName
+}
+
+func (c *ScheduleCommand) Args() [][]string {
+	return c.args
+}
+
+func NewScheduleCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ScheduleCommand) {
+	c := &scheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &ScheduleCommand{c}
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel)
+}
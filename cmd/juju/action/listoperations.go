@@ -37,6 +37,7 @@ type listOperationsCommand struct {
 	machineNames     []string
 	actionNames      []string
 	statusValues     []string
+	scheduleIds      []string
 }
 
 const listOperationsDoc = `
@@ -51,6 +52,7 @@ Examples:
     juju operations --units mysql/0,mediawiki/1
     juju operations --machines 0,1
     juju operations --status pending,completed
+    juju operations --schedule 1,2
     juju operations --apps mysql --units mediawiki/0 --status running --actions backup
 
 See also:
@@ -76,6 +78,7 @@ func (c *listOperationsCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machineNames), "machines", "Comma separated list of machines to filter on")
 	f.Var(cmd.NewStringsValue(nil, &c.actionNames), "actions", "Comma separated list of actions names to filter on")
 	f.Var(cmd.NewStringsValue(nil, &c.statusValues), "status", "Comma separated list of operation status values to filter on")
+	f.Var(cmd.NewStringsValue(nil, &c.scheduleIds), "schedule", "Comma separated list of action schedule ids to filter on")
 }
 
 func (c *listOperationsCommand) Info() *cmd.Info {
@@ -147,6 +150,7 @@ func (c *listOperationsCommand) Run(ctx *cmd.Context) error {
 		Machines:     c.machineNames,
 		ActionNames:  c.actionNames,
 		Status:       c.statusValues,
+		Schedules:    c.scheduleIds,
 	}
 	results, err := api.ListOperations(args)
 	if err != nil {
@@ -269,12 +273,15 @@ func (s byId) Less(i, j int) bool {
 }
 
 type operationInfo struct {
-	Summary string              `yaml:"summary" json:"summary"`
-	Status  string              `yaml:"status" json:"status"`
-	Error   string              `yaml:"error,omitempty" json:"error,omitempty"`
-	Action  *actionSummary      `yaml:"action,omitempty" json:"action,omitempty"`
-	Timing  timingInfo          `yaml:"timing,omitempty" json:"timing,omitempty"`
-	Tasks   map[string]taskInfo `yaml:"tasks,omitempty" json:"tasks,omitempty"`
+	Summary       string              `yaml:"summary" json:"summary"`
+	Status        string              `yaml:"status" json:"status"`
+	Schedule      string              `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+	MaxParallel   int                 `yaml:"max-parallel,omitempty" json:"max-parallel,omitempty"`
+	StopOnFailure int                 `yaml:"stop-on-failure,omitempty" json:"stop-on-failure,omitempty"`
+	Error         string              `yaml:"error,omitempty" json:"error,omitempty"`
+	Action        *actionSummary      `yaml:"action,omitempty" json:"action,omitempty"`
+	Timing        timingInfo          `yaml:"timing,omitempty" json:"timing,omitempty"`
+	Tasks         map[string]taskInfo `yaml:"tasks,omitempty" json:"tasks,omitempty"`
 }
 
 type timingInfo struct {
@@ -302,8 +309,11 @@ type taskInfo struct {
 // write in an easy-to-read format.
 func formatOperationResult(operation params.OperationResult, utc bool) operationInfo {
 	result := operationInfo{
-		Summary: operation.Summary,
-		Status:  operation.Status,
+		Summary:       operation.Summary,
+		Status:        operation.Status,
+		Schedule:      operation.ScheduleId,
+		MaxParallel:   operation.MaxParallel,
+		StopOnFailure: operation.StopOnFailure,
 		Timing: timingInfo{
 			Enqueued:  formatTimestamp(operation.Enqueued, false, utc, false),
 			Started:   formatTimestamp(operation.Started, false, utc, false),
@@ -89,6 +89,7 @@ func (s *ListOperationsSuite) TestRunQueryArgs(c *gc.C) {
 		"--machines", "0,1",
 		"--actions", "backup",
 		"--status", "completed,pending",
+		"--schedule", "1,2",
 	}
 	for _, modelFlag := range s.modelFlags {
 		s.wrappedCommand, s.command = action.NewListOperationsCommandForTest(s.store)
@@ -102,6 +103,7 @@ func (s *ListOperationsSuite) TestRunQueryArgs(c *gc.C) {
 			Machines:     []string{"0", "1"},
 			ActionNames:  []string{"backup"},
 			Status:       []string{"completed", "pending"},
+			Schedules:    []string{"1", "2"},
 		})
 	}
 }
@@ -0,0 +1,151 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/actions"
+)
+
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+// listSchedulesCommand lists the action schedules in a model.
+type listSchedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+	utc bool
+}
+
+const listSchedulesDoc = `
+List the action schedules in the model, along with when each schedule is
+next due and the operation it last created.
+
+Examples:
+    juju schedules
+    juju schedules --format yaml
+
+See also:
+    schedule-action
+    remove-schedule
+    operations
+`
+
+// SetFlags implements Command.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "plain", map[string]cmd.Formatter{
+		"yaml":  cmd.FormatYaml,
+		"json":  cmd.FormatJson,
+		"plain": c.formatTabular,
+	})
+	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
+}
+
+// Info implements Command.
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "schedules",
+		Purpose: "Lists the action schedules in the model.",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"list-schedules"},
+	})
+}
+
+// Init implements Command.
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+type scheduleInfo struct {
+	Receiver        string                 `yaml:"receiver" json:"receiver"`
+	Action          string                 `yaml:"action" json:"action"`
+	Parameters      map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Cron            string                 `yaml:"cron" json:"cron"`
+	MissedRunPolicy string                 `yaml:"missed-run-policy" json:"missed-run-policy"`
+	Created         string                 `yaml:"created" json:"created"`
+	LastRun         string                 `yaml:"last-run,omitempty" json:"last-run,omitempty"`
+	LastOperation   string                 `yaml:"last-operation,omitempty" json:"last-operation,omitempty"`
+	NextRun         string                 `yaml:"next-run,omitempty" json:"next-run,omitempty"`
+}
+
+// Run implements Command.
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	schedules, err := api.ListActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(schedules) == 0 {
+		ctx.Infof("no action schedules")
+		return nil
+	}
+	if c.out.Name() == "plain" {
+		return c.out.Write(ctx, schedules)
+	}
+	out := make(map[string]scheduleInfo, len(schedules))
+	for _, schedule := range schedules {
+		out[schedule.Id] = scheduleInfo{
+			Receiver:        schedule.Receiver,
+			Action:          schedule.Name,
+			Parameters:      schedule.Parameters,
+			Cron:            schedule.Cron,
+			MissedRunPolicy: schedule.MissedRunPolicy,
+			Created:         formatTimestamp(schedule.Created, false, c.utc, false),
+			LastRun:         formatTimestamp(schedule.LastRun, false, c.utc, false),
+			LastOperation:   schedule.LastOperation,
+			NextRun:         formatTimestamp(nextScheduledRun(schedule), false, c.utc, false),
+		}
+	}
+	return c.out.Write(ctx, out)
+}
+
+func (c *listSchedulesCommand) formatTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.([]params.ActionSchedule)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.SetColumnAlignRight(0)
+
+	w.Println("Id", "Receiver", "Action", "Cron", "Next run", "Last run", "Last operation")
+	for _, schedule := range schedules {
+		w.Print(schedule.Id, schedule.Receiver, schedule.Name, schedule.Cron)
+		w.Print(formatTimestamp(nextScheduledRun(schedule), false, c.utc, true))
+		w.Print(formatTimestamp(schedule.LastRun, false, c.utc, true))
+		w.Println(schedule.LastOperation)
+	}
+	return tw.Flush()
+}
+
+// nextScheduledRun returns the next time the schedule is due after it
+// last ran, ignoring any missed run policy.
+func nextScheduledRun(schedule params.ActionSchedule) time.Time {
+	cron, err := actions.ParseCronSchedule(schedule.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	from := schedule.LastRun
+	if from.IsZero() {
+		from = schedule.Created
+	}
+	return cron.Next(from)
+}
@@ -143,6 +143,7 @@ type fakeAPIClient struct {
 	operationResults   []params.OperationResult
 	operationQueryArgs params.OperationQueryArgs
 	enqueuedActions    params.Actions
+	rollingActions     params.RollingActions
 	actionsByReceivers []params.ActionsByReceiver
 	charmActions       map[string]params.ActionSpec
 	machines           set.Strings
@@ -150,6 +151,13 @@ type fakeAPIClient struct {
 	apiErr             error
 	logMessageCh       chan []string
 	waitForResults     chan bool
+	addedSchedules     []params.AddActionSchedule
+	scheduleResults    []params.ActionScheduleResult
+	schedules          []params.ActionSchedule
+	removedSchedules   []string
+	removeResults      []params.ErrorResult
+	watchedOutput      bool
+	outputNotSupported bool
 }
 
 var _ action.APIClient = (*fakeAPIClient)(nil)
@@ -171,6 +179,13 @@ func (c *fakeAPIClient) EnqueueOperation(args params.Actions) (params.EnqueuedAc
 		Actions:      c.actionResults}, c.apiErr
 }
 
+func (c *fakeAPIClient) EnqueueRollingOperation(args params.RollingActions) (params.EnqueuedActions, error) {
+	c.rollingActions = args
+	return params.EnqueuedActions{
+		OperationTag: "operation-1",
+		Actions:      c.actionResults}, c.apiErr
+}
+
 func (c *fakeAPIClient) Cancel(args params.Entities) (params.ActionResults, error) {
 	return c.getActionResults(args.Entities), c.apiErr
 }
@@ -237,6 +252,28 @@ func (c *fakeAPIClient) WatchActionProgress(actionId string) (watcher.StringsWat
 	return watchertest.NewMockStringsWatcher(c.logMessageCh), nil
 }
 
+func (c *fakeAPIClient) WatchActionOutput(actionId string) (watcher.StringsWatcher, error) {
+	if c.outputNotSupported {
+		return nil, errors.NotSupportedf("WatchActionOutput")
+	}
+	c.watchedOutput = true
+	return watchertest.NewMockStringsWatcher(c.logMessageCh), nil
+}
+
+func (c *fakeAPIClient) AddActionSchedules(args []params.AddActionSchedule) ([]params.ActionScheduleResult, error) {
+	c.addedSchedules = args
+	return c.scheduleResults, c.apiErr
+}
+
+func (c *fakeAPIClient) ListActionSchedules() ([]params.ActionSchedule, error) {
+	return c.schedules, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveActionSchedules(ids []string) ([]params.ErrorResult, error) {
+	c.removedSchedules = ids
+	return c.removeResults, c.apiErr
+}
+
 func (c *fakeAPIClient) ListOperations(args params.OperationQueryArgs) (params.OperationResults, error) {
 	c.operationQueryArgs = args
 	return params.OperationResults{
@@ -0,0 +1,81 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+// removeScheduleCommand removes action schedules by id.
+type removeScheduleCommand struct {
+	ActionCommandBase
+	ids []string
+}
+
+const removeScheduleDoc = `
+Remove the action schedules with the given ids. Operations already created
+by the schedules are not affected.
+
+Examples:
+    juju remove-schedule 1
+    juju remove-schedule 1 2
+
+See also:
+    schedule-action
+    schedules
+`
+
+// Info implements Command.
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-schedule",
+		Args:    "<schedule-id> [...]",
+		Purpose: "Remove action schedules.",
+		Doc:     removeScheduleDoc,
+	})
+}
+
+// Init implements Command.
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule ids specified")
+	}
+	c.ids = args
+	return nil
+}
+
+// Run implements Command.
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RemoveActionSchedules(c.ids)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "removing schedule %s failed: %s\n", c.ids[i], result.Error)
+			failed = true
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
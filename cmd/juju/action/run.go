@@ -11,12 +11,12 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
-	"gopkg.in/yaml.v2"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
-	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
 )
 
 func NewRunCommand() cmd.Command {
@@ -35,11 +35,15 @@ func NewRunCommand() cmd.Command {
 type runCommand struct {
 	runCommandBase
 	unitReceivers []string
+	applications  []string
 	leaders       map[string]string
 	actionName    string
 	paramsYAML    cmd.FileVar
 	parseStrings  bool
 	args          [][]string
+	maxParallel   int
+	stopOnFailure int
+	leaderOrder   string
 }
 
 const runDoc = `
@@ -57,6 +61,9 @@ use the --background option.
 
 To set the maximum time to wait for a action to complete, use the --wait option.
 
+When running a single action, the --stream option shows the output of the
+action as it is written, rather than only once the action has finished.
+
 By default, the output of a single action will just be that action's stdout.
 For multiple actions, each action stdout is printed with the action id.
 To see more detailed information about run timings etc, use --format yaml.
@@ -68,32 +75,51 @@ Valid unit identifiers are:
 If the leader syntax is used, the leader unit for the application will be
 resolved before the action is enqueued.
 
-Params are validated according to the charm for the unit's application.  The
-valid params can be seen using "juju actions <application> --schema".
-Params may be in a yaml file which is passed with the --params option, or they
-may be specified by a key.key.key...=value format (see examples below.)
+Params are validated according to the charm for the unit's application before
+the action is enqueued, so that mistakes such as misspelt param names are
+reported straight away.  The valid params can be seen using
+"juju actions <application> --schema".
+Params may be in a yaml or json file which is passed with the --params-file
+option, or they may be specified by a key.key.key...=value format (see examples
+below.)  Files with a .json extension are read as json, any others as yaml.
+
+When run from a terminal, any required params which have not been given are
+prompted for.
 
 Params given in the CLI invocation will be parsed as YAML unless the
 --string-args option is set.  This can be helpful for values such as 'y', which
 is a boolean true in YAML.
 
-If --params is passed, along with key.key...=value explicit arguments, the
+If --params-file is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+The --app option runs the action on every unit of the given applications.
+
+Tasks can be rolled out a few at a time rather than all at once. The
+--max-parallel option limits how many tasks run at the same time, and
+--stop-on-failure cancels the tasks not yet started once the given number
+of tasks have failed. With --leader-order, the task on an application's
+leader runs on its own either "first" or "last". The progress of the
+rollout can be followed with 'juju show-operation'.
+
 Examples:
 
     juju run mysql/3 backup --background
     juju run mysql/3 backup --wait=2m
+    juju run mysql/3 upgrade --stream
     juju run mysql/3 backup --format yaml
     juju run mysql/3 backup --utc
     juju run mysql/3 backup
     juju run mysql/leader backup
     juju show-operation <ID>
-    juju run mysql/3 backup --params parameters.yml
+    juju run mysql/3 backup --params-file parameters.yml
+    juju run mysql/3 backup --params-file parameters.json
     juju run mysql/3 backup out=out.tar.bz2 file.kind=xz file.quality=high
-    juju run mysql/3 backup --params p.yml file.kind=xz file.quality=high
+    juju run mysql/3 backup --params-file p.yml file.kind=xz file.quality=high
     juju run sleeper/0 pause time=1000
     juju run sleeper/0 pause --string-args time=1000
+    juju run --app mysql restart --max-parallel 2 --stop-on-failure 1
+    juju run --app mysql restart --max-parallel 1 --leader-order last
 
 See also:
     list-operations
@@ -106,14 +132,19 @@ See also:
 func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.runCommandBase.SetFlags(f)
 
-	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.Var(&c.paramsYAML, "params-file", "Path to yaml or json formatted params file")
+	f.Var(&c.paramsYAML, "params", "Alias for --params-file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+	f.Var(cmd.NewStringsValue(nil, &c.applications), "app", "Comma separated list of applications to run the action on all units of")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "Maximum number of tasks to run at once (0 for no limit)")
+	f.IntVar(&c.stopOnFailure, "stop-on-failure", 0, "Number of failed tasks after which no more are started (0 for no limit)")
+	f.StringVar(&c.leaderOrder, "leader-order", "", `Run the task on the leader "first" or "last"`)
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "run",
-		Args:    "<unit> [<unit> ...] <action-name> [<key>=<value> [<key>[.<key> ...]=<value>]]",
+		Args:    "[<unit> ...] <action-name> [<key>=<value> [<key>[.<key> ...]=<value>]]",
 		Purpose: "Run an action on a specified unit.",
 		Doc:     runDoc,
 	})
@@ -134,31 +165,30 @@ func (c *runCommand) Init(args []string) (err error) {
 			return errors.Errorf("invalid unit or action name %q", arg)
 		}
 	}
-	if len(c.unitReceivers) == 0 {
+	for _, app := range c.applications {
+		if !names.IsValidApplication(app) {
+			return errors.Errorf("invalid application name %q", app)
+		}
+	}
+	if len(c.unitReceivers) == 0 && len(c.applications) == 0 {
 		return errors.New("no unit specified")
 	}
 	if c.actionName == "" {
 		return errors.New("no action specified")
 	}
+	if c.maxParallel < 0 {
+		return errors.New("--max-parallel must not be negative")
+	}
+	if c.stopOnFailure < 0 {
+		return errors.New("--stop-on-failure must not be negative")
+	}
+	if err := actions.LeaderOrder(c.leaderOrder).Validate(); err != nil {
+		return errors.Trace(err)
+	}
 
 	// Parse CLI key-value args if they exist.
-	c.args = make([][]string, 0)
-	for _, arg := range args[len(c.unitReceivers)+1:] {
-		thisArg := strings.SplitN(arg, "=", 2)
-		if len(thisArg) != 2 {
-			return errors.Errorf("argument %q must be of the form key.key.key...=value", arg)
-		}
-		keySlice := strings.Split(thisArg[0], ".")
-		// check each key for validity
-		for _, key := range keySlice {
-			if valid := nameRule.MatchString(key); !valid {
-				return errors.Errorf("key %q must start and end with lowercase alphanumeric, "+
-					"and contain only lowercase alphanumeric and hyphens", key)
-			}
-		}
-		c.args = append(c.args, append(keySlice, thisArg[1]))
-	}
-	return nil
+	c.args, err = parseActionArgs(args[len(c.unitReceivers)+1:])
+	return errors.Trace(err)
 }
 
 func (c *runCommand) Run(ctx *cmd.Context) error {
@@ -167,78 +197,87 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer c.api.Close()
 
-	results, err := c.enqueueActions(ctx)
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkActionParams(ctx, c.api, c.receiverApplications(), c.actionName, actionParams); err != nil {
+		return errors.Trace(err)
+	}
+	results, err := c.enqueueActions(actionParams)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	return c.processOperationResults(ctx, results)
 }
 
-func (c *runCommand) enqueueActions(ctx *cmd.Context) (*params.EnqueuedActions, error) {
-	actionParams := map[string]interface{}{}
-	if c.paramsYAML.Path != "" {
-		b, err := c.paramsYAML.Read(ctx)
-		if err != nil {
-			return nil, errors.Trace(err)
+// receiverApplications returns the applications of all the units and
+// applications the action is run on, without duplicates.
+func (c *runCommand) receiverApplications() []string {
+	apps := set.NewStrings(c.applications...)
+	for _, unitReceiver := range c.unitReceivers {
+		if strings.HasSuffix(unitReceiver, "leader") {
+			apps.Add(strings.TrimSuffix(unitReceiver, "/leader"))
+			continue
 		}
+		app, err := names.UnitApplication(unitReceiver)
+		if err == nil {
+			apps.Add(app)
+		}
+	}
+	return apps.SortedValues()
+}
 
-		err = yaml.Unmarshal(b, &actionParams)
-		if err != nil {
-			return nil, errors.Trace(err)
+func (c *runCommand) enqueueActions(actionParams map[string]interface{}) (*params.EnqueuedActions, error) {
+	var receivers []params.Action
+	for _, unitReceiver := range c.unitReceivers {
+		receiver := unitReceiver
+		if !strings.HasSuffix(unitReceiver, "leader") {
+			receiver = names.NewUnitTag(unitReceiver).String()
 		}
+		receivers = append(receivers, params.Action{
+			Receiver:   receiver,
+			Name:       c.actionName,
+			Parameters: actionParams,
+		})
+	}
+	for _, app := range c.applications {
+		receivers = append(receivers, params.Action{
+			Receiver:   names.NewApplicationTag(app).String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+		})
+	}
 
-		conformantParams, err := common.ConformYAML(actionParams)
+	if !c.rolling() {
+		results, err := c.api.EnqueueOperation(params.Actions{Actions: receivers})
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-
-		betterParams, ok := conformantParams.(map[string]interface{})
-		if !ok {
-			return nil, errors.New("params must contain a YAML map with string keys")
+		if len(results.Actions) != len(c.unitReceivers) {
+			return nil, errors.New("illegal number of results returned")
 		}
-
-		actionParams = betterParams
-	}
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
+		return &results, nil
 	}
-	conformantParams, err := common.ConformYAML(actionParams)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	typedConformantParams, ok := conformantParams.(map[string]interface{})
-	if !ok {
-		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
-	}
-	actions := make([]params.Action, len(c.unitReceivers))
-	for i, unitReceiver := range c.unitReceivers {
-		if strings.HasSuffix(unitReceiver, "leader") {
-			actions[i].Receiver = unitReceiver
-		} else {
-			actions[i].Receiver = names.NewUnitTag(unitReceiver).String()
-		}
-		actions[i].Name = c.actionName
-		actions[i].Parameters = actionParams
-	}
-	results, err := c.api.EnqueueOperation(params.Actions{Actions: actions})
+	// Application receivers are expanded to their units, so
+	// there is no fixed number of results.
+	results, err := c.api.EnqueueRollingOperation(params.RollingActions{
+		Actions:       receivers,
+		MaxParallel:   c.maxParallel,
+		StopOnFailure: c.stopOnFailure,
+		LeaderOrder:   c.leaderOrder,
+	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	if len(results.Actions) != len(c.unitReceivers) {
-		return nil, errors.New("illegal number of results returned")
+	if len(results.Actions) == 0 {
+		return nil, errors.New("no tasks enqueued")
 	}
 	return &results, nil
 }
+
+// rolling reports whether the action needs to be run as a rolling
+// operation, which is also the only way to target whole applications.
+func (c *runCommand) rolling() bool {
+	return len(c.applications) > 0 || c.maxParallel > 0 || c.stopOnFailure > 0 || c.leaderOrder != ""
+}
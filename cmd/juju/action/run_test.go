@@ -7,6 +7,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"sync"
@@ -79,6 +82,10 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		should:      "fail with missing args",
 		args:        []string{},
 		expectError: "no unit specified",
+	}, {
+		should:      "fail with both --stream and --background",
+		args:        []string{"--background", "--stream", validUnitId, "action"},
+		expectError: "cannot specify both --stream and --background",
 	}, {
 		should:      "fail with both --background and --wait",
 		args:        []string{"--background", "--wait=60s", validUnitId, "action"},
@@ -243,6 +250,76 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}
 }
 
+func (s *RunSuite) TestInitRolling(c *gc.C) {
+	tests := []struct {
+		should      string
+		args        []string
+		expectError string
+	}{{
+		should: "accept an application without units",
+		args:   []string{"--app", "mysql", "restart"},
+	}, {
+		should: "accept applications alongside units",
+		args:   []string{"--app", "mysql,wordpress", validUnitId, "restart", "--max-parallel", "2", "--leader-order", "last"},
+	}, {
+		should:      "fail with an invalid application",
+		args:        []string{"--app", "MySQL", "restart"},
+		expectError: `invalid application name "MySQL"`,
+	}, {
+		should:      "fail with a negative --max-parallel",
+		args:        []string{"--app", "mysql", "restart", "--max-parallel", "-1"},
+		expectError: "--max-parallel must not be negative",
+	}, {
+		should:      "fail with a negative --stop-on-failure",
+		args:        []string{"--app", "mysql", "restart", "--stop-on-failure", "-1"},
+		expectError: "--stop-on-failure must not be negative",
+	}, {
+		should:      "fail with an invalid --leader-order",
+		args:        []string{"--app", "mysql", "restart", "--leader-order", "middle"},
+		expectError: `leader order "middle" not valid`,
+	}}
+
+	for i, t := range tests {
+		c.Logf("test %d: should %s", i, t.should)
+		wrappedCommand, _ := action.NewRunCommandForTest(s.store, testClock(), nil)
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := cmdtesting.InitCommand(wrappedCommand, args)
+		if t.expectError == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.expectError)
+		}
+	}
+}
+
+func (s *RunSuite) TestRunRolling(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+		}},
+		charmActions: anyParamsActions("restart"),
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store, testClock(), nil)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin",
+		"--app", "mysql", "restart", "--max-parallel", "2", "--stop-on-failure", "1", "--background")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.rollingActions, jc.DeepEquals, params.RollingActions{
+		Actions: []params.Action{{
+			Receiver:   "application-mysql",
+			Name:       "restart",
+			Parameters: map[string]interface{}{},
+		}},
+		MaxParallel:   2,
+		StopOnFailure: 1,
+	})
+}
+
 func (s *RunSuite) TestRun(c *gc.C) {
 	tests := []struct {
 		should                 string
@@ -795,6 +872,7 @@ mysql/1:
 			fakeClient := &fakeAPIClient{
 				actionResults: t.withActionResults,
 				logMessageCh:  make(chan []string, len(t.expectedLogs)),
+				charmActions:  anyParamsActions("some-action"),
 			}
 
 			numExpectedTimers := 0
@@ -828,6 +906,172 @@ mysql/1:
 	}
 }
 
+func (s *RunSuite) TestRunStream(c *gc.C) {
+	s.clock = testClock()
+	fakeClient := &fakeAPIClient{
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+				Name:     "some-action",
+			},
+			Status: "completed",
+			Output: map[string]interface{}{
+				"return-code": 0,
+				"stdout":      "hello",
+			},
+		}},
+		logMessageCh:   make(chan []string, 1),
+		waitForResults: make(chan bool),
+		charmActions:   anyParamsActions("some-action"),
+	}
+	s.testRunHelper(c, fakeClient, "", "hello", "-m",
+		[]string{validUnitId, "some-action", "--stream"},
+		1, 2,
+		[]params.Action{{
+			Name:       "some-action",
+			Parameters: map[string]interface{}{},
+			Receiver:   names.NewUnitTag(validUnitId).String(),
+		}},
+		[]string{"log line 1"},
+	)
+	c.Assert(fakeClient.watchedOutput, jc.IsTrue)
+}
+
+// anyParamsActions returns action specs for the given actions which
+// accept any params.
+func anyParamsActions(actionNames ...string) map[string]params.ActionSpec {
+	specs := make(map[string]params.ActionSpec)
+	for _, name := range actionNames {
+		specs[name] = params.ActionSpec{Params: map[string]interface{}{"type": "object"}}
+	}
+	return specs
+}
+
+var backupActions = map[string]params.ActionSpec{
+	"backup": {Params: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"out":   map[string]interface{}{"type": "string"},
+			"level": map[string]interface{}{"type": "integer"},
+		},
+		"required":             []interface{}{"out"},
+		"additionalProperties": false,
+	}},
+}
+
+func (s *RunSuite) TestRunValidatesParams(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{{
+		args:        []string{validUnitId, "restore"},
+		expectedErr: `action "restore" not defined for application "mysql"`,
+	}, {
+		args:        []string{validUnitId, "backup", "out=x", "levle=2"},
+		expectedErr: `invalid params for action "backup" on application "mysql": validation failed: .*levle.*`,
+	}, {
+		args:        []string{validUnitId, "backup", "out=x", "level=high"},
+		expectedErr: `invalid params for action "backup" on application "mysql": validation failed: .*level.*`,
+	}, {
+		args:        []string{validUnitId, "backup"},
+		expectedErr: `invalid params for action "backup" on application "mysql": validation failed: .*out.*`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		fakeClient := &fakeAPIClient{charmActions: backupActions}
+		restore := s.patchAPIClient(fakeClient)
+
+		wrappedCommand, _ := action.NewRunCommandForTest(s.store, testClock(), nil)
+		args := append([]string{"-m", "admin", "--background"}, t.args...)
+		_, err := cmdtesting.RunCommand(c, wrappedCommand, args...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+		c.Check(fakeClient.enqueuedActions.Actions, gc.HasLen, 0)
+		restore()
+	}
+}
+
+func (s *RunSuite) TestRunParamsFileJSON(c *gc.C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "params.json")
+	err := ioutil.WriteFile(path, []byte(`{"out": "backup.tar", "level": 3}`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fakeClient := &fakeAPIClient{
+		charmActions: backupActions,
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store, testClock(), nil)
+	_, err = cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", "--background",
+		validUnitId, "backup", "--params-file", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.enqueuedActions.Actions, jc.DeepEquals, []params.Action{{
+		Receiver:   names.NewUnitTag(validUnitId).String(),
+		Name:       "backup",
+		Parameters: map[string]interface{}{"out": "backup.tar", "level": float64(3)},
+	}})
+}
+
+func (s *RunSuite) TestRunPromptsForRequiredParams(c *gc.C) {
+	s.PatchValue(action.IsTerminal, func(io.Reader) bool { return true })
+	fakeClient := &fakeAPIClient{
+		charmActions: backupActions,
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store, testClock(), nil)
+	err := cmdtesting.InitCommand(wrappedCommand, []string{"-m", "admin", "--background", validUnitId, "backup"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("backup.tar\n")
+	err = wrappedCommand.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, "(?s)Enter out.*")
+	c.Assert(fakeClient.enqueuedActions.Actions, jc.DeepEquals, []params.Action{{
+		Receiver:   names.NewUnitTag(validUnitId).String(),
+		Name:       "backup",
+		Parameters: map[string]interface{}{"out": "backup.tar"},
+	}})
+}
+
+func (s *RunSuite) TestDecodeLogMessage(c *gc.C) {
+	encode := func(msg actions.ActionMessage) string {
+		data, err := json.Marshal(msg)
+		c.Assert(err, jc.ErrorIsNil)
+		return string(data)
+	}
+	timestamp := time.Date(2015, time.February, 14, 6, 6, 6, 0, time.UTC)
+
+	msg, err := action.DecodeLogMessage(encode(actions.ActionMessage{
+		Message:   "log line",
+		Timestamp: timestamp,
+	}), true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(msg, gc.Equals, "06:06:06 log line")
+
+	msg, err = action.DecodeLogMessage(encode(actions.ActionMessage{
+		Message:   "line 1\nline 2\n",
+		Timestamp: timestamp,
+		Stream:    "stdout",
+	}), true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(msg, gc.Equals, "line 1\nline 2")
+}
+
 func (s *RunSuite) testRunHelper(c *gc.C, client *fakeAPIClient,
 	expectedErr, expectedOutput, modelFlag string, withArgs []string,
 	numTicks int, numExpectedTimers int,
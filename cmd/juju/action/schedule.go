@@ -0,0 +1,147 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
+)
+
+func NewScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&scheduleCommand{})
+}
+
+// scheduleCommand adds a schedule which periodically runs an action.
+type scheduleCommand struct {
+	ActionCommandBase
+	receiver        string
+	actionName      string
+	cron            string
+	missedRunPolicy string
+	paramsYAML      cmd.FileVar
+	parseStrings    bool
+	args            [][]string
+}
+
+const scheduleDoc = `
+Schedule a charm action to run periodically on an application, a unit, or
+the leader unit of an application.
+
+The schedule is a standard 5 field cron expression (minute, hour, day of
+month, month, day of week) evaluated in UTC, or one of the macros @yearly,
+@monthly, @weekly, @daily or @hourly.
+
+Each time the schedule is due a new operation is created. When the target
+is an application, the operation has a task on every unit of the
+application at that time. When the target is <application>/leader, the
+leader is resolved each time the schedule fires.
+
+If the controller was unable to run the action when it was due, the
+--missed-run-policy option decides what happens: "skip" (the default)
+waits for the next due time, "run-once" runs the action once as soon as
+possible.
+
+Params are validated according to the charm for the application when the
+schedule is added, and are given in the same way as for 'juju run'.
+
+Examples:
+
+    juju schedule-action mysql backup --cron "0 3 * * *"
+    juju schedule-action mysql/leader backup --cron @daily out=out.tar.bz2
+    juju schedule-action mysql/0 backup --cron "*/30 * * * *" --missed-run-policy run-once
+    juju schedule-action mysql backup --cron @weekly --params-file parameters.yml
+
+See also:
+    list-schedules
+    remove-schedule
+    operations
+`
+
+// SetFlags implements Command.
+func (c *scheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.StringVar(&c.cron, "cron", "", "Cron expression for when the action is run")
+	f.StringVar(&c.missedRunPolicy, "missed-run-policy", string(actions.MissedRunSkip), `What to do with missed runs, "skip" or "run-once"`)
+	f.Var(&c.paramsYAML, "params-file", "Path to yaml or json formatted params file")
+	f.Var(&c.paramsYAML, "params", "Alias for --params-file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+// Info implements Command.
+func (c *scheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "schedule-action",
+		Args:    "<application>|<unit> <action-name> [<key>=<value> [<key>[.<key> ...]=<value>]]",
+		Purpose: "Run an action periodically.",
+		Doc:     scheduleDoc,
+	})
+}
+
+// Init implements Command.
+func (c *scheduleCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no application or unit specified")
+	}
+	c.receiver = args[0]
+	if !names.IsValidApplication(c.receiver) && !names.IsValidUnit(c.receiver) && !validLeader.MatchString(c.receiver) {
+		return errors.Errorf("invalid application or unit name %q", c.receiver)
+	}
+	if len(args) < 2 {
+		return errors.New("no action specified")
+	}
+	c.actionName = args[1]
+	if !nameRule.MatchString(c.actionName) {
+		return errors.Errorf("invalid action name %q", c.actionName)
+	}
+	if c.cron == "" {
+		return errors.New("no schedule specified, use --cron")
+	}
+	if _, err := actions.ParseCronSchedule(c.cron); err != nil {
+		return errors.Trace(err)
+	}
+	if err := actions.MissedRunPolicy(c.missedRunPolicy).Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	c.args, err = parseActionArgs(args[2:])
+	return errors.Trace(err)
+}
+
+// Run implements Command.
+func (c *scheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	results, err := api.AddActionSchedules([]params.AddActionSchedule{{
+		Receiver:        c.receiver,
+		Name:            c.actionName,
+		Parameters:      actionParams,
+		Cron:            c.cron,
+		MissedRunPolicy: c.missedRunPolicy,
+	}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	ctx.Infof("Added schedule %s", results[0].Schedule.Id)
+	return nil
+}
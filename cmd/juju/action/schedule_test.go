@@ -0,0 +1,192 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) TestInit(c *gc.C) {
+	tests := []struct {
+		should      string
+		args        []string
+		expectedErr string
+	}{{
+		should:      "fail with no receiver",
+		args:        []string{},
+		expectedErr: "no application or unit specified",
+	}, {
+		should:      "fail with invalid receiver",
+		args:        []string{"Mysql", "backup", "--cron", "@daily"},
+		expectedErr: `invalid application or unit name "Mysql"`,
+	}, {
+		should:      "fail with no action",
+		args:        []string{"mysql", "--cron", "@daily"},
+		expectedErr: "no action specified",
+	}, {
+		should:      "fail with no cron expression",
+		args:        []string{"mysql", "backup"},
+		expectedErr: "no schedule specified, use --cron",
+	}, {
+		should:      "fail with invalid cron expression",
+		args:        []string{"mysql", "backup", "--cron", "61 * * * *"},
+		expectedErr: `minute value "61" not valid`,
+	}, {
+		should:      "fail with invalid missed run policy",
+		args:        []string{"mysql", "backup", "--cron", "@daily", "--missed-run-policy", "always"},
+		expectedErr: `missed run policy "always" not valid`,
+	}, {
+		should:      "fail with invalid param",
+		args:        []string{"mysql", "backup", "--cron", "@daily", "out"},
+		expectedErr: `argument "out" must be of the form key.key.key...=value`,
+	}, {
+		should: "accept an application leader",
+		args:   []string{"mysql/leader", "backup", "--cron", "@daily", "out.file=x"},
+	}}
+
+	for i, t := range tests {
+		c.Logf("test %d should %s", i, t.should)
+		wrappedCommand, _ := action.NewScheduleCommandForTest(s.store)
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := cmdtesting.InitCommand(wrappedCommand, args)
+		if t.expectedErr == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.expectedErr)
+		}
+	}
+}
+
+func (s *ScheduleSuite) TestRun(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		scheduleResults: []params.ActionScheduleResult{{
+			Schedule: &params.ActionSchedule{Id: "3"},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewScheduleCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin",
+		"mysql/leader", "backup", "--cron", "0 3 * * *", "--missed-run-policy", "run-once",
+		"out=out.tar.bz2", "file.kind=xz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Added schedule 3\n")
+	c.Assert(fakeClient.addedSchedules, jc.DeepEquals, []params.AddActionSchedule{{
+		Receiver: "mysql/leader",
+		Name:     "backup",
+		Parameters: map[string]interface{}{
+			"out":  "out.tar.bz2",
+			"file": map[string]interface{}{"kind": "xz"},
+		},
+		Cron:            "0 3 * * *",
+		MissedRunPolicy: "run-once",
+	}})
+}
+
+func (s *ScheduleSuite) TestRunError(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		scheduleResults: []params.ActionScheduleResult{{
+			Error: &params.Error{Message: `action "backup" not defined for application "mysql"`},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewScheduleCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", "mysql", "backup", "--cron", "@daily")
+	c.Assert(err, gc.ErrorMatches, `action "backup" not defined for application "mysql"`)
+}
+
+func (s *ScheduleSuite) TestListSchedules(c *gc.C) {
+	created := time.Date(2021, time.January, 5, 10, 0, 0, 0, time.UTC)
+	fakeClient := &fakeAPIClient{
+		schedules: []params.ActionSchedule{{
+			Id:              "1",
+			Receiver:        "mysql",
+			Name:            "backup",
+			Cron:            "0 3 * * *",
+			MissedRunPolicy: "skip",
+			Created:         created,
+		}, {
+			Id:              "2",
+			Receiver:        "mysql/leader",
+			Name:            "vacuum",
+			Cron:            "@hourly",
+			MissedRunPolicy: "run-once",
+			Created:         created,
+			LastRun:         created.Add(2 * time.Hour),
+			LastOperation:   "7",
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Id  Receiver      Action  Cron       Next run             Last run             Last operation\n"+
+		" 1  mysql         backup  0 3 * * *  2021-01-06T03:00:00                       \n"+
+		" 2  mysql/leader  vacuum  @hourly    2021-01-05T13:00:00  2021-01-05T12:00:00  7\n"+
+		"\n")
+
+	ctx, err = cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin", "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"1":
+  receiver: mysql
+  action: backup
+  cron: 0 3 * * *
+  missed-run-policy: skip
+  created: 2021-01-05 10:00:00 +0000 UTC
+  next-run: 2021-01-06 03:00:00 +0000 UTC
+"2":
+  receiver: mysql/leader
+  action: vacuum
+  cron: '@hourly'
+  missed-run-policy: run-once
+  created: 2021-01-05 10:00:00 +0000 UTC
+  last-run: 2021-01-05 12:00:00 +0000 UTC
+  last-operation: "7"
+  next-run: 2021-01-05 13:00:00 +0000 UTC
+`[1:])
+}
+
+func (s *ScheduleSuite) TestListSchedulesNone(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{})
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "no action schedules\n")
+}
+
+func (s *ScheduleSuite) TestRemoveSchedule(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		removeResults: []params.ErrorResult{{}, {
+			Error: &params.Error{Message: `action schedule "9" not found`},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "-m", "admin", "1", "9")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(fakeClient.removedSchedules, jc.DeepEquals, []string{"1", "9"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "removing schedule 9 failed: action schedule \"9\" not found\n")
+}
@@ -46,6 +46,8 @@ Show the results returned by a task with the given ID.
 To block until the result is known completed or failed, use
 the --wait option with a duration, as in --wait 5s or --wait 1h.
 Use --watch to wait indefinitely.  
+While waiting, any messages logged by the task and any output it writes
+are shown as they happen.
 
 The default behavior without --wait or --watch is to immediately check and return;
 if the results are "pending" then only the available information will be
@@ -142,7 +144,11 @@ func (c *showTaskCommand) Run(ctx *cmd.Context) error {
 	}
 
 	if shouldWatch {
-		logsWatcher, err = api.WatchActionProgress(c.requestedId)
+		logsWatcher, err = api.WatchActionOutput(c.requestedId)
+		if errors.IsNotSupported(err) {
+			// Older controllers can only report log messages.
+			logsWatcher, err = api.WatchActionProgress(c.requestedId)
+		}
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -82,6 +82,7 @@ func (s *ShowTaskSuite) TestRun(c *gc.C) {
 		expectedErr       string
 		expectedOutput    string
 		expectedLogs      []string
+		outputUnsupported bool
 		watch             bool
 	}{{
 		should:            "timeout if result never comes",
@@ -334,6 +335,27 @@ timing:
 		expectedOutput: `
 id: "1"
 status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+`[1:],
+	}, {
+		should:            "print log messages when watching an older controller",
+		withClientQueryID: validActionId,
+		withAPITimeout:    5 * time.Second,
+		withAPIDelay:      1 * time.Second,
+		watch:             true,
+		expectedLogs:      []string{"log line 1", "log line 2"},
+		withTicks:         1,
+		outputUnsupported: true,
+		withAPIResponse: []params.ActionResult{{
+			Status:    "completed",
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		expectedOutput: `
+id: "1"
+status: completed
 timing:
   completed: 2015-02-14 08:15:30 +0000 UTC
   enqueued: 2015-02-14 08:13:00 +0000 UTC
@@ -370,6 +392,7 @@ timing:
 			}
 
 			fakeClient.logMessageCh = make(chan []string, len(t.expectedLogs))
+			fakeClient.outputNotSupported = t.outputUnsupported
 			if len(t.expectedLogs) > 0 {
 				fakeClient.waitForResults = make(chan bool)
 			}
@@ -0,0 +1,121 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"io"
+	"os"
+	"sort"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/names/v4"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/interact"
+)
+
+// isTerminal reports whether the given input is an interactive terminal.
+var isTerminal = func(stdin io.Reader) bool {
+	f, ok := stdin.(*os.File)
+	return ok && terminal.IsTerminal(int(f.Fd()))
+}
+
+// checkActionParams validates the action params against the action's
+// schema in the charm of each of the given applications, so that any
+// mistakes are reported before tasks are enqueued. When stdin is a
+// terminal, the user is first asked for any required params that have
+// not been given.
+func checkActionParams(
+	ctx *cmd.Context, api APIClient, applications []string, actionName string, actionParams map[string]interface{},
+) error {
+	interactive := isTerminal(ctx.Stdin)
+	for _, app := range applications {
+		specs, err := api.ApplicationCharmActions(params.Entity{Tag: names.NewApplicationTag(app).String()})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		spec, ok := specs[actionName]
+		if !ok {
+			return errors.Errorf("action %q not defined for application %q", actionName, app)
+		}
+		if interactive {
+			if err := queryRequiredParams(ctx, spec, actionParams); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		charmSpec := charm.ActionSpec{Params: spec.Params}
+		if err := charmSpec.ValidateParams(actionParams); err != nil {
+			return errors.Annotatef(err, "invalid params for action %q on application %q", actionName, app)
+		}
+	}
+	return nil
+}
+
+// queryRequiredParams prompts the user for each required param in the
+// action spec which is missing from actionParams, and adds the answers.
+func queryRequiredParams(ctx *cmd.Context, spec params.ActionSpec, actionParams map[string]interface{}) error {
+	required, _ := spec.Params["required"].([]interface{})
+	properties, _ := spec.Params["properties"].(map[string]interface{})
+	var missing []string
+	for _, name := range required {
+		name, ok := name.(string)
+		if !ok {
+			continue
+		}
+		if _, ok := actionParams[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+
+	pollster := interact.New(ctx.Stdin, ctx.Stderr, interact.NewErrWriter(ctx.Stderr))
+	for _, name := range missing {
+		schema, err := paramSchema(name, properties[name])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		value, err := pollster.QuerySchema(schema)
+		if err != nil {
+			return errors.Annotatef(err, "querying param %q", name)
+		}
+		actionParams[name] = value
+	}
+	return nil
+}
+
+// paramTypes maps the json schema type names which can be prompted for
+// to their jsonschema types.
+var paramTypes = map[string]jsonschema.Type{
+	"string":  jsonschema.StringType,
+	"integer": jsonschema.IntegerType,
+	"number":  jsonschema.NumberType,
+	"boolean": jsonschema.BooleanType,
+}
+
+// paramSchema converts the action spec for a single param into a schema
+// the pollster can query. Params without a type are queried as strings.
+func paramSchema(name string, spec interface{}) (*jsonschema.Schema, error) {
+	props, _ := spec.(map[string]interface{})
+	schema := &jsonschema.Schema{
+		Singular: name,
+		Type:     []jsonschema.Type{jsonschema.StringType},
+	}
+	if typeName, ok := props["type"].(string); ok {
+		paramType, ok := paramTypes[typeName]
+		if !ok {
+			return nil, errors.Errorf("cannot prompt for param %q of type %q, use --params-file", name, typeName)
+		}
+		schema.Type = []jsonschema.Type{paramType}
+	}
+	schema.Description, _ = props["description"].(string)
+	schema.Enum, _ = props["enum"].([]interface{})
+	return schema, nil
+}
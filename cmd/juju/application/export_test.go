@@ -187,6 +187,24 @@ func NewResumeRelationCommandForTest(api SetRelationSuspendedAPI, store jujuclie
 	return modelcmd.Wrap(cmd)
 }
 
+// NewPauseUnitCommandForTest returns a PauseUnitCommand with the api provided as specified.
+func NewPauseUnitCommandForTest(api PauseUnitsAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &pauseUnitCommand{newAPIFunc: func() (PauseUnitsAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewResumeUnitCommandForTest returns a ResumeUnitCommand with the api provided as specified.
+func NewResumeUnitCommandForTest(api PauseUnitsAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &resumeUnitCommand{newAPIFunc: func() (PauseUnitsAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewRemoveSaasCommandForTest returns a RemoveSaasCommand with the api provided as specified.
 func NewRemoveSaasCommandForTest(api RemoveSaasAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &removeSaasCommand{newAPIFunc: func() (RemoveSaasAPI, error) {
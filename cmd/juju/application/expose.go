@@ -64,6 +64,17 @@ juju to only allow access to ports opened for the "logs" endpoint from CIDR
 juju expose apache2 --endpoints logs --to-cidrs 10.0.0.0/24
 juju expose apache2 --endpoints logs --to-cidrs 192.168.0.0/24
 
+For applications deployed to a k8s model, the --hostname option may be used
+to expose the application through an ingress resource routing the given
+hostname to the ports opened by the application. The --path, --tls-secret and
+--ingress-class options further configure the ingress. For example, to serve
+gitlab over TLS using the certificate stored in the "gitlab-tls" secret, you
+can run:
+
+juju expose gitlab --hostname gitlab.example.com --tls-secret gitlab-tls --path /
+
+Running "juju unexpose" removes the ingress.
+
 See also: 
     unexpose`[1:]
 
@@ -79,6 +90,12 @@ type exposeCommand struct {
 	ExposedEndpointsList string
 	ExposeToSpacesList   string
 	ExposeToCIDRsList    string
+
+	// Ingress related options for k8s applications.
+	Hostname     string
+	Path         string
+	TLSSecret    string
+	IngressClass string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -95,6 +112,10 @@ func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.ExposedEndpointsList, "endpoints", "", "Expose only the ports that charms have opened for this comma-delimited list of endpoints")
 	f.StringVar(&c.ExposeToSpacesList, "to-spaces", "", "A comma-delimited list of spaces that should be able to access the application ports once exposed")
 	f.StringVar(&c.ExposeToCIDRsList, "to-cidrs", "", "A comma-delimited list of CIDRs that should be able to access the application ports once exposed")
+	f.StringVar(&c.Hostname, "hostname", "", "Expose a k8s application through an ingress for this hostname")
+	f.StringVar(&c.Path, "path", "", "The HTTP path routed to the application by the ingress")
+	f.StringVar(&c.TLSSecret, "tls-secret", "", "The k8s secret holding the TLS certificate for the ingress hostname")
+	f.StringVar(&c.IngressClass, "ingress-class", "", "The ingress class used to create the ingress")
 }
 
 func (c *exposeCommand) Init(args []string) error {
@@ -102,12 +123,19 @@ func (c *exposeCommand) Init(args []string) error {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	if c.Hostname == "" && (c.Path != "" || c.TLSSecret != "" || c.IngressClass != "") {
+		return errors.New("--path, --tls-secret and --ingress-class require --hostname")
+	}
+	if c.Path != "" && !strings.HasPrefix(c.Path, "/") {
+		return errors.Errorf("ingress path %q must start with a /", c.Path)
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type applicationExposeAPI interface {
 	Close() error
 	Expose(applicationName string, exposedEndpoints map[string]params.ExposedEndpoint) error
+	ExposeIngress(applicationName string, exposedEndpoints map[string]params.ExposedEndpoint, ingress params.ExposedIngress) error
 	Unexpose(applicationName string, exposedEndpoints []string) error
 }
 
@@ -129,6 +157,15 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 	defer client.Close()
 
 	exposedEndpoints := c.buildExposedEndpoints()
+	if c.Hostname != "" {
+		err = client.ExposeIngress(c.ApplicationName, exposedEndpoints, params.ExposedIngress{
+			Hostname:  c.Hostname,
+			Path:      c.Path,
+			TLSSecret: c.TLSSecret,
+			Class:     c.IngressClass,
+		})
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
 	return block.ProcessBlockedError(client.Expose(c.ApplicationName, exposedEndpoints), block.BlockChange)
 }
 
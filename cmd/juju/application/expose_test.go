@@ -64,3 +64,10 @@ func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	err := runExpose(c, "some-application-name")
 	s.AssertBlocked(c, err, ".*TestBlockExpose.*")
 }
+
+func (s *ExposeSuite) TestExposeIngressFlagsRequireHostname(c *gc.C) {
+	err := runExpose(c, "some-application-name", "--tls-secret", "some-secret")
+	c.Assert(err, gc.ErrorMatches, "--path, --tls-secret and --ingress-class require --hostname")
+	err = runExpose(c, "some-application-name", "--hostname", "foo.example.com", "--path", "foo")
+	c.Assert(err, gc.ErrorMatches, `ingress path "foo" must start with a /`)
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var pauseUnitHelpSummary = `
+Pauses hook execution on units.`[1:]
+
+var pauseUnitHelpDetails = `
+The unit agents of the given units finish any hook or action they are
+running, but do not start any new ones. Changes the agents would respond
+to, such as config changes or relation changes, are queued and acted on
+once the units are resumed with resume-unit.
+
+The unit's workload keeps running while its agent is paused. Paused units
+are shown in status with an agent status of "paused".
+
+Examples:
+    juju pause-unit mysql/0
+    juju pause-unit mysql/0 mysql/1
+
+See also:
+    resume-unit
+    status`
+
+// PauseUnitsAPI provides the application API methods used to pause and
+// resume units.
+type PauseUnitsAPI interface {
+	Close() error
+	BestAPIVersion() int
+	PauseUnits(units []string) error
+	ResumeUnits(units []string) error
+}
+
+// NewPauseUnitCommand returns a command to pause hook execution on units.
+func NewPauseUnitCommand() cmd.Command {
+	cmd := &pauseUnitCommand{}
+	cmd.newAPIFunc = func() (PauseUnitsAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type pauseUnitCommand struct {
+	modelcmd.ModelCommandBase
+	unitNames  []string
+	newAPIFunc func() (PauseUnitsAPI, error)
+}
+
+func (c *pauseUnitCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "pause-unit",
+		Args:    "<unit> [<unit> ...]",
+		Purpose: pauseUnitHelpSummary,
+		Doc:     pauseUnitHelpDetails,
+	})
+}
+
+func (c *pauseUnitCommand) Init(args []string) (err error) {
+	c.unitNames, err = parseUnitNames(args)
+	return errors.Trace(err)
+}
+
+func (c *pauseUnitCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 14 {
+		return errors.New("pausing a unit is not supported by this version of Juju")
+	}
+	err = client.PauseUnits(c.unitNames)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// parseUnitNames checks that at least one unit has been given and that
+// all of them are valid unit names.
+func parseUnitNames(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no units specified")
+	}
+	for _, unit := range args {
+		if !names.IsValidUnit(unit) {
+			return nil, errors.NotValidf("unit name %q", unit)
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type PauseUnitSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockPauseUnitsAPI
+}
+
+func (s *PauseUnitSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockPauseUnitsAPI{Stub: &testing.Stub{}, version: 14}
+}
+
+var _ = gc.Suite(&PauseUnitSuite{})
+
+func (s *PauseUnitSuite) runPauseUnit(c *gc.C, args ...string) error {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, NewPauseUnitCommandForTest(s.mockAPI, store), args...)
+	return err
+}
+
+func (s *PauseUnitSuite) TestPauseUnitInvalidArguments(c *gc.C) {
+	err := s.runPauseUnit(c)
+	c.Assert(err, gc.ErrorMatches, "no units specified")
+
+	err = s.runPauseUnit(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+}
+
+func (s *PauseUnitSuite) TestPauseUnitOldServer(c *gc.C) {
+	s.mockAPI.version = 13
+	err := s.runPauseUnit(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "pausing a unit is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *PauseUnitSuite) TestPauseUnitSuccess(c *gc.C) {
+	err := s.runPauseUnit(c, "mysql/0", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "PauseUnits", "Close")
+	s.mockAPI.CheckCall(c, 0, "PauseUnits", []string{"mysql/0", "mysql/1"})
+}
+
+func (s *PauseUnitSuite) TestPauseUnitFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	err := s.runPauseUnit(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.mockAPI.CheckCallNames(c, "PauseUnits", "Close")
+}
+
+func (s *PauseUnitSuite) TestPauseUnitBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(apiservererrors.OperationBlockedError("TestPauseUnitBlocked"))
+	err := s.runPauseUnit(c, "mysql/0")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestPauseUnitBlocked.*")
+	s.mockAPI.CheckCallNames(c, "PauseUnits", "Close")
+}
+
+type mockPauseUnitsAPI struct {
+	*testing.Stub
+	version int
+}
+
+func (s mockPauseUnitsAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return nil
+}
+
+func (s mockPauseUnitsAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s mockPauseUnitsAPI) PauseUnits(units []string) error {
+	s.MethodCall(s, "PauseUnits", units)
+	return s.NextErr()
+}
+
+func (s mockPauseUnitsAPI) ResumeUnits(units []string) error {
+	s.MethodCall(s, "ResumeUnits", units)
+	return s.NextErr()
+}
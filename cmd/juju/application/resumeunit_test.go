@@ -0,0 +1,60 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ResumeUnitSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockPauseUnitsAPI
+}
+
+func (s *ResumeUnitSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockPauseUnitsAPI{Stub: &testing.Stub{}, version: 14}
+}
+
+var _ = gc.Suite(&ResumeUnitSuite{})
+
+func (s *ResumeUnitSuite) runResumeUnit(c *gc.C, args ...string) error {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, NewResumeUnitCommandForTest(s.mockAPI, store), args...)
+	return err
+}
+
+func (s *ResumeUnitSuite) TestResumeUnitInvalidArguments(c *gc.C) {
+	err := s.runResumeUnit(c)
+	c.Assert(err, gc.ErrorMatches, "no units specified")
+
+	err = s.runResumeUnit(c, "mysql/x")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql/x" not valid`)
+}
+
+func (s *ResumeUnitSuite) TestResumeUnitOldServer(c *gc.C) {
+	s.mockAPI.version = 13
+	err := s.runResumeUnit(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "resuming a unit is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *ResumeUnitSuite) TestResumeUnitSuccess(c *gc.C) {
+	err := s.runResumeUnit(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "ResumeUnits", "Close")
+	s.mockAPI.CheckCall(c, 0, "ResumeUnits", []string{"mysql/0"})
+}
+
+func (s *ResumeUnitSuite) TestResumeUnitFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	err := s.runResumeUnit(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -185,6 +185,18 @@ type ApplicationInfo struct {
 	ExposedEndpoints map[string]ExposedEndpoint `yaml:"exposed-endpoints,omitempty" json:"exposed-endpoints,omitempty"`
 	Remote           bool                       `yaml:"remote" json:"remote"`
 	EndpointBindings map[string]string          `yaml:"endpoint-bindings,omitempty" json:"endpoint-bindings,omitempty"`
+	Availability     *Availability              `yaml:"availability,omitempty" json:"availability,omitempty"`
+}
+
+// Availability defines the serialization behavior of the availability
+// policy of a k8s application and the status of its disruption budget.
+type Availability struct {
+	MaxUnavailableUnits int      `yaml:"max-unavailable-units,omitempty" json:"max-unavailable-units,omitempty"`
+	SpreadAcross        []string `yaml:"spread-across,omitempty" json:"spread-across,omitempty"`
+	DisruptionsAllowed  *int     `yaml:"disruptions-allowed,omitempty" json:"disruptions-allowed,omitempty"`
+	CurrentHealthy      *int     `yaml:"current-healthy,omitempty" json:"current-healthy,omitempty"`
+	DesiredHealthy      *int     `yaml:"desired-healthy,omitempty" json:"desired-healthy,omitempty"`
+	DisruptionBudget    string   `yaml:"disruption-budget,omitempty" json:"disruption-budget,omitempty"`
 }
 
 // ExposedEndpoint defines the serialization behavior of the expose settings
@@ -223,5 +235,19 @@ func createApplicationInfo(details params.ApplicationResult) (names.ApplicationT
 		Remote:           details.Remote,
 		EndpointBindings: details.EndpointBindings,
 	}
+	if a := details.Availability; a != nil {
+		info.Availability = &Availability{
+			MaxUnavailableUnits: a.MaxUnavailableUnits,
+			SpreadAcross:        a.SpreadAcross,
+		}
+		if a.Unknown {
+			// The status of the disruption budget could not be retrieved.
+			info.Availability.DisruptionBudget = "unknown"
+		} else {
+			info.Availability.DisruptionsAllowed = &a.DisruptionsAllowed
+			info.Availability.CurrentHealthy = &a.CurrentHealthy
+			info.Availability.DesiredHealthy = &a.DesiredHealthy
+		}
+	}
 	return tag, info, nil
 }
@@ -201,6 +201,80 @@ wordpress:
 	})
 }
 
+func (s *ShowSuite) TestShowAvailability(c *gc.C) {
+	app := s.createTestApplicationInfo("mariadb", "")
+	app.Availability = &params.ApplicationAvailability{
+		MaxUnavailableUnits: 1,
+		SpreadAcross:        []string{"zone", "node"},
+		DisruptionsAllowed:  1,
+		CurrentHealthy:      3,
+		DesiredHealthy:      2,
+	}
+	s.mockAPI.applicationsInfoFunc = func([]names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+		return []params.ApplicationInfoResult{{Result: app}}, nil
+	}
+	s.assertRunShow(c, showTest{
+		args: []string{"mariadb"},
+		stdout: `
+mariadb:
+  charm: charm-mariadb
+  series: quantal
+  channel: development
+  constraints:
+    arch: amd64
+    cores: 1
+    mem: 4096
+    root-disk: 8192
+  principal: true
+  exposed: false
+  remote: false
+  endpoint-bindings:
+    juju-info: myspace
+  availability:
+    max-unavailable-units: 1
+    spread-across:
+    - zone
+    - node
+    disruptions-allowed: 1
+    current-healthy: 3
+    desired-healthy: 2
+`[1:],
+	})
+}
+
+func (s *ShowSuite) TestShowAvailabilityUnknown(c *gc.C) {
+	app := s.createTestApplicationInfo("mariadb", "")
+	app.Availability = &params.ApplicationAvailability{
+		MaxUnavailableUnits: 1,
+		Unknown:             true,
+	}
+	s.mockAPI.applicationsInfoFunc = func([]names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+		return []params.ApplicationInfoResult{{Result: app}}, nil
+	}
+	s.assertRunShow(c, showTest{
+		args: []string{"mariadb"},
+		stdout: `
+mariadb:
+  charm: charm-mariadb
+  series: quantal
+  channel: development
+  constraints:
+    arch: amd64
+    cores: 1
+    mem: 4096
+    root-disk: 8192
+  principal: true
+  exposed: false
+  remote: false
+  endpoint-bindings:
+    juju-info: myspace
+  availability:
+    max-unavailable-units: 1
+    disruption-budget: unknown
+`[1:],
+	})
+}
+
 func (s *ShowSuite) TestShowJSON(c *gc.C) {
 	s.mockAPI.applicationsInfoFunc = func([]names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
 		return []params.ApplicationInfoResult{
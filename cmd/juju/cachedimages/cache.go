@@ -0,0 +1,125 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cachedimages
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/v2/arch"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const cacheCommandDoc = `
+Fetch os images into the Juju model's image cache.
+
+The controller caches an image the first time a machine in the model
+needs it, and serves it to the other machines from then on, fetching it
+again when a newer image is published. This command fetches the images
+ahead of time, so that the first deployments do not wait on the download,
+replacing any that are already cached. Only lxd container images are
+supported.
+
+Images are identified by:
+  Type         eg "lxd"
+  Series       eg "focal"
+  Architecture eg "amd64"
+Several series may be specified, separated by commas.
+
+Examples:
+  # Cache the lxd image for focal amd64.
+  juju cache-images --series focal --type lxd
+
+  # Cache the lxd images for bionic and focal arm64.
+  juju cache-images --series bionic,focal --arch arm64
+
+See also:
+    cached-images
+    remove-cached-images
+`
+
+// NewCacheCommand returns a command used to cache images.
+func NewCacheCommand() cmd.Command {
+	return modelcmd.Wrap(&cacheCommand{})
+}
+
+// cacheCommand fetches images into the Juju server's image cache.
+type cacheCommand struct {
+	CachedImagesCommandBase
+	Kind, Arch string
+	Series     []string
+}
+
+// Info implements Command.Info.
+func (c *cacheCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "cache-images",
+		Purpose: "Caches OS images on the controller.",
+		Doc:     cacheCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *cacheCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CachedImagesCommandBase.SetFlags(f)
+	f.StringVar(&c.Kind, "type", "lxd", "The image type to cache eg lxd")
+	f.Var(cmd.NewStringsValue(nil, &c.Series), "series", "The series of the images to cache eg focal")
+	f.StringVar(&c.Arch, "arch", arch.AMD64, "The architecture of the images to cache eg amd64")
+}
+
+// Init implements Command.Init.
+func (c *cacheCommand) Init(args []string) error {
+	if c.Kind == "" {
+		return errors.New("image type must be specified")
+	}
+	if len(c.Series) == 0 {
+		return errors.New("image series must be specified")
+	}
+	if c.Arch == "" {
+		return errors.New("image architecture must be specified")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// CacheImageAPI defines the imagemanager API methods that the cache command uses.
+type CacheImageAPI interface {
+	CacheImage(kind, series, arch string) error
+	Close() error
+}
+
+var getCacheImageAPI = func(p *CachedImagesCommandBase) (CacheImageAPI, error) {
+	return p.NewImagesManagerClient()
+}
+
+// Run implements Command.Run.
+func (c *cacheCommand) Run(ctx *cmd.Context) error {
+	client, err := getCacheImageAPI(&c.CachedImagesCommandBase)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var failed []string
+	for _, series := range c.Series {
+		ctx.Infof("Caching %s image for %s/%s...", c.Kind, series, c.Arch)
+		if err := client.CacheImage(c.Kind, series, c.Arch); err != nil {
+			// Errors that apply to every image abort the command.
+			if errors.IsNotSupported(err) || params.IsCodeNotSupported(err) || params.IsCodeOperationBlocked(err) {
+				return block.ProcessBlockedError(err, block.BlockChange)
+			}
+			ctx.Warningf("cannot cache %s image for %s/%s: %v", c.Kind, series, c.Arch, err)
+			failed = append(failed, series)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("caching images failed for series %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
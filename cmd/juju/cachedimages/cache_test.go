@@ -0,0 +1,103 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cachedimages_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/cachedimages"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type cacheImageCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	mockAPI *fakeImageCacheAPI
+}
+
+var _ = gc.Suite(&cacheImageCommandSuite{})
+
+type fakeImageCacheAPI struct {
+	jtesting.Stub
+}
+
+func (f *fakeImageCacheAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeImageCacheAPI) CacheImage(kind, series, arch string) error {
+	f.MethodCall(f, "CacheImage", kind, series, arch)
+	return f.NextErr()
+}
+
+func (s *cacheImageCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.mockAPI = &fakeImageCacheAPI{}
+	s.PatchValue(cachedimages.GetCacheImageAPI, func(_ *cachedimages.CachedImagesCommandBase) (cachedimages.CacheImageAPI, error) {
+		return s.mockAPI, nil
+	})
+}
+
+func runCacheCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, cachedimages.NewCacheCommandForTest(jujuclienttesting.MinimalStore()), args...)
+}
+
+func (s *cacheImageCommandSuite) TestCacheImage(c *gc.C) {
+	ctx, err := runCacheCommand(c, "--series", "focal", "--type", "lxd")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Caching lxd image for focal/amd64...\n")
+	s.mockAPI.CheckCallNames(c, "CacheImage", "Close")
+	s.mockAPI.CheckCall(c, 0, "CacheImage", "lxd", "focal", "amd64")
+}
+
+func (s *cacheImageCommandSuite) TestCacheImagesMultipleSeries(c *gc.C) {
+	_, err := runCacheCommand(c, "--series", "bionic,focal", "--arch", "arm64")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "CacheImage", "CacheImage", "Close")
+	s.mockAPI.CheckCall(c, 0, "CacheImage", "lxd", "bionic", "arm64")
+	s.mockAPI.CheckCall(c, 1, "CacheImage", "lxd", "focal", "arm64")
+}
+
+func (s *cacheImageCommandSuite) TestCacheImagesPartialFailure(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("no matching image found"))
+	_, err := runCacheCommand(c, "--series", "bionic,focal")
+	c.Assert(err, gc.ErrorMatches, "caching images failed for series bionic")
+	c.Assert(c.GetTestLog(), jc.Contains, "cannot cache lxd image for bionic/amd64: no matching image found")
+	s.mockAPI.CheckCallNames(c, "CacheImage", "CacheImage", "Close")
+}
+
+func (s *cacheImageCommandSuite) TestCacheImagesNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Message: `caching of "kvm" images not supported`, Code: params.CodeNotSupported})
+	_, err := runCacheCommand(c, "--series", "bionic,focal", "--type", "kvm")
+	c.Assert(err, gc.ErrorMatches, `caching of "kvm" images not supported`)
+	s.mockAPI.CheckCallNames(c, "CacheImage", "Close")
+}
+
+func (s *cacheImageCommandSuite) TestCacheImagesBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Message: "blocked", Code: params.CodeOperationBlocked})
+	_, err := runCacheCommand(c, "--series", "focal")
+	c.Assert(err, gc.ErrorMatches, "(?s)blocked.*")
+}
+
+func (*cacheImageCommandSuite) TestTooManyArgs(c *gc.C) {
+	_, err := runCacheCommand(c, "--series", "focal", "bad")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["bad"\]`)
+}
+
+func (*cacheImageCommandSuite) TestSeriesRequired(c *gc.C) {
+	_, err := runCacheCommand(c, "--type", "lxd")
+	c.Assert(err, gc.ErrorMatches, `image series must be specified`)
+}
+
+func (*cacheImageCommandSuite) TestTypeRequired(c *gc.C) {
+	_, err := runCacheCommand(c, "--series", "focal", "--type", "")
+	c.Assert(err, gc.ErrorMatches, `image type must be specified`)
+}
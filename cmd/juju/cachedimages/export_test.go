@@ -13,6 +13,7 @@ import (
 var (
 	GetListImagesAPI  = &getListImagesAPI
 	GetRemoveImageAPI = &getRemoveImageAPI
+	GetCacheImageAPI  = &getCacheImageAPI
 )
 
 func NewListCommandForTest(store jujuclient.ClientStore) cmd.Command {
@@ -26,3 +27,9 @@ func NewRemoveCommandForTest(store jujuclient.ClientStore) cmd.Command {
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+func NewCacheCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	cmd := &cacheCommand{}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -102,6 +102,7 @@ clouds:                           # mandatory
  - cloudsigma
  - ec2
  - gce
+ - hetzner
  - oci
 
 When a running controller is updated, the credential for the cloud
@@ -1988,6 +1988,7 @@ azure-china
 cloudsigma                                    
 ecs                                           
 google                                        
+hetzner                                       
 oracle                                        
 rackspace                                     \n?(localhost\s+)?(microk8s\s+)?
 dummy-cloud                      joe          home
@@ -2051,6 +2052,7 @@ azure-china
 cloudsigma                                    
 ecs                                           
 google                                        
+hetzner                                       
 oracle                                        
 rackspace                                     \n?(localhost\s+)?(microk8s\s+)?
 dummy-cloud-dummy-region-config               
@@ -0,0 +1,129 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+)
+
+const defaultDebugImage = "busybox"
+
+var usageDebugPodSummary = `
+Attaches a debug container to the workload pod of a k8s unit.`[1:]
+
+var usageDebugPodDetails = `
+Charm workload containers often don't include a shell or any debugging
+tools. "juju debug-pod" adds an ephemeral container running the image given
+by --image to the unit's pod, and attaches to it. The debug container shares
+the process namespace of the workload container chosen by --container, or
+the first workload container of the pod, skipping the charm container and
+any sidecars, so its processes can be inspected and its filesystem is
+reachable at /proc/<pid>/root.
+
+The optional command is run in the debug container instead of a shell.
+
+Kubernetes doesn't allow ephemeral containers to be removed from a pod, so
+the debug container stops when the session ends and remains listed in the
+pod until the pod is replaced. The cluster must support ephemeral containers.
+
+Examples:
+
+    juju debug-pod mariadb-k8s/0
+    juju debug-pod mariadb-k8s/0 --image nicolaka/netshoot --container mariadb
+    juju debug-pod mariadb-k8s/0 -- ps aux
+
+See also:
+    ssh
+`
+
+func newDebugPodCommand(isTerminal func(interface{}) bool) cmd.Command {
+	c := &debugPodCommand{isTerminal: isTerminal}
+	return modelcmd.Wrap(c)
+}
+
+// debugPodCommand attaches an ephemeral debug container to the
+// workload pod of a unit in a k8s model.
+type debugPodCommand struct {
+	modelcmd.ModelCommandBase
+	sshContainer
+
+	image      string
+	isTerminal func(interface{}) bool
+	pty        autoBoolValue
+}
+
+// Info implements Command.
+func (c *debugPodCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "debug-pod",
+		Args:    "<unit name> [command]",
+		Purpose: usageDebugPodSummary,
+		Doc:     usageDebugPodDetails,
+	})
+}
+
+// SetFlags implements Command.
+func (c *debugPodCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.image, "image", defaultDebugImage, "the image of the debug container")
+	f.StringVar(&c.container, "container", "", "the workload container to debug")
+	f.Var(&c.pty, "pty", "Enable pseudo-tty allocation")
+}
+
+// Init implements Command.
+func (c *debugPodCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no unit name specified")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.Errorf("%q is not a valid unit name", args[0])
+	}
+	if c.image == "" {
+		return errors.Errorf("debug image cannot be empty")
+	}
+	modelType, err := c.ModelType()
+	if err != nil {
+		return err
+	}
+	if modelType != model.CAAS {
+		return errors.Errorf("only k8s models support debug-pod")
+	}
+	// The debug container is always added to the workload pod.
+	c.remote = true
+	c.setTarget(args[0])
+	c.setArgs(args[1:])
+	return nil
+}
+
+// Run implements Command.
+func (c *debugPodCommand) Run(ctx *cmd.Context) error {
+	if err := c.initRun(&c.ModelCommandBase); err != nil {
+		return errors.Trace(err)
+	}
+	defer c.cleanupRun()
+
+	target, err := c.resolveTarget(c.getTarget())
+	if err != nil {
+		return err
+	}
+
+	var pty bool
+	if c.pty.b != nil {
+		pty = *c.pty.b
+	} else {
+		isTerminal := isTerminal
+		if c.isTerminal != nil {
+			isTerminal = c.isTerminal
+		}
+		pty = isTerminal(ctx.Stdin)
+	}
+	return c.debug(ctx, c.image, pty, target)
+}
@@ -0,0 +1,56 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/commands"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type debugPodSuite struct {
+	testing.BaseSuite
+
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&debugPodSuite{})
+
+func (s *debugPodSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.store = jujuclienttesting.MinimalStore()
+	models := s.store.Models["arthur"]
+	details := models.Models["king/sword"]
+	details.ModelType = model.CAAS
+	models.Models["king/sword"] = details
+}
+
+func (s *debugPodSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no unit name specified",
+	}, {
+		args: []string{"mariadb-k8s"},
+		err:  `"mariadb-k8s" is not a valid unit name`,
+	}, {
+		args: []string{"mariadb-k8s/0", "--image", ""},
+		err:  "debug image cannot be empty",
+	}} {
+		_, err := cmdtesting.RunCommand(c, commands.NewDebugPodCommandForTest(s.store), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *debugPodSuite) TestInitIAASModel(c *gc.C) {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, commands.NewDebugPodCommandForTest(store), "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "only k8s models support debug-pod")
+}
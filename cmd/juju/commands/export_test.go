@@ -4,8 +4,12 @@
 package commands
 
 import (
+	"github.com/juju/cmd"
+
 	k8sexec "github.com/juju/juju/caas/kubernetes/provider/exec"
+	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/jujuclient"
 )
 
 type (
@@ -37,6 +41,10 @@ func (c *sshContainer) SSH(ctx Context, enablePty bool, target *resolvedTarget)
 	return c.ssh(ctx, enablePty, target)
 }
 
+func (c *sshContainer) Debug(ctx Context, image string, enablePty bool, target *resolvedTarget) error {
+	return c.debug(ctx, image, enablePty, target)
+}
+
 func (c *sshContainer) Copy(ctx Context) error {
 	return c.copy(ctx)
 }
@@ -54,6 +62,7 @@ type SSHContainerInterfaceForTest interface {
 	ResolveTarget(string) (*resolvedTarget, error)
 	SSH(Context, bool, *resolvedTarget) error
 	Copy(ctx Context) error
+	Debug(Context, string, bool, *resolvedTarget) error
 	GetExecClient() (k8sexec.Executor, error)
 
 	SetArgs([]string)
@@ -81,3 +90,9 @@ func NewSSHContainer(
 		container: containerName,
 	}
 }
+
+func NewDebugPodCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := modelcmd.Wrap(&debugPodCommand{})
+	c.SetClientStore(store)
+	return c
+}
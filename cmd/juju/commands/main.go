@@ -320,6 +320,7 @@ func registerCommands(r commandRegistry) {
 	// Firewall rule commands.
 	r.Register(firewall.NewSetFirewallRuleCommand())
 	r.Register(firewall.NewListFirewallRulesCommand())
+	r.Register(firewall.NewShowFirewallCommand())
 
 	// Destruction commands.
 	r.Register(application.NewRemoveRelationCommand())
@@ -337,9 +338,12 @@ func registerCommands(r commandRegistry) {
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil, nil))
 	r.Register(application.NewResolvedCommand())
+	r.Register(application.NewPauseUnitCommand())
+	r.Register(application.NewResumeUnitCommand())
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
 	r.Register(newDebugCodeCommand(nil))
+	r.Register(newDebugPodCommand(nil))
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -384,6 +388,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(user.NewWhoAmICommand())
 
 	// Manage cached images
+	r.Register(cachedimages.NewCacheCommand())
 	r.Register(cachedimages.NewRemoveCommand())
 	r.Register(cachedimages.NewListCommand())
 
@@ -403,6 +408,8 @@ func registerCommands(r commandRegistry) {
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
 	r.Register(model.NewModelCredentialCommand())
+	r.Register(model.NewAddRegistryCredentialCommand())
+	r.Register(model.NewRemoveRegistryCredentialCommand())
 	if featureflag.Enabled(feature.Branches) || featureflag.Enabled(feature.Generations) {
 		r.Register(model.NewAddBranchCommand())
 		r.Register(model.NewCommitCommand())
@@ -430,6 +437,9 @@ func registerCommands(r commandRegistry) {
 	r.Register(action.NewListOperationsCommand())
 	r.Register(action.NewShowOperationCommand())
 	r.Register(action.NewShowTaskCommand())
+	r.Register(action.NewScheduleCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -291,6 +291,7 @@ var commandNames = []string{
 	"add-k8s",
 	"add-machine",
 	"add-model",
+	"add-registry-credential",
 	"add-relation",
 	"add-space",
 	"add-ssh-key",
@@ -308,6 +309,7 @@ var commandNames = []string{
 	"bind",
 	"bootstrap",
 	"budget",
+	"cache-images",
 	"cached-images",
 	"cancel-task",
 	"change-user-password",
@@ -328,6 +330,7 @@ var commandNames = []string{
 	"debug-hook",
 	"debug-hooks",
 	"debug-log",
+	"debug-pod",
 	"default-credential",
 	"default-region",
 	"deploy",
@@ -380,6 +383,7 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-schedules",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -400,6 +404,7 @@ var commandNames = []string{
 	"offer",
 	"offers",
 	"operations",
+	"pause-unit",
 	"payloads",
 	"plans",
 	"refresh",
@@ -416,8 +421,10 @@ var commandNames = []string{
 	"remove-k8s",
 	"remove-machine",
 	"remove-offer",
+	"remove-registry-credential",
 	"remove-relation",
 	"remove-saas",
+	"remove-schedule",
 	"remove-space",
 	"remove-ssh-key",
 	"remove-storage",
@@ -429,11 +436,14 @@ var commandNames = []string{
 	"resolve",
 	"resources",
 	"resume-relation",
+	"resume-unit",
 	"retry-provisioning",
 	"revoke",
 	"revoke-cloud",
 	"run",
 	"scale-application",
+	"schedule-action",
+	"schedules",
 	"scp",
 	"set-credential",
 	"set-constraints",
@@ -452,6 +462,7 @@ var commandNames = []string{
 	"show-controller",
 	"show-credential",
 	"show-credentials",
+	"show-firewall",
 	"show-machine",
 	"show-model",
 	"show-offer",
@@ -227,6 +227,26 @@ func (c *sshContainer) ssh(ctx Context, enablePty bool, target *resolvedTarget)
 	)
 }
 
+// debug attaches to an ephemeral container running the image, which shares
+// the process namespace of the target container.
+func (c *sshContainer) debug(ctx Context, image string, enablePty bool, target *resolvedTarget) error {
+	cancel, stop := getInterruptAbortChan(ctx)
+	defer stop()
+	return c.execClient.Debug(
+		k8sexec.DebugParams{
+			PodName:       target.entity,
+			ContainerName: c.container,
+			Image:         image,
+			Commands:      c.args,
+			Stdout:        ctx.GetStdout(),
+			Stderr:        ctx.GetStderr(),
+			Stdin:         ctx.GetStdin(),
+			TTY:           enablePty,
+		},
+		cancel,
+	)
+}
+
 func getInterruptAbortChan(ctx Context) (<-chan struct{}, func()) {
 	ch := make(chan os.Signal, 1)
 	cancel := make(chan struct{})
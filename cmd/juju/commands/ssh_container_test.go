@@ -393,6 +393,37 @@ func (s *sshContainerSuite) TestSSHCancelled(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cancelled`)
 }
 
+func (s *sshContainerSuite) TestDebug(c *gc.C) {
+	ctrl := s.setUpController(c, true, "mariadb-k8s")
+	ctx := mocks.NewMockContext(ctrl)
+	defer ctrl.Finish()
+
+	buffer := bytes.NewBuffer(nil)
+
+	gomock.InOrder(
+		ctx.EXPECT().InterruptNotify(gomock.Any()),
+		ctx.EXPECT().GetStdout().Return(buffer),
+		ctx.EXPECT().GetStderr().Return(buffer),
+		ctx.EXPECT().GetStdin().Return(buffer),
+		s.execClient.EXPECT().Debug(k8sexec.DebugParams{
+			PodName:       "mariadb-k8s-0",
+			ContainerName: "mariadb-k8s",
+			Image:         "busybox",
+			TTY:           true,
+			Stdout:        buffer,
+			Stderr:        buffer,
+			Stdin:         buffer,
+		}, gomock.Any()).
+			Return(nil),
+		ctx.EXPECT().StopInterruptNotify(gomock.Any()),
+	)
+
+	target := &commands.ResolvedTarget{}
+	target.SetEntity("mariadb-k8s-0")
+	err := s.sshC.Debug(ctx, "busybox", true, target)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *sshContainerSuite) TestGetInterruptAbortChanInterrupted(c *gc.C) {
 	ctrl := s.setUpController(c, true, "")
 	ctx := mocks.NewMockContext(ctrl)
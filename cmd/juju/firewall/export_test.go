@@ -33,3 +33,15 @@ func NewSetRulesCommandForTest(
 	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
 	return modelcmd.Wrap(aCmd)
 }
+
+func NewShowFirewallCommandForTest(
+	api ShowFirewallAPI,
+) cmd.Command {
+	aCmd := &showFirewallCommand{
+		newAPIFunc: func() (ShowFirewallAPI, error) {
+			return api, nil
+		},
+	}
+	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(aCmd)
+}
@@ -0,0 +1,188 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/firewallrules"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/network/firewall"
+)
+
+var showFirewallHelpSummary = `
+Shows the effective firewall rules of an application's machines.`[1:]
+
+var showFirewallHelpDetails = `
+Computes the ingress rules that Juju expects to be applied to each machine
+hosting units of the application, from the ports opened by the units on the
+machine, the exposed endpoints of their applications and the ingress needed
+by cross-model relations, and compares them with the rules reported by the
+cloud provider.
+
+Rules reported by the provider that differ from the expected ones are flagged
+as drift. Use --fix to open and close ports on the provider so that the rules
+match again.
+
+Only models using the "instance" firewall mode are supported.
+
+Examples:
+    juju show-firewall mysql
+    juju show-firewall mysql --format yaml
+    juju show-firewall mysql --fix
+
+See also:
+    expose
+    list-firewall-rules
+    set-firewall-rule`
+
+// NewShowFirewallCommand returns a command to show the effective
+// firewall rules of an application.
+func NewShowFirewallCommand() cmd.Command {
+	cmd := &showFirewallCommand{}
+	cmd.newAPIFunc = func() (ShowFirewallAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return firewallrules.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type showFirewallCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	out cmd.Output
+
+	application string
+	fix         bool
+
+	newAPIFunc func() (ShowFirewallAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *showFirewallCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-firewall",
+		Args:    "<application>",
+		Purpose: showFirewallHelpSummary,
+		Doc:     showFirewallHelpDetails,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *showFirewallCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.fix, "fix", false, "Reconcile the provider firewall with the expected rules")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatShowTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *showFirewallCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	c.application = args[0]
+	if !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ShowFirewallAPI defines the API methods that the show firewall command uses.
+type ShowFirewallAPI interface {
+	Close() error
+	ApplicationFirewall(application string) ([]params.MachineFirewall, error)
+	ReconcileApplicationFirewall(application string) error
+}
+
+// Run implements cmd.Command.
+func (c *showFirewallCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.ApplicationFirewall(c.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machines := make(map[string]machineFirewall, len(result))
+	var drifted int
+	for _, m := range result {
+		tag, err := names.ParseMachineTag(m.MachineTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		mf := newMachineFirewall(m)
+		if mf.drifted() {
+			drifted++
+		}
+		machines[tag.Id()] = mf
+	}
+	if err := c.out.Write(ctx, machines); err != nil {
+		return errors.Trace(err)
+	}
+
+	switch {
+	case drifted == 0:
+		return nil
+	case !c.fix:
+		ctx.Infof("Firewall drift detected on %d machine(s); use --fix to reconcile.", drifted)
+		return nil
+	}
+	if err := client.ReconcileApplicationFirewall(c.application); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Reconciled the firewall of %d machine(s).", drifted)
+	return nil
+}
+
+// newMachineFirewall returns the output form of a machine's firewall,
+// recording the rules that are missing from the provider and the
+// provider rules that are not expected.
+func newMachineFirewall(m params.MachineFirewall) machineFirewall {
+	mf := machineFirewall{
+		InstanceId: m.InstanceId,
+		Expected:   toIngressRules(m.ExpectedRules),
+		Provider:   toIngressRules(m.ProviderRules),
+	}
+	if m.Error != nil {
+		mf.Error = m.Error.Error()
+		return mf
+	}
+	missing, unexpected := params.ToIngressRules(m.ProviderRules).Diff(params.ToIngressRules(m.ExpectedRules))
+	mf.Missing = fromFirewallRules(missing)
+	mf.Unexpected = fromFirewallRules(unexpected)
+	return mf
+}
+
+func toIngressRules(rules []params.IngressRule) []ingressRule {
+	return fromFirewallRules(params.ToIngressRules(rules))
+}
+
+func fromFirewallRules(rules firewall.IngressRules) []ingressRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]ingressRule, len(rules))
+	for i, rule := range rules {
+		result[i] = ingressRule{
+			Ports:       rule.PortRange.String(),
+			SourceCIDRs: rule.SourceCIDRs.SortedValues(),
+		}
+	}
+	return result
+}
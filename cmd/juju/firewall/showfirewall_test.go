@@ -0,0 +1,180 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/testing"
+)
+
+type ShowFirewallSuite struct {
+	testing.BaseSuite
+
+	mockAPI *mockShowFirewallAPI
+}
+
+var _ = gc.Suite(&ShowFirewallSuite{})
+
+func (s *ShowFirewallSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mockAPI = &mockShowFirewallAPI{
+		machines: []params.MachineFirewall{{
+			MachineTag: "machine-10",
+			InstanceId: "inst-10",
+			ExpectedRules: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 3306, ToPort: 3306, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}},
+			ProviderRules: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 3306, ToPort: 3306, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}},
+		}, {
+			MachineTag: "machine-2",
+			InstanceId: "inst-2",
+			ExpectedRules: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				SourceCIDRs: []string{"10.0.0.0/24"},
+			}, {
+				PortRange:   params.PortRange{FromPort: 3306, ToPort: 3306, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0", "192.168.0.0/24"},
+			}},
+			ProviderRules: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}, {
+				PortRange:   params.PortRange{FromPort: 3306, ToPort: 3306, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}},
+		}, {
+			MachineTag: "machine-3",
+			Error:      &params.Error{Message: "machine 3 not provisioned"},
+		}},
+	}
+}
+
+func (s *ShowFirewallSuite) runShow(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, firewall.NewShowFirewallCommandForTest(s.mockAPI), args...)
+}
+
+func (s *ShowFirewallSuite) TestInit(c *gc.C) {
+	_, err := s.runShow(c)
+	c.Assert(err, gc.ErrorMatches, "no application specified")
+	_, err = s.runShow(c, "mysql", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["wordpress"\]`)
+	_, err = s.runShow(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+}
+
+func (s *ShowFirewallSuite) TestShowTabular(c *gc.C) {
+	ctx, err := s.runShow(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Machine  Instance  Ports     Expected                  Provider   Status
+2        inst-2    22/tcp                              0.0.0.0/0  unexpected
+                   80/tcp    10.0.0.0/24                          missing
+                   3306/tcp  0.0.0.0/0,192.168.0.0/24  0.0.0.0/0  drift
+3                                                                 error: machine 3 not provisioned
+10       inst-10   3306/tcp  0.0.0.0/0                 0.0.0.0/0  ok
+
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Firewall drift detected on 1 machine(s); use --fix to reconcile.\n")
+	s.mockAPI.CheckCall(c, 0, "ApplicationFirewall", "mysql")
+	s.mockAPI.CheckCallNames(c, "ApplicationFirewall", "Close")
+}
+
+func (s *ShowFirewallSuite) TestShowYAML(c *gc.C) {
+	s.mockAPI.machines = s.mockAPI.machines[1:2]
+	ctx, err := s.runShow(c, "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"2":
+  instance-id: inst-2
+  expected:
+  - ports: 80/tcp
+    source-cidrs:
+    - 10.0.0.0/24
+  - ports: 3306/tcp
+    source-cidrs:
+    - 0.0.0.0/0
+    - 192.168.0.0/24
+  provider:
+  - ports: 22/tcp
+    source-cidrs:
+    - 0.0.0.0/0
+  - ports: 3306/tcp
+    source-cidrs:
+    - 0.0.0.0/0
+  missing:
+  - ports: 80/tcp
+    source-cidrs:
+    - 10.0.0.0/24
+  - ports: 3306/tcp
+    source-cidrs:
+    - 192.168.0.0/24
+  unexpected:
+  - ports: 22/tcp
+    source-cidrs:
+    - 0.0.0.0/0
+`[1:])
+}
+
+func (s *ShowFirewallSuite) TestShowFix(c *gc.C) {
+	ctx, err := s.runShow(c, "mysql", "--fix")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Reconciled the firewall of 1 machine(s).\n")
+	s.mockAPI.CheckCallNames(c, "ApplicationFirewall", "ReconcileApplicationFirewall", "Close")
+	s.mockAPI.CheckCall(c, 1, "ReconcileApplicationFirewall", "mysql")
+}
+
+func (s *ShowFirewallSuite) TestShowFixNoDrift(c *gc.C) {
+	s.mockAPI.machines = s.mockAPI.machines[:1]
+	ctx, err := s.runShow(c, "mysql", "--fix")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+	s.mockAPI.CheckCallNames(c, "ApplicationFirewall", "Close")
+}
+
+func (s *ShowFirewallSuite) TestShowFixError(c *gc.C) {
+	s.mockAPI.SetErrors(nil, errors.New("fail"))
+	_, err := s.runShow(c, "mysql", "--fix")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *ShowFirewallSuite) TestShowError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("fail"))
+	_, err := s.runShow(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+type mockShowFirewallAPI struct {
+	jtesting.Stub
+	machines []params.MachineFirewall
+}
+
+func (s *mockShowFirewallAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return nil
+}
+
+func (s *mockShowFirewallAPI) ApplicationFirewall(application string) ([]params.MachineFirewall, error) {
+	s.MethodCall(s, "ApplicationFirewall", application)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.machines, nil
+}
+
+func (s *mockShowFirewallAPI) ReconcileApplicationFirewall(application string) error {
+	s.MethodCall(s, "ReconcileApplicationFirewall", application)
+	return s.NextErr()
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+
+	"github.com/juju/juju/cmd/output"
+)
+
+type ingressRule struct {
+	Ports       string   `yaml:"ports" json:"ports"`
+	SourceCIDRs []string `yaml:"source-cidrs" json:"source-cidrs"`
+}
+
+type machineFirewall struct {
+	InstanceId string        `yaml:"instance-id,omitempty" json:"instance-id,omitempty"`
+	Expected   []ingressRule `yaml:"expected,omitempty" json:"expected,omitempty"`
+	Provider   []ingressRule `yaml:"provider,omitempty" json:"provider,omitempty"`
+	Missing    []ingressRule `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Unexpected []ingressRule `yaml:"unexpected,omitempty" json:"unexpected,omitempty"`
+	Error      string        `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// drifted reports whether the provider rules differ from the expected ones.
+func (m machineFirewall) drifted() bool {
+	return len(m.Missing) > 0 || len(m.Unexpected) > 0
+}
+
+func formatShowTabular(writer io.Writer, value interface{}) error {
+	machines, ok := value.(map[string]machineFirewall)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", machines, value)
+	}
+	formatMachineFirewallsTabular(writer, machines)
+	return nil
+}
+
+// formatMachineFirewallsTabular returns a tabular summary of the expected
+// and provider ingress rules of each machine, by port range.
+func formatMachineFirewallsTabular(writer io.Writer, machines map[string]machineFirewall) {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	ids := make([]string, 0, len(machines))
+	for id := range machines {
+		ids = append(ids, id)
+	}
+	naturalsort.Sort(ids)
+
+	w.Println("Machine", "Instance", "Ports", "Expected", "Provider", "Status")
+	for _, id := range ids {
+		m := machines[id]
+		if m.Error != "" {
+			w.Println(id, m.InstanceId, "", "", "", "error: "+m.Error)
+			continue
+		}
+
+		var ports []string
+		expected := make(map[string][]string)
+		provider := make(map[string][]string)
+		for _, rule := range m.Expected {
+			ports = append(ports, rule.Ports)
+			expected[rule.Ports] = rule.SourceCIDRs
+		}
+		for _, rule := range m.Provider {
+			if _, ok := expected[rule.Ports]; !ok {
+				ports = append(ports, rule.Ports)
+			}
+			provider[rule.Ports] = rule.SourceCIDRs
+		}
+		naturalsort.Sort(ports)
+
+		if len(ports) == 0 {
+			w.Println(id, m.InstanceId, "", "", "", "ok")
+			continue
+		}
+		for i, p := range ports {
+			machineId, instanceId := id, m.InstanceId
+			if i > 0 {
+				machineId, instanceId = "", ""
+			}
+			w.Println(
+				machineId, instanceId, p,
+				strings.Join(expected[p], ","),
+				strings.Join(provider[p], ","),
+				ruleStatus(expected[p], provider[p]),
+			)
+		}
+	}
+	tw.Flush()
+}
+
+func ruleStatus(expected, provider []string) string {
+	switch {
+	case len(provider) == 0:
+		return "missing"
+	case len(expected) == 0:
+		return "unexpected"
+	case strings.Join(expected, ",") != strings.Join(provider, ","):
+		return "drift"
+	}
+	return "ok"
+}
@@ -77,6 +77,32 @@ Manual provisioning is the process of installing Juju on an existing machine
 and bringing it under Juju's management. The Juju controller must be able to
 access the new machine over the network.
 
+Many hosts can be provisioned at once by passing an inventory file to the
+--inventory option instead of an address. The inventory is a YAML file
+listing the hosts by name, in the style of an Ansible inventory:
+
+    vars:
+      user: admin
+      key: ~/.ssh/metal
+    hosts:
+      node01:
+        host: 10.10.0.3
+        series: focal
+        tags: [rack1]
+      node02:
+        host: 10.10.0.4
+        spaces: [storage]
+
+Each host may specify its address (defaulting to its name), the user to
+log in as, the private key to connect with, the series it is expected to
+run, and the tags and spaces recorded as constraints of the new machine.
+Values missing from a host are taken from vars. Hosts are provisioned in
+parallel, at most --parallel at a time, without prompting, so each host
+must accept the given key. A summary of the outcome for each host is
+printed once all have been provisioned. Hosts that are already provisioned
+are skipped, so the same inventory can be used again after fixing any
+failures.
+
 
 Container creation
 
@@ -122,6 +148,9 @@ Examples:
 	# Allocate a machine to the model via SSH
 	juju add-machine ssh:user@10.10.0.3
 
+	# Allocate the machines listed in an inventory file, 20 at a time
+	juju add-machine --inventory hosts.yaml --parallel 20
+
 	# Allocate a machine to the model via WinRM
 	juju add-machine winrm:user@10.10.0.3
 
@@ -162,6 +191,10 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// Inventory is the path to a file listing hosts to provision manually.
+	Inventory string
+	// Parallel is the number of inventory hosts to provision at a time.
+	Parallel int
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -179,6 +212,8 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Machine constraints that overwrite those available from 'juju get-model-constraints' and provider's defaults")
 	f.Var(disksFlag{&c.Disks}, "disks", "Storage constraints for disks to attach to the machine(s)")
+	f.StringVar(&c.Inventory, "inventory", "", "Path to a YAML file listing hosts to provision manually")
+	f.IntVar(&c.Parallel, "parallel", defaultInventoryParallel, "The number of inventory hosts to provision at a time")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -200,6 +235,19 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.Inventory != "" {
+		switch {
+		case c.Placement != nil:
+			return errors.New("cannot use --inventory when specifying a placement directive")
+		case c.NumMachines != 1:
+			return errors.New("cannot use --inventory with -n")
+		case c.Series != "" || c.ConstraintsStr != "" || len(c.Disks) > 0:
+			return errors.New("cannot use --inventory with --series, --constraints or --disks; specify them in the inventory")
+		}
+	}
+	if c.Parallel < 1 {
+		return errors.New("--parallel must be at least 1")
+	}
 	return nil
 }
 
@@ -306,6 +354,10 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	if c.Inventory != "" {
+		return c.provisionInventory(client, cfg, ctx)
+	}
+
 	if c.Placement != nil {
 		err := c.tryManualProvision(client, cfg, ctx)
 		if err != errNonManualScope {
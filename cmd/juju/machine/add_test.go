@@ -4,8 +4,11 @@
 package machine_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -90,6 +93,21 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"something:special"},
 			count:     1,
 			placement: "something:special",
+		}, {
+			args:  []string{"--inventory", "hosts.yaml", "--parallel", "20"},
+			count: 1,
+		}, {
+			args:        []string{"--inventory", "hosts.yaml", "ssh:10.10.0.3"},
+			errorString: "cannot use --inventory when specifying a placement directive",
+		}, {
+			args:        []string{"--inventory", "hosts.yaml", "-n", "2"},
+			errorString: "cannot use --inventory with -n",
+		}, {
+			args:        []string{"--inventory", "hosts.yaml", "--series", "focal"},
+			errorString: "cannot use --inventory with --series, --constraints or --disks; specify them in the inventory",
+		}, {
+			args:        []string{"--parallel", "0"},
+			errorString: "--parallel must be at least 1",
 		},
 	} {
 		c.Logf("test %d", i)
@@ -153,6 +171,95 @@ func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
 }
 
+func (s *AddMachineSuite) writeInventory(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "hosts.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *AddMachineSuite) TestInventory(c *gc.C) {
+	path := s.writeInventory(c, `
+vars:
+  user: admin
+  key: keys/metal
+  series: focal
+hosts:
+  node01:
+    host: 10.0.0.1
+    tags: [rack1]
+  node02:
+    host: 10.0.0.2
+    user: root
+    key: /etc/juju/key
+  node03:
+    spaces: [storage]
+`)
+	var mu sync.Mutex
+	provisioned := make(map[string]manual.ProvisionMachineArgs)
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		mu.Lock()
+		provisioned[args.Host] = args
+		mu.Unlock()
+		switch args.Host {
+		case "10.0.0.1":
+			return "7", nil
+		case "10.0.0.2":
+			return "", manual.ErrProvisioned
+		}
+		return "", errors.New("permission denied")
+	})
+	context, err := s.run(c, "--inventory", path, "--parallel", "2")
+	c.Assert(err, gc.ErrorMatches, "failed to enlist 1 of 3 hosts")
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Host    Address   Machine  Status
+node01  10.0.0.1  7        enlisted
+node02  10.0.0.2           skipped: already provisioned
+node03  node03             failed: permission denied
+`[1:])
+
+	c.Assert(provisioned, gc.HasLen, 3)
+	keyPath := filepath.Join(filepath.Dir(path), "keys", "metal")
+	node01 := provisioned["10.0.0.1"]
+	c.Check(node01.User, gc.Equals, "admin")
+	c.Check(node01.IdentityFile, gc.Equals, keyPath)
+	c.Check(node01.Series, gc.Equals, "focal")
+	c.Check(node01.Constraints.String(), gc.Equals, "tags=rack1")
+	c.Check(node01.Stdin, gc.NotNil)
+	c.Check(node01.NonInteractive, jc.IsTrue)
+	node02 := provisioned["10.0.0.2"]
+	c.Check(node02.User, gc.Equals, "root")
+	c.Check(node02.IdentityFile, gc.Equals, "/etc/juju/key")
+	c.Check(node02.Constraints.String(), gc.Equals, "")
+	node03 := provisioned["node03"]
+	c.Check(node03.IdentityFile, gc.Equals, keyPath)
+	c.Check(node03.Constraints.String(), gc.Equals, "spaces=storage")
+}
+
+func (s *AddMachineSuite) TestInventoryInvalid(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Fatalf("unexpected provisioning of %q", args.Host)
+		return "", nil
+	})
+	for i, test := range []struct {
+		content string
+		err     string
+	}{{
+		content: "vars:\n  user: admin\n",
+		err:     `invalid inventory ".*": no hosts specified`,
+	}, {
+		content: "hosts:\n  node01:\n    address: 10.0.0.1\n",
+		err:     `invalid inventory ".*": yaml: unmarshal errors:\n.*field address not found.*`,
+	}, {
+		content: "hosts:\n  node01:\n    host: 10.0.0.1\n  node02:\n    host: 10.0.0.1\n",
+		err:     `invalid inventory ".*": hosts "node01" and "node02" have the same address "10.0.0.1"`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.run(c, "--inventory", s.writeInventory(c, test.content))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *AddMachineSuite) TestParamsPassedOn(c *gc.C) {
 	_, err := s.run(c, "--constraints", "mem=8G", "--series=special", "zone=nz")
 	c.Assert(err, jc.ErrorIsNil)
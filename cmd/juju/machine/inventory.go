@@ -0,0 +1,261 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/manual"
+)
+
+// defaultInventoryParallel is the default number of inventory hosts
+// that are provisioned at the same time.
+const defaultInventoryParallel = 10
+
+// inventory describes a set of hosts to be enlisted into the model by
+// manual provisioning. Its layout follows that of an Ansible YAML
+// inventory: vars holds the values used by hosts that do not specify
+// their own.
+//
+//	vars:
+//	  user: admin
+//	  key: ~/.ssh/metal
+//	hosts:
+//	  node01:
+//	    host: 10.0.0.1
+//	    tags: [rack1]
+//	  node02:
+//	    spaces: [storage]
+type inventory struct {
+	Vars  inventoryHost            `yaml:"vars"`
+	Hosts map[string]inventoryHost `yaml:"hosts"`
+}
+
+// inventoryHost holds the details of a single inventory host.
+type inventoryHost struct {
+	// Name is the name of the host in the inventory.
+	Name string `yaml:"-"`
+
+	// Host is the address of the host, defaulting to its name.
+	Host string `yaml:"host"`
+
+	// User is the user to log in as when initialising the host.
+	User string `yaml:"user"`
+
+	// Key is the path to the private key used to connect to the host.
+	Key string `yaml:"key"`
+
+	// Series is the series the host is expected to be running.
+	Series string `yaml:"series"`
+
+	// Tags and Spaces are recorded as constraints of the new machine.
+	Tags   []string `yaml:"tags"`
+	Spaces []string `yaml:"spaces"`
+}
+
+// constraints returns the constraints recorded against the machine
+// created for the host.
+func (h inventoryHost) constraints() constraints.Value {
+	var cons constraints.Value
+	if len(h.Tags) > 0 {
+		tags := append([]string(nil), h.Tags...)
+		cons.Tags = &tags
+	}
+	if len(h.Spaces) > 0 {
+		spaces := append([]string(nil), h.Spaces...)
+		cons.Spaces = &spaces
+	}
+	return cons
+}
+
+// readInventory reads the inventory file at the given path and returns
+// its hosts, sorted by name, with the inventory vars applied. Relative
+// key paths are resolved against the directory holding the inventory.
+func readInventory(path string) ([]inventoryHost, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	hosts, err := parseInventory(data, filepath.Dir(path))
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid inventory %q", path)
+	}
+	return hosts, nil
+}
+
+func parseInventory(data []byte, baseDir string) ([]inventoryHost, error) {
+	var inv inventory
+	if err := yaml.UnmarshalStrict(data, &inv); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(inv.Hosts) == 0 {
+		return nil, errors.New("no hosts specified")
+	}
+
+	hosts := make([]inventoryHost, 0, len(inv.Hosts))
+	addresses := make(map[string]string)
+	for name, h := range inv.Hosts {
+		h.Name = name
+		if h.Host == "" {
+			h.Host = name
+		}
+		if h.User == "" {
+			h.User = inv.Vars.User
+		}
+		if h.Key == "" {
+			h.Key = inv.Vars.Key
+		}
+		if h.Series == "" {
+			h.Series = inv.Vars.Series
+		}
+		if h.Tags == nil {
+			h.Tags = inv.Vars.Tags
+		}
+		if h.Spaces == nil {
+			h.Spaces = inv.Vars.Spaces
+		}
+		if other, ok := addresses[h.Host]; ok {
+			if other > name {
+				other, name = name, other
+			}
+			return nil, errors.Errorf("hosts %q and %q have the same address %q", other, name, h.Host)
+		}
+		addresses[h.Host] = name
+
+		if h.Key != "" {
+			key, err := utils.NormalizePath(h.Key)
+			if err != nil {
+				return nil, errors.Annotatef(err, "host %q key", name)
+			}
+			if !filepath.IsAbs(key) {
+				key = filepath.Join(baseDir, key)
+			}
+			h.Key = key
+		}
+		hosts = append(hosts, h)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Name < hosts[j].Name
+	})
+	return hosts, nil
+}
+
+// inventoryResult records the outcome of enlisting an inventory host.
+type inventoryResult struct {
+	host      inventoryHost
+	machineId string
+	skipped   bool
+	err       error
+}
+
+func (r inventoryResult) status() string {
+	switch {
+	case r.err != nil:
+		return "failed: " + r.err.Error()
+	case r.skipped:
+		return "skipped: already provisioned"
+	}
+	return "enlisted"
+}
+
+// provisionInventory enlists the hosts in the inventory file, running
+// at most c.Parallel provisioners at a time. Hosts that already have a
+// machine agent are skipped, so that an inventory may be applied again
+// after fixing any failed hosts.
+func (c *addCommand) provisionInventory(client AddMachineAPI, config *config.Config, ctx *cmd.Context) error {
+	hosts, err := readInventory(ctx.AbsPath(c.Inventory))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
+	if err != nil {
+		return errors.Annotatef(err, "cannot read authorized-keys")
+	}
+	updateBehavior := &params.UpdateBehavior{
+		EnableOSRefreshUpdate: config.EnableOSRefreshUpdate(),
+		EnableOSUpgrade:       config.EnableOSUpgrade(),
+	}
+
+	ctx.Infof("enlisting %d hosts", len(hosts))
+	results := make([]inventoryResult, len(hosts))
+	sem := make(chan struct{}, c.Parallel)
+	var wg sync.WaitGroup
+	for i, h := range hosts {
+		wg.Add(1)
+		go func(i int, h inventoryHost) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			// There is no terminal to answer prompts when provisioning
+			// in bulk, so hosts must accept the configured key; any
+			// that would prompt for a password fail instead.
+			machineId, err := sshProvisioner(manual.ProvisionMachineArgs{
+				Host:           h.Host,
+				User:           h.User,
+				Client:         client,
+				Stdin:          strings.NewReader(""),
+				Stdout:         ioutil.Discard,
+				Stderr:         ioutil.Discard,
+				AuthorizedKeys: authKeys,
+				IdentityFile:   h.Key,
+				Series:         h.Series,
+				Constraints:    h.constraints(),
+				NonInteractive: true,
+				UpdateBehavior: updateBehavior,
+			})
+			result := inventoryResult{host: h, machineId: machineId}
+			if errors.Cause(err) == manual.ErrProvisioned {
+				result.skipped = true
+			} else if err != nil {
+				logger.Debugf("enlisting %q: %v", h.Name, errors.ErrorStack(err))
+				result.err = err
+			}
+			results[i] = result
+		}(i, h)
+	}
+	wg.Wait()
+
+	writeInventoryResults(ctx.Stdout, results)
+	var failed int
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to enlist %d of %d hosts", failed, len(results))
+	}
+	return nil
+}
+
+// writeInventoryResults writes a tabular summary of the inventory results.
+func writeInventoryResults(writer io.Writer, results []inventoryResult) {
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	print("Host", "Address", "Machine", "Status")
+	for _, r := range results {
+		print(r.host.Name, r.host.Host, r.machineId, r.status())
+	}
+	tw.Flush()
+}
@@ -246,3 +246,21 @@ func NewShowCommitCommandForTest(api ShowCommitCommandAPI, store jujuclient.Clie
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+// NewAddRegistryCredentialCommandForTest returns an addRegistryCredentialCommand
+// with the api provided as specified.
+func NewAddRegistryCredentialCommandForTest(api RegistryCredentialAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &addRegistryCredentialCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRemoveRegistryCredentialCommandForTest returns a removeRegistryCredentialCommand
+// with the api provided as specified.
+func NewRemoveRegistryCredentialCommandForTest(api RegistryCredentialAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &removeRegistryCredentialCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,208 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/juju/juju/api/modelconfig"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const addRegistryCredentialDoc = `
+Adds or replaces the credentials used by workloads in a Kubernetes model to
+pull images from a private registry. The credentials are stored in an image
+pull secret in the model's namespace, which every workload pod in the model
+references. Only the name of the secret is recorded in the
+image-registry-credentials model config.
+
+The password is read from the file given with --password-file, or otherwise
+from standard input.
+
+Examples:
+
+    juju add-registry-credential registry.internal --username fred
+    juju add-registry-credential registry.internal --username fred --password-file ./token
+
+See also:
+    remove-registry-credential
+    model-config
+`
+
+const removeRegistryCredentialDoc = `
+Removes the credentials used by workloads in a Kubernetes model to pull
+images from a private registry.
+
+Examples:
+
+    juju remove-registry-credential registry.internal
+
+See also:
+    add-registry-credential
+    model-config
+`
+
+// RegistryCredentialAPI defines methods on the model config API that
+// the add-registry-credential and remove-registry-credential commands call.
+type RegistryCredentialAPI interface {
+	Close() error
+	SetImageRegistryCredential(registry, username, password string) error
+	RemoveImageRegistryCredential(registry string) error
+}
+
+// registryCredentialCommandBase holds what is common to the commands
+// managing private registry credentials.
+type registryCredentialCommandBase struct {
+	modelcmd.ModelCommandBase
+	modelcmd.CAASOnlyCommand
+	api RegistryCredentialAPI
+
+	registry string
+}
+
+func (c *registryCredentialCommandBase) getAPI() (RegistryCredentialAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelconfig.NewClient(api), nil
+}
+
+// NewAddRegistryCredentialCommand returns a command to add private
+// registry credentials to a model.
+func NewAddRegistryCredentialCommand() cmd.Command {
+	return modelcmd.Wrap(&addRegistryCredentialCommand{})
+}
+
+// addRegistryCredentialCommand adds credentials for a private registry
+// to a model.
+type addRegistryCredentialCommand struct {
+	registryCredentialCommandBase
+
+	username     string
+	passwordFile string
+}
+
+func (c *addRegistryCredentialCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add-registry-credential",
+		Args:    "<registry>",
+		Purpose: "Adds credentials for pulling workload images from a private registry.",
+		Doc:     addRegistryCredentialDoc,
+	})
+}
+
+func (c *addRegistryCredentialCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.username, "username", "", "The user name used to log in to the registry")
+	f.StringVar(&c.passwordFile, "password-file", "", "A file containing the password used to log in to the registry")
+}
+
+func (c *addRegistryCredentialCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no registry specified")
+	}
+	c.registry, args = args[0], args[1:]
+	if c.username == "" {
+		return errors.New("no username specified")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *addRegistryCredentialCommand) Run(ctx *cmd.Context) error {
+	password, err := c.readPassword(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if password == "" {
+		return errors.New("no password specified")
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.SetImageRegistryCredential(c.registry, c.username, password)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+func (c *addRegistryCredentialCommand) readPassword(ctx *cmd.Context) (string, error) {
+	if c.passwordFile != "" {
+		data, err := ioutil.ReadFile(ctx.AbsPath(c.passwordFile))
+		if err != nil {
+			return "", errors.Annotate(err, "reading password file")
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	if f, ok := ctx.Stdin.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		fmt.Fprintf(ctx.Stderr, "Enter password for %s: ", c.registry)
+		password, err := terminal.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(ctx.Stderr)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return string(password), nil
+	}
+	password, err := bufio.NewReader(ctx.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", errors.Trace(err)
+	}
+	return strings.TrimSpace(password), nil
+}
+
+// NewRemoveRegistryCredentialCommand returns a command to remove private
+// registry credentials from a model.
+func NewRemoveRegistryCredentialCommand() cmd.Command {
+	return modelcmd.Wrap(&removeRegistryCredentialCommand{})
+}
+
+// removeRegistryCredentialCommand removes the credentials for a private
+// registry from a model.
+type removeRegistryCredentialCommand struct {
+	registryCredentialCommandBase
+}
+
+func (c *removeRegistryCredentialCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-registry-credential",
+		Args:    "<registry>",
+		Purpose: "Removes credentials for pulling workload images from a private registry.",
+		Doc:     removeRegistryCredentialDoc,
+	})
+}
+
+func (c *removeRegistryCredentialCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no registry specified")
+	}
+	c.registry, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+func (c *removeRegistryCredentialCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.RemoveImageRegistryCredential(c.registry)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
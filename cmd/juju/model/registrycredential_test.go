@@ -0,0 +1,126 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type RegistryCredentialSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeRegistryCredentialAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&RegistryCredentialSuite{})
+
+func (s *RegistryCredentialSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeRegistryCredentialAPI{creds: make(map[string]string)}
+	s.store = jujuclienttesting.MinimalStore()
+	s.store.Models["arthur"] = &jujuclient.ControllerModels{
+		CurrentModel: "king/sword",
+		Models: map[string]jujuclient.ModelDetails{"king/sword": {
+			ModelType: coremodel.CAAS,
+		}},
+	}
+}
+
+func (s *RegistryCredentialSuite) runAdd(c *gc.C, stdin string, args ...string) (*cmd.Context, error) {
+	command := model.NewAddRegistryCredentialCommandForTest(s.fake, s.store)
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader(stdin)
+	if err := cmdtesting.InitCommand(command, args); err != nil {
+		return ctx, err
+	}
+	return ctx, command.Run(ctx)
+}
+
+func (s *RegistryCredentialSuite) runRemove(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewRemoveRegistryCredentialCommandForTest(s.fake, s.store), args...)
+}
+
+func (s *RegistryCredentialSuite) TestAddInit(c *gc.C) {
+	_, err := s.runAdd(c, "")
+	c.Assert(err, gc.ErrorMatches, "no registry specified")
+	_, err = s.runAdd(c, "", "registry.internal")
+	c.Assert(err, gc.ErrorMatches, "no username specified")
+	_, err = s.runAdd(c, "", "registry.internal", "extra", "--username", "fred")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *RegistryCredentialSuite) TestAddFromStdin(c *gc.C) {
+	_, err := s.runAdd(c, "secret\n", "registry.internal", "--username", "fred")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.creds, jc.DeepEquals, map[string]string{"registry.internal": "fred:secret"})
+}
+
+func (s *RegistryCredentialSuite) TestAddFromFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "token")
+	err := ioutil.WriteFile(path, []byte("secret\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runAdd(c, "", "registry.internal", "--username", "fred", "--password-file", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.creds, jc.DeepEquals, map[string]string{"registry.internal": "fred:secret"})
+}
+
+func (s *RegistryCredentialSuite) TestAddNoPassword(c *gc.C) {
+	_, err := s.runAdd(c, "", "registry.internal", "--username", "fred")
+	c.Assert(err, gc.ErrorMatches, "no password specified")
+}
+
+func (s *RegistryCredentialSuite) TestAddIAASModel(c *gc.C) {
+	s.store = jujuclienttesting.MinimalStore()
+	_, err := s.runAdd(c, "secret", "registry.internal", "--username", "fred")
+	c.Assert(err, gc.ErrorMatches, `Juju command "add-registry-credential" not supported on non-container models`)
+}
+
+func (s *RegistryCredentialSuite) TestRemove(c *gc.C) {
+	s.fake.creds["registry.internal"] = "fred:secret"
+	s.fake.creds["docker.io"] = "mary:hunter2"
+	_, err := s.runRemove(c, "registry.internal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.creds, jc.DeepEquals, map[string]string{"docker.io": "mary:hunter2"})
+}
+
+func (s *RegistryCredentialSuite) TestRemoveNotFound(c *gc.C) {
+	_, err := s.runRemove(c, "registry.internal")
+	c.Assert(err, gc.ErrorMatches, `credentials for registry "registry.internal" not found`)
+}
+
+type fakeRegistryCredentialAPI struct {
+	creds map[string]string
+}
+
+func (f *fakeRegistryCredentialAPI) Close() error {
+	return nil
+}
+
+func (f *fakeRegistryCredentialAPI) SetImageRegistryCredential(registry, username, password string) error {
+	f.creds[registry] = username + ":" + password
+	return nil
+}
+
+func (f *fakeRegistryCredentialAPI) RemoveImageRegistryCredential(registry string) error {
+	if _, ok := f.creds[registry]; !ok {
+		return errors.NotFoundf("credentials for registry %q", registry)
+	}
+	delete(f.creds, registry)
+	return nil
+}
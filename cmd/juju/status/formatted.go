@@ -245,7 +245,7 @@ func (s *formattedStatus) applicationScale(name string) (string, bool) {
 			return
 		}
 		switch u.JujuStatusInfo.Current {
-		case status.Executing, status.Idle, status.Running:
+		case status.Executing, status.Idle, status.Running, status.Paused:
 			currentUnitCount++
 		}
 	}
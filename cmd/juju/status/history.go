@@ -189,6 +189,21 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
 		}
 		tag = names.NewUnitTag(c.entityName)
+	case status.KindApplication:
+		if !names.IsValidApplication(c.entityName) {
+			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
+		}
+		tag = names.NewApplicationTag(c.entityName)
+	case status.KindK8sEvent:
+		// Events are reported for both units and applications.
+		switch {
+		case names.IsValidUnit(c.entityName):
+			tag = names.NewUnitTag(c.entityName)
+		case names.IsValidApplication(c.entityName):
+			tag = names.NewApplicationTag(c.entityName)
+		default:
+			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
+		}
 	default:
 		if !names.IsValidMachine(c.entityName) {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
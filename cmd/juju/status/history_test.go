@@ -109,9 +109,48 @@ func (s *StatusHistorySuite) TestResults(c *gc.C) {
 	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
 }
 
+func (s *StatusHistorySuite) TestK8sEvents(c *gc.C) {
+	api := &fakeHistoryAPI{
+		history: status.History{
+			{
+				Kind:   status.KindK8sEvent,
+				Status: "normal",
+				Info:   "Pulled: Successfully pulled image",
+				Since:  s.next(),
+			}, {
+				Kind:   status.KindK8sEvent,
+				Status: "warning",
+				Info:   "BackOff: Back-off restarting failed container",
+				Since:  s.next(),
+			},
+		},
+	}
+	s.api = api
+	expected := "" +
+		"Time                  Type       Status   Message\n" +
+		"2017-11-28 12:34:56Z  k8s-event  normal   Pulled: Successfully pulled image\n" +
+		"2017-11-28 12:35:56Z  k8s-event  warning  BackOff: Back-off restarting failed container\n"
+
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "gitlab", "--type", "k8s-event", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
+	c.Check(api.tag, gc.Equals, names.NewApplicationTag("gitlab"))
+
+	_, err = cmdtesting.RunCommand(c, s.newCommand(), "gitlab/0", "--type", "k8s-event", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(api.tag, gc.Equals, names.NewUnitTag("gitlab/0"))
+}
+
+func (s *StatusHistorySuite) TestApplicationInvalidName(c *gc.C) {
+	s.api = &fakeHistoryAPI{}
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "gitlab/0", "--type", "application")
+	c.Assert(err, gc.ErrorMatches, `"gitlab/0" is not a valid name for a application`)
+}
+
 type fakeHistoryAPI struct {
 	err     error
 	history status.History
+	tag     names.Tag
 }
 
 func (*fakeHistoryAPI) Close() error {
@@ -119,5 +158,6 @@ func (*fakeHistoryAPI) Close() error {
 }
 
 func (f *fakeHistoryAPI) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	f.tag = tag
 	return f.history, f.err
 }
@@ -15,6 +15,7 @@ import (
 	coreagent "github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
+	caaseventsapi "github.com/juju/juju/api/caasevents"
 	caasfirewallerapi "github.com/juju/juju/api/caasfirewaller"
 	caasunitprovisionerapi "github.com/juju/juju/api/caasunitprovisioner"
 	"github.com/juju/juju/apiserver/apiserverhttp"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/pki"
 	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -32,6 +34,7 @@ import (
 	"github.com/juju/juju/worker/caasapplicationprovisioner"
 	"github.com/juju/juju/worker/caasbroker"
 	"github.com/juju/juju/worker/caasenvironupgrader"
+	"github.com/juju/juju/worker/caasevents"
 	"github.com/juju/juju/worker/caasfirewaller"
 	"github.com/juju/juju/worker/caasfirewallerembedded"
 	"github.com/juju/juju/worker/caasmodeloperator"
@@ -299,6 +302,13 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			PruneInterval: config.ActionPrunerInterval,
 			Logger:        config.LoggingContext.GetLogger("juju.worker.pruner.action"),
 		})),
+		actionSchedulerName: ifNotMigrating(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			NewFacade:     actionscheduler.NewFacade,
+			NewWorker:     actionscheduler.NewWorker,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.actionscheduler"),
+		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
@@ -507,6 +517,19 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		)),
 
+		caasEventsName: ifNotMigrating(caasevents.Manifold(
+			caasevents.ManifoldConfig{
+				APICallerName: apiCallerName,
+				BrokerName:    caasBrokerTrackerName,
+				Clock:         config.Clock,
+				NewClient: func(caller base.APICaller) caasevents.Client {
+					return caaseventsapi.NewClient(caller)
+				},
+				NewWorker: caasevents.NewWorker,
+				Logger:    config.LoggingContext.GetLogger("juju.worker.caasevents"),
+			},
+		)),
+
 		caasModelOperatorName: ifResponsible(caasmodeloperator.Manifold(caasmodeloperator.ManifoldConfig{
 			AgentName:     agentName,
 			APICallerName: apiCallerName,
@@ -680,6 +703,7 @@ const (
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	actionSchedulerName      = "action-scheduler"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
@@ -687,6 +711,7 @@ const (
 	instanceMutaterName      = "instance-mutater"
 
 	caasAdmissionName              = "caas-admission"
+	caasEventsName                 = "caas-events"
 	caasFirewallerNameLegacy       = "caas-firewaller-legacy"
 	caasFirewallerNameEmbedded     = "caas-firewaller-embedded"
 	caasModelOperatorName          = "caas-model-operator"
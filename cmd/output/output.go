@@ -106,6 +106,7 @@ var statusColors = map[status.Status]*ansiterm.Context{
 	status.Maintenance: WarningHighlight,
 	status.Pending:     WarningHighlight,
 	status.Rebooting:   WarningHighlight,
+	status.Paused:      WarningHighlight,
 	status.Stopped:     WarningHighlight,
 	status.Unknown:     WarningHighlight,
 	status.Detaching:   WarningHighlight,
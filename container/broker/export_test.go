@@ -8,8 +8,9 @@ import (
 )
 
 var (
-	ResolvConfFiles       = &resolvConfFiles
-	CombinedCloudInitData = combinedCloudInitData
+	ResolvConfFiles          = &resolvConfFiles
+	CombinedCloudInitData    = combinedCloudInitData
+	AddImageCacheCredentials = addImageCacheCredentials
 )
 
 type patcher interface {
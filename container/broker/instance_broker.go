@@ -82,6 +82,9 @@ func New(config Config) (environs.InstanceBroker, error) {
 		return nil, errors.Trace(err)
 	}
 
+	if config.ContainerType == instance.LXD {
+		addImageCacheCredentials(config.ManagerConfig, config.AgentConfig)
+	}
 	manager, err := factory.NewContainerManager(config.ContainerType, config.ManagerConfig)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -106,6 +109,27 @@ func New(config Config) (environs.InstanceBroker, error) {
 	return broker, nil
 }
 
+// addImageCacheCredentials adds the credentials of the machine agent to
+// the manager configuration when it holds the URLs of the controller
+// image cache, as only the model's machine agents may use it.
+func addImageCacheCredentials(managerConfig container.ManagerConfig, agentConfig agent.Config) {
+	if managerConfig[container.ConfigImageCacheURLs] == "" {
+		return
+	}
+	apiInfo, ok := agentConfig.APIInfo()
+	if !ok || apiInfo.Tag == nil {
+		return
+	}
+	// Until the agent has changed its password, it logs in with the old one.
+	password := apiInfo.Password
+	if password == "" {
+		password = agentConfig.OldPassword()
+	}
+	managerConfig[container.ConfigImageCacheTag] = apiInfo.Tag.String()
+	managerConfig[container.ConfigImageCachePassword] = password
+	managerConfig[container.ConfigImageCacheNonce] = apiInfo.Nonce
+}
+
 func prepareHost(config Config) PrepareHostFunc {
 	return func(containerTag names.MachineTag, log loggo.Logger, abort <-chan struct{}) error {
 		preparer := NewHostPreparer(HostPreparerParams{
@@ -72,6 +72,29 @@ func (s *lxdBrokerSuite) SetUpTest(c *gc.C) {
 	s.manager = &fakeContainerManager{}
 }
 
+func (s *lxdBrokerSuite) TestAddImageCacheCredentials(c *gc.C) {
+	cfg := container.ManagerConfig{
+		container.ConfigImageCacheURLs: "https://10.0.0.1:17070/model/uuid",
+	}
+	broker.AddImageCacheCredentials(cfg, s.agentConfig)
+	c.Check(cfg, jc.DeepEquals, container.ManagerConfig{
+		container.ConfigImageCacheURLs:     "https://10.0.0.1:17070/model/uuid",
+		container.ConfigImageCacheTag:      "machine-1",
+		container.ConfigImageCachePassword: "dummy-secret",
+		container.ConfigImageCacheNonce:    "nonce",
+	})
+
+	// Once the agent has changed its password, the new one is used.
+	s.agentConfig.SetPassword("new-secret")
+	broker.AddImageCacheCredentials(cfg, s.agentConfig)
+	c.Check(cfg[container.ConfigImageCachePassword], gc.Equals, "new-secret")
+
+	// Without an image cache, no credentials are added.
+	cfg = container.ManagerConfig{container.ConfigModelUUID: coretesting.ModelTag.Id()}
+	broker.AddImageCacheCredentials(cfg, s.agentConfig)
+	c.Check(cfg, jc.DeepEquals, container.ManagerConfig{container.ConfigModelUUID: coretesting.ModelTag.Id()})
+}
+
 func (s *lxdBrokerSuite) startInstance(c *gc.C, broker environs.InstanceBroker, machineId string) (*environs.StartInstanceResult, error) {
 	return callStartInstance(c, s, broker, machineId)
 }
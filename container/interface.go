@@ -16,6 +16,22 @@ const (
 	ConfigModelUUID        = "model-uuid"
 	ConfigLogDir           = "log-dir"
 	ConfigAvailabilityZone = "availability-zone"
+
+	// ConfigImageCacheURLs holds the space-separated base URLs
+	// from which container images cached by the controller are served.
+	ConfigImageCacheURLs = "image-cache-urls"
+
+	// ConfigImageCacheCACert holds the CA certificate used to
+	// verify the servers of the image cache URLs.
+	ConfigImageCacheCACert = "image-cache-ca-cert"
+
+	// ConfigImageCacheTag, ConfigImageCachePassword and
+	// ConfigImageCacheNonce hold the credentials of the host's machine
+	// agent, with which requests to the image cache URLs are
+	// authenticated. They are added by the host, not the controller.
+	ConfigImageCacheTag      = "image-cache-tag"
+	ConfigImageCachePassword = "image-cache-password"
+	ConfigImageCacheNonce    = "image-cache-nonce"
 )
 
 //go:generate go run github.com/golang/mock/mockgen -package testing -destination testing/package_mock.go github.com/juju/juju/container Manager,Initialiser
@@ -18,6 +18,7 @@ import (
 	"github.com/lxc/lxd/shared/version"
 
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/network"
 )
@@ -30,6 +31,20 @@ const (
 	AutoStartKey        = "boot.autostart"
 )
 
+// configDriveDevice is the name of the disk device through which LXD
+// delivers cloud-init configuration to virtual machines.
+const configDriveDevice = "config"
+
+const (
+	// agentPollDelay is the interval at which a new virtual machine is
+	// polled to determine whether its LXD agent is running.
+	agentPollDelay = 5 * time.Second
+
+	// agentWaitTimeout is the time allowed for the LXD agent in a new
+	// virtual machine to start.
+	agentWaitTimeout = 10 * time.Minute
+)
+
 // ContainerSpec represents the data required to create a new container.
 type ContainerSpec struct {
 	Architecture string
@@ -39,6 +54,7 @@ type ContainerSpec struct {
 	Config       map[string]string
 	Profiles     []string
 	InstanceType string
+	VirtType     instance.VirtType
 }
 
 // minMiBVersion is the minimum LXD version that we are sure will recognise the
@@ -54,6 +70,11 @@ func (c *ContainerSpec) ApplyConstraints(serverVersion string, cons constraints.
 	if cons.HasInstanceType() {
 		c.InstanceType = *cons.InstanceType
 	}
+	virtType, err := VirtTypeFromConstraints(cons)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.VirtType = virtType
 	if cons.HasCpuCores() {
 		c.Config["limits.cpu"] = fmt.Sprintf("%d", *cons.CpuCores)
 	}
@@ -71,7 +92,9 @@ func (c *ContainerSpec) ApplyConstraints(serverVersion string, cons constraints.
 		c.Architecture = *cons.Arch
 	}
 	if cons.HasRootDisk() || cons.HasRootDiskSource() {
-		if !cons.HasRootDiskSource() {
+		// A virtual machine without a root disk source has its root disk
+		// sized in the storage pool given by its profiles.
+		if !cons.HasRootDiskSource() && c.VirtType != instance.InstanceTypeVM {
 			return errors.New("root disk size constraints require a root disk source")
 		}
 
@@ -81,9 +104,11 @@ func (c *ContainerSpec) ApplyConstraints(serverVersion string, cons constraints.
 
 		c.Devices["root"] = map[string]string{
 			"type": "disk",
-			"pool": *cons.RootDiskSource,
 			"path": "/",
 		}
+		if cons.HasRootDiskSource() {
+			c.Devices["root"]["pool"] = *cons.RootDiskSource
+		}
 
 		if cons.HasRootDisk() {
 			// Ensure that we use the correct "MB"/"MiB" suffix.
@@ -100,9 +125,19 @@ func (c *ContainerSpec) ApplyConstraints(serverVersion string, cons constraints.
 	return nil
 }
 
-// Container extends the upstream LXD container type.
+// VirtTypeFromConstraints returns the type of LXD instance requested by
+// the virt-type constraint. A system container is used by default.
+func VirtTypeFromConstraints(cons constraints.Value) (instance.VirtType, error) {
+	if !cons.HasVirtType() {
+		return instance.DefaultInstanceType, nil
+	}
+	virtType, err := instance.ParseVirtType(*cons.VirtType)
+	return virtType, errors.Trace(err)
+}
+
+// Container extends the upstream LXD instance type.
 type Container struct {
-	api.Container
+	api.Instance
 }
 
 // Metadata returns the value from container config for the input key.
@@ -197,7 +232,7 @@ func (s *Server) AliveContainers(prefix string) ([]Container, error) {
 // FilterContainers retrieves the list of containers from the server and filters
 // them based on the input namespace prefix and any supplied statuses.
 func (s *Server) FilterContainers(prefix string, statuses ...string) ([]Container, error) {
-	containers, err := s.GetContainers()
+	containers, err := s.GetInstances(api.InstanceTypeAny)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -218,7 +253,7 @@ func (s *Server) FilterContainers(prefix string, statuses ...string) ([]Containe
 // ContainerAddresses gets usable network addresses for the container
 // identified by the input name.
 func (s *Server) ContainerAddresses(name string) ([]corenetwork.ProviderAddress, error) {
-	state, _, err := s.GetContainerState(name)
+	state, _, err := s.GetInstanceState(name)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -252,18 +287,26 @@ func (s *Server) ContainerAddresses(name string) ([]corenetwork.ProviderAddress,
 func (s *Server) CreateContainerFromSpec(spec ContainerSpec) (*Container, error) {
 	logger.Infof("starting new container %q (image %q)", spec.Name, spec.Image.Image.Filename)
 	logger.Debugf("new container has profiles %v", spec.Profiles)
-	req := api.ContainersPost{
+	devices := spec.Devices
+	if spec.VirtType == instance.InstanceTypeVM {
+		var err error
+		if devices, err = s.virtualMachineDevices(spec); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	req := api.InstancesPost{
 		Name:         spec.Name,
 		InstanceType: spec.InstanceType,
-		ContainerPut: api.ContainerPut{
+		Type:         api.InstanceType(spec.VirtType),
+		InstancePut: api.InstancePut{
 			Architecture: spec.Architecture,
 			Profiles:     spec.Profiles,
-			Devices:      spec.Devices,
+			Devices:      devices,
 			Config:       spec.Config,
 			Ephemeral:    false,
 		},
 	}
-	op, err := s.CreateContainerFromImage(spec.Image.LXDServer, *spec.Image.Image, req)
+	op, err := s.CreateInstanceFromImage(spec.Image.LXDServer, *spec.Image.Image, req)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -281,14 +324,18 @@ func (s *Server) CreateContainerFromSpec(spec ContainerSpec) (*Container, error)
 
 	logger.Debugf("created container %q, waiting for start...", spec.Name)
 
-	if err := s.StartContainer(spec.Name); err != nil {
+	err = s.StartContainer(spec.Name)
+	if err == nil && spec.VirtType == instance.InstanceTypeVM {
+		err = s.waitForAgent(spec.Name)
+	}
+	if err != nil {
 		if remErr := s.RemoveContainer(spec.Name); remErr != nil {
 			logger.Errorf("failed to remove container after unsuccessful start: %s", remErr.Error())
 		}
 		return nil, errors.Trace(err)
 	}
 
-	container, _, err := s.GetContainer(spec.Name)
+	container, _, err := s.GetInstance(spec.Name)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -296,15 +343,98 @@ func (s *Server) CreateContainerFromSpec(spec ContainerSpec) (*Container, error)
 	return &c, nil
 }
 
+// virtualMachineDevices returns the devices from the input spec, along with
+// the config drive through which LXD passes the cloud-init user data to a
+// virtual machine. If the root disk is sized without a storage pool, the
+// pool of the root disk in the spec profiles is used.
+func (s *Server) virtualMachineDevices(spec ContainerSpec) (map[string]device, error) {
+	devices := make(map[string]device, len(spec.Devices)+1)
+	for name, dev := range spec.Devices {
+		devices[name] = dev
+	}
+	devices[configDriveDevice] = device{
+		"type":   "disk",
+		"source": "cloud-init:config",
+	}
+
+	root, ok := devices["root"]
+	if !ok || root["pool"] != "" {
+		return devices, nil
+	}
+	pool, err := s.profilesRootDiskPool(spec.Profiles)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if pool == "" {
+		return nil, errors.New("root disk size constraints require a root disk source or a profile with a root disk")
+	}
+	sized := device{"pool": pool}
+	for k, v := range root {
+		sized[k] = v
+	}
+	devices["root"] = sized
+	return devices, nil
+}
+
+// profilesRootDiskPool returns the storage pool of the root disk
+// resulting from applying the input profiles in order.
+func (s *Server) profilesRootDiskPool(profiles []string) (string, error) {
+	var pool string
+	for _, name := range profiles {
+		profile, _, err := s.GetProfile(name)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		for _, dev := range profile.Devices {
+			if dev["type"] == "disk" && dev["path"] == "/" {
+				pool = dev["pool"]
+			}
+		}
+	}
+	return pool, nil
+}
+
+// waitForAgent blocks until the LXD agent in the virtual machine identified
+// by the input name is running. Until then LXD can not report the addresses
+// of the machine.
+func (s *Server) waitForAgent(name string) error {
+	logger.Debugf("waiting for LXD agent in virtual machine %q", name)
+	retryArgs := retry.CallArgs{
+		Clock: s.Clock(),
+		IsFatalError: func(err error) bool {
+			return !errors.IsNotProvisioned(err)
+		},
+		Func: func() error {
+			state, _, err := s.GetInstanceState(name)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			// LXD does not report any processes for a virtual machine
+			// until its agent is running.
+			if state.Processes <= 0 {
+				return errors.NotProvisionedf("LXD agent in virtual machine %q", name)
+			}
+			return nil
+		},
+		Delay:       agentPollDelay,
+		MaxDuration: agentWaitTimeout,
+	}
+	err := retry.Call(retryArgs)
+	if retry.IsDurationExceeded(err) {
+		err = retry.LastError(err)
+	}
+	return errors.Trace(err)
+}
+
 // StartContainer starts the extant container identified by the input name.
 func (s *Server) StartContainer(name string) error {
-	req := api.ContainerStatePut{
+	req := api.InstanceStatePut{
 		Action:   "start",
 		Timeout:  -1,
 		Force:    false,
 		Stateful: false,
 	}
-	op, err := s.UpdateContainerState(name, req, "")
+	op, err := s.UpdateInstanceState(name, req, "")
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -335,19 +465,19 @@ func (s *Server) RemoveContainers(names []string) error {
 // Remove container first ensures that the container is stopped,
 // then deletes it.
 func (s *Server) RemoveContainer(name string) error {
-	state, eTag, err := s.GetContainerState(name)
+	state, eTag, err := s.GetInstanceState(name)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
 	if state.StatusCode != api.Stopped {
-		req := api.ContainerStatePut{
+		req := api.InstanceStatePut{
 			Action:   "stop",
 			Timeout:  -1,
 			Force:    true,
 			Stateful: false,
 		}
-		op, err := s.UpdateContainerState(name, req, eTag)
+		op, err := s.UpdateInstanceState(name, req, eTag)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -373,7 +503,7 @@ func (s *Server) RemoveContainer(name string) error {
 			return errors.IsBadRequest(err)
 		},
 		Func: func() error {
-			op, err := s.DeleteContainer(name)
+			op, err := s.DeleteInstance(name)
 			if err != nil {
 				// sigh, LXD not found container - it's been deleted so, we
 				// just need to return nil.
@@ -396,7 +526,7 @@ func (s *Server) RemoveContainer(name string) error {
 // WriteContainer writes the current representation of the input container to
 // the server.
 func (s *Server) WriteContainer(c *Container) error {
-	resp, err := s.UpdateContainer(c.Name, c.Writable(), "")
+	resp, err := s.UpdateInstance(c.Name, c.Writable(), "")
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -415,7 +545,7 @@ func (s *Server) Clock() clock.Clock {
 
 // containerHasStatus returns true if the input container has a status
 // matching one from the input list.
-func containerHasStatus(container api.Container, statuses []string) bool {
+func containerHasStatus(container api.Instance, statuses []string) bool {
 	for _, status := range statuses {
 		if container.StatusCode.String() == status {
 			return true
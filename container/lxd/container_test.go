@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/container/lxd/mocks"
 	lxdtesting "github.com/juju/juju/container/lxd/testing"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/network"
@@ -87,7 +88,7 @@ func (s *containerSuite) TestFilterContainers(c *gc.C) {
 	defer ctrl.Finish()
 	cSvr := s.NewMockServer(ctrl)
 
-	matching := []api.Container{
+	matching := []api.Instance{
 		{
 			Name:       "prefix-c1",
 			StatusCode: api.Starting,
@@ -97,7 +98,7 @@ func (s *containerSuite) TestFilterContainers(c *gc.C) {
 			StatusCode: api.Stopped,
 		},
 	}
-	ret := append(matching, []api.Container{
+	ret := append(matching, []api.Instance{
 		{
 			Name:       "prefix-c3",
 			StatusCode: api.Started,
@@ -107,7 +108,7 @@ func (s *containerSuite) TestFilterContainers(c *gc.C) {
 			StatusCode: api.Stopped,
 		},
 	}...)
-	cSvr.EXPECT().GetContainers().Return(ret, nil)
+	cSvr.EXPECT().GetInstances(api.InstanceTypeAny).Return(ret, nil)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -128,7 +129,7 @@ func (s *containerSuite) TestAliveContainers(c *gc.C) {
 	defer ctrl.Finish()
 	cSvr := s.NewMockServer(ctrl)
 
-	matching := []api.Container{
+	matching := []api.Instance{
 		{
 			Name:       "c1",
 			StatusCode: api.Starting,
@@ -142,11 +143,11 @@ func (s *containerSuite) TestAliveContainers(c *gc.C) {
 			StatusCode: api.Running,
 		},
 	}
-	ret := append(matching, api.Container{
+	ret := append(matching, api.Instance{
 		Name:       "c4",
 		StatusCode: api.Frozen,
 	})
-	cSvr.EXPECT().GetContainers().Return(ret, nil)
+	cSvr.EXPECT().GetInstances(api.InstanceTypeAny).Return(ret, nil)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -166,10 +167,10 @@ func (s *containerSuite) TestContainerAddresses(c *gc.C) {
 	defer ctrl.Finish()
 	cSvr := s.NewMockServer(ctrl)
 
-	state := api.ContainerState{
-		Network: map[string]api.ContainerStateNetwork{
+	state := api.InstanceState{
+		Network: map[string]api.InstanceStateNetwork{
 			"eth0": {
-				Addresses: []api.ContainerStateNetworkAddress{
+				Addresses: []api.InstanceStateNetworkAddress{
 					{
 						Family:  "inet",
 						Address: "10.0.8.173",
@@ -185,7 +186,7 @@ func (s *containerSuite) TestContainerAddresses(c *gc.C) {
 				},
 			},
 			"lo": {
-				Addresses: []api.ContainerStateNetworkAddress{
+				Addresses: []api.InstanceStateNetworkAddress{
 					{
 						Family:  "inet",
 						Address: "127.0.0.1",
@@ -201,7 +202,7 @@ func (s *containerSuite) TestContainerAddresses(c *gc.C) {
 				},
 			},
 			"lxcbr0": {
-				Addresses: []api.ContainerStateNetworkAddress{
+				Addresses: []api.InstanceStateNetworkAddress{
 					{
 						Family:  "inet",
 						Address: "10.0.5.12",
@@ -217,7 +218,7 @@ func (s *containerSuite) TestContainerAddresses(c *gc.C) {
 				},
 			},
 			"lxdbr0": {
-				Addresses: []api.ContainerStateNetworkAddress{
+				Addresses: []api.InstanceStateNetworkAddress{
 					{
 						Family:  "inet",
 						Address: "10.0.6.17",
@@ -234,7 +235,7 @@ func (s *containerSuite) TestContainerAddresses(c *gc.C) {
 			},
 		},
 	}
-	cSvr.EXPECT().GetContainerState("c1").Return(&state, lxdtesting.ETag, nil)
+	cSvr.EXPECT().GetInstanceState("c1").Return(&state, lxdtesting.ETag, nil)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -282,9 +283,9 @@ func (s *containerSuite) TestCreateContainerFromSpecSuccess(c *gc.C) {
 		},
 	}
 
-	createReq := api.ContainersPost{
+	createReq := api.InstancesPost{
 		Name: spec.Name,
-		ContainerPut: api.ContainerPut{
+		InstancePut: api.InstancePut{
 			Profiles:  spec.Profiles,
 			Devices:   spec.Devices,
 			Config:    spec.Config,
@@ -292,7 +293,7 @@ func (s *containerSuite) TestCreateContainerFromSpecSuccess(c *gc.C) {
 		},
 	}
 
-	startReq := api.ContainerStatePut{
+	startReq := api.InstanceStatePut{
 		Action:   "start",
 		Timeout:  -1,
 		Force:    false,
@@ -302,9 +303,9 @@ func (s *containerSuite) TestCreateContainerFromSpecSuccess(c *gc.C) {
 	// Container created, started and returned.
 	exp := cSvr.EXPECT()
 	gomock.InOrder(
-		exp.CreateContainerFromImage(cSvr, image, createReq).Return(createOp, nil),
-		exp.UpdateContainerState(spec.Name, startReq, "").Return(startOp, nil),
-		exp.GetContainer(spec.Name).Return(&api.Container{}, lxdtesting.ETag, nil),
+		exp.CreateInstanceFromImage(cSvr, image, createReq).Return(createOp, nil),
+		exp.UpdateInstanceState(spec.Name, startReq, "").Return(startOp, nil),
+		exp.GetInstance(spec.Name).Return(&api.Instance{}, lxdtesting.ETag, nil),
 	)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
@@ -349,9 +350,9 @@ func (s *containerSuite) TestCreateContainerFromSpecStartFailed(c *gc.C) {
 		},
 	}
 
-	createReq := api.ContainersPost{
+	createReq := api.InstancesPost{
 		Name: spec.Name,
-		ContainerPut: api.ContainerPut{
+		InstancePut: api.InstancePut{
 			Profiles:  spec.Profiles,
 			Devices:   spec.Devices,
 			Config:    spec.Config,
@@ -359,7 +360,7 @@ func (s *containerSuite) TestCreateContainerFromSpecStartFailed(c *gc.C) {
 		},
 	}
 
-	startReq := api.ContainerStatePut{
+	startReq := api.InstanceStatePut{
 		Action:   "start",
 		Timeout:  -1,
 		Force:    false,
@@ -369,11 +370,11 @@ func (s *containerSuite) TestCreateContainerFromSpecStartFailed(c *gc.C) {
 	// Container created, starting fails, container state checked, container deleted.
 	exp := cSvr.EXPECT()
 	gomock.InOrder(
-		exp.CreateContainerFromImage(cSvr, image, createReq).Return(createOp, nil),
-		exp.UpdateContainerState(spec.Name, startReq, "").Return(nil, errors.New("start failed")),
-		exp.GetContainerState(spec.Name).Return(
-			&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil),
-		exp.DeleteContainer(spec.Name).Return(deleteOp, nil),
+		exp.CreateInstanceFromImage(cSvr, image, createReq).Return(createOp, nil),
+		exp.UpdateInstanceState(spec.Name, startReq, "").Return(nil, errors.New("start failed")),
+		exp.GetInstanceState(spec.Name).Return(
+			&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil),
+		exp.DeleteInstance(spec.Name).Return(deleteOp, nil),
 	)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
@@ -384,6 +385,112 @@ func (s *containerSuite) TestCreateContainerFromSpecStartFailed(c *gc.C) {
 	c.Check(container, gc.IsNil)
 }
 
+func (s *containerSuite) TestCreateContainerFromSpecVirtualMachine(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+
+	// Operation arrangements.
+	createOp := lxdtesting.NewMockRemoteOperation(ctrl)
+	createOp.EXPECT().Wait().Return(nil)
+	createOp.EXPECT().GetTarget().Return(&api.Operation{StatusCode: api.Success}, nil)
+
+	startOp := lxdtesting.NewMockOperation(ctrl)
+	startOp.EXPECT().Wait().Return(nil)
+
+	// Request data.
+	image := api.Image{Filename: "vm-image"}
+	spec := lxd.ContainerSpec{
+		Name: "vm1",
+		Image: lxd.SourcedImage{
+			Image:     &image,
+			LXDServer: cSvr,
+		},
+		Profiles: []string{"default"},
+		Devices: map[string]map[string]string{
+			"root": {
+				"type": "disk",
+				"path": "/",
+				"size": "20480MiB",
+			},
+		},
+		Config: map[string]string{
+			"limits.cpu": "2",
+		},
+		VirtType: instance.InstanceTypeVM,
+	}
+
+	profile := &api.Profile{
+		ProfilePut: api.ProfilePut{
+			Devices: map[string]map[string]string{
+				"root": {
+					"type": "disk",
+					"path": "/",
+					"pool": "fast",
+				},
+			},
+		},
+	}
+
+	createReq := api.InstancesPost{
+		Name: spec.Name,
+		Type: api.InstanceTypeVM,
+		InstancePut: api.InstancePut{
+			Profiles: spec.Profiles,
+			Devices: map[string]map[string]string{
+				"root": {
+					"type": "disk",
+					"path": "/",
+					"pool": "fast",
+					"size": "20480MiB",
+				},
+				"config": {
+					"type":   "disk",
+					"source": "cloud-init:config",
+				},
+			},
+			Config:    spec.Config,
+			Ephemeral: false,
+		},
+	}
+
+	startReq := api.InstanceStatePut{
+		Action:   "start",
+		Timeout:  -1,
+		Force:    false,
+		Stateful: false,
+	}
+
+	clock := mocks.NewMockClock(ctrl)
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	cExp := clock.EXPECT()
+	cExp.Now().Return(time.Now()).AnyTimes()
+	cExp.After(5 * time.Second).Return(ch)
+
+	// Virtual machine created and started, then polled until its agent is
+	// running before being returned.
+	exp := cSvr.EXPECT()
+	gomock.InOrder(
+		exp.GetProfile("default").Return(profile, lxdtesting.ETag, nil),
+		exp.CreateInstanceFromImage(cSvr, image, createReq).Return(createOp, nil),
+		exp.UpdateInstanceState(spec.Name, startReq, "").Return(startOp, nil),
+		exp.GetInstanceState(spec.Name).Return(&api.InstanceState{Processes: -1}, lxdtesting.ETag, nil),
+		exp.GetInstanceState(spec.Name).Return(&api.InstanceState{Processes: 12}, lxdtesting.ETag, nil),
+		exp.GetInstance(spec.Name).Return(&api.Instance{Type: "virtual-machine"}, lxdtesting.ETag, nil),
+	)
+
+	jujuSvr, err := lxd.NewTestingServer(cSvr, clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	container, err := jujuSvr.CreateContainerFromSpec(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(container.Type, gc.Equals, "virtual-machine")
+
+	// The spec devices are left untouched.
+	c.Check(spec.Devices, gc.HasLen, 1)
+}
+
 func (s *containerSuite) TestRemoveContainersSuccess(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -395,7 +502,7 @@ func (s *containerSuite) TestRemoveContainersSuccess(c *gc.C) {
 	deleteOp := lxdtesting.NewMockOperation(ctrl)
 	deleteOp.EXPECT().Wait().Return(nil).Times(2)
 
-	stopReq := api.ContainerStatePut{
+	stopReq := api.InstanceStatePut{
 		Action:   "stop",
 		Timeout:  -1,
 		Force:    true,
@@ -404,11 +511,11 @@ func (s *containerSuite) TestRemoveContainersSuccess(c *gc.C) {
 
 	// Container c1 is already stopped. Container c2 is started and stopped before deletion.
 	exp := cSvr.EXPECT()
-	exp.GetContainerState("c1").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c1").Return(deleteOp, nil)
-	exp.GetContainerState("c2").Return(&api.ContainerState{StatusCode: api.Started}, lxdtesting.ETag, nil)
-	exp.UpdateContainerState("c2", stopReq, lxdtesting.ETag).Return(stopOp, nil)
-	exp.DeleteContainer("c2").Return(deleteOp, nil)
+	exp.GetInstanceState("c1").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c1").Return(deleteOp, nil)
+	exp.GetInstanceState("c2").Return(&api.InstanceState{StatusCode: api.Started}, lxdtesting.ETag, nil)
+	exp.UpdateInstanceState("c2", stopReq, lxdtesting.ETag).Return(stopOp, nil)
+	exp.DeleteInstance("c2").Return(deleteOp, nil)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -428,7 +535,7 @@ func (s *containerSuite) TestRemoveContainersSuccessWithNotFound(c *gc.C) {
 	deleteOp := lxdtesting.NewMockOperation(ctrl)
 	deleteOp.EXPECT().Wait().Return(nil)
 
-	stopReq := api.ContainerStatePut{
+	stopReq := api.InstanceStatePut{
 		Action:   "stop",
 		Timeout:  -1,
 		Force:    true,
@@ -437,11 +544,11 @@ func (s *containerSuite) TestRemoveContainersSuccessWithNotFound(c *gc.C) {
 
 	// Container c1 is already stopped. Container c2 is started and stopped before deletion.
 	exp := cSvr.EXPECT()
-	exp.GetContainerState("c1").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c1").Return(deleteOp, nil)
-	exp.GetContainerState("c2").Return(&api.ContainerState{StatusCode: api.Started}, lxdtesting.ETag, nil)
-	exp.UpdateContainerState("c2", stopReq, lxdtesting.ETag).Return(stopOp, nil)
-	exp.DeleteContainer("c2").Return(deleteOp, errors.New("not found"))
+	exp.GetInstanceState("c1").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c1").Return(deleteOp, nil)
+	exp.GetInstanceState("c2").Return(&api.InstanceState{StatusCode: api.Started}, lxdtesting.ETag, nil)
+	exp.UpdateInstanceState("c2", stopReq, lxdtesting.ETag).Return(stopOp, nil)
+	exp.DeleteInstance("c2").Return(deleteOp, errors.New("not found"))
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -461,7 +568,7 @@ func (s *containerSuite) TestRemoveContainersPartialFailure(c *gc.C) {
 	deleteOp := lxdtesting.NewMockOperation(ctrl)
 	deleteOp.EXPECT().Wait().Return(nil)
 
-	stopReq := api.ContainerStatePut{
+	stopReq := api.InstanceStatePut{
 		Action:   "stop",
 		Timeout:  -1,
 		Force:    true,
@@ -470,13 +577,13 @@ func (s *containerSuite) TestRemoveContainersPartialFailure(c *gc.C) {
 
 	// Container c1, c2 already stopped, but delete fails. Container c2 is started and stopped before deletion.
 	exp := cSvr.EXPECT()
-	exp.GetContainerState("c1").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c1").Return(nil, errors.New("deletion failed"))
-	exp.GetContainerState("c2").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c2").Return(nil, errors.New("deletion failed"))
-	exp.GetContainerState("c3").Return(&api.ContainerState{StatusCode: api.Started}, lxdtesting.ETag, nil)
-	exp.UpdateContainerState("c3", stopReq, lxdtesting.ETag).Return(stopOp, nil)
-	exp.DeleteContainer("c3").Return(deleteOp, nil)
+	exp.GetInstanceState("c1").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c1").Return(nil, errors.New("deletion failed"))
+	exp.GetInstanceState("c2").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c2").Return(nil, errors.New("deletion failed"))
+	exp.GetInstanceState("c3").Return(&api.InstanceState{StatusCode: api.Started}, lxdtesting.ETag, nil)
+	exp.UpdateInstanceState("c3", stopReq, lxdtesting.ETag).Return(stopOp, nil)
+	exp.DeleteInstance("c3").Return(deleteOp, nil)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -500,12 +607,12 @@ func (s *containerSuite) TestDeleteContainersPartialFailure(c *gc.C) {
 
 	// Container c1, c2 already stopped, but delete fails. Container c2 is started and stopped before deletion.
 	exp := cSvr.EXPECT()
-	exp.GetContainerState("c1").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c1").Return(deleteOpFail, nil)
-	exp.DeleteContainer("c1").Return(deleteOpSuccess, nil)
+	exp.GetInstanceState("c1").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c1").Return(deleteOpFail, nil)
+	exp.DeleteInstance("c1").Return(deleteOpSuccess, nil)
 
-	exp.GetContainerState("c2").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c2").Return(deleteOpFail, nil).Times(retries)
+	exp.GetInstanceState("c2").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c2").Return(deleteOpFail, nil).Times(retries)
 
 	clock := mocks.NewMockClock(ctrl)
 	ch := make(chan time.Time)
@@ -558,3 +665,44 @@ func (s *managerSuite) TestSpecApplyConstraints(c *gc.C) {
 	c.Check(spec.Config, gc.DeepEquals, exp)
 	c.Check(spec.InstanceType, gc.Equals, instType)
 }
+
+func (s *managerSuite) TestSpecApplyConstraintsVirtualMachine(c *gc.C) {
+	spec := lxd.ContainerSpec{
+		Config: map[string]string{},
+	}
+
+	cons := constraints.MustParse("virt-type=virtual-machine cores=2 mem=4G root-disk=20G")
+	err := spec.ApplyConstraints("3.10.0", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.VirtType, gc.Equals, instance.InstanceTypeVM)
+	c.Check(spec.Config, gc.DeepEquals, map[string]string{
+		"limits.memory": "4096MiB",
+		"limits.cpu":    "2",
+	})
+	c.Check(spec.Devices, gc.DeepEquals, map[string]map[string]string{
+		"root": {
+			"type": "disk",
+			"path": "/",
+			"size": "20480MiB",
+		},
+	})
+}
+
+func (s *managerSuite) TestSpecApplyConstraintsRootDiskContainer(c *gc.C) {
+	spec := lxd.ContainerSpec{
+		Config: map[string]string{},
+	}
+
+	err := spec.ApplyConstraints("3.10.0", constraints.MustParse("root-disk=20G"))
+	c.Assert(err, gc.ErrorMatches, "root disk size constraints require a root disk source")
+	c.Check(spec.VirtType, gc.Equals, instance.InstanceTypeContainer)
+}
+
+func (s *managerSuite) TestSpecApplyConstraintsInvalidVirtType(c *gc.C) {
+	spec := lxd.ContainerSpec{
+		Config: map[string]string{},
+	}
+
+	err := spec.ApplyConstraints("3.10.0", constraints.MustParse("virt-type=kvm"))
+	c.Assert(err, gc.ErrorMatches, `virtual type "kvm" not valid`)
+}
@@ -5,6 +5,7 @@ package lxd
 
 import (
 	"errors"
+	"time"
 
 	"github.com/juju/clock"
 	lxdclient "github.com/lxc/lxd/client"
@@ -18,6 +19,7 @@ var (
 	CheckBridgeConfigFile = checkBridgeConfigFile
 	SeriesRemoteAliases   = seriesRemoteAliases
 	ErrIPV6NotSupported   = errIPV6NotSupported
+	IsIPv6Only            = isIPv6Only
 )
 
 type patcher interface {
@@ -35,6 +37,13 @@ func PatchConnectRemote(patcher patcher, remotes map[string]lxdclient.ImageServe
 	})
 }
 
+// PatchImageCacheTimeouts sets the timeouts of requests to the
+// controller image cache.
+func PatchImageCacheTimeouts(patcher patcher, response, idle time.Duration) {
+	patcher.PatchValue(&imageCacheResponseTimeout, response)
+	patcher.PatchValue(&imageCacheIdleTimeout, idle)
+}
+
 func PatchGenerateVirtualMACAddress(patcher patcher) {
 	patcher.PatchValue(&network.GenerateVirtualMACAddress, func() string {
 		return "00:16:3e:00:00:00"
@@ -45,6 +54,10 @@ func PatchLXDViaSnap(patcher patcher, isSnap bool) {
 	patcher.PatchValue(&lxdViaSnap, func() bool { return isSnap })
 }
 
+func PatchHostIPv6Only(patcher patcher, ipv6Only bool) {
+	patcher.PatchValue(&hostIPv6Only, func() bool { return ipv6Only })
+}
+
 func PatchHostSeries(patcher patcher, series string) {
 	patcher.PatchValue(&hostSeries, func() (string, error) { return series, nil })
 }
@@ -14,6 +14,7 @@ import (
 	lxd "github.com/lxc/lxd/client"
 	"github.com/lxc/lxd/shared/api"
 
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 )
@@ -28,7 +29,7 @@ type SourcedImage struct {
 }
 
 // FindImage searches the input sources in supplied order, looking for an OS
-// image matching the supplied series and architecture.
+// image matching the supplied series, architecture and virtualisation type.
 // If found, the image and the server from which it was acquired are returned.
 // If the server is remote the image will be cached by LXD when used to create
 // a container.
@@ -37,6 +38,7 @@ type SourcedImage struct {
 // The callback argument is used to report copy progress.
 func (s *Server) FindImage(
 	series, arch string,
+	virtType instance.VirtType,
 	sources []ServerSpec,
 	copyLocal bool,
 	callback environs.StatusCallbackFunc,
@@ -46,7 +48,7 @@ func (s *Server) FindImage(
 	}
 
 	// First we check if we have the image locally.
-	localAlias := seriesLocalAlias(series, arch)
+	localAlias := seriesLocalAlias(series, arch, virtType)
 	var target string
 	entry, _, err := s.GetImageAlias(localAlias)
 	if err != nil && !IsLXDNotFound(err) {
@@ -66,16 +68,41 @@ func (s *Server) FindImage(
 		}
 	}
 
-	sourced := SourcedImage{}
-	lastErr := fmt.Errorf("no matching image found")
-
 	// We don't have an image locally with the juju-specific alias,
 	// so look in each of the provided remote sources for any of the aliases
 	// that might identify the image we want.
-	aliases, err := seriesRemoteAliases(series, arch)
+	sourced, _, err := findRemoteImage(series, arch, virtType, sources)
 	if err != nil {
 		return sourced, errors.Trace(err)
 	}
+
+	// If requested, copy the image to the local cache, adding the local alias.
+	if copyLocal {
+		if err := s.CopyRemoteImage(sourced, []string{localAlias}, callback); err != nil {
+			return sourced, errors.Trace(err)
+		}
+
+		// Now that we have the image cached locally, we indicate in the return
+		// that the source is local instead of the remote where we found it.
+		sourced.LXDServer = s.ContainerServer
+	}
+
+	return sourced, nil
+}
+
+// findRemoteImage searches the input sources in supplied order for an image
+// matching the supplied series, architecture and virtualisation type.
+// The image is returned along with the spec of the source that supplied it.
+func findRemoteImage(
+	series, arch string, virtType instance.VirtType, sources []ServerSpec,
+) (SourcedImage, ServerSpec, error) {
+	sourced := SourcedImage{}
+	lastErr := fmt.Errorf("no matching image found")
+
+	aliases, err := seriesRemoteAliases(series, arch)
+	if err != nil {
+		return sourced, ServerSpec{}, errors.Trace(err)
+	}
 	for _, remote := range sources {
 		source, err := ConnectImageRemote(remote)
 		if err != nil {
@@ -83,41 +110,27 @@ func (s *Server) FindImage(
 			lastErr = errors.Trace(err)
 			continue
 		}
+		var target string
 		for _, alias := range aliases {
-			if result, _, err := source.GetImageAlias(alias); err == nil && result != nil && result.Target != "" {
+			if result, _, err := source.GetImageAliasType(string(virtType), alias); err == nil && result != nil && result.Target != "" {
 				target = result.Target
 				break
 			}
 		}
-		if target != "" {
-			image, _, err := source.GetImage(target)
-			if err == nil {
-				logger.Debugf("Found image remotely - %q %q %q", remote.Name, image.Filename, target)
-				sourced.Image = image
-				sourced.LXDServer = source
-				break
-			} else {
-				lastErr = errors.Trace(err)
-			}
+		if target == "" {
+			continue
 		}
-	}
-
-	if sourced.Image == nil {
-		return sourced, lastErr
-	}
-
-	// If requested, copy the image to the local cache, adding the local alias.
-	if copyLocal {
-		if err := s.CopyRemoteImage(sourced, []string{localAlias}, callback); err != nil {
-			return sourced, errors.Trace(err)
+		image, _, err := source.GetImage(target)
+		if err != nil {
+			lastErr = errors.Trace(err)
+			continue
 		}
-
-		// Now that we have the image cached locally, we indicate in the return
-		// that the source is local instead of the remote where we found it.
-		sourced.LXDServer = s.ContainerServer
+		logger.Debugf("Found image remotely - %q %q %q", remote.Name, image.Filename, target)
+		sourced.Image = image
+		sourced.LXDServer = source
+		return sourced, remote, nil
 	}
-
-	return sourced, nil
+	return sourced, ServerSpec{}, lastErr
 }
 
 // CopyRemoteImage accepts an image sourced from a remote server and copies it
@@ -173,8 +186,14 @@ func (s *Server) CopyRemoteImage(
 // seriesLocalAlias returns the alias to assign to images for the
 // specified series. The alias is juju-specific, to support the
 // user supplying a customised image (e.g. CentOS with cloud-init).
-func seriesLocalAlias(series, arch string) string {
-	return fmt.Sprintf("juju/%s/%s", series, arch)
+// Virtual machine images are distinguished by a suffix, leaving the
+// aliases of container images as they have always been.
+func seriesLocalAlias(series, arch string, virtType instance.VirtType) string {
+	alias := fmt.Sprintf("juju/%s/%s", series, arch)
+	if virtType == instance.InstanceTypeVM {
+		alias = path.Join(alias, string(virtType))
+	}
+	return alias
 }
 
 // seriesRemoteAliases returns the aliases to look for in remotes.
@@ -0,0 +1,394 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujuhttp "github.com/juju/http"
+	lxd "github.com/lxc/lxd/client"
+	"github.com/lxc/lxd/shared/api"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/imagemetadata"
+)
+
+const (
+	// ImageCacheKind is the kind under which LXD container images
+	// are recorded in the controller's image storage.
+	ImageCacheKind = "lxd"
+
+	// Names of the entries in an image archive.
+	imageArchiveMetadata = "metadata"
+	imageArchiveRootfs   = "rootfs"
+)
+
+var (
+	// imageCacheResponseTimeout is how long to wait for a controller to
+	// start sending a cached image. It allows for the controller fetching
+	// the image from the image sources before it is cached.
+	imageCacheResponseTimeout = 10 * time.Minute
+
+	// imageCacheIdleTimeout is how long a download from the image cache
+	// may go without receiving any data.
+	imageCacheIdleTimeout = time.Minute
+)
+
+// ImageSources returns a list of LXD remote image sources based on the
+// container image metadata URL and stream of a model's configuration.
+func ImageSources(imageMetadataURL, imageStream string) ([]ServerSpec, error) {
+	imURL := imageMetadataURL
+
+	// Unless the configuration explicitly requests the daily stream,
+	// an empty image metadata URL results in a search of the default sources.
+	if imURL == "" && imageStream != "daily" {
+		logger.Debugf("checking default image metadata sources")
+		return []ServerSpec{CloudImagesRemote, CloudImagesDailyRemote}, nil
+	}
+	// Otherwise only check the daily stream.
+	if imURL == "" {
+		return []ServerSpec{CloudImagesDailyRemote}, nil
+	}
+
+	imURL, err := imagemetadata.ImageMetadataURL(imURL, imageStream)
+	if err != nil {
+		return nil, errors.Annotatef(err, "generating image metadata source")
+	}
+	imURL = EnsureHTTPS(imURL)
+	remote := ServerSpec{
+		Name:     strings.Replace(imURL, "https://", "", 1),
+		Host:     imURL,
+		Protocol: SimpleStreamsProtocol,
+	}
+
+	// If the daily stream was configured with custom image metadata URL,
+	// only use the Ubuntu daily as a fallback.
+	if imageStream == "daily" {
+		return []ServerSpec{remote, CloudImagesDailyRemote}, nil
+	}
+	return []ServerSpec{remote, CloudImagesRemote, CloudImagesDailyRemote}, nil
+}
+
+// FindImage searches the input sources in supplied order for the container
+// image matching the supplied series and architecture, without downloading
+// it. The image is returned along with the host of the source that has it.
+func FindImage(series, arch string, sources []ServerSpec) (*api.Image, string, error) {
+	sourced, remote, err := findRemoteImage(series, arch, instance.InstanceTypeContainer, sources)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return sourced.Image, remote.Host, nil
+}
+
+// WriteImageArchive searches the input sources in supplied order for the
+// container image matching the supplied series and architecture, and writes
+// it to w as a tar archive holding the image's metadata and rootfs files.
+// The image is returned along with the host of the source that supplied it.
+func WriteImageArchive(series, arch string, sources []ServerSpec, w io.Writer) (*api.Image, string, error) {
+	sourced, remote, err := findRemoteImage(series, arch, instance.InstanceTypeContainer, sources)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+
+	metaFile, err := ioutil.TempFile("", "juju-lxd-image-metadata")
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	defer removeTempFile(metaFile)
+	rootfsFile, err := ioutil.TempFile("", "juju-lxd-image-rootfs")
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	defer removeTempFile(rootfsFile)
+
+	logger.Debugf("downloading image %q from %q", sourced.Image.Fingerprint, remote.Host)
+	resp, err := sourced.LXDServer.GetImageFile(sourced.Image.Fingerprint, lxd.ImageFileRequest{
+		MetaFile:   metaFile,
+		RootfsFile: rootfsFile,
+	})
+	if err != nil {
+		return nil, "", errors.Annotatef(err, "downloading image %q", sourced.Image.Fingerprint)
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeArchiveEntry(tw, imageArchiveMetadata, metaFile, resp.MetaSize); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	// Unified images have no separate rootfs file.
+	if resp.RootfsSize > 0 {
+		if err := writeArchiveEntry(tw, imageArchiveRootfs, rootfsFile, resp.RootfsSize); err != nil {
+			return nil, "", errors.Trace(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return sourced.Image, remote.Host, nil
+}
+
+func writeArchiveEntry(tw *tar.Writer, name string, f *os.File, size int64) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: size}); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := io.CopyN(tw, f, size)
+	return errors.Annotatef(err, "writing image %s", name)
+}
+
+// ImportImageArchive adds the image from the tar archive read from r,
+// as written by WriteImageArchive, to the local image store,
+// assigning it the supplied aliases.
+func (s *Server) ImportImageArchive(r io.Reader, aliases []string) error {
+	var metaFile, rootfsFile *os.File
+	defer func() {
+		for _, f := range []*os.File{metaFile, rootfsFile} {
+			if f != nil {
+				removeTempFile(f)
+			}
+		}
+	}()
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Annotate(err, "reading image archive")
+		}
+		var f **os.File
+		switch hdr.Name {
+		case imageArchiveMetadata:
+			f = &metaFile
+		case imageArchiveRootfs:
+			f = &rootfsFile
+		default:
+			return errors.NotValidf("image archive entry %q", hdr.Name)
+		}
+		if *f, err = ioutil.TempFile("", "juju-lxd-image-"+hdr.Name); err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := io.Copy(*f, tr); err != nil {
+			return errors.Annotatef(err, "reading image %s", hdr.Name)
+		}
+		if _, err := (*f).Seek(0, io.SeekStart); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if metaFile == nil {
+		return errors.NotValidf("image archive without metadata")
+	}
+
+	args := &lxd.ImageCreateArgs{
+		MetaFile: metaFile,
+		MetaName: imageArchiveMetadata,
+		Type:     string(instance.InstanceTypeContainer),
+	}
+	if rootfsFile != nil {
+		args.RootfsFile = rootfsFile
+		args.RootfsName = imageArchiveRootfs
+	}
+	op, err := s.CreateImage(api.ImagesPost{}, args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := op.Wait(); err != nil {
+		return errors.Trace(err)
+	}
+	fingerprint, _ := op.Get().Metadata["fingerprint"].(string)
+	if fingerprint == "" {
+		return errors.New("image import did not report a fingerprint")
+	}
+
+	for _, alias := range aliases {
+		req := api.ImageAliasesPost{ImageAliasesEntry: api.ImageAliasesEntry{
+			Name:                 alias,
+			ImageAliasesEntryPut: api.ImageAliasesEntryPut{Target: fingerprint},
+		}}
+		if err := s.CreateImageAlias(req); err != nil {
+			return errors.Annotatef(err, "adding alias %q to image %q", alias, fingerprint)
+		}
+	}
+	return nil
+}
+
+func removeTempFile(f *os.File) {
+	_ = f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		logger.Warningf("removing temporary image file: %v", err)
+	}
+}
+
+// ImageCache provides container images cached by the Juju controller.
+type ImageCache interface {
+	// OpenImage returns a reader for the archive of the container image
+	// for the supplied series and architecture,
+	// in the form written by WriteImageArchive.
+	OpenImage(series, arch string) (io.ReadCloser, error)
+}
+
+// CopyCachedImage ensures that the container image for the supplied series
+// and architecture is in the local image store, importing it from the
+// image cache if it is not.
+// The callback argument is used to report progress.
+func (s *Server) CopyCachedImage(series, arch string, cache ImageCache, callback environs.StatusCallbackFunc) error {
+	localAlias := seriesLocalAlias(series, arch, instance.InstanceTypeContainer)
+	entry, _, err := s.GetImageAlias(localAlias)
+	if err != nil && !IsLXDNotFound(err) {
+		return errors.Trace(err)
+	}
+	if entry != nil {
+		return nil
+	}
+
+	if callback != nil {
+		_ = callback(status.Provisioning, "Retrieving image from controller cache", nil)
+	}
+	rc, err := cache.OpenImage(series, arch)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = rc.Close() }()
+	return errors.Trace(s.ImportImageArchive(rc, []string{localAlias}))
+}
+
+// ImageCacheCredentials are the credentials of the machine agent
+// with which requests to the controller image cache are authenticated.
+type ImageCacheCredentials struct {
+	Tag      string
+	Password string
+	Nonce    string
+}
+
+// NewControllerImageCache returns an image cache that downloads images
+// from the supplied controller API server URLs, trying each in turn.
+// The servers are verified with the supplied CA certificate,
+// and requests are authenticated with the supplied credentials.
+func NewControllerImageCache(urls []string, caCert string, credentials ImageCacheCredentials) (ImageCache, error) {
+	if len(urls) == 0 {
+		return nil, errors.NotValidf("empty image cache URLs")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(caCert)) {
+		return nil, errors.NotValidf("image cache CA certificate")
+	}
+	tlsConfig := jujuhttp.SecureTLSConfig()
+	tlsConfig.RootCAs = pool
+	tlsConfig.ServerName = "juju-apiserver"
+	transport := jujuhttp.NewHttpTLSTransport(tlsConfig)
+	transport.ResponseHeaderTimeout = imageCacheResponseTimeout
+	return &controllerImageCache{
+		urls:        urls,
+		credentials: credentials,
+		client:      &http.Client{Transport: transport},
+		idleTimeout: imageCacheIdleTimeout,
+	}, nil
+}
+
+type controllerImageCache struct {
+	urls        []string
+	credentials ImageCacheCredentials
+	client      *http.Client
+	idleTimeout time.Duration
+}
+
+// OpenImage is part of the ImageCache interface.
+// Requests that time out fail like any other, so that the caller can
+// fall back to the remote image sources rather than wait indefinitely.
+func (c *controllerImageCache) OpenImage(series, arch string) (io.ReadCloser, error) {
+	var lastErr error
+	for _, baseURL := range c.urls {
+		url := fmt.Sprintf("%s/images/%s/%s/%s", strings.TrimSuffix(baseURL, "/"), ImageCacheKind, series, arch)
+		rc, err := c.openImage(url)
+		if err != nil {
+			logger.Debugf("failed to fetch cached image from %q: %v", url, err)
+			lastErr = errors.Trace(err)
+			continue
+		}
+		return rc, nil
+	}
+	return nil, lastErr
+}
+
+func (c *controllerImageCache) openImage(url string) (io.ReadCloser, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		cancel()
+		return nil, errors.Trace(err)
+	}
+	if c.credentials.Tag != "" {
+		req.SetBasicAuth(c.credentials.Tag, c.credentials.Password)
+	}
+	if c.credentials.Nonce != "" {
+		req.Header.Set(params.MachineNonceHeader, c.credentials.Nonce)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		cancel()
+		return nil, errors.Errorf("fetching cached image from %q: %s", url, resp.Status)
+	}
+	return newIdleTimeoutBody(ctx, cancel, resp.Body, c.idleTimeout), nil
+}
+
+// idleTimeoutBody is a response body that abandons its request when no
+// data is read from it within the timeout, so that a stalled download
+// fails instead of holding up the provisioning of containers.
+type idleTimeoutBody struct {
+	io.ReadCloser
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+	timer   *time.Timer
+}
+
+func newIdleTimeoutBody(
+	ctx context.Context, cancel context.CancelFunc, body io.ReadCloser, timeout time.Duration,
+) *idleTimeoutBody {
+	return &idleTimeoutBody{
+		ReadCloser: body,
+		ctx:        ctx,
+		cancel:     cancel,
+		timeout:    timeout,
+		timer:      time.AfterFunc(timeout, cancel),
+	}
+}
+
+// Read is part of the io.Reader interface.
+func (b *idleTimeoutBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && b.ctx.Err() != nil {
+		return n, errors.Timeoutf("cached image download idle for %v", b.timeout)
+	}
+	b.timer.Reset(b.timeout)
+	return n, err
+}
+
+// Close is part of the io.Closer interface.
+func (b *idleTimeoutBody) Close() error {
+	b.timer.Stop()
+	b.cancel()
+	return b.ReadCloser.Close()
+}
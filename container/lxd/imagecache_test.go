@@ -0,0 +1,357 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/tls"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	lxdclient "github.com/lxc/lxd/client"
+	lxdapi "github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/container/lxd"
+	lxdtesting "github.com/juju/juju/container/lxd/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&imageCacheSuite{})
+
+type imageCacheSuite struct {
+	lxdtesting.BaseSuite
+}
+
+func (s *imageCacheSuite) TestImageSources(c *gc.C) {
+	sources, err := lxd.ImageSources("", "released")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sources, gc.DeepEquals, []lxd.ServerSpec{lxd.CloudImagesRemote, lxd.CloudImagesDailyRemote})
+
+	sources, err = lxd.ImageSources("", "daily")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sources, gc.DeepEquals, []lxd.ServerSpec{lxd.CloudImagesDailyRemote})
+
+	sources, err = lxd.ImageSources("http://images.example.com", "daily")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sources, gc.DeepEquals, []lxd.ServerSpec{{
+		Name:     "images.example.com",
+		Host:     "https://images.example.com",
+		Protocol: lxd.SimpleStreamsProtocol,
+	}, lxd.CloudImagesDailyRemote})
+}
+
+func (s *imageCacheSuite) TestFindImage(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	rSvr := lxdtesting.NewMockImageServer(ctrl)
+	lxd.PatchConnectRemote(s, map[string]lxdclient.ImageServer{"server-that-has-image": rSvr})
+
+	image := lxdapi.Image{Filename: "this-is-our-image", Fingerprint: "fingerprint"}
+	alias := lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "fingerprint"}}
+	gomock.InOrder(
+		rSvr.EXPECT().GetImageAliasType("container", "focal/"+s.Arch()).Return(&alias, lxdtesting.ETag, nil),
+		rSvr.EXPECT().GetImage("fingerprint").Return(&image, lxdtesting.ETag, nil),
+	)
+
+	remotes := []lxd.ServerSpec{{Name: "server-that-has-image", Host: "https://images.example.com"}}
+	found, source, err := lxd.FindImage("focal", s.Arch(), remotes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*found, gc.DeepEquals, image)
+	c.Check(source, gc.Equals, "https://images.example.com")
+}
+
+func (s *imageCacheSuite) TestWriteImageArchive(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	rSvr := lxdtesting.NewMockImageServer(ctrl)
+	lxd.PatchConnectRemote(s, map[string]lxdclient.ImageServer{"server-that-has-image": rSvr})
+
+	image := lxdapi.Image{Filename: "this-is-our-image", Fingerprint: "fingerprint"}
+	alias := lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "fingerprint"}}
+	gomock.InOrder(
+		rSvr.EXPECT().GetImageAliasType("container", "focal/"+s.Arch()).Return(&alias, lxdtesting.ETag, nil),
+		rSvr.EXPECT().GetImage("fingerprint").Return(&image, lxdtesting.ETag, nil),
+		rSvr.EXPECT().GetImageFile("fingerprint", gomock.Any()).DoAndReturn(
+			func(_ string, req lxdclient.ImageFileRequest) (*lxdclient.ImageFileResponse, error) {
+				_, err := req.MetaFile.Write([]byte("meta"))
+				c.Assert(err, jc.ErrorIsNil)
+				_, err = req.RootfsFile.Write([]byte("root-fs"))
+				c.Assert(err, jc.ErrorIsNil)
+				return &lxdclient.ImageFileResponse{MetaSize: 4, RootfsSize: 7}, nil
+			}),
+	)
+
+	var buf bytes.Buffer
+	remotes := []lxd.ServerSpec{{Name: "server-that-has-image", Host: "https://images.example.com"}}
+	found, source, err := lxd.WriteImageArchive("focal", s.Arch(), remotes, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*found, gc.DeepEquals, image)
+	c.Check(source, gc.Equals, "https://images.example.com")
+	c.Check(readArchive(c, &buf), gc.DeepEquals, map[string]string{"metadata": "meta", "rootfs": "root-fs"})
+}
+
+func (s *imageCacheSuite) TestWriteImageArchiveNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	rSvr := lxdtesting.NewMockImageServer(ctrl)
+	lxd.PatchConnectRemote(s, map[string]lxdclient.ImageServer{"server-without-image": rSvr})
+	rSvr.EXPECT().GetImageAliasType("container", "focal/"+s.Arch()).Return(nil, lxdtesting.ETag, errors.New("not found"))
+
+	_, _, err := lxd.WriteImageArchive("focal", s.Arch(), []lxd.ServerSpec{{Name: "server-without-image"}}, ioutil.Discard)
+	c.Assert(err, gc.ErrorMatches, "no matching image found")
+}
+
+func (s *imageCacheSuite) TestImportImageArchive(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+
+	createOp := lxdtesting.NewMockOperation(ctrl)
+	createOp.EXPECT().Wait().Return(nil)
+	createOp.EXPECT().Get().Return(lxdapi.Operation{Metadata: map[string]interface{}{"fingerprint": "fingerprint"}})
+
+	gomock.InOrder(
+		cSvr.EXPECT().CreateImage(lxdapi.ImagesPost{}, gomock.Any()).DoAndReturn(
+			func(_ lxdapi.ImagesPost, args *lxdclient.ImageCreateArgs) (lxdclient.Operation, error) {
+				c.Check(args.MetaName, gc.Equals, "metadata")
+				c.Check(args.RootfsName, gc.Equals, "rootfs")
+				c.Check(args.Type, gc.Equals, "container")
+				meta, err := ioutil.ReadAll(args.MetaFile)
+				c.Assert(err, jc.ErrorIsNil)
+				c.Check(string(meta), gc.Equals, "meta")
+				rootfs, err := ioutil.ReadAll(args.RootfsFile)
+				c.Assert(err, jc.ErrorIsNil)
+				c.Check(string(rootfs), gc.Equals, "root-fs")
+				return createOp, nil
+			}),
+		cSvr.EXPECT().CreateImageAlias(lxdapi.ImageAliasesPost{ImageAliasesEntry: lxdapi.ImageAliasesEntry{
+			Name:                 "juju/focal/" + s.Arch(),
+			ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "fingerprint"},
+		}}).Return(nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archive := writeArchive(c, map[string]string{"metadata": "meta", "rootfs": "root-fs"})
+	err = jujuSvr.ImportImageArchive(archive, []string{"juju/focal/" + s.Arch()})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *imageCacheSuite) TestImportImageArchiveInvalid(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	jujuSvr, err := lxd.NewServer(s.NewMockServer(ctrl))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.ImportImageArchive(writeArchive(c, map[string]string{"rootfs": "root-fs"}), nil)
+	c.Assert(err, gc.ErrorMatches, "image archive without metadata not valid")
+
+	err = jujuSvr.ImportImageArchive(writeArchive(c, map[string]string{"kernel": "vmlinuz"}), nil)
+	c.Assert(err, gc.ErrorMatches, `image archive entry "kernel" not valid`)
+}
+
+func (s *imageCacheSuite) TestCopyCachedImageAlreadyLocal(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+
+	alias := &lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "fingerprint"}}
+	cSvr.EXPECT().GetImageAlias("juju/focal/"+s.Arch()).Return(alias, lxdtesting.ETag, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cache := &fakeImageCache{err: errors.New("should not be called")}
+	err = jujuSvr.CopyCachedImage("focal", s.Arch(), cache, lxdtesting.NoOpCallback)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *imageCacheSuite) TestCopyCachedImageError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+	cSvr.EXPECT().GetImageAlias("juju/focal/"+s.Arch()).Return(nil, lxdtesting.ETag, errors.New("not found"))
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cache := &fakeImageCache{err: errors.New("boom")}
+	err = jujuSvr.CopyCachedImage("focal", s.Arch(), cache, lxdtesting.NoOpCallback)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Check(cache.requested, gc.DeepEquals, []string{"focal/" + s.Arch()})
+}
+
+func (s *imageCacheSuite) TestControllerImageCache(c *gc.C) {
+	var requested []string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		tag, password, ok := r.BasicAuth()
+		c.Check(ok, jc.IsTrue)
+		c.Check(tag, gc.Equals, "machine-0")
+		c.Check(password, gc.Equals, "secret")
+		c.Check(r.Header.Get(params.MachineNonceHeader), gc.Equals, "nonce")
+		if r.URL.Path == "/model/uuid/images/lxd/focal/amd64" {
+			_, _ = w.Write([]byte("archive"))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{*coretesting.ServerTLSCert}}
+	srv.StartTLS()
+	defer srv.Close()
+
+	cache, err := lxd.NewControllerImageCache([]string{srv.URL + "/model/uuid"}, coretesting.CACert, lxd.ImageCacheCredentials{
+		Tag:      "machine-0",
+		Password: "secret",
+		Nonce:    "nonce",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rc, err := cache.OpenImage("focal", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(rc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rc.Close(), jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "archive")
+
+	_, err = cache.OpenImage("bionic", "amd64")
+	c.Assert(err, gc.ErrorMatches, `fetching cached image from ".*/model/uuid/images/lxd/bionic/amd64": 404 Not Found`)
+	c.Check(requested, gc.DeepEquals, []string{
+		"/model/uuid/images/lxd/focal/amd64",
+		"/model/uuid/images/lxd/bionic/amd64",
+	})
+}
+
+// newImageCacheServer returns a started TLS server for the handler,
+// as verified by coretesting.CACert.
+func newImageCacheServer(handler http.HandlerFunc) *httptest.Server {
+	srv := httptest.NewUnstartedServer(handler)
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{*coretesting.ServerTLSCert}}
+	srv.StartTLS()
+	return srv
+}
+
+func (s *imageCacheSuite) TestControllerImageCacheResponseTimeout(c *gc.C) {
+	lxd.PatchImageCacheTimeouts(s, 100*time.Millisecond, coretesting.LongWait)
+
+	// The first controller never responds; the image is fetched
+	// from the second instead.
+	release := make(chan struct{})
+	stalled := newImageCacheServer(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	})
+	defer stalled.Close()
+	defer close(release)
+	srv := newImageCacheServer(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("archive"))
+	})
+	defer srv.Close()
+
+	cache, err := lxd.NewControllerImageCache(
+		[]string{stalled.URL + "/model/uuid", srv.URL + "/model/uuid"}, coretesting.CACert, lxd.ImageCacheCredentials{})
+	c.Assert(err, jc.ErrorIsNil)
+	rc, err := cache.OpenImage("focal", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(rc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rc.Close(), jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "archive")
+
+	// With no other controller, the timeout is reported.
+	cache, err = lxd.NewControllerImageCache(
+		[]string{stalled.URL + "/model/uuid"}, coretesting.CACert, lxd.ImageCacheCredentials{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cache.OpenImage("focal", "amd64")
+	c.Assert(err, gc.ErrorMatches, ".*timeout awaiting response headers")
+}
+
+func (s *imageCacheSuite) TestControllerImageCacheIdleTimeout(c *gc.C) {
+	lxd.PatchImageCacheTimeouts(s, coretesting.LongWait, 100*time.Millisecond)
+
+	// The download stalls after the first bytes.
+	release := make(chan struct{})
+	srv := newImageCacheServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "7")
+		_, _ = w.Write([]byte("arc"))
+		w.(http.Flusher).Flush()
+		<-release
+	})
+	defer srv.Close()
+	defer close(release)
+
+	cache, err := lxd.NewControllerImageCache([]string{srv.URL + "/model/uuid"}, coretesting.CACert, lxd.ImageCacheCredentials{})
+	c.Assert(err, jc.ErrorIsNil)
+	rc, err := cache.OpenImage("focal", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { _ = rc.Close() }()
+	_, err = ioutil.ReadAll(rc)
+	c.Assert(err, jc.Satisfies, errors.IsTimeout)
+	c.Assert(err, gc.ErrorMatches, "cached image download idle for 100ms timeout")
+}
+
+func (s *imageCacheSuite) TestNewControllerImageCacheInvalid(c *gc.C) {
+	_, err := lxd.NewControllerImageCache(nil, coretesting.CACert, lxd.ImageCacheCredentials{})
+	c.Assert(err, gc.ErrorMatches, "empty image cache URLs not valid")
+	_, err = lxd.NewControllerImageCache([]string{"https://10.0.0.1:17070"}, "junk", lxd.ImageCacheCredentials{})
+	c.Assert(err, gc.ErrorMatches, "image cache CA certificate not valid")
+}
+
+type fakeImageCache struct {
+	requested []string
+	archive   string
+	err       error
+}
+
+func (f *fakeImageCache) OpenImage(series, arch string) (io.ReadCloser, error) {
+	f.requested = append(f.requested, series+"/"+arch)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return ioutil.NopCloser(bytes.NewBufferString(f.archive)), nil
+}
+
+func writeArchive(c *gc.C, entries map[string]string) io.Reader {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range []string{"metadata", "rootfs", "kernel"} {
+		content, ok := entries[name]
+		if !ok {
+			continue
+		}
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = tw.Write([]byte(content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	return &buf
+}
+
+func readArchive(c *gc.C, r io.Reader) map[string]string {
+	entries := make(map[string]string)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		content, err := ioutil.ReadAll(tr)
+		c.Assert(err, jc.ErrorIsNil)
+		entries[hdr.Name] = string(content)
+	}
+}
@@ -24,7 +24,6 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
-	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/network"
 )
@@ -44,6 +43,7 @@ type containerManager struct {
 
 	imageMetadataURL string
 	imageStream      string
+	imageCache       ImageCache
 	imageMutex       sync.Mutex
 
 	profileMutex sync.Mutex
@@ -74,6 +74,21 @@ func NewContainerManager(cfg container.ManagerConfig, svr *Server) (container.Ma
 	imageMetaDataURL := cfg.PopValue(config.ContainerImageMetadataURLKey)
 	imageStream := cfg.PopValue(config.ContainerImageStreamKey)
 
+	var imageCache ImageCache
+	imageCacheURLs := strings.Fields(cfg.PopValue(container.ConfigImageCacheURLs))
+	imageCacheCACert := cfg.PopValue(container.ConfigImageCacheCACert)
+	imageCacheCredentials := ImageCacheCredentials{
+		Tag:      cfg.PopValue(container.ConfigImageCacheTag),
+		Password: cfg.PopValue(container.ConfigImageCachePassword),
+		Nonce:    cfg.PopValue(container.ConfigImageCacheNonce),
+	}
+	if len(imageCacheURLs) > 0 {
+		imageCache, err = NewControllerImageCache(imageCacheURLs, imageCacheCACert, imageCacheCredentials)
+		if err != nil {
+			return nil, errors.Annotate(err, "creating controller image cache")
+		}
+	}
+
 	// This value is also popped by the provisioner worker; the following
 	// dummy pop operation ensures that we don't get a spurious warning
 	// for it when calling WarnAboutUnused() below.
@@ -87,6 +102,7 @@ func NewContainerManager(cfg container.ManagerConfig, svr *Server) (container.Ma
 		availabilityZone: availabilityZone,
 		imageMetadataURL: imageMetaDataURL,
 		imageStream:      imageStream,
+		imageCache:       imageCache,
 	}, nil
 }
 
@@ -163,12 +179,24 @@ func (m *containerManager) getContainerSpec(
 		return ContainerSpec{}, errors.Trace(err)
 	}
 
+	virtType, err := VirtTypeFromConstraints(cons)
+	if err != nil {
+		return ContainerSpec{}, errors.Trace(err)
+	}
+
 	// Lock around finding an image.
 	// The provisioner works concurrently to create containers.
 	// If an image needs to be copied from a remote, we don't want many
 	// goroutines attempting to do it at once.
+	// Container images cached by the controller are preferred over the
+	// remote sources, so that hosts do not each download the image.
 	m.imageMutex.Lock()
-	found, err := m.server.FindImage(series, jujuarch.HostArch(), imageSources, true, callback)
+	if m.imageCache != nil && virtType == instance.InstanceTypeContainer {
+		if err := m.server.CopyCachedImage(series, jujuarch.HostArch(), m.imageCache, callback); err != nil {
+			logger.Warningf("acquiring LXD image from controller cache, falling back to remote sources: %v", err)
+		}
+	}
+	found, err := m.server.FindImage(series, jujuarch.HostArch(), virtType, imageSources, true, callback)
 	m.imageMutex.Unlock()
 	if err != nil {
 		return ContainerSpec{}, errors.Annotatef(err, "acquiring LXD image")
@@ -187,16 +215,21 @@ func (m *containerManager) getContainerSpec(
 	logger.Debugf("configuring container %q with network devices: %v", name, nics)
 
 	// If the default LXD bridge was supplied in network config,
-	// but without a CIDR, attempt to ensure it is configured for IPv4.
+	// but without a CIDR, attempt to ensure it is configured for IPv4,
+	// or IPv6 on hosts without IPv4 connectivity.
 	// If there are others with incomplete info, log a warning.
 	if len(unknown) > 0 {
 		if len(unknown) == 1 && unknown[0] == network.DefaultLXDBridge && m.server.networkAPISupport {
-			mod, err := m.server.EnsureIPv4(network.DefaultLXDBridge)
+			ensure, family := m.server.EnsureIPv4, "IPv4"
+			if hostIPv6Only() {
+				ensure, family = m.server.EnsureIPv6, "IPv6"
+			}
+			mod, err := ensure(network.DefaultLXDBridge)
 			if err != nil {
-				return ContainerSpec{}, errors.Annotate(err, "ensuring default bridge IPv4 config")
+				return ContainerSpec{}, errors.Annotatef(err, "ensuring default bridge %s config", family)
 			}
 			if mod {
-				logger.Infof(`added "auto" IPv4 configuration to default LXD bridge`)
+				logger.Infof(`added "auto" %s configuration to default LXD bridge`, family)
 			}
 		} else {
 			logger.Warningf("no CIDR was detected for the following networks: %v", unknown)
@@ -248,36 +281,7 @@ func (m *containerManager) getContainerSpec(
 // getImageSources returns a list of LXD remote image sources based on the
 // configuration that was passed into the container manager.
 func (m *containerManager) getImageSources() ([]ServerSpec, error) {
-	imURL := m.imageMetadataURL
-
-	// Unless the configuration explicitly requests the daily stream,
-	// an empty image metadata URL results in a search of the default sources.
-	if imURL == "" && m.imageStream != "daily" {
-		logger.Debugf("checking default image metadata sources")
-		return []ServerSpec{CloudImagesRemote, CloudImagesDailyRemote}, nil
-	}
-	// Otherwise only check the daily stream.
-	if imURL == "" {
-		return []ServerSpec{CloudImagesDailyRemote}, nil
-	}
-
-	imURL, err := imagemetadata.ImageMetadataURL(imURL, m.imageStream)
-	if err != nil {
-		return nil, errors.Annotatef(err, "generating image metadata source")
-	}
-	imURL = EnsureHTTPS(imURL)
-	remote := ServerSpec{
-		Name:     strings.Replace(imURL, "https://", "", 1),
-		Host:     imURL,
-		Protocol: SimpleStreamsProtocol,
-	}
-
-	// If the daily stream was configured with custom image metadata URL,
-	// only use the Ubuntu daily as a fallback.
-	if m.imageStream == "daily" {
-		return []ServerSpec{remote, CloudImagesDailyRemote}, nil
-	}
-	return []ServerSpec{remote, CloudImagesRemote, CloudImagesDailyRemote}, nil
+	return ImageSources(m.imageMetadataURL, m.imageStream)
 }
 
 // networkDevicesFromConfig uses the input container network configuration to
@@ -4,8 +4,12 @@
 package lxd_test
 
 import (
+	"crypto/tls"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	stdtesting "testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/juju/names/v4"
@@ -52,6 +56,7 @@ var _ = gc.Suite(&managerSuite{})
 func (s *managerSuite) patch() {
 	lxd.PatchConnectRemote(s, map[string]lxdclient.ImageServer{"cloud-images.ubuntu.com": s.cSvr})
 	lxd.PatchGenerateVirtualMACAddress(s)
+	lxd.PatchHostIPv6Only(s, false)
 }
 
 func (s *managerSuite) makeManager(c *gc.C) {
@@ -140,12 +145,12 @@ func (s *managerSuite) TestContainerCreateDestroy(c *gc.C) {
 
 	// Arrangements for the container creation.
 	s.expectCreateContainer(ctrl)
-	exp.UpdateContainerState(hostName, lxdapi.ContainerStatePut{Action: "start", Timeout: -1}, "").Return(s.startOp, nil)
+	exp.UpdateInstanceState(hostName, lxdapi.InstanceStatePut{Action: "start", Timeout: -1}, "").Return(s.startOp, nil)
 
-	exp.GetContainerState(hostName).Return(
-		&lxdapi.ContainerState{
+	exp.GetInstanceState(hostName).Return(
+		&lxdapi.InstanceState{
 			StatusCode: lxdapi.Running,
-			Network: map[string]lxdapi.ContainerStateNetwork{
+			Network: map[string]lxdapi.InstanceStateNetwork{
 				"fan0": {
 					Type: "fan",
 				},
@@ -156,18 +161,18 @@ func (s *managerSuite) TestContainerCreateDestroy(c *gc.C) {
 			},
 		}, lxdtesting.ETag, nil).Times(2)
 
-	exp.GetContainer(hostName).Return(&lxdapi.Container{Name: hostName}, lxdtesting.ETag, nil)
+	exp.GetInstance(hostName).Return(&lxdapi.Instance{Name: hostName}, lxdtesting.ETag, nil)
 
 	// Arrangements for the container destruction.
-	stopReq := lxdapi.ContainerStatePut{
+	stopReq := lxdapi.InstanceStatePut{
 		Action:   "stop",
 		Timeout:  -1,
 		Stateful: false,
 		Force:    true,
 	}
 	gomock.InOrder(
-		exp.UpdateContainerState(hostName, stopReq, lxdtesting.ETag).Return(s.stopOp, nil),
-		exp.DeleteContainer(hostName).Return(s.deleteOp, nil),
+		exp.UpdateInstanceState(hostName, stopReq, lxdtesting.ETag).Return(s.stopOp, nil),
+		exp.DeleteInstance(hostName).Return(s.deleteOp, nil),
 	)
 
 	instance, hc, err := s.manager.CreateContainer(
@@ -213,8 +218,8 @@ func (s *managerSuite) TestContainerCreateUpdateIPv4Network(c *gc.C) {
 	s.expectCreateContainer(ctrl)
 	s.expectStartOp(ctrl)
 
-	exp.UpdateContainerState(hostName, lxdapi.ContainerStatePut{Action: "start", Timeout: -1}, "").Return(s.startOp, nil)
-	exp.GetContainer(hostName).Return(&lxdapi.Container{Name: hostName}, lxdtesting.ETag, nil)
+	exp.UpdateInstanceState(hostName, lxdapi.InstanceStatePut{Action: "start", Timeout: -1}, "").Return(s.startOp, nil)
+	exp.GetInstance(hostName).Return(&lxdapi.Instance{Name: hostName}, lxdtesting.ETag, nil)
 
 	// Supplying config for a single device with default bridge and without a
 	// CIDR will cause the default bridge to be updated with IPv4 config.
@@ -230,6 +235,51 @@ func (s *managerSuite) TestContainerCreateUpdateIPv4Network(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *managerSuite) TestContainerCreateUpdateIPv6Network(c *gc.C) {
+	ctrl := s.setupWithExtensions(c, "network")
+	defer ctrl.Finish()
+
+	s.patch()
+	lxd.PatchHostIPv6Only(s, true)
+
+	s.makeManager(c)
+	iCfg := prepInstanceConfig(c)
+	hostName, err := s.manager.Namespace().Hostname(iCfg.MachineId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	exp := s.cSvr.EXPECT()
+
+	req := lxdapi.NetworkPut{
+		Config: map[string]string{
+			"ipv6.address": "auto",
+			"ipv6.nat":     "false",
+		},
+	}
+	gomock.InOrder(
+		exp.GetNetwork(network.DefaultLXDBridge).Return(&lxdapi.Network{}, lxdtesting.ETag, nil),
+		exp.UpdateNetwork(network.DefaultLXDBridge, req, lxdtesting.ETag).Return(nil),
+	)
+
+	s.expectCreateContainer(ctrl)
+	s.expectStartOp(ctrl)
+
+	exp.UpdateInstanceState(hostName, lxdapi.InstanceStatePut{Action: "start", Timeout: -1}, "").Return(s.startOp, nil)
+	exp.GetInstance(hostName).Return(&lxdapi.Instance{Name: hostName}, lxdtesting.ETag, nil)
+
+	// On a host without IPv4 connectivity, the default bridge is
+	// updated with IPv6 config instead.
+	netConfig := container.BridgeNetworkConfig("eth0", 1500, corenetwork.InterfaceInfos{{
+		InterfaceName:       "eth0",
+		InterfaceType:       corenetwork.EthernetInterface,
+		ConfigType:          corenetwork.ConfigDHCP,
+		ParentInterfaceName: network.DefaultLXDBridge,
+	}})
+	_, _, err = s.manager.CreateContainer(
+		iCfg, constraints.Value{}, "xenial", netConfig, &container.StorageConfig{}, lxdtesting.NoOpCallback,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *managerSuite) TestCreateContainerCreateFailed(c *gc.C) {
 	ctrl := s.setup(c)
 	defer ctrl.Finish()
@@ -240,7 +290,7 @@ func (s *managerSuite) TestCreateContainerCreateFailed(c *gc.C) {
 	s.expectGetImage(image, nil)
 
 	exp := s.cSvr.EXPECT()
-	exp.CreateContainerFromImage(s.cSvr, image, gomock.Any()).Return(s.createRemoteOp, nil)
+	exp.CreateInstanceFromImage(s.cSvr, image, gomock.Any()).Return(s.createRemoteOp, nil)
 
 	s.makeManager(c)
 	_, _, err := s.manager.CreateContainer(
@@ -293,10 +343,10 @@ func (s *managerSuite) TestCreateContainerStartFailed(c *gc.C) {
 
 	exp := s.cSvr.EXPECT()
 	gomock.InOrder(
-		exp.UpdateContainerState(
-			hostName, lxdapi.ContainerStatePut{Action: "start", Timeout: -1}, "").Return(s.updateOp, nil),
-		exp.GetContainerState(hostName).Return(&lxdapi.ContainerState{StatusCode: lxdapi.Stopped}, lxdtesting.ETag, nil),
-		exp.DeleteContainer(hostName).Return(s.deleteOp, nil),
+		exp.UpdateInstanceState(
+			hostName, lxdapi.InstanceStatePut{Action: "start", Timeout: -1}, "").Return(s.updateOp, nil),
+		exp.GetInstanceState(hostName).Return(&lxdapi.InstanceState{StatusCode: lxdapi.Stopped}, lxdtesting.ETag, nil),
+		exp.DeleteInstance(hostName).Return(s.deleteOp, nil),
 	)
 
 	_, _, err = s.manager.CreateContainer(
@@ -317,7 +367,7 @@ func (s *managerSuite) TestListContainers(c *gc.C) {
 	prefix := s.manager.Namespace().Prefix()
 	wrongPrefix := prefix[:len(prefix)-1] + "j"
 
-	containers := []lxdapi.Container{
+	containers := []lxdapi.Instance{
 		{Name: "foobar"},
 		{Name: "definitely-not-a-juju-container"},
 		{Name: wrongPrefix + "-0"},
@@ -327,7 +377,7 @@ func (s *managerSuite) TestListContainers(c *gc.C) {
 		{Name: "nothing-to-see-here-please"},
 	}
 
-	s.cSvr.EXPECT().GetContainers().Return(containers, nil)
+	s.cSvr.EXPECT().GetInstances(lxdapi.InstanceTypeAny).Return(containers, nil)
 
 	result, err := s.manager.ListContainers()
 	c.Assert(err, jc.ErrorIsNil)
@@ -438,6 +488,76 @@ func (s *managerSuite) TestNetworkDevicesFromConfigNoInputGetsProfileNICs(c *gc.
 	c.Check(result, gc.DeepEquals, exp)
 }
 
+func (s *managerSuite) TestNewContainerManagerImageCache(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	svr, err := lxd.NewServer(s.cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg := getBaseConfig()
+	cfg[container.ConfigImageCacheURLs] = "https://10.0.0.1:17070/model/uuid https://10.0.0.2:17070/model/uuid"
+	cfg[container.ConfigImageCacheCACert] = coretesting.CACert
+	cfg[container.ConfigImageCacheTag] = "machine-0"
+	cfg[container.ConfigImageCachePassword] = "secret"
+	cfg[container.ConfigImageCacheNonce] = "nonce"
+	_, err = lxd.NewContainerManager(cfg, svr)
+	c.Assert(err, jc.ErrorIsNil)
+	// The credentials are consumed rather than reported as unused.
+	c.Check(cfg, gc.HasLen, 0)
+
+	cfg = getBaseConfig()
+	cfg[container.ConfigImageCacheURLs] = "https://10.0.0.1:17070/model/uuid"
+	cfg[container.ConfigImageCacheCACert] = "junk"
+	_, err = lxd.NewContainerManager(cfg, svr)
+	c.Assert(err, gc.ErrorMatches, "creating controller image cache: image cache CA certificate not valid")
+}
+
+func (s *managerSuite) TestCreateContainerImageCacheTimeout(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+	lxd.PatchImageCacheTimeouts(s, 100*time.Millisecond, coretesting.LongWait)
+
+	requested := make(chan struct{}, 1)
+	release := make(chan struct{})
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested <- struct{}{}
+		<-release
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{*coretesting.ServerTLSCert}}
+	srv.StartTLS()
+	defer srv.Close()
+	defer close(release)
+
+	// The image is not held locally when the cache is consulted.
+	// Once the cache times out, the image is acquired as if there were
+	// no cache; here that fails on creation of the container.
+	s.cSvr.EXPECT().GetImageAlias("juju/xenial/"+s.Arch()).Return(nil, "", errors.New("not found"))
+	s.expectCreateRemoteOp(ctrl, &lxdapi.Operation{StatusCode: lxdapi.Failure, Err: "create failed"})
+	image := lxdapi.Image{Filename: "this-is-our-image"}
+	s.expectGetImage(image, nil)
+	s.cSvr.EXPECT().CreateInstanceFromImage(s.cSvr, image, gomock.Any()).Return(s.createRemoteOp, nil)
+
+	cfg := getBaseConfig()
+	cfg[container.ConfigImageCacheURLs] = srv.URL + "/model/uuid"
+	cfg[container.ConfigImageCacheCACert] = coretesting.CACert
+	s.makeManagerForConfig(c, cfg)
+	_, _, err := s.manager.CreateContainer(
+		prepInstanceConfig(c),
+		constraints.Value{},
+		"xenial",
+		prepNetworkConfig(),
+		&container.StorageConfig{},
+		lxdtesting.NoOpCallback,
+	)
+	c.Assert(err, gc.ErrorMatches, ".*create failed")
+
+	select {
+	case <-requested:
+	default:
+		c.Fatalf("image cache not consulted")
+	}
+}
+
 func (s *managerSuite) TestGetImageSourcesDefaultConfig(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -604,7 +724,7 @@ func (s *managerSuite) expectCreateContainer(ctrl *gomock.Controller) {
 	s.expectGetImage(image, nil)
 
 	exp := s.cSvr.EXPECT()
-	exp.CreateContainerFromImage(s.cSvr, image, gomock.Any()).Return(s.createRemoteOp, nil)
+	exp.CreateInstanceFromImage(s.cSvr, image, gomock.Any()).Return(s.createRemoteOp, nil)
 }
 
 // expectCreateRemoteOp is a convenience function for the expectations
@@ -673,17 +793,17 @@ func (s *managerSuite) expectUpdateContainerProfiles(old, new string, newProfile
 		cExp.GetProfileNames().Return(oldProfiles, nil),
 		cExp.CreateProfile(post).Return(nil),
 		cExp.GetProfile(post.Name).Return(&expProfile, "etag", nil),
-		cExp.GetContainer(instId).Return(
-			&lxdapi.Container{
-				ContainerPut: lxdapi.ContainerPut{
+		cExp.GetInstance(instId).Return(
+			&lxdapi.Instance{
+				InstancePut: lxdapi.InstancePut{
 					Profiles: oldProfiles,
 				},
 			}, "", nil),
-		cExp.UpdateContainer(instId, gomock.Any(), gomock.Any()).Return(s.updateOp, nil),
+		cExp.UpdateInstance(instId, gomock.Any(), gomock.Any()).Return(s.updateOp, nil),
 		cExp.DeleteProfile(old).Return(nil),
-		cExp.GetContainer(instId).Return(
-			&lxdapi.Container{
-				ContainerPut: lxdapi.ContainerPut{
+		cExp.GetInstance(instId).Return(
+			&lxdapi.Instance{
+				InstancePut: lxdapi.InstancePut{
 					Profiles: newProfiles,
 				},
 			}, "", nil),
@@ -6,6 +6,7 @@ package lxd
 import (
 	"fmt"
 	"io/ioutil"
+	stdnet "net"
 	"os"
 	"sort"
 	"strconv"
@@ -87,6 +88,32 @@ func (s *Server) EnsureIPv4(netName string) (bool, error) {
 	return modified, nil
 }
 
+// EnsureIPv6 retrieves the network for the input name and checks its IPv6
+// configuration. If none is detected, it is set to "auto" without NAT,
+// so that containers are reachable at their own global addresses.
+// The boolean return indicates if modification was necessary.
+func (s *Server) EnsureIPv6(netName string) (bool, error) {
+	net, eTag, err := s.GetNetwork(netName)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+
+	cfg, ok := net.Config["ipv6.address"]
+	if ok && cfg != "none" {
+		return false, nil
+	}
+	if net.Config == nil {
+		net.Config = make(device, 2)
+	}
+	net.Config["ipv6.address"] = "auto"
+	net.Config["ipv6.nat"] = "false"
+
+	if err := s.UpdateNetwork(netName, net.Writable(), eTag); err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
 // GetNICsFromProfile returns all NIC devices in the profile with the input
 // name. All returned devices have a MAC address; generated if required.
 func (s *Server) GetNICsFromProfile(profileName string) (map[string]device, error) {
@@ -127,25 +154,35 @@ func (s *Server) VerifyNetworkDevice(profile *api.Profile, eTag string) error {
 }
 
 // ensureDefaultNetworking ensures that the default LXD bridge exists,
-// that it is not configured to use IPv6, and that a NIC device exists in
-// the input profile.
-// An error is returned if the bridge exists with IPv6 configuration.
-// If the bridge does not exist, it is created.
+// that its IP configuration is usable by Juju, and that a NIC device
+// exists in the input profile.
+// An error is returned if the bridge exists with unusable configuration.
+// If the bridge does not exist, it is created; with IPv6 only on hosts
+// without IPv4 connectivity, otherwise with IPv4 only.
 func (s *Server) ensureDefaultNetworking(profile *api.Profile, eTag string) error {
 	net, _, err := s.GetNetwork(network.DefaultLXDBridge)
 	if err != nil {
 		if !IsLXDNotFound(err) {
 			return errors.Trace(err)
 		}
-		req := api.NetworksPost{
-			Name: network.DefaultLXDBridge,
-			Type: netTypeBridge,
-			NetworkPut: api.NetworkPut{Config: map[string]string{
-				"ipv4.address": "auto",
-				"ipv4.nat":     "true",
-				"ipv6.address": "none",
+		netConfig := map[string]string{
+			"ipv4.address": "auto",
+			"ipv4.nat":     "true",
+			"ipv6.address": "none",
+			"ipv6.nat":     "false",
+		}
+		if hostIPv6Only() {
+			netConfig = map[string]string{
+				"ipv4.address": "none",
+				"ipv4.nat":     "false",
+				"ipv6.address": "auto",
 				"ipv6.nat":     "false",
-			}},
+			}
+		}
+		req := api.NetworksPost{
+			Name:       network.DefaultLXDBridge,
+			Type:       netTypeBridge,
+			NetworkPut: api.NetworkPut{Config: netConfig},
 		}
 		err := s.CreateNetwork(req)
 		if err != nil {
@@ -156,7 +193,7 @@ func (s *Server) ensureDefaultNetworking(profile *api.Profile, eTag string) erro
 			return errors.Trace(err)
 		}
 	} else {
-		if err := verifyNoIPv6(net); err != nil {
+		if err := verifyIPConfig(net); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -210,7 +247,7 @@ func (s *Server) verifyNICsWithAPI(nics map[string]device) error {
 			return errors.Annotatef(err, "retrieving network %q", netName)
 		}
 
-		if err := verifyNoIPv6(net); err != nil {
+		if err := verifyIPConfig(net); err != nil {
 			ipV6ErrMsg = err
 			continue
 		}
@@ -228,7 +265,8 @@ func (s *Server) verifyNICsWithAPI(nics map[string]device) error {
 		return nil
 	}
 
-	// A nic with valid type found, but the network configures IPv6.
+	// A nic with valid type found, but the network IP configuration is
+	// not usable on this host.
 	if ipV6ErrMsg != nil {
 		return ipV6ErrMsg
 	}
@@ -237,7 +275,7 @@ func (s *Server) verifyNICsWithAPI(nics map[string]device) error {
 	return errors.Errorf(fmt.Sprintf(
 		"no network device found with nictype %q or %q"+
 			"\n\tthe following devices were checked: %s"+
-			"\nNote: juju does not support IPv6."+
+			"\nNote: juju only supports IPv6 on hosts without IPv4 connectivity."+
 			"\nReconfigure lxd to use a network of type %q or %q, disabling IPv6.",
 		nicTypeBridged, nicTypeMACVLAN, strings.Join(checked, ", "), nicTypeBridged, nicTypeMACVLAN))
 }
@@ -305,10 +343,19 @@ func getProfileNICs(profile *api.Profile) map[string]device {
 	return nics
 }
 
+// verifyIPConfig checks that the IP configuration of the input network
+// is usable on this host. On hosts without IPv4 connectivity the network
+// must not have IPv6 disabled; on all others it must have no IPv6
+// configuration.
+func verifyIPConfig(net *api.Network) error {
+	if hostIPv6Only() {
+		return verifyIPv6(net)
+	}
+	return verifyNoIPv6(net)
+}
+
 // verifyNoIPv6 checks that the input network has no IPv6 configuration.
 // An error is returned when it does.
-// TODO (manadart 2018-05-28) The intention is to support IPv6, so this
-// restriction is temporary.
 func verifyNoIPv6(net *api.Network) error {
 	if !net.Managed {
 		return nil
@@ -321,11 +368,51 @@ func verifyNoIPv6(net *api.Network) error {
 		return nil
 	}
 
-	return errors.Errorf("juju does not support IPv6. Disable IPv6 in LXD via:\n"+
+	return errors.Errorf("juju only supports IPv6 on hosts without IPv4 connectivity. Disable IPv6 in LXD via:\n"+
 		"\tlxc network set %s ipv6.address none\n"+
 		"and run the command again", net.Name)
 }
 
+// verifyIPv6 checks that the input network does not have IPv6 disabled.
+// An error is returned when it does.
+func verifyIPv6(net *api.Network) error {
+	if !net.Managed || net.Config["ipv6.address"] != "none" {
+		return nil
+	}
+	return errors.Errorf("this host has no IPv4 connectivity, but IPv6 is disabled for %[1]s. Enable IPv6 in LXD via:\n"+
+		"\tlxc network set %[1]s ipv6.address auto\n"+
+		"\tlxc network set %[1]s ipv6.nat false\n"+
+		"and run the command again", net.Name)
+}
+
+// hostIPv6Only reports whether this host has global IPv6 addresses, but
+// no IPv4 addresses other than loopback and link-local ones.
+var hostIPv6Only = func() bool {
+	addrs, err := stdnet.InterfaceAddrs()
+	if err != nil {
+		logger.Warningf("cannot get host addresses: %v", err)
+		return false
+	}
+	return isIPv6Only(addrs)
+}
+
+func isIPv6Only(addrs []stdnet.Addr) bool {
+	var ipv6 bool
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*stdnet.IPNet)
+		if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			return false
+		}
+		if ipNet.IP.IsGlobalUnicast() {
+			ipv6 = true
+		}
+	}
+	return ipv6
+}
+
 func isValidNICType(nic device) bool {
 	return nic["nictype"] == nicTypeBridged || nic["nictype"] == nicTypeMACVLAN
 }
@@ -5,6 +5,7 @@ package lxd_test
 
 import (
 	"errors"
+	stdnet "net"
 
 	"github.com/golang/mock/gomock"
 	jc "github.com/juju/testing/checkers"
@@ -23,6 +24,11 @@ type networkSuite struct {
 
 var _ = gc.Suite(&networkSuite{})
 
+func (s *networkSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	lxd.PatchHostIPv6Only(s, false)
+}
+
 func (s *networkSuite) patch() {
 	lxd.PatchGenerateVirtualMACAddress(s)
 }
@@ -102,6 +108,59 @@ func (s *networkSuite) TestEnsureIPv4Modified(c *gc.C) {
 	c.Check(mod, jc.IsTrue)
 }
 
+func (s *networkSuite) TestEnsureIPv6NoChange(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "network")
+
+	net := &lxdapi.Network{
+		NetworkPut: lxdapi.NetworkPut{
+			Config: map[string]string{
+				"ipv6.address": "2001:db8::1/64",
+			},
+		},
+	}
+	cSvr.EXPECT().GetNetwork("some-net-name").Return(net, lxdtesting.ETag, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mod, err := jujuSvr.EnsureIPv6("some-net-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod, jc.IsFalse)
+}
+
+func (s *networkSuite) TestEnsureIPv6Modified(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "network")
+
+	net := &lxdapi.Network{
+		NetworkPut: lxdapi.NetworkPut{
+			Config: map[string]string{
+				"ipv6.address": "none",
+			},
+		},
+	}
+	req := lxdapi.NetworkPut{
+		Config: map[string]string{
+			"ipv6.address": "auto",
+			"ipv6.nat":     "false",
+		},
+	}
+	gomock.InOrder(
+		cSvr.EXPECT().GetNetwork(network.DefaultLXDBridge).Return(net, lxdtesting.ETag, nil),
+		cSvr.EXPECT().UpdateNetwork(network.DefaultLXDBridge, req, lxdtesting.ETag).Return(nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mod, err := jujuSvr.EnsureIPv6(network.DefaultLXDBridge)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod, jc.IsTrue)
+}
+
 func (s *networkSuite) TestGetNICsFromProfile(c *gc.C) {
 	lxd.PatchGenerateVirtualMACAddress(s)
 
@@ -216,7 +275,7 @@ func (s *networkSuite) TestVerifyNetworkDevicePresentBadNicType(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches,
 		`profile "default": no network device found with nictype "bridged" or "macvlan"\n`+
 			`\tthe following devices were checked: eth0\n`+
-			`Note: juju does not support IPv6.\n`+
+			`Note: juju only supports IPv6 on hosts without IPv4 connectivity.\n`+
 			`Reconfigure lxd to use a network of type "bridged" or "macvlan", disabling IPv6.`)
 }
 
@@ -241,11 +300,68 @@ func (s *networkSuite) TestVerifyNetworkDeviceIPv6Present(c *gc.C) {
 
 	err = jujuSvr.VerifyNetworkDevice(defaultLegacyProfileWithNIC(), "")
 	c.Assert(err, gc.ErrorMatches,
-		`profile "default": juju does not support IPv6. Disable IPv6 in LXD via:\n`+
+		`profile "default": juju only supports IPv6 on hosts without IPv4 connectivity. Disable IPv6 in LXD via:\n`+
 			`\tlxc network set lxdbr0 ipv6.address none\n`+
 			`and run the command again`)
 }
 
+func (s *networkSuite) TestVerifyNetworkDeviceIPv6PresentIPv6Only(c *gc.C) {
+	lxd.PatchHostIPv6Only(s, true)
+
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "network")
+
+	net := &lxdapi.Network{
+		Name:    network.DefaultLXDBridge,
+		Managed: true,
+		NetworkPut: lxdapi.NetworkPut{
+			Config: map[string]string{
+				"ipv4.address": "none",
+				"ipv6.address": "2001:db8::1/64",
+			},
+		},
+	}
+	cSvr.EXPECT().GetNetwork(network.DefaultLXDBridge).Return(net, "", nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.VerifyNetworkDevice(defaultLegacyProfileWithNIC(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(jujuSvr.LocalBridgeName(), gc.Equals, network.DefaultLXDBridge)
+}
+
+func (s *networkSuite) TestVerifyNetworkDeviceIPv6DisabledIPv6Only(c *gc.C) {
+	lxd.PatchHostIPv6Only(s, true)
+
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "network")
+
+	net := &lxdapi.Network{
+		Name:    network.DefaultLXDBridge,
+		Managed: true,
+		NetworkPut: lxdapi.NetworkPut{
+			Config: map[string]string{
+				"ipv4.address": "auto",
+				"ipv6.address": "none",
+			},
+		},
+	}
+	cSvr.EXPECT().GetNetwork(network.DefaultLXDBridge).Return(net, "", nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.VerifyNetworkDevice(defaultLegacyProfileWithNIC(), "")
+	c.Assert(err, gc.ErrorMatches,
+		`profile "default": this host has no IPv4 connectivity, but IPv6 is disabled for lxdbr0. Enable IPv6 in LXD via:\n`+
+			`\tlxc network set lxdbr0 ipv6.address auto\n`+
+			`\tlxc network set lxdbr0 ipv6.nat false\n`+
+			`and run the command again`)
+}
+
 func (s *networkSuite) TestVerifyNetworkDeviceNotPresentCreated(c *gc.C) {
 	s.patch()
 
@@ -287,6 +403,48 @@ func (s *networkSuite) TestVerifyNetworkDeviceNotPresentCreated(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *networkSuite) TestVerifyNetworkDeviceNotPresentCreatedIPv6Only(c *gc.C) {
+	s.patch()
+	lxd.PatchHostIPv6Only(s, true)
+
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "network")
+
+	netConf := map[string]string{
+		"ipv4.address": "none",
+		"ipv4.nat":     "false",
+		"ipv6.address": "auto",
+		"ipv6.nat":     "false",
+	}
+	netCreateReq := lxdapi.NetworksPost{
+		Name:       network.DefaultLXDBridge,
+		Type:       "bridge",
+		NetworkPut: lxdapi.NetworkPut{Config: netConf},
+	}
+	newNet := &lxdapi.Network{
+		Name:       network.DefaultLXDBridge,
+		Type:       "bridge",
+		Managed:    true,
+		NetworkPut: lxdapi.NetworkPut{Config: netConf},
+	}
+	gomock.InOrder(
+		cSvr.EXPECT().GetNetwork(network.DefaultLXDBridge).Return(nil, "", errors.New("not found")),
+		cSvr.EXPECT().CreateNetwork(netCreateReq).Return(nil),
+		cSvr.EXPECT().GetNetwork(network.DefaultLXDBridge).Return(newNet, "", nil),
+		cSvr.EXPECT().UpdateProfile("default", defaultLegacyProfileWithNIC().Writable(), lxdtesting.ETag).Return(nil),
+	)
+
+	profile := defaultLegacyProfileWithNIC()
+	delete(profile.Devices, "eth0")
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.VerifyNetworkDevice(profile, lxdtesting.ETag)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *networkSuite) TestVerifyNetworkDeviceNotPresentCreatedWithUnusedName(c *gc.C) {
 	s.patch()
 
@@ -717,3 +875,35 @@ func (s *networkSuite) TestNewNICDeviceWithAssignedHostNICName(c *gc.C) {
 	}
 	c.Assert(device, gc.DeepEquals, expected)
 }
+
+func (s *networkSuite) TestIsIPv6Only(c *gc.C) {
+	ipNet := func(cidr string) stdnet.Addr {
+		ip, ipNet, err := stdnet.ParseCIDR(cidr)
+		c.Assert(err, jc.ErrorIsNil)
+		ipNet.IP = ip
+		return ipNet
+	}
+	for i, test := range []struct {
+		addrs    []string
+		ipv6Only bool
+	}{{
+		addrs:    []string{"127.0.0.1/8", "::1/128", "2001:db8::10/64", "fe80::1/64"},
+		ipv6Only: true,
+	}, {
+		addrs:    []string{"127.0.0.1/8", "169.254.0.5/16", "2001:db8::10/64"},
+		ipv6Only: true,
+	}, {
+		addrs:    []string{"127.0.0.1/8", "10.0.0.5/24", "2001:db8::10/64"},
+		ipv6Only: false,
+	}, {
+		addrs:    []string{"127.0.0.1/8", "::1/128", "fe80::1/64"},
+		ipv6Only: false,
+	}} {
+		c.Logf("test %d: %v", i, test.addrs)
+		var addrs []stdnet.Addr
+		for _, addr := range test.addrs {
+			addrs = append(addrs, ipNet(addr))
+		}
+		c.Check(lxd.IsIPv6Only(addrs), gc.Equals, test.ipv6Only)
+	}
+}
@@ -161,7 +161,7 @@ func (s *Server) UpdateServerConfig(cfg map[string]string) error {
 // UpdateContainerConfig updates the configuration for the container with the
 // input name, using the input values.
 func (s *Server) UpdateContainerConfig(name string, cfg map[string]string) error {
-	container, eTag, err := s.GetContainer(name)
+	container, eTag, err := s.GetInstance(name)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -172,7 +172,7 @@ func (s *Server) UpdateContainerConfig(name string, cfg map[string]string) error
 		container.Config[k] = v
 	}
 
-	resp, err := s.UpdateContainer(name, container.Writable(), eTag)
+	resp, err := s.UpdateInstance(name, container.Writable(), eTag)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -182,7 +182,7 @@ func (s *Server) UpdateContainerConfig(name string, cfg map[string]string) error
 // GetContainerProfiles returns the list of profiles that are assocated with a
 // container.
 func (s *Server) GetContainerProfiles(name string) ([]string, error) {
-	container, _, err := s.GetContainer(name)
+	container, _, err := s.GetInstance(name)
 	if err != nil {
 		return []string{}, errors.Trace(err)
 	}
@@ -195,14 +195,14 @@ func (s *Server) GetContainerProfiles(name string) ([]string, error) {
 // ReplaceOrAddContainerProfile updates the profiles for the container with the
 // input name, using the input values.
 func (s *Server) ReplaceOrAddContainerProfile(name, oldProfile, newProfile string) error {
-	container, eTag, err := s.GetContainer(name)
+	container, eTag, err := s.GetInstance(name)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to get container %q", name))
 	}
 	profiles := addRemoveReplaceProfileName(container.Profiles, oldProfile, newProfile)
 
 	container.Profiles = profiles
-	resp, err := s.UpdateContainer(name, container.Writable(), eTag)
+	resp, err := s.UpdateInstance(name, container.Writable(), eTag)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to updated container %q", name))
 	}
@@ -241,13 +241,13 @@ func addRemoveReplaceProfileName(profiles []string, oldProfile, newProfile strin
 // named container.  It is assumed the profiles have all been added to
 // the server before hand.
 func (s *Server) UpdateContainerProfiles(name string, profiles []string) error {
-	container, eTag, err := s.GetContainer(name)
+	container, eTag, err := s.GetInstance(name)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to get %q", name))
 	}
 
 	container.Profiles = profiles
-	resp, err := s.UpdateContainer(name, container.Writable(), eTag)
+	resp, err := s.UpdateInstance(name, container.Writable(), eTag)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to update %q with profiles", name))
 	}
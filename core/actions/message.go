@@ -5,8 +5,13 @@ package actions
 
 import "time"
 
-// ActionMessage is a timestamped message logged by a running action.
+// ActionMessage is a timestamped message logged by a running action,
+// or a chunk of output written by it.
 type ActionMessage struct {
 	Message   string    `json:"message"`
 	Timestamp time.Time `json:"timestamp"`
+
+	// Stream is "stdout" or "stderr" for output written by
+	// the action, and empty for progress messages.
+	Stream string `json:"stream,omitempty"`
 }
@@ -0,0 +1,63 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"github.com/juju/errors"
+)
+
+// LeaderOrder determines when the task for an application's leader
+// unit is run relative to the rest of a rolling operation.
+type LeaderOrder string
+
+const (
+	// LeaderAnyOrder runs the leader's task alongside the other tasks.
+	LeaderAnyOrder LeaderOrder = ""
+
+	// LeaderFirst runs the leader's task on its own before any
+	// other task is started.
+	LeaderFirst LeaderOrder = "first"
+
+	// LeaderLast runs the leader's task on its own once every
+	// other task has finished.
+	LeaderLast LeaderOrder = "last"
+)
+
+// Validate returns an error if the order is not recognised.
+func (o LeaderOrder) Validate() error {
+	switch o {
+	case LeaderAnyOrder, LeaderFirst, LeaderLast:
+		return nil
+	}
+	return errors.NotValidf("leader order %q", string(o))
+}
+
+// RolloutStages splits the given tasks into the stages of a rolling
+// operation. Each stage is only started once every task in the stages
+// before it has finished. isLeader reports whether a task targets an
+// application leader.
+func RolloutStages(tasks []string, isLeader func(string) bool, order LeaderOrder) [][]string {
+	if order == LeaderAnyOrder {
+		return [][]string{tasks}
+	}
+	var leaders, others []string
+	for _, task := range tasks {
+		if isLeader(task) {
+			leaders = append(leaders, task)
+		} else {
+			others = append(others, task)
+		}
+	}
+	var stages [][]string
+	if order == LeaderFirst && len(leaders) > 0 {
+		stages = append(stages, leaders)
+	}
+	if len(others) > 0 {
+		stages = append(stages, others)
+	}
+	if order == LeaderLast && len(leaders) > 0 {
+		stages = append(stages, leaders)
+	}
+	return stages
+}
@@ -0,0 +1,36 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type rollingSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&rollingSuite{})
+
+func (s *rollingSuite) TestLeaderOrderValidate(c *gc.C) {
+	c.Assert(actions.LeaderAnyOrder.Validate(), jc.ErrorIsNil)
+	c.Assert(actions.LeaderFirst.Validate(), jc.ErrorIsNil)
+	c.Assert(actions.LeaderLast.Validate(), jc.ErrorIsNil)
+	c.Assert(actions.LeaderOrder("middle").Validate(), gc.ErrorMatches, `leader order "middle" not valid`)
+}
+
+func (s *rollingSuite) TestRolloutStages(c *gc.C) {
+	isLeader := func(task string) bool {
+		return task == "2"
+	}
+	tasks := []string{"1", "2", "3"}
+	c.Assert(actions.RolloutStages(tasks, isLeader, actions.LeaderAnyOrder), jc.DeepEquals, [][]string{{"1", "2", "3"}})
+	c.Assert(actions.RolloutStages(tasks, isLeader, actions.LeaderFirst), jc.DeepEquals, [][]string{{"2"}, {"1", "3"}})
+	c.Assert(actions.RolloutStages(tasks, isLeader, actions.LeaderLast), jc.DeepEquals, [][]string{{"1", "3"}, {"2"}})
+	c.Assert(actions.RolloutStages([]string{"2"}, isLeader, actions.LeaderLast), jc.DeepEquals, [][]string{{"2"}})
+}
@@ -0,0 +1,212 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// MissedRunPolicy determines what happens to scheduled action runs
+// which were due while no scheduler was able to fire them.
+type MissedRunPolicy string
+
+const (
+	// MissedRunSkip indicates that missed runs are dropped and the
+	// schedule resumes at its next due time.
+	MissedRunSkip MissedRunPolicy = "skip"
+
+	// MissedRunOnce indicates that a single run is fired as soon as
+	// possible to make up for any number of missed runs.
+	MissedRunOnce MissedRunPolicy = "run-once"
+)
+
+// Validate returns an error if the policy is not recognised.
+func (p MissedRunPolicy) Validate() error {
+	switch p {
+	case MissedRunSkip, MissedRunOnce:
+		return nil
+	}
+	return errors.NotValidf("missed run policy %q", string(p))
+}
+
+// CronSchedule is a parsed standard 5 field cron expression
+// (minute, hour, day of month, month and day of week).
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record whether the day of month and day
+	// of week fields were unrestricted, as cron matches either day
+	// field when both of them are restricted.
+	domStar, dowStar bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week accepts both 0 and 7 for Sunday.
+	dowField = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCronSchedule parses a standard 5 field cron expression, or one
+// of the @yearly, @monthly, @weekly, @daily and @hourly shorthands.
+func ParseCronSchedule(spec string) (*CronSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	if expanded, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = expanded
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("cron expression %q: expected 5 fields, got %d", spec, len(fields))
+	}
+	var (
+		s   CronSchedule
+		err error
+	)
+	if s.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Fold Sunday-as-7 into Sunday-as-0.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+	return &s, nil
+}
+
+func (f cronField) parse(expr string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangeExpr = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.NotValidf("%s step %q", f.name, part[i+1:])
+			}
+		}
+		lo, hi := f.min, f.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi, err = f.value(bounds[1]); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if lo > hi {
+				return 0, errors.NotValidf("%s range %q", f.name, rangeExpr)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rangeExpr); err != nil {
+				return 0, errors.Trace(err)
+			}
+			// A single value with a step, such as 5/15, runs from
+			// that value to the end of the range.
+			hi = lo
+			if step > 1 {
+				hi = f.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, errors.NotValidf("%s value %q", f.name, s)
+	}
+	return v, nil
+}
+
+// maxScheduleSearch bounds how far ahead Next will look for a matching
+// time; expressions such as "0 0 30 2 *" never match.
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first time strictly after t which matches the
+// schedule, or the zero time if no time matches within five years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
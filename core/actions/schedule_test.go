@@ -0,0 +1,88 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type scheduleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func mustTime(c *gc.C, value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	c.Assert(err, jc.ErrorIsNil)
+	return t
+}
+
+func (s *scheduleSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec     string
+		from     string
+		expected string
+	}{
+		{"0 3 * * *", "2021-03-01 02:59", "2021-03-01 03:00"},
+		{"0 3 * * *", "2021-03-01 03:00", "2021-03-02 03:00"},
+		{"*/15 * * * *", "2021-03-01 10:16", "2021-03-01 10:30"},
+		{"5/20 * * * *", "2021-03-01 10:26", "2021-03-01 10:45"},
+		{"30 1-3 * * *", "2021-03-01 03:31", "2021-03-02 01:30"},
+		{"0 0 * * sun", "2021-03-01 00:00", "2021-03-07 00:00"},
+		{"0 0 * * 7", "2021-03-01 00:00", "2021-03-07 00:00"},
+		{"0 0 1 jan *", "2021-03-01 00:00", "2022-01-01 00:00"},
+		{"@hourly", "2021-03-01 10:00", "2021-03-01 11:00"},
+		{"@monthly", "2021-12-15 10:00", "2022-01-01 00:00"},
+		// When both day fields are restricted either may match.
+		{"0 0 15 * mon", "2021-03-02 00:00", "2021-03-08 00:00"},
+		{"0 0 29 2 *", "2021-01-01 00:00", "2024-02-29 00:00"},
+	} {
+		c.Logf("test %d: %q from %s", i, test.spec, test.from)
+		schedule, err := actions.ParseCronSchedule(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.Next(mustTime(c, test.from)), gc.Equals, mustTime(c, test.expected))
+	}
+}
+
+func (s *scheduleSuite) TestNextNeverMatches(c *gc.C) {
+	schedule, err := actions.ParseCronSchedule("0 0 30 2 *")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Next(mustTime(c, "2021-01-01 00:00")).IsZero(), jc.IsTrue)
+}
+
+func (s *scheduleSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{
+		{"", `cron expression "": expected 5 fields, got 0 not valid`},
+		{"* * * *", `cron expression "\* \* \* \*": expected 5 fields, got 4 not valid`},
+		{"60 * * * *", `minute value "60" not valid`},
+		{"* 24 * * *", `hour value "24" not valid`},
+		{"* * 0 * *", `day of month value "0" not valid`},
+		{"* * * foo *", `month value "foo" not valid`},
+		{"* * * * 8", `day of week value "8" not valid`},
+		{"*/0 * * * *", `minute step "0" not valid`},
+		{"10-5 * * * *", `minute range "10-5" not valid`},
+	} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := actions.ParseCronSchedule(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *scheduleSuite) TestMissedRunPolicyValidate(c *gc.C) {
+	c.Assert(actions.MissedRunSkip.Validate(), jc.ErrorIsNil)
+	c.Assert(actions.MissedRunOnce.Validate(), jc.ErrorIsNil)
+	c.Assert(actions.MissedRunPolicy("sometimes").Validate(), gc.ErrorMatches, `missed run policy "sometimes" not valid`)
+}
@@ -0,0 +1,8 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+// ReplaceLostMachinesConfigOptionName is the option name used to opt an
+// application in to the replacement of machines whose instances are lost.
+const ReplaceLostMachinesConfigOptionName = "replace-lost-machines"
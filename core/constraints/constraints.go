@@ -35,8 +35,24 @@ const (
 	VirtType         = "virt-type"
 	Zones            = "zones"
 	AllocatePublicIP = "allocate-public-ip"
+	Spot             = "spot"
+
+	// The following constraints describe the compute resources
+	// requested by, and the limits imposed on, Kubernetes workloads.
+	MemRequest            = "mem-request"
+	MemLimit              = "mem-limit"
+	CpuRequest            = "cpu-request"
+	CpuLimit              = "cpu-limit"
+	EphemeralStorageLimit = "ephemeral-storage-limit"
+	ContainerResources    = "container-resources"
 )
 
+// containerResourceConstraints are the constraints which may be
+// overridden for individual containers with ContainerResources.
+var containerResourceConstraints = []string{
+	MemRequest, MemLimit, CpuRequest, CpuLimit, EphemeralStorageLimit,
+}
+
 // Value describes a user's requirements of the hardware on which units
 // of an application will run. Constraints are used to choose an existing machine
 // onto which a unit will be deployed, or to provision a new machine if no
@@ -104,6 +120,39 @@ type Value struct {
 	// The default behaviour if the value is not specified is to allocate
 	// a public IP so that public cloud behaviour works out of the box.
 	AllocatePublicIP *bool `json:"allocate-public-ip,omitempty" yaml:"allocate-public-ip,omitempty"`
+
+	// Spot, if true, indicates that machines should be provisioned on
+	// the cloud's spot (or preemptible) market, at a lower price but
+	// subject to being interrupted by the cloud at any time.
+	Spot *bool `json:"spot,omitempty" yaml:"spot,omitempty"`
+
+	// MemRequest, if not nil, indicates the megabytes of RAM a
+	// Kubernetes workload is guaranteed to be scheduled with.
+	MemRequest *uint64 `json:"mem-request,omitempty" yaml:"mem-request,omitempty"`
+
+	// MemLimit, if not nil, indicates the megabytes of RAM a Kubernetes
+	// workload may use before it is terminated.
+	MemLimit *uint64 `json:"mem-limit,omitempty" yaml:"mem-limit,omitempty"`
+
+	// CpuRequest, if not nil, indicates the CPU power a Kubernetes
+	// workload is guaranteed to be scheduled with, in the same units
+	// as CpuPower.
+	CpuRequest *uint64 `json:"cpu-request,omitempty" yaml:"cpu-request,omitempty"`
+
+	// CpuLimit, if not nil, indicates the CPU power a Kubernetes
+	// workload is throttled to, in the same units as CpuPower.
+	CpuLimit *uint64 `json:"cpu-limit,omitempty" yaml:"cpu-limit,omitempty"`
+
+	// EphemeralStorageLimit, if not nil, indicates the megabytes of
+	// local ephemeral storage a Kubernetes workload may use before it
+	// is evicted.
+	EphemeralStorageLimit *uint64 `json:"ephemeral-storage-limit,omitempty" yaml:"ephemeral-storage-limit,omitempty"`
+
+	// ContainerResources, if not nil, holds resource constraints which
+	// apply to a single container of a Kubernetes workload rather than
+	// to all of them. Each entry is of the form
+	// "<container>.<constraint>=<value>", eg "nginx.mem-limit=512M".
+	ContainerResources *[]string `json:"container-resources,omitempty" yaml:"container-resources,omitempty"`
 }
 
 var rawAliases = map[string]string{
@@ -220,6 +269,66 @@ func (v *Value) HasAllocatePublicIP() bool {
 	return v.AllocatePublicIP != nil
 }
 
+// HasSpot returns whether the spot constraint was specified and true.
+func (v *Value) HasSpot() bool {
+	return v.Spot != nil && *v.Spot
+}
+
+// HasResourceRequirements returns whether any of the constraints
+// describing the requests and limits of Kubernetes workloads were
+// specified.
+func (v *Value) HasResourceRequirements() bool {
+	return v.MemRequest != nil || v.MemLimit != nil ||
+		v.CpuRequest != nil || v.CpuLimit != nil ||
+		v.EphemeralStorageLimit != nil ||
+		(v.ContainerResources != nil && len(*v.ContainerResources) > 0)
+}
+
+// ContainerResourceConstraints returns the resource constraints
+// specified for individual containers, keyed on container name.
+func (v *Value) ContainerResourceConstraints() (map[string]Value, error) {
+	if v.ContainerResources == nil {
+		return nil, nil
+	}
+	result := make(map[string]Value)
+	for _, entry := range *v.ContainerResources {
+		name, raw, err := splitContainerResource(entry)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attr, val, err := splitRaw(raw)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !isContainerResourceConstraint(attr) {
+			return nil, errors.Errorf("constraint %q cannot be set for container %q", attr, name)
+		}
+		cons := result[name]
+		if err := cons.setRaw(attr, val); err != nil {
+			return nil, errors.Annotatef(err, "container %q", name)
+		}
+		result[name] = cons
+	}
+	return result, nil
+}
+
+func splitContainerResource(entry string) (name, raw string, err error) {
+	dot := strings.Index(entry, ".")
+	if dot <= 0 {
+		return "", "", errors.Errorf("malformed container resource %q, expected <container>.<constraint>=<value>", entry)
+	}
+	return entry[:dot], entry[dot+1:], nil
+}
+
+func isContainerResourceConstraint(name string) bool {
+	for _, attr := range containerResourceConstraints {
+		if name == attr {
+			return true
+		}
+	}
+	return false
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -274,6 +383,28 @@ func (v Value) String() string {
 	if v.AllocatePublicIP != nil {
 		strs = append(strs, "allocate-public-ip="+boolStr(*v.AllocatePublicIP))
 	}
+	if v.Spot != nil {
+		strs = append(strs, "spot="+boolStr(*v.Spot))
+	}
+	if v.MemRequest != nil {
+		strs = append(strs, "mem-request="+sizeStr(*v.MemRequest))
+	}
+	if v.MemLimit != nil {
+		strs = append(strs, "mem-limit="+sizeStr(*v.MemLimit))
+	}
+	if v.CpuRequest != nil {
+		strs = append(strs, "cpu-request="+uintStr(*v.CpuRequest))
+	}
+	if v.CpuLimit != nil {
+		strs = append(strs, "cpu-limit="+uintStr(*v.CpuLimit))
+	}
+	if v.EphemeralStorageLimit != nil {
+		strs = append(strs, "ephemeral-storage-limit="+sizeStr(*v.EphemeralStorageLimit))
+	}
+	if v.ContainerResources != nil {
+		s := strings.Join(*v.ContainerResources, ",")
+		strs = append(strs, "container-resources="+s)
+	}
 
 	// Ensure constraint values with spaces are properly escaped
 	for i := 0; i < len(strs); i++ {
@@ -329,6 +460,29 @@ func (v Value) GoString() string {
 	if v.AllocatePublicIP != nil {
 		values = append(values, fmt.Sprintf("AllocatePublicIP: %v", *v.AllocatePublicIP))
 	}
+	if v.Spot != nil {
+		values = append(values, fmt.Sprintf("Spot: %v", *v.Spot))
+	}
+	if v.MemRequest != nil {
+		values = append(values, fmt.Sprintf("MemRequest: %v", *v.MemRequest))
+	}
+	if v.MemLimit != nil {
+		values = append(values, fmt.Sprintf("MemLimit: %v", *v.MemLimit))
+	}
+	if v.CpuRequest != nil {
+		values = append(values, fmt.Sprintf("CpuRequest: %v", *v.CpuRequest))
+	}
+	if v.CpuLimit != nil {
+		values = append(values, fmt.Sprintf("CpuLimit: %v", *v.CpuLimit))
+	}
+	if v.EphemeralStorageLimit != nil {
+		values = append(values, fmt.Sprintf("EphemeralStorageLimit: %v", *v.EphemeralStorageLimit))
+	}
+	if v.ContainerResources != nil && *v.ContainerResources != nil {
+		values = append(values, fmt.Sprintf("ContainerResources: %q", *v.ContainerResources))
+	} else if v.ContainerResources != nil {
+		values = append(values, "ContainerResources: (*[]string)(nil)")
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -339,6 +493,16 @@ func uintStr(i uint64) string {
 	return fmt.Sprintf("%d", i)
 }
 
+// sizeStr returns the megabytes i with the suffix expected when
+// parsing sizes, or "" if i is zero.
+func sizeStr(i uint64) string {
+	s := uintStr(i)
+	if s != "" {
+		s += "M"
+	}
+	return s
+}
+
 func boolStr(b bool) string {
 	return fmt.Sprintf("%v", b)
 }
@@ -500,6 +664,20 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setZones(str)
 	case AllocatePublicIP:
 		err = v.setAllocatePublicIP(str)
+	case Spot:
+		err = v.setSpot(str)
+	case MemRequest:
+		err = v.setSize(&v.MemRequest, str)
+	case MemLimit:
+		err = v.setSize(&v.MemLimit, str)
+	case CpuRequest:
+		err = v.setUint64(&v.CpuRequest, str)
+	case CpuLimit:
+		err = v.setUint64(&v.CpuLimit, str)
+	case EphemeralStorageLimit:
+		err = v.setSize(&v.EphemeralStorageLimit, str)
+	case ContainerResources:
+		err = v.setContainerResources(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -569,6 +747,23 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			v.Zones, err = parseYamlStrings("zones", val)
 		case AllocatePublicIP:
 			v.AllocatePublicIP, err = parseBool(vstr)
+		case Spot:
+			v.Spot, err = parseBool(vstr)
+		case MemRequest:
+			v.MemRequest, err = parseUint64(vstr)
+		case MemLimit:
+			v.MemLimit, err = parseUint64(vstr)
+		case CpuRequest:
+			v.CpuRequest, err = parseUint64(vstr)
+		case CpuLimit:
+			v.CpuLimit, err = parseUint64(vstr)
+		case EphemeralStorageLimit:
+			v.EphemeralStorageLimit, err = parseUint64(vstr)
+		case ContainerResources:
+			v.ContainerResources, err = parseYamlStrings("container-resources", val)
+			if err == nil {
+				_, err = v.ContainerResourceConstraints()
+			}
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -720,6 +915,44 @@ func (v *Value) setAllocatePublicIP(str string) (err error) {
 	return
 }
 
+func (v *Value) setSpot(str string) (err error) {
+	if str == "" {
+		return nil
+	}
+	if v.Spot != nil {
+		return errors.Errorf("already set")
+	}
+	v.Spot, err = parseBool(str)
+	return
+}
+
+// setSize sets the size constraint held by field, in megabytes.
+func (v *Value) setSize(field **uint64, str string) (err error) {
+	if *field != nil {
+		return errors.Errorf("already set")
+	}
+	*field, err = parseSize(str)
+	return
+}
+
+// setUint64 sets the integer constraint held by field.
+func (v *Value) setUint64(field **uint64, str string) (err error) {
+	if *field != nil {
+		return errors.Errorf("already set")
+	}
+	*field, err = parseUint64(str)
+	return
+}
+
+func (v *Value) setContainerResources(str string) error {
+	if v.ContainerResources != nil {
+		return errors.Errorf("already set")
+	}
+	v.ContainerResources = parseCommaDelimited(str)
+	_, err := v.ContainerResourceConstraints()
+	return err
+}
+
 func parseBool(str string) (*bool, error) {
 	var value bool
 	if str != "" {
@@ -366,6 +366,55 @@ var parseConstraintsTests = []struct {
 		err:     `bad "allocate-public-ip" constraint: already set`,
 	},
 
+	// Spot
+	{
+		summary: "set spot",
+		args:    []string{"spot=true"},
+	}, {
+		summary: "set nonsense spot",
+		args:    []string{"spot=fred"},
+		err:     `bad "spot" constraint: must be 'true' or 'false'`,
+	}, {
+		summary: "try to set spot twice",
+		args:    []string{"spot=true spot=false"},
+		err:     `bad "spot" constraint: already set`,
+	},
+
+	// Kubernetes resource requirements
+	{
+		summary: "set mem-request and mem-limit",
+		args:    []string{"mem-request=256M mem-limit=1G"},
+	}, {
+		summary: "set cpu-request and cpu-limit",
+		args:    []string{"cpu-request=250 cpu-limit=1000"},
+	}, {
+		summary: "set nonsense cpu-request",
+		args:    []string{"cpu-request=lots"},
+		err:     `bad "cpu-request" constraint: must be a non-negative integer`,
+	}, {
+		summary: "set ephemeral-storage-limit",
+		args:    []string{"ephemeral-storage-limit=2G"},
+	}, {
+		summary: "try to set mem-limit twice",
+		args:    []string{"mem-limit=1G", "mem-limit=2G"},
+		err:     `bad "mem-limit" constraint: already set`,
+	}, {
+		summary: "set container-resources",
+		args:    []string{"container-resources=nginx.mem-limit=512M,nginx.cpu-request=250,redis.mem-request=1G"},
+	}, {
+		summary: "set malformed container-resources",
+		args:    []string{"container-resources=mem-limit=512M"},
+		err:     `bad "container-resources" constraint: malformed container resource "mem-limit=512M", expected <container>.<constraint>=<value>`,
+	}, {
+		summary: "set unsupported container-resources",
+		args:    []string{"container-resources=nginx.arch=amd64"},
+		err:     `bad "container-resources" constraint: constraint "arch" cannot be set for container "nginx"`,
+	}, {
+		summary: "set nonsense container-resources",
+		args:    []string{"container-resources=nginx.mem-limit=lots"},
+		err:     `bad "container-resources" constraint: container "nginx": bad "mem-limit" constraint: must be a non-negative float with optional M/G/T/P suffix`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
@@ -579,6 +628,18 @@ func (s *ConstraintsSuite) TestHasAllocatePublicIP(c *gc.C) {
 	c.Check(con.HasAllocatePublicIP(), jc.IsFalse)
 }
 
+func (s *ConstraintsSuite) TestHasSpot(c *gc.C) {
+	con := constraints.MustParse("spot=true")
+	c.Check(con.HasSpot(), jc.IsTrue)
+
+	con = constraints.MustParse("spot=false")
+	c.Assert(con.Spot, gc.Not(gc.IsNil))
+	c.Check(con.HasSpot(), jc.IsFalse)
+
+	con = constraints.MustParse("spot=")
+	c.Check(con.HasSpot(), jc.IsFalse)
+}
+
 func (s *ConstraintsSuite) TestHasRootDiskSource(c *gc.C) {
 	con := constraints.MustParse("root-disk-source=pilgrim")
 	c.Check(con.HasRootDiskSource(), jc.IsTrue)
@@ -626,6 +687,40 @@ func (s *ConstraintsSuite) TestIsEmpty(c *gc.C) {
 	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
 	con = constraints.MustParse("allocate-public-ip=")
 	c.Check(&con, jc.Satisfies, constraints.IsEmpty)
+	con = constraints.MustParse("mem-limit=")
+	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
+	con = constraints.MustParse("container-resources=")
+	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
+}
+
+func (s *ConstraintsSuite) TestHasResourceRequirements(c *gc.C) {
+	con := constraints.MustParse("mem=4G cpu-power=100")
+	c.Check(con.HasResourceRequirements(), jc.IsFalse)
+	con = constraints.MustParse("cpu-request=100")
+	c.Check(con.HasResourceRequirements(), jc.IsTrue)
+	con = constraints.MustParse("container-resources=")
+	c.Check(con.HasResourceRequirements(), jc.IsFalse)
+	con = constraints.MustParse("container-resources=nginx.mem-limit=1G")
+	c.Check(con.HasResourceRequirements(), jc.IsTrue)
+}
+
+func (s *ConstraintsSuite) TestContainerResourceConstraints(c *gc.C) {
+	con := constraints.MustParse("mem-limit=2G")
+	resources, err := con.ContainerResourceConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 0)
+
+	con = constraints.MustParse("container-resources=nginx.mem-limit=512M,nginx.cpu-request=250,redis.ephemeral-storage-limit=1G")
+	resources, err = con.ContainerResourceConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, map[string]constraints.Value{
+		"nginx": {MemLimit: uint64p(512), CpuRequest: uint64p(250)},
+		"redis": {EphemeralStorageLimit: uint64p(1024)},
+	})
+
+	con = constraints.Value{ContainerResources: &[]string{"nginx.mem-limit=1G", "nginx.mem-limit=2G"}}
+	_, err = con.ContainerResourceConstraints()
+	c.Assert(err, gc.ErrorMatches, `container "nginx": bad "mem-limit" constraint: already set`)
 }
 
 func boolp(b bool) *bool {
@@ -684,6 +779,16 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Zones3", constraints.Value{Zones: &[]string{"az1", "az2"}}},
 	{"AllocatePublicIP1", constraints.Value{AllocatePublicIP: nil}},
 	{"AllocatePublicIP2", constraints.Value{AllocatePublicIP: boolp(true)}},
+	{"Spot1", constraints.Value{Spot: boolp(true)}},
+	{"Spot2", constraints.Value{Spot: boolp(false)}},
+	{"MemRequest1", constraints.Value{MemRequest: uint64p(0)}},
+	{"MemRequest2", constraints.Value{MemRequest: uint64p(512)}},
+	{"MemLimit", constraints.Value{MemLimit: uint64p(2048)}},
+	{"CpuRequest", constraints.Value{CpuRequest: uint64p(250)}},
+	{"CpuLimit", constraints.Value{CpuLimit: uint64p(1000)}},
+	{"EphemeralStorageLimit", constraints.Value{EphemeralStorageLimit: uint64p(4096)}},
+	{"ContainerResources1", constraints.Value{ContainerResources: &[]string{}}},
+	{"ContainerResources2", constraints.Value{ContainerResources: &[]string{"nginx.mem-limit=512M", "redis.cpu-request=250"}}},
 	{"All", constraints.Value{
 		Arch:             strp("i386"),
 		Container:        ctypep("lxd"),
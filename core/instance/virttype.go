@@ -0,0 +1,43 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instance
+
+import (
+	"github.com/juju/errors"
+)
+
+// VirtType represents the type of virtualisation used by an LXD instance,
+// as given by the virt-type constraint.
+type VirtType string
+
+const (
+	// InstanceTypeContainer is a system container, sharing the kernel
+	// of its host.
+	InstanceTypeContainer VirtType = "container"
+
+	// InstanceTypeVM is a virtual machine, running its own kernel.
+	InstanceTypeVM VirtType = "virtual-machine"
+)
+
+// DefaultInstanceType is the virtualisation type used when no virt-type
+// constraint is specified.
+const DefaultInstanceType = InstanceTypeContainer
+
+// ParseVirtType converts the specified string into a supported VirtType
+// or returns an error if the type is not known. An empty string is
+// parsed as the default type.
+func ParseVirtType(value string) (VirtType, error) {
+	switch VirtType(value) {
+	case "":
+		return DefaultInstanceType, nil
+	case InstanceTypeContainer, InstanceTypeVM:
+		return VirtType(value), nil
+	}
+	return "", errors.NotValidf("virtual type %q", value)
+}
+
+// String returns the string representation of the virtualisation type.
+func (v VirtType) String() string {
+	return string(v)
+}
@@ -0,0 +1,32 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instance_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+)
+
+type VirtTypeSuite struct{}
+
+var _ = gc.Suite(&VirtTypeSuite{})
+
+func (s *VirtTypeSuite) TestParseVirtType(c *gc.C) {
+	virtType, err := instance.ParseVirtType("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(virtType, gc.Equals, instance.InstanceTypeContainer)
+
+	virtType, err = instance.ParseVirtType("container")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(virtType, gc.Equals, instance.InstanceTypeContainer)
+
+	virtType, err = instance.ParseVirtType("virtual-machine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(virtType, gc.Equals, instance.InstanceTypeVM)
+
+	_, err = instance.ParseVirtType("kvm")
+	c.Assert(err, gc.ErrorMatches, `virtual type "kvm" not valid`)
+}
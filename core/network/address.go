@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	"sort"
+	"strings"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -182,7 +183,7 @@ func (a MachineAddress) String() string {
 
 // IP returns the net.IP representation of this address.
 func (a MachineAddress) IP() net.IP {
-	return net.ParseIP(a.Value)
+	return parseIP(a.Value)
 }
 
 // ValueForCIDR returns the value of the address combined with a subnet mask
@@ -275,7 +276,7 @@ func deriveScope(addr MachineAddress) Scope {
 	if addr.Type == HostName {
 		return addr.Scope
 	}
-	ip := net.ParseIP(addr.Value)
+	ip := parseIP(addr.Value)
 	if ip == nil {
 		return addr.Scope
 	}
@@ -658,8 +659,10 @@ func (sas SpaceAddresses) EqualTo(other SpaceAddresses) bool {
 }
 
 // DeriveAddressType attempts to detect the type of address given.
+// IPv6 addresses qualified with a zone, such as "fe80::1%eth0",
+// are recognised as IPv6 addresses.
 func DeriveAddressType(value string) AddressType {
-	ip := net.ParseIP(value)
+	ip := parseIP(value)
 	switch {
 	case ip == nil:
 		// TODO(gz): Check value is a valid hostname
@@ -673,6 +676,15 @@ func DeriveAddressType(value string) AddressType {
 	}
 }
 
+// parseIP parses the input value as an IP address, ignoring any IPv6 zone.
+// Nil is returned if the value is not an IP address.
+func parseIP(value string) net.IP {
+	if i := strings.LastIndex(value, "%"); i > 0 && strings.Contains(value[:i], ":") {
+		value = value[:i]
+	}
+	return net.ParseIP(value)
+}
+
 // ScopeMatchPublic is an address scope matching function for determining the
 // extent to which the input address' scope satisfies a requirement for public
 // accessibility.
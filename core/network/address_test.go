@@ -83,6 +83,8 @@ func (s *AddressSuite) TestNewScopedAddressIPv6(c *gc.C) {
 		{"2001:db8::1", network.ScopePublic},
 		// link-local
 		{"fe80::1", network.ScopeLinkLocal},
+		// link-local with a zone
+		{"fe80::1%eth0", network.ScopeLinkLocal},
 		// unique local address (ULA) - first group
 		{"fc00::1", network.ScopeCloudLocal},
 		// unique local address (ULA) - second group
@@ -453,6 +455,13 @@ var selectInternalTests = []selectTest{{
 		network.NewScopedSpaceAddress("fc00::1", network.ScopeCloudLocal),
 	},
 	2,
+}, {
+	"a global IPv6 address is preferred to a zoned link-local address",
+	[]network.SpaceAddress{
+		network.NewSpaceAddress("fe80::1%eth0"),
+		network.NewSpaceAddress("2001:db8::1"),
+	},
+	1,
 }}
 
 func (s *AddressSuite) TestSelectInternalAddress(c *gc.C) {
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/EvilSuperstars/go-cidrman"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 
@@ -203,6 +204,18 @@ func (rules IngressRules) cidrsByPortRange() map[network.PortRange]set.Strings {
 	return result
 }
 
+// MergeByPortRange returns a sorted copy of the ingress rule list where
+// the source CIDRs of rules for the same port range are combined into a
+// single rule, which is how providers report the rules of an instance.
+func (rules IngressRules) MergeByPortRange() IngressRules {
+	var merged IngressRules
+	for portRange, cidrs := range rules.cidrsByPortRange() {
+		merged = append(merged, NewIngressRule(portRange, cidrs.Values()...))
+	}
+	merged.Sort()
+	return merged
+}
+
 // UniqueRules returns a copy of the ingress rule list after removing any
 // duplicate entries.
 func (rules IngressRules) UniqueRules() IngressRules {
@@ -222,6 +235,86 @@ nextRule:
 	return uniqueRules
 }
 
+// ExposedIngressRules returns the ingress rules that allow access to the
+// open port ranges of a unit, grouped by endpoint, from the source CIDRs
+// of each exposed endpoint. The CIDRs for the wildcard ("") endpoint apply
+// to all endpoints that are not exposed in their own right. Endpoints
+// exposed to no CIDRs yield no rules.
+func ExposedIngressRules(openPortRanges network.GroupedPortRanges, exposedCIDRs map[string]set.Strings) IngressRules {
+	var rules IngressRules
+	for exposedEndpoint, srcCIDRs := range exposedCIDRs {
+		if len(srcCIDRs) == 0 {
+			continue // no rules required
+		}
+
+		// If this is a named (i.e. not the wildcard) endpoint, look up
+		// the port ranges opened for *all* endpoints as well as for
+		// that endpoint name specifically, and create ingress rules.
+		if exposedEndpoint != "" {
+			for _, portRange := range openPortRanges[exposedEndpoint] { // ports opened for this endpoint
+				rules = append(rules, NewIngressRule(portRange, srcCIDRs.Values()...))
+			}
+			for _, portRange := range openPortRanges[""] { // ports opened for ALL endpoints
+				rules = append(rules, NewIngressRule(portRange, srcCIDRs.Values()...))
+			}
+			continue
+		}
+
+		// Create ingress rules for all endpoints except the ones that
+		// have their own dedicated entry in the exposed endpoints map.
+		for endpointName, portRanges := range openPortRanges {
+			// This non-wildcard endpoint has an entry in the exposed
+			// endpoints list that override the global expose-all
+			// entry so we should skip it.
+			if _, hasExposeOverride := exposedCIDRs[endpointName]; hasExposeOverride && endpointName != "" {
+				continue
+			}
+
+			for _, portRange := range portRanges {
+				rules = append(rules, NewIngressRule(portRange, srcCIDRs.Values()...))
+			}
+		}
+	}
+	return rules
+}
+
+// MaxRelationCIDRs is the number of source CIDRs beyond which the
+// ingress CIDRs of cross-model relations are consolidated.
+// TODO(wallyworld) - consider making this configurable.
+const MaxRelationCIDRs = 20
+
+// ConsolidateRelationCIDRs returns the source CIDRs to use for ingress
+// required by cross-model relations. If there are more than
+// MaxRelationCIDRs, they are merged; if there are still too many, the
+// CIDRs returned by offerWhitelist are used instead, and failing that,
+// all networks.
+func ConsolidateRelationCIDRs(cidrs set.Strings, offerWhitelist func() ([]string, error)) (set.Strings, error) {
+	if cidrs.Size() > MaxRelationCIDRs {
+		// First, try and merge the cidrs.
+		merged, err := cidrman.MergeCIDRs(cidrs.Values())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs = set.NewStrings(merged...)
+	}
+
+	// If there's still too many after merging, look for any firewall whitelist.
+	if cidrs.Size() > MaxRelationCIDRs {
+		whitelist, err := offerWhitelist()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs = set.NewStrings(whitelist...)
+
+		// No relevant firewall rule exists, so go public.
+		if cidrs.Size() == 0 {
+			cidrs.Add(AllNetworksIPV4CIDR)
+			cidrs.Add(AllNetworksIPV6CIDR)
+		}
+	}
+	return cidrs, nil
+}
+
 // RemoveCIDRsMatchingAddressType returns a new list of rules where any CIDR
 // whose address type corresponds to the specified AddressType argument has
 // been removed.
@@ -4,6 +4,10 @@
 package firewall
 
 import (
+	"fmt"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -273,3 +277,87 @@ func (IngressRuleSuite) TestRemoveCIDRsMatchingAddressType(c *gc.C) {
 		NewIngressRule(network.MustParsePortRange("81/tcp"), "35.187.1.35/32"),
 	})
 }
+
+func (IngressRuleSuite) TestMergeByPortRange(c *gc.C) {
+	in := IngressRules{
+		NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/24"),
+		NewIngressRule(network.MustParsePortRange("22/tcp"), "10.0.0.0/24"),
+		NewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.0.0/24", "10.0.0.0/24"),
+	}
+	c.Assert(in.MergeByPortRange(), jc.DeepEquals, IngressRules{
+		NewIngressRule(network.MustParsePortRange("22/tcp"), "10.0.0.0/24"),
+		NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/24", "192.168.0.0/24"),
+	})
+}
+
+func (IngressRuleSuite) TestExposedIngressRules(c *gc.C) {
+	openPortRanges := network.GroupedPortRanges{
+		"":        {network.MustParsePortRange("22/tcp")},
+		"website": {network.MustParsePortRange("80/tcp")},
+		"metrics": {network.MustParsePortRange("9100/tcp")},
+	}
+	exposedCIDRs := map[string]set.Strings{
+		"":        set.NewStrings("10.0.0.0/24"),
+		"website": set.NewStrings("0.0.0.0/0"),
+		"metrics": set.NewStrings(),
+	}
+
+	rules := ExposedIngressRules(openPortRanges, exposedCIDRs).UniqueRules()
+	rules.Sort()
+	c.Assert(rules, gc.DeepEquals, IngressRules{
+		NewIngressRule(network.MustParsePortRange("22/tcp"), "0.0.0.0/0"),
+		NewIngressRule(network.MustParsePortRange("22/tcp"), "10.0.0.0/24"),
+		NewIngressRule(network.MustParsePortRange("80/tcp"), "0.0.0.0/0"),
+	})
+}
+
+func (IngressRuleSuite) TestExposedIngressRulesNotExposed(c *gc.C) {
+	openPortRanges := network.GroupedPortRanges{
+		"": {network.MustParsePortRange("22/tcp")},
+	}
+	c.Assert(ExposedIngressRules(openPortRanges, nil), gc.HasLen, 0)
+}
+
+func (IngressRuleSuite) TestConsolidateRelationCIDRs(c *gc.C) {
+	whitelist := func() ([]string, error) {
+		c.Fatalf("unexpected whitelist lookup")
+		return nil, nil
+	}
+	cidrs, err := ConsolidateRelationCIDRs(set.NewStrings("10.0.0.1/32", "10.0.0.2/32"), whitelist)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs.SortedValues(), jc.DeepEquals, []string{"10.0.0.1/32", "10.0.0.2/32"})
+}
+
+func (IngressRuleSuite) TestConsolidateRelationCIDRsMerged(c *gc.C) {
+	in := set.NewStrings()
+	for i := 0; i < 32; i++ {
+		in.Add(fmt.Sprintf("10.0.0.%d/32", i))
+	}
+	cidrs, err := ConsolidateRelationCIDRs(in, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs.SortedValues(), jc.DeepEquals, []string{"10.0.0.0/27"})
+}
+
+func (IngressRuleSuite) TestConsolidateRelationCIDRsWhitelist(c *gc.C) {
+	in := set.NewStrings()
+	for i := 0; i < 32; i++ {
+		in.Add(fmt.Sprintf("10.0.%d.1/32", i))
+	}
+
+	cidrs, err := ConsolidateRelationCIDRs(in, func() ([]string, error) {
+		return []string{"10.0.0.0/16"}, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs.SortedValues(), jc.DeepEquals, []string{"10.0.0.0/16"})
+
+	cidrs, err = ConsolidateRelationCIDRs(in, func() ([]string, error) {
+		return nil, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs.SortedValues(), jc.DeepEquals, []string{AllNetworksIPV4CIDR, AllNetworksIPV6CIDR})
+
+	_, err = ConsolidateRelationCIDRs(in, func() ([]string, error) {
+		return nil, errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -107,6 +107,12 @@ const (
 	// error (e.g it loses contact with the Juju server) moves it to a different state.
 	Idle Status = "idle"
 
+	// Paused is set when:
+	// Hook execution has been paused by the operator with "juju pause-unit".
+	// The agent finishes any operation already under way, but starts no new
+	// ones until it is resumed.
+	Paused Status = "paused"
+
 	// Failed is set when:
 	// The unit agent has failed in some way,eg the agent ought to be signalling
 	// activity, but it cannot be detected. It might also be that the unit agent
@@ -230,6 +236,10 @@ const (
 	Provisioning      Status = "allocating"
 	Running           Status = "running"
 	ProvisioningError Status = "provisioning error"
+
+	// Interrupted indicates that the provider reclaimed a spot or
+	// preemptible instance.
+	Interrupted Status = "interrupted"
 )
 
 // ModificationStatus
@@ -266,6 +276,8 @@ func (status Status) KnownInstanceStatus() bool {
 		ProvisioningError,
 		Allocating,
 		Running,
+		Interrupted,
+		Terminated,
 		Error,
 		Unknown:
 		return true
@@ -284,7 +296,8 @@ func (status Status) KnownAgentStatus() bool {
 		Failed,
 		Rebooting,
 		Executing,
-		Idle:
+		Idle,
+		Paused:
 		return true
 	}
 	return false
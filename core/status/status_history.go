@@ -94,6 +94,11 @@ const (
 	KindContainerInstance HistoryKind = "container"
 	// KindContainer represents an entry for a container agent.
 	KindContainer HistoryKind = "juju-container"
+	// KindApplication represents an application and its k8s events combined.
+	KindApplication HistoryKind = "application"
+	// KindK8sEvent represents an event reported by Kubernetes for a unit
+	// or an application.
+	KindK8sEvent HistoryKind = "k8s-event"
 )
 
 // String returns a string representation of the HistoryKind.
@@ -106,7 +111,8 @@ func (k HistoryKind) Valid() bool {
 	switch k {
 	case KindUnit, KindUnitAgent, KindWorkload,
 		KindMachineInstance, KindMachine,
-		KindContainerInstance, KindContainer:
+		KindContainerInstance, KindContainer,
+		KindApplication, KindK8sEvent:
 		return true
 	}
 	return false
@@ -122,5 +128,7 @@ func AllHistoryKind() map[HistoryKind]string {
 		KindMachine:           "status of the agent that is managing a machine",
 		KindContainerInstance: "statuses from the agent that is managing containers",
 		KindContainer:         "statuses from the containers only and not their host machines",
+		KindApplication:       "statuses for specified application and its k8s events",
+		KindK8sEvent:          "events reported by Kubernetes for a unit or an application",
 	}
 }
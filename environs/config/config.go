@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/network/netplan"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -256,6 +257,22 @@ const (
 	// CharmHubURLKey is the key for the url to use for CharmHub API calls
 	CharmHubURLKey = "charm-hub-url"
 
+	// HostFirewallBackendKey is the key for the tool used to manage the
+	// firewall of machines on clouds without per-instance security groups.
+	HostFirewallBackendKey = "host-firewall-backend"
+
+	// ReplaceInterruptedMachinesKey is the key for whether machines whose
+	// spot or preemptible instances are interrupted are replaced.
+	ReplaceInterruptedMachinesKey = "replace-interrupted-machines"
+
+	// ReplaceLostMachinesKey is the key for whether machines whose
+	// instances have been terminated are replaced.
+	ReplaceLostMachinesKey = "replace-lost-machines"
+
+	// NetworkTemplateKey is the key for the bonds and VLANs to be
+	// created on machines using netplan.
+	NetworkTemplateKey = "network-template"
+
 	// ModeKey is the key for defining the mode that a given model should be
 	// using.
 	// It is expected that when in a different mode, Juju will perform in a
@@ -707,6 +724,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[NetworkTemplateKey].(string); ok && v != "" {
+		if _, err := netplan.ParseTemplate([]byte(v)); err != nil {
+			return errors.Annotate(err, "invalid network-template")
+		}
+	}
+
 	if v, ok := cfg.defined[ContainerNetworkingMethod].(string); ok {
 		switch v {
 		case "fan":
@@ -1168,6 +1191,42 @@ func (c *Config) FirewallMode() string {
 	return c.mustString("firewall-mode")
 }
 
+// HostFirewallBackend returns the tool used to manage the firewall of
+// machines on clouds without per-instance security groups: "iptables",
+// "nftables", or "auto" (the default) to detect it on each machine.
+func (c *Config) HostFirewallBackend() string {
+	if backend := c.asString(HostFirewallBackendKey); backend != "" {
+		return backend
+	}
+	return "auto"
+}
+
+// ReplaceInterruptedMachines reports whether a machine whose spot or
+// preemptible instance is reclaimed by the cloud should automatically be
+// replaced by a new machine hosting the same applications.
+func (c *Config) ReplaceInterruptedMachines() bool {
+	v, _ := c.defined[ReplaceInterruptedMachinesKey].(bool)
+	return v
+}
+
+// ReplaceLostMachines reports whether a machine whose instance is no
+// longer known to the cloud should automatically be replaced by a new
+// machine hosting the same applications.
+func (c *Config) ReplaceLostMachines() bool {
+	v, _ := c.defined[ReplaceLostMachinesKey].(bool)
+	return v
+}
+
+// NetworkTemplate returns the bonds and VLANs to be created on machines
+// using netplan, or nil if none are configured.
+func (c *Config) NetworkTemplate() (*netplan.Template, error) {
+	v := c.asString(NetworkTemplateKey)
+	if v == "" {
+		return nil, nil
+	}
+	return netplan.ParseTemplate([]byte(v))
+}
+
 // AgentVersion returns the proposed version number for the agent tools,
 // and whether it has been set. Once an environment is bootstrapped, this
 // must always be valid.
@@ -1662,6 +1721,10 @@ var alwaysOptional = schema.Defaults{
 	DefaultSpace:                  schema.Omit,
 	LXDSnapChannel:                schema.Omit,
 	CharmHubURLKey:                schema.Omit,
+	HostFirewallBackendKey:        schema.Omit,
+	ReplaceInterruptedMachinesKey: schema.Omit,
+	ReplaceLostMachinesKey:        schema.Omit,
+	NetworkTemplateKey:            schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -2080,6 +2143,40 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	HostFirewallBackendKey: {
+		Description: `The tool used to manage the firewall of machines on clouds
+without per-instance security groups.
+
+'auto' uses nftables on machines where iptables is absent or is itself
+backed by nftables, and iptables otherwise.
+
+'iptables' and 'nftables' force the use of the respective tool.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{"auto", "iptables", "nftables"},
+		Group:  environschema.EnvironGroup,
+	},
+	ReplaceInterruptedMachinesKey: {
+		Description: `Whether a machine whose spot or preemptible instance is reclaimed
+by the cloud is replaced by a new machine with the same constraints,
+hosting new units of the same applications.`,
+		Type:  environschema.Tbool,
+		Group: environschema.EnvironGroup,
+	},
+	ReplaceLostMachinesKey: {
+		Description: `Whether a machine whose instance has been terminated is replaced by
+a new machine with the same constraints and placement, hosting new units
+of the same applications. Applications may also opt in individually.`,
+		Type:  environschema.Tbool,
+		Group: environschema.EnvironGroup,
+	},
+	NetworkTemplateKey: {
+		Description: `A YAML netplan fragment of bonds and VLANs to be created on machines
+other than controllers and containers, naming devices as they appear on the
+machine. Devices that already exist are left alone; if connectivity to the controller is lost once the new
+configuration is applied, the previous configuration is restored.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
 	TestModeKey: {
 		Description: `Whether the model is intended for testing.
 If true, accessing the charm store does not affect statistical
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/utils/v2/winrm"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 )
 
 var (
@@ -51,6 +52,22 @@ type ProvisionMachineArgs struct {
 	// ubuntu user's ~/.ssh/authorized_keys.
 	AuthorizedKeys string
 
+	// IdentityFile, if non-empty, is the path to the private key used to
+	// connect to the host over SSH.
+	IdentityFile string
+
+	// Series, if non-empty, is the series the host is expected to be
+	// running. Provisioning fails if the detected series differs.
+	Series string
+
+	// Constraints are recorded against the new machine, so that it can
+	// be targeted by tags and spaces when deploying.
+	Constraints constraints.Value
+
+	// NonInteractive, if true, prevents provisioning from prompting for
+	// passwords, so that it fails instead when there is nobody to answer.
+	NonInteractive bool
+
 	// WinRM contains keys and client interface api with the remote windows machine
 	WinRM WinRMArgs
 
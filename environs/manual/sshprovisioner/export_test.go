@@ -7,3 +7,9 @@ package sshprovisioner
 const (
 	DetectionScript = detectionScript
 )
+
+// InitUbuntuUserNonInteractive calls initUbuntuUser without allowing
+// password prompts.
+func InitUbuntuUserNonInteractive(host, login, authorizedKeys string) error {
+	return initUbuntuUser(host, login, authorizedKeys, "", false, nil, nil)
+}
@@ -5,6 +5,9 @@
 package sshprovisioner_test
 
 import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	jc "github.com/juju/testing/checkers"
@@ -168,6 +171,27 @@ func (s *initialisationSuite) TestInitUbuntuUserExisting(c *gc.C) {
 	sshprovisioner.InitUbuntuUser("testhost", "testuser", "", nil, nil)
 }
 
+func (s *initialisationSuite) TestInitUbuntuUserNonInteractive(c *gc.C) {
+	// Record the arguments of each ssh command, failing them all.
+	fakebin := c.MkDir()
+	argsFile := filepath.Join(fakebin, "ssh.args")
+	script := fmt.Sprintf("#!/bin/bash --norc\necho \"$*\" >> %s\nexit 1\n", argsFile)
+	err := ioutil.WriteFile(filepath.Join(fakebin, "ssh"), []byte(script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchEnvPathPrepend(fakebin)
+
+	err = sshprovisioner.InitUbuntuUserNonInteractive("testhost", "testuser", "")
+	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 1")
+
+	data, err := ioutil.ReadFile(argsFile)
+	c.Assert(err, jc.ErrorIsNil)
+	args := string(data)
+	c.Check(args, jc.Contains, "ubuntu@testhost sudo -n true")
+	c.Check(args, jc.Contains, "testuser@testhost sudo -n /bin/bash -c")
+	c.Check(strings.Count(args, "-o PasswordAuthentication no"), gc.Equals, 2)
+	c.Check(args, gc.Not(jc.Contains), "-t -t")
+}
+
 func (s *initialisationSuite) TestInitUbuntuUserError(c *gc.C) {
 	defer installFakeSSH(c, "", []string{"", "failed to create ubuntu user"}, 123)()
 	defer installFakeSSH(c, "", "", 1)() // simulate failure of ubuntu@ login
@@ -80,6 +80,20 @@ func (s *provisionerSuite) TestProvisionMachine(c *gc.C) {
 	envtesting.AssertUploadFakeToolsVersions(c, s.DefaultToolsStorage, "released", "released", binVersion)
 	envtools.DefaultBaseURL = defaultToolsURL
 
+	// Provisioning fails if the host is not running the expected series.
+	args.Series = "quantal"
+	restore := fakeSSH{
+		Series:             series,
+		Arch:               arch,
+		InitUbuntuUser:     true,
+		SkipProvisionAgent: true,
+	}.install(c)
+	machineId, err = sshprovisioner.ProvisionMachine(args)
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`host is running series %q, expected "quantal"`, series))
+	c.Assert(machineId, gc.Equals, "")
+	restore.Restore()
+	args.Series = ""
+
 	for i, errorCode := range []int{255, 0} {
 		c.Logf("test %d: code %d", i, errorCode)
 		defer fakeSSH{
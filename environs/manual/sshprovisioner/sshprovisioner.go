@@ -40,6 +40,13 @@ import (
 // authorizedKeys may be empty, in which case the file
 // will be created and left empty.
 func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io.Writer) error {
+	return initUbuntuUser(host, login, authorizedKeys, "", true, read, write)
+}
+
+// initUbuntuUser is InitUbuntuUser, connecting with the given private
+// key if it is not empty. If interactive is false, password
+// authentication is disabled and sudo fails rather than prompting.
+func initUbuntuUser(host, login, authorizedKeys, identityFile string, interactive bool, read io.Reader, write io.Writer) error {
 	logger.Infof("initialising %q, user %q", host, login)
 
 	// To avoid unnecessary prompting for the specified login,
@@ -49,7 +56,7 @@ func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io
 	//
 	// Note that we explicitly do not allocate a PTY, so we
 	// get a failure if sudo prompts.
-	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, sshOptions(identityFile))
 	if cmd.Run() == nil {
 		logger.Infof("ubuntu user is already initialised")
 		return nil
@@ -62,9 +69,18 @@ func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io
 	}
 	script := fmt.Sprintf(initUbuntuScript, utils.ShQuote(authorizedKeys))
 	var options ssh.Options
-	options.AllowPasswordAuthentication()
-	options.EnablePTY()
-	cmd = ssh.Command(host, []string{"sudo", "/bin/bash -c " + utils.ShQuote(script)}, &options)
+	if identityFile != "" {
+		options.SetIdentities(identityFile)
+	}
+	sudo := []string{"sudo"}
+	if interactive {
+		options.AllowPasswordAuthentication()
+		options.EnablePTY()
+	} else {
+		// There is nobody to answer a password prompt.
+		sudo = append(sudo, "-n")
+	}
+	cmd = ssh.Command(host, append(sudo, "/bin/bash -c "+utils.ShQuote(script)), &options)
 	var stderr bytes.Buffer
 	cmd.Stdin = read
 	cmd.Stdout = write
@@ -93,14 +109,27 @@ if [ ! -z "$authorized_keys" ]; then
     su ubuntu -c 'printf "%%s\n" "$authorized_keys" >> ~/.ssh/authorized_keys'
 fi`
 
+// sshOptions returns the options used to connect to a host with the
+// given private key, or nil if the default identities are to be used.
+func sshOptions(identityFile string) *ssh.Options {
+	if identityFile == "" {
+		return nil
+	}
+	var options ssh.Options
+	options.SetIdentities(identityFile)
+	return &options
+}
+
 // DetectSeriesAndHardwareCharacteristics detects the OS
 // series and hardware characteristics of the remote machine
 // by connecting to the machine and executing a bash script.
-var DetectSeriesAndHardwareCharacteristics = detectSeriesAndHardwareCharacteristics
+var DetectSeriesAndHardwareCharacteristics = func(host string) (instance.HardwareCharacteristics, string, error) {
+	return detectSeriesAndHardwareCharacteristics(host, "")
+}
 
-func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCharacteristics, series string, err error) {
+func detectSeriesAndHardwareCharacteristics(host, identityFile string) (hc instance.HardwareCharacteristics, series string, err error) {
 	logger.Infof("Detecting series and characteristics on %s", host)
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, sshOptions(identityFile))
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -161,14 +190,16 @@ func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCh
 
 // CheckProvisioned checks if any juju init service already
 // exist on the host machine.
-var CheckProvisioned = checkProvisioned
+var CheckProvisioned = func(host string) (bool, error) {
+	return checkProvisioned(host, "")
+}
 
-func checkProvisioned(host string) (bool, error) {
+func checkProvisioned(host, identityFile string) (bool, error) {
 	logger.Infof("Checking if %s is already provisioned", host)
 
 	script := service.ListServicesScript()
 
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, sshOptions(identityFile))
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -210,7 +241,7 @@ cat /proc/cpuinfo`
 // The hostname supplied should not include a username.
 // If we can, we will reverse lookup the hostname by its IP address, and use
 // the DNS resolved name, rather than the name that was supplied
-func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
+func gatherMachineParams(hostname, identityFile string) (*params.AddMachineParams, error) {
 
 	// Generate a unique nonce for the machine.
 	uuid, err := utils.NewUUID()
@@ -223,7 +254,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, errors.Annotatef(err, "failed to compute public address for %q", hostname)
 	}
 
-	provisioned, err := checkProvisioned(hostname)
+	provisioned, err := checkProvisioned(hostname, identityFile)
 	if err != nil {
 		return nil, errors.Annotatef(err, "error checking if provisioned")
 	}
@@ -231,7 +262,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, manual.ErrProvisioned
 	}
 
-	hc, series, err := DetectSeriesAndHardwareCharacteristics(hostname)
+	hc, series, err := detectSeriesAndHardwareCharacteristics(hostname, identityFile)
 	if err != nil {
 		return nil, errors.Annotatef(err, "error detecting linux hardware characteristics")
 	}
@@ -254,9 +285,10 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 	return machineParams, nil
 }
 
-func runProvisionScript(script, host string, progressWriter io.Writer) error {
+func runProvisionScript(script, host, identityFile string, progressWriter io.Writer) error {
 	params := sshinit.ConfigureParams{
 		Host:           "ubuntu@" + host,
+		SSHOptions:     sshOptions(identityFile),
 		ProgressWriter: progressWriter,
 	}
 	return sshinit.RunConfigureScript(script, params)
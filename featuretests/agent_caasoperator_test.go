@@ -260,6 +260,10 @@ func (*mockExecutor) Copy(params exec.CopyParams, cancel <-chan struct{}) error
 	return errors.NotImplementedf("exec copy")
 }
 
+func (*mockExecutor) Debug(params exec.DebugParams, cancel <-chan struct{}) error {
+	return errors.NotImplementedf("exec debug")
+}
+
 func (m *mockExecutor) NameSpace() string {
 	return "test"
 }
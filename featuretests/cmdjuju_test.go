@@ -115,6 +115,13 @@ func (s *cmdJujuSuite) TestApplicationUnset(c *gc.C) {
 func (s *cmdJujuSuite) TestApplicationGetIAASModel(c *gc.C) {
 	expected := `application: dummy-application
 application-config:
+  replace-lost-machines:
+    default: false
+    description: Are machines hosting this application replaced when their instances
+      are lost
+    source: default
+    type: bool
+    value: false
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -164,11 +171,45 @@ application-config:
     source: default
     type: string
     value: /
+  juju-autoscale-max-units:
+    description: the maximum number of units of an autoscaled application, setting
+      this enables autoscaling
+    source: unset
+    type: int
+  juju-autoscale-metric:
+    description: a custom metric to autoscale an application on, as <metric>=<target
+      average value>
+    source: unset
+    type: string
+  juju-autoscale-min-units:
+    description: the minimum number of units of an autoscaled application
+    source: unset
+    type: int
+  juju-autoscale-target-cpu:
+    description: the target average CPU utilisation of an autoscaled application,
+      as a percentage
+    source: unset
+    type: int
+  juju-autoscale-target-memory:
+    description: the target average memory utilisation of an autoscaled application,
+      as a percentage
+    source: unset
+    type: int
   juju-external-hostname:
     description: the external hostname of an exposed application
     source: user
     type: string
     value: ext-host
+  juju-max-unavailable-units:
+    description: the maximum number of units which may be unavailable at once during
+      voluntary disruptions
+    source: unset
+    type: int
+  juju-spread-units:
+    description: a comma separated list of the topologies (node, zone) to spread units
+      across
+    source: unset
+    type: string
   kubernetes-ingress-allow-http:
     default: false
     description: whether to allow HTTP traffic to the ingress controller
@@ -282,6 +323,13 @@ settings:
 func (s *cmdJujuSuite) TestApplicationGetWeirdYAML(c *gc.C) {
 	expected := `application: yaml-config
 application-config:
+  replace-lost-machines:
+    default: false
+    description: Are machines hosting this application replaced when their instances
+      are lost
+    source: default
+    type: bool
+    value: false
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -251,6 +251,13 @@ func (s *MongoSuite) TestSelectPeerAddress(c *gc.C) {
 	c.Assert(address, gc.Equals, "10.0.0.1")
 }
 
+func (s *MongoSuite) TestSelectPeerAddressIPv6Only(c *gc.C) {
+	addresses := network.NewProviderAddresses("::1", "fe80::1%eth0", "2001:db8::5")
+
+	address := mongo.SelectPeerAddress(addresses)
+	c.Assert(address, gc.Equals, "2001:db8::5")
+}
+
 func (s *MongoSuite) TestGenerateSharedSecret(c *gc.C) {
 	secret, err := mongo.GenerateSharedSecret()
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,74 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewall abstracts over the tools used by Juju to manage
+// the firewall of a machine from the outside, for clouds without support
+// for per-instance security groups.
+package hostfirewall
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/network/iptables"
+	"github.com/juju/juju/network/nftables"
+)
+
+const (
+	// Auto selects the backend by inspecting the machine.
+	Auto = "auto"
+
+	// IPTables selects the iptables backend.
+	IPTables = "iptables"
+
+	// NFTables selects the nftables backend.
+	NFTables = "nftables"
+)
+
+// DetectCommand is a bash command which prints the name of the backend
+// to use on the machine it is run on. nftables is preferred when the nft
+// tool is installed and iptables is either absent or itself a front end
+// to nftables, so that Juju's rules are never split across both.
+const DetectCommand = `if command -v nft >/dev/null 2>&1 && ` +
+	`{ ! command -v iptables >/dev/null 2>&1 || iptables --version | grep -q nf_tables; }; ` +
+	`then echo nftables; else echo iptables; fi`
+
+// Backend renders the bash commands required to manage the firewall
+// of a machine with a particular tool.
+type Backend interface {
+	// Name returns the name of the backend.
+	Name() string
+
+	// DropCommands returns the commands which drop all new connections
+	// to the specified address or interface, except for TCP connections
+	// to the specified ports.
+	DropCommands(addr, iface string, exceptPorts []int) []string
+
+	// ListIngressRulesCommand returns the command which lists the
+	// ingress rules, in the format expected by ParseIngressRules.
+	ListIngressRulesCommand() string
+
+	// ParseIngressRules parses the output of ListIngressRulesCommand.
+	ParseIngressRules(r io.Reader) (firewall.IngressRules, error)
+
+	// ChangeIngressRulesCommands returns the commands which open (insert)
+	// or close the specified ingress rules for the address, given the
+	// rules currently in place.
+	ChangeIngressRulesCommands(addr string, insert bool, rules, current firewall.IngressRules) []string
+}
+
+// NewBackend returns the backend with the specified name. The Auto
+// backend cannot be returned; the output of DetectCommand should be
+// used to resolve it instead.
+func NewBackend(name string) (Backend, error) {
+	switch strings.TrimSpace(name) {
+	case IPTables:
+		return iptables.Backend{}, nil
+	case NFTables:
+		return nftables.Backend{}, nil
+	}
+	return nil, errors.NotValidf("host firewall backend %q", name)
+}
@@ -0,0 +1,41 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewall_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network/hostfirewall"
+	"github.com/juju/juju/network/iptables"
+	"github.com/juju/juju/network/nftables"
+)
+
+type HostFirewallSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HostFirewallSuite{})
+
+func (*HostFirewallSuite) TestNewBackend(c *gc.C) {
+	backend, err := hostfirewall.NewBackend(hostfirewall.IPTables)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(backend, gc.Equals, iptables.Backend{})
+	c.Assert(backend.Name(), gc.Equals, "iptables")
+
+	// The output of the detect command is newline terminated.
+	backend, err = hostfirewall.NewBackend("nftables\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(backend, gc.Equals, nftables.Backend{})
+	c.Assert(backend.Name(), gc.Equals, "nftables")
+}
+
+func (*HostFirewallSuite) TestNewBackendInvalid(c *gc.C) {
+	_, err := hostfirewall.NewBackend(hostfirewall.Auto)
+	c.Assert(err, gc.ErrorMatches, `host firewall backend "auto" not valid`)
+
+	_, err = hostfirewall.NewBackend("ufw")
+	c.Assert(err, gc.ErrorMatches, `host firewall backend "ufw" not valid`)
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package iptables
+
+import (
+	"io"
+
+	"github.com/juju/juju/core/network/firewall"
+)
+
+// Backend renders the bash commands required to manage the firewall
+// of a machine using iptables.
+type Backend struct{}
+
+// Name returns the name of the backend.
+func (Backend) Name() string {
+	return "iptables"
+}
+
+// DropCommands returns the commands which drop all new connections to
+// the specified address or interface, except for TCP connections to the
+// specified ports.
+func (Backend) DropCommands(addr, iface string, exceptPorts []int) []string {
+	cmds := []string{
+		DropCommand{DestinationAddress: addr, Interface: iface}.Render(),
+	}
+	for _, port := range exceptPorts {
+		cmds = append(cmds, AcceptInternalCommand{
+			Protocol:           "tcp",
+			DestinationAddress: addr,
+			DestinationPort:    port,
+		}.Render())
+	}
+	return cmds
+}
+
+// ListIngressRulesCommand returns the command which lists the INPUT
+// chain, in the format expected by ParseIngressRules.
+func (Backend) ListIngressRulesCommand() string {
+	return "sudo iptables -L INPUT -n"
+}
+
+// ParseIngressRules parses the output of ListIngressRulesCommand.
+func (Backend) ParseIngressRules(r io.Reader) (firewall.IngressRules, error) {
+	return ParseIngressRules(r)
+}
+
+// ChangeIngressRulesCommands returns the commands which open (insert) or
+// close the specified ingress rules for the address. The current rules
+// are not needed, as each command checks for the existing rule itself.
+func (Backend) ChangeIngressRulesCommands(
+	addr string, insert bool, rules, _ firewall.IngressRules,
+) []string {
+	var cmds []string
+	for _, rule := range rules {
+		cmds = append(cmds, IngressRuleCommand{
+			Rule:               rule,
+			DestinationAddress: addr,
+			Delete:             !insert,
+		}.Render())
+	}
+	return cmds
+}
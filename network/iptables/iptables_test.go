@@ -101,6 +101,19 @@ func (*IptablesSuite) TestIngressRuleCommand(c *gc.C) {
 	)
 }
 
+func (*IptablesSuite) TestBackendCommands(c *gc.C) {
+	backend := iptables.Backend{}
+	c.Assert(backend.DropCommands("1.2.3.4", "", []int{22}), jc.DeepEquals, []string{
+		"sudo iptables -I INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal' -d 1.2.3.4",
+		"sudo iptables -I INPUT -j ACCEPT -m comment --comment 'juju internal' -p tcp -d 1.2.3.4 --dport 22",
+	})
+
+	rule := firewall.NewIngressRule(network.MustParsePortRange("53/udp"))
+	c.Assert(backend.ChangeIngressRulesCommands("", false, firewall.IngressRules{rule}, nil), jc.DeepEquals, []string{
+		iptables.IngressRuleCommand{Rule: rule, Delete: true}.Render(),
+	})
+}
+
 func (*IptablesSuite) TestParseIngressRulesEmpty(c *gc.C) {
 	assertParseIngressRules(c, ``, firewall.IngressRules{})
 }
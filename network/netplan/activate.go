@@ -111,3 +111,86 @@ func BridgeAndActivate(params ActivationParams) (*ActivationResult, error) {
 	}
 	return nil, nil
 }
+
+// TemplateActivationParams contains options to use when applying a
+// network template.
+type TemplateActivationParams struct {
+	Clock     clock.Clock
+	Template  *Template
+	RunPrefix string
+	Directory string
+	Timeout   time.Duration
+
+	// CheckConnectivity is called once the new configuration has been
+	// applied. If it returns an error the previous configuration is
+	// restored.
+	CheckConnectivity func() error
+}
+
+// ApplyTemplateAndActivate will parse a set of netplan yaml files in a
+// directory, add the bonds and VLANs of the template that are missing,
+// then reconfigure the network. If the network cannot be reconfigured,
+// or connectivity is lost afterwards, the previous configuration is
+// restored and applied again.
+func ApplyTemplateAndActivate(params TemplateActivationParams) (*ActivationResult, error) {
+	if params.Template == nil {
+		return nil, errors.Errorf("no template specified")
+	}
+	netplan, err := ReadDirectory(params.Directory)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := netplan.ApplyTemplate(params.Template)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !changed {
+		return nil, nil
+	}
+	if _, err = netplan.Write(""); err != nil {
+		return nil, err
+	}
+	if err = netplan.MoveYamlsToBak(); err != nil {
+		netplan.Rollback()
+		return nil, err
+	}
+
+	rollback := func() {
+		netplan.Rollback()
+		if result, err := applyNetplan(params.RunPrefix, params.Clock, params.Timeout); err != nil {
+			logger.Errorf("cannot restore network configuration: %v", err)
+		} else if result.Code != 0 {
+			logger.Errorf("cannot restore network configuration: error code %d: %s", result.Code, result.Stderr)
+		}
+	}
+	result, err := applyNetplan(params.RunPrefix, params.Clock, params.Timeout)
+	if err != nil {
+		rollback()
+		return nil, errors.Errorf("network template activation error: %s", err)
+	}
+	if result.Code != 0 {
+		rollback()
+		return &result, errors.Errorf("network template activation error code %d", result.Code)
+	}
+	if params.CheckConnectivity != nil {
+		if err := params.CheckConnectivity(); err != nil {
+			rollback()
+			return &result, errors.Annotate(err, "network template rolled back")
+		}
+	}
+	return nil, nil
+}
+
+func applyNetplan(runPrefix string, clock clock.Clock, timeout time.Duration) (ActivationResult, error) {
+	command := fmt.Sprintf("%snetplan generate && netplan apply && sleep 10", runPrefix)
+	result, err := scriptrunner.RunCommand(command, os.Environ(), clock, timeout)
+	if err != nil {
+		return ActivationResult{}, err
+	}
+	logger.Debugf("Netplan activation result %q %q %d", result.Stderr, result.Stdout, result.Code)
+	return ActivationResult{
+		Stderr: string(result.Stderr),
+		Stdout: string(result.Stdout),
+		Code:   result.Code,
+	}, nil
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/juju/clock"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -196,3 +197,95 @@ func (s *ActivateSuite) TestActivateTimeout(c *gc.C) {
 	c.Check(result, gc.NotNil)
 	c.Check(err, gc.ErrorMatches, "bridge activation error: command cancelled")
 }
+
+func (s *ActivateSuite) writeTemplateFiles(c *gc.C, dir string) [][]byte {
+	files := []string{"00.yaml", "01.yaml"}
+	contents := make([][]byte, len(files))
+	for i, file := range files {
+		var err error
+		contents[i], err = ioutil.ReadFile(path.Join("testdata/TestReadWriteBackup", file))
+		c.Assert(err, jc.ErrorIsNil)
+		err = ioutil.WriteFile(path.Join(dir, file), contents[i], 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return contents
+}
+
+func (s *ActivateSuite) TestApplyTemplateSuccess(c *gc.C) {
+	coretesting.SkipIfWindowsBug(c, "lp:1771077")
+	tempDir := c.MkDir()
+	s.writeTemplateFiles(c, tempDir)
+	template, err := netplan.ParseTemplate([]byte("vlans: {eno1.200: {id: 200, link: eno1, dhcp4: true}}"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	var checked bool
+	result, err := netplan.ApplyTemplateAndActivate(netplan.TemplateActivationParams{
+		Template:  template,
+		Directory: tempDir,
+		RunPrefix: "exit 0 &&",
+		CheckConnectivity: func() error {
+			checked = true
+			return nil
+		},
+	})
+	c.Check(result, gc.IsNil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(checked, jc.IsTrue)
+
+	np, err := netplan.ReadDirectory(tempDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*np.Network.VLANs["eno1.200"].Id, gc.Equals, 200)
+	c.Check(np.Network.VLANs["eno1.200"].Link, gc.Equals, "eno1")
+}
+
+func (s *ActivateSuite) TestApplyTemplateUnchanged(c *gc.C) {
+	coretesting.SkipIfWindowsBug(c, "lp:1771077")
+	tempDir := c.MkDir()
+	s.writeTemplateFiles(c, tempDir)
+	template, err := netplan.ParseTemplate([]byte("vlans: {eno1.123: {id: 123, link: eno1}}"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The VLAN already exists, so the network is not reconfigured.
+	result, err := netplan.ApplyTemplateAndActivate(netplan.TemplateActivationParams{
+		Template:  template,
+		Directory: tempDir,
+		RunPrefix: "exit 1 &&",
+	})
+	c.Check(result, gc.IsNil)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ActivateSuite) TestApplyTemplateConnectivityLost(c *gc.C) {
+	coretesting.SkipIfWindowsBug(c, "lp:1771077")
+	tempDir := c.MkDir()
+	contents := s.writeTemplateFiles(c, tempDir)
+	template, err := netplan.ParseTemplate([]byte("bonds: {bond0: {interfaces: [eno1, id1]}}"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := netplan.ApplyTemplateAndActivate(netplan.TemplateActivationParams{
+		Template:  template,
+		Directory: tempDir,
+		RunPrefix: "exit 0 &&",
+		CheckConnectivity: func() error {
+			return errors.New("cannot reach controller")
+		},
+	})
+	c.Check(result, gc.NotNil)
+	c.Check(err, gc.ErrorMatches, "network template rolled back: cannot reach controller")
+
+	// The previous configuration is restored.
+	fileInfos, err := ioutil.ReadDir(tempDir)
+	c.Assert(err, jc.ErrorIsNil)
+	var yamls []string
+	for _, fileInfo := range fileInfos {
+		if strings.HasSuffix(fileInfo.Name(), ".yaml") {
+			yamls = append(yamls, fileInfo.Name())
+		}
+	}
+	c.Check(yamls, jc.DeepEquals, []string{"00.yaml", "01.yaml"})
+	for i, file := range yamls {
+		content, err := ioutil.ReadFile(path.Join(tempDir, file))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(content), gc.Equals, string(contents[i]))
+	}
+}
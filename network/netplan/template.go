@@ -0,0 +1,214 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package netplan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// Template describes bonds and VLAN sub-interfaces that should exist on a
+// machine. It uses the same layout as the bonds and vlans sections of a
+// netplan configuration, with devices referred to by name:
+//
+//	bonds:
+//	  bond0:
+//	    interfaces: [enp1s0, enp2s0]
+//	    parameters:
+//	      mode: 802.3ad
+//	vlans:
+//	  bond0.100:
+//	    id: 100
+//	    link: bond0
+//	    dhcp4: true
+type Template struct {
+	Bonds map[string]Bond `yaml:"bonds,omitempty"`
+	VLANs map[string]VLAN `yaml:"vlans,omitempty"`
+}
+
+// ParseTemplate parses and validates a network template.
+func ParseTemplate(in []byte) (*Template, error) {
+	var t Template
+	if err := goyaml.UnmarshalStrict(in, &t); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := t.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &t, nil
+}
+
+// Hash returns a digest of the template, which changes whenever the
+// bonds or VLANs it describes change.
+func (t *Template) Hash() (string, error) {
+	data, err := goyaml.Marshal(t)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DeviceNames returns the sorted names of the bonds and VLANs the template
+// describes.
+func (t *Template) DeviceNames() []string {
+	names := append(bondNames(t.Bonds), vlanNames(t.VLANs)...)
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks that the template is self-consistent.
+func (t *Template) Validate() error {
+	members := make(map[string]string)
+	for _, name := range bondNames(t.Bonds) {
+		bond := t.Bonds[name]
+		if len(bond.Interfaces) == 0 {
+			return errors.NotValidf("bond %q without interfaces", name)
+		}
+		for _, intf := range bond.Interfaces {
+			if other, ok := members[intf]; ok {
+				return errors.NotValidf("interface %q in bonds %q and %q", intf, other, name)
+			}
+			if _, ok := t.Bonds[intf]; ok {
+				return errors.NotValidf("bond %q in bond %q", intf, name)
+			}
+			members[intf] = name
+		}
+	}
+	for _, name := range vlanNames(t.VLANs) {
+		vlan := t.VLANs[name]
+		if _, ok := t.Bonds[name]; ok {
+			return errors.NotValidf("VLAN %q with the same name as a bond", name)
+		}
+		if _, ok := members[name]; ok {
+			return errors.NotValidf("VLAN %q in bond %q", name, members[name])
+		}
+		if vlan.Id == nil || *vlan.Id < 1 || *vlan.Id > 4094 {
+			return errors.NotValidf("VLAN %q without an id between 1 and 4094", name)
+		}
+		if vlan.Link == "" {
+			return errors.NotValidf("VLAN %q without a link", name)
+		}
+		if _, ok := t.VLANs[vlan.Link]; ok {
+			return errors.NotValidf("VLAN %q linked to VLAN %q", name, vlan.Link)
+		}
+	}
+	return nil
+}
+
+// ApplyTemplate adds the bonds and VLANs of the template that are not
+// already configured. When an interface with addresses is added to a
+// bond that has none, the bond takes over its addresses so that the
+// machine remains reachable. ApplyTemplate reports whether the
+// configuration was changed.
+func (np *Netplan) ApplyTemplate(t *Template) (bool, error) {
+	var changed bool
+	for _, name := range bondNames(t.Bonds) {
+		if _, ok := np.Network.Bonds[name]; ok {
+			continue
+		}
+		if err := np.addBond(name, t.Bonds[name]); err != nil {
+			return false, errors.Annotatef(err, "creating bond %q", name)
+		}
+		changed = true
+	}
+	for _, name := range vlanNames(t.VLANs) {
+		if _, ok := np.Network.VLANs[name]; ok {
+			continue
+		}
+		vlan := t.VLANs[name]
+		link, _, err := np.FindDeviceByNameOrMAC(vlan.Link, "")
+		if err != nil {
+			return false, errors.Annotatef(err, "creating VLAN %q", name)
+		}
+		vlan.Link = link
+		if np.Network.VLANs == nil {
+			np.Network.VLANs = make(map[string]VLAN)
+		}
+		np.Network.VLANs[name] = vlan
+		changed = true
+	}
+	return changed, nil
+}
+
+func (np *Netplan) addBond(name string, bond Bond) error {
+	ids := make([]string, len(bond.Interfaces))
+	for i, intf := range bond.Interfaces {
+		id, err := np.FindEthernetByName(intf)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for bName, b := range np.Network.Bonds {
+			if containsString(b.Interfaces, id) {
+				return errors.AlreadyExistsf("device %q in bond %q", intf, bName)
+			}
+		}
+		for bName, b := range np.Network.Bridges {
+			if containsString(b.Interfaces, id) {
+				return errors.AlreadyExistsf("device %q in bridge %q", intf, bName)
+			}
+		}
+		ids[i] = id
+	}
+
+	claimed := hasAddressing(bond.Interface)
+	for _, id := range ids {
+		ethernet := np.Network.Ethernets[id]
+		if !claimed && hasAddressing(ethernet.Interface) {
+			mtu := bond.MTU
+			bond.Interface = ethernet.Interface
+			if mtu != 0 {
+				bond.MTU = mtu
+			}
+			claimed = true
+		}
+		ethernet.Interface = Interface{MTU: ethernet.MTU}
+		np.Network.Ethernets[id] = ethernet
+	}
+	bond.Interfaces = ids
+	if np.Network.Bonds == nil {
+		np.Network.Bonds = make(map[string]Bond)
+	}
+	np.Network.Bonds[name] = bond
+	return nil
+}
+
+// hasAddressing reports whether the interface is configured with
+// addresses, either statically or by DHCP.
+func hasAddressing(intf Interface) bool {
+	return len(intf.Addresses) > 0 ||
+		(intf.DHCP4 != nil && *intf.DHCP4) ||
+		(intf.DHCP6 != nil && *intf.DHCP6)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func bondNames(bonds map[string]Bond) []string {
+	names := make([]string, 0, len(bonds))
+	for name := range bonds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func vlanNames(vlans map[string]VLAN) []string {
+	names := make([]string, 0, len(vlans))
+	for name := range vlans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,197 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package netplan_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network/netplan"
+)
+
+type TemplateSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TemplateSuite{})
+
+func (s *TemplateSuite) TestParseTemplate(c *gc.C) {
+	t, err := netplan.ParseTemplate([]byte(`
+bonds:
+  bond0:
+    interfaces: [eno1, eno2]
+    parameters:
+      mode: 802.3ad
+vlans:
+  bond0.100:
+    id: 100
+    link: bond0
+    dhcp4: true
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(t.Bonds["bond0"].Interfaces, jc.DeepEquals, []string{"eno1", "eno2"})
+	c.Check(*t.Bonds["bond0"].Parameters.Mode.String, gc.Equals, "802.3ad")
+	c.Check(*t.VLANs["bond0.100"].Id, gc.Equals, 100)
+	c.Check(t.VLANs["bond0.100"].Link, gc.Equals, "bond0")
+}
+
+func (s *TemplateSuite) TestParseTemplateInvalid(c *gc.C) {
+	for i, test := range []struct {
+		template string
+		err      string
+	}{{
+		template: "ethernets: {}",
+		err:      `yaml: unmarshal errors:\n.*field ethernets not found.*`,
+	}, {
+		template: "bonds: {bond0: {}}",
+		err:      `bond "bond0" without interfaces not valid`,
+	}, {
+		template: "bonds: {bond0: {interfaces: [eno1]}, bond1: {interfaces: [eno1]}}",
+		err:      `interface "eno1" in bonds "bond0" and "bond1" not valid`,
+	}, {
+		template: "bonds: {bond0: {interfaces: [bond1]}, bond1: {interfaces: [eno1]}}",
+		err:      `bond "bond1" in bond "bond0" not valid`,
+	}, {
+		template: "vlans: {eno1.5000: {id: 5000, link: eno1}}",
+		err:      `VLAN "eno1.5000" without an id between 1 and 4094 not valid`,
+	}, {
+		template: "vlans: {eno1.100: {id: 100}}",
+		err:      `VLAN "eno1.100" without a link not valid`,
+	}, {
+		template: "vlans: {eno1.100: {id: 100, link: eno1}, eno1.100.5: {id: 5, link: eno1.100}}",
+		err:      `VLAN "eno1.100.5" linked to VLAN "eno1.100" not valid`,
+	}, {
+		template: "bonds: {bond0: {interfaces: [eno1]}}\nvlans: {bond0: {id: 100, link: eno2}}",
+		err:      `VLAN "bond0" with the same name as a bond not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.template)
+		_, err := netplan.ParseTemplate([]byte(test.template))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *TemplateSuite) TestTemplateHash(c *gc.C) {
+	hash := func(template string) string {
+		t, err := netplan.ParseTemplate([]byte(template))
+		c.Assert(err, jc.ErrorIsNil)
+		h, err := t.Hash()
+		c.Assert(err, jc.ErrorIsNil)
+		return h
+	}
+	h := hash("vlans: {eno1.100: {id: 100, link: eno1}, eno1.200: {id: 200, link: eno1}}")
+	c.Check(hash("vlans:\n  eno1.200: {link: eno1, id: 200}\n  eno1.100: {link: eno1, id: 100}\n"), gc.Equals, h)
+	c.Check(hash("vlans: {eno1.100: {id: 100, link: eno1}}"), gc.Not(gc.Equals), h)
+}
+
+func (s *TemplateSuite) TestTemplateDeviceNames(c *gc.C) {
+	t, err := netplan.ParseTemplate([]byte(`
+bonds:
+  bond0: {interfaces: [eno1, eno2]}
+vlans:
+  bond0.100: {id: 100, link: bond0}
+  bond0.20: {id: 20, link: bond0}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t.DeviceNames(), jc.DeepEquals, []string{"bond0", "bond0.100", "bond0.20"})
+}
+
+func (s *TemplateSuite) TestApplyTemplate(c *gc.C) {
+	np := MustNetplanFromYaml(c, `
+network:
+  version: 2
+  ethernets:
+    id0:
+      match:
+        macaddress: "00:11:22:33:44:55"
+      set-name: eno1
+      mtu: 9000
+      addresses:
+      - 10.0.0.10/24
+      gateway4: 10.0.0.1
+    eno2:
+      mtu: 9000
+`)
+	t, err := netplan.ParseTemplate([]byte(`
+bonds:
+  bond0:
+    interfaces: [eno1, eno2]
+    parameters:
+      mode: active-backup
+vlans:
+  bond0.100:
+    id: 100
+    link: bond0
+    addresses: [192.168.100.10/24]
+`))
+	c.Assert(err, jc.ErrorIsNil)
+
+	changed, err := np.ApplyTemplate(t)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changed, jc.IsTrue)
+	out, err := netplan.Marshal(np)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, `
+network:
+  version: 2
+  ethernets:
+    eno2:
+      mtu: 9000
+    id0:
+      match:
+        macaddress: "00:11:22:33:44:55"
+      set-name: eno1
+      mtu: 9000
+  bonds:
+    bond0:
+      interfaces: [id0, eno2]
+      addresses:
+      - 10.0.0.10/24
+      gateway4: 10.0.0.1
+      mtu: 9000
+      parameters:
+        mode: active-backup
+  vlans:
+    bond0.100:
+      id: 100
+      link: bond0
+      addresses:
+      - 192.168.100.10/24
+`[1:])
+
+	// Applying the template again changes nothing.
+	changed, err = np.ApplyTemplate(t)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changed, jc.IsFalse)
+}
+
+func (s *TemplateSuite) TestApplyTemplateBridgedDevice(c *gc.C) {
+	np := MustNetplanFromYaml(c, `
+network:
+  version: 2
+  ethernets:
+    eno1: {}
+  bridges:
+    br-eno1:
+      interfaces: [eno1]
+      dhcp4: true
+`)
+	t, err := netplan.ParseTemplate([]byte("bonds: {bond0: {interfaces: [eno1]}}"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = np.ApplyTemplate(t)
+	c.Assert(err, gc.ErrorMatches, `creating bond "bond0": device "eno1" in bridge "br-eno1" already exists`)
+}
+
+func (s *TemplateSuite) TestApplyTemplateUnknownDevice(c *gc.C) {
+	np := MustNetplanFromYaml(c, `
+network:
+  version: 2
+  ethernets:
+    eno1: {}
+`)
+	t, err := netplan.ParseTemplate([]byte("vlans: {eno2.100: {id: 100, link: eno2}}"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = np.ApplyTemplate(t)
+	c.Assert(err, gc.ErrorMatches, `creating VLAN "eno2.100": device - name "eno2" MAC "" not found`)
+}
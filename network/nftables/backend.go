@@ -0,0 +1,65 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package nftables
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/juju/core/network/firewall"
+)
+
+// Backend renders the bash commands required to manage the firewall
+// of a machine using nftables.
+type Backend struct{}
+
+// Name returns the name of the backend.
+func (Backend) Name() string {
+	return "nftables"
+}
+
+// DropCommands returns the commands which drop all new connections to
+// the specified address or interface, except for TCP connections to the
+// specified ports.
+func (Backend) DropCommands(addr, iface string, exceptPorts []int) []string {
+	batch := Batch(SetupStatements())
+	batch = append(batch, DropCommand{
+		DestinationAddress: addr,
+		Interface:          iface,
+	}.Statement())
+	for _, port := range exceptPorts {
+		batch = append(batch, AcceptInternalCommand{
+			Protocol:           "tcp",
+			DestinationAddress: addr,
+			DestinationPort:    port,
+		}.Statement())
+	}
+	return []string{batch.Render()}
+}
+
+// ListIngressRulesCommand returns the command which lists the content
+// of the ingress chain, in the format expected by ParseIngressRules.
+// Nothing is listed if the juju table has not yet been created.
+func (Backend) ListIngressRulesCommand() string {
+	return fmt.Sprintf("sudo nft list chain %s %s %s 2>/dev/null || true", tableFamily, tableName, ingressChain)
+}
+
+// ParseIngressRules parses the output of ListIngressRulesCommand.
+func (Backend) ParseIngressRules(r io.Reader) (firewall.IngressRules, error) {
+	return ParseIngressRules(r)
+}
+
+// ChangeIngressRulesCommands returns the commands which open (insert) or
+// close the specified ingress rules for the address, given the rules
+// currently in place. The ingress chain is replaced in a single batch; no
+// commands are returned if the change is a no-op.
+func (Backend) ChangeIngressRulesCommands(
+	addr string, insert bool, rules, current firewall.IngressRules,
+) []string {
+	desired := IngressRulesAfterChange(current, insert, rules)
+	if toOpen, toClose := current.Diff(desired); len(toOpen) == 0 && len(toClose) == 0 {
+		return nil
+	}
+	return []string{IngressRulesBatch(addr, desired).Render()}
+}
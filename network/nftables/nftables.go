@@ -0,0 +1,562 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package nftables renders and parses the nftables rules used by Juju to
+// firewall machines on clouds without per-instance security groups.
+//
+// All rules live in a dedicated "inet juju" table, so that they never
+// interfere with rules installed by the machine's own tooling. The table
+// holds three chains:
+//
+//   - input, a base chain hooked into the input path, which only jumps to
+//     the two chains below;
+//   - ingress, which holds the rules that expose applications;
+//   - internal, which holds Juju's own accept rules (e.g. for SSH and the
+//     API server) followed by the rules dropping all other new connections.
+//
+// Every change is sent to "nft -f" as a single batch, so that it is applied
+// atomically.
+package nftables
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
+)
+
+var logger = loggo.GetLogger("juju.network.nftables")
+
+const (
+	// tableFamily is the family of the juju table. The inet family
+	// handles both IPv4 and IPv6 traffic.
+	tableFamily = "inet"
+
+	// tableName is the name of the table holding all juju rules.
+	tableName = "juju"
+
+	// inputChain is the base chain hooked into the input path.
+	inputChain = "input"
+
+	// ingressChain is the chain holding the ingress rules.
+	ingressChain = "ingress"
+
+	// internalChain is the chain holding the rules that are not
+	// directly related to ingress rules.
+	internalChain = "internal"
+
+	// nftablesIngressComment is the comment attached to nftables
+	// rules directly related to ingress rules.
+	nftablesIngressComment = "juju ingress"
+
+	// nftablesInternalComment is the comment attached to nftables
+	// rules that are not directly related to ingress rules.
+	nftablesInternalComment = "juju internal"
+)
+
+// Batch is a list of nftables statements which are applied atomically.
+type Batch []string
+
+// Render renders the batch to a string which can be executed via
+// bash in order to apply all of the statements in a single transaction.
+func (b Batch) Render() string {
+	return fmt.Sprintf("sudo nft -f - <<'EOF'\n%s\nEOF", strings.Join(b, "\n"))
+}
+
+// SetupStatements returns the statements which create the juju table and
+// its chains, if they do not already exist. The input chain is flushed and
+// repopulated, so that the statements can safely be applied repeatedly.
+func SetupStatements() []string {
+	return []string{
+		fmt.Sprintf("add table %s %s", tableFamily, tableName),
+		fmt.Sprintf(
+			"add chain %s %s %s { type filter hook input priority 0; policy accept; }",
+			tableFamily, tableName, inputChain,
+		),
+		fmt.Sprintf("add chain %s %s %s", tableFamily, tableName, ingressChain),
+		fmt.Sprintf("add chain %s %s %s", tableFamily, tableName, internalChain),
+		fmt.Sprintf("flush chain %s %s %s", tableFamily, tableName, inputChain),
+		fmt.Sprintf("add rule %s %s %s jump %s", tableFamily, tableName, inputChain, ingressChain),
+		fmt.Sprintf("add rule %s %s %s jump %s", tableFamily, tableName, inputChain, internalChain),
+	}
+}
+
+// DropCommand represents an nftables rule dropping all new connections,
+// optionally restricted to a destination address or an input interface.
+type DropCommand struct {
+	DestinationAddress string
+	Interface          string
+}
+
+// Statement renders the rule to an nftables statement. The rule is
+// appended to the internal chain, so that it is evaluated after any
+// internal accept rules.
+func (c DropCommand) Statement() string {
+	args := []string{"add rule", tableFamily, tableName, internalChain}
+	if c.DestinationAddress != "" {
+		args = append(args, addressFamily(c.DestinationAddress), "daddr", c.DestinationAddress)
+	}
+	if c.Interface != "" {
+		args = append(args, "iifname", fmt.Sprintf("%q", c.Interface))
+	}
+	args = append(args,
+		"ct state new drop",
+		"comment", fmt.Sprintf("%q", nftablesInternalComment),
+	)
+	return strings.Join(args, " ")
+}
+
+// AcceptInternalCommand represents an nftables accept rule for traffic
+// according to Juju's internal rules, e.g. for API or SSH. This should not
+// be used for managing the ingress rules for exposing applications.
+type AcceptInternalCommand struct {
+	DestinationAddress string
+	DestinationPort    int
+	Protocol           string
+}
+
+// Statement renders the rule to an nftables statement. The rule is
+// inserted at the start of the internal chain, so that it is evaluated
+// before any drop rules.
+func (c AcceptInternalCommand) Statement() string {
+	args := []string{"insert rule", tableFamily, tableName, internalChain}
+	if c.DestinationAddress != "" {
+		args = append(args, addressFamily(c.DestinationAddress), "daddr", c.DestinationAddress)
+	}
+	if c.Protocol != "" {
+		if c.DestinationPort > 0 {
+			args = append(args, c.Protocol, "dport", fmt.Sprint(c.DestinationPort))
+		} else {
+			args = append(args, "meta l4proto", c.Protocol)
+		}
+	}
+	args = append(args,
+		"accept",
+		"comment", fmt.Sprintf("%q", nftablesInternalComment),
+	)
+	return strings.Join(args, " ")
+}
+
+// IngressRulesBatch returns the batch which replaces the content of the
+// ingress chain with the specified rules.
+func IngressRulesBatch(destinationAddress string, rules firewall.IngressRules) Batch {
+	batch := Batch{
+		fmt.Sprintf("add table %s %s", tableFamily, tableName),
+		fmt.Sprintf("add chain %s %s %s", tableFamily, tableName, ingressChain),
+		fmt.Sprintf("flush chain %s %s %s", tableFamily, tableName, ingressChain),
+	}
+	for _, rule := range rules {
+		batch = append(batch, IngressRuleCommand{
+			Rule:               rule,
+			DestinationAddress: destinationAddress,
+		}.Statements()...)
+	}
+	return batch
+}
+
+// IngressRuleCommand represents an nftables accept rule for an
+// ingress rule.
+type IngressRuleCommand struct {
+	Rule               firewall.IngressRule
+	DestinationAddress string
+}
+
+// Statements renders the ingress rule to nftables statements. A separate
+// statement is rendered for each address family of the rule's source
+// CIDRs, as nftables matches IPv4 and IPv6 addresses separately. If a
+// destination address is specified, source CIDRs of the other address
+// family are ignored.
+func (c IngressRuleCommand) Statements() []string {
+	var families []string
+	if c.DestinationAddress != "" {
+		families = []string{addressFamily(c.DestinationAddress)}
+	} else {
+		families = []string{"ip", "ip6"}
+	}
+
+	if c.Rule.SourceCIDRs.IsEmpty() {
+		family := ""
+		if c.DestinationAddress != "" {
+			family = families[0]
+		}
+		return []string{c.statement(family, nil)}
+	}
+
+	cidrsByFamily := make(map[string][]string)
+	for _, cidr := range c.Rule.SourceCIDRs.SortedValues() {
+		family := addressFamily(cidr)
+		cidrsByFamily[family] = append(cidrsByFamily[family], cidr)
+	}
+	var statements []string
+	for _, family := range families {
+		cidrs, ok := cidrsByFamily[family]
+		if !ok {
+			continue
+		}
+		for _, cidr := range cidrs {
+			if cidr == firewall.AllNetworksIPV4CIDR || cidr == firewall.AllNetworksIPV6CIDR {
+				cidrs = nil
+				break
+			}
+		}
+		statements = append(statements, c.statement(family, cidrs))
+	}
+	return statements
+}
+
+func (c IngressRuleCommand) statement(family string, sourceCIDRs []string) string {
+	args := []string{"add rule", tableFamily, tableName, ingressChain}
+	if c.DestinationAddress != "" {
+		args = append(args, family, "daddr", c.DestinationAddress)
+	}
+	switch len(sourceCIDRs) {
+	case 0:
+		if family != "" && c.DestinationAddress == "" {
+			// Restrict the rule to the family, even though
+			// any source address is accepted.
+			args = append(args, "meta nfproto", ipv4OrIPv6(family))
+		}
+	case 1:
+		args = append(args, family, "saddr", sourceCIDRs[0])
+	default:
+		args = append(args, family, "saddr", fmt.Sprintf("{ %s }", strings.Join(sourceCIDRs, ", ")))
+	}
+
+	portRange := c.Rule.PortRange
+	if portRange.Protocol == "icmp" {
+		if family == "ip6" {
+			args = append(args, "icmpv6 type echo-request")
+		} else {
+			args = append(args, "icmp type echo-request")
+		}
+	} else if portRange.ToPort-portRange.FromPort > 0 {
+		args = append(args, portRange.Protocol, "dport", fmt.Sprintf("%d-%d", portRange.FromPort, portRange.ToPort))
+	} else {
+		args = append(args, portRange.Protocol, "dport", fmt.Sprint(portRange.FromPort))
+	}
+
+	// Comment always comes last.
+	args = append(args,
+		"accept",
+		"comment", fmt.Sprintf("%q", nftablesIngressComment),
+	)
+	return strings.Join(args, " ")
+}
+
+// addressFamily returns the nftables family matching the specified
+// address or CIDR.
+func addressFamily(addr string) string {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		ip, _, _ = net.ParseCIDR(addr)
+	}
+	if ip != nil && ip.To4() == nil {
+		return "ip6"
+	}
+	return "ip"
+}
+
+func ipv4OrIPv6(family string) string {
+	if family == "ip6" {
+		return "ipv6"
+	}
+	return "ipv4"
+}
+
+// familyCIDRs returns the CIDRs representing all networks of the
+// specified family, or both families if none is specified.
+func familyCIDRs(family string) set.Strings {
+	switch family {
+	case "ip":
+		return set.NewStrings(firewall.AllNetworksIPV4CIDR)
+	case "ip6":
+		return set.NewStrings(firewall.AllNetworksIPV6CIDR)
+	}
+	return set.NewStrings()
+}
+
+// IngressRulesAfterChange returns the ingress rules which are in place
+// after opening (insert) or closing the specified rules on top of the
+// current ones. Closing a rule without source CIDRs closes its port
+// range for all sources.
+func IngressRulesAfterChange(current firewall.IngressRules, insert bool, rules firewall.IngressRules) firewall.IngressRules {
+	if insert {
+		result := append(firewall.IngressRules{}, current...)
+		result = append(result, rules...)
+		result = result.UniqueRules()
+		result.Sort()
+		return result
+	}
+
+	closeCIDRs := make(map[network.PortRange]set.Strings)
+	closeAll := make(map[network.PortRange]bool)
+	for _, rule := range rules {
+		if rule.SourceCIDRs.IsEmpty() {
+			closeAll[rule.PortRange] = true
+			continue
+		}
+		cidrs, ok := closeCIDRs[rule.PortRange]
+		if !ok {
+			cidrs = set.NewStrings()
+			closeCIDRs[rule.PortRange] = cidrs
+		}
+		for cidr := range rule.SourceCIDRs {
+			cidrs.Add(cidr)
+		}
+	}
+
+	var result firewall.IngressRules
+	for _, rule := range current {
+		if closeAll[rule.PortRange] {
+			continue
+		}
+		cidrs, ok := closeCIDRs[rule.PortRange]
+		if !ok {
+			result = append(result, rule)
+			continue
+		}
+		sources := rule.SourceCIDRs
+		if sources.IsEmpty() {
+			sources = set.NewStrings(firewall.AllNetworksIPV4CIDR, firewall.AllNetworksIPV6CIDR)
+		}
+		remaining := sources.Difference(cidrs)
+		if remaining.IsEmpty() {
+			continue
+		}
+		result = append(result, firewall.NewIngressRule(rule.PortRange, remaining.SortedValues()...))
+	}
+	result.Sort()
+	return result
+}
+
+// ParseIngressRules parses the output of "nft list chain inet juju ingress",
+// extracting previously added ingress rules, as rendered by
+// IngressRuleCommand.
+func ParseIngressRules(r io.Reader) (firewall.IngressRules, error) {
+	var rules firewall.IngressRules
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		rule, ok, err := parseIngressRule(strings.TrimSpace(line))
+		if err != nil {
+			logger.Warningf("failed to parse nftables line %q: %v", line, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Annotate(err, "reading nftables output")
+	}
+	return rules, nil
+}
+
+// parseIngressRule parses a single nftables output line, extracting
+// an ingress rule if the line represents one, or returning false
+// otherwise.
+//
+// The nftables rules we care about have the following format, and we
+// will skip all other lines:
+//
+//	table inet juju {
+//		chain ingress {
+//			ip daddr 10.0.0.1 tcp dport 80 accept comment "juju ingress"
+//			ip daddr 10.0.0.1 ip saddr { 10.0.0.0/24, 192.168.0.0/16 } tcp dport 3456-3458 accept comment "juju ingress"
+//			ip6 daddr 2001:db8::1 icmpv6 type echo-request accept comment "juju ingress"
+//		}
+//	}
+func parseIngressRule(line string) (firewall.IngressRule, bool, error) {
+	fail := func(err error) (firewall.IngressRule, bool, error) {
+		return firewall.IngressRule{}, false, err
+	}
+
+	// Strip any rule handle, as printed by "nft -a".
+	if i := strings.Index(line, " # handle "); i != -1 {
+		line = line[:i]
+	}
+
+	// We only care about rules with the comment "juju ingress".
+	commentStart := strings.LastIndex(line, ` comment "`)
+	if commentStart == -1 || !strings.HasSuffix(line, `"`) {
+		return firewall.IngressRule{}, false, nil
+	}
+	line, comment := line[:commentStart], line[commentStart+len(` comment "`):len(line)-1]
+	if comment != nftablesIngressComment {
+		return firewall.IngressRule{}, false, nil
+	}
+
+	replacer := strings.NewReplacer("{", " { ", "}", " } ", ",", " ")
+	fields := strings.Fields(replacer.Replace(line))
+	next := func() (string, bool) {
+		if len(fields) == 0 {
+			return "", false
+		}
+		field := fields[0]
+		fields = fields[1:]
+		return field, true
+	}
+	values := func() ([]string, bool) {
+		field, ok := next()
+		if !ok {
+			return nil, false
+		}
+		if field != "{" {
+			return []string{field}, true
+		}
+		var result []string
+		for {
+			field, ok := next()
+			if !ok {
+				return nil, false
+			}
+			if field == "}" {
+				return result, true
+			}
+			result = append(result, field)
+		}
+	}
+
+	var (
+		family   string
+		sources  []string
+		proto    string
+		fromPort int
+		toPort   int
+		accepted bool
+	)
+	for {
+		field, ok := next()
+		if !ok {
+			break
+		}
+		switch field {
+		case "ip", "ip6":
+			family = field
+			selector, ok := next()
+			if !ok {
+				return fail(errors.Errorf("missing %s selector", field))
+			}
+			addrs, ok := values()
+			if !ok {
+				return fail(errors.Errorf("could not extract %s %s", field, selector))
+			}
+			switch selector {
+			case "saddr":
+				for _, addr := range addrs {
+					sources = append(sources, normaliseCIDR(family, addr))
+				}
+			case "daddr":
+			default:
+				return fail(errors.Errorf("unexpected %s selector %q", field, selector))
+			}
+		case "meta":
+			if key, _ := next(); key != "nfproto" {
+				return fail(errors.Errorf("unexpected meta key %q", key))
+			}
+			nfproto, _ := next()
+			switch nfproto {
+			case "ipv4":
+				family = "ip"
+			case "ipv6":
+				family = "ip6"
+			default:
+				return fail(errors.Errorf("unexpected nfproto %q", nfproto))
+			}
+		case "tcp", "udp":
+			proto = field
+			if selector, _ := next(); selector != "dport" {
+				return fail(errors.Errorf("unexpected %s selector %q", field, selector))
+			}
+			ports, ok := next()
+			if !ok {
+				return fail(errors.New("could not extract destination port"))
+			}
+			var err error
+			if fromPort, toPort, err = parsePortRange(ports); err != nil {
+				return fail(errors.Trace(err))
+			}
+		case "icmp", "icmpv6":
+			proto = "icmp"
+			if selector, _ := next(); selector != "type" {
+				return fail(errors.Errorf("unexpected %s selector %q", field, selector))
+			}
+			if icmpType, _ := next(); icmpType != "echo-request" {
+				return fail(errors.Errorf("unexpected %s type %q", field, icmpType))
+			}
+			fromPort, toPort = -1, -1
+		case "accept":
+			accepted = true
+		default:
+			return fail(errors.Errorf("unexpected field %q", field))
+		}
+	}
+	if !accepted {
+		return fail(errors.New("rule does not accept traffic"))
+	}
+	if proto == "" {
+		return fail(errors.New("could not extract protocol"))
+	}
+
+	if len(sources) == 0 {
+		sources = familyCIDRs(family).SortedValues()
+	}
+	rule := firewall.NewIngressRule(network.PortRange{
+		FromPort: fromPort,
+		ToPort:   toPort,
+		Protocol: proto,
+	}, sources...)
+	if err := rule.Validate(); err != nil {
+		return fail(errors.Trace(err))
+	}
+	return rule, true, nil
+}
+
+// normaliseCIDR returns the address in CIDR notation, as nftables
+// prints single host CIDRs as plain addresses.
+func normaliseCIDR(family, addr string) string {
+	if strings.Contains(addr, "/") {
+		return addr
+	}
+	if family == "ip6" {
+		return addr + "/128"
+	}
+	return addr + "/32"
+}
+
+func parsePortRange(s string) (int, int, error) {
+	fields := strings.Split(s, "-")
+	if len(fields) > 2 {
+		return -1, -1, errors.New("expected N or M-N")
+	}
+	from, err := parsePort(fields[0])
+	if err != nil {
+		return -1, -1, errors.Trace(err)
+	}
+	if len(fields) == 1 {
+		return from, from, nil
+	}
+	to, err := parsePort(fields[1])
+	if err != nil {
+		return -1, -1, errors.Trace(err)
+	}
+	return from, to, nil
+}
+
+func parsePort(s string) (int, error) {
+	n, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return -1, errors.Trace(err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,259 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package nftables_test
+
+import (
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/network/nftables"
+)
+
+type NftablesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&NftablesSuite{})
+
+func (*NftablesSuite) TestDropCommand(c *gc.C) {
+	c.Assert(
+		nftables.DropCommand{}.Statement(), gc.Equals,
+		`add rule inet juju internal ct state new drop comment "juju internal"`,
+	)
+	c.Assert(
+		nftables.DropCommand{DestinationAddress: "1.2.3.4"}.Statement(), gc.Equals,
+		`add rule inet juju internal ip daddr 1.2.3.4 ct state new drop comment "juju internal"`,
+	)
+	c.Assert(
+		nftables.DropCommand{DestinationAddress: "2001:db8::1"}.Statement(), gc.Equals,
+		`add rule inet juju internal ip6 daddr 2001:db8::1 ct state new drop comment "juju internal"`,
+	)
+	c.Assert(
+		nftables.DropCommand{Interface: "eth0"}.Statement(), gc.Equals,
+		`add rule inet juju internal iifname "eth0" ct state new drop comment "juju internal"`,
+	)
+}
+
+func (*NftablesSuite) TestAcceptInternalCommand(c *gc.C) {
+	c.Assert(
+		nftables.AcceptInternalCommand{}.Statement(), gc.Equals,
+		`insert rule inet juju internal accept comment "juju internal"`,
+	)
+	c.Assert(
+		nftables.AcceptInternalCommand{
+			DestinationAddress: "1.2.3.4",
+			DestinationPort:    17070,
+			Protocol:           "tcp",
+		}.Statement(), gc.Equals,
+		`insert rule inet juju internal ip daddr 1.2.3.4 tcp dport 17070 accept comment "juju internal"`,
+	)
+}
+
+func (*NftablesSuite) TestIngressRuleCommand(c *gc.C) {
+	assertStatements(c,
+		nftables.IngressRuleCommand{
+			Rule: firewall.NewIngressRule(network.MustParsePortRange("icmp")),
+		},
+		`add rule inet juju ingress icmp type echo-request accept comment "juju ingress"`,
+	)
+
+	// The destination address selects the family.
+	assertStatements(c,
+		nftables.IngressRuleCommand{
+			Rule:               firewall.NewIngressRule(network.MustParsePortRange("icmp")),
+			DestinationAddress: "2001:db8::1",
+		},
+		`add rule inet juju ingress ip6 daddr 2001:db8::1 icmpv6 type echo-request accept comment "juju ingress"`,
+	)
+
+	// UDP, single port.
+	assertStatements(c,
+		nftables.IngressRuleCommand{
+			Rule:               firewall.NewIngressRule(network.MustParsePortRange("53/udp")),
+			DestinationAddress: "1.2.3.4",
+		},
+		`add rule inet juju ingress ip daddr 1.2.3.4 udp dport 53 accept comment "juju ingress"`,
+	)
+
+	// TCP, port range, from multiple CIDRs.
+	assertStatements(c,
+		nftables.IngressRuleCommand{
+			Rule: firewall.NewIngressRule(
+				network.MustParsePortRange("6001-6007/tcp"), "1.2.3.0/24", "5.6.7.8/32"),
+			DestinationAddress: "1.2.3.4",
+		},
+		`add rule inet juju ingress ip daddr 1.2.3.4 ip saddr { 1.2.3.0/24, 5.6.7.8/32 } tcp dport 6001-6007 accept comment "juju ingress"`,
+	)
+
+	// Mixed families are rendered as separate statements, and all
+	// networks are rendered as a family match.
+	assertStatements(c,
+		nftables.IngressRuleCommand{
+			Rule: firewall.NewIngressRule(
+				network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR, "2001:db8::/32"),
+		},
+		`add rule inet juju ingress meta nfproto ipv4 tcp dport 80 accept comment "juju ingress"`,
+		`add rule inet juju ingress ip6 saddr 2001:db8::/32 tcp dport 80 accept comment "juju ingress"`,
+	)
+
+	// CIDRs of a different family than the destination are ignored.
+	assertStatements(c,
+		nftables.IngressRuleCommand{
+			Rule: firewall.NewIngressRule(
+				network.MustParsePortRange("80/tcp"), "10.0.0.0/8", "2001:db8::/32"),
+			DestinationAddress: "1.2.3.4",
+		},
+		`add rule inet juju ingress ip daddr 1.2.3.4 ip saddr 10.0.0.0/8 tcp dport 80 accept comment "juju ingress"`,
+	)
+}
+
+func (*NftablesSuite) TestIngressRulesBatch(c *gc.C) {
+	batch := nftables.IngressRulesBatch("1.2.3.4", firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR),
+	})
+	c.Assert(batch.Render(), gc.Equals, `
+sudo nft -f - <<'EOF'
+add table inet juju
+add chain inet juju ingress
+flush chain inet juju ingress
+add rule inet juju ingress ip daddr 1.2.3.4 tcp dport 80 accept comment "juju ingress"
+EOF`[1:])
+}
+
+func (*NftablesSuite) TestIngressRulesAfterChange(c *gc.C) {
+	current := firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8", "192.168.0.0/16"),
+		firewall.NewIngressRule(network.MustParsePortRange("443/tcp"), firewall.AllNetworksIPV4CIDR),
+	}
+
+	result := nftables.IngressRulesAfterChange(current, true, firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("53/udp"), firewall.AllNetworksIPV4CIDR),
+		firewall.NewIngressRule(network.MustParsePortRange("443/tcp"), firewall.AllNetworksIPV4CIDR),
+	})
+	c.Assert(result, jc.DeepEquals, firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8", "192.168.0.0/16"),
+		firewall.NewIngressRule(network.MustParsePortRange("443/tcp"), firewall.AllNetworksIPV4CIDR),
+		firewall.NewIngressRule(network.MustParsePortRange("53/udp"), firewall.AllNetworksIPV4CIDR),
+	})
+
+	result = nftables.IngressRulesAfterChange(current, false, firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		firewall.NewIngressRule(network.MustParsePortRange("443/tcp")),
+	})
+	c.Assert(result, jc.DeepEquals, firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.0.0/16"),
+	})
+}
+
+func (*NftablesSuite) TestBackendChangeIngressRulesCommandsNoop(c *gc.C) {
+	current := firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR),
+	}
+	cmds := nftables.Backend{}.ChangeIngressRulesCommands("1.2.3.4", true, current, current)
+	c.Assert(cmds, gc.HasLen, 0)
+
+	cmds = nftables.Backend{}.ChangeIngressRulesCommands("1.2.3.4", false, current, current)
+	c.Assert(cmds, jc.DeepEquals, []string{nftables.IngressRulesBatch("1.2.3.4", nil).Render()})
+}
+
+func (*NftablesSuite) TestBackendDropCommands(c *gc.C) {
+	cmds := nftables.Backend{}.DropCommands("1.2.3.4", "", []int{22})
+	c.Assert(cmds, jc.DeepEquals, []string{`
+sudo nft -f - <<'EOF'
+add table inet juju
+add chain inet juju input { type filter hook input priority 0; policy accept; }
+add chain inet juju ingress
+add chain inet juju internal
+flush chain inet juju input
+add rule inet juju input jump ingress
+add rule inet juju input jump internal
+add rule inet juju internal ip daddr 1.2.3.4 ct state new drop comment "juju internal"
+insert rule inet juju internal ip daddr 1.2.3.4 tcp dport 22 accept comment "juju internal"
+EOF`[1:]})
+}
+
+func (*NftablesSuite) TestParseIngressRulesEmpty(c *gc.C) {
+	assertParseIngressRules(c, ``, nil)
+}
+
+func (*NftablesSuite) TestParseIngressRulesGarbage(c *gc.C) {
+	assertParseIngressRules(c, `a
+b
+accept comment "juju ingress"
+tcp dport zing accept comment "juju ingress"
+blargh
+
+`, nil)
+}
+
+func (*NftablesSuite) TestParseIngressRulesChecksComment(c *gc.C) {
+	assertParseIngressRules(c, `
+table inet juju {
+	chain ingress {
+		tcp dport 53 accept comment "managed by lxd"
+		ip daddr 1.2.3.4 tcp dport 53 accept comment "juju ingress"
+		udp dport 67 accept
+	}
+}
+`[1:], firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("53/tcp"), firewall.AllNetworksIPV4CIDR),
+	})
+}
+
+func (*NftablesSuite) TestParseIngressRules(c *gc.C) {
+	assertParseIngressRules(c, `
+table inet juju {
+	chain ingress { # handle 2
+		ip daddr 1.2.3.4 tcp dport 3456-3458 accept comment "juju ingress" # handle 5
+		ip daddr 1.2.3.4 ip saddr 1.2.3.0/20 tcp dport 12345 accept comment "juju ingress" # handle 6
+		ip daddr 1.2.3.4 ip saddr { 1.2.3.0/20, 5.6.7.8 } udp dport 12345 accept comment "juju ingress" # handle 7
+		ip daddr 1.2.3.4 icmp type echo-request accept comment "juju ingress" # handle 8
+		ip6 daddr 2001:db8::1 icmpv6 type echo-request accept comment "juju ingress" # handle 9
+		meta nfproto ipv6 tcp dport 80 accept comment "juju ingress" # handle 10
+		tcp dport 443 accept comment "juju ingress" # handle 11
+	}
+}
+`[1:],
+		firewall.IngressRules{
+			firewall.NewIngressRule(network.MustParsePortRange("3456-3458/tcp"), firewall.AllNetworksIPV4CIDR),
+			firewall.NewIngressRule(network.MustParsePortRange("12345/tcp"), "1.2.3.0/20"),
+			firewall.NewIngressRule(network.MustParsePortRange("12345/udp"), "1.2.3.0/20", "5.6.7.8/32"),
+			firewall.NewIngressRule(network.MustParsePortRange("icmp"), firewall.AllNetworksIPV4CIDR),
+			firewall.NewIngressRule(network.MustParsePortRange("icmp"), firewall.AllNetworksIPV6CIDR),
+			firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV6CIDR),
+			firewall.NewIngressRule(network.MustParsePortRange("443/tcp")),
+		},
+	)
+}
+
+func (*NftablesSuite) TestRenderParseRoundTrip(c *gc.C) {
+	rules := firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR),
+		firewall.NewIngressRule(network.MustParsePortRange("1000-2000/udp"), "10.0.0.0/8", "192.168.1.0/24"),
+		firewall.NewIngressRule(network.MustParsePortRange("icmp"), firewall.AllNetworksIPV4CIDR),
+	}
+	var out []string
+	for _, statement := range nftables.IngressRulesBatch("1.2.3.4", rules) {
+		// nft lists rules without the "add rule <table> <chain>" prefix.
+		if strings.HasPrefix(statement, "add rule inet juju ingress ") {
+			out = append(out, strings.TrimPrefix(statement, "add rule inet juju ingress "))
+		}
+	}
+	assertParseIngressRules(c, strings.Join(out, "\n"), rules)
+}
+
+func assertParseIngressRules(c *gc.C, in string, expect firewall.IngressRules) {
+	rules, err := nftables.ParseIngressRules(strings.NewReader(in))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expect)
+}
+
+func assertStatements(c *gc.C, cmd nftables.IngressRuleCommand, expect ...string) {
+	c.Assert(cmd.Statements(), jc.DeepEquals, expect)
+}
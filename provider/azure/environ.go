@@ -437,6 +437,13 @@ func (env *azureEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		constraints.MemRequest,
+		constraints.MemLimit,
+		constraints.CpuRequest,
+		constraints.CpuLimit,
+		constraints.EphemeralStorageLimit,
+		constraints.ContainerResources,
+		constraints.Spot,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
@@ -14,6 +14,13 @@ var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
 	constraints.AllocatePublicIP,
+	constraints.MemRequest,
+	constraints.MemLimit,
+	constraints.CpuRequest,
+	constraints.CpuLimit,
+	constraints.EphemeralStorageLimit,
+	constraints.ContainerResources,
+	constraints.Spot,
 }
 
 // ConstraintsValidator returns a Validator instance which
@@ -11,7 +11,7 @@ import (
 	"github.com/juju/utils/v2/ssh"
 
 	"github.com/juju/juju/core/network/firewall"
-	"github.com/juju/juju/network/iptables"
+	"github.com/juju/juju/network/hostfirewall"
 )
 
 const (
@@ -41,10 +41,18 @@ type sshInstanceConfigurator struct {
 	client  ssh.Client
 	host    string
 	options *ssh.Options
+
+	// firewallBackend is the name of the host firewall backend to use,
+	// as given by the model config. The backend is detected on the
+	// machine if it is "auto" or empty.
+	firewallBackend string
+	backend         hostfirewall.Backend
 }
 
-// NewSshInstanceConfigurator creates new sshInstanceConfigurator.
-func NewSshInstanceConfigurator(host string) InstanceConfigurator {
+// NewSshInstanceConfigurator creates new sshInstanceConfigurator,
+// managing the machine's firewall with the specified host firewall
+// backend.
+func NewSshInstanceConfigurator(host, firewallBackend string) InstanceConfigurator {
 	options := ssh.Options{}
 	options.SetIdentities("/var/lib/juju/system-identity")
 
@@ -59,9 +67,10 @@ func NewSshInstanceConfigurator(host string) InstanceConfigurator {
 	options.SetKnownHostsFile(os.DevNull)
 
 	return &sshInstanceConfigurator{
-		client:  ssh.DefaultClient,
-		host:    "ubuntu@" + host,
-		options: &options,
+		client:          ssh.DefaultClient,
+		host:            "ubuntu@" + host,
+		options:         &options,
+		firewallBackend: firewallBackend,
 	}
 }
 
@@ -75,18 +84,39 @@ func (c *sshInstanceConfigurator) runCommand(cmd string) (string, error) {
 	return string(output), nil
 }
 
+// hostFirewallBackend returns the host firewall backend to use, detecting
+// it on the machine the first time if required.
+func (c *sshInstanceConfigurator) hostFirewallBackend() (hostfirewall.Backend, error) {
+	if c.backend != nil {
+		return c.backend, nil
+	}
+	name := c.firewallBackend
+	if name == "" || name == hostfirewall.Auto {
+		output, err := c.runCommand(hostfirewall.DetectCommand)
+		if err != nil {
+			return nil, errors.Errorf("failed to detect host firewall backend: %s", output)
+		}
+		// The output may be preceded by warnings from ssh,
+		// so only the last line is considered.
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		name = lines[len(lines)-1]
+	}
+	backend, err := hostfirewall.NewBackend(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("using %s host firewall backend for %s", backend.Name(), c.host)
+	c.backend = backend
+	return backend, nil
+}
+
 // DropAllPorts implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) DropAllPorts(exceptPorts []int, addr string) error {
-	cmds := []string{
-		iptables.DropCommand{DestinationAddress: addr}.Render(),
-	}
-	for _, port := range exceptPorts {
-		cmds = append(cmds, iptables.AcceptInternalCommand{
-			Protocol:           "tcp",
-			DestinationAddress: addr,
-			DestinationPort:    port,
-		}.Render())
+	backend, err := c.hostFirewallBackend()
+	if err != nil {
+		return errors.Trace(err)
 	}
+	cmds := backend.DropCommands(addr, "", exceptPorts)
 
 	output, err := c.runCommand(strings.Join(cmds, "\n"))
 	if err != nil {
@@ -98,24 +128,25 @@ func (c *sshInstanceConfigurator) DropAllPorts(exceptPorts []int, addr string) e
 
 // ConfigureExternalIpAddressCommands returns the commands to run to configure
 // the external IP address
-func ConfigureExternalIpAddressCommands(apiPort int) []string {
+func ConfigureExternalIpAddressCommands(backend hostfirewall.Backend, apiPort int) []string {
 	commands := []string{
 		`printf 'auto eth1\niface eth1 inet dhcp' | sudo tee -a /etc/network/interfaces.d/eth1.cfg`,
 		"sudo ifup eth1",
-		iptables.DropCommand{Interface: "eth1"}.Render(),
 	}
+	var exceptPorts []int
 	if apiPort > 0 {
-		commands = append(commands, iptables.AcceptInternalCommand{
-			Protocol:        "tcp",
-			DestinationPort: apiPort,
-		}.Render())
+		exceptPorts = append(exceptPorts, apiPort)
 	}
-	return commands
+	return append(commands, backend.DropCommands("", "eth1", exceptPorts)...)
 }
 
 // ConfigureExternalIpAddress implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) ConfigureExternalIpAddress(apiPort int) error {
-	cmds := ConfigureExternalIpAddressCommands(apiPort)
+	backend, err := c.hostFirewallBackend()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cmds := ConfigureExternalIpAddressCommands(backend, apiPort)
 	output, err := c.runCommand(strings.Join(cmds, "\n"))
 	if err != nil {
 		return errors.Errorf("failed to allocate external IP address: %s", output)
@@ -126,13 +157,18 @@ func (c *sshInstanceConfigurator) ConfigureExternalIpAddress(apiPort int) error
 
 // ChangeIngressRules implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bool, rules firewall.IngressRules) error {
-	var cmds []string
-	for _, rule := range rules {
-		cmds = append(cmds, iptables.IngressRuleCommand{
-			Rule:               rule,
-			DestinationAddress: ipAddress,
-			Delete:             !insert,
-		}.Render())
+	backend, err := c.hostFirewallBackend()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := c.findIngressRules(backend)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cmds := backend.ChangeIngressRulesCommands(ipAddress, insert, rules, current)
+	if len(cmds) == 0 {
+		logger.Tracef("ingress rules for address %q already up to date", ipAddress)
+		return nil
 	}
 
 	output, err := c.runCommand(strings.Join(cmds, "\n"))
@@ -145,10 +181,18 @@ func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bo
 
 // FindIngressRules implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) FindIngressRules() (firewall.IngressRules, error) {
-	output, err := c.runCommand("sudo iptables -L INPUT -n")
+	backend, err := c.hostFirewallBackend()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.findIngressRules(backend)
+}
+
+func (c *sshInstanceConfigurator) findIngressRules(backend hostfirewall.Backend) (firewall.IngressRules, error) {
+	output, err := c.runCommand(backend.ListIngressRulesCommand())
 	if err != nil {
 		return nil, errors.Errorf("failed to list open ports: %s", output)
 	}
 	logger.Tracef("find open ports output: %s", output)
-	return iptables.ParseIngressRules(strings.NewReader(output))
+	return backend.ParseIngressRules(strings.NewReader(output))
 }
@@ -14,6 +14,10 @@
 // after the environment has been opened will return
 // the error "broken environment", and will also log that.
 //
+// The configuration data also accepts an "ipv6-only" property
+// of type boolean. If this is true, instances and networks
+// are given IPv6 addresses only.
+//
 // The DNS name of instances is the same as the Id,
 // with ".dns" appended.
 package dummy
@@ -585,6 +589,10 @@ var configSchema = environschema.Fields{
 		Description: "A secret",
 		Type:        environschema.Tstring,
 	},
+	"ipv6-only": {
+		Description: "Whether instances and networks are given IPv6 addresses only",
+		Type:        environschema.Tbool,
+	},
 }
 
 var configFields = func() schema.Fields {
@@ -599,6 +607,7 @@ var configDefaults = schema.Defaults{
 	"broken":     "",
 	"secret":     "pork",
 	"controller": false,
+	"ipv6-only":  schema.Omit,
 }
 
 type environConfig struct {
@@ -618,6 +627,20 @@ func (c *environConfig) secret() string {
 	return c.attrs["secret"].(string)
 }
 
+func (c *environConfig) ipv6Only() bool {
+	v, _ := c.attrs["ipv6-only"].(bool)
+	return v
+}
+
+// allNetworksCIDR returns the CIDR used for ingress rules that do not
+// specify any.
+func allNetworksCIDR(ipv6Only bool) string {
+	if ipv6Only {
+		return firewall.AllNetworksIPV6CIDR
+	}
+	return firewall.AllNetworksIPV4CIDR
+}
+
 func (p *environProvider) newConfig(cfg *config.Config) (*environConfig, error) {
 	valid, err := p.Validate(cfg, nil)
 	if err != nil {
@@ -1159,7 +1182,11 @@ func (e *environ) DestroyController(ctx context.ProviderCallContext, controllerU
 // ConstraintsValidator is defined on the Environs interface.
 func (e *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
 	validator := constraints.NewValidator()
-	validator.RegisterUnsupported([]string{constraints.CpuPower, constraints.VirtType})
+	validator.RegisterUnsupported([]string{
+		constraints.CpuPower, constraints.VirtType,
+		constraints.MemRequest, constraints.MemLimit, constraints.CpuRequest, constraints.CpuLimit,
+		constraints.EphemeralStorageLimit, constraints.ContainerResources,
+	})
 	validator.RegisterConflicts([]string{constraints.InstanceType}, []string{constraints.Mem})
 	validator.RegisterVocabulary(constraints.Arch, []string{arch.AMD64, arch.ARM64, arch.I386, arch.PPC64EL, arch.S390X})
 	return validator, nil
@@ -1204,7 +1231,13 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	idString := fmt.Sprintf("%s-%d", e.name, estate.maxId)
 	// Add the addresses we want to see in the machine doc. This means both
 	// IPv4 and IPv6 loopback, as well as the DNS name.
+	// IPv6-only instances have IPv6 loopback and a global IPv6 address.
+	ipv6Only := e.ecfg().ipv6Only()
 	addrs := corenetwork.NewProviderAddresses(idString+".dns", "127.0.0.1", "::1")
+	if ipv6Only {
+		addrs = corenetwork.NewProviderAddresses(
+			idString+".dns", "::1", fmt.Sprintf("2001:db8::%x", estate.maxId+1))
+	}
 	logger.Debugf("StartInstance addresses: %v", addrs)
 	i := &dummyInstance{
 		id:           instance.Id(idString),
@@ -1212,6 +1245,7 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 		machineId:    machineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
+		ipv6Only:     ipv6Only,
 		state:        estate,
 	}
 
@@ -1453,16 +1487,25 @@ func (env *environ) NetworkInterfaces(ctx context.ProviderCallContext, ids []ins
 
 	// Simulate 3 NICs - primary and secondary enabled plus a disabled NIC.
 	// all configured using DHCP and having fake DNS servers and gateway.
+	ipv6Only := env.ecfg().ipv6Only()
 	infos := make([]corenetwork.InterfaceInfos, len(ids))
 	for idIndex, instId := range ids {
 		infos[idIndex] = make(corenetwork.InterfaceInfos, 3)
 		for i, netName := range []string{"private", "public", "disabled"} {
+			cidr := fmt.Sprintf("0.%d.0.0/24", (i+1)*10)
+			addr := fmt.Sprintf("0.%d.0.%d", (i+1)*10+idIndex, estate.maxAddr+2)
+			gateway := fmt.Sprintf("0.%d.0.1", (i+1)*10+idIndex)
+			if ipv6Only {
+				cidr = fmt.Sprintf("2001:db8:%d::/64", (i+1)*10)
+				addr = fmt.Sprintf("2001:db8:%d::%d", (i+1)*10+idIndex, estate.maxAddr+2)
+				gateway = fmt.Sprintf("2001:db8:%d::1", (i+1)*10+idIndex)
+			}
 			infos[idIndex][i] = corenetwork.InterfaceInfo{
 				DeviceIndex:      i,
 				ProviderId:       corenetwork.Id(fmt.Sprintf("dummy-eth%d", i)),
 				ProviderSubnetId: corenetwork.Id("dummy-" + netName),
 				InterfaceType:    corenetwork.EthernetInterface,
-				CIDR:             fmt.Sprintf("0.%d.0.0/24", (i+1)*10),
+				CIDR:             cidr,
 				InterfaceName:    fmt.Sprintf("eth%d", i),
 				VLANTag:          i,
 				MACAddress:       fmt.Sprintf("aa:bb:cc:dd:ee:f%d", i),
@@ -1470,15 +1513,11 @@ func (env *environ) NetworkInterfaces(ctx context.ProviderCallContext, ids []ins
 				NoAutoStart:      i%2 != 0,
 				ConfigType:       corenetwork.ConfigDHCP,
 				Addresses: corenetwork.ProviderAddresses{
-					corenetwork.NewProviderAddress(
-						fmt.Sprintf("0.%d.0.%d", (i+1)*10+idIndex, estate.maxAddr+2),
-					),
+					corenetwork.NewProviderAddress(addr),
 				},
-				DNSServers: corenetwork.NewProviderAddresses("ns1.dummy", "ns2.dummy"),
-				GatewayAddress: corenetwork.NewProviderAddress(
-					fmt.Sprintf("0.%d.0.1", (i+1)*10+idIndex),
-				),
-				Origin: corenetwork.OriginProvider,
+				DNSServers:     corenetwork.NewProviderAddresses("ns1.dummy", "ns2.dummy"),
+				GatewayAddress: corenetwork.NewProviderAddress(gateway),
+				Origin:         corenetwork.OriginProvider,
 			}
 		}
 
@@ -1578,6 +1617,10 @@ func (env *environ) Subnets(
 		CIDR:       "0.20.0.0/24",
 		ProviderId: "dummy-public",
 	}}
+	if env.ecfg().ipv6Only() {
+		allSubnets[0].CIDR = "2001:db8:10::/64"
+		allSubnets[1].CIDR = "2001:db8:20::/64"
+	}
 
 	// Filter result by ids, if given.
 	var result []corenetwork.SubnetInfo
@@ -1682,7 +1725,7 @@ func (e *environ) OpenPorts(ctx context.ProviderCallContext, rules firewall.Ingr
 	defer estate.mu.Unlock()
 	for _, r := range rules {
 		if len(r.SourceCIDRs) == 0 {
-			r.SourceCIDRs.Add(firewall.AllNetworksIPV4CIDR)
+			r.SourceCIDRs.Add(allNetworksCIDR(e.ecfg().ipv6Only()))
 		}
 		found := false
 		for _, rule := range estate.globalRules {
@@ -1761,6 +1804,7 @@ type dummyInstance struct {
 	series       string
 	firewallMode string
 	controller   bool
+	ipv6Only     bool
 
 	mu        sync.Mutex
 	addresses []corenetwork.ProviderAddress
@@ -1859,7 +1903,7 @@ func (inst *dummyInstance) OpenPorts(ctx context.ProviderCallContext, machineId
 	}
 	for _, newRule := range rules {
 		if len(newRule.SourceCIDRs) == 0 {
-			newRule.SourceCIDRs.Add(firewall.AllNetworksIPV4CIDR)
+			newRule.SourceCIDRs.Add(allNetworksCIDR(inst.ipv6Only))
 		}
 		found := false
 
@@ -348,6 +348,53 @@ func (s *suite) TestSubnets(c *gc.C) {
 	c.Assert(netInfo, gc.HasLen, 0)
 }
 
+func (s *suite) TestNetworkInterfacesIPv6Only(c *gc.C) {
+	s.PatchValue(&s.TestConfig, s.TestConfig.Merge(testing.Attrs{"ipv6-only": true}))
+	e := s.bootstrapTestEnviron(c)
+	defer func() {
+		err := e.Destroy(s.callCtx)
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	infoList, err := e.NetworkInterfaces(s.callCtx, []instance.Id{instance.Id("i-42")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infoList, gc.HasLen, 1)
+	info := infoList[0]
+	c.Assert(info, gc.HasLen, 3)
+
+	c.Check(info[0].CIDR, gc.Equals, "2001:db8:10::/64")
+	c.Check(info[0].Addresses, jc.DeepEquals, corenetwork.ProviderAddresses{
+		corenetwork.NewProviderAddress("2001:db8:10::2"),
+	})
+	c.Check(info[0].GatewayAddress, jc.DeepEquals, corenetwork.NewProviderAddress("2001:db8:10::1"))
+	c.Check(info[1].CIDR, gc.Equals, "2001:db8:20::/64")
+	c.Check(info[2].CIDR, gc.Equals, "2001:db8:30::/64")
+	for _, nic := range info {
+		c.Check(nic.Addresses[0].Type, gc.Equals, corenetwork.IPv6Address)
+		c.Check(nic.Addresses[0].Scope, gc.Equals, corenetwork.ScopePublic)
+	}
+}
+
+func (s *suite) TestSubnetsIPv6Only(c *gc.C) {
+	s.PatchValue(&s.TestConfig, s.TestConfig.Merge(testing.Attrs{"ipv6-only": true}))
+	e := s.bootstrapTestEnviron(c)
+	defer func() {
+		err := e.Destroy(s.callCtx)
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	netInfo, err := e.Subnets(s.callCtx, "i-foo", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(netInfo, jc.DeepEquals, []corenetwork.SubnetInfo{{
+		CIDR:              "2001:db8:10::/64",
+		ProviderId:        "dummy-private",
+		AvailabilityZones: []string{"zone1", "zone2"},
+	}, {
+		CIDR:       "2001:db8:20::/64",
+		ProviderId: "dummy-public",
+	}})
+}
+
 func assertInterfaces(c *gc.C, e environs.Environ, opc chan dummy.Operation, expectInstId instance.Id, expectInfo corenetwork.InterfaceInfos) {
 	select {
 	case op := <-opc:
@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	"encoding/base64"
 	"fmt"
 	"math/rand"
 	"net"
@@ -12,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/juju/clock"
 	"github.com/juju/collections/set"
@@ -63,6 +65,11 @@ var (
 	// instances in an environment.
 	aliveInstanceStates = []string{"pending", "running"}
 
+	// deadInstanceStates are the states which are additionally listed
+	// when looking up specific instances, so that spot instances
+	// reclaimed by AWS can be reported as interrupted.
+	deadInstanceStates = []string{"shutting-down", "terminated", "stopping", "stopped"}
+
 	// Ensure that environ implements FirewallFeatureQuerier.
 	_ environs.FirewallFeatureQuerier = (*environ)(nil)
 )
@@ -74,6 +81,7 @@ type ec2Client interface {
 	DescribeInstanceTypeOfferings(*ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
 	DescribeInstanceTypes(*ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error)
 	DescribeSpotPriceHistory(*ec2.DescribeSpotPriceHistoryInput) (*ec2.DescribeSpotPriceHistoryOutput, error)
+	RunInstances(*ec2.RunInstancesInput) (*ec2.Reservation, error)
 }
 
 var _ ec2Client = (*ec2.EC2)(nil)
@@ -193,6 +201,12 @@ var unsupportedConstraints = []string{
 	// use virt-type in StartInstances
 	constraints.VirtType,
 	constraints.AllocatePublicIP,
+	constraints.MemRequest,
+	constraints.MemLimit,
+	constraints.CpuRequest,
+	constraints.CpuLimit,
+	constraints.EphemeralStorageLimit,
+	constraints.ContainerResources,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -566,7 +580,11 @@ func (e *environ) StartInstance(
 	_ = callback(status.Allocating,
 		fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
 
-	instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	if args.Constraints.HasSpot() {
+		instResp, err = e.runSpotInstances(ctx, runArgs, callback)
+	} else {
+		instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	}
 	if err != nil {
 		if !isZoneOrSubnetConstrainedError(err) {
 			err = annotateWrapError(err, "cannot run instances")
@@ -1028,6 +1046,110 @@ func _runInstances(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.Ru
 	return resp, maybeConvertCredentialError(err, ctx)
 }
 
+// runSpotInstances starts the instances described by ri as one-time spot
+// instances. The amz library has no notion of instance market options, so
+// the request is made through the AWS SDK and the started instances are
+// then read back through amz for the remainder of StartInstance.
+func (e *environ) runSpotInstances(ctx context.ProviderCallContext, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+	input := spotRunInstancesInput(ri)
+	var (
+		reservation *ec2.Reservation
+		err         error
+	)
+	try := 1
+	for a := shortAttempt.Start(); a.Next(); {
+		c(status.Allocating, fmt.Sprintf("Start spot instance attempt %d", try), nil)
+		reservation, err = e.ec2Client.RunInstances(input)
+		if err == nil || !isNotFoundError(err) {
+			break
+		}
+		try++
+	}
+	if err != nil {
+		return nil, maybeConvertCredentialError(err, ctx)
+	}
+
+	var ids []string
+	for _, inst := range reservation.Instances {
+		ids = append(ids, aws.StringValue(inst.InstanceId))
+	}
+	resp, err := e.ec2.Instances(ids, nil)
+	if err != nil {
+		return nil, maybeConvertCredentialError(err, ctx)
+	}
+	result := &amzec2.RunInstancesResp{
+		ReservationId: aws.StringValue(reservation.ReservationId),
+		OwnerId:       aws.StringValue(reservation.OwnerId),
+	}
+	for _, r := range resp.Reservations {
+		result.Instances = append(result.Instances, r.Instances...)
+	}
+	return result, nil
+}
+
+// spotRunInstancesInput converts amz RunInstances arguments into the
+// equivalent AWS SDK input, requesting a one-time spot instance that is
+// terminated when interrupted.
+func spotRunInstancesInput(ri *amzec2.RunInstances) *ec2.RunInstancesInput {
+	input := &ec2.RunInstancesInput{
+		ImageId:      aws.String(ri.ImageId),
+		InstanceType: aws.String(ri.InstanceType),
+		MinCount:     aws.Int64(int64(ri.MinCount)),
+		MaxCount:     aws.Int64(int64(ri.MaxCount)),
+		InstanceMarketOptions: &ec2.InstanceMarketOptionsRequest{
+			MarketType: aws.String(ec2.MarketTypeSpot),
+			SpotOptions: &ec2.SpotMarketOptions{
+				SpotInstanceType:             aws.String(ec2.SpotInstanceTypeOneTime),
+				InstanceInterruptionBehavior: aws.String(ec2.InstanceInterruptionBehaviorTerminate),
+			},
+		},
+	}
+	if len(ri.UserData) > 0 {
+		input.UserData = aws.String(base64.StdEncoding.EncodeToString(ri.UserData))
+	}
+	if ri.AvailZone != "" {
+		input.Placement = &ec2.Placement{AvailabilityZone: aws.String(ri.AvailZone)}
+	}
+	if ri.SubnetId != "" {
+		input.SubnetId = aws.String(ri.SubnetId)
+	}
+	for _, g := range ri.SecurityGroups {
+		if g.Id != "" {
+			input.SecurityGroupIds = append(input.SecurityGroupIds, aws.String(g.Id))
+		} else {
+			input.SecurityGroups = append(input.SecurityGroups, aws.String(g.Name))
+		}
+	}
+	for _, b := range ri.BlockDeviceMappings {
+		mapping := &ec2.BlockDeviceMapping{
+			DeviceName: aws.String(b.DeviceName),
+		}
+		if b.VirtualName != "" {
+			mapping.VirtualName = aws.String(b.VirtualName)
+			input.BlockDeviceMappings = append(input.BlockDeviceMappings, mapping)
+			continue
+		}
+		ebs := &ec2.EbsBlockDevice{
+			DeleteOnTermination: aws.Bool(b.DeleteOnTermination),
+		}
+		if b.SnapshotId != "" {
+			ebs.SnapshotId = aws.String(b.SnapshotId)
+		}
+		if b.VolumeType != "" {
+			ebs.VolumeType = aws.String(b.VolumeType)
+		}
+		if b.VolumeSize > 0 {
+			ebs.VolumeSize = aws.Int64(b.VolumeSize)
+		}
+		if b.IOPS > 0 {
+			ebs.Iops = aws.Int64(b.IOPS)
+		}
+		mapping.Ebs = ebs
+		input.BlockDeviceMappings = append(input.BlockDeviceMappings, mapping)
+	}
+	return input
+}
+
 func (e *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
 	return errors.Trace(e.terminateInstances(ctx, ids))
 }
@@ -1080,7 +1202,7 @@ func (e *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id)
 			}
 		}
 		filters := []*ec2.Filter{
-			makeFilter("instance-state-name", aliveInstanceStates...),
+			makeFilter("instance-state-name", append(aliveInstanceStates, deadInstanceStates...)...),
 			makeFilter("instance-id", need...),
 			makeModelFilter(e.uuid()),
 		}
@@ -1132,6 +1254,9 @@ func (e *environ) gatherInstances(
 				if *inst.InstanceId != string(id) {
 					continue
 				}
+				if !isAliveInstance(inst) && !isSpotInterrupted(inst) {
+					continue
+				}
 				insts[i] = &sdkInstance{e: e, i: inst}
 				n++
 			}
@@ -6,6 +6,8 @@ package ec2
 import (
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	gomock "github.com/golang/mock/gomock"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -15,6 +17,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -407,3 +410,71 @@ func (*Suite) TestGetValidSubnetZoneMapIntersectionSelectsCorrectIndex(c *gc.C)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(subnetZones, gc.DeepEquals, allSubnetZones[1])
 }
+
+func (*Suite) TestSpotRunInstancesInput(c *gc.C) {
+	input := spotRunInstancesInput(&amzec2.RunInstances{
+		ImageId:      "ami-1",
+		InstanceType: "m5.large",
+		MinCount:     1,
+		MaxCount:     1,
+		UserData:     []byte("#cloud-config"),
+		AvailZone:    "az-1",
+		SubnetId:     "subnet-1",
+		SecurityGroups: []amzec2.SecurityGroup{
+			{Id: "sg-1", Name: "juju-model"},
+			{Name: "juju-model-0"},
+		},
+		BlockDeviceMappings: []amzec2.BlockDeviceMapping{{
+			DeviceName:          "/dev/sda1",
+			VolumeSize:          8,
+			VolumeType:          "gp2",
+			DeleteOnTermination: true,
+		}, {
+			DeviceName:  "/dev/sdb",
+			VirtualName: "ephemeral0",
+		}},
+	})
+
+	c.Check(aws.StringValue(input.InstanceMarketOptions.MarketType), gc.Equals, "spot")
+	c.Check(aws.StringValue(input.InstanceMarketOptions.SpotOptions.SpotInstanceType), gc.Equals, "one-time")
+	c.Check(aws.StringValue(input.InstanceMarketOptions.SpotOptions.InstanceInterruptionBehavior), gc.Equals, "terminate")
+	c.Check(aws.StringValue(input.ImageId), gc.Equals, "ami-1")
+	c.Check(aws.StringValue(input.InstanceType), gc.Equals, "m5.large")
+	c.Check(aws.StringValue(input.UserData), gc.Equals, "I2Nsb3VkLWNvbmZpZw==")
+	c.Check(aws.StringValue(input.Placement.AvailabilityZone), gc.Equals, "az-1")
+	c.Check(aws.StringValue(input.SubnetId), gc.Equals, "subnet-1")
+	c.Check(aws.StringValueSlice(input.SecurityGroupIds), jc.DeepEquals, []string{"sg-1"})
+	c.Check(aws.StringValueSlice(input.SecurityGroups), jc.DeepEquals, []string{"juju-model-0"})
+	c.Assert(input.BlockDeviceMappings, gc.HasLen, 2)
+	c.Check(aws.Int64Value(input.BlockDeviceMappings[0].Ebs.VolumeSize), gc.Equals, int64(8))
+	c.Check(aws.StringValue(input.BlockDeviceMappings[0].Ebs.VolumeType), gc.Equals, "gp2")
+	c.Check(aws.BoolValue(input.BlockDeviceMappings[0].Ebs.DeleteOnTermination), jc.IsTrue)
+	c.Check(input.BlockDeviceMappings[1].Ebs, gc.IsNil)
+	c.Check(aws.StringValue(input.BlockDeviceMappings[1].VirtualName), gc.Equals, "ephemeral0")
+}
+
+func (*Suite) TestSpotInstanceInterruptedStatus(c *gc.C) {
+	inst := &sdkInstance{i: &ec2.Instance{
+		InstanceId:        aws.String("i-1"),
+		InstanceLifecycle: aws.String("spot"),
+		State:             &ec2.InstanceState{Name: aws.String("terminated")},
+		StateReason: &ec2.StateReason{
+			Code:    aws.String("Server.SpotInstanceTermination"),
+			Message: aws.String("Server.SpotInstanceTermination: Spot instance termination"),
+		},
+	}}
+	c.Check(isSpotInterrupted(inst.i), jc.IsTrue)
+	st := inst.Status(context.NewCloudCallContext())
+	c.Check(st.Status, gc.Equals, status.Interrupted)
+	c.Check(st.Message, gc.Equals, "Server.SpotInstanceTermination: Spot instance termination")
+
+	// A spot instance terminated by the user is not interrupted.
+	inst.i.StateReason.Code = aws.String("Client.UserInitiatedShutdown")
+	c.Check(isSpotInterrupted(inst.i), jc.IsFalse)
+	c.Check(inst.Status(context.NewCloudCallContext()).Status, gc.Equals, status.Empty)
+
+	// Nor is an on-demand instance.
+	inst.i.StateReason.Code = aws.String("Server.SpotInstanceTermination")
+	inst.i.InstanceLifecycle = nil
+	c.Check(isSpotInterrupted(inst.i), jc.IsFalse)
+}
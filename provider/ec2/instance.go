@@ -5,7 +5,9 @@ package ec2
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	amzec2 "gopkg.in/amz.v3/ec2"
 
@@ -151,6 +153,13 @@ func (inst *sdkInstance) Status(ctx context.ProviderCallContext) instance.Status
 		return instance.Status{Status: status.Empty}
 	}
 
+	if isSpotInterrupted(inst.i) {
+		return instance.Status{
+			Status:  status.Interrupted,
+			Message: aws.StringValue(inst.i.StateReason.Message),
+		}
+	}
+
 	// pending | running | shutting-down | terminated | stopping | stopped
 	var jujuStatus status.Status
 	switch *inst.i.State.Name {
@@ -235,3 +244,30 @@ func (inst *sdkInstance) IngressRules(ctx context.ProviderCallContext, machineId
 	}
 	return ranges, nil
 }
+
+// isAliveInstance reports whether the instance is in one of the
+// aliveInstanceStates.
+func isAliveInstance(inst *ec2.Instance) bool {
+	if inst.State == nil {
+		return false
+	}
+	name := aws.StringValue(inst.State.Name)
+	for _, state := range aliveInstanceStates {
+		if name == state {
+			return true
+		}
+	}
+	return false
+}
+
+// isSpotInterrupted reports whether the instance is a spot instance that
+// AWS has stopped or terminated in order to reclaim its capacity.
+func isSpotInterrupted(inst *ec2.Instance) bool {
+	if aws.StringValue(inst.InstanceLifecycle) != ec2.InstanceLifecycleTypeSpot {
+		return false
+	}
+	if isAliveInstance(inst) || inst.StateReason == nil {
+		return false
+	}
+	return strings.HasPrefix(aws.StringValue(inst.StateReason.Code), "Server.SpotInstance")
+}
@@ -146,3 +146,46 @@ func (*mockEC2Session) DescribeSpotPriceHistory(*ec2.DescribeSpotPriceHistoryInp
 		SpotPriceHistory: nil,
 	}, nil
 }
+
+func (s *mockEC2Session) RunInstances(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+	// Proxy the RunInstances request through to the amz test server, which
+	// has no notion of instance market options.
+	ri := &amzec2.RunInstances{
+		ImageId:      aws.StringValue(input.ImageId),
+		InstanceType: aws.StringValue(input.InstanceType),
+		MinCount:     int(aws.Int64Value(input.MinCount)),
+		MaxCount:     int(aws.Int64Value(input.MaxCount)),
+		SubnetId:     aws.StringValue(input.SubnetId),
+	}
+	if input.Placement != nil {
+		ri.AvailZone = aws.StringValue(input.Placement.AvailabilityZone)
+	}
+	for _, id := range input.SecurityGroupIds {
+		ri.SecurityGroups = append(ri.SecurityGroups, amzec2.SecurityGroup{Id: aws.StringValue(id)})
+	}
+	for _, name := range input.SecurityGroups {
+		ri.SecurityGroups = append(ri.SecurityGroups, amzec2.SecurityGroup{Name: aws.StringValue(name)})
+	}
+
+	client := s.newInstancesClient()
+	resp, err := client.RunInstances(ri)
+	if err != nil {
+		return nil, err
+	}
+	res := &ec2.Reservation{
+		ReservationId: aws.String(resp.ReservationId),
+		OwnerId:       aws.String(resp.OwnerId),
+	}
+	for _, i := range resp.Instances {
+		i := i
+		res.Instances = append(res.Instances, &ec2.Instance{
+			InstanceId:        &i.InstanceId,
+			InstanceType:      &i.InstanceType,
+			InstanceLifecycle: aws.String(ec2.InstanceLifecycleTypeSpot),
+			State: &ec2.InstanceState{
+				Name: &i.State.Name,
+			},
+		})
+	}
+	return res, nil
+}
@@ -202,6 +202,7 @@ func (env *environ) newRawInstance(
 		Tags:              tags,
 		AvailabilityZone:  args.AvailabilityZone,
 		AllocatePublicIP:  allocatePublicIP,
+		Preemptible:       args.Constraints.HasSpot(),
 	})
 	if err != nil {
 		// We currently treat all AddInstance failures
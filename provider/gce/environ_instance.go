@@ -26,6 +26,14 @@ var instStatuses = []string{
 	google.StatusRunning,
 }
 
+// preemptedStatuses are the statuses of preemptible instances that GCE
+// has stopped to reclaim capacity. Instances looks these up in addition
+// to instStatuses so that such instances can be reported as interrupted.
+var preemptedStatuses = []string{
+	google.StatusStopping,
+	google.StatusTerminated,
+}
+
 // Instances returns the available instances in the environment that
 // match the provided instance IDs. For IDs that did not match any
 // instances, the result at the corresponding index will be nil. In that
@@ -36,7 +44,8 @@ func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id
 		return nil, environs.ErrNoInstances
 	}
 
-	all, err := getInstances(env, ctx)
+	statuses := append(append([]string(nil), instStatuses...), preemptedStatuses...)
+	all, err := getInstances(env, ctx, statuses...)
 	if err != nil {
 		// We don't return the error since we need to pack one instance
 		// for each ID into the result. If there is a problem then we
@@ -51,9 +60,10 @@ func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id
 	results := make([]instances.Instance, len(ids))
 	for i, id := range ids {
 		inst := findInst(id, all)
-		if inst != nil {
-			numFound++
+		if inst == nil || !isAliveOrPreempted(inst) {
+			continue
 		}
+		numFound++
 		results[i] = inst
 	}
 
@@ -67,6 +77,24 @@ func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id
 	return results, err
 }
 
+// isAliveOrPreempted reports whether the instance is in one of the
+// instStatuses, or is a preemptible instance that has been preempted.
+func isAliveOrPreempted(inst instances.Instance) bool {
+	envInst, ok := inst.(*environInstance)
+	if !ok {
+		return true
+	}
+	if envInst.base.Preemptible {
+		return true
+	}
+	for _, st := range instStatuses {
+		if envInst.base.Status() == st {
+			return true
+		}
+	}
+	return false
+}
+
 var getInstances = func(env *environ, ctx context.ProviderCallContext, statusFilters ...string) ([]instances.Instance, error) {
 	return env.instances(ctx, statusFilters...)
 }
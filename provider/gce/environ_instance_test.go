@@ -37,6 +37,23 @@ func (s *environInstSuite) TestInstances(c *gc.C) {
 	c.Check(insts, jc.DeepEquals, []instances.Instance{spam, eggs, ham})
 }
 
+func (s *environInstSuite) TestInstancesPreempted(c *gc.C) {
+	spam := s.NewInstance(c, "spam")
+	stopped := s.NewBaseInstance(c, "ham")
+	stopped.InstanceSummary.Status = google.StatusTerminated
+	preempted := s.NewBaseInstance(c, "eggs")
+	preempted.InstanceSummary.Status = google.StatusTerminated
+	preempted.InstanceSummary.Preemptible = true
+	eggs := s.NewInstanceFromBase(preempted)
+	s.FakeEnviron.Insts = []instances.Instance{spam, s.NewInstanceFromBase(stopped), eggs}
+
+	ids := []instance.Id{"spam", "ham", "eggs"}
+	insts, err := s.Env.Instances(s.CallCtx, ids)
+	c.Assert(errors.Cause(err), gc.Equals, environs.ErrPartialInstances)
+
+	c.Check(insts, jc.DeepEquals, []instances.Instance{spam, nil, eggs})
+}
+
 func (s *environInstSuite) TestInstancesEmptyArg(c *gc.C) {
 	_, err := s.Env.Instances(s.CallCtx, nil)
 
@@ -34,6 +34,12 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.MemRequest,
+	constraints.MemLimit,
+	constraints.CpuRequest,
+	constraints.CpuLimit,
+	constraints.EphemeralStorageLimit,
+	constraints.ContainerResources,
 }
 
 // instanceTypeConstraints defines the fields defined on each of the
@@ -21,6 +21,10 @@ var (
 	MatchesPrefix       = matchesPrefix
 )
 
+func RawInstance(spec InstanceSpec) *compute.Instance {
+	return spec.raw()
+}
+
 func SetRawConn(conn *Connection, svc service) {
 	conn.service = svc
 }
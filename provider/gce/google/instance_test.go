@@ -36,6 +36,28 @@ func (s *instanceSuite) TestNewInstanceNoSpec(c *gc.C) {
 	c.Check(spec, gc.IsNil)
 }
 
+func (s *instanceSuite) TestNewInstancePreemptible(c *gc.C) {
+	spec := s.InstanceSpec
+	spec.Preemptible = true
+	raw := google.RawInstance(spec)
+
+	c.Assert(raw.Scheduling, gc.NotNil)
+	c.Check(raw.Scheduling.Preemptible, jc.IsTrue)
+	c.Check(*raw.Scheduling.AutomaticRestart, jc.IsFalse)
+	c.Check(raw.Scheduling.OnHostMaintenance, gc.Equals, "TERMINATE")
+
+	inst := google.NewInstanceRaw(raw, &spec)
+	c.Check(inst.Preemptible, jc.IsTrue)
+}
+
+func (s *instanceSuite) TestNewInstanceNotPreemptible(c *gc.C) {
+	raw := google.RawInstance(s.InstanceSpec)
+
+	c.Check(raw.Scheduling, gc.IsNil)
+	inst := google.NewInstanceRaw(raw, &s.InstanceSpec)
+	c.Check(inst.Preemptible, jc.IsFalse)
+}
+
 func (s *instanceSuite) TestInstanceRootDiskGB(c *gc.C) {
 	size := s.Instance.RootDiskGB()
 
@@ -37,6 +37,12 @@ func (inst *environInstance) Id() instance.Id {
 // Status implements instances.Instance.
 func (inst *environInstance) Status(ctx context.ProviderCallContext) instance.Status {
 	instStatus := inst.base.Status()
+	if inst.base.Preemptible && (instStatus == google.StatusStopping || instStatus == google.StatusTerminated) {
+		return instance.Status{
+			Status:  status.Interrupted,
+			Message: "instance preempted",
+		}
+	}
 	var jujuStatus status.Status
 	switch instStatus {
 	case "PROVISIONING", "STAGING":
@@ -8,6 +8,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/provider/gce"
 	"github.com/juju/juju/provider/gce/google"
 )
@@ -40,6 +41,17 @@ func (s *instanceSuite) TestStatus(c *gc.C) {
 	s.CheckNoAPI(c)
 }
 
+func (s *instanceSuite) TestStatusPreempted(c *gc.C) {
+	base := s.NewBaseInstance(c, "spam")
+	base.InstanceSummary.Preemptible = true
+	base.InstanceSummary.Status = google.StatusTerminated
+	inst := s.NewInstanceFromBase(base)
+
+	instStatus := inst.Status(s.CallCtx)
+	c.Check(instStatus.Status, gc.Equals, status.Interrupted)
+	c.Check(instStatus.Message, gc.Equals, "instance preempted")
+}
+
 func (s *instanceSuite) TestAddresses(c *gc.C) {
 	addresses, err := s.Instance.Addresses(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,459 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// The Hetzner Cloud API is documented at https://docs.hetzner.cloud/.
+// Only the subset of it used by the provider is implemented here.
+
+const defaultEndpoint = "https://api.hetzner.cloud/v1"
+
+// Server statuses, as reported by the API.
+const (
+	serverInitializing = "initializing"
+	serverStarting     = "starting"
+	serverRunning      = "running"
+	serverStopping     = "stopping"
+	serverOff          = "off"
+	serverDeleting     = "deleting"
+)
+
+type server struct {
+	ID         int64             `json:"id"`
+	Name       string            `json:"name"`
+	Status     string            `json:"status"`
+	PublicNet  publicNet         `json:"public_net"`
+	PrivateNet []privateNet      `json:"private_net"`
+	ServerType serverType        `json:"server_type"`
+	Datacenter datacenter        `json:"datacenter"`
+	Labels     map[string]string `json:"labels"`
+}
+
+type publicNet struct {
+	IPv4 *ipAddress `json:"ipv4"`
+	IPv6 *ipAddress `json:"ipv6"`
+}
+
+type ipAddress struct {
+	IP string `json:"ip"`
+}
+
+type privateNet struct {
+	IP string `json:"ip"`
+}
+
+type datacenter struct {
+	Name     string   `json:"name"`
+	Location location `json:"location"`
+}
+
+type location struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	NetworkZone string `json:"network_zone"`
+}
+
+type serverType struct {
+	ID           int64             `json:"id"`
+	Name         string            `json:"name"`
+	Cores        int               `json:"cores"`
+	Memory       float64           `json:"memory"`
+	Disk         int               `json:"disk"`
+	Architecture string            `json:"architecture"`
+	Deprecated   bool              `json:"deprecated"`
+	Prices       []serverTypePrice `json:"prices"`
+}
+
+type serverTypePrice struct {
+	Location    string `json:"location"`
+	PriceHourly price  `json:"price_hourly"`
+}
+
+type price struct {
+	Net string `json:"net"`
+}
+
+type image struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	OSFlavor     string `json:"os_flavor"`
+	OSVersion    string `json:"os_version"`
+	Architecture string `json:"architecture"`
+}
+
+type firewallRule struct {
+	Direction string   `json:"direction"`
+	Protocol  string   `json:"protocol"`
+	Port      string   `json:"port,omitempty"`
+	SourceIPs []string `json:"source_ips"`
+}
+
+type firewallResource struct {
+	Type   string             `json:"type"`
+	Server *firewallServerRef `json:"server,omitempty"`
+}
+
+type firewallServerRef struct {
+	ID int64 `json:"id"`
+}
+
+type hcloudFirewall struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	Labels    map[string]string  `json:"labels"`
+	Rules     []firewallRule     `json:"rules"`
+	AppliedTo []firewallResource `json:"applied_to"`
+}
+
+type volume struct {
+	ID          int64             `json:"id"`
+	Name        string            `json:"name"`
+	Size        int               `json:"size"`
+	Server      *int64            `json:"server"`
+	Location    location          `json:"location"`
+	LinuxDevice string            `json:"linux_device"`
+	Labels      map[string]string `json:"labels"`
+}
+
+type createServerOpts struct {
+	Name       string              `json:"name"`
+	ServerType string              `json:"server_type"`
+	Image      string              `json:"image"`
+	Location   string              `json:"location,omitempty"`
+	UserData   string              `json:"user_data,omitempty"`
+	Labels     map[string]string   `json:"labels,omitempty"`
+	Firewalls  []serverFirewallRef `json:"firewalls,omitempty"`
+}
+
+type serverFirewallRef struct {
+	Firewall int64 `json:"firewall"`
+}
+
+type createVolumeOpts struct {
+	Name     string            `json:"name"`
+	Size     int               `json:"size"`
+	Location string            `json:"location"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+// apiError is the error returned by the API for failed requests.
+type apiError struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+}
+
+// isNotFound reports whether err is an API error for a missing resource.
+func isNotFound(err error) bool {
+	apiErr, ok := errors.Cause(err).(*apiError)
+	return ok && apiErr.Code == "not_found"
+}
+
+// isUnauthorized reports whether err is an API error caused by an
+// invalid or insufficiently privileged API token.
+func isUnauthorized(err error) bool {
+	apiErr, ok := errors.Cause(err).(*apiError)
+	return ok && (apiErr.Code == "unauthorized" || apiErr.Code == "forbidden" || apiErr.StatusCode == http.StatusUnauthorized)
+}
+
+type listMeta struct {
+	Pagination struct {
+		NextPage *int `json:"next_page"`
+	} `json:"pagination"`
+}
+
+// nextPage returns the number of the next page of a list, or 0 if
+// there are no more pages.
+func (m listMeta) nextPage() int {
+	if m.Pagination.NextPage == nil {
+		return 0
+	}
+	return *m.Pagination.NextPage
+}
+
+// client is a minimal client for the Hetzner Cloud API.
+type client struct {
+	endpoint string
+	token    string
+	http     *http.Client
+}
+
+func newClient(endpoint, token string) *client {
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	return &client{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    token,
+		http:     http.DefaultClient,
+	}
+}
+
+func (c *client) do(method, path string, query url.Values, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var errResp struct {
+			Error apiError `json:"error"`
+		}
+		if err := json.Unmarshal(data, &errResp); err != nil || errResp.Error.Code == "" {
+			return &apiError{
+				StatusCode: resp.StatusCode,
+				Code:       strings.ToLower(strings.Replace(http.StatusText(resp.StatusCode), " ", "_", -1)),
+				Message:    fmt.Sprintf("%s %s: %s", method, path, resp.Status),
+			}
+		}
+		errResp.Error.StatusCode = resp.StatusCode
+		return &errResp.Error
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return errors.Trace(json.Unmarshal(data, out))
+}
+
+// pageQuery returns a copy of query requesting the given page.
+func pageQuery(query url.Values, page int) url.Values {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Set("page", strconv.Itoa(page))
+	q.Set("per_page", "50")
+	return q
+}
+
+// labelQuery returns a query selecting resources with all the given labels.
+func labelQuery(labels map[string]string) url.Values {
+	if len(labels) == 0 {
+		return nil
+	}
+	var selectors []string
+	for k, v := range labels {
+		selectors = append(selectors, k+"="+v)
+	}
+	return url.Values{"label_selector": {strings.Join(selectors, ",")}}
+}
+
+// servers returns the servers with all the given labels.
+func (c *client) servers(labels map[string]string) ([]server, error) {
+	var result []server
+	for page := 1; page != 0; {
+		var resp struct {
+			Servers []server `json:"servers"`
+			Meta    listMeta `json:"meta"`
+		}
+		if err := c.do(http.MethodGet, "/servers", pageQuery(labelQuery(labels), page), nil, &resp); err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, resp.Servers...)
+		page = resp.Meta.nextPage()
+	}
+	return result, nil
+}
+
+func (c *client) createServer(opts createServerOpts) (server, error) {
+	var resp struct {
+		Server server `json:"server"`
+	}
+	err := c.do(http.MethodPost, "/servers", nil, opts, &resp)
+	return resp.Server, errors.Trace(err)
+}
+
+func (c *client) deleteServer(id int64) error {
+	return errors.Trace(c.do(http.MethodDelete, fmt.Sprintf("/servers/%d", id), nil, nil, nil))
+}
+
+func (c *client) setServerLabels(id int64, labels map[string]string) error {
+	body := map[string]interface{}{"labels": labels}
+	return errors.Trace(c.do(http.MethodPut, fmt.Sprintf("/servers/%d", id), nil, body, nil))
+}
+
+func (c *client) locations() ([]location, error) {
+	var resp struct {
+		Locations []location `json:"locations"`
+	}
+	err := c.do(http.MethodGet, "/locations", nil, nil, &resp)
+	return resp.Locations, errors.Trace(err)
+}
+
+func (c *client) serverTypes() ([]serverType, error) {
+	var result []serverType
+	for page := 1; page != 0; {
+		var resp struct {
+			ServerTypes []serverType `json:"server_types"`
+			Meta        listMeta     `json:"meta"`
+		}
+		if err := c.do(http.MethodGet, "/server_types", pageQuery(nil, page), nil, &resp); err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, resp.ServerTypes...)
+		page = resp.Meta.nextPage()
+	}
+	return result, nil
+}
+
+// systemImage returns the system image with the given name for the
+// given architecture.
+func (c *client) systemImage(name, arch string) (image, error) {
+	query := url.Values{
+		"type":         {"system"},
+		"name":         {name},
+		"architecture": {arch},
+	}
+	var resp struct {
+		Images []image `json:"images"`
+	}
+	if err := c.do(http.MethodGet, "/images", query, nil, &resp); err != nil {
+		return image{}, errors.Trace(err)
+	}
+	if len(resp.Images) == 0 {
+		return image{}, errors.NotFoundf("%s image %q", arch, name)
+	}
+	return resp.Images[0], nil
+}
+
+func (c *client) firewalls(labels map[string]string) ([]hcloudFirewall, error) {
+	var result []hcloudFirewall
+	for page := 1; page != 0; {
+		var resp struct {
+			Firewalls []hcloudFirewall `json:"firewalls"`
+			Meta      listMeta         `json:"meta"`
+		}
+		if err := c.do(http.MethodGet, "/firewalls", pageQuery(labelQuery(labels), page), nil, &resp); err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, resp.Firewalls...)
+		page = resp.Meta.nextPage()
+	}
+	return result, nil
+}
+
+func (c *client) createFirewall(name string, labels map[string]string, rules []firewallRule) (hcloudFirewall, error) {
+	if rules == nil {
+		rules = []firewallRule{}
+	}
+	body := map[string]interface{}{
+		"name":   name,
+		"labels": labels,
+		"rules":  rules,
+	}
+	var resp struct {
+		Firewall hcloudFirewall `json:"firewall"`
+	}
+	err := c.do(http.MethodPost, "/firewalls", nil, body, &resp)
+	return resp.Firewall, errors.Trace(err)
+}
+
+func (c *client) setFirewallRules(id int64, rules []firewallRule) error {
+	if rules == nil {
+		rules = []firewallRule{}
+	}
+	body := map[string]interface{}{"rules": rules}
+	return errors.Trace(c.do(http.MethodPost, fmt.Sprintf("/firewalls/%d/actions/set_rules", id), nil, body, nil))
+}
+
+func (c *client) setFirewallLabels(id int64, labels map[string]string) error {
+	body := map[string]interface{}{"labels": labels}
+	return errors.Trace(c.do(http.MethodPut, fmt.Sprintf("/firewalls/%d", id), nil, body, nil))
+}
+
+func (c *client) deleteFirewall(id int64) error {
+	return errors.Trace(c.do(http.MethodDelete, fmt.Sprintf("/firewalls/%d", id), nil, nil, nil))
+}
+
+func (c *client) volumes(labels map[string]string) ([]volume, error) {
+	var result []volume
+	for page := 1; page != 0; {
+		var resp struct {
+			Volumes []volume `json:"volumes"`
+			Meta    listMeta `json:"meta"`
+		}
+		if err := c.do(http.MethodGet, "/volumes", pageQuery(labelQuery(labels), page), nil, &resp); err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, resp.Volumes...)
+		page = resp.Meta.nextPage()
+	}
+	return result, nil
+}
+
+func (c *client) volume(id int64) (volume, error) {
+	var resp struct {
+		Volume volume `json:"volume"`
+	}
+	err := c.do(http.MethodGet, fmt.Sprintf("/volumes/%d", id), nil, nil, &resp)
+	return resp.Volume, errors.Trace(err)
+}
+
+func (c *client) createVolume(opts createVolumeOpts) (volume, error) {
+	var resp struct {
+		Volume volume `json:"volume"`
+	}
+	err := c.do(http.MethodPost, "/volumes", nil, opts, &resp)
+	return resp.Volume, errors.Trace(err)
+}
+
+func (c *client) attachVolume(id, serverID int64) error {
+	body := map[string]interface{}{"server": serverID, "automount": false}
+	return errors.Trace(c.do(http.MethodPost, fmt.Sprintf("/volumes/%d/actions/attach", id), nil, body, nil))
+}
+
+func (c *client) detachVolume(id int64) error {
+	return errors.Trace(c.do(http.MethodPost, fmt.Sprintf("/volumes/%d/actions/detach", id), nil, struct{}{}, nil))
+}
+
+func (c *client) setVolumeLabels(id int64, labels map[string]string) error {
+	body := map[string]interface{}{"labels": labels}
+	return errors.Trace(c.do(http.MethodPut, fmt.Sprintf("/volumes/%d", id), nil, body, nil))
+}
+
+func (c *client) deleteVolume(id int64) error {
+	return errors.Trace(c.do(http.MethodDelete, fmt.Sprintf("/volumes/%d", id), nil, nil, nil))
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+
+	api    *fakeAPI
+	client *client
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = newFakeAPI()
+	s.AddCleanup(func(*gc.C) { s.api.Close() })
+	s.client = newClient(s.api.URL+"/v1/", fakeToken)
+}
+
+func (s *clientSuite) TestPagination(c *gc.C) {
+	s.api.perPage = 2
+	types, err := s.client.serverTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, gc.HasLen, 5)
+	c.Check(types[4].Name, gc.Equals, "cx10")
+}
+
+func (s *clientSuite) TestLabelSelector(c *gc.C) {
+	s.api.servers[1] = &server{ID: 1, Labels: map[string]string{"a": "1", "b": "2"}}
+	s.api.servers[2] = &server{ID: 2, Labels: map[string]string{"a": "1"}}
+	s.api.servers[3] = &server{ID: 3}
+
+	servers, err := s.client.servers(map[string]string{"a": "1", "b": "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(servers, gc.HasLen, 1)
+	c.Check(servers[0].ID, gc.Equals, int64(1))
+
+	servers, err = s.client.servers(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(servers, gc.HasLen, 3)
+}
+
+func (s *clientSuite) TestErrors(c *gc.C) {
+	err := s.client.deleteServer(42)
+	c.Assert(err, gc.ErrorMatches, `not_found error \(not_found\)`)
+	c.Check(isNotFound(err), jc.IsTrue)
+	c.Check(isUnauthorized(err), jc.IsFalse)
+
+	s.client.token = "wrong"
+	_, err = s.client.locations()
+	c.Check(isUnauthorized(err), jc.IsTrue)
+	c.Check(isNotFound(err), jc.IsFalse)
+}
+
+func (s *clientSuite) TestSystemImage(c *gc.C) {
+	img, err := s.client.systemImage("ubuntu-20.04", "arm")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(img.ID, gc.Equals, int64(21))
+
+	_, err = s.client.systemImage("ubuntu-14.04", "x86")
+	c.Assert(err, gc.ErrorMatches, `x86 image "ubuntu-14.04" not found`)
+}
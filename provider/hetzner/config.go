@@ -0,0 +1,49 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+)
+
+var configFields = schema.Fields{}
+
+var configDefaultFields = schema.Defaults{}
+
+func validateConfig(cfg *config.Config, old *environConfig) (*environConfig, error) {
+	var oldCfg *config.Config
+	if old != nil {
+		oldCfg = old.Config
+	}
+	if err := config.Validate(cfg, oldCfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	newAttrs, err := cfg.ValidateUnknownAttrs(configFields, configDefaultFields)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Ingress rules are managed with a firewall per machine.
+	if cfg.FirewallMode() == config.FwGlobal {
+		return nil, errors.New("global firewall mode is not supported")
+	}
+
+	newCfg, err := cfg.Apply(newAttrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &environConfig{
+		Config: newCfg,
+		attrs:  newAttrs,
+	}, nil
+}
+
+type environConfig struct {
+	*config.Config
+	attrs map[string]interface{}
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+type environProviderCredentials struct{}
+
+const (
+	credAttrToken = "token"
+
+	// tokenEnvVar is the environment variable used by the hcloud
+	// command line client to hold the API token.
+	tokenEnvVar = "HCLOUD_TOKEN"
+)
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.OAuth2AuthType: {{
+			Name: credAttrToken,
+			CredentialAttr: cloud.CredentialAttr{
+				Description: "project API token (read & write)",
+				Hidden:      true,
+			},
+		}},
+	}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	token := os.Getenv(tokenEnvVar)
+	if token == "" {
+		return nil, errors.NotFoundf("credentials")
+	}
+	cred := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+		credAttrToken: token,
+	})
+	cred.Label = "hetzner credential from " + tokenEnvVar
+	return &cloud.CloudCredential{
+		AuthCredentials: map[string]cloud.Credential{
+			"default": cred,
+		},
+	}, nil
+}
+
+// FinalizeCredential is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
+	return &args.Credential, nil
+}
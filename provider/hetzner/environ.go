@@ -0,0 +1,257 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/common"
+)
+
+type environ struct {
+	name      string
+	cloud     environscloudspec.CloudSpec
+	client    *client
+	namespace instance.Namespace
+
+	lock sync.Mutex
+	ecfg *environConfig
+}
+
+var _ environs.Environ = (*environ)(nil)
+
+// Name returns the Environ's name.
+func (env *environ) Name() string {
+	return env.name
+}
+
+// Provider returns the EnvironProvider that created this Environ.
+func (*environ) Provider() environs.EnvironProvider {
+	return providerInstance
+}
+
+// SetConfig updates the Environ's configuration.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	ecfg, err := validateConfig(cfg, env.ecfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if env.namespace == nil {
+		namespace, err := instance.NewNamespace(cfg.UUID())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		env.namespace = namespace
+	}
+	env.ecfg = ecfg
+	return nil
+}
+
+// Config returns the configuration data with which the Environ was created.
+func (env *environ) Config() *config.Config {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg.Config
+}
+
+// PrepareForBootstrap is part of the Environ interface.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext, controllerName string) error {
+	return nil
+}
+
+// Create is part of the Environ interface.
+func (env *environ) Create(ctx context.ProviderCallContext, args environs.CreateParams) error {
+	if _, err := env.client.locations(); err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return errors.Annotate(err, "verifying credentials")
+	}
+	return nil
+}
+
+// Bootstrap is part of the Environ interface.
+func (env *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.ProviderCallContext, params environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return common.Bootstrap(ctx, env, callCtx, params)
+}
+
+// modelLabels returns the labels identifying resources of the model.
+func (env *environ) modelLabels() map[string]string {
+	return map[string]string{tags.JujuModel: env.Config().UUID()}
+}
+
+// ControllerInstances is part of the Environ interface.
+func (env *environ) ControllerInstances(ctx context.ProviderCallContext, controllerUUID string) ([]instance.Id, error) {
+	servers, err := env.client.servers(map[string]string{
+		tags.JujuController:   controllerUUID,
+		tags.JujuIsController: "true",
+	})
+	if err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return nil, errors.Trace(err)
+	}
+	if len(servers) == 0 {
+		return nil, environs.ErrNotBootstrapped
+	}
+	ids := make([]instance.Id, len(servers))
+	for i, s := range servers {
+		ids[i] = serverInstanceId(s.ID)
+	}
+	return ids, nil
+}
+
+// AdoptResources is part of the Environ interface. It relabels all
+// the model's servers, volumes and firewalls with the new controller.
+func (env *environ) AdoptResources(ctx context.ProviderCallContext, controllerUUID string, fromVersion version.Number) error {
+	modelLabels := env.modelLabels()
+	relabel := func(labels map[string]string) map[string]string {
+		result := make(map[string]string, len(labels))
+		for k, v := range labels {
+			result[k] = v
+		}
+		result[tags.JujuController] = controllerUUID
+		return result
+	}
+
+	servers, err := env.client.servers(modelLabels)
+	if err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return errors.Annotate(err, "listing servers")
+	}
+	for _, s := range servers {
+		if err := env.client.setServerLabels(s.ID, relabel(s.Labels)); err != nil {
+			return errors.Annotatef(err, "updating labels of server %d", s.ID)
+		}
+	}
+	volumes, err := env.client.volumes(modelLabels)
+	if err != nil {
+		return errors.Annotate(err, "listing volumes")
+	}
+	for _, v := range volumes {
+		if err := env.client.setVolumeLabels(v.ID, relabel(v.Labels)); err != nil {
+			return errors.Annotatef(err, "updating labels of volume %d", v.ID)
+		}
+	}
+	firewalls, err := env.client.firewalls(modelLabels)
+	if err != nil {
+		return errors.Annotate(err, "listing firewalls")
+	}
+	for _, fw := range firewalls {
+		if err := env.client.setFirewallLabels(fw.ID, relabel(fw.Labels)); err != nil {
+			return errors.Annotatef(err, "updating labels of firewall %d", fw.ID)
+		}
+	}
+	return nil
+}
+
+// Destroy is part of the Environ interface.
+func (env *environ) Destroy(ctx context.ProviderCallContext) error {
+	if err := common.Destroy(env, ctx); err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return errors.Trace(err)
+	}
+	return errors.Trace(env.deleteFirewalls(ctx, env.modelLabels(), false))
+}
+
+// DestroyController is part of the Environ interface. As well as the
+// controller model, it destroys the servers, volumes and firewalls of
+// all the models hosted by the controller.
+func (env *environ) DestroyController(ctx context.ProviderCallContext, controllerUUID string) error {
+	if err := env.Destroy(ctx); err != nil {
+		return errors.Trace(err)
+	}
+
+	controllerLabels := map[string]string{tags.JujuController: controllerUUID}
+	servers, err := env.client.servers(controllerLabels)
+	if err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return errors.Annotate(err, "listing hosted model servers")
+	}
+	for _, s := range servers {
+		if err := env.client.deleteServer(s.ID); err != nil && !isNotFound(err) {
+			return errors.Annotatef(err, "deleting server %d", s.ID)
+		}
+	}
+	volumes, err := env.client.volumes(controllerLabels)
+	if err != nil {
+		return errors.Annotate(err, "listing hosted model volumes")
+	}
+	for _, v := range volumes {
+		if v.Server != nil {
+			if err := env.client.detachVolume(v.ID); err != nil && !isNotFound(err) {
+				return errors.Annotatef(err, "detaching volume %d", v.ID)
+			}
+		}
+		if err := env.client.deleteVolume(v.ID); err != nil && !isNotFound(err) {
+			return errors.Annotatef(err, "deleting volume %d", v.ID)
+		}
+	}
+	return errors.Trace(env.deleteFirewalls(ctx, controllerLabels, false))
+}
+
+// PrecheckInstance is part of the Environ interface.
+func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
+	if args.Placement != "" {
+		return errors.Errorf("unknown placement directive: %s", args.Placement)
+	}
+	if !args.Constraints.HasInstanceType() {
+		return nil
+	}
+	types, err := env.instanceTypes(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, itype := range types {
+		if itype.Name == *args.Constraints.InstanceType {
+			return nil
+		}
+	}
+	return errors.Errorf("invalid instance type %q", *args.Constraints.InstanceType)
+}
+
+func serverInstanceId(id int64) instance.Id {
+	return instance.Id(strconv.FormatInt(id, 10))
+}
+
+// resourceLabels returns the resource tags that can be used as labels.
+func resourceLabels(resourceTags map[string]string) map[string]string {
+	labels := make(map[string]string)
+	for k, v := range resourceTags {
+		if isLabel(k) && isLabel(v) {
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
+// isLabel reports whether s can be used as a label key or value. They
+// are limited to 63 characters, which must be alphanumeric or one of
+// '-', '_' and '.', and must start and end with an alphanumeric.
+func isLabel(s string) bool {
+	if len(s) > 63 {
+		return false
+	}
+	for i, c := range s {
+		alnum := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+		if alnum {
+			continue
+		}
+		if (c == '-' || c == '_' || c == '.') && i > 0 && i < len(s)-1 {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,265 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/cloudconfig/providerinit/renderers"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/tools"
+)
+
+// StartInstance is specified in the InstanceBroker interface.
+func (env *environ) StartInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) (_ *environs.StartInstanceResult, err error) {
+	if args.InstanceConfig == nil {
+		return nil, errors.New("instance configuration is nil")
+	}
+	if args.AvailabilityZone != "" {
+		return nil, errors.NotSupportedf("availability zones")
+	}
+	defer func() {
+		if err != nil {
+			common.HandleCredentialError(isUnauthorized, err, ctx)
+		}
+	}()
+
+	itype, err := env.findInstanceType(ctx, args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	arch := itype.Arches[0]
+	osVersion, err := series.UbuntuSeriesVersion(args.InstanceConfig.Series)
+	if err != nil {
+		return nil, errors.NotSupportedf("series %q", args.InstanceConfig.Series)
+	}
+	img, err := env.client.systemImage("ubuntu-"+osVersion, imageArch(arch))
+	if err != nil {
+		return nil, errors.Annotate(err, "finding image")
+	}
+
+	envTools, err := args.Tools.Match(tools.Filter{Arch: arch})
+	if err != nil {
+		return nil, errors.Errorf("chosen architecture %v not present in %v", arch, args.Tools.Arches())
+	}
+	if err := args.InstanceConfig.SetTools(envTools); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, env.Config()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	_ = args.StatusCallback(status.Allocating, "Making user data", nil)
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, nil, HetznerRenderer{})
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing user data")
+	}
+
+	var apiPorts []int
+	isController := args.InstanceConfig.Controller != nil
+	if isController {
+		apiPorts = append(apiPorts, args.InstanceConfig.Controller.Config.APIPort())
+		if args.InstanceConfig.Controller.Config.AutocertDNSName() != "" {
+			// Open port 80 as well as it handles Let's Encrypt HTTP challenge.
+			apiPorts = append(apiPorts, 80)
+		}
+	}
+	_ = args.StatusCallback(status.Allocating, "Setting up firewalls", nil)
+	machineID := args.InstanceConfig.MachineId
+	modelFirewall, err := env.ensureModelFirewall(args.ControllerUUID, apiPorts)
+	if err != nil {
+		return nil, errors.Annotate(err, "setting up model firewall")
+	}
+	// Remove any firewall left over from an earlier attempt to start
+	// this machine.
+	_ = env.deleteFirewalls(ctx, env.machineFirewallLabels(machineID), true)
+	machineFirewall, err := env.createMachineFirewall(args.ControllerUUID, machineID)
+	if err != nil {
+		return nil, errors.Annotate(err, "setting up machine firewall")
+	}
+	defer func() {
+		if err != nil {
+			if err := env.client.deleteFirewall(machineFirewall.ID); err != nil {
+				logger.Warningf("cannot delete firewall %q: %v", machineFirewall.Name, err)
+			}
+		}
+	}()
+
+	hostname, err := env.namespace.Hostname(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	labels := resourceLabels(args.InstanceConfig.Tags)
+	labels[tags.JujuMachine] = machineID
+	_ = args.StatusCallback(status.Allocating, "Creating server", nil)
+	srv, err := env.client.createServer(createServerOpts{
+		Name:       hostname,
+		ServerType: itype.Name,
+		Image:      strconv.FormatInt(img.ID, 10),
+		Location:   env.cloud.Region,
+		UserData:   string(userData),
+		Labels:     labels,
+		Firewalls: []serverFirewallRef{
+			{Firewall: modelFirewall.ID},
+			{Firewall: machineFirewall.ID},
+		},
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating server")
+	}
+	logger.Infof("started server %d (%s) for machine %q", srv.ID, itype.Name, machineID)
+
+	cores := itype.CpuCores
+	mem := itype.Mem
+	rootDisk := itype.RootDisk
+	return &environs.StartInstanceResult{
+		Instance: &hetznerInstance{server: srv, env: env},
+		Hardware: &instance.HardwareCharacteristics{
+			Arch:     &arch,
+			CpuCores: &cores,
+			Mem:      &mem,
+			RootDisk: &rootDisk,
+		},
+	}, nil
+}
+
+// findInstanceType returns the cheapest server type that matches the
+// constraints and for which there are agent binaries.
+func (env *environ) findInstanceType(ctx context.ProviderCallContext, args environs.StartInstanceParams) (instances.InstanceType, error) {
+	types, err := env.instanceTypes(ctx)
+	if err != nil {
+		return instances.InstanceType{}, errors.Trace(err)
+	}
+	matching, err := instances.MatchingInstanceTypes(types, env.cloud.Region, args.Constraints)
+	if err != nil {
+		return instances.InstanceType{}, errors.Trace(err)
+	}
+	toolsArches := args.Tools.Arches()
+	for _, itype := range matching {
+		for _, a := range toolsArches {
+			if itype.Arches[0] == a {
+				return itype, nil
+			}
+		}
+	}
+	return instances.InstanceType{}, errors.NotFoundf("server type for architectures %v matching %q", toolsArches, args.Constraints)
+}
+
+// StopInstances is specified in the InstanceBroker interface.
+func (env *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	servers, err := env.client.servers(env.modelLabels())
+	if err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return errors.Annotate(err, "listing servers")
+	}
+	machineIDs := make(map[instance.Id]string, len(servers))
+	for _, s := range servers {
+		machineIDs[serverInstanceId(s.ID)] = s.Labels[tags.JujuMachine]
+	}
+
+	for _, id := range ids {
+		serverID, err := strconv.ParseInt(string(id), 10, 64)
+		if err != nil {
+			return errors.NotValidf("instance id %q", id)
+		}
+		if err := env.client.deleteServer(serverID); err != nil && !isNotFound(err) {
+			common.HandleCredentialError(isUnauthorized, err, ctx)
+			return errors.Annotatef(err, "deleting server %d", serverID)
+		}
+		if machineID := machineIDs[id]; machineID != "" {
+			// The firewall may still be applied to the server while it
+			// is being deleted, in which case it is removed when the
+			// machine is next started or the model is destroyed.
+			_ = env.deleteFirewalls(ctx, env.machineFirewallLabels(machineID), true)
+		}
+	}
+	return nil
+}
+
+// AllInstances is specified in the InstanceLister interface.
+func (env *environ) AllInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
+	return env.modelInstances(ctx, nil)
+}
+
+// AllRunningInstances is specified in the InstanceLister interface.
+func (env *environ) AllRunningInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
+	return env.modelInstances(ctx, func(s server) bool {
+		switch s.Status {
+		case serverInitializing, serverStarting, serverRunning:
+			return true
+		}
+		return false
+	})
+}
+
+func (env *environ) modelInstances(ctx context.ProviderCallContext, include func(server) bool) ([]instances.Instance, error) {
+	servers, err := env.client.servers(env.modelLabels())
+	if err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return nil, errors.Annotate(err, "listing servers")
+	}
+	var result []instances.Instance
+	for _, s := range servers {
+		if include == nil || include(s) {
+			result = append(result, &hetznerInstance{server: s, env: env})
+		}
+	}
+	return result, nil
+}
+
+// Instances is specified in the InstanceLister interface.
+func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	all, err := env.AllInstances(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byID := make(map[instance.Id]instances.Instance, len(all))
+	for _, inst := range all {
+		byID[inst.Id()] = inst
+	}
+
+	var found int
+	result := make([]instances.Instance, len(ids))
+	for i, id := range ids {
+		if inst, ok := byID[id]; ok {
+			result[i] = inst
+			found++
+		}
+	}
+	if found == 0 {
+		return nil, environs.ErrNoInstances
+	} else if found != len(ids) {
+		return result, environs.ErrPartialInstances
+	}
+	return result, nil
+}
+
+// HetznerRenderer renders cloud-init user data for Hetzner Cloud
+// servers, which accept it unencoded.
+type HetznerRenderer struct{}
+
+// Render is part of the renderers.ProviderRenderer interface.
+func (HetznerRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
+	switch os {
+	case jujuos.Ubuntu:
+		return renderers.RenderYAML(cfg)
+	default:
+		return nil, errors.Errorf("cannot encode userdata for OS: %s", os.String())
+	}
+}
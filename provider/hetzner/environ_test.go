@@ -0,0 +1,394 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"strconv"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/v2/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type environSuite struct {
+	testing.IsolationSuite
+
+	api            *fakeAPI
+	env            *environ
+	callCtx        context.ProviderCallContext
+	controllerUUID string
+}
+
+var _ = gc.Suite(&environSuite{})
+
+func (s *environSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.api = newFakeAPI()
+	s.AddCleanup(func(*gc.C) { s.api.Close() })
+
+	provider, err := environs.Provider("hetzner")
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := environs.Open(provider, environs.OpenParams{
+		Cloud:  fakeCloudSpec(s.api.URL + "/v1"),
+		Config: newConfig(c, nil),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.env = env.(*environ)
+	s.callCtx = context.NewCloudCallContext()
+	s.controllerUUID = coretesting.FakeControllerConfig().ControllerUUID()
+}
+
+func (s *environSuite) startInstanceParams(c *gc.C, machineID string, cons constraints.Value) environs.StartInstanceParams {
+	tools := coretools.List{{
+		Version: version.MustParseBinary("2.9.0-focal-amd64"),
+		URL:     "https://example.com/amd64",
+	}, {
+		Version: version.MustParseBinary("2.9.0-focal-arm64"),
+		URL:     "https://example.com/arm64",
+	}}
+	apiInfo := &api.Info{
+		Addrs:    []string{"localhost:17777"},
+		CACert:   coretesting.CACert,
+		Password: "admin",
+		Tag:      names.NewMachineTag(machineID),
+		ModelTag: coretesting.ModelTag,
+	}
+	icfg, err := instancecfg.NewInstanceConfig(
+		names.NewControllerTag(s.controllerUUID), machineID, "yanonce", imagemetadata.ReleasedStream, "focal", apiInfo,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	icfg.Tags = map[string]string{
+		tags.JujuModel:      coretesting.ModelTag.Id(),
+		tags.JujuController: s.controllerUUID,
+		"owner":             "not a label value",
+	}
+	return environs.StartInstanceParams{
+		ControllerUUID: s.controllerUUID,
+		InstanceConfig: icfg,
+		Tools:          tools,
+		Constraints:    cons,
+		StatusCallback: func(status.Status, string, map[string]interface{}) error { return nil },
+	}
+}
+
+func (s *environSuite) startInstance(c *gc.C, machineID string, cons constraints.Value) *environs.StartInstanceResult {
+	result, err := s.env.StartInstance(s.callCtx, s.startInstanceParams(c, machineID, cons))
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
+func (s *environSuite) TestStartInstance(c *gc.C) {
+	result := s.startInstance(c, "1", constraints.Value{})
+
+	c.Assert(s.api.servers, gc.HasLen, 1)
+	srv := s.api.servers[mustParseId(c, string(result.Instance.Id()))]
+	c.Check(srv.Name, gc.Equals, "juju-"+coretesting.ModelTag.Id()[30:]+"-1")
+	// The cheapest amd64 server type available in the region is chosen.
+	c.Check(srv.ServerType.Name, gc.Equals, "cx11")
+	c.Check(srv.Datacenter.Location.Name, gc.Equals, "fsn1")
+	c.Check(srv.Labels, jc.DeepEquals, map[string]string{
+		tags.JujuModel:      coretesting.ModelTag.Id(),
+		tags.JujuController: s.controllerUUID,
+		tags.JujuMachine:    "1",
+	})
+	c.Check(s.api.userData[srv.ID], gc.Matches, "(?s)#cloud-config\n.*")
+
+	c.Check(*result.Hardware.Arch, gc.Equals, arch.AMD64)
+	c.Check(*result.Hardware.CpuCores, gc.Equals, uint64(1))
+	c.Check(*result.Hardware.Mem, gc.Equals, uint64(2048))
+	c.Check(*result.Hardware.RootDisk, gc.Equals, uint64(20480))
+	c.Check(result.Instance.Status(s.callCtx).Status, gc.Equals, status.Provisioning)
+	addrs, err := result.Instance.Addresses(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addrs, gc.HasLen, 1)
+	c.Check(addrs[0].Scope, gc.Equals, corenetwork.ScopePublic)
+
+	// The server is started with the model and machine firewalls.
+	c.Assert(s.api.firewalls, gc.HasLen, 2)
+	for _, fw := range s.api.firewalls {
+		c.Check(fw.AppliedTo, gc.HasLen, 1)
+		if fw.Name == s.env.modelFirewallName() {
+			c.Check(fw.Rules, jc.DeepEquals, []firewallRule{tcpRule(22)})
+		} else {
+			c.Check(fw.Name, gc.Equals, s.env.machineFirewallName("1"))
+			c.Check(fw.Rules, gc.HasLen, 0)
+		}
+	}
+}
+
+func (s *environSuite) TestStartInstanceConstraints(c *gc.C) {
+	result := s.startInstance(c, "1", constraints.MustParse("arch=arm64"))
+	c.Check(*result.Hardware.Arch, gc.Equals, arch.ARM64)
+	srv := s.api.servers[mustParseId(c, string(result.Instance.Id()))]
+	c.Check(srv.ServerType.Name, gc.Equals, "cax11")
+
+	result = s.startInstance(c, "2", constraints.MustParse("mem=3G arch=amd64"))
+	srv = s.api.servers[mustParseId(c, string(result.Instance.Id()))]
+	c.Check(srv.ServerType.Name, gc.Equals, "cx21")
+}
+
+func (s *environSuite) TestStartInstanceNoMatchingType(c *gc.C) {
+	_, err := s.env.StartInstance(s.callCtx, s.startInstanceParams(c, "1", constraints.MustParse("mem=64G")))
+	c.Assert(err, gc.ErrorMatches, "no instance types in fsn1 matching constraints .*")
+	c.Assert(s.api.servers, gc.HasLen, 0)
+}
+
+func (s *environSuite) TestStartInstanceController(c *gc.C) {
+	cons := constraints.Value{}
+	icfg, err := instancecfg.NewBootstrapInstanceConfig(
+		coretesting.FakeControllerConfig(), cons, cons, "focal", "", nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	args := s.startInstanceParams(c, "0", cons)
+	icfg.Controller.Config["api-port"] = 17777
+	icfg.Tags = map[string]string{
+		tags.JujuModel:        coretesting.ModelTag.Id(),
+		tags.JujuController:   s.controllerUUID,
+		tags.JujuIsController: "true",
+	}
+	args.InstanceConfig = icfg
+
+	result, err := s.env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+	srv := s.api.servers[mustParseId(c, string(result.Instance.Id()))]
+	c.Check(srv.Labels[tags.JujuIsController], gc.Equals, "true")
+
+	fw, ok := s.api.firewalls[s.modelFirewall(c).ID]
+	c.Assert(ok, jc.IsTrue)
+	c.Check(fw.Rules, jc.DeepEquals, []firewallRule{tcpRule(22), tcpRule(17777)})
+
+	ids, err := s.env.ControllerInstances(s.callCtx, s.controllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, jc.DeepEquals, []instance.Id{result.Instance.Id()})
+}
+
+func (s *environSuite) TestControllerInstancesNotBootstrapped(c *gc.C) {
+	s.startInstance(c, "1", constraints.Value{})
+	_, err := s.env.ControllerInstances(s.callCtx, s.controllerUUID)
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
+
+func (s *environSuite) TestInstances(c *gc.C) {
+	// Enough servers to need several pages.
+	var ids []instance.Id
+	for _, machineID := range []string{"1", "2", "3"} {
+		ids = append(ids, s.startInstance(c, machineID, constraints.Value{}).Instance.Id())
+	}
+	// Servers of other models are ignored.
+	s.api.servers[999] = &server{ID: 999, Labels: map[string]string{tags.JujuModel: "other"}}
+
+	all, err := s.env.AllInstances(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(all, gc.HasLen, 3)
+
+	s.api.servers[mustParseId(c, string(ids[1]))].Status = serverOff
+	running, err := s.env.AllRunningInstances(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(running, gc.HasLen, 2)
+
+	insts, err := s.env.Instances(s.callCtx, []instance.Id{ids[2], "999"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(insts, gc.HasLen, 2)
+	c.Check(insts[0].Id(), gc.Equals, ids[2])
+	c.Check(insts[1], gc.IsNil)
+
+	_, err = s.env.Instances(s.callCtx, []instance.Id{"999"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environSuite) TestStopInstances(c *gc.C) {
+	inst1 := s.startInstance(c, "1", constraints.Value{}).Instance
+	inst2 := s.startInstance(c, "2", constraints.Value{}).Instance
+
+	err := s.env.StopInstances(s.callCtx, inst1.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.servers, gc.HasLen, 1)
+	_, ok := s.api.servers[mustParseId(c, string(inst2.Id()))]
+	c.Check(ok, jc.IsTrue)
+
+	// The firewall of the stopped machine is removed.
+	var names []string
+	for _, fw := range s.api.firewalls {
+		names = append(names, fw.Name)
+	}
+	c.Check(names, jc.SameContents, []string{s.env.modelFirewallName(), s.env.machineFirewallName("2")})
+
+	// Stopping a missing instance is not an error.
+	err = s.env.StopInstances(s.callCtx, inst1.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *environSuite) TestIngressRules(c *gc.C) {
+	inst := s.startInstance(c, "1", constraints.Value{}).Instance.(instances.InstanceFirewaller)
+
+	err := inst.OpenPorts(s.callCtx, "1", firewall.IngressRules{
+		firewall.NewIngressRule(corenetwork.MustParsePortRange("80/tcp")),
+		firewall.NewIngressRule(corenetwork.MustParsePortRange("1000-2000/udp"), "10.0.0.0/8"),
+		firewall.NewIngressRule(corenetwork.MustParsePortRange("icmp"), "192.168.0.0/24"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = inst.OpenPorts(s.callCtx, "1", firewall.IngressRules{
+		firewall.NewIngressRule(corenetwork.MustParsePortRange("1000-2000/udp"), "172.16.0.0/12"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err := inst.IngressRules(s.callCtx, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, firewall.IngressRules{
+		firewall.NewIngressRule(corenetwork.MustParsePortRange("icmp"), "192.168.0.0/24"),
+		firewall.NewIngressRule(corenetwork.MustParsePortRange("80/tcp"), allNetworks...),
+		firewall.NewIngressRule(corenetwork.MustParsePortRange("1000-2000/udp"), "10.0.0.0/8", "172.16.0.0/12"),
+	})
+
+	err = inst.ClosePorts(s.callCtx, "1", firewall.IngressRules{
+		firewall.NewIngressRule(corenetwork.MustParsePortRange("80/tcp")),
+		firewall.NewIngressRule(corenetwork.MustParsePortRange("1000-2000/udp"), "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = inst.IngressRules(s.callCtx, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, firewall.IngressRules{
+		firewall.NewIngressRule(corenetwork.MustParsePortRange("icmp"), "192.168.0.0/24"),
+		firewall.NewIngressRule(corenetwork.MustParsePortRange("1000-2000/udp"), "172.16.0.0/12"),
+	})
+
+	// The model firewall is left alone.
+	c.Check(s.modelFirewall(c).Rules, jc.DeepEquals, []firewallRule{tcpRule(22)})
+}
+
+func (s *environSuite) TestInstanceTypes(c *gc.C) {
+	result, err := s.env.InstanceTypes(s.callCtx, constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.CostCurrency, gc.Equals, "EUR")
+	var names []string
+	for _, itype := range result.InstanceTypes {
+		names = append(names, itype.Name)
+	}
+	// Deprecated types and those not offered in the region are excluded.
+	c.Check(names, jc.SameContents, []string{"cx11", "cax11", "cx21"})
+	c.Check(result.InstanceTypes[0].Cost, gc.Equals, uint64(50))
+}
+
+func (s *environSuite) TestConstraintsValidator(c *gc.C) {
+	validator, err := s.env.ConstraintsValidator(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unsupported, err := validator.Validate(constraints.MustParse("instance-type=cx21 spot=true tags=foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unsupported, jc.SameContents, []string{"spot", "tags"})
+
+	_, err = validator.Validate(constraints.MustParse("instance-type=m5.large"))
+	c.Check(err, gc.ErrorMatches, `invalid constraint value: instance-type=m5.large\n.*`)
+	_, err = validator.Validate(constraints.MustParse("arch=s390x"))
+	c.Check(err, gc.ErrorMatches, `invalid constraint value: arch=s390x\n.*`)
+}
+
+func (s *environSuite) TestPrecheckInstance(c *gc.C) {
+	err := s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{
+		Constraints: constraints.MustParse("instance-type=cx21"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{
+		Constraints: constraints.MustParse("instance-type=cpx11"),
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid instance type "cpx11"`)
+
+	err = s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{
+		Placement: "zone=fsn1-dc14",
+	})
+	c.Assert(err, gc.ErrorMatches, "unknown placement directive: zone=fsn1-dc14")
+}
+
+func (s *environSuite) TestAdoptResources(c *gc.C) {
+	inst := s.startInstance(c, "1", constraints.Value{}).Instance
+	s.api.volumes[500] = &volume{ID: 500, Labels: map[string]string{tags.JujuModel: coretesting.ModelTag.Id()}}
+
+	err := s.env.AdoptResources(s.callCtx, "new-controller", version.MustParse("2.9.0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.servers[mustParseId(c, string(inst.Id()))].Labels[tags.JujuController], gc.Equals, "new-controller")
+	c.Check(s.api.volumes[500].Labels[tags.JujuController], gc.Equals, "new-controller")
+	for _, fw := range s.api.firewalls {
+		c.Check(fw.Labels[tags.JujuController], gc.Equals, "new-controller")
+	}
+}
+
+func (s *environSuite) TestDestroy(c *gc.C) {
+	s.startInstance(c, "1", constraints.Value{})
+	s.startInstance(c, "2", constraints.Value{})
+	s.api.volumes[500] = &volume{ID: 500, Labels: map[string]string{tags.JujuModel: coretesting.ModelTag.Id()}}
+	s.api.servers[999] = &server{ID: 999, Labels: map[string]string{tags.JujuModel: "other"}}
+
+	err := s.env.Destroy(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.servers, gc.HasLen, 1)
+	c.Check(s.api.volumes, gc.HasLen, 0)
+	c.Check(s.api.firewalls, gc.HasLen, 0)
+}
+
+func (s *environSuite) TestDestroyController(c *gc.C) {
+	s.startInstance(c, "1", constraints.Value{})
+	hosted := map[string]string{tags.JujuModel: "hosted", tags.JujuController: s.controllerUUID}
+	s.api.servers[999] = &server{ID: 999, Labels: hosted}
+	attached := int64(999)
+	s.api.volumes[500] = &volume{ID: 500, Labels: hosted, Server: &attached}
+	s.api.servers[998] = &server{ID: 998, Labels: map[string]string{tags.JujuController: "other"}}
+
+	err := s.env.DestroyController(s.callCtx, s.controllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.servers, gc.HasLen, 1)
+	_, ok := s.api.servers[998]
+	c.Check(ok, jc.IsTrue)
+	c.Check(s.api.volumes, gc.HasLen, 0)
+	c.Check(s.api.firewalls, gc.HasLen, 0)
+}
+
+func (s *environSuite) TestInvalidCredential(c *gc.C) {
+	s.env.client.token = "wrong"
+	invalidated := false
+	s.callCtx = &context.CloudCallContext{
+		InvalidateCredentialFunc: func(string) error {
+			invalidated = true
+			return nil
+		},
+	}
+	_, err := s.env.AllInstances(s.callCtx)
+	c.Assert(err, gc.ErrorMatches, "listing servers: unauthorized error \\(unauthorized\\)")
+	c.Check(invalidated, jc.IsTrue)
+}
+
+func (s *environSuite) modelFirewall(c *gc.C) *hcloudFirewall {
+	for _, fw := range s.api.firewalls {
+		if fw.Name == s.env.modelFirewallName() {
+			return fw
+		}
+	}
+	c.Fatalf("model firewall not found")
+	return nil
+}
+
+func mustParseId(c *gc.C, id string) int64 {
+	serverID, err := strconv.ParseInt(id, 10, 64)
+	c.Assert(err, jc.ErrorIsNil)
+	return serverID
+}
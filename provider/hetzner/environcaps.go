@@ -0,0 +1,53 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2/arch"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/context"
+)
+
+var unsupportedConstraints = []string{
+	constraints.Container,
+	constraints.CpuPower,
+	constraints.Tags,
+	constraints.VirtType,
+	constraints.Spaces,
+	constraints.Zones,
+	constraints.RootDiskSource,
+	constraints.AllocatePublicIP,
+	constraints.MemRequest,
+	constraints.MemLimit,
+	constraints.CpuRequest,
+	constraints.CpuLimit,
+	constraints.EphemeralStorageLimit,
+	constraints.ContainerResources,
+	constraints.Spot,
+}
+
+// ConstraintsValidator returns a Validator instance which
+// is used to validate and merge constraints.
+func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterConflicts(
+		[]string{constraints.InstanceType},
+		[]string{constraints.Mem, constraints.Cores, constraints.RootDisk},
+	)
+	validator.RegisterVocabulary(constraints.Arch, []string{arch.AMD64, arch.ARM64})
+
+	types, err := env.instanceTypes(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(types))
+	for i, itype := range types {
+		names[i] = itype.Name
+	}
+	validator.RegisterVocabulary(constraints.InstanceType, names)
+	return validator, nil
+}
@@ -0,0 +1,427 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const fakeToken = "s3cr3t"
+
+// fakeAPI is an in-process fake of the parts of the Hetzner Cloud API
+// used by the provider.
+type fakeAPI struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	nextID      int64
+	servers     map[int64]*server
+	firewalls   map[int64]*hcloudFirewall
+	volumes     map[int64]*volume
+	serverTypes []serverType
+	images      []image
+	// userData records the user data of created servers.
+	userData map[int64]string
+	// perPage is the size of the pages returned by list requests.
+	perPage int
+}
+
+func newFakeAPI() *fakeAPI {
+	api := &fakeAPI{
+		nextID:    100,
+		servers:   make(map[int64]*server),
+		firewalls: make(map[int64]*hcloudFirewall),
+		volumes:   make(map[int64]*volume),
+		userData:  make(map[int64]string),
+		perPage:   2,
+		serverTypes: []serverType{{
+			ID: 1, Name: "cx11", Cores: 1, Memory: 2, Disk: 20, Architecture: "x86",
+			Prices: []serverTypePrice{{Location: "fsn1", PriceHourly: price{Net: "0.0050"}}},
+		}, {
+			ID: 2, Name: "cx21", Cores: 2, Memory: 4, Disk: 40, Architecture: "x86",
+			Prices: []serverTypePrice{{Location: "fsn1", PriceHourly: price{Net: "0.0080"}}},
+		}, {
+			ID: 3, Name: "cax11", Cores: 2, Memory: 4, Disk: 40, Architecture: "arm",
+			Prices: []serverTypePrice{{Location: "fsn1", PriceHourly: price{Net: "0.0060"}}},
+		}, {
+			ID: 4, Name: "cpx11", Cores: 2, Memory: 2, Disk: 40, Architecture: "x86",
+			Prices: []serverTypePrice{{Location: "ash", PriceHourly: price{Net: "0.0070"}}},
+		}, {
+			ID: 5, Name: "cx10", Cores: 1, Memory: 1, Disk: 20, Architecture: "x86", Deprecated: true,
+			Prices: []serverTypePrice{{Location: "fsn1", PriceHourly: price{Net: "0.0010"}}},
+		}},
+		images: []image{
+			{ID: 20, Name: "ubuntu-20.04", OSFlavor: "ubuntu", OSVersion: "20.04", Architecture: "x86"},
+			{ID: 21, Name: "ubuntu-20.04", OSFlavor: "ubuntu", OSVersion: "20.04", Architecture: "arm"},
+		},
+	}
+	api.Server = httptest.NewServer(http.HandlerFunc(api.serveHTTP))
+	return api
+}
+
+type fakeError struct {
+	status int
+	code   string
+}
+
+func (api *fakeAPI) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") != "Bearer "+fakeToken {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	var body map[string]json.RawMessage
+	if req.Body != nil {
+		_ = json.NewDecoder(req.Body).Decode(&body)
+	}
+	path := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	result, ferr := api.handle(req, path, body)
+	if ferr != nil {
+		writeError(w, ferr.status, ferr.code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if result == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{"code": code, "message": code + " error"},
+	})
+}
+
+var (
+	errNotFound = &fakeError{http.StatusNotFound, "not_found"}
+	errInvalid  = &fakeError{http.StatusBadRequest, "invalid_input"}
+	errInUse    = &fakeError{http.StatusConflict, "resource_in_use"}
+)
+
+func (api *fakeAPI) handle(req *http.Request, path []string, body map[string]json.RawMessage) (interface{}, *fakeError) {
+	if len(path) < 2 || path[0] != "v1" {
+		return nil, errNotFound
+	}
+	resource, path := path[1], path[2:]
+	var id int64
+	if len(path) > 0 {
+		var err error
+		if id, err = strconv.ParseInt(path[0], 10, 64); err != nil {
+			return nil, errNotFound
+		}
+	}
+	selector := parseSelector(req.URL.Query().Get("label_selector"))
+
+	switch {
+	case resource == "locations" && req.Method == http.MethodGet:
+		return map[string]interface{}{"locations": []location{{ID: 1, Name: "fsn1"}}}, nil
+
+	case resource == "server_types" && req.Method == http.MethodGet:
+		var items []interface{}
+		for _, st := range api.serverTypes {
+			items = append(items, st)
+		}
+		return api.page(req, "server_types", items), nil
+
+	case resource == "images" && req.Method == http.MethodGet:
+		q := req.URL.Query()
+		var matching []image
+		for _, img := range api.images {
+			if img.Name == q.Get("name") && img.Architecture == q.Get("architecture") {
+				matching = append(matching, img)
+			}
+		}
+		return map[string]interface{}{"images": matching}, nil
+
+	case resource == "servers" && req.Method == http.MethodGet && len(path) == 0:
+		var items []interface{}
+		for _, id := range sortedIDs(api.servers) {
+			if s := api.servers[id]; labelsMatch(s.Labels, selector) {
+				items = append(items, s)
+			}
+		}
+		return api.page(req, "servers", items), nil
+
+	case resource == "servers" && req.Method == http.MethodPost:
+		var opts createServerOpts
+		decode(body, &opts)
+		for _, s := range api.servers {
+			if s.Name == opts.Name {
+				return nil, &fakeError{http.StatusConflict, "uniqueness_error"}
+			}
+		}
+		var st *serverType
+		for i := range api.serverTypes {
+			if api.serverTypes[i].Name == opts.ServerType {
+				st = &api.serverTypes[i]
+			}
+		}
+		if st == nil {
+			return nil, errInvalid
+		}
+		api.nextID++
+		s := &server{
+			ID:         api.nextID,
+			Name:       opts.Name,
+			Status:     serverInitializing,
+			ServerType: *st,
+			Labels:     opts.Labels,
+			PublicNet: publicNet{
+				IPv4: &ipAddress{IP: fmt.Sprintf("192.0.2.%d", api.nextID%256)},
+			},
+			Datacenter: datacenter{Location: location{Name: opts.Location}},
+		}
+		for _, ref := range opts.Firewalls {
+			fw, ok := api.firewalls[ref.Firewall]
+			if !ok {
+				return nil, errInvalid
+			}
+			fw.AppliedTo = append(fw.AppliedTo, firewallResource{
+				Type: "server", Server: &firewallServerRef{ID: s.ID},
+			})
+		}
+		api.servers[s.ID] = s
+		api.userData[s.ID] = opts.UserData
+		return map[string]interface{}{"server": s}, nil
+
+	case resource == "servers" && req.Method == http.MethodPut:
+		s, ok := api.servers[id]
+		if !ok {
+			return nil, errNotFound
+		}
+		decode(body["labels"], &s.Labels)
+		return map[string]interface{}{"server": s}, nil
+
+	case resource == "servers" && req.Method == http.MethodDelete:
+		if _, ok := api.servers[id]; !ok {
+			return nil, errNotFound
+		}
+		delete(api.servers, id)
+		for _, fw := range api.firewalls {
+			var applied []firewallResource
+			for _, r := range fw.AppliedTo {
+				if r.Server == nil || r.Server.ID != id {
+					applied = append(applied, r)
+				}
+			}
+			fw.AppliedTo = applied
+		}
+		return map[string]interface{}{"action": map[string]string{"status": "running"}}, nil
+
+	case resource == "firewalls" && req.Method == http.MethodGet:
+		var items []interface{}
+		for _, id := range sortedIDs(api.firewalls) {
+			if fw := api.firewalls[id]; labelsMatch(fw.Labels, selector) {
+				items = append(items, fw)
+			}
+		}
+		return api.page(req, "firewalls", items), nil
+
+	case resource == "firewalls" && req.Method == http.MethodPost && len(path) == 0:
+		api.nextID++
+		fw := &hcloudFirewall{ID: api.nextID}
+		decode(body["name"], &fw.Name)
+		decode(body["labels"], &fw.Labels)
+		decode(body["rules"], &fw.Rules)
+		for _, other := range api.firewalls {
+			if other.Name == fw.Name {
+				return nil, &fakeError{http.StatusConflict, "uniqueness_error"}
+			}
+		}
+		api.firewalls[fw.ID] = fw
+		return map[string]interface{}{"firewall": fw}, nil
+
+	case resource == "firewalls" && req.Method == http.MethodPost && len(path) == 3 && path[2] == "set_rules":
+		fw, ok := api.firewalls[id]
+		if !ok {
+			return nil, errNotFound
+		}
+		fw.Rules = nil
+		decode(body["rules"], &fw.Rules)
+		return map[string]interface{}{"actions": []interface{}{}}, nil
+
+	case resource == "firewalls" && req.Method == http.MethodPut:
+		fw, ok := api.firewalls[id]
+		if !ok {
+			return nil, errNotFound
+		}
+		decode(body["labels"], &fw.Labels)
+		return map[string]interface{}{"firewall": fw}, nil
+
+	case resource == "firewalls" && req.Method == http.MethodDelete:
+		fw, ok := api.firewalls[id]
+		if !ok {
+			return nil, errNotFound
+		}
+		if len(fw.AppliedTo) > 0 {
+			return nil, errInUse
+		}
+		delete(api.firewalls, id)
+		return nil, nil
+
+	case resource == "volumes" && req.Method == http.MethodGet && len(path) == 0:
+		var items []interface{}
+		for _, id := range sortedIDs(api.volumes) {
+			if v := api.volumes[id]; labelsMatch(v.Labels, selector) {
+				items = append(items, v)
+			}
+		}
+		return api.page(req, "volumes", items), nil
+
+	case resource == "volumes" && req.Method == http.MethodGet:
+		v, ok := api.volumes[id]
+		if !ok {
+			return nil, errNotFound
+		}
+		return map[string]interface{}{"volume": v}, nil
+
+	case resource == "volumes" && req.Method == http.MethodPost && len(path) == 0:
+		var opts createVolumeOpts
+		decode(body, &opts)
+		if opts.Size < minVolumeSizeInGB {
+			return nil, errInvalid
+		}
+		api.nextID++
+		v := &volume{
+			ID:          api.nextID,
+			Name:        opts.Name,
+			Size:        opts.Size,
+			Labels:      opts.Labels,
+			Location:    location{Name: opts.Location},
+			LinuxDevice: fmt.Sprintf("/dev/disk/by-id/scsi-0HC_Volume_%d", api.nextID),
+		}
+		api.volumes[v.ID] = v
+		return map[string]interface{}{"volume": v}, nil
+
+	case resource == "volumes" && req.Method == http.MethodPost && len(path) == 3:
+		v, ok := api.volumes[id]
+		if !ok {
+			return nil, errNotFound
+		}
+		switch path[2] {
+		case "attach":
+			var serverID int64
+			decode(body["server"], &serverID)
+			if _, ok := api.servers[serverID]; !ok {
+				return nil, errNotFound
+			}
+			v.Server = &serverID
+		case "detach":
+			v.Server = nil
+		default:
+			return nil, errNotFound
+		}
+		return map[string]interface{}{"action": map[string]string{"status": "running"}}, nil
+
+	case resource == "volumes" && req.Method == http.MethodPut:
+		v, ok := api.volumes[id]
+		if !ok {
+			return nil, errNotFound
+		}
+		v.Labels = nil
+		decode(body["labels"], &v.Labels)
+		return map[string]interface{}{"volume": v}, nil
+
+	case resource == "volumes" && req.Method == http.MethodDelete:
+		v, ok := api.volumes[id]
+		if !ok {
+			return nil, errNotFound
+		}
+		if v.Server != nil {
+			return nil, errInUse
+		}
+		delete(api.volumes, id)
+		return nil, nil
+	}
+	return nil, errNotFound
+}
+
+// page returns the requested page of items, in the same shape
+// as the list responses of the API.
+func (api *fakeAPI) page(req *http.Request, key string, items []interface{}) map[string]interface{} {
+	page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * api.perPage
+	end := start + api.perPage
+	var next *int
+	if end < len(items) {
+		n := page + 1
+		next = &n
+	} else {
+		end = len(items)
+	}
+	if start > end {
+		start = end
+	}
+	result := make([]interface{}, 0, end-start)
+	result = append(result, items[start:end]...)
+	return map[string]interface{}{
+		key: result,
+		"meta": map[string]interface{}{
+			"pagination": map[string]interface{}{"page": page, "next_page": next},
+		},
+	}
+}
+
+func decode(data interface{}, out interface{}) {
+	raw, _ := json.Marshal(data)
+	if r, ok := data.(json.RawMessage); ok {
+		raw = r
+	}
+	_ = json.Unmarshal(raw, out)
+}
+
+func parseSelector(s string) map[string]string {
+	result := make(map[string]string)
+	for _, part := range strings.Split(s, ",") {
+		if kv := strings.SplitN(part, "=", 2); len(kv) == 2 {
+			result[kv[0]] = kv[1]
+		}
+	}
+	return result
+}
+
+func labelsMatch(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedIDs(m interface{}) []int64 {
+	var ids []int64
+	switch m := m.(type) {
+	case map[int64]*server:
+		for id := range m {
+			ids = append(ids, id)
+		}
+	case map[int64]*hcloudFirewall:
+		for id := range m {
+			ids = append(ids, id)
+		}
+	case map[int64]*volume:
+		for id := range m {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -0,0 +1,253 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/common"
+)
+
+// Each server is started with two firewalls: the model firewall, which
+// is shared by all the model's servers and allows SSH (and API access
+// on controllers), and a machine firewall that holds the ingress rules
+// managed by the Juju firewaller.
+
+// allNetworks are the source CIDRs used when an ingress
+// rule does not restrict them.
+var allNetworks = []string{firewall.AllNetworksIPV4CIDR, firewall.AllNetworksIPV6CIDR}
+
+func (env *environ) modelFirewallName() string {
+	return "juju-" + env.Config().UUID()
+}
+
+func (env *environ) machineFirewallName(machineID string) string {
+	return fmt.Sprintf("juju-%s-%s", env.Config().UUID(), strings.Replace(machineID, "/", "-", -1))
+}
+
+func (env *environ) machineFirewallLabels(machineID string) map[string]string {
+	labels := env.modelLabels()
+	labels[tags.JujuMachine] = machineID
+	return labels
+}
+
+// ensureModelFirewall returns the model firewall, creating it if
+// necessary, making sure it allows access to SSH and the given
+// API ports.
+func (env *environ) ensureModelFirewall(controllerUUID string, apiPorts []int) (hcloudFirewall, error) {
+	rules := []firewallRule{tcpRule(22)}
+	for _, port := range apiPorts {
+		rules = append(rules, tcpRule(port))
+	}
+
+	labels := env.modelLabels()
+	labels[tags.JujuController] = controllerUUID
+	firewalls, err := env.client.firewalls(env.modelLabels())
+	if err != nil {
+		return hcloudFirewall{}, errors.Trace(err)
+	}
+	name := env.modelFirewallName()
+	for _, fw := range firewalls {
+		if fw.Name != name {
+			continue
+		}
+		missing := false
+		for _, rule := range rules {
+			if !hasRule(fw.Rules, rule) {
+				fw.Rules = append(fw.Rules, rule)
+				missing = true
+			}
+		}
+		if missing {
+			if err := env.client.setFirewallRules(fw.ID, fw.Rules); err != nil {
+				return hcloudFirewall{}, errors.Trace(err)
+			}
+		}
+		return fw, nil
+	}
+	fw, err := env.client.createFirewall(name, labels, rules)
+	return fw, errors.Trace(err)
+}
+
+// createMachineFirewall creates the firewall holding the ingress rules
+// of the given machine.
+func (env *environ) createMachineFirewall(controllerUUID, machineID string) (hcloudFirewall, error) {
+	labels := env.machineFirewallLabels(machineID)
+	labels[tags.JujuController] = controllerUUID
+	fw, err := env.client.createFirewall(env.machineFirewallName(machineID), labels, nil)
+	return fw, errors.Trace(err)
+}
+
+func (env *environ) machineFirewall(machineID string) (hcloudFirewall, error) {
+	firewalls, err := env.client.firewalls(env.machineFirewallLabels(machineID))
+	if err != nil {
+		return hcloudFirewall{}, errors.Trace(err)
+	}
+	if len(firewalls) == 0 {
+		return hcloudFirewall{}, errors.NotFoundf("firewall for machine %q", machineID)
+	}
+	return firewalls[0], nil
+}
+
+// machineIngressRules returns the ingress rules of the given machine.
+func (env *environ) machineIngressRules(ctx context.ProviderCallContext, machineID string) (firewall.IngressRules, error) {
+	fw, err := env.machineFirewall(machineID)
+	if err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return nil, errors.Trace(err)
+	}
+	rules, err := ingressRules(fw.Rules)
+	if err != nil {
+		return nil, errors.Annotatef(err, "firewall %q", fw.Name)
+	}
+	rules.Sort()
+	return rules, nil
+}
+
+// updateMachineFirewall opens and closes the given ingress rules on the
+// machine firewall.
+func (env *environ) updateMachineFirewall(ctx context.ProviderCallContext, machineID string, toOpen, toClose firewall.IngressRules) error {
+	fw, err := env.machineFirewall(machineID)
+	if err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return errors.Trace(err)
+	}
+	current, err := ingressRules(fw.Rules)
+	if err != nil {
+		return errors.Annotatef(err, "firewall %q", fw.Name)
+	}
+
+	cidrs := make(map[corenetwork.PortRange]set.Strings)
+	for _, rule := range current {
+		cidrs[rule.PortRange] = rule.SourceCIDRs
+	}
+	for _, rule := range toOpen {
+		if cidrs[rule.PortRange] == nil {
+			cidrs[rule.PortRange] = set.NewStrings()
+		}
+		cidrs[rule.PortRange] = cidrs[rule.PortRange].Union(ruleSourceCIDRs(rule))
+	}
+	for _, rule := range toClose {
+		if existing, ok := cidrs[rule.PortRange]; ok {
+			cidrs[rule.PortRange] = existing.Difference(ruleSourceCIDRs(rule))
+		}
+	}
+
+	var rules firewall.IngressRules
+	for portRange, sourceCIDRs := range cidrs {
+		if sourceCIDRs.IsEmpty() {
+			continue
+		}
+		rules = append(rules, firewall.NewIngressRule(portRange, sourceCIDRs.SortedValues()...))
+	}
+	rules.Sort()
+	if err := env.client.setFirewallRules(fw.ID, firewallRules(rules)); err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return errors.Annotatef(err, "updating firewall %q", fw.Name)
+	}
+	return nil
+}
+
+// deleteFirewalls deletes the firewalls with the given labels. If
+// bestEffort is true, failures to delete a firewall are logged rather
+// than returned.
+func (env *environ) deleteFirewalls(ctx context.ProviderCallContext, labels map[string]string, bestEffort bool) error {
+	firewalls, err := env.client.firewalls(labels)
+	if err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return errors.Annotate(err, "listing firewalls")
+	}
+	for _, fw := range firewalls {
+		if err := env.client.deleteFirewall(fw.ID); err != nil && !isNotFound(err) {
+			if bestEffort {
+				logger.Warningf("cannot delete firewall %q: %v", fw.Name, err)
+				continue
+			}
+			return errors.Annotatef(err, "deleting firewall %q", fw.Name)
+		}
+	}
+	return nil
+}
+
+func ruleSourceCIDRs(rule firewall.IngressRule) set.Strings {
+	if rule.SourceCIDRs.IsEmpty() {
+		return set.NewStrings(allNetworks...)
+	}
+	return rule.SourceCIDRs
+}
+
+func tcpRule(port int) firewallRule {
+	return firewallRule{
+		Direction: "in",
+		Protocol:  "tcp",
+		Port:      strconv.Itoa(port),
+		SourceIPs: allNetworks,
+	}
+}
+
+func hasRule(rules []firewallRule, rule firewallRule) bool {
+	for _, r := range rules {
+		if r.Direction == rule.Direction && r.Protocol == rule.Protocol && r.Port == rule.Port {
+			return true
+		}
+	}
+	return false
+}
+
+// firewallRules converts Juju ingress rules to firewall rules.
+func firewallRules(rules firewall.IngressRules) []firewallRule {
+	result := make([]firewallRule, 0, len(rules))
+	for _, rule := range rules {
+		fwRule := firewallRule{
+			Direction: "in",
+			Protocol:  rule.PortRange.Protocol,
+			SourceIPs: ruleSourceCIDRs(rule).SortedValues(),
+		}
+		if rule.PortRange.Protocol != "icmp" {
+			fwRule.Port = strconv.Itoa(rule.PortRange.FromPort)
+			if rule.PortRange.ToPort != rule.PortRange.FromPort {
+				fwRule.Port += "-" + strconv.Itoa(rule.PortRange.ToPort)
+			}
+		}
+		result = append(result, fwRule)
+	}
+	return result
+}
+
+// ingressRules converts the inbound firewall rules to Juju ingress rules.
+func ingressRules(rules []firewallRule) (firewall.IngressRules, error) {
+	var result firewall.IngressRules
+	for _, rule := range rules {
+		if rule.Direction != "in" {
+			continue
+		}
+		portRange := corenetwork.PortRange{
+			Protocol: rule.Protocol,
+			FromPort: -1,
+			ToPort:   -1,
+		}
+		switch rule.Protocol {
+		case "tcp", "udp":
+			var err error
+			if portRange, err = corenetwork.ParsePortRange(rule.Port + "/" + rule.Protocol); err != nil {
+				return nil, errors.Trace(err)
+			}
+		case "icmp":
+		default:
+			// Rules for other protocols are not managed by Juju.
+			continue
+		}
+		result = append(result, firewall.NewIngressRule(portRange, rule.SourceIPs...))
+	}
+	return result, nil
+}
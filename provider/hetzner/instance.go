@@ -0,0 +1,79 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+)
+
+type hetznerInstance struct {
+	server server
+	env    *environ
+}
+
+var (
+	_ instances.Instance           = (*hetznerInstance)(nil)
+	_ instances.InstanceFirewaller = (*hetznerInstance)(nil)
+)
+
+// Id returns a provider-generated identifier for the Instance.
+func (i *hetznerInstance) Id() instance.Id {
+	return serverInstanceId(i.server.ID)
+}
+
+// Status returns the provider-specific status for the instance.
+func (i *hetznerInstance) Status(ctx context.ProviderCallContext) instance.Status {
+	var jujuStatus status.Status
+	switch i.server.Status {
+	case serverInitializing, serverStarting:
+		jujuStatus = status.Provisioning
+	case serverRunning:
+		jujuStatus = status.Running
+	default:
+		jujuStatus = status.Empty
+	}
+	return instance.Status{
+		Status:  jujuStatus,
+		Message: i.server.Status,
+	}
+}
+
+// Addresses returns a list of hostnames or ip addresses
+// associated with the instance.
+func (i *hetznerInstance) Addresses(ctx context.ProviderCallContext) (corenetwork.ProviderAddresses, error) {
+	var addrs corenetwork.ProviderAddresses
+	if ipv4 := i.server.PublicNet.IPv4; ipv4 != nil && ipv4.IP != "" {
+		addrs = append(addrs, corenetwork.NewScopedProviderAddress(ipv4.IP, corenetwork.ScopePublic))
+	}
+	for _, net := range i.server.PrivateNet {
+		if net.IP != "" {
+			addrs = append(addrs, corenetwork.NewScopedProviderAddress(net.IP, corenetwork.ScopeCloudLocal))
+		}
+	}
+	return addrs, nil
+}
+
+// OpenPorts opens the given ports on the instance, which
+// should have been started with the given machine id.
+func (i *hetznerInstance) OpenPorts(ctx context.ProviderCallContext, machineID string, rules firewall.IngressRules) error {
+	return i.env.updateMachineFirewall(ctx, machineID, rules, nil)
+}
+
+// ClosePorts closes the given ports on the instance, which
+// should have been started with the given machine id.
+func (i *hetznerInstance) ClosePorts(ctx context.ProviderCallContext, machineID string, rules firewall.IngressRules) error {
+	return i.env.updateMachineFirewall(ctx, machineID, nil, rules)
+}
+
+// IngressRules returns the set of ports open on the instance, which
+// should have been started with the given machine id.
+// The rules are returned as sorted by SortInstanceRules.
+func (i *hetznerInstance) IngressRules(ctx context.ProviderCallContext, machineID string) (firewall.IngressRules, error) {
+	return i.env.machineIngressRules(ctx, machineID)
+}
@@ -0,0 +1,101 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2/arch"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/common"
+)
+
+var _ environs.InstanceTypesFetcher = (*environ)(nil)
+
+// serverArches maps the architecture names used by the API
+// to Juju architectures.
+var serverArches = map[string]string{
+	"x86": arch.AMD64,
+	"arm": arch.ARM64,
+}
+
+// imageArch returns the API architecture name for the given
+// Juju architecture.
+func imageArch(jujuArch string) string {
+	for apiArch, a := range serverArches {
+		if a == jujuArch {
+			return apiArch
+		}
+	}
+	return jujuArch
+}
+
+// InstanceTypes implements InstanceTypesFetcher.
+func (env *environ) InstanceTypes(ctx context.ProviderCallContext, cons constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	types, err := env.instanceTypes(ctx)
+	if err != nil {
+		return instances.InstanceTypesWithCostMetadata{}, errors.Trace(err)
+	}
+	matching, err := instances.MatchingInstanceTypes(types, env.cloud.Region, cons)
+	if err != nil {
+		return instances.InstanceTypesWithCostMetadata{}, errors.Trace(err)
+	}
+	return instances.InstanceTypesWithCostMetadata{
+		InstanceTypes: matching,
+		CostUnit:      "EUR/hour",
+		CostCurrency:  "EUR",
+		CostDivisor:   10000,
+	}, nil
+}
+
+// instanceTypes returns the server types that can be started in the
+// model's region. Cost is the net hourly price in hundredths of a cent.
+func (env *environ) instanceTypes(ctx context.ProviderCallContext) ([]instances.InstanceType, error) {
+	serverTypes, err := env.client.serverTypes()
+	if err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return nil, errors.Annotate(err, "listing server types")
+	}
+	var result []instances.InstanceType
+	for _, st := range serverTypes {
+		a, ok := serverArches[st.Architecture]
+		if !ok || st.Deprecated {
+			continue
+		}
+		cost, ok := serverTypeCost(st, env.cloud.Region)
+		if !ok {
+			// Not available in this region.
+			continue
+		}
+		result = append(result, instances.InstanceType{
+			Id:       strconv.FormatInt(st.ID, 10),
+			Name:     st.Name,
+			Arches:   []string{a},
+			CpuCores: uint64(st.Cores),
+			Mem:      uint64(st.Memory * 1024),
+			RootDisk: uint64(st.Disk) * 1024,
+			Cost:     cost,
+		})
+	}
+	return result, nil
+}
+
+func serverTypeCost(st serverType, location string) (uint64, bool) {
+	for _, p := range st.Prices {
+		if p.Location != location {
+			continue
+		}
+		price, err := strconv.ParseFloat(p.PriceHourly.Net, 64)
+		if err != nil {
+			return 0, false
+		}
+		return uint64(price*10000 + 0.5), true
+	}
+	return 0, false
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hetzner implements a Juju provider for Hetzner Cloud.
+package hetzner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+)
+
+var logger = loggo.GetLogger("juju.provider.hetzner")
+
+const (
+	providerType = "hetzner"
+)
+
+type environProvider struct {
+	environProviderCredentials
+}
+
+var providerInstance = environProvider{}
+
+// check the provider implements environs.EnvironProvider interface
+var _ environs.CloudEnvironProvider = (*environProvider)(nil)
+
+func init() {
+	// This will only happen in binaries that actually import this provider
+	// somewhere. To enable a provider, import it in the "providers/all"
+	// package; please do *not* import individual providers anywhere else,
+	// except in direct tests for that provider.
+	environs.RegisterProvider(providerType, providerInstance)
+}
+
+// Version is part of the EnvironProvider interface.
+func (environProvider) Version() int {
+	return 0
+}
+
+// Open opens the environment and returns it.
+// The configuration must have come from a previously
+// prepared environment.
+func (environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	logger.Infof("opening model %q", args.Config.Name())
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+
+	env := &environ{
+		name:   args.Config.Name(),
+		cloud:  args.Cloud,
+		client: newClient(args.Cloud.Endpoint, args.Cloud.Credential.Attributes()[credAttrToken]),
+	}
+	if err := env.SetConfig(args.Config); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// CloudSchema returns the schema used to validate input for add-cloud.  Since
+// this provider does not support custom clouds, this always returns nil.
+func (p environProvider) CloudSchema() *jsonschema.Schema {
+	return nil
+}
+
+// Ping tests the connection to the cloud, to verify the endpoint is valid.
+func (p environProvider) Ping(ctx context.ProviderCallContext, endpoint string) error {
+	return errors.NotImplementedf("Ping")
+}
+
+// PrepareConfig is defined by EnvironProvider.
+func (environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return args.Config, nil
+}
+
+// Validate ensures that config is a valid configuration for this
+// provider, applying changes to it if necessary, and returns the
+// validated configuration.
+// If old is not nil, it holds the previous environment configuration
+// for consideration when validating changes.
+func (environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	newEcfg, err := validateConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Errorf("invalid config: %v", err)
+	}
+	if old != nil {
+		oldEcfg, err := validateConfig(old, nil)
+		if err != nil {
+			return nil, errors.Errorf("invalid base config: %v", err)
+		}
+		if newEcfg, err = validateConfig(cfg, oldEcfg); err != nil {
+			return nil, errors.Errorf("invalid config change: %v", err)
+		}
+	}
+	return newEcfg.Config, nil
+}
+
+func validateCloudSpec(spec environscloudspec.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Credential == nil {
+		return errors.NotValidf("missing credential")
+	}
+	if authType := spec.Credential.AuthType(); authType != cloud.OAuth2AuthType {
+		return errors.NotSupportedf("%q auth-type", authType)
+	}
+	if spec.Credential.Attributes()[credAttrToken] == "" {
+		return errors.NotValidf("missing %q credential attribute", credAttrToken)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	stdtesting "testing"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/config"
+	envtesting "github.com/juju/juju/environs/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func TestHetzner(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+func newConfig(c *gc.C, attrs coretesting.Attrs) *config.Config {
+	attrs = coretesting.FakeConfig().Merge(coretesting.Attrs{"type": "hetzner"}).Merge(attrs)
+	cfg, err := config.New(config.NoDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func fakeCloudSpec(endpoint string) environscloudspec.CloudSpec {
+	cred := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+		credAttrToken: fakeToken,
+	})
+	return environscloudspec.CloudSpec{
+		Type:       "hetzner",
+		Name:       "hetzner",
+		Region:     "fsn1",
+		Endpoint:   endpoint,
+		Credential: &cred,
+	}
+}
+
+type providerSuite struct {
+	testing.IsolationSuite
+
+	provider environs.EnvironProvider
+	spec     environscloudspec.CloudSpec
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	provider, err := environs.Provider("hetzner")
+	c.Assert(err, jc.ErrorIsNil)
+	s.provider = provider
+	s.spec = fakeCloudSpec("https://api.hetzner.cloud/v1")
+}
+
+func (s *providerSuite) TestOpen(c *gc.C) {
+	env, err := environs.Open(s.provider, environs.OpenParams{
+		Cloud:  s.spec,
+		Config: newConfig(c, nil),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, gc.NotNil)
+}
+
+func (s *providerSuite) TestOpenMissingCredential(c *gc.C) {
+	s.spec.Credential = nil
+	s.testOpenError(c, s.spec, `validating cloud spec: missing credential not valid`)
+}
+
+func (s *providerSuite) TestOpenUnsupportedCredential(c *gc.C) {
+	credential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{})
+	s.spec.Credential = &credential
+	s.testOpenError(c, s.spec, `validating cloud spec: "userpass" auth-type not supported`)
+}
+
+func (s *providerSuite) TestOpenMissingToken(c *gc.C) {
+	credential := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{})
+	s.spec.Credential = &credential
+	s.testOpenError(c, s.spec, `validating cloud spec: missing "token" credential attribute not valid`)
+}
+
+func (s *providerSuite) TestValidateGlobalFirewallMode(c *gc.C) {
+	_, err := s.provider.Validate(newConfig(c, coretesting.Attrs{"firewall-mode": "global"}), nil)
+	c.Assert(err, gc.ErrorMatches, "invalid config: global firewall mode is not supported")
+}
+
+func (s *providerSuite) testOpenError(c *gc.C, spec environscloudspec.CloudSpec, expect string) {
+	_, err := environs.Open(s.provider, environs.OpenParams{
+		Cloud:  spec,
+		Config: newConfig(c, nil),
+	})
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+func (s *providerSuite) TestCredentialSchemas(c *gc.C) {
+	envtesting.AssertProviderAuthTypes(c, s.provider, "oauth2")
+}
+
+func (s *providerSuite) TestOAuth2CredentialsValid(c *gc.C) {
+	envtesting.AssertProviderCredentialsValid(c, s.provider, "oauth2", map[string]string{
+		"token": "s3cr3t",
+	})
+}
+
+func (s *providerSuite) TestDetectCredentials(c *gc.C) {
+	s.PatchEnvironment(tokenEnvVar, "s3cr3t")
+	credentials, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	cred := credentials.AuthCredentials["default"]
+	c.Assert(cred.AuthType(), gc.Equals, cloud.OAuth2AuthType)
+	c.Assert(cred.Attributes(), jc.DeepEquals, map[string]string{"token": "s3cr3t"})
+}
+
+func (s *providerSuite) TestDetectCredentialsNotFound(c *gc.C) {
+	s.PatchEnvironment(tokenEnvVar, "")
+	_, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
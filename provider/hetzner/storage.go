@@ -0,0 +1,300 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	hetznerStorageProviderType = storage.ProviderType("hetzner")
+
+	// minVolumeSizeInGB and maxVolumeSizeInGB are the limits on the
+	// size of a single volume. For more information please see:
+	// https://docs.hetzner.cloud/#volumes-create-a-volume
+	minVolumeSizeInGB = 10
+	maxVolumeSizeInGB = 10240
+)
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (env *environ) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return []storage.ProviderType{hetznerStorageProviderType}, nil
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == hetznerStorageProviderType {
+		return &storageProvider{env: env}, nil
+	}
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+type storageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+// VolumeSource is part of the storage.Provider interface.
+func (p *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	return &volumeSource{env: p.env}, nil
+}
+
+// FilesystemSource is part of the storage.Provider interface.
+func (p *storageProvider) FilesystemSource(cfg *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is part of the storage.Provider interface.
+func (p *storageProvider) Supports(kind storage.StorageKind) bool {
+	return kind == storage.StorageKindBlock
+}
+
+// Scope is part of the storage.Provider interface.
+func (p *storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is part of the storage.Provider interface.
+func (p *storageProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is part of the storage.Provider interface.
+func (p *storageProvider) Releasable() bool {
+	return true
+}
+
+// DefaultPools is part of the storage.Provider interface.
+func (p *storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// ValidateConfig is part of the storage.Provider interface.
+func (p *storageProvider) ValidateConfig(cfg *storage.Config) error {
+	return nil
+}
+
+type volumeSource struct {
+	env *environ
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+func mibToGib(m uint64) uint64 {
+	return (m + 1023) / 1024
+}
+
+func parseVolumeId(id string) (int64, error) {
+	volID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, errors.NotValidf("volume id %q", id)
+	}
+	return volID, nil
+}
+
+func makeVolumeInfo(v volume) storage.VolumeInfo {
+	return storage.VolumeInfo{
+		VolumeId:   strconv.FormatInt(v.ID, 10),
+		Size:       uint64(v.Size) * 1024,
+		Persistent: true,
+	}
+}
+
+// ValidateVolumeParams is part of the storage.VolumeSource interface.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	size := mibToGib(params.Size)
+	if size > maxVolumeSizeInGB {
+		return errors.Errorf(
+			"invalid volume size %d. Valid range is %d - %d (GiB)", size, minVolumeSizeInGB, maxVolumeSizeInGB)
+	}
+	return nil
+}
+
+// CreateVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) CreateVolumes(ctx context.ProviderCallContext, params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(params))
+	for i, p := range params {
+		size := mibToGib(p.Size)
+		if size < minVolumeSizeInGB {
+			size = minVolumeSizeInGB
+		}
+		vol, err := v.env.client.createVolume(createVolumeOpts{
+			Name:     fmt.Sprintf("juju-%s-%s", v.env.Config().UUID()[:8], p.Tag.String()),
+			Size:     int(size),
+			Location: v.env.cloud.Region,
+			Labels:   resourceLabels(p.ResourceTags),
+		})
+		if err != nil {
+			common.HandleCredentialError(isUnauthorized, err, ctx)
+			results[i].Error = errors.Annotatef(err, "creating volume for %s", p.Tag.Id())
+			continue
+		}
+		results[i].Volume = &storage.Volume{Tag: p.Tag, VolumeInfo: makeVolumeInfo(vol)}
+	}
+	return results, nil
+}
+
+// ListVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
+	volumes, err := v.env.client.volumes(v.env.modelLabels())
+	if err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return nil, errors.Trace(err)
+	}
+	ids := make([]string, len(volumes))
+	for i, vol := range volumes {
+		ids[i] = strconv.FormatInt(vol.ID, 10)
+	}
+	return ids, nil
+}
+
+// DescribeVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DescribeVolumes(ctx context.ProviderCallContext, volIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volIds))
+	for i, id := range volIds {
+		vol, err := v.volume(ctx, id)
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		info := makeVolumeInfo(vol)
+		results[i].VolumeInfo = &info
+	}
+	return results, nil
+}
+
+// DestroyVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DestroyVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	errs := make([]error, len(volIds))
+	for i, id := range volIds {
+		vol, err := v.volume(ctx, id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			errs[i] = errors.Trace(err)
+			continue
+		}
+		if vol.Server != nil {
+			// Detaching is asynchronous; the volume can only be deleted
+			// once it has completed, so report an error to be retried.
+			if err := v.env.client.detachVolume(vol.ID); err != nil && !isNotFound(err) {
+				errs[i] = errors.Annotatef(err, "detaching volume %s", id)
+				continue
+			}
+			errs[i] = errors.Errorf("volume %s is being detached", id)
+			continue
+		}
+		if err := v.env.client.deleteVolume(vol.ID); err != nil && !isNotFound(err) {
+			common.HandleCredentialError(isUnauthorized, err, ctx)
+			errs[i] = errors.Annotatef(err, "deleting volume %s", id)
+		}
+	}
+	return errs, nil
+}
+
+// ReleaseVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	errs := make([]error, len(volIds))
+	for i, id := range volIds {
+		vol, err := v.volume(ctx, id)
+		if err != nil {
+			errs[i] = errors.Trace(err)
+			continue
+		}
+		labels := make(map[string]string)
+		for k, val := range vol.Labels {
+			if k != tags.JujuModel && k != tags.JujuController {
+				labels[k] = val
+			}
+		}
+		if err := v.env.client.setVolumeLabels(vol.ID, labels); err != nil {
+			common.HandleCredentialError(isUnauthorized, err, ctx)
+			errs[i] = errors.Annotatef(err, "releasing volume %s", id)
+		}
+	}
+	return errs, nil
+}
+
+// AttachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) AttachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(params))
+	for i, p := range params {
+		vol, err := v.volume(ctx, p.VolumeId)
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		serverID, err := strconv.ParseInt(string(p.InstanceId), 10, 64)
+		if err != nil {
+			results[i].Error = errors.NotValidf("instance id %q", p.InstanceId)
+			continue
+		}
+		if vol.Server == nil {
+			if err := v.env.client.attachVolume(vol.ID, serverID); err != nil {
+				common.HandleCredentialError(isUnauthorized, err, ctx)
+				results[i].Error = errors.Annotatef(err, "attaching volume %s to server %d", p.VolumeId, serverID)
+				continue
+			}
+		} else if *vol.Server != serverID {
+			results[i].Error = errors.Errorf("volume %s is attached to server %d", p.VolumeId, *vol.Server)
+			continue
+		}
+		results[i].VolumeAttachment = &storage.VolumeAttachment{
+			Volume:  p.Volume,
+			Machine: p.Machine,
+			VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+				DeviceLink: vol.LinuxDevice,
+				ReadOnly:   p.ReadOnly,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DetachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DetachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]error, error) {
+	errs := make([]error, len(params))
+	for i, p := range params {
+		vol, err := v.volume(ctx, p.VolumeId)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			errs[i] = errors.Trace(err)
+			continue
+		}
+		if vol.Server == nil || strconv.FormatInt(*vol.Server, 10) != string(p.InstanceId) {
+			continue
+		}
+		if err := v.env.client.detachVolume(vol.ID); err != nil && !isNotFound(err) {
+			common.HandleCredentialError(isUnauthorized, err, ctx)
+			errs[i] = errors.Annotatef(err, "detaching volume %s", p.VolumeId)
+		}
+	}
+	return errs, nil
+}
+
+// volume returns the model volume with the given id.
+func (v *volumeSource) volume(ctx context.ProviderCallContext, id string) (volume, error) {
+	volID, err := parseVolumeId(id)
+	if err != nil {
+		return volume{}, errors.Trace(err)
+	}
+	vol, err := v.env.client.volume(volID)
+	if isNotFound(err) {
+		return volume{}, errors.NotFoundf("volume %s", id)
+	} else if err != nil {
+		common.HandleCredentialError(isUnauthorized, err, ctx)
+		return volume{}, errors.Trace(err)
+	}
+	return vol, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hetzner
+
+import (
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/storage"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type storageSuite struct {
+	environSuite
+
+	source storage.VolumeSource
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.environSuite.SetUpTest(c)
+
+	provider, err := s.env.StorageProvider("hetzner")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+	s.source, err = provider.VolumeSource(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) createVolume(c *gc.C, id string, size uint64) string {
+	results, err := s.source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag(id),
+		Size:     size,
+		Provider: "hetzner",
+		ResourceTags: map[string]string{
+			tags.JujuModel:      coretesting.ModelTag.Id(),
+			tags.JujuController: s.controllerUUID,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	return results[0].Volume.VolumeId
+}
+
+func (s *storageSuite) TestCreateVolumes(c *gc.C) {
+	volID := s.createVolume(c, "0", 1024)
+	// Volumes are at least 10 GiB.
+	vol := s.api.volumes[mustParseId(c, volID)]
+	c.Check(vol.Size, gc.Equals, minVolumeSizeInGB)
+	c.Check(vol.Location.Name, gc.Equals, "fsn1")
+	c.Check(vol.Labels[tags.JujuModel], gc.Equals, coretesting.ModelTag.Id())
+
+	ids, err := s.source.ListVolumes(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, jc.DeepEquals, []string{volID})
+
+	results, err := s.source.DescribeVolumes(s.callCtx, []string{volID, "999"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId:   volID,
+		Size:       10240,
+		Persistent: true,
+	})
+	c.Check(results[1].Error, gc.ErrorMatches, "volume 999 not found")
+}
+
+func (s *storageSuite) TestValidateVolumeParams(c *gc.C) {
+	err := s.source.ValidateVolumeParams(storage.VolumeParams{Size: 20 * 1024 * 1024})
+	c.Assert(err, gc.ErrorMatches, `invalid volume size 20480. Valid range is 10 - 10240 \(GiB\)`)
+}
+
+func (s *storageSuite) TestAttachDetachVolumes(c *gc.C) {
+	inst := s.startInstance(c, "1", constraints.Value{}).Instance
+	volID := s.createVolume(c, "0", 20*1024)
+
+	params := []storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Provider:   "hetzner",
+			Machine:    names.NewMachineTag("1"),
+			InstanceId: inst.Id(),
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: volID,
+	}}
+	results, err := s.source.AttachVolumes(s.callCtx, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Check(results[0].VolumeAttachment.DeviceLink, gc.Equals, "/dev/disk/by-id/scsi-0HC_Volume_"+volID)
+	c.Check(*s.api.volumes[mustParseId(c, volID)].Server, gc.Equals, mustParseId(c, string(inst.Id())))
+
+	// Attaching again is a no-op.
+	results, err = s.source.AttachVolumes(s.callCtx, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+
+	errs, err := s.source.DetachVolumes(s.callCtx, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Check(s.api.volumes[mustParseId(c, volID)].Server, gc.IsNil)
+}
+
+func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
+	inst := s.startInstance(c, "1", constraints.Value{}).Instance
+	volID := s.createVolume(c, "0", 20*1024)
+	serverID := mustParseId(c, string(inst.Id()))
+	s.api.volumes[mustParseId(c, volID)].Server = &serverID
+
+	// An attached volume is detached first.
+	errs, err := s.source.DestroyVolumes(s.callCtx, []string{volID})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs[0], gc.ErrorMatches, "volume .* is being detached")
+	c.Check(s.api.volumes[mustParseId(c, volID)].Server, gc.IsNil)
+
+	errs, err = s.source.DestroyVolumes(s.callCtx, []string{volID, "999"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil, nil})
+	c.Check(s.api.volumes, gc.HasLen, 0)
+}
+
+func (s *storageSuite) TestReleaseVolumes(c *gc.C) {
+	volID := s.createVolume(c, "0", 20*1024)
+	errs, err := s.source.ReleaseVolumes(s.callCtx, []string{volID})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Check(s.api.volumes[mustParseId(c, volID)].Labels, gc.HasLen, 0)
+
+	ids, err := s.source.ListVolumes(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, gc.HasLen, 0)
+}
@@ -115,7 +115,12 @@ func (env *environ) newContainer(
 		return nil, errors.Trace(err)
 	}
 
-	image, err := target.FindImage(args.InstanceConfig.Series, arch, imageSources, true, statusCallback)
+	virtType, err := lxd.VirtTypeFromConstraints(args.Constraints)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	image, err := target.FindImage(args.InstanceConfig.Series, arch, virtType, imageSources, true, statusCallback)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
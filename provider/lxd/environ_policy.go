@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 )
@@ -21,9 +22,15 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.Tags,
-	constraints.VirtType,
 	constraints.Container,
 	constraints.AllocatePublicIP,
+	constraints.MemRequest,
+	constraints.MemLimit,
+	constraints.CpuRequest,
+	constraints.CpuLimit,
+	constraints.EphemeralStorageLimit,
+	constraints.ContainerResources,
+	constraints.Spot,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -33,6 +40,10 @@ func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (const
 
 	validator.RegisterUnsupported(unsupportedConstraints)
 	validator.RegisterVocabulary(constraints.Arch, env.server().SupportedArches())
+	validator.RegisterVocabulary(constraints.VirtType, []string{
+		string(instance.InstanceTypeContainer),
+		string(instance.InstanceTypeVM),
+	})
 
 	return validator, nil
 }
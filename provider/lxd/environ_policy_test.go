@@ -135,7 +135,7 @@ func (s *environPolicySuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"instance-type=some-type",
 		"cores=2",
 		"cpu-power=250",
-		"virt-type=kvm",
+		"virt-type=virtual-machine",
 	}, " "))
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
@@ -143,7 +143,6 @@ func (s *environPolicySuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	expected := []string{
 		"tags",
 		"cpu-power",
-		"virt-type",
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
@@ -172,6 +171,18 @@ func (s *environPolicySuite) TestConstraintsValidatorVocabArchUnknown(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "invalid constraint value: arch=ppc64el\nvalid values are: \\[amd64\\]")
 }
 
+func (s *environPolicySuite) TestConstraintsValidatorVocabVirtTypeUnknown(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	validator, err := s.env.ConstraintsValidator(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("virt-type=kvm")
+	_, err = validator.Validate(cons)
+
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: virt-type=kvm\nvalid values are: \\[container virtual-machine\\]")
+}
+
 func (s *environPolicySuite) TestConstraintsValidatorVocabContainerUnknown(c *gc.C) {
 	c.Skip("this will fail until we add a container vocabulary")
 
@@ -282,11 +282,11 @@ func (s *BaseSuiteUnpatched) NewContainer(c *gc.C, name string) *containerlxd.Co
 	}
 
 	return &containerlxd.Container{
-		Container: api.Container{
+		Instance: api.Instance{
 			Name:       name,
 			StatusCode: api.Running,
 			Status:     api.Running.String(),
-			ContainerPut: api.ContainerPut{
+			InstancePut: api.InstancePut{
 				Config: metadata,
 			},
 		},
@@ -455,7 +455,7 @@ func (conn *StubClient) CreateContainerFromSpec(spec lxd.ContainerSpec) (*lxd.Co
 }
 
 func (conn *StubClient) FindImage(
-	series, arch string, sources []lxd.ServerSpec, copyLocal bool, callback environs.StatusCallbackFunc,
+	series, arch string, virtType instance.VirtType, sources []lxd.ServerSpec, copyLocal bool, callback environs.StatusCallbackFunc,
 ) (lxd.SourcedImage, error) {
 	conn.AddCall("FindImage", series, arch)
 	if err := conn.NextErr(); err != nil {
@@ -723,11 +723,11 @@ func (*StubClient) GetNetworkState(string) (*api.NetworkState, error) {
 	panic("this stub is deprecated; use mocks instead")
 }
 
-func (*StubClient) GetContainer(string) (*api.Container, string, error) {
+func (*StubClient) GetInstance(string) (*api.Instance, string, error) {
 	panic("this stub is deprecated; use mocks instead")
 }
 
-func (*StubClient) GetContainerState(string) (*api.ContainerState, string, error) {
+func (*StubClient) GetInstanceState(string) (*api.InstanceState, string, error) {
 	panic("this stub is deprecated; use mocks instead")
 }
 
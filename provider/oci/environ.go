@@ -332,6 +332,13 @@ func (e *Environ) ConstraintsValidator(ctx envcontext.ProviderCallContext) (cons
 		constraints.Container,
 		constraints.VirtType,
 		constraints.Tags,
+		constraints.MemRequest,
+		constraints.MemLimit,
+		constraints.CpuRequest,
+		constraints.CpuLimit,
+		constraints.EphemeralStorageLimit,
+		constraints.ContainerResources,
+		constraints.Spot,
 	}
 
 	validator := constraints.NewValidator()
@@ -75,7 +75,9 @@ func newInstance(raw ociCore.Instance, env *Environ) (*ociInstance, error) {
 		raw:                     raw,
 		env:                     env,
 		arch:                    "amd64",
-		newInstanceConfigurator: common.NewSshInstanceConfigurator,
+		newInstanceConfigurator: func(host string) common.InstanceConfigurator {
+			return common.NewSshInstanceConfigurator(host, env.Config().HostFirewallBackend())
+		},
 	}, nil
 }
 
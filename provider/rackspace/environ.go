@@ -67,7 +67,7 @@ func (e environ) StartInstance(ctx envcontext.ProviderCallContext, args environs
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		client := newInstanceConfigurator(addr)
+		client := newInstanceConfigurator(addr, e.Config().HostFirewallBackend())
 		apiPort := 0
 		if args.InstanceConfig.Controller != nil {
 			apiPort = args.InstanceConfig.Controller.Config.APIPort()
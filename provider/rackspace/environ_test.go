@@ -87,7 +87,7 @@ func (s *environSuite) TestStartInstance(c *gc.C) {
 		}
 		return addresses[0].Value, nil
 	})
-	s.PatchValue(rackspace.NewInstanceConfigurator, func(host string) common.InstanceConfigurator {
+	s.PatchValue(rackspace.NewInstanceConfigurator, func(host, firewallBackend string) common.InstanceConfigurator {
 		return configurator
 	})
 	cfg, err := config.New(config.UseDefaults, map[string]interface{}{
@@ -137,7 +137,7 @@ func (s *environSuite) TestStartInstanceInvalidCredential(c *gc.C) {
 		}
 		return addresses[0].Value, nil
 	})
-	s.PatchValue(rackspace.NewInstanceConfigurator, func(host string) common.InstanceConfigurator {
+	s.PatchValue(rackspace.NewInstanceConfigurator, func(host, firewallBackend string) common.InstanceConfigurator {
 		return configurator
 	})
 	cfg, err := config.New(config.UseDefaults, map[string]interface{}{
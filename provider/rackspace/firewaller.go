@@ -23,10 +23,12 @@ var _ openstack.FirewallerFactory = (*firewallerFactory)(nil)
 
 // GetFirewaller implements FirewallerFactory
 func (f *firewallerFactory) GetFirewaller(env environs.Environ) openstack.Firewaller {
-	return &rackspaceFirewaller{}
+	return &rackspaceFirewaller{env: env}
 }
 
-type rackspaceFirewaller struct{}
+type rackspaceFirewaller struct {
+	env environs.Environ
+}
 
 var _ openstack.Firewaller = (*rackspaceFirewaller)(nil)
 
@@ -129,6 +131,6 @@ func (c *rackspaceFirewaller) getInstanceConfigurator(
 		return addresses, nil, errors.New("No addresses found")
 	}
 
-	client := common.NewSshInstanceConfigurator(addresses[0].Value)
+	client := common.NewSshInstanceConfigurator(addresses[0].Value, c.env.Config().HostFirewallBackend())
 	return addresses, client, err
 }
@@ -128,6 +128,6 @@ func (inst *environInstance) getInstanceConfigurator(
 		}
 	}
 
-	client := common.NewSshInstanceConfigurator(localAddr)
+	client := common.NewSshInstanceConfigurator(localAddr, inst.env.Config().HostFirewallBackend())
 	return addresses, client, err
 }
@@ -6,6 +6,7 @@ package state
 import (
 	"strconv"
 	"time"
+	"unicode/utf8"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -129,12 +130,35 @@ type actionDoc struct {
 
 	// Logs holds the progress messages logged by the action.
 	Logs []ActionMessage `bson:"messages"`
+
+	// Output holds the chunks of output written by the action so far.
+	Output []ActionMessage `bson:"output,omitempty"`
+
+	// OutputSize is the total size in bytes of the chunks of output,
+	// which is limited to maxActionOutputSize.
+	OutputSize int `bson:"output-size,omitempty"`
 }
 
-// ActionMessage represents a progress message logged by an action.
+const (
+	// ActionStdout is the stream of output written to an action's stdout.
+	ActionStdout = "stdout"
+
+	// ActionStderr is the stream of output written to an action's stderr.
+	ActionStderr = "stderr"
+
+	// maxActionOutputSize is the most output in bytes which is recorded
+	// for an action; any further output is discarded. This keeps the
+	// action document well within mongo's document size limit.
+	maxActionOutputSize = 1024 * 1024
+)
+
+// ActionMessage represents a progress message logged by an action,
+// or a chunk of output written by it.
 type ActionMessage struct {
 	MessageValue   string    `bson:"message"`
 	TimestampValue time.Time `bson:"timestamp"`
+	// StreamValue is empty for progress messages.
+	StreamValue string `bson:"stream,omitempty"`
 }
 
 // Timestamp returns the message timestamp.
@@ -147,6 +171,12 @@ func (m ActionMessage) Message() string {
 	return m.MessageValue
 }
 
+// Stream returns the output stream the message was written to, or
+// the empty string for a progress message.
+func (m ActionMessage) Stream() string {
+	return m.StreamValue
+}
+
 // action represents an instruction to do some "action" and is expected
 // to match an action definition in a charm.
 type action struct {
@@ -410,6 +440,7 @@ func (a *action) removeAndLogBuildTxn(finalStatus ActionStatus, results map[stri
 		// for the parent operation, the operation itself is also
 		// marked as complete.
 		var updateOperationOp *txn.Op
+		var rolloutOps []txn.Op
 		var err error
 		if parentOperation != nil {
 			if attempt > 0 {
@@ -427,6 +458,24 @@ func (a *action) removeAndLogBuildTxn(finalStatus ActionStatus, results map[stri
 					numComplete++
 				}
 			}
+			// Finishing a task of a rolling operation may start
+			// the next queued tasks, or cancel them all if too
+			// many tasks have failed.
+			var rolloutUpdate bson.D
+			if rollout := parentOperation.(*operation).doc.Rollout; rollout != nil {
+				var numCancelled int
+				var stages [][]string
+				rolloutOps, numCancelled, stages, err = parentOperation.(*operation).advanceRollout(
+					a.Id(), finalStatus, completedTime)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if numCancelled > 0 {
+					statusStats.Add(string(ActionCancelled))
+					numComplete += numCancelled
+				}
+				rolloutUpdate = bson.D{{"rollout.stages", stages}}
+			}
 			if numComplete == len(tasks)-1 {
 				// Set the operation status based on the individual
 				// task status values. eg if any task is failed,
@@ -442,20 +491,20 @@ func (a *action) removeAndLogBuildTxn(finalStatus ActionStatus, results map[stri
 					C:      operationsC,
 					Id:     a.st.docID(parentOperation.Id()),
 					Assert: assertNotComplete,
-					Update: bson.D{{"$set", bson.D{
+					Update: bson.D{{"$set", append(bson.D{
 						{"status", finalOperationStatus},
 						{"completed", completedTime},
 						{"complete-task-count", numComplete + 1},
-					}}},
+					}, rolloutUpdate...)}},
 				}
 			} else {
 				updateOperationOp = &txn.Op{
 					C:      operationsC,
 					Id:     a.st.docID(parentOperation.Id()),
 					Assert: bson.D{{"complete-task-count", parentOperation.(*operation).doc.CompleteTaskCount}},
-					Update: bson.D{{"$set", bson.D{
+					Update: bson.D{{"$set", append(bson.D{
 						{"complete-task-count", numComplete + 1},
-					}}},
+					}, rolloutUpdate...)}},
 				}
 			}
 		}
@@ -478,7 +527,7 @@ func (a *action) removeAndLogBuildTxn(finalStatus ActionStatus, results map[stri
 		if updateOperationOp != nil {
 			ops = append(ops, *updateOperationOp)
 		}
-		return ops, nil
+		return append(ops, rolloutOps...), nil
 	}
 }
 
@@ -503,6 +552,56 @@ func (a *action) Log(message string) error {
 		logger.Warningf("exceeded 1000 log messages, action may be stuck")
 		return nil
 	}
+	return a.updateRunning(func() (bson.D, bson.D, error) {
+		update := bson.D{{"$push", bson.D{
+			{"messages", ActionMessage{MessageValue: message, TimestampValue: a.st.nowToTheSecond().UTC()}},
+		}}}
+		return nil, update, nil
+	})
+}
+
+// LogOutput adds a chunk of output written by the action to the given
+// stream, so that it can be watched while the action runs. Output beyond
+// maxActionOutputSize bytes in total is discarded.
+func (a *action) LogOutput(stream, data string) error {
+	if stream != ActionStdout && stream != ActionStderr {
+		return errors.NotValidf("action output stream %q", stream)
+	}
+	return a.updateRunning(func() (bson.D, bson.D, error) {
+		chunk := data
+		if remaining := maxActionOutputSize - a.doc.OutputSize; len(chunk) > remaining {
+			if remaining <= 0 {
+				return nil, nil, jujutxn.ErrNoOperations
+			}
+			// Don't split a multi-byte character.
+			for remaining > 0 && !utf8.RuneStart(chunk[remaining]) {
+				remaining--
+			}
+			chunk = chunk[:remaining]
+			logger.Warningf("task %q exceeded %d bytes of output, discarding the rest", a.Id(), maxActionOutputSize)
+		}
+		if chunk == "" {
+			return nil, nil, jujutxn.ErrNoOperations
+		}
+		// The output must not have grown since it was read.
+		assert := bson.D{{"output-size", bson.D{{"$not", bson.D{{"$gt", a.doc.OutputSize}}}}}}
+		update := bson.D{
+			{"$push", bson.D{{"output", ActionMessage{
+				MessageValue:   chunk,
+				TimestampValue: a.st.nowToTheSecond().UTC(),
+				StreamValue:    stream,
+			}}}},
+			{"$inc", bson.D{{"output-size", len(chunk)}}},
+		}
+		return assert, update, nil
+	})
+}
+
+// updateRunning runs a transaction updating the action, which must be
+// running. The update, and any additional assertion on the action, are
+// built by the supplied function, which is called again with the action
+// refreshed if the transaction is aborted.
+func (a *action) updateRunning(build func() (bson.D, bson.D, error)) error {
 	m, err := a.st.Model()
 	if err != nil {
 		return errors.Trace(err)
@@ -518,17 +617,20 @@ func (a *action) Log(message string) error {
 		if s := a.Status(); s != ActionRunning && s != ActionAborting {
 			return nil, errors.Errorf("cannot log message to task %q with status %v", a.Id(), s)
 		}
+		assert, update, err := build()
+		if err != nil {
+			return nil, err
+		}
+		assert = append(bson.D{{"$or", []bson.D{
+			{{"status", ActionRunning}},
+			{{"status", ActionAborting}},
+		}}}, assert...)
 		ops := []txn.Op{
 			{
-				C:  actionsC,
-				Id: a.doc.DocId,
-				Assert: bson.D{{"$or", []bson.D{
-					{{"status", ActionRunning}},
-					{{"status", ActionAborting}},
-				}}},
-				Update: bson.D{{"$push", bson.D{
-					{"messages", ActionMessage{MessageValue: message, TimestampValue: a.st.nowToTheSecond().UTC()}},
-				}}},
+				C:      actionsC,
+				Id:     a.doc.DocId,
+				Assert: assert,
+				Update: update,
 			}}
 		return ops, nil
 	}
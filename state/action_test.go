@@ -1201,6 +1201,110 @@ func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
 	checkExpected(wc2, expected)
 }
 
+func (s *ActionSuite) TestWatchActionOutput(c *gc.C) {
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	fa1, err := s.unit.AddAction(operationID, "snapshot", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	fa1, err = fa1.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = fa1.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+	err = fa1.LogOutput(state.ActionStdout, "hello\n")
+	c.Assert(err, jc.ErrorIsNil)
+	err = fa1.LogOutput("stdin", "hello\n")
+	c.Assert(err, gc.ErrorMatches, `action output stream "stdin" not valid`)
+
+	decode := func(changes []string) []actions.ActionMessage {
+		var msgs []actions.ActionMessage
+		for _, change := range changes {
+			var msg actions.ActionMessage
+			err := json.Unmarshal([]byte(change), &msg)
+			c.Assert(err, jc.ErrorIsNil)
+			msg.Timestamp = time.Time{}
+			msgs = append(msgs, msg)
+		}
+		return msgs
+	}
+
+	w := s.State.WatchActionOutput(fa1.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	s.State.StartSync()
+	select {
+	case changes := <-w.Changes():
+		c.Assert(decode(changes), jc.DeepEquals, []actions.ActionMessage{
+			{Message: "first"},
+			{Message: "hello\n", Stream: "stdout"},
+		})
+	case <-time.After(testing.LongWait):
+		c.Fatalf("watcher did not send change")
+	}
+	wc.AssertNoChange()
+
+	err = fa1.LogOutput(state.ActionStderr, "oops\n")
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+	select {
+	case changes := <-w.Changes():
+		c.Assert(decode(changes), jc.DeepEquals, []actions.ActionMessage{
+			{Message: "oops\n", Stream: "stderr"},
+		})
+	case <-time.After(testing.LongWait):
+		c.Fatalf("watcher did not send change")
+	}
+
+	// Output is not reported as a progress message.
+	err = fa1.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	messages := fa1.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message(), gc.Equals, "first")
+}
+
+func (s *ActionSuite) TestLogOutputLimit(c *gc.C) {
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	fa1, err := s.unit.AddAction(operationID, "snapshot", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	fa1, err = fa1.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Fill all but two bytes of the output, so that only the
+	// first character of the next chunk fits.
+	err = fa1.LogOutput(state.ActionStdout, strings.Repeat("x", 1024*1024-2))
+	c.Assert(err, jc.ErrorIsNil)
+	err = fa1.LogOutput(state.ActionStdout, "h\u00e9llo")
+	c.Assert(err, jc.ErrorIsNil)
+	err = fa1.LogOutput(state.ActionStderr, "dropped")
+	c.Assert(err, jc.ErrorIsNil)
+	// Progress messages are not limited by the output.
+	err = fa1.Log("still logging")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchActionOutput(fa1.Id())
+	defer statetesting.AssertStop(c, w)
+	s.State.StartSync()
+	select {
+	case changes := <-w.Changes():
+		var output []string
+		for _, change := range changes {
+			var msg actions.ActionMessage
+			err := json.Unmarshal([]byte(change), &msg)
+			c.Assert(err, jc.ErrorIsNil)
+			if msg.Stream == "" {
+				c.Assert(msg.Message, gc.Equals, "still logging")
+				continue
+			}
+			output = append(output, msg.Message)
+		}
+		c.Assert(output, gc.HasLen, 2)
+		c.Assert(output[1], gc.Equals, "h")
+	case <-time.After(testing.LongWait):
+		c.Fatalf("watcher did not send change")
+	}
+}
+
 func (s *ActionSuite) TestWatchActionResults(c *gc.C) {
 	w := s.Model.WatchActionResultsFilteredBy(s.unit)
 	defer statetesting.AssertStop(c, w)
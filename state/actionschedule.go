@@ -0,0 +1,427 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// ActionSchedule represents an action which is run as a new operation
+// each time its cron expression is due.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+type actionScheduleDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Receiver is the application name, unit name or
+	// <application>/leader the action is run against.
+	Receiver string `bson:"receiver"`
+
+	// Name identifies the action that should be run.
+	Name string `bson:"name"`
+
+	// Parameters holds the action's parameters, if any.
+	Parameters map[string]interface{} `bson:"parameters"`
+
+	// Cron is the cron expression determining when the action runs.
+	Cron string `bson:"cron"`
+
+	// MissedRunPolicy determines how runs which were due while the
+	// scheduler was unavailable are handled.
+	MissedRunPolicy actions.MissedRunPolicy `bson:"missed-run-policy"`
+
+	// Created is the time the schedule was added.
+	Created time.Time `bson:"created"`
+
+	// LastRun is the time the schedule last fired an operation.
+	LastRun time.Time `bson:"last-run,omitempty"`
+
+	// LastOperation is the id of the last operation fired by the schedule.
+	LastOperation string `bson:"last-operation,omitempty"`
+}
+
+// Id returns the local id of the schedule.
+func (s *ActionSchedule) Id() string {
+	return s.st.localID(s.doc.DocId)
+}
+
+// Receiver returns the application name, unit name or
+// <application>/leader the action is run against.
+func (s *ActionSchedule) Receiver() string {
+	return s.doc.Receiver
+}
+
+// Name returns the name of the action to run.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Parameters returns the parameters passed to the action.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Cron returns the cron expression determining when the action runs.
+func (s *ActionSchedule) Cron() string {
+	return s.doc.Cron
+}
+
+// MissedRunPolicy returns the policy for handling missed runs.
+func (s *ActionSchedule) MissedRunPolicy() actions.MissedRunPolicy {
+	return s.doc.MissedRunPolicy
+}
+
+// Created returns the time the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// LastRun returns the time the schedule last fired,
+// or the zero time if it has never fired.
+func (s *ActionSchedule) LastRun() time.Time {
+	return s.doc.LastRun
+}
+
+// LastOperation returns the id of the operation last
+// fired by the schedule.
+func (s *ActionSchedule) LastOperation() string {
+	return s.doc.LastOperation
+}
+
+// Refresh refreshes the contents of the schedule.
+func (s *ActionSchedule) Refresh() error {
+	doc, err := s.st.getActionScheduleDoc(s.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.doc = *doc
+	return nil
+}
+
+// Fire enqueues an operation for the scheduled action, tagged with the
+// schedule id, and records the run against the schedule. The operation
+// id is returned along with the actions which could be enqueued;
+// receivers which reject the action are logged and skipped.
+// The operation, its actions and the run are recorded in a single
+// transaction which asserts that the schedule has not fired since it
+// was read, so a run is never enqueued twice or lost.
+func (s *ActionSchedule) Fire(now time.Time) (string, []Action, error) {
+	var (
+		operationID string
+		enqueued    []Action
+	)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if !now.After(s.doc.LastRun) {
+			return nil, errors.AlreadyExistsf("run of action schedule %q at %v", s.Id(), now)
+		}
+		var err error
+		var actionDocs []actionDoc
+		var ops []txn.Op
+		operationID, actionDocs, ops, err = s.fireOps(now)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		enqueued = make([]Action, len(actionDocs))
+		for i, doc := range actionDocs {
+			enqueued[i] = newAction(s.st, doc)
+		}
+		return ops, nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	s.doc.LastRun = now
+	s.doc.LastOperation = operationID
+	return operationID, enqueued, nil
+}
+
+// fireOps returns the operations which enqueue a run of the schedule,
+// along with the id of the operation and the actions to be enqueued.
+func (s *ActionSchedule) fireOps(now time.Time) (string, []actionDoc, []txn.Op, error) {
+	receivers, err := s.resolveReceivers()
+	if err != nil {
+		return "", nil, nil, errors.Trace(err)
+	}
+	summary := s.doc.Name + " run on " + s.doc.Receiver + " by schedule " + s.Id()
+	operation, operationID, err := newOperationDoc(s.st, summary)
+	if err != nil {
+		return "", nil, nil, errors.Annotate(err, "creating operation for scheduled action")
+	}
+	operation.ScheduleId = s.Id()
+
+	// The schedule must not have fired since it was read.
+	lastRunAssert := bson.D{{"last-run", s.doc.LastRun}}
+	if s.doc.LastRun.IsZero() {
+		lastRunAssert = bson.D{{"last-run", bson.D{{"$exists", false}}}}
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: lastRunAssert,
+		Update: bson.D{{"$set", bson.D{
+			{"last-run", now},
+			{"last-operation", operationID},
+		}}},
+	}}
+
+	var actionDocs []actionDoc
+	var actionOps []txn.Op
+	for _, unit := range receivers {
+		if unit.Life() == Dead {
+			actionLogger.Warningf("cannot enqueue scheduled action %q on dead unit %q", s.doc.Name, unit.Name())
+			continue
+		}
+		payload, parallel, executionGroup, err := unit.prepareAction(s.doc.Name, s.doc.Parameters, nil, nil)
+		if err != nil {
+			actionLogger.Warningf("cannot enqueue scheduled action %q on %q: %v", s.doc.Name, unit.Name(), err)
+			continue
+		}
+		doc, ndoc, err := newActionDoc(s.st, operationID, unit.Tag(), s.doc.Name, payload, parallel, executionGroup)
+		if err != nil {
+			return "", nil, nil, errors.Trace(err)
+		}
+		actionDocs = append(actionDocs, doc)
+		actionOps = append(actionOps, txn.Op{
+			C:      unitsC,
+			Id:     unit.doc.DocID,
+			Assert: notDeadDoc,
+		}, txn.Op{
+			C:      actionsC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}, txn.Op{
+			C:      actionNotificationsC,
+			Id:     ndoc.DocId,
+			Assert: txn.DocMissing,
+			Insert: ndoc,
+		})
+	}
+	if len(actionDocs) == 0 {
+		actionLogger.Warningf("scheduled action %q has no units to run on", s.doc.Name)
+		operation.Status = ActionFailed
+		operation.Completed = s.st.nowToTheSecond()
+	}
+	ops = append(ops, txn.Op{
+		C:      operationsC,
+		Id:     operation.DocId,
+		Assert: txn.DocMissing,
+		Insert: operation,
+	})
+	return operationID, actionDocs, append(ops, actionOps...), nil
+}
+
+// resolveReceivers returns the units the scheduled action should run on
+// at this point in time.
+func (s *ActionSchedule) resolveReceivers() ([]*Unit, error) {
+	receiver := s.doc.Receiver
+	switch {
+	case names.IsValidApplication(receiver):
+		app, err := s.st.Application(receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return app.AllUnits()
+	case strings.HasSuffix(receiver, "/leader"):
+		appName := strings.TrimSuffix(receiver, "/leader")
+		leaders, err := s.st.ApplicationLeaders()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		leader, ok := leaders[appName]
+		if !ok {
+			return nil, errors.Errorf("could not determine leader for %q", appName)
+		}
+		receiver = leader
+	}
+	unit, err := s.st.Unit(receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []*Unit{unit}, nil
+}
+
+// ActionScheduleArgs contains the arguments for AddActionSchedule.
+type ActionScheduleArgs struct {
+	// Receiver is the application name, unit name or
+	// <application>/leader to run the action against.
+	Receiver string
+
+	// Name is the name of the action to run.
+	Name string
+
+	// Parameters holds the action's parameters.
+	Parameters map[string]interface{}
+
+	// Cron is the cron expression determining when the action runs.
+	Cron string
+
+	// MissedRunPolicy determines how missed runs are handled.
+	// It defaults to skipping them.
+	MissedRunPolicy actions.MissedRunPolicy
+}
+
+func (m *Model) validateActionScheduleArgs(args *ActionScheduleArgs) error {
+	if _, err := actions.ParseCronSchedule(args.Cron); err != nil {
+		return errors.Trace(err)
+	}
+	if args.MissedRunPolicy == "" {
+		args.MissedRunPolicy = actions.MissedRunSkip
+	}
+	if err := args.MissedRunPolicy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if args.Name == "" {
+		return errors.NotValidf("empty action name")
+	}
+
+	var appName string
+	switch {
+	case names.IsValidApplication(args.Receiver):
+		appName = args.Receiver
+	case strings.HasSuffix(args.Receiver, "/leader"):
+		appName = strings.TrimSuffix(args.Receiver, "/leader")
+	case names.IsValidUnit(args.Receiver):
+		unit, err := m.st.Unit(args.Receiver)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		appName = unit.ApplicationName()
+	default:
+		return errors.NotValidf("action receiver %q", args.Receiver)
+	}
+	app, err := m.st.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	spec, ok := actions.PredefinedActionsSpec[args.Name]
+	if !ok {
+		ch, _, err := app.Charm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var specs map[string]charm.ActionSpec
+		if chActions := ch.Actions(); chActions != nil {
+			specs = chActions.ActionSpecs
+		}
+		if spec, ok = specs[args.Name]; !ok {
+			return errors.Errorf("action %q not defined for application %q", args.Name, appName)
+		}
+	}
+	return errors.Trace(spec.ValidateParams(args.Parameters))
+}
+
+// AddActionSchedule validates and stores a new action schedule.
+func (m *Model) AddActionSchedule(args ActionScheduleArgs) (*ActionSchedule, error) {
+	if err := m.validateActionScheduleArgs(&args); err != nil {
+		return nil, errors.Annotate(err, "cannot add action schedule")
+	}
+	id, err := sequenceWithMin(m.st, "actionschedule", 1)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := actionScheduleDoc{
+		DocId:           m.st.docID(strconv.Itoa(id)),
+		ModelUUID:       m.st.ModelUUID(),
+		Receiver:        args.Receiver,
+		Name:            args.Name,
+		Parameters:      args.Parameters,
+		Cron:            args.Cron,
+		MissedRunPolicy: args.MissedRunPolicy,
+		Created:         m.st.nowToTheSecond(),
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotate(err, "cannot add action schedule")
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (m *Model) ActionSchedule(id string) (*ActionSchedule, error) {
+	doc, err := m.st.getActionScheduleDoc(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionSchedule{st: m.st, doc: *doc}, nil
+}
+
+func (st *State) getActionScheduleDoc(id string) (*actionScheduleDoc, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return &doc, nil
+}
+
+// AllActionSchedules returns all the action schedules in the model.
+func (m *Model) AllActionSchedules() ([]*ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all action schedules")
+	}
+	results := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		results[i] = &ActionSchedule{st: m.st, doc: doc}
+	}
+	return results, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given id.
+// Operations already fired by the schedule are left untouched.
+func (m *Model) RemoveActionSchedule(id string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     m.st.docID(id),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := m.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", id)
+	}
+	return errors.Annotatef(err, "cannot remove action schedule %q", id)
+}
+
+// WatchActionSchedules returns a NotifyWatcher which triggers when
+// action schedules are added, fired or removed.
+func (m *Model) WatchActionSchedules() NotifyWatcher {
+	return newNotifyCollWatcher(m.st, actionSchedulesC, isLocalID(m.st))
+}
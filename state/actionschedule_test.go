@@ -0,0 +1,224 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	application *state.Application
+	unit        *state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingApplication(c, "dummy", ch)
+	var err error
+	s.unit, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedule, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver:   "dummy",
+		Name:       "snapshot",
+		Parameters: map[string]interface{}{"outfile": "out.bz2"},
+		Cron:       "0 3 * * *",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Id(), gc.Equals, "1")
+	c.Assert(schedule.Receiver(), gc.Equals, "dummy")
+	c.Assert(schedule.Name(), gc.Equals, "snapshot")
+	c.Assert(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.bz2"})
+	c.Assert(schedule.Cron(), gc.Equals, "0 3 * * *")
+	c.Assert(schedule.MissedRunPolicy(), gc.Equals, actions.MissedRunSkip)
+	c.Assert(schedule.Created(), gc.Equals, clock.Now())
+	c.Assert(schedule.LastRun().IsZero(), jc.IsTrue)
+
+	fetched, err := s.Model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fetched.Cron(), gc.Equals, "0 3 * * *")
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args state.ActionScheduleArgs
+		err  string
+	}{{
+		args: state.ActionScheduleArgs{Receiver: "dummy", Name: "snapshot", Cron: "every day"},
+		err:  `cannot add action schedule: cron expression "every day": expected 5 fields, got 2 not valid`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "dummy", Name: "snapshot", Cron: "@daily", MissedRunPolicy: "maybe"},
+		err:  `cannot add action schedule: missed run policy "maybe" not valid`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "dummy", Name: "backup", Cron: "@daily"},
+		err:  `cannot add action schedule: action "backup" not defined for application "dummy"`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "missing", Name: "snapshot", Cron: "@daily"},
+		err:  `cannot add action schedule: application "missing" not found`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "dummy/0", Name: "snapshot", Cron: "@daily",
+			Parameters: map[string]interface{}{"outfile": 1}},
+		err: `cannot add action schedule: validation failed: \(root\).outfile : must be of type string, given 1`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.Model.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestAllAndRemoveActionSchedules(c *gc.C) {
+	first, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: "dummy/leader", Name: "snapshot", Cron: "@hourly",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	second, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: "dummy/0", Name: "snapshot", Cron: "@daily", MissedRunPolicy: actions.MissedRunOnce,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.Model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Assert(all[0].Id(), gc.Equals, first.Id())
+	c.Assert(all[1].Id(), gc.Equals, second.Id())
+
+	err = s.Model.RemoveActionSchedule(first.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Model.ActionSchedule(first.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.Model.RemoveActionSchedule(first.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	all, err = s.Model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestFire(c *gc.C) {
+	schedule, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: "dummy", Name: "snapshot", Cron: "@daily",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := coretesting.NonZeroTime().Round(time.Second)
+	operationID, enqueued, err := schedule.Fire(now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enqueued, gc.HasLen, 1)
+	c.Assert(enqueued[0].Receiver(), gc.Equals, s.unit.Name())
+	c.Assert(enqueued[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.ScheduleId(), gc.Equals, schedule.Id())
+	c.Assert(operation.Summary(), gc.Equals, "snapshot run on dummy by schedule 1")
+
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastRun().Equal(now), jc.IsTrue)
+	c.Assert(schedule.LastOperation(), gc.Equals, operationID)
+
+	_, err = s.Model.EnqueueOperation("unscheduled")
+	c.Assert(err, jc.ErrorIsNil)
+	operations, _, err := s.Model.ListOperations(nil, nil, nil, []string{schedule.Id()}, 0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operations, gc.HasLen, 1)
+	c.Assert(operations[0].Operation.Id(), gc.Equals, operationID)
+}
+
+func (s *ActionScheduleSuite) TestFireNoUnits(c *gc.C) {
+	err := s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	schedule, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: "dummy", Name: "snapshot", Cron: "@daily",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operationID, enqueued, err := schedule.Fire(coretesting.NonZeroTime())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enqueued, gc.HasLen, 0)
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionFailed)
+}
+
+func (s *ActionScheduleSuite) TestFireTwiceAtSameTime(c *gc.C) {
+	schedule, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: "dummy", Name: "snapshot", Cron: "@daily",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := coretesting.NonZeroTime().Round(time.Second)
+	_, _, err = schedule.Fire(now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A second scheduler reading the schedule afresh must not fire it again.
+	other, err := s.Model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = other.Fire(now)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	operations, _, err := s.Model.ListOperations(nil, nil, nil, []string{schedule.Id()}, 0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operations, gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestFireStaleSchedule(c *gc.C) {
+	schedule, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: "dummy", Name: "snapshot", Cron: "@daily",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stale, err := s.Model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := coretesting.NonZeroTime().Round(time.Second)
+	operationID, _, err := schedule.Fire(now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The stale copy refreshes when its assertion fails, and finds
+	// that the run has already been recorded.
+	_, _, err = stale.Fire(now)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(stale.LastOperation(), gc.Equals, operationID)
+
+	actions, err := s.Model.AllActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.Model.WatchActionSchedules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	schedule, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: "dummy", Name: "snapshot", Cron: "@daily",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.Model.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
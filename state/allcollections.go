@@ -417,6 +417,7 @@ func allCollections() CollectionSchema {
 				Key: []string{"model-uuid", "_id"},
 			}},
 		},
+		actionSchedulesC: {},
 
 		// -----
 
@@ -551,6 +552,7 @@ func allCollections() CollectionSchema {
 const (
 	actionNotificationsC       = "actionnotifications"
 	actionresultsC             = "actionresults"
+	actionSchedulesC           = "actionschedules"
 	actionsC                   = "actions"
 	annotationsC               = "annotations"
 	autocertCacheC             = "autocertCache"
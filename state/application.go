@@ -65,6 +65,23 @@ func (exp ExposedEndpoint) AllowTrafficFromAnyNetwork() bool {
 	return false
 }
 
+// ExposedIngress describes the ingress resource used to expose a k8s
+// application to the outside world.
+type ExposedIngress struct {
+	// Hostname is the external hostname routed to the application.
+	Hostname string `bson:"hostname"`
+
+	// Path is the HTTP path routed to the application.
+	Path string `bson:"path,omitempty"`
+
+	// TLSSecret is the name of the k8s secret holding the TLS
+	// certificate used to terminate traffic for Hostname.
+	TLSSecret string `bson:"tls-secret,omitempty"`
+
+	// Class is the ingress class used to provision the ingress.
+	Class string `bson:"class,omitempty"`
+}
+
 // Application represents the state of an application.
 type Application struct {
 	st  *State
@@ -104,6 +121,10 @@ type applicationDoc struct {
 	// represents all application endpoints.
 	ExposedEndpoints map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
 
+	// ExposedIngress holds the ingress settings for an exposed
+	// k8s application.
+	ExposedIngress *ExposedIngress `bson:"exposed-ingress,omitempty"`
+
 	// CAAS related attributes.
 	DesiredScale int    `bson:"scale"`
 	PasswordHash string `bson:"passwordhash"`
@@ -694,6 +715,12 @@ func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
 	return a.doc.ExposedEndpoints
 }
 
+// ExposedIngress returns the ingress settings used to expose a k8s
+// application, or nil if none have been set.
+func (a *Application) ExposedIngress() *ExposedIngress {
+	return a.doc.ExposedIngress
+}
+
 // UnsetExposeSettings removes the expose settings for the provided list of
 // endpoint names. If the resulting exposed endpoints map for the application
 // becomes empty after the settings are removed, the application will be
@@ -731,6 +758,7 @@ func (a *Application) UnsetExposeSettings(exposedEndpoints []string) error {
 		// retain expose flag if we still have any expose settings left
 		len(mergedExposedEndpoints) != 0,
 		mergedExposedEndpoints,
+		nil,
 	)
 }
 
@@ -740,6 +768,17 @@ func (a *Application) UnsetExposeSettings(exposedEndpoints []string) error {
 //
 // See ClearExposed and IsExposed.
 func (a *Application) MergeExposeSettings(exposedEndpoints map[string]ExposedEndpoint) error {
+	return a.MergeExposeSettingsWithIngress(exposedEndpoints, nil)
+}
+
+// MergeExposeSettingsWithIngress behaves like MergeExposeSettings and, in
+// the same transaction, records the ingress settings used to expose a k8s
+// application. A nil ingress leaves any existing ingress settings in place;
+// they are removed when the application is unexposed.
+func (a *Application) MergeExposeSettingsWithIngress(exposedEndpoints map[string]ExposedEndpoint, ingress *ExposedIngress) error {
+	if ingress != nil && ingress.Hostname == "" {
+		return errors.NotValidf("ingress without hostname")
+	}
 	bindings, _, err := readEndpointBindings(a.st, a.globalKey())
 	if err != nil {
 		return errors.Trace(err)
@@ -788,7 +827,7 @@ func (a *Application) MergeExposeSettings(exposedEndpoints map[string]ExposedEnd
 		mergedExposedEndpoints[endpoint] = exposeParams
 	}
 
-	return a.setExposed(true, mergedExposedEndpoints)
+	return a.setExposed(true, mergedExposedEndpoints, ingress)
 }
 
 func uniqueSortedStrings(in []string) []string {
@@ -802,24 +841,39 @@ func uniqueSortedStrings(in []string) []string {
 // ClearExposed removes the exposed flag from the application.
 // See MergeExposeSettings and IsExposed.
 func (a *Application) ClearExposed() error {
-	return a.setExposed(false, nil)
+	return a.setExposed(false, nil, nil)
 }
 
-func (a *Application) setExposed(exposed bool, exposedEndpoints map[string]ExposedEndpoint) (err error) {
+func (a *Application) setExposed(exposed bool, exposedEndpoints map[string]ExposedEndpoint, ingress *ExposedIngress) (err error) {
+	set := bson.D{
+		{"exposed", exposed},
+		{"exposed-endpoints", exposedEndpoints},
+	}
+	if ingress != nil {
+		set = append(set, bson.DocElem{"exposed-ingress", ingress})
+	}
+	update := bson.D{{"$set", set}}
+	if !exposed {
+		// Ingress settings only make sense for exposed applications.
+		update = append(update, bson.DocElem{"$unset", bson.D{{"exposed-ingress", nil}}})
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{
-			{"exposed", exposed},
-			{"exposed-endpoints", exposedEndpoints},
-		}}},
+		Update: update,
 	}}
 	if err := a.st.db().RunTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, applicationNotAliveErr))
 	}
 	a.doc.Exposed = exposed
 	a.doc.ExposedEndpoints = exposedEndpoints
+	if ingress != nil {
+		a.doc.ExposedIngress = ingress
+	}
+	if !exposed {
+		a.doc.ExposedIngress = nil
+	}
 	return nil
 }
 
@@ -3114,6 +3168,24 @@ func (a *Application) StatusHistory(filter status.StatusHistoryFilter) ([]status
 	return statusHistory(args)
 }
 
+// RecordEvent adds an event the cloud reported for the application to
+// its event history. The status of the application is unchanged.
+func (a *Application) RecordEvent(event status.StatusInfo) error {
+	return recordEvent(a.st.db(), a.globalKey(), event)
+}
+
+// EventHistory returns a slice of at most filter.Size StatusInfo items
+// or items as old as filter.Date or items newer than now - filter.Delta time
+// representing the events the cloud reported for this application.
+func (a *Application) EventHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	args := &statusHistoryArgs{
+		db:        a.st.db(),
+		globalKey: globalEventKey(a.globalKey()),
+		filter:    filter,
+	}
+	return statusHistory(args)
+}
+
 // UnitStatuses returns a map of unit names to their Status results (workload
 // status).
 func (a *Application) UnitStatuses() (map[string]status.StatusInfo, error) {
@@ -2489,6 +2489,45 @@ func (s *ApplicationSuite) TestApplicationExposeWithoutSpaceAndCIDR(c *gc.C) {
 	c.Assert(s.mysql.ExposedEndpoints(), gc.DeepEquals, exp, gc.Commentf("expected the implicit 0.0.0.0/0 and ::/0 CIDRs to be added when an empty ExposedEndpoint value is provided to MergeExposeSettings"))
 }
 
+func (s *ApplicationSuite) TestApplicationExposedIngress(c *gc.C) {
+	ingress := &state.ExposedIngress{
+		Hostname:  "mysql.example.com",
+		Path:      "/",
+		TLSSecret: "mysql-tls",
+		Class:     "nginx",
+	}
+
+	err := s.mysql.MergeExposeSettingsWithIngress(nil, &state.ExposedIngress{})
+	c.Assert(err, gc.ErrorMatches, `ingress without hostname not valid`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+
+	// The application is exposed and the ingress recorded together.
+	err = s.mysql.MergeExposeSettingsWithIngress(nil, ingress)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedIngress(), gc.DeepEquals, ingress)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedIngress(), gc.DeepEquals, ingress)
+
+	// Merging expose settings without an ingress keeps the existing one.
+	err = s.mysql.MergeExposeSettings(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedIngress(), gc.DeepEquals, ingress)
+
+	// Unexposing the application removes the ingress settings.
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedIngress(), gc.IsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedIngress(), gc.IsNil)
+}
+
 func (s *ApplicationSuite) TestApplicationUnsetExposeEndpoints(c *gc.C) {
 	// Check that querying for the exposed flag works correctly.
 	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
@@ -4084,6 +4123,43 @@ var updateApplicationConfigTests = []struct {
 	update:  application.ConfigAttributes{"skill-level": nil},
 }}
 
+func (s *ApplicationSuite) TestWatchApplicationConfig(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	w := app.WatchApplicationConfig()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := app.UpdateApplicationConfig(application.ConfigAttributes{"outlook": "positive"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Non-change is not reported.
+	err = app.UpdateApplicationConfig(application.ConfigAttributes{"outlook": "positive"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *ApplicationSuite) TestWatchConstraints(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	w := app.WatchConstraints()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := app.SetConstraints(constraints.MustParse("mem-request=1G mem-limit=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	cons, err := app.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem-request=1G mem-limit=4G"))
+}
+
 func (s *ApplicationSuite) TestUpdateApplicationConfig(c *gc.C) {
 	sch := s.AddTestingCharm(c, "dummy")
 	for i, t := range updateApplicationConfigTests {
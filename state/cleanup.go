@@ -61,6 +61,10 @@ const (
 	cleanupStorageForDyingModel  cleanupKind = "modelStorage"
 	cleanupForceStorage          cleanupKind = "forceStorage"
 	cleanupBranchesForDyingModel cleanupKind = "branches"
+
+	// cleanupReplacementUnit adds the unit replacing a unit of a
+	// replaced machine, once the original unit's storage is detached.
+	cleanupReplacementUnit cleanupKind = "replacementUnit"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -206,6 +210,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupForceStorage(args)
 		case cleanupBranchesForDyingModel:
 			err = st.cleanupBranchesForDyingModel(args)
+		case cleanupReplacementUnit:
+			err = st.cleanupReplacementUnit(doc.Prefix, args)
 		default:
 			err = errors.Errorf("unknown cleanup kind %q", doc.Kind)
 		}
@@ -17,58 +17,79 @@ import (
 
 // constraintsDoc is the Mongo DB representation of a constraints.Value.
 type constraintsDoc struct {
-	DocID            string `bson:"_id,omitempty"`
-	ModelUUID        string `bson:"model-uuid"`
-	Arch             *string
-	CpuCores         *uint64
-	CpuPower         *uint64
-	Mem              *uint64
-	RootDisk         *uint64
-	RootDiskSource   *string
-	InstanceType     *string
-	Container        *instance.ContainerType
-	Tags             *[]string
-	Spaces           *[]string
-	VirtType         *string
-	Zones            *[]string
-	AllocatePublicIP *bool
+	DocID                 string `bson:"_id,omitempty"`
+	ModelUUID             string `bson:"model-uuid"`
+	Arch                  *string
+	CpuCores              *uint64
+	CpuPower              *uint64
+	Mem                   *uint64
+	RootDisk              *uint64
+	RootDiskSource        *string
+	InstanceType          *string
+	Container             *instance.ContainerType
+	Tags                  *[]string
+	Spaces                *[]string
+	VirtType              *string
+	Zones                 *[]string
+	AllocatePublicIP      *bool
+	Spot                  *bool
+	MemRequest            *uint64
+	MemLimit              *uint64
+	CpuRequest            *uint64
+	CpuLimit              *uint64
+	EphemeralStorageLimit *uint64
+	ContainerResources    *[]string
 }
 
 func newConstraintsDoc(cons constraints.Value, id string) constraintsDoc {
 	result := constraintsDoc{
-		DocID:            id,
-		Arch:             cons.Arch,
-		CpuCores:         cons.CpuCores,
-		CpuPower:         cons.CpuPower,
-		Mem:              cons.Mem,
-		RootDisk:         cons.RootDisk,
-		RootDiskSource:   cons.RootDiskSource,
-		InstanceType:     cons.InstanceType,
-		Container:        cons.Container,
-		Tags:             cons.Tags,
-		Spaces:           cons.Spaces,
-		VirtType:         cons.VirtType,
-		Zones:            cons.Zones,
-		AllocatePublicIP: cons.AllocatePublicIP,
+		DocID:                 id,
+		Arch:                  cons.Arch,
+		CpuCores:              cons.CpuCores,
+		CpuPower:              cons.CpuPower,
+		Mem:                   cons.Mem,
+		RootDisk:              cons.RootDisk,
+		RootDiskSource:        cons.RootDiskSource,
+		InstanceType:          cons.InstanceType,
+		Container:             cons.Container,
+		Tags:                  cons.Tags,
+		Spaces:                cons.Spaces,
+		VirtType:              cons.VirtType,
+		Zones:                 cons.Zones,
+		AllocatePublicIP:      cons.AllocatePublicIP,
+		Spot:                  cons.Spot,
+		MemRequest:            cons.MemRequest,
+		MemLimit:              cons.MemLimit,
+		CpuRequest:            cons.CpuRequest,
+		CpuLimit:              cons.CpuLimit,
+		EphemeralStorageLimit: cons.EphemeralStorageLimit,
+		ContainerResources:    cons.ContainerResources,
 	}
 	return result
 }
 
 func (doc constraintsDoc) value() constraints.Value {
 	result := constraints.Value{
-		Arch:             doc.Arch,
-		CpuCores:         doc.CpuCores,
-		CpuPower:         doc.CpuPower,
-		Mem:              doc.Mem,
-		RootDisk:         doc.RootDisk,
-		RootDiskSource:   doc.RootDiskSource,
-		InstanceType:     doc.InstanceType,
-		Container:        doc.Container,
-		Tags:             doc.Tags,
-		Spaces:           doc.Spaces,
-		VirtType:         doc.VirtType,
-		Zones:            doc.Zones,
-		AllocatePublicIP: doc.AllocatePublicIP,
+		Arch:                  doc.Arch,
+		CpuCores:              doc.CpuCores,
+		CpuPower:              doc.CpuPower,
+		Mem:                   doc.Mem,
+		RootDisk:              doc.RootDisk,
+		RootDiskSource:        doc.RootDiskSource,
+		InstanceType:          doc.InstanceType,
+		Container:             doc.Container,
+		Tags:                  doc.Tags,
+		Spaces:                doc.Spaces,
+		VirtType:              doc.VirtType,
+		Zones:                 doc.Zones,
+		AllocatePublicIP:      doc.AllocatePublicIP,
+		Spot:                  doc.Spot,
+		MemRequest:            doc.MemRequest,
+		MemLimit:              doc.MemLimit,
+		CpuRequest:            doc.CpuRequest,
+		CpuLimit:              doc.CpuLimit,
+		EphemeralStorageLimit: doc.EphemeralStorageLimit,
+		ContainerResources:    doc.ContainerResources,
 	}
 	return result
 }
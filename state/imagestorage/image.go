@@ -99,15 +99,17 @@ func (s *imageStorage) AddImage(r io.Reader, metadata *Metadata) (resultErr erro
 	}()
 
 	newDoc := imageMetadataDoc{
-		Id:        docId(metadata),
-		ModelUUID: s.modelUUID,
-		Kind:      metadata.Kind,
-		Series:    metadata.Series,
-		Arch:      metadata.Arch,
-		Size:      metadata.Size,
-		SHA256:    metadata.SHA256,
-		SourceURL: metadata.SourceURL,
-		Path:      path,
+		Id:          docId(metadata),
+		ModelUUID:   s.modelUUID,
+		Kind:        metadata.Kind,
+		Series:      metadata.Series,
+		Arch:        metadata.Arch,
+		Size:        metadata.Size,
+		SHA256:      metadata.SHA256,
+		SourceURL:   metadata.SourceURL,
+		Fingerprint: metadata.Fingerprint,
+		Stream:      metadata.Stream,
+		Path:        path,
 		// TODO(fwereade): 2016-03-17 lp:1558657
 		Created: time.Now(),
 	}
@@ -124,7 +126,8 @@ func (s *imageStorage) AddImage(r io.Reader, metadata *Metadata) (resultErr erro
 		// On the first attempt we assume we're adding a new image blob.
 		// Subsequent attempts to add image will fetch the existing
 		// doc, record the old path, and attempt to update the
-		// size, path and hash fields.
+		// size, path and hash fields, along with where the image
+		// came from.
 		if attempt == 0 {
 			op.Assert = txn.DocMissing
 			op.Insert = &newDoc
@@ -135,15 +138,17 @@ func (s *imageStorage) AddImage(r io.Reader, metadata *Metadata) (resultErr erro
 			}
 			oldPath = oldDoc.Path
 			op.Assert = bson.D{{"path", oldPath}}
-			if oldPath != path {
-				op.Update = bson.D{{
-					"$set", bson.D{
-						{"size", metadata.Size},
-						{"sha256", metadata.SHA256},
-						{"path", path},
-					},
-				}}
-			}
+			op.Update = bson.D{{
+				"$set", bson.D{
+					{"size", metadata.Size},
+					{"sha256", metadata.SHA256},
+					{"path", path},
+					{"sourceurl", metadata.SourceURL},
+					{"fingerprint", metadata.Fingerprint},
+					{"stream", metadata.Stream},
+					{"created", newDoc.Created},
+				},
+			}}
 		}
 		return []txn.Op{op}, nil
 	}
@@ -182,6 +187,9 @@ func (s *imageStorage) ListImages(filter ImageFilter) ([]*Metadata, error) {
 			SHA256:    metadataDoc.SHA256,
 			Created:   metadataDoc.Created,
 			SourceURL: metadataDoc.SourceURL,
+
+			Fingerprint: metadataDoc.Fingerprint,
+			Stream:      metadataDoc.Stream,
 		}
 	}
 	return result, nil
@@ -248,6 +256,9 @@ func (s *imageStorage) Image(kind, series, arch string) (*Metadata, io.ReadClose
 		SHA256:    metadataDoc.SHA256,
 		SourceURL: metadataDoc.SourceURL,
 		Created:   metadataDoc.Created,
+
+		Fingerprint: metadataDoc.Fingerprint,
+		Stream:      metadataDoc.Stream,
 	}
 	imageResult := &imageCloser{
 		image,
@@ -267,6 +278,9 @@ type imageMetadataDoc struct {
 	Path      string    `bson:"path"`
 	Created   time.Time `bson:"created"`
 	SourceURL string    `bson:"sourceurl"`
+
+	Fingerprint string `bson:"fingerprint,omitempty"`
+	Stream      string `bson:"stream,omitempty"`
 }
 
 func (s *imageStorage) imageMetadataDoc(modelUUID, kind, series, arch string) (imageMetadataDoc, error) {
@@ -95,6 +95,9 @@ func (s *ImageSuite) testAddImage(c *gc.C, content string) {
 		Size:      int64(len(content)),
 		SHA256:    "hash(" + content + ")",
 		SourceURL: "http://path",
+
+		Fingerprint: "fingerprint(" + content + ")",
+		Stream:      "released",
 	}
 	err := s.storage.AddImage(r, addedMetadata)
 	c.Assert(err, gc.IsNil)
@@ -18,6 +18,11 @@ type Metadata struct {
 	SHA256    string
 	Created   time.Time
 	SourceURL string
+
+	// Fingerprint and Stream identify the upstream image that was
+	// stored, if known, so that it can be refreshed when that changes.
+	Fingerprint string
+	Stream      string
 }
 
 // ImageFilter is used to query image metadata.
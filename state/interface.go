@@ -241,6 +241,10 @@ type Action interface {
 	// Log adds message to the action's progress message array.
 	Log(message string) error
 
+	// LogOutput adds a chunk of output written by the action to the
+	// given stream, either "stdout" or "stderr".
+	LogOutput(stream, data string) error
+
 	// Messages returns the action's progress messages.
 	Messages() []ActionMessage
 
@@ -2074,24 +2074,36 @@ func (m *Machine) VolumeAttachments() ([]VolumeAttachment, error) {
 
 // AddAction is part of the ActionReceiver interface.
 func (m *Machine) AddAction(operationID, name string, payload map[string]interface{}, parallel *bool, executionGroup *string) (Action, error) {
+	payloadWithDefaults, runParallel, runExecutionGroup, err := m.prepareAction(name, payload, parallel, executionGroup)
+	if err != nil {
+		return nil, err
+	}
+	model, err := m.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return model.EnqueueAction(operationID, m.Tag(), name, payloadWithDefaults, runParallel, runExecutionGroup)
+}
+
+// prepareAction validates the named predefined action and its payload,
+// returning the payload with defaults inserted along with the parallel
+// and execution group settings the action should run with.
+func (m *Machine) prepareAction(
+	name string, payload map[string]interface{}, parallel *bool, executionGroup *string,
+) (map[string]interface{}, bool, string, error) {
 	spec, ok := actions.PredefinedActionsSpec[name]
 	if !ok {
-		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
+		return nil, false, "", errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
 	}
 
 	// Reject bad payloads before attempting to insert defaults.
 	err := spec.ValidateParams(payload)
 	if err != nil {
-		return nil, err
+		return nil, false, "", err
 	}
 	payloadWithDefaults, err := spec.InsertDefaults(payload)
 	if err != nil {
-		return nil, err
-	}
-
-	model, err := m.st.Model()
-	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, false, "", err
 	}
 
 	runParallel := spec.Parallel
@@ -2102,7 +2114,7 @@ func (m *Machine) AddAction(operationID, name string, payload map[string]interfa
 	if executionGroup != nil {
 		runExecutionGroup = *executionGroup
 	}
-	return model.EnqueueAction(operationID, m.Tag(), name, payloadWithDefaults, runParallel, runExecutionGroup)
+	return payloadWithDefaults, runParallel, runExecutionGroup, nil
 }
 
 // CancelAction is part of the ActionReceiver interface.
@@ -0,0 +1,345 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// replacedMachineMaxWait is how long the force destruction of a replaced
+// machine waits for its units to clean up before removing them anyway.
+// The machine's instance is already gone, so there is nothing to wait for.
+const replacedMachineMaxWait = time.Duration(0)
+
+// ReplaceMachine adds a new machine to take over from the machine with
+// the given id, whose instance has been lost. The new machine has the
+// same series, constraints, jobs and placement as the original; when the
+// original was not placed explicitly it is placed in the same
+// availability zone. The original machine is force destroyed along with
+// its units, and a new unit of each of its principal applications is
+// assigned to the new machine once the original unit has been removed.
+//
+// The new machine, the destruction of the original and the replacement
+// of its units are committed in a single transaction, so a machine is
+// either replaced completely or not at all; the original is left Dying,
+// so it is not replaced twice. Units with detachable storage are given
+// the original unit's storage in place of new storage. Each step is
+// recorded in the status history of the machines and units involved.
+//
+// Controller, manually provisioned and container-hosting machines cannot
+// be replaced.
+func (st *State) ReplaceMachine(id string) (_ *Machine, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot replace machine %s", id)
+
+	m, err := st.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	template, err := replacementMachineTemplate(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sb, err := NewStorageBackend(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var (
+		mdoc   *machineDoc
+		addOps []txn.Op
+	)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := checkReplaceable(m); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if mdoc == nil {
+			if mdoc, addOps, err = st.addMachineOps(template); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		replaceOps, err := replaceMachineOps(sb, m, mdoc.Id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := append([]txn.Op(nil), addOps...)
+		return append(ops, replaceOps...), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	replacement := newMachine(st, mdoc)
+	logger.Infof("added machine %s to replace machine %s", replacement.Id(), m.Id())
+
+	now := st.clock().Now()
+	if err := replacement.SetStatus(status.StatusInfo{
+		Status:  status.Pending,
+		Message: fmt.Sprintf("replacing machine %s", m.Id()),
+		Since:   &now,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := m.SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: fmt.Sprintf("instance lost, replaced by machine %s", replacement.Id()),
+		Since:   &now,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return replacement, nil
+}
+
+// CanReplace returns an error satisfying errors.IsNotSupported if
+// the machine is a controller, is manually provisioned or hosts
+// containers, none of which ReplaceMachine can replace.
+func (m *Machine) CanReplace() error {
+	if m.IsManager() {
+		return errors.NewNotSupported(nil, "machine is a controller")
+	}
+	if manual, err := m.IsManual(); err != nil {
+		return errors.Trace(err)
+	} else if manual {
+		return errors.NewNotSupported(nil, "machine is manually provisioned")
+	}
+	if containers, err := m.Containers(); err != nil {
+		return errors.Trace(err)
+	} else if len(containers) > 0 {
+		return errors.NewNotSupported(nil, fmt.Sprintf("machine hosts containers %v", containers))
+	}
+	return nil
+}
+
+// checkReplaceable returns an error if the given machine cannot be
+// replaced.
+func checkReplaceable(m *Machine) error {
+	if m.Life() != Alive {
+		return errors.Errorf("machine is not alive")
+	}
+	return errors.Trace(m.CanReplace())
+}
+
+// replaceMachineOps returns the operations needed to set the given
+// machine to Dying and force destroy it, and to replace each of its
+// principal units with a unit on the machine with the given id once
+// the original unit has been removed.
+func replaceMachineOps(sb *storageBackend, m *Machine, replacementId string) ([]txn.Op, error) {
+	unitsAssert := noUnitAsserts()
+	if len(m.doc.Principals) > 0 {
+		unitsAssert = bson.DocElem{"principals", m.doc.Principals}
+	}
+	ops := []txn.Op{{
+		C:  machinesC,
+		Id: m.doc.DocID,
+		Assert: bson.D{
+			{"life", Alive},
+			{"jobs", bson.D{{"$nin", []MachineJob{JobManageModel}}}},
+			unitsAssert,
+		},
+		Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+	}, m.noContainersOp()}
+	for _, unitName := range m.doc.Principals {
+		u, err := m.st.Unit(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		storageIds, err := unitDetachableStorage(sb, u)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(storageIds) > 0 {
+			logger.Infof("replacing unit %s once its storage %v is detached", unitName, storageIds)
+		}
+		ops = append(ops, newCleanupOp(cleanupReplacementUnit, unitName, replacementId, storageIds))
+	}
+	return append(ops, newCleanupOp(cleanupForceDestroyedMachine, m.doc.Id, replacedMachineMaxWait)), nil
+}
+
+// unitDetachableStorage returns the ids of the detachable storage
+// instances owned by the given unit.
+func unitDetachableStorage(sb *storageBackend, u *Unit) ([]string, error) {
+	attachments, err := sb.UnitStorageAttachments(u.UnitTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	for _, a := range attachments {
+		si, err := sb.storageInstance(a.StorageInstance())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if owner, ok := si.Owner(); !ok || owner != u.UnitTag() {
+			continue
+		}
+		var detachable bool
+		switch si.Kind() {
+		case StorageKindBlock:
+			v, err := sb.storageInstanceVolume(si.StorageTag())
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			detachable = v.Detachable()
+		case StorageKindFilesystem:
+			f, err := sb.storageInstanceFilesystem(si.StorageTag())
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			detachable = f.Detachable()
+		}
+		if detachable {
+			ids = append(ids, si.StorageTag().Id())
+		}
+	}
+	return ids, nil
+}
+
+// addReplacementUnit adds a unit of the given application, attached to
+// the given storage, to take over from the named unit on the given
+// replacement machine.
+func addReplacementUnit(app *Application, unitName string, machine *Machine, storage []names.StorageTag) (*Unit, error) {
+	newUnit, err := app.AddUnit(AddUnitParams{AttachStorage: storage})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := newUnit.AssignToMachine(machine); err != nil {
+		// Remove the unassigned unit, so that a retry of the
+		// replacement doesn't leave it behind.
+		if destroyErr := newUnit.Destroy(); destroyErr != nil {
+			logger.Warningf("cannot remove unassigned unit %s: %v", newUnit.Name(), destroyErr)
+		}
+		return nil, errors.Trace(err)
+	}
+	logger.Infof("replacing unit %s with %s on machine %s", unitName, newUnit.Name(), machine.Id())
+
+	message := fmt.Sprintf("replacing unit %s", unitName)
+	if len(storage) > 0 {
+		ids := make([]string, len(storage))
+		for i, tag := range storage {
+			ids[i] = tag.Id()
+		}
+		message += fmt.Sprintf(", reattaching storage %s", strings.Join(ids, ", "))
+	}
+	now := app.st.clock().Now()
+	if err := newUnit.SetStatus(status.StatusInfo{
+		Status:  status.Waiting,
+		Message: message,
+		Since:   &now,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newUnit, nil
+}
+
+// cleanupReplacementUnit adds the unit that replaces the named unit of a
+// replaced machine, attaching the original unit's detachable storage if
+// it had any.
+// It fails, to be retried, until the original unit has been removed and
+// its storage detached.
+func (st *State) cleanupReplacementUnit(unitName string, cleanupArgs []bson.Raw) error {
+	if n := len(cleanupArgs); n != 2 {
+		return errors.Errorf("expected 2 arguments, got %d", n)
+	}
+	var machineId string
+	if err := cleanupArgs[0].Unmarshal(&machineId); err != nil {
+		return errors.Annotate(err, "unmarshalling cleanup arg 'machineId'")
+	}
+	var storageIds []string
+	if err := cleanupArgs[1].Unmarshal(&storageIds); err != nil {
+		return errors.Annotate(err, "unmarshalling cleanup arg 'storageIds'")
+	}
+
+	if _, err := st.Unit(unitName); err == nil {
+		return errors.Errorf("unit %s has not been removed yet", unitName)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	machine, err := st.Machine(machineId)
+	if errors.IsNotFound(err) || (err == nil && machine.Life() != Alive) {
+		logger.Infof("not replacing unit %s: machine %s is no longer alive", unitName, machineId)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	appName, err := names.UnitApplication(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := st.Application(appName)
+	if errors.IsNotFound(err) || (err == nil && app.Life() != Alive) {
+		logger.Infof("not replacing unit %s: application %s is no longer alive", unitName, appName)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	sb, err := NewStorageBackend(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var storage []names.StorageTag
+	for _, id := range storageIds {
+		tag := names.NewStorageTag(id)
+		si, err := sb.storageInstance(tag)
+		if errors.IsNotFound(err) {
+			logger.Warningf("storage %s of unit %s has been removed", id, unitName)
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if si.Life() != Alive {
+			logger.Warningf("storage %s of unit %s is %s", id, unitName, si.Life())
+			continue
+		}
+		if owner, ok := si.Owner(); ok {
+			return errors.Errorf("storage %s is still owned by %s", id, names.ReadableString(owner))
+		}
+		storage = append(storage, tag)
+	}
+	_, err = addReplacementUnit(app, unitName, machine, storage)
+	return errors.Trace(err)
+}
+
+// replacementMachineTemplate returns a template for a machine that
+// matches the given machine.
+func replacementMachineTemplate(m *Machine) (MachineTemplate, error) {
+	cons, err := m.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return MachineTemplate{}, errors.Trace(err)
+	}
+	template := MachineTemplate{
+		Series:      m.Series(),
+		Constraints: cons,
+		Jobs:        m.Jobs(),
+		Placement:   m.Placement(),
+	}
+	if template.Placement != "" {
+		return template, nil
+	}
+	hc, err := m.HardwareCharacteristics()
+	if errors.IsNotFound(err) {
+		return template, nil
+	} else if err != nil {
+		return MachineTemplate{}, errors.Trace(err)
+	}
+	if hc.AvailabilityZone != nil && *hc.AvailabilityZone != "" {
+		template.Placement = "zone=" + *hc.AvailabilityZone
+	}
+	return template, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type MachineReplaceSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&MachineReplaceSuite{})
+
+func (s *MachineReplaceSuite) TestReplaceMachine(c *gc.C) {
+	zone := "us-east-1b"
+	m := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("mem=4G spot=true"),
+		Characteristics: &instance.HardwareCharacteristics{
+			AvailabilityZone: &zone,
+		},
+	})
+	app := s.Factory.MakeApplication(c, nil)
+	u := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: m})
+
+	replacement, err := s.State.ReplaceMachine(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replacement.Id(), gc.Not(gc.Equals), m.Id())
+	c.Check(replacement.Series(), gc.Equals, m.Series())
+	c.Check(replacement.Placement(), gc.Equals, "zone=us-east-1b")
+	cons, err := replacement.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons.String(), gc.Equals, "mem=4096M spot=true")
+
+	c.Assert(m.Refresh(), jc.ErrorIsNil)
+	c.Check(m.Life(), gc.Equals, state.Dying)
+	statusInfo, err := m.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Error)
+	c.Check(statusInfo.Message, gc.Equals, "instance lost, replaced by machine "+replacement.Id())
+
+	// The new unit is added once the original unit has been removed.
+	units, err := replacement.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+	for i := 0; i < 5 && len(units) == 0; i++ {
+		assertCleanupRuns(c, s.State)
+		units, err = replacement.Units()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(units, gc.HasLen, 1)
+	c.Check(units[0].ApplicationName(), gc.Equals, app.Name())
+	c.Check(units[0].Name(), gc.Not(gc.Equals), u.Name())
+
+	statusInfo, err = units[0].Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Waiting)
+	c.Check(statusInfo.Message, gc.Equals, "replacing unit "+u.Name())
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineOnlyOnce(c *gc.C) {
+	m := s.Factory.MakeMachine(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Machine: m})
+
+	_, err := s.State.ReplaceMachine(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ReplaceMachine(m.Id())
+	c.Assert(err, gc.ErrorMatches, "cannot replace machine "+m.Id()+": machine is not alive")
+
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 2)
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineConcurrentlyAdvancedLife(c *gc.C) {
+	m := s.Factory.MakeMachine(c, nil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		c.Assert(m.Destroy(), jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.State.ReplaceMachine(m.Id())
+	c.Assert(err, gc.ErrorMatches, "cannot replace machine "+m.Id()+": machine is not alive")
+
+	// Nothing is left half done.
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineNotAlive(c *gc.C) {
+	m := s.Factory.MakeMachine(c, nil)
+	c.Assert(m.Destroy(), jc.ErrorIsNil)
+	_, err := s.State.ReplaceMachine(m.Id())
+	c.Assert(err, gc.ErrorMatches, "cannot replace machine "+m.Id()+": machine is not alive")
+}
+
+func (s *MachineReplaceSuite) TestCanReplace(c *gc.C) {
+	m := s.Factory.MakeMachine(c, nil)
+	c.Assert(m.CanReplace(), jc.ErrorIsNil)
+}
+
+func (s *MachineReplaceSuite) TestCanReplaceController(c *gc.C) {
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobManageModel},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.CanReplace()
+	c.Assert(err, gc.ErrorMatches, "machine is a controller")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	_, err = s.State.ReplaceMachine(m.Id())
+	c.Assert(err, gc.ErrorMatches, "cannot replace machine "+m.Id()+": machine is a controller")
+}
+
+func (s *MachineReplaceSuite) TestCanReplaceContainerHost(c *gc.C) {
+	m := s.Factory.MakeMachine(c, nil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, m.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.CanReplace()
+	c.Assert(err, gc.ErrorMatches, `machine hosts containers \[`+container.Id()+`\]`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	// The container itself can be replaced.
+	c.Assert(container.CanReplace(), jc.ErrorIsNil)
+}
+
+type MachineReplaceStorageSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&MachineReplaceStorageSuite{})
+
+func (s *MachineReplaceStorageSuite) TestReplaceMachineReattachesDetachableStorage(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	m := unitMachine(c, s.st, u)
+	err = m.SetProvisioned("inst-id", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	replacement, err := s.st.ReplaceMachine(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	statusInfo, err := replacement.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Message, gc.Equals, "replacing machine "+m.Id())
+
+	// The new unit is only added once the original unit has been
+	// removed and its storage detached.
+	units, err := replacement.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+	for i := 0; i < 5 && len(units) == 0; i++ {
+		assertCleanupRuns(c, s.st)
+		units, err = replacement.Units()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(units, gc.HasLen, 1)
+	c.Check(units[0].ApplicationName(), gc.Equals, app.Name())
+	c.Check(units[0].Name(), gc.Not(gc.Equals), u.Name())
+
+	attachments, err := s.storageBackend.UnitStorageAttachments(units[0].UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Check(attachments[0].StorageInstance(), gc.Equals, storageTag)
+
+	statusInfo, err = units[0].Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Waiting)
+	c.Check(statusInfo.Message, gc.Equals, "replacing unit "+u.Name()+", reattaching storage "+storageTag.Id())
+}
@@ -18,6 +18,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/core/charm"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/container"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/network"
@@ -213,6 +214,9 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 	if err := export.operations(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.actionSchedules(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if err := export.cloudimagemetadata(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -866,6 +870,12 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	}
 
 	// Include exposed endpoint details
+	if application.doc.ExposedIngress != nil {
+		// The model description has no place for the ingress
+		// settings yet, so refuse to export them rather than
+		// silently drop them.
+		return errors.NotSupportedf("migrating exposed ingress for application %q", appName)
+	}
 	if len(application.doc.ExposedEndpoints) > 0 {
 		args.ExposedEndpoints = make(map[string]description.ExposedEndpointArgs)
 		for epName, details := range application.doc.ExposedEndpoints {
@@ -1701,6 +1711,26 @@ func (e *exporter) operations() error {
 	return nil
 }
 
+// actionSchedules refuses to export a model with action schedules,
+// which the model description has no place for yet, rather than
+// silently drop them.
+func (e *exporter) actionSchedules() error {
+	if e.cfg.SkipActions {
+		return nil
+	}
+
+	coll, closer := e.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+	n, err := coll.Count()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n > 0 {
+		return errors.NotSupportedf("migrating %d action schedules", n)
+	}
+	return nil
+}
+
 func (e *exporter) readAllRelationScopes() (set.Strings, error) {
 	relationScopes, closer := e.st.db().GetCollection(relationScopesC)
 	defer closer()
@@ -2106,6 +2136,21 @@ func (e *exporter) statusHistoryArgs(globalKey string) []description.StatusArgs
 	return result
 }
 
+// unmigratedConstraints are the constraints the model description
+// can't carry, with the keys of their fields in constraints docs.
+var unmigratedConstraints = []struct {
+	docKey string
+	name   string
+}{
+	{"memrequest", constraints.MemRequest},
+	{"memlimit", constraints.MemLimit},
+	{"cpurequest", constraints.CpuRequest},
+	{"cpulimit", constraints.CpuLimit},
+	{"ephemeralstoragelimit", constraints.EphemeralStorageLimit},
+	{"containerresources", constraints.ContainerResources},
+	{"spot", constraints.Spot},
+}
+
 func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArgs, error) {
 	doc, found := e.constraints[globalKey]
 	if !found {
@@ -2160,6 +2205,15 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 		}
 		return nil
 	}
+	// The model description has no place for the k8s resource
+	// constraints yet, so refuse to export them rather than
+	// silently drop them.
+	for _, field := range unmigratedConstraints {
+		if doc[field.docKey] != nil {
+			return description.ConstraintsArgs{}, errors.NotSupportedf(
+				"migrating %q constraint for %q", field.name, globalKey)
+		}
+	}
 	result := description.ConstraintsArgs{
 		Architecture:   optionalString("arch"),
 		Container:      optionalString("container"),
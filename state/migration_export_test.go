@@ -373,6 +373,15 @@ func (s *MigrationExportSuite) assertMachinesMigrated(c *gc.C, cons constraints.
 	c.Assert(exAddr.SpaceID(), gc.Equals, "0")
 }
 
+func (s *MigrationExportSuite) TestMachineWithSpotConstraintNotSupported(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("spot=true"),
+	})
+
+	_, err := s.State.Export()
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`.*migrating "spot" constraint for "m#%s" not supported`, machine.Id()))
+}
+
 func (s *MigrationExportSuite) TestMachineDevices(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, nil)
 	// Create two devices, first with all fields set, second just to show that
@@ -439,6 +448,40 @@ func (s *MigrationExportSuite) TestApplicationsWithRootDiskSourceConstraint(c *g
 	s.assertMigrateApplications(c, s.State, constraints.MustParse("arch=amd64 mem=8G root-disk-source=vonnegut"))
 }
 
+func (s *MigrationExportSuite) TestApplicationsWithResourceConstraintsNotSupported(c *gc.C) {
+	caasSt := s.Factory.MakeCAASModel(c, nil)
+	s.AddCleanup(func(_ *gc.C) { caasSt.Close() })
+	f := factory.NewFactory(caasSt, s.StatePool)
+
+	ch := f.MakeCharm(c, &factory.CharmParams{Series: "kubernetes"})
+	f.MakeApplication(c, &factory.ApplicationParams{
+		Name:        "gitlab",
+		Charm:       ch,
+		Constraints: constraints.MustParse("mem-request=1G"),
+	})
+
+	_, err := caasSt.Export()
+	c.Assert(err, gc.ErrorMatches, `.*migrating "mem-request" constraint for "a#gitlab" not supported`)
+}
+
+func (s *MigrationExportSuite) TestApplicationsWithExposedIngressNotSupported(c *gc.C) {
+	caasSt := s.Factory.MakeCAASModel(c, nil)
+	s.AddCleanup(func(_ *gc.C) { caasSt.Close() })
+	f := factory.NewFactory(caasSt, s.StatePool)
+
+	ch := f.MakeCharm(c, &factory.CharmParams{Series: "kubernetes"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "gitlab",
+		Charm: ch,
+	})
+	err := app.MergeExposeSettingsWithIngress(nil, &state.ExposedIngress{Hostname: "gitlab.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = caasSt.Export()
+	c.Assert(err, gc.ErrorMatches, `.*migrating exposed ingress for application "gitlab" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, st *state.State, cons constraints.Value) {
 	f := factory.NewFactory(st, s.StatePool)
 
@@ -1648,6 +1691,25 @@ func (s *MigrationExportSuite) TestActions(c *gc.C) {
 	c.Assert(logs[0].Timestamp().IsZero(), jc.IsFalse)
 }
 
+func (s *MigrationExportSuite) TestActionSchedulesNotSupported(c *gc.C) {
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"})
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "dummy", Charm: ch})
+	_, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: "dummy",
+		Name:     "snapshot",
+		Cron:     "0 3 * * *",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "migrating 1 action schedules not supported")
+
+	// Partial exports which skip actions skip the schedules too.
+	_, err = s.State.ExportPartial(state.ExportConfig{SkipActions: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *MigrationExportSuite) TestActionsSkipped(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
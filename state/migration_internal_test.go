@@ -176,6 +176,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
 
+		// Action schedules are not yet part of the model description;
+		// models with any are refused by the export.
+		actionSchedulesC,
+
 		// Global settings store controller specific configuration settings
 		// and are not to be migrated.
 		globalSettingsC,
@@ -392,6 +396,9 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// ExposedIngress is not yet supported by the description package;
+		// models with any are refused by the export.
+		"ExposedIngress",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -424,6 +431,9 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Application",
 		// Resolved is not migrated as we check that all is good before we start.
 		"Resolved",
+		// Paused is not migrated, units resume hook execution once
+		// they are running against the new controller.
+		"Paused",
 		// Series and CharmURL also come from the application.
 		"Series",
 		"CharmURL",
@@ -580,6 +590,15 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"VirtType",
 		"Zones",
 		"AllocatePublicIP",
+		// The k8s resource and spot constraints aren't in the
+		// model description yet, so models using them fail to export.
+		"MemRequest",
+		"MemLimit",
+		"CpuRequest",
+		"CpuLimit",
+		"EphemeralStorageLimit",
+		"ContainerResources",
+		"Spot",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -13,6 +13,9 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+	stateerrors "github.com/juju/juju/state/errors"
 )
 
 // Operation represents a number of tasks resulting from running an action.
@@ -44,6 +47,18 @@ type Operation interface {
 	// Status returns the final state of the operation.
 	Status() ActionStatus
 
+	// ScheduleId returns the id of the action schedule which
+	// fired the operation, if any.
+	ScheduleId() string
+
+	// MaxParallel returns the maximum number of the operation's tasks
+	// which may run at once, or 0 if there is no limit.
+	MaxParallel() int
+
+	// FailureBudget returns the number of failed tasks after which no
+	// more of the operation's tasks are started, or 0 if there is no limit.
+	FailureBudget() int
+
 	// OperationTag returns the operation's tag.
 	OperationTag() names.OperationTag
 
@@ -75,6 +90,30 @@ type operationDoc struct {
 	// If not explicitly set, this is derived from the
 	// status of the associated actions.
 	Status ActionStatus `bson:"status"`
+
+	// ScheduleId is the id of the action schedule
+	// which fired the operation, if any.
+	ScheduleId string `bson:"schedule-id,omitempty"`
+
+	// Rollout is set for rolling operations, which start
+	// their tasks a few at a time.
+	Rollout *rolloutDoc `bson:"rollout,omitempty"`
+}
+
+// rolloutDoc records how the tasks of a rolling operation are started.
+type rolloutDoc struct {
+	// MaxParallel is the maximum number of tasks
+	// which may run at once, or 0 if there is no limit.
+	MaxParallel int `bson:"max-parallel,omitempty"`
+
+	// FailureBudget is the number of failed tasks after which
+	// queued tasks are cancelled, or 0 if there is no limit.
+	FailureBudget int `bson:"failure-budget,omitempty"`
+
+	// Stages holds the ids of the tasks which have not yet been
+	// started. The tasks in a stage are only started once all
+	// tasks in the earlier stages have finished.
+	Stages [][]string `bson:"stages,omitempty"`
 }
 
 // operation represents a group of associated actions.
@@ -140,6 +179,43 @@ func (op *operation) Status() ActionStatus {
 	return op.doc.Status
 }
 
+// ScheduleId returns the id of the action schedule which
+// fired the operation, if any.
+func (op *operation) ScheduleId() string {
+	return op.doc.ScheduleId
+}
+
+// MaxParallel returns the maximum number of the operation's tasks
+// which may run at once, or 0 if there is no limit.
+func (op *operation) MaxParallel() int {
+	if op.doc.Rollout == nil {
+		return 0
+	}
+	return op.doc.Rollout.MaxParallel
+}
+
+// FailureBudget returns the number of failed tasks after which no
+// more of the operation's tasks are started, or 0 if there is no limit.
+func (op *operation) FailureBudget() int {
+	if op.doc.Rollout == nil {
+		return 0
+	}
+	return op.doc.Rollout.FailureBudget
+}
+
+// queuedTasks returns the number of tasks of a rolling
+// operation which have not yet been started.
+func (op *operation) queuedTasks() int {
+	if op.doc.Rollout == nil {
+		return 0
+	}
+	var count int
+	for _, stage := range op.doc.Rollout.Stages {
+		count += len(stage)
+	}
+	return count
+}
+
 // Refresh refreshes the contents of the operation.
 func (op *operation) Refresh() error {
 	doc, taskStatus, err := op.st.getOperationDoc(op.Id())
@@ -217,6 +293,371 @@ func (m *Model) EnqueueOperation(summary string) (string, error) {
 	return operationID, errors.Trace(err)
 }
 
+// RolloutArgs holds the parameters of a rolling operation.
+type RolloutArgs struct {
+	// MaxParallel is the maximum number of tasks
+	// which may run at once, or 0 if there is no limit.
+	MaxParallel int
+
+	// FailureBudget is the number of failed tasks after which no
+	// more tasks are started, or 0 if there is no limit.
+	FailureBudget int
+
+	// LeaderOrder determines when the tasks targeting
+	// application leaders are started.
+	LeaderOrder actions.LeaderOrder
+}
+
+// RollingTaskArgs holds the parameters of a task of a rolling operation.
+type RollingTaskArgs struct {
+	// Receiver is the unit or machine to run the action on.
+	Receiver ActionReceiver
+
+	// Name is the name of the action to run.
+	Name string
+
+	// Parameters holds the parameters of the action.
+	Parameters map[string]interface{}
+
+	// Parallel and ExecutionGroup override the
+	// settings of the action's spec when not nil.
+	Parallel       *bool
+	ExecutionGroup *string
+
+	// Leader records whether the receiver
+	// is the leader of its application.
+	Leader bool
+}
+
+// RollingTaskResult holds the outcome of adding a task
+// to a rolling operation.
+type RollingTaskResult struct {
+	// Action is the task added, if any.
+	Action Action
+
+	// Error is set if the task could not be added.
+	Error error
+}
+
+// rollingTask is a task of a rolling operation which has
+// been validated against its receiver.
+type rollingTask struct {
+	index          int
+	receiver       names.Tag
+	collection     string
+	id             interface{}
+	name           string
+	payload        map[string]interface{}
+	parallel       bool
+	executionGroup string
+	leader         bool
+}
+
+// EnqueueRollingOperation records an operation whose tasks are started a
+// few at a time, along with its tasks, and starts the first of them.
+// The operation and all of its tasks are added in a single transaction.
+// Tasks which are rejected by their receivers are reported in the results
+// and not added; an operation left with no tasks is failed immediately.
+func (m *Model) EnqueueRollingOperation(summary string, args RolloutArgs, tasks []RollingTaskArgs) (string, []RollingTaskResult, error) {
+	if args.MaxParallel < 0 {
+		return "", nil, errors.NotValidf("max parallel %d", args.MaxParallel)
+	}
+	if args.FailureBudget < 0 {
+		return "", nil, errors.NotValidf("failure budget %d", args.FailureBudget)
+	}
+	if err := args.LeaderOrder.Validate(); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+
+	results := make([]RollingTaskResult, len(tasks))
+	var prepared []rollingTask
+	for i, task := range tasks {
+		collection, id, err := m.st.tagToCollectionAndId(task.Receiver.Tag())
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		payload, parallel, executionGroup, err := prepareReceiverAction(task.Receiver, task.Name, task.Parameters, task.Parallel, task.ExecutionGroup)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		prepared = append(prepared, rollingTask{
+			index:          i,
+			receiver:       task.Receiver.Tag(),
+			collection:     collection,
+			id:             id,
+			name:           task.Name,
+			payload:        payload,
+			parallel:       parallel,
+			executionGroup: executionGroup,
+			leader:         task.Leader,
+		})
+	}
+
+	var (
+		operationID string
+		actionDocs  map[int]actionDoc
+	)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			// Drop the tasks of receivers which have died since.
+			var alive []rollingTask
+			for _, task := range prepared {
+				if notDead, err := isNotDead(m.st, task.collection, task.id); err != nil {
+					return nil, errors.Trace(err)
+				} else if !notDead {
+					results[task.index].Error = stateerrors.ErrDead
+					continue
+				}
+				alive = append(alive, task)
+			}
+			prepared = alive
+		}
+
+		doc, id, err := newOperationDoc(m.st, summary)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		operationID = id
+		rollout := &rolloutDoc{
+			MaxParallel:   args.MaxParallel,
+			FailureBudget: args.FailureBudget,
+		}
+		doc.Rollout = rollout
+
+		actionDocs = make(map[int]actionDoc)
+		var taskOps []txn.Op
+		notifications := make(map[string]actionNotificationDoc)
+		taskIDs := make([]string, len(prepared))
+		leaders := set.NewStrings()
+		for i, task := range prepared {
+			adoc, ndoc, err := newActionDoc(m.st, operationID, task.receiver, task.name, task.payload, task.parallel, task.executionGroup)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			taskID := m.st.localID(adoc.DocId)
+			taskIDs[i] = taskID
+			if task.leader {
+				leaders.Add(taskID)
+			}
+			notifications[taskID] = ndoc
+			actionDocs[task.index] = adoc
+		}
+
+		stages := actions.RolloutStages(taskIDs, leaders.Contains, args.LeaderOrder)
+		dispatch, remaining := nextRolloutTasks(stages, 0, args.MaxParallel)
+		rollout.Stages = remaining
+		dispatched := set.NewStrings(dispatch...)
+
+		for i, task := range prepared {
+			adoc := actionDocs[task.index]
+			if !dispatched.Contains(taskIDs[i]) {
+				adoc.Message = queuedTaskMessage
+				actionDocs[task.index] = adoc
+			}
+			taskOps = append(taskOps, txn.Op{
+				C:      task.collection,
+				Id:     task.id,
+				Assert: notDeadDoc,
+			}, txn.Op{
+				C:      actionsC,
+				Id:     adoc.DocId,
+				Assert: txn.DocMissing,
+				Insert: adoc,
+			})
+			if dispatched.Contains(taskIDs[i]) {
+				ndoc := notifications[taskIDs[i]]
+				taskOps = append(taskOps, txn.Op{
+					C:      actionNotificationsC,
+					Id:     ndoc.DocId,
+					Assert: txn.DocMissing,
+					Insert: ndoc,
+				})
+			}
+		}
+		if len(prepared) == 0 {
+			doc.Status = ActionFailed
+			doc.Completed = m.st.nowToTheSecond()
+		}
+
+		ops := []txn.Op{{
+			C:      operationsC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}}
+		return append(ops, taskOps...), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return "", nil, errors.Annotate(err, "cannot enqueue rolling operation")
+	}
+	for index, doc := range actionDocs {
+		results[index].Action = newAction(m.st, doc)
+	}
+	return operationID, results, nil
+}
+
+// prepareReceiverAction validates the named action and its payload
+// against the receiver, returning the payload with defaults inserted
+// along with the parallel and execution group settings to run with.
+func prepareReceiverAction(
+	receiver ActionReceiver, name string, payload map[string]interface{}, parallel *bool, executionGroup *string,
+) (map[string]interface{}, bool, string, error) {
+	switch r := receiver.(type) {
+	case *Unit:
+		return r.prepareAction(name, payload, parallel, executionGroup)
+	case *Machine:
+		return r.prepareAction(name, payload, parallel, executionGroup)
+	}
+	return nil, false, "", errors.NotSupportedf("actions on %s", names.ReadableString(receiver.Tag()))
+}
+
+// nextRolloutTasks returns the queued tasks which can be started
+// given the number of tasks already in flight, along with the stages
+// left to run.
+func nextRolloutTasks(stages [][]string, inflight, maxParallel int) ([]string, [][]string) {
+	remaining := make([][]string, len(stages))
+	copy(remaining, stages)
+	var dispatch []string
+	for len(remaining) > 0 {
+		if len(remaining[0]) == 0 {
+			if inflight > 0 {
+				// Wait for the current stage to finish.
+				break
+			}
+			remaining = remaining[1:]
+			continue
+		}
+		count := len(remaining[0])
+		if maxParallel > 0 && count > maxParallel-inflight {
+			count = maxParallel - inflight
+		}
+		if count <= 0 {
+			break
+		}
+		dispatch = append(dispatch, remaining[0][:count]...)
+		remaining[0] = remaining[0][count:]
+		inflight += count
+	}
+	return dispatch, remaining
+}
+
+// advanceRollout returns the operations needed to move a rolling
+// operation on when the task with the given id finishes with the given
+// status. Queued tasks are started if there is room for them, or all
+// cancelled once the failure budget is exhausted. The number of
+// cancelled tasks and the stages left to run are also returned.
+func (op *operation) advanceRollout(taskID string, finalStatus ActionStatus, completedTime time.Time) ([]txn.Op, int, [][]string, error) {
+	rollout := op.doc.Rollout
+
+	// The finishing task is still queued if it was cancelled
+	// before it was started.
+	var queued []string
+	stages := make([][]string, len(rollout.Stages))
+	for i, stage := range rollout.Stages {
+		stages[i] = []string{}
+		for _, id := range stage {
+			if id != taskID {
+				stages[i] = append(stages[i], id)
+				queued = append(queued, id)
+			}
+		}
+	}
+
+	statuses := append(append([]ActionStatus(nil), op.taskStatus...), finalStatus)
+	var active, failed int
+	for _, status := range statuses {
+		switch status {
+		case ActionPending, ActionRunning, ActionAborting:
+			active++
+		case ActionFailed, ActionAborted:
+			failed++
+		}
+	}
+	// Neither the finishing task, counted once for its current
+	// status, nor the queued tasks are in flight.
+	inflight := active - 1 - len(queued)
+
+	if rollout.FailureBudget > 0 && failed >= rollout.FailureBudget {
+		ops := make([]txn.Op, len(queued))
+		for i, id := range queued {
+			ops[i] = txn.Op{
+				C:      actionsC,
+				Id:     op.st.docID(id),
+				Assert: bson.D{{"status", ActionPending}},
+				Update: bson.D{{"$set", bson.D{
+					{"status", ActionCancelled},
+					{"message", failureBudgetMessage},
+					{"completed", completedTime},
+				}}},
+			}
+		}
+		return ops, len(queued), nil, nil
+	}
+
+	dispatch, remaining := nextRolloutTasks(stages, inflight, rollout.MaxParallel)
+	ops, err := op.st.dispatchTaskOps(dispatch)
+	if err != nil {
+		return nil, 0, nil, errors.Trace(err)
+	}
+	return ops, 0, remaining, nil
+}
+
+const (
+	// queuedTaskMessage is the message of a task of a
+	// rolling operation which has not yet been started.
+	queuedTaskMessage = "waiting for earlier tasks of the operation"
+
+	// failureBudgetMessage is the message of a task of a rolling
+	// operation which was cancelled as too many other tasks failed.
+	failureBudgetMessage = "not started as too many tasks of the operation failed"
+)
+
+// dispatchTaskOps returns the operations needed to make queued
+// tasks of a rolling operation visible to their receivers.
+func (st *State) dispatchTaskOps(ids []string) ([]txn.Op, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	docIDs := make([]string, len(ids))
+	for i, id := range ids {
+		docIDs[i] = st.docID(id)
+	}
+	var docs []actionDoc
+	if err := actions.Find(bson.D{{"_id", bson.D{{"$in", docIDs}}}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get queued tasks")
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		if doc.Status != ActionPending {
+			// Cancelled before it was started.
+			continue
+		}
+		actionID := st.localID(doc.DocId)
+		ops = append(ops, txn.Op{
+			C:      actionsC,
+			Id:     doc.DocId,
+			Assert: bson.D{{"status", ActionPending}},
+			Update: bson.D{{"$set", bson.D{{"message", ""}}}},
+		}, txn.Op{
+			C:      actionNotificationsC,
+			Id:     st.docID(ensureActionMarker(doc.Receiver) + actionID),
+			Assert: txn.DocMissing,
+			Insert: actionNotificationDoc{
+				DocId:     st.docID(ensureActionMarker(doc.Receiver) + actionID),
+				ModelUUID: st.ModelUUID(),
+				Receiver:  doc.Receiver,
+				ActionID:  actionID,
+			},
+		})
+	}
+	return ops, nil
+}
+
 // Operation returns an Operation by Id.
 func (m *Model) Operation(id string) (Operation, error) {
 	doc, taskStatus, err := m.st.getOperationDoc(id)
@@ -321,7 +762,7 @@ type OperationInfo struct {
 // ListOperations returns operations that match the specified criteria.
 func (m *Model) ListOperations(
 	actionNames []string, actionReceivers []names.Tag, operationStatus []ActionStatus,
-	offset, limit int,
+	scheduleIDs []string, offset, limit int,
 ) ([]OperationInfo, bool, error) {
 	// First gather the matching actions and record the parent operation ids we need.
 	actionsCollection, closer := m.st.db().GetCollection(actionsC)
@@ -371,7 +812,11 @@ func (m *Model) ListOperations(
 	if len(operationStatus) > 0 {
 		statusTerm = bson.D{{"status", bson.D{{"$in", operationStatus}}}}
 	}
-	operationsQuery := append(idsTerm, statusTerm...)
+	var scheduleTerm bson.D
+	if len(scheduleIDs) > 0 {
+		scheduleTerm = bson.D{{"schedule-id", bson.D{{"$in", scheduleIDs}}}}
+	}
+	operationsQuery := append(append(idsTerm, statusTerm...), scheduleTerm...)
 
 	operationCollection, closer := m.st.db().GetCollection(operationsC)
 	defer closer()
@@ -0,0 +1,63 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+var _ = gc.Suite(&rolloutSuite{})
+
+type rolloutSuite struct {
+	testing.IsolationSuite
+}
+
+func (s *rolloutSuite) TestNextRolloutTasks(c *gc.C) {
+	for i, test := range []struct {
+		stages      [][]string
+		inflight    int
+		maxParallel int
+		dispatch    []string
+		remaining   [][]string
+	}{{
+		stages:    [][]string{{"1", "2", "3"}},
+		dispatch:  []string{"1", "2", "3"},
+		remaining: [][]string{{}},
+	}, {
+		stages:      [][]string{{"1", "2", "3"}},
+		maxParallel: 2,
+		dispatch:    []string{"1", "2"},
+		remaining:   [][]string{{"3"}},
+	}, {
+		stages:      [][]string{{"3"}},
+		inflight:    2,
+		maxParallel: 2,
+		remaining:   [][]string{{"3"}},
+	}, {
+		stages:      [][]string{{"1"}, {"2", "3"}},
+		maxParallel: 2,
+		dispatch:    []string{"1"},
+		remaining:   [][]string{{}, {"2", "3"}},
+	}, {
+		stages:      [][]string{{}, {"2", "3"}},
+		inflight:    1,
+		maxParallel: 2,
+		remaining:   [][]string{{}, {"2", "3"}},
+	}, {
+		stages:      [][]string{{}, {"2", "3"}},
+		maxParallel: 2,
+		dispatch:    []string{"2", "3"},
+		remaining:   [][]string{{}},
+	}, {
+		stages:    [][]string{{}},
+		remaining: [][]string{},
+	}} {
+		c.Logf("test %d", i)
+		dispatch, remaining := nextRolloutTasks(test.stages, test.inflight, test.maxParallel)
+		c.Check(dispatch, jc.DeepEquals, test.dispatch)
+		c.Check(remaining, jc.DeepEquals, test.remaining)
+	}
+}
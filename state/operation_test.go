@@ -12,7 +12,9 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -168,7 +170,7 @@ func (s *OperationSuite) assertActions(c *gc.C, operations []state.OperationInfo
 
 func (s *OperationSuite) TestListOperationsNoFilter(c *gc.C) {
 	s.setupOperations(c)
-	operations, truncated, err := s.Model.ListOperations(nil, nil, nil, 0, 0)
+	operations, truncated, err := s.Model.ListOperations(nil, nil, nil, nil, 0, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(truncated, jc.IsFalse)
 	c.Assert(operations, gc.HasLen, 3)
@@ -183,7 +185,7 @@ func (s *OperationSuite) TestListOperationsNoFilter(c *gc.C) {
 
 func (s *OperationSuite) TestListOperations(c *gc.C) {
 	unitTag := s.setupOperations(c)
-	operations, truncated, err := s.Model.ListOperations([]string{"backup"}, []names.Tag{unitTag}, []state.ActionStatus{state.ActionRunning}, 0, 0)
+	operations, truncated, err := s.Model.ListOperations([]string{"backup"}, []names.Tag{unitTag}, []state.ActionStatus{state.ActionRunning}, nil, 0, 0)
 	c.Assert(truncated, jc.IsFalse)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(operations, gc.HasLen, 1)
@@ -194,7 +196,7 @@ func (s *OperationSuite) TestListOperations(c *gc.C) {
 
 func (s *OperationSuite) TestListOperationsByStatus(c *gc.C) {
 	s.setupOperations(c)
-	operations, truncated, err := s.Model.ListOperations(nil, nil, []state.ActionStatus{state.ActionCompleted}, 0, 0)
+	operations, truncated, err := s.Model.ListOperations(nil, nil, []state.ActionStatus{state.ActionCompleted}, nil, 0, 0)
 	c.Assert(truncated, jc.IsFalse)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(operations, gc.HasLen, 1)
@@ -205,7 +207,7 @@ func (s *OperationSuite) TestListOperationsByStatus(c *gc.C) {
 
 func (s *OperationSuite) TestListOperationsByName(c *gc.C) {
 	s.setupOperations(c)
-	operations, truncated, err := s.Model.ListOperations([]string{"restore"}, nil, nil, 0, 0)
+	operations, truncated, err := s.Model.ListOperations([]string{"restore"}, nil, nil, nil, 0, 0)
 	c.Assert(truncated, jc.IsFalse)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(operations, gc.HasLen, 1)
@@ -216,7 +218,7 @@ func (s *OperationSuite) TestListOperationsByName(c *gc.C) {
 
 func (s *OperationSuite) TestListOperationsByReceiver(c *gc.C) {
 	unitTag := s.setupOperations(c)
-	operations, truncated, err := s.Model.ListOperations(nil, []names.Tag{unitTag}, nil, 0, 0)
+	operations, truncated, err := s.Model.ListOperations(nil, []names.Tag{unitTag}, nil, nil, 0, 0)
 	c.Assert(truncated, jc.IsFalse)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(operations, gc.HasLen, 2)
@@ -229,7 +231,7 @@ func (s *OperationSuite) TestListOperationsByReceiver(c *gc.C) {
 
 func (s *OperationSuite) TestListOperationsSubset(c *gc.C) {
 	s.setupOperations(c)
-	operations, truncated, err := s.Model.ListOperations(nil, nil, nil, 1, 1)
+	operations, truncated, err := s.Model.ListOperations(nil, nil, nil, nil, 1, 1)
 	c.Assert(truncated, jc.IsTrue)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(operations, gc.HasLen, 1)
@@ -263,3 +265,175 @@ func (s *OperationSuite) TestOperationWithActionsNotFound(c *gc.C) {
 	_, err := s.Model.OperationWithActions("1")
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *OperationSuite) setupRollingOperation(c *gc.C, args state.RolloutArgs, numUnits int) (string, []state.Action, []*state.Unit) {
+	charm := s.AddTestingCharm(c, "dummy")
+	application := s.AddTestingApplication(c, "dummy", charm)
+
+	var (
+		tasks []state.RollingTaskArgs
+		units []*state.Unit
+	)
+	for i := 0; i < numUnits; i++ {
+		unit, err := application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		units = append(units, unit)
+		tasks = append(tasks, state.RollingTaskArgs{Receiver: unit, Name: "snapshot"})
+	}
+	operationID, results, err := s.Model.EnqueueRollingOperation("a rolling operation", args, tasks)
+	c.Assert(err, jc.ErrorIsNil)
+	actions := make([]state.Action, len(results))
+	for i, result := range results {
+		c.Assert(result.Error, jc.ErrorIsNil)
+		actions[i] = result.Action
+	}
+	return operationID, actions, units
+}
+
+func (s *OperationSuite) assertDispatched(c *gc.C, units []*state.Unit, actions []state.Action, expected ...bool) {
+	for i, unit := range units {
+		var ids []string
+		if expected[i] {
+			ids = []string{actions[i].Id()}
+		}
+		w := unit.WatchPendingActionNotifications()
+		wc := statetesting.NewStringsWatcherC(c, s.State, w)
+		wc.AssertChange(ids...)
+		statetesting.AssertStop(c, w)
+	}
+}
+
+func (s *OperationSuite) TestRollingOperationMaxParallel(c *gc.C) {
+	operationID, actions, units := s.setupRollingOperation(c, state.RolloutArgs{MaxParallel: 2}, 3)
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.MaxParallel(), gc.Equals, 2)
+	c.Assert(operation.FailureBudget(), gc.Equals, 0)
+	s.assertDispatched(c, units, actions, true, true, false)
+
+	queued, err := s.Model.Action(actions[2].Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, message := queued.Results()
+	c.Assert(message, gc.Equals, "waiting for earlier tasks of the operation")
+
+	_, err = actions[0].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDispatched(c, units, actions, false, true, true)
+
+	_, err = actions[1].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = actions[2].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = operation.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionCompleted)
+}
+
+func (s *OperationSuite) TestRollingOperationFailureBudget(c *gc.C) {
+	operationID, actions, _ := s.setupRollingOperation(c, state.RolloutArgs{MaxParallel: 1, FailureBudget: 1}, 3)
+
+	_, err := actions[0].Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, a := range actions[1:] {
+		a, err := s.Model.Action(a.Id())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(a.Status(), gc.Equals, state.ActionCancelled)
+		_, message := a.Results()
+		c.Assert(message, gc.Equals, "not started as too many tasks of the operation failed")
+	}
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(operation.Completed().IsZero(), jc.IsFalse)
+}
+
+func (s *OperationSuite) TestRollingOperationCancelQueued(c *gc.C) {
+	_, actions, units := s.setupRollingOperation(c, state.RolloutArgs{MaxParallel: 1}, 3)
+
+	_, err := actions[1].Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = actions[0].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDispatched(c, units, actions, false, false, true)
+}
+
+func (s *OperationSuite) TestRollingOperationStages(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	application := s.AddTestingApplication(c, "dummy", charm)
+	var (
+		tasks []state.RollingTaskArgs
+		units []*state.Unit
+	)
+	for i := 0; i < 3; i++ {
+		unit, err := application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		units = append(units, unit)
+		tasks = append(tasks, state.RollingTaskArgs{Receiver: unit, Name: "snapshot", Leader: i == 2})
+	}
+	_, results, err := s.Model.EnqueueRollingOperation("a rolling operation", state.RolloutArgs{
+		LeaderOrder: actions.LeaderFirst,
+	}, tasks)
+	c.Assert(err, jc.ErrorIsNil)
+	enqueued := make([]state.Action, len(results))
+	for i, result := range results {
+		c.Assert(result.Error, jc.ErrorIsNil)
+		enqueued[i] = result.Action
+	}
+	s.assertDispatched(c, units, enqueued, false, false, true)
+
+	_, err = enqueued[2].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDispatched(c, units, enqueued, true, true, false)
+}
+
+func (s *OperationSuite) TestEnqueueRollingOperationRejectedTasks(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	application := s.AddTestingApplication(c, "dummy", charm)
+	unit, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operationID, results, err := s.Model.EnqueueRollingOperation("a rolling operation", state.RolloutArgs{}, []state.RollingTaskArgs{
+		{Receiver: unit, Name: "missing"},
+		{Receiver: unit, Name: "snapshot"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, gc.ErrorMatches, `action "missing" not defined on unit "dummy/0"`)
+	c.Assert(results[0].Action, gc.IsNil)
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+
+	operation, err := s.Model.OperationWithActions(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Actions, gc.HasLen, 1)
+	c.Assert(operation.Actions[0].Id(), gc.Equals, results[1].Action.Id())
+}
+
+func (s *OperationSuite) TestEnqueueRollingOperationNoTasks(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	application := s.AddTestingApplication(c, "dummy", charm)
+	unit, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operationID, results, err := s.Model.EnqueueRollingOperation("a rolling operation", state.RolloutArgs{}, []state.RollingTaskArgs{
+		{Receiver: unit, Name: "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.NotNil)
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(operation.Completed().IsZero(), jc.IsFalse)
+}
+
+func (s *OperationSuite) TestEnqueueRollingOperationInvalid(c *gc.C) {
+	_, _, err := s.Model.EnqueueRollingOperation("bad", state.RolloutArgs{MaxParallel: -1}, nil)
+	c.Assert(err, gc.ErrorMatches, "max parallel -1 not valid")
+	_, _, err = s.Model.EnqueueRollingOperation("bad", state.RolloutArgs{FailureBudget: -1}, nil)
+	c.Assert(err, gc.ErrorMatches, "failure budget -1 not valid")
+	_, _, err = s.Model.EnqueueRollingOperation("bad", state.RolloutArgs{LeaderOrder: "middle"}, nil)
+	c.Assert(err, gc.ErrorMatches, `leader order "middle" not valid`)
+}
@@ -514,6 +514,29 @@ func eraseStatusHistory(mb modelBackend, globalKey string) error {
 	return nil
 }
 
+// globalEventKey returns the global database key for the history of
+// events the cloud reported for the entity with the given global key.
+func globalEventKey(globalKey string) string {
+	return globalKey + "#event"
+}
+
+// recordEvent adds an event reported by the cloud to the event history
+// of the entity with the given global key. Unlike a status, an event
+// has no current value, so only the history is written.
+func recordEvent(db Database, globalKey string, event status.StatusInfo) error {
+	if event.Since == nil {
+		return errors.NotValidf("nil event time")
+	}
+	doc := statusDoc{
+		Status:     event.Status,
+		StatusInfo: event.Message,
+		StatusData: utils.EscapeKeys(event.Data),
+		Updated:    event.Since.UnixNano(),
+	}
+	_, err := probablyUpdateStatusHistory(db, globalEventKey(globalKey), doc)
+	return errors.Annotate(err, "cannot record event")
+}
+
 // statusHistoryArgs hold the arguments to call statusHistory.
 type statusHistoryArgs struct {
 	db        Database
@@ -327,3 +327,57 @@ func (s *StatusHistorySuite) TestSameValueNotRepeated(c *gc.C) {
 	c.Assert(history[0].Message, gc.Equals, "current status")
 	c.Assert(history[1].Message, gc.Equals, "waiting for machine")
 }
+
+func (s *StatusHistorySuite) TestRecordEvent(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	unitStatus, err := unit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		when := now.Add(time.Duration(i) * time.Second)
+		err := unit.RecordEvent(status.StatusInfo{
+			Status:  "warning",
+			Message: "BackOff: Back-off pulling image",
+			Data:    map[string]interface{}{"object": "Pod/gitlab-0"},
+			Since:   &when,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	later := now.Add(time.Minute)
+	err = application.RecordEvent(status.StatusInfo{
+		Status:  "normal",
+		Message: "SuccessfulCreate: create Pod gitlab-0",
+		Since:   &later,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Repeated events only update the time of the last entry.
+	history, err := unit.EventHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Status, gc.Equals, status.Status("warning"))
+	c.Assert(history[0].Message, gc.Equals, "BackOff: Back-off pulling image")
+	c.Assert(history[0].Data, jc.DeepEquals, map[string]interface{}{"object": "Pod/gitlab-0"})
+	c.Assert(history[0].Since.UnixNano(), gc.Equals, now.Add(2*time.Second).UnixNano())
+
+	history, err = application.EventHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Message, gc.Equals, "SuccessfulCreate: create Pod gitlab-0")
+
+	// Events don't change the status, or its history.
+	newStatus, err := unit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newStatus.Status, gc.Equals, unitStatus.Status)
+	statusHistory, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusHistory, gc.HasLen, 1)
+}
+
+func (s *StatusHistorySuite) TestRecordEventRequiresTime(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.RecordEvent(status.StatusInfo{Status: "warning", Message: "FailedScheduling"})
+	c.Assert(err, gc.ErrorMatches, "nil event time not valid")
+}
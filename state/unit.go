@@ -90,6 +90,7 @@ type unitDoc struct {
 	StorageAttachmentCount int `bson:"storageattachmentcount"`
 	MachineId              string
 	Resolved               ResolvedMode
+	Paused                 bool         `bson:"paused,omitempty"`
 	Tools                  *tools.Tools `bson:",omitempty"`
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
@@ -590,6 +591,12 @@ func (op *DestroyUnitOperation) eraseHistory() error {
 			return one
 		}
 	}
+	if err := eraseStatusHistory(op.unit.st, globalEventKey(op.unit.globalKey())); err != nil {
+		one := errors.Annotate(err, "events")
+		if op.FatalError(one) {
+			return one
+		}
+	}
 	return nil
 }
 
@@ -1078,6 +1085,11 @@ func (u *Unit) Resolved() ResolvedMode {
 	return u.doc.Resolved
 }
 
+// Paused reports whether hook execution has been paused for the unit.
+func (u *Unit) Paused() bool {
+	return u.doc.Paused
+}
+
 // IsPrincipal returns whether the unit is deployed in its own container,
 // and can therefore have subordinate applications deployed alongside it.
 func (u *Unit) IsPrincipal() bool {
@@ -1360,6 +1372,24 @@ func (u *Unit) StatusHistory(filter status.StatusHistoryFilter) ([]status.Status
 	return statusHistory(args)
 }
 
+// RecordEvent adds an event the cloud reported for the unit to its
+// event history. The status of the unit is unchanged.
+func (u *Unit) RecordEvent(event status.StatusInfo) error {
+	return recordEvent(u.st.db(), u.globalKey(), event)
+}
+
+// EventHistory returns a slice of at most filter.Size StatusInfo items
+// or items as old as filter.Date or items newer than now - filter.Delta time
+// representing the events the cloud reported for this unit.
+func (u *Unit) EventHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	args := &statusHistoryArgs{
+		db:        u.st.db(),
+		globalKey: globalEventKey(u.globalKey()),
+		filter:    filter,
+	}
+	return statusHistory(args)
+}
+
 // Status returns the status of the unit.
 // This method relies on globalKey instead of globalAgentKey since it is part of
 // the effort to separate Unit from UnitAgent. Now the Status for UnitAgent is in
@@ -2688,8 +2718,25 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(operationID, name string, payload map[string]interface{}, parallel *bool, executionGroup *string) (Action, error) {
+	payloadWithDefaults, runParallel, runExecutionGroup, err := u.prepareAction(name, payload, parallel, executionGroup)
+	if err != nil {
+		return nil, err
+	}
+	m, err := u.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.EnqueueAction(operationID, u.Tag(), name, payloadWithDefaults, runParallel, runExecutionGroup)
+}
+
+// prepareAction validates the named action and its payload against the
+// unit's charm, returning the payload with defaults inserted along with
+// the parallel and execution group settings the action should run with.
+func (u *Unit) prepareAction(
+	name string, payload map[string]interface{}, parallel *bool, executionGroup *string,
+) (map[string]interface{}, bool, string, error) {
 	if len(name) == 0 {
-		return nil, errors.New("no action name given")
+		return nil, false, "", errors.New("no action name given")
 	}
 
 	// If the action is predefined inside juju, get spec from map
@@ -2697,42 +2744,38 @@ func (u *Unit) AddAction(operationID, name string, payload map[string]interface{
 	if !ok {
 		specs, err := u.ActionSpecs()
 		if err != nil {
-			return nil, err
+			return nil, false, "", err
 		}
 		spec, ok = specs[name]
 		if !ok {
-			return nil, errors.Errorf("action %q not defined on unit %q", name, u.Name())
+			return nil, false, "", errors.Errorf("action %q not defined on unit %q", name, u.Name())
 		}
 	}
 	// Reject bad payloads before attempting to insert defaults.
 	err := spec.ValidateParams(payload)
 	if err != nil {
-		return nil, err
+		return nil, false, "", err
 	}
 	payloadWithDefaults, err := spec.InsertDefaults(payload)
 	if err != nil {
-		return nil, err
+		return nil, false, "", err
 	}
 
 	// For k8s operators, we run the action on the operator pod by default.
 	if _, ok := payloadWithDefaults["workload-context"]; !ok {
 		app, err := u.Application()
 		if err != nil {
-			return nil, err
+			return nil, false, "", err
 		}
 		ch, _, err := app.Charm()
 		if err != nil {
-			return nil, err
+			return nil, false, "", err
 		}
 		if ch.Meta().Deployment != nil && ch.Meta().Deployment.DeploymentMode == charm.ModeOperator {
 			payloadWithDefaults["workload-context"] = false
 		}
 	}
 
-	m, err := u.st.Model()
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
 	runParallel := spec.Parallel
 	if parallel != nil {
 		runParallel = *parallel
@@ -2741,7 +2784,7 @@ func (u *Unit) AddAction(operationID, name string, payload map[string]interface{
 	if executionGroup != nil {
 		runExecutionGroup = *executionGroup
 	}
-	return m.EnqueueAction(operationID, u.Tag(), name, payloadWithDefaults, runParallel, runExecutionGroup)
+	return payloadWithDefaults, runParallel, runExecutionGroup, nil
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -2870,6 +2913,32 @@ func (u *Unit) ClearResolved() error {
 	return nil
 }
 
+// SetPaused pauses or resumes hook execution for the unit. While paused,
+// the unit agent finishes any operation it has started but does not
+// start any new ones; changes it would otherwise respond to are queued
+// until the unit is resumed.
+func (u *Unit) SetPaused(paused bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set paused for unit %q", u)
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"paused", paused}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err == nil {
+		u.doc.Paused = paused
+		return nil
+	} else if err != txn.ErrAborted {
+		return err
+	}
+	if ok, err := isNotDead(u.st, unitsC, u.doc.DocID); err != nil {
+		return err
+	} else if !ok {
+		return stateerrors.ErrDead
+	}
+	return errors.NotFoundf("unit")
+}
+
 // StorageConstraints returns the unit's storage constraints.
 func (u *Unit) StorageConstraints() (map[string]StorageConstraints, error) {
 	if u.doc.CharmURL == nil {
@@ -1617,6 +1617,13 @@ func (s *UnitSuite) TestDestroyRemovesStatusHistory(c *gc.C) {
 
 		err = s.unit.SetWorkloadVersion(fmt.Sprintf("v.%d", i))
 		c.Assert(err, jc.ErrorIsNil)
+
+		err = s.unit.RecordEvent(status.StatusInfo{
+			Status:  status.Waiting,
+			Message: fmt.Sprintf("event %d", i),
+			Since:   &now,
+		})
+		c.Assert(err, jc.ErrorIsNil)
 	}
 
 	filter := status.StatusHistoryFilter{Size: 100}
@@ -1632,6 +1639,10 @@ func (s *UnitSuite) TestDestroyRemovesStatusHistory(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(len(versionInfo), jc.GreaterThan, 9)
 
+	eventInfo, err := s.unit.EventHistory(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(eventInfo), jc.GreaterThan, 9)
+
 	err = s.unit.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1646,6 +1657,10 @@ func (s *UnitSuite) TestDestroyRemovesStatusHistory(c *gc.C) {
 	versionInfo, err = s.unit.WorkloadVersionHistory().StatusHistory(filter)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(versionInfo, gc.HasLen, 0)
+
+	eventInfo, err = s.unit.EventHistory(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eventInfo, gc.HasLen, 0)
 }
 
 func assertLife(c *gc.C, entity state.Living, life state.Life) {
@@ -1773,6 +1788,27 @@ func (s *UnitSuite) TestGetSetClearResolved(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot set resolved mode for unit "wordpress/0": invalid error resolution mode: "foo"`)
 }
 
+func (s *UnitSuite) TestSetPaused(c *gc.C) {
+	c.Assert(s.unit.Paused(), jc.IsFalse)
+
+	err := s.unit.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Paused(), jc.IsTrue)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Paused(), jc.IsTrue)
+
+	err = s.unit.SetPaused(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Paused(), jc.IsFalse)
+
+	c.Assert(s.unit.EnsureDead(), jc.ErrorIsNil)
+	err = s.unit.SetPaused(true)
+	c.Assert(err, gc.ErrorMatches, `cannot set paused for unit "wordpress/0": not found or dead`)
+}
+
 func (s *UnitSuite) TesOpenedPorts(c *gc.C) {
 	// Accessing the port ranges for the unit should fail if it's not assigned to a machine.
 	_, err := s.unit.OpenedPortRanges()
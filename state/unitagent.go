@@ -70,7 +70,7 @@ func (u *UnitAgent) SetStatus(unitAgentStatus status.StatusInfo) (err error) {
 	isPrincipal := unit.doc.Principal == ""
 
 	switch unitAgentStatus.Status {
-	case status.Idle, status.Executing, status.Rebooting, status.Failed:
+	case status.Idle, status.Executing, status.Rebooting, status.Failed, status.Paused:
 		if !isAssigned && isPrincipal && shouldBeAssigned {
 			return errors.Errorf("cannot set status %q until unit is assigned", unitAgentStatus.Status)
 		}
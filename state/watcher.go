@@ -1931,6 +1931,18 @@ func (a *Application) WatchLeaderSettings() NotifyWatcher {
 	return newEntityWatcher(a.st, settingsC, docId)
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to
+// the application's config.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
+// WatchConstraints returns a watcher for observing changes to
+// the application's constraints.
+func (a *Application) WatchConstraints() NotifyWatcher {
+	return newEntityWatcher(a.st, constraintsC, a.st.docID(a.globalKey()))
+}
+
 // Watch returns a watcher for observing changes to a unit.
 func (u *Unit) Watch() NotifyWatcher {
 	return newEntityWatcher(u.st, unitsC, u.doc.DocID)
@@ -2677,26 +2689,36 @@ type actionStatusWatcher struct {
 // notifies on new log messages for a specified action being added.
 // The strings are json encoded action messages.
 func (st *State) WatchActionLogs(actionId string) StringsWatcher {
-	return newActionLogsWatcher(st, actionId)
+	return newActionLogsWatcher(st, actionId, false)
 }
 
-// actionLogsWatcher reports new action progress messages.
+// WatchActionOutput starts and returns a StringsWatcher that
+// notifies on new log messages and chunks of output for a specified
+// action being added. The strings are json encoded action messages.
+func (st *State) WatchActionOutput(actionId string) StringsWatcher {
+	return newActionLogsWatcher(st, actionId, true)
+}
+
+// actionLogsWatcher reports new action progress messages,
+// and optionally the action's output.
 type actionLogsWatcher struct {
 	commonWatcher
 	coll func() (mongo.Collection, func())
 	out  chan []string
 
-	actionId string
+	actionId      string
+	includeOutput bool
 }
 
 var _ Watcher = (*actionLogsWatcher)(nil)
 
-func newActionLogsWatcher(st *State, actionId string) StringsWatcher {
+func newActionLogsWatcher(st *State, actionId string, includeOutput bool) StringsWatcher {
 	w := &actionLogsWatcher{
 		commonWatcher: newCommonWatcher(st),
 		coll:          collFactory(st.db(), actionsC),
 		out:           make(chan []string),
 		actionId:      actionId,
+		includeOutput: includeOutput,
 	}
 	w.tomb.Go(func() error {
 		defer close(w.out)
@@ -2710,28 +2732,45 @@ func (w *actionLogsWatcher) Changes() <-chan []string {
 	return w.out
 }
 
-func (w *actionLogsWatcher) messages() ([]string, error) {
-	// Get the initial logs.
+// messages returns the action's progress messages, and its
+// output if it is being watched.
+func (w *actionLogsWatcher) messages() ([]ActionMessage, []ActionMessage, error) {
 	type messagesDoc struct {
 		Messages []ActionMessage `bson:"messages"`
+		Output   []ActionMessage `bson:"output"`
+	}
+	fields := bson.D{{"messages", 1}}
+	if w.includeOutput {
+		fields = append(fields, bson.DocElem{"output", 1})
 	}
 	coll, closer := w.coll()
 	defer closer()
 	var doc messagesDoc
-	err := coll.FindId(w.backend.docID(w.actionId)).Select(bson.D{{"messages", 1}}).One(&doc)
+	err := coll.FindId(w.backend.docID(w.actionId)).Select(fields).One(&doc)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
-	var changes []string
-	for _, m := range doc.Messages {
+	return doc.Messages, doc.Output, nil
+}
+
+// changes returns the json encoded progress messages and chunks
+// of output, in the order in which they were recorded.
+func (w *actionLogsWatcher) changes(messages, output []ActionMessage) ([]string, error) {
+	all := append(append([]ActionMessage(nil), messages...), output...)
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].TimestampValue.Before(all[j].TimestampValue)
+	})
+	changes := make([]string, len(all))
+	for i, m := range all {
 		mjson, err := json.Marshal(actions.ActionMessage{
 			Message:   m.MessageValue,
 			Timestamp: m.TimestampValue.UTC(),
+			Stream:    m.StreamValue,
 		})
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		changes = append(changes, string(mjson))
+		changes[i] = string(mjson)
 	}
 	return changes, nil
 }
@@ -2749,13 +2788,17 @@ func (w *actionLogsWatcher) loop() error {
 	w.watcher.WatchCollectionWithFilter(actionsC, in, filter)
 	defer w.watcher.UnwatchCollection(actionsC, in)
 
-	changes, err := w.messages()
+	messages, output, err := w.messages()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	changes, err := w.changes(messages, output)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	// Record how many messages already sent so we
-	// only send new ones.
-	var reportedCount int
+	// Record how many messages and chunks of output have
+	// already been sent so we only send new ones.
+	var reportedMessages, reportedOutput int
 	out := w.out
 
 	for {
@@ -2765,16 +2808,20 @@ func (w *actionLogsWatcher) loop() error {
 		case <-w.tomb.Dying():
 			return tomb.ErrDying
 		case <-in:
-			messages, err := w.messages()
+			latestMessages, latestOutput, err := w.messages()
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if len(messages) > reportedCount {
+			if len(latestMessages) > reportedMessages || len(latestOutput) > reportedOutput {
+				messages, output = latestMessages, latestOutput
+				changes, err = w.changes(messages[reportedMessages:], output[reportedOutput:])
+				if err != nil {
+					return errors.Trace(err)
+				}
 				out = w.out
-				changes = messages[reportedCount:]
 			}
 		case out <- changes:
-			reportedCount += len(changes)
+			reportedMessages, reportedOutput = len(messages), len(output)
 			out = nil
 		}
 	}
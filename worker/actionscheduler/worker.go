@@ -0,0 +1,212 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/watcher"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// missedRunGrace is how late a scheduled run may be fired before it is
+// considered missed, and handled according to the schedule's policy.
+const missedRunGrace = time.Minute
+
+// Facade exposes the controller functionality required by the worker.
+type Facade interface {
+	WatchActionSchedules() (watcher.NotifyWatcher, error)
+	ActionSchedules() ([]params.ActionSchedule, error)
+	FireActionSchedule(id string, now time.Time) error
+}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Errorf(string, ...interface{})
+	Warningf(string, ...interface{})
+	Debugf(string, ...interface{})
+}
+
+// Config holds the dependencies of the action scheduler worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+	Logger Logger
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+type schedule struct {
+	params.ActionSchedule
+	cron *actions.CronSchedule
+}
+
+// Worker fires an operation for each action schedule in the
+// model whenever its cron expression is due.
+type Worker struct {
+	catacomb  catacomb.Catacomb
+	config    Config
+	schedules map[string]*schedule
+}
+
+// NewWorker returns a new action scheduler worker.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:    config,
+		schedules: make(map[string]*schedule),
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher, err := w.config.Facade.WatchActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var timer clock.Timer
+	var timeout <-chan time.Time
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("action schedule watcher closed")
+			}
+			if err := w.refresh(); err != nil {
+				return errors.Trace(err)
+			}
+		case <-timeout:
+		}
+
+		next := w.fireDue()
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		if !next.IsZero() {
+			timer = w.config.Clock.NewTimer(next.Sub(w.config.Clock.Now()))
+			timeout = timer.Chan()
+		}
+	}
+}
+
+// refresh replaces the known schedules with those currently in the model.
+func (w *Worker) refresh() error {
+	schedules, err := w.config.Facade.ActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.schedules = make(map[string]*schedule, len(schedules))
+	for _, s := range schedules {
+		cron, err := actions.ParseCronSchedule(s.Cron)
+		if err != nil {
+			// This is validated when the schedule is added.
+			w.config.Logger.Errorf("ignoring action schedule %q: %v", s.Id, err)
+			continue
+		}
+		w.schedules[s.Id] = &schedule{ActionSchedule: s, cron: cron}
+	}
+	return nil
+}
+
+// fireDue fires all schedules which are due, and returns the time the
+// next schedule is due, or the zero time if there are no schedules.
+func (w *Worker) fireDue() time.Time {
+	now := w.config.Clock.Now()
+	var earliest time.Time
+	for id, s := range w.schedules {
+		next := nextRun(s, now)
+		if next.IsZero() {
+			continue
+		}
+		if !next.After(now) {
+			w.config.Logger.Debugf("firing action schedule %q (%s on %s)", id, s.Name, s.Receiver)
+			if err := w.config.Facade.FireActionSchedule(id, now); err != nil {
+				// Don't retry until the schedule is next due.
+				w.config.Logger.Errorf("cannot fire action schedule %q: %v", id, err)
+			}
+			s.LastRun = now
+			next = nextRun(s, now)
+			if next.IsZero() {
+				continue
+			}
+		}
+		if earliest.IsZero() || next.Before(earliest) {
+			earliest = next
+		}
+	}
+	return earliest
+}
+
+// nextRun returns the time the schedule should next fire, which is
+// not after now if it should fire immediately. Runs which are more
+// than missedRunGrace late are either run once or skipped, depending
+// on the schedule's missed run policy.
+func nextRun(s *schedule, now time.Time) time.Time {
+	last := s.LastRun
+	if last.IsZero() {
+		last = s.Created
+	}
+	due := s.cron.Next(last)
+	if due.IsZero() || due.After(now) || now.Sub(due) <= missedRunGrace {
+		return due
+	}
+	if actions.MissedRunPolicy(s.MissedRunPolicy) == actions.MissedRunOnce {
+		return now
+	}
+	// Skip the missed runs, but not one which is due right now.
+	return s.cron.Next(now.Add(-missedRunGrace))
+}
@@ -0,0 +1,198 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	facade *mockFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2021, 3, 1, 2, 59, 0, 0, time.UTC))
+	s.facade = &mockFacade{
+		changes: make(chan struct{}, 1),
+		fired:   make(chan params.FireActionSchedule, 10),
+	}
+	s.facade.changes <- struct{}{}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := actionscheduler.NewWorker(actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := actionscheduler.NewWorker(actionscheduler.Config{
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestFiresWhenDue(c *gc.C) {
+	s.facade.setSchedules(params.ActionSchedule{
+		Id:      "1",
+		Cron:    "0 3 * * *",
+		Created: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFired(c, "1", time.Date(2021, 3, 1, 3, 0, 0, 0, time.UTC))
+
+	// The worker waits for the next day's run.
+	err = s.clock.WaitAdvance(23*time.Hour+59*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNotFired(c)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFired(c, "1", time.Date(2021, 3, 2, 3, 0, 0, 0, time.UTC))
+}
+
+func (s *WorkerSuite) TestMissedRunSkipped(c *gc.C) {
+	s.clock = testclock.NewClock(time.Date(2021, 3, 1, 5, 0, 0, 0, time.UTC))
+	s.facade.setSchedules(params.ActionSchedule{
+		Id:              "1",
+		Cron:            "0 3 * * *",
+		MissedRunPolicy: "skip",
+		Created:         time.Date(2021, 2, 28, 12, 0, 0, 0, time.UTC),
+	})
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(21*time.Hour+59*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNotFired(c)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFired(c, "1", time.Date(2021, 3, 2, 3, 0, 0, 0, time.UTC))
+}
+
+func (s *WorkerSuite) TestMissedRunOnce(c *gc.C) {
+	now := time.Date(2021, 3, 1, 5, 0, 0, 0, time.UTC)
+	s.clock = testclock.NewClock(now)
+	s.facade.setSchedules(params.ActionSchedule{
+		Id:              "1",
+		Cron:            "@hourly",
+		MissedRunPolicy: "run-once",
+		Created:         time.Date(2021, 2, 28, 12, 0, 0, 0, time.UTC),
+	})
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// Many runs were missed, but only one is made up.
+	s.assertFired(c, "1", now)
+	s.assertNotFired(c)
+}
+
+func (s *WorkerSuite) TestRefreshOnChange(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.setSchedules(params.ActionSchedule{
+		Id:      "2",
+		Cron:    "* * * * *",
+		Created: s.clock.Now(),
+	})
+	s.facade.changes <- struct{}{}
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFired(c, "2", time.Date(2021, 3, 1, 3, 0, 0, 0, time.UTC))
+}
+
+func (s *WorkerSuite) TestFireErrorNotFatal(c *gc.C) {
+	s.facade.fireErr = errors.New("boom")
+	s.facade.setSchedules(params.ActionSchedule{
+		Id:      "1",
+		Cron:    "0 3 * * *",
+		Created: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFired(c, "1", time.Date(2021, 3, 1, 3, 0, 0, 0, time.UTC))
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) assertFired(c *gc.C, id string, at time.Time) {
+	select {
+	case fired := <-s.facade.fired:
+		c.Assert(fired.Id, gc.Equals, id)
+		c.Assert(fired.Time, gc.Equals, at)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("action schedule %q not fired", id)
+	}
+}
+
+func (s *WorkerSuite) assertNotFired(c *gc.C) {
+	select {
+	case fired := <-s.facade.fired:
+		c.Fatalf("unexpected firing of action schedule %q", fired.Id)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	mu        sync.Mutex
+	schedules []params.ActionSchedule
+	changes   chan struct{}
+	fired     chan params.FireActionSchedule
+	fireErr   error
+}
+
+func (f *mockFacade) setSchedules(schedules ...params.ActionSchedule) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.schedules = schedules
+}
+
+func (f *mockFacade) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *mockFacade) ActionSchedules() ([]params.ActionSchedule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]params.ActionSchedule(nil), f.schedules...), nil
+}
+
+func (f *mockFacade) FireActionSchedule(id string, now time.Time) error {
+	f.fired <- params.FireActionSchedule{Id: id, Time: now}
+	return f.fireErr
+}
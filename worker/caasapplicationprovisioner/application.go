@@ -125,6 +125,8 @@ func (a *appWorker) loop() error {
 	var appChanges watcher.NotifyChannel
 	var replicaChanges watcher.NotifyChannel
 	var appStateChanges watcher.NotifyChannel
+	var appConfigChanges watcher.NotifyChannel
+	var appConstraintsChanges watcher.NotifyChannel
 	var lastReportedStatus map[string]status.StatusInfo
 
 	done := false
@@ -148,6 +150,26 @@ func (a *appWorker) loop() error {
 				}
 				appStateChanges = appStateWatcher.Changes()
 			}
+			if appConfigChanges == nil {
+				appConfigWatcher, err := a.facade.WatchApplicationConfig(a.name)
+				if err != nil {
+					return errors.Annotatef(err, "failed to watch for changes to application %q config", a.name)
+				}
+				if err := a.catacomb.Add(appConfigWatcher); err != nil {
+					return errors.Trace(err)
+				}
+				appConfigChanges = appConfigWatcher.Changes()
+			}
+			if appConstraintsChanges == nil {
+				appConstraintsWatcher, err := a.facade.WatchApplicationConstraints(a.name)
+				if err != nil {
+					return errors.Annotatef(err, "failed to watch for changes to application %q constraints", a.name)
+				}
+				if err := a.catacomb.Add(appConstraintsWatcher); err != nil {
+					return errors.Trace(err)
+				}
+				appConstraintsChanges = appConstraintsWatcher.Changes()
+			}
 			err = a.alive(app)
 			if err != nil {
 				return errors.Trace(err)
@@ -198,6 +220,20 @@ func (a *appWorker) loop() error {
 			if err != nil {
 				return errors.Trace(err)
 			}
+		case <-appConfigChanges:
+			// Respond to application config changes, such as
+			// the autoscale policy.
+			err = handleChange()
+			if err != nil {
+				return errors.Trace(err)
+			}
+		case <-appConstraintsChanges:
+			// Respond to application constraints changes, such as
+			// resource requests and limits.
+			err = handleChange()
+			if err != nil {
+				return errors.Trace(err)
+			}
 		case <-a.changes:
 			// Respond to life changes.
 			err = handleChange()
@@ -258,6 +294,12 @@ func (a *appWorker) updateState(app caas.Application, force bool, lastReportedSt
 		ApplicationTag: names.NewApplicationTag(a.name).String(),
 		Status:         params.EntityStatus{},
 	}
+	if st.Autoscaled {
+		// The autoscaler owns the replica count, so reconcile
+		// Juju's scale with it rather than fighting it.
+		scale := st.DesiredReplicas
+		args.Scale = &scale
+	}
 	for _, u := range units {
 		// For pods managed by the substrate, any marked as dying
 		// are treated as non-existing.
@@ -437,6 +479,8 @@ func (a *appWorker) alive(app caas.Application) error {
 		CharmBaseImage:       charmBaseImage,
 		Containers:           containers,
 		CharmModifiedVersion: provisionInfo.CharmModifiedVersion,
+		Autoscale:            provisionInfo.Autoscale,
+		Availability:         provisionInfo.Availability,
 	}
 	reason := "unchanged"
 	// TODO(embedded): implement Equals method for caas.ApplicationConfig
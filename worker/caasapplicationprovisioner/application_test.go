@@ -87,6 +87,15 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 	appProvisioningInfo := api.ProvisioningInfo{
 		Series:   "focal",
 		CharmURL: appCharmURL,
+		Autoscale: &caas.AutoscalePolicy{
+			MinUnits:  1,
+			MaxUnits:  3,
+			TargetCPU: 80,
+		},
+		Availability: &caas.AvailabilityPolicy{
+			MaxUnavailable: 1,
+			SpreadAcross:   []caas.Topology{caas.TopologyNode},
+		},
 	}
 	ociResources := map[string]resources.DockerImageDetails{
 		"test-oci": {
@@ -99,6 +108,9 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 	appStateChan := make(chan struct{}, 1)
 	appStateWatcher := watchertest.NewMockNotifyWatcher(appStateChan)
 
+	appConfigWatcher := watchertest.NewMockNotifyWatcher(make(chan struct{}))
+	appConstraintsWatcher := watchertest.NewMockNotifyWatcher(make(chan struct{}))
+
 	appChan := make(chan struct{}, 1)
 	appWatcher := watchertest.NewMockNotifyWatcher(appChan)
 
@@ -109,6 +121,9 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 	broker := mocks.NewMockCAASBroker(ctrl)
 	facade := mocks.NewMockCAASProvisionerFacade(ctrl)
 
+	// The autoscaled replica count is reconciled back into Juju.
+	desiredScale := 1
+
 	done := make(chan struct{})
 	gomock.InOrder(
 		// Initialize in loop.
@@ -130,6 +145,8 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 			return life.Alive, nil
 		}),
 		facade.EXPECT().WatchApplication("test").Return(appStateWatcher, nil),
+		facade.EXPECT().WatchApplicationConfig("test").Return(appConfigWatcher, nil),
+		facade.EXPECT().WatchApplicationConstraints("test").Return(appConstraintsWatcher, nil),
 		facade.EXPECT().ProvisioningInfo("test").DoAndReturn(func(string) (api.ProvisioningInfo, error) {
 			return appProvisioningInfo, nil
 		}),
@@ -158,6 +175,15 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 						},
 					},
 				},
+				Autoscale: &caas.AutoscalePolicy{
+					MinUnits:  1,
+					MaxUnits:  3,
+					TargetCPU: 80,
+				},
+				Availability: &caas.AvailabilityPolicy{
+					MaxUnavailable: 1,
+					SpreadAcross:   []caas.Topology{caas.TopologyNode},
+				},
 			})
 			return nil
 		}),
@@ -178,6 +204,7 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 			return caas.ApplicationState{
 				DesiredReplicas: 1,
 				Replicas:        []string{"test-0"},
+				Autoscaled:      true,
 			}, nil
 		}),
 		facade.EXPECT().GarbageCollect("test", []names.Tag{names.NewUnitTag("test/0")}, 1, []string{"test-0"}, false).DoAndReturn(func(appName string, observedUnits []names.Tag, desiredReplicas int, activePodNames []string, force bool) error {
@@ -213,6 +240,7 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 		}}, nil),
 		facade.EXPECT().UpdateUnits(params.UpdateApplicationUnits{
 			ApplicationTag: "application-test",
+			Scale:          &desiredScale,
 			Status:         params.EntityStatus{},
 			Units: []params.ApplicationUnitParams{{
 				ProviderId: "test-0",
@@ -41,6 +41,8 @@ type CAASProvisionerFacade interface {
 	ApplicationOCIResources(appName string) (map[string]resources.DockerImageDetails, error)
 	UpdateUnits(arg params.UpdateApplicationUnits) (*params.UpdateApplicationUnitsInfo, error)
 	WatchApplication(appName string) (watcher.NotifyWatcher, error)
+	WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error)
+	WatchApplicationConstraints(appName string) (watcher.NotifyWatcher, error)
 }
 
 // CAASBroker exposes CAAS broker functionality to a worker.
@@ -0,0 +1,161 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasevents_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	dt "github.com/juju/worker/v2/dependency/testing"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/caasevents"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	testing.Stub
+	manifold dependency.Manifold
+	clock    *testclock.Clock
+
+	apiCaller fakeAPICaller
+	broker    fakeEventBroker
+	client    mockClient
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.ResetCalls()
+
+	s.clock = testclock.NewClock(time.Time{})
+	s.manifold = caasevents.Manifold(s.validConfig())
+}
+
+func (s *ManifoldSuite) validConfig() caasevents.ManifoldConfig {
+	return caasevents.ManifoldConfig{
+		APICallerName: "api-caller",
+		BrokerName:    "broker",
+		Clock:         s.clock,
+		NewClient:     s.newClient,
+		NewWorker:     s.newWorker,
+		Logger:        loggo.GetLogger("test"),
+	}
+}
+
+func (s *ManifoldSuite) newClient(apiCaller base.APICaller) caasevents.Client {
+	s.MethodCall(s, "NewClient", apiCaller)
+	return &s.client
+}
+
+func (s *ManifoldSuite) newWorker(config caasevents.Config) (worker.Worker, error) {
+	s.MethodCall(s, "NewWorker", config)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	w := worker.NewRunner(worker.RunnerParams{})
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w, nil
+}
+
+func (s *ManifoldSuite) newContext(overlay map[string]interface{}) dependency.Context {
+	resources := map[string]interface{}{
+		"api-caller": &s.apiCaller,
+		"broker":     &s.broker,
+	}
+	for k, v := range overlay {
+		resources[k] = v
+	}
+	return dt.StubContext(nil, resources)
+}
+
+func (s *ManifoldSuite) TestMissingAPICallerName(c *gc.C) {
+	config := s.validConfig()
+	config.APICallerName = ""
+	s.checkConfigInvalid(c, config, "empty APICallerName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingBrokerName(c *gc.C) {
+	config := s.validConfig()
+	config.BrokerName = ""
+	s.checkConfigInvalid(c, config, "empty BrokerName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingClock(c *gc.C) {
+	config := s.validConfig()
+	config.Clock = nil
+	s.checkConfigInvalid(c, config, "nil Clock not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
+	config := s.validConfig()
+	config.NewWorker = nil
+	s.checkConfigInvalid(c, config, "nil NewWorker not valid")
+}
+
+func (s *ManifoldSuite) TestMissingLogger(c *gc.C) {
+	config := s.validConfig()
+	config.Logger = nil
+	s.checkConfigInvalid(c, config, "nil Logger not valid")
+}
+
+func (s *ManifoldSuite) checkConfigInvalid(c *gc.C, config caasevents.ManifoldConfig, expect string) {
+	err := config.Validate()
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+var expectedInputs = []string{"api-caller", "broker"}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	c.Assert(s.manifold.Inputs, jc.SameContents, expectedInputs)
+}
+
+func (s *ManifoldSuite) TestMissingInputs(c *gc.C) {
+	for _, input := range expectedInputs {
+		context := s.newContext(map[string]interface{}{
+			input: dependency.ErrMissing,
+		})
+		_, err := s.manifold.Start(context)
+		c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	}
+}
+
+func (s *ManifoldSuite) TestStart(c *gc.C) {
+	w, err := s.manifold.Start(s.newContext(nil))
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+
+	s.CheckCallNames(c, "NewClient", "NewWorker")
+	s.CheckCall(c, 0, "NewClient", &s.apiCaller)
+
+	args := s.Calls()[1].Args
+	c.Assert(args, gc.HasLen, 1)
+	c.Assert(args[0], gc.FitsTypeOf, caasevents.Config{})
+	config := args[0].(caasevents.Config)
+
+	c.Assert(config, jc.DeepEquals, caasevents.Config{
+		Client: &s.client,
+		Broker: &s.broker,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	})
+}
+
+func (s *ManifoldSuite) TestStartBrokerWithoutEvents(c *gc.C) {
+	_, err := s.manifold.Start(s.newContext(map[string]interface{}{
+		"broker": &fakeBroker{},
+	}))
+	c.Assert(err, gc.Equals, dependency.ErrUninstall)
+	s.CheckNoCalls(c)
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasevents_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+)
+
+type fakeAPICaller struct {
+	base.APICaller
+}
+
+type fakeBroker struct {
+	caas.Broker
+}
+
+type fakeEventBroker struct {
+	fakeBroker
+	mockEventWatcher
+}
+
+type mockEventWatcher struct {
+	testing.Stub
+	watcher *watchertest.MockNotifyWatcher
+	events  [][]caas.Event
+}
+
+func (m *mockEventWatcher) WatchEvents() (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchEvents")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.watcher, nil
+}
+
+func (m *mockEventWatcher) Events(since time.Time) ([]caas.Event, error) {
+	m.MethodCall(m, "Events", since)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	events := m.events[0]
+	m.events = m.events[1:]
+	return events, nil
+}
+
+type mockClient struct {
+	testing.Stub
+	recorded chan<- []params.CAASEvent
+}
+
+func (m *mockClient) RecordEvents(events []params.CAASEvent) error {
+	m.MethodCall(m, "RecordEvents", events)
+	m.recorded <- events
+	return m.NextErr()
+}
@@ -0,0 +1,161 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasevents
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+)
+
+// eventOverlap is how far before the last event seen the next
+// query starts. Event times only have second resolution, so
+// events seen in the same second as the previous query would
+// otherwise be missed.
+const eventOverlap = time.Second
+
+// Client records cloud events in the model.
+type Client interface {
+	RecordEvents([]params.CAASEvent) error
+}
+
+// Config holds configuration for the CAAS events worker.
+type Config struct {
+	Client Client
+	Broker caas.EventWatcher
+	Clock  clock.Clock
+	Logger Logger
+}
+
+// Validate validates the worker configuration.
+func (config Config) Validate() error {
+	if config.Client == nil {
+		return errors.NotValidf("missing Client")
+	}
+	if config.Broker == nil {
+		return errors.NotValidf("missing Broker")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("missing Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("missing Logger")
+	}
+	return nil
+}
+
+// NewWorker starts and returns a new worker which records the
+// events the cloud reports for the model's applications in their
+// status history, and logs the warnings to the model's log.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &eventsWorker{
+		config: config,
+		since:  config.Clock.Now(),
+		seen:   make(map[string]time.Time),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, err
+}
+
+type eventsWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// since is the time of the latest event recorded.
+	since time.Time
+	// seen holds the time each recent event was recorded at,
+	// so events repeated in the overlap aren't recorded twice.
+	seen map[string]time.Time
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *eventsWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *eventsWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *eventsWorker) loop() error {
+	watcher, err := w.config.Broker.WatchEvents()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			if err := w.recordEvents(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *eventsWorker) recordEvents() error {
+	logger := w.config.Logger
+	events, err := w.config.Broker.Events(w.since.Add(-eventOverlap))
+	if err != nil {
+		return errors.Annotate(err, "getting events")
+	}
+	var args []params.CAASEvent
+	for _, event := range events {
+		if seen, ok := w.seen[event.Id]; ok && !event.Time.After(seen) {
+			continue
+		}
+		w.seen[event.Id] = event.Time
+		if event.Time.After(w.since) {
+			w.since = event.Time
+		}
+
+		if event.Warning {
+			logger.Warningf("%s %s: %s", event.Object, event.Reason, event.Message)
+		} else {
+			logger.Debugf("%s %s: %s", event.Object, event.Reason, event.Message)
+		}
+		args = append(args, params.CAASEvent{
+			ApplicationTag: names.NewApplicationTag(event.Application).String(),
+			ProviderId:     event.UnitId,
+			Object:         event.Object,
+			Warning:        event.Warning,
+			Reason:         event.Reason,
+			Message:        event.Message,
+			Time:           event.Time,
+		})
+	}
+	// Events older than the overlap can't be returned again.
+	for id, t := range w.seen {
+		if t.Before(w.since.Add(-eventOverlap)) {
+			delete(w.seen, id)
+		}
+	}
+	// Events are informational, so one which can't be recorded,
+	// eg because its application has been removed, is only logged.
+	if err := w.config.Client.RecordEvents(args); err != nil {
+		logger.Warningf("cannot record events: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasevents_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/caasevents"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	now      time.Time
+	changes  chan struct{}
+	recorded chan []params.CAASEvent
+	broker   mockEventWatcher
+	client   mockClient
+	config   caasevents.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.now = time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	s.changes = make(chan struct{})
+	s.recorded = make(chan []params.CAASEvent)
+	s.broker = mockEventWatcher{
+		watcher: watchertest.NewMockNotifyWatcher(s.changes),
+	}
+	s.client = mockClient{recorded: s.recorded}
+	s.config = caasevents.Config{
+		Client: &s.client,
+		Broker: &s.broker,
+		Clock:  testclock.NewClock(s.now),
+		Logger: loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
+	s.testValidateConfig(c, func(config *caasevents.Config) {
+		config.Client = nil
+	}, `missing Client not valid`)
+	s.testValidateConfig(c, func(config *caasevents.Config) {
+		config.Broker = nil
+	}, `missing Broker not valid`)
+	s.testValidateConfig(c, func(config *caasevents.Config) {
+		config.Clock = nil
+	}, `missing Clock not valid`)
+	s.testValidateConfig(c, func(config *caasevents.Config) {
+		config.Logger = nil
+	}, `missing Logger not valid`)
+}
+
+func (s *WorkerSuite) testValidateConfig(c *gc.C, f func(*caasevents.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := caasevents.NewWorker(config)
+	if err == nil {
+		workertest.DirtyKill(c, w)
+	}
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) sendChange(c *gc.C) {
+	select {
+	case s.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending change")
+	}
+}
+
+func (s *WorkerSuite) assertRecorded(c *gc.C, expected []params.CAASEvent) {
+	select {
+	case events := <-s.recorded:
+		c.Assert(events, jc.DeepEquals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for events to be recorded")
+	}
+}
+
+func (s *WorkerSuite) TestRecordsEvents(c *gc.C) {
+	backOff := caas.Event{
+		Id:          "uid-1",
+		Application: "gitlab",
+		UnitId:      "gitlab-0",
+		Object:      "Pod/gitlab-0",
+		Warning:     true,
+		Reason:      "BackOff",
+		Message:     "Back-off restarting failed container",
+		Time:        s.now.Add(time.Minute),
+	}
+	scaled := caas.Event{
+		Id:          "uid-2",
+		Application: "gitlab",
+		Object:      "StatefulSet/gitlab",
+		Reason:      "SuccessfulCreate",
+		Message:     "create Pod gitlab-1 in StatefulSet gitlab successful",
+		Time:        s.now.Add(time.Minute),
+	}
+	backOffAgain := backOff
+	backOffAgain.Time = s.now.Add(2 * time.Minute)
+	s.broker.events = [][]caas.Event{
+		{backOff, scaled},
+		// The first events are returned again in the overlap.
+		{backOff, scaled, backOffAgain},
+	}
+
+	w, err := caasevents.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertRecorded(c, []params.CAASEvent{{
+		ApplicationTag: "application-gitlab",
+		ProviderId:     "gitlab-0",
+		Object:         "Pod/gitlab-0",
+		Warning:        true,
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		Time:           s.now.Add(time.Minute),
+	}, {
+		ApplicationTag: "application-gitlab",
+		Object:         "StatefulSet/gitlab",
+		Reason:         "SuccessfulCreate",
+		Message:        "create Pod gitlab-1 in StatefulSet gitlab successful",
+		Time:           s.now.Add(time.Minute),
+	}})
+
+	s.sendChange(c)
+	s.assertRecorded(c, []params.CAASEvent{{
+		ApplicationTag: "application-gitlab",
+		ProviderId:     "gitlab-0",
+		Object:         "Pod/gitlab-0",
+		Warning:        true,
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		Time:           s.now.Add(2 * time.Minute),
+	}})
+
+	workertest.CleanKill(c, w)
+	s.broker.CheckCallNames(c, "WatchEvents", "Events", "Events")
+	s.broker.CheckCall(c, 1, "Events", s.now.Add(-time.Second))
+	s.broker.CheckCall(c, 2, "Events", s.now.Add(time.Minute-time.Second))
+}
+
+func (s *WorkerSuite) TestRecordErrorIsNotFatal(c *gc.C) {
+	event := caas.Event{
+		Id:          "uid-1",
+		Application: "gitlab",
+		Object:      "StatefulSet/gitlab",
+		Reason:      "SuccessfulDelete",
+		Time:        s.now.Add(time.Minute),
+	}
+	s.broker.events = [][]caas.Event{{event}, {}}
+	s.client.SetErrors(errors.NotFoundf(`application "gitlab"`))
+
+	w, err := caasevents.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertRecorded(c, []params.CAASEvent{{
+		ApplicationTag: "application-gitlab",
+		Object:         "StatefulSet/gitlab",
+		Reason:         "SuccessfulDelete",
+		Time:           s.now.Add(time.Minute),
+	}})
+	s.sendChange(c)
+	s.assertRecorded(c, nil)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestEventsError(c *gc.C) {
+	s.broker.SetErrors(nil, errors.New("boom"))
+
+	w, err := caasevents.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting events: boom")
+}
@@ -25,12 +25,16 @@ type applicationWorker struct {
 
 	firewallerAPI CAASFirewallerAPI
 
-	broker         CAASBroker
-	portMutator    PortMutator
-	serviceUpdater ServiceUpdater
+	broker               CAASBroker
+	portMutator          PortMutator
+	serviceUpdater       ServiceUpdater
+	networkPolicyUpdater NetworkPolicyUpdater
+	ingressUpdater       IngressUpdater
 
-	appWatcher   watcher.NotifyWatcher
-	portsWatcher watcher.StringsWatcher
+	appWatcher         watcher.NotifyWatcher
+	portsWatcher       watcher.StringsWatcher
+	relationsWatcher   watcher.StringsWatcher
+	modelConfigWatcher watcher.NotifyWatcher
 
 	lifeGetter LifeGetter
 
@@ -39,6 +43,12 @@ type applicationWorker struct {
 
 	currentPorts portRanges
 
+	networkPolicySet     bool
+	currentNetworkPolicy *caas.NetworkPolicy
+
+	ingressSet     bool
+	currentIngress *caas.Ingress
+
 	logger Logger
 }
 
@@ -128,6 +138,22 @@ func (w *applicationWorker) setUp() (err error) {
 		return errors.Trace(err)
 	}
 
+	w.relationsWatcher, err = w.firewallerAPI.WatchRelations(w.appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(w.relationsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	w.modelConfigWatcher, err = w.firewallerAPI.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(w.modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	charmInfo, err := w.firewallerAPI.ApplicationCharmInfo(w.appName)
 	if err != nil {
 		return errors.Annotatef(err, "failed to get application charm deployment metadata for %q", w.appName)
@@ -142,6 +168,8 @@ func (w *applicationWorker) setUp() (err error) {
 	app := w.broker.Application(w.appName, caas.DeploymentStateful)
 	w.portMutator = app
 	w.serviceUpdater = app
+	w.networkPolicyUpdater = app
+	w.ingressUpdater = app
 
 	// TODO(embedded):
 	/*
@@ -180,6 +208,28 @@ func (w *applicationWorker) loop() (err error) {
 				}
 				return errors.Trace(err)
 			}
+			// The exposed CIDRs and ingress may change without
+			// the application being unexposed.
+			if err := w.onNetworkPolicyChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			if err := w.onIngressChanged(); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-w.relationsWatcher.Changes():
+			if !ok {
+				return errors.New("relations watcher closed")
+			}
+			if err := w.onNetworkPolicyChanged(); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-w.modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			if err := w.onNetworkPolicyChanged(); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-w.portsWatcher.Changes():
 			if !ok {
 				return errors.New("application watcher closed")
@@ -191,6 +241,12 @@ func (w *applicationWorker) loop() (err error) {
 			if err := w.onPortChanged(); err != nil {
 				return errors.Trace(err)
 			}
+			// The ingress routes to the opened ports, so
+			// re-apply it even if it hasn't changed.
+			w.ingressSet = false
+			if err := w.onIngressChanged(); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 }
@@ -245,6 +301,64 @@ func (w *applicationWorker) onApplicationChanged() (err error) {
 	return errors.Trace(unExposeService(w.serviceUpdater))
 }
 
+// onNetworkPolicyChanged restricts ingress to the application to its
+// related applications and exposed CIDRs when network policies are
+// enabled for the model, and removes any restriction otherwise.
+func (w *applicationWorker) onNetworkPolicyChanged() error {
+	cfg, err := w.firewallerAPI.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var policy *caas.NetworkPolicy
+	if caas.NetworkPoliciesEnabled(cfg) {
+		related, err := w.firewallerAPI.RelatedApplications(w.appName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cidrs, err := w.firewallerAPI.ExposedCIDRs(w.appName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		policy = &caas.NetworkPolicy{
+			RelatedApplications: related,
+			IngressCIDRs:        cidrs,
+		}
+	}
+	if w.networkPolicySet && reflect.DeepEqual(policy, w.currentNetworkPolicy) {
+		return nil
+	}
+	if err := w.networkPolicyUpdater.UpdateNetworkPolicy(policy); err != nil {
+		return errors.Annotatef(err, "updating network policy for %q", w.appName)
+	}
+	w.networkPolicySet = true
+	w.currentNetworkPolicy = policy
+	return nil
+}
+
+// onIngressChanged creates, updates or removes the ingress used to
+// expose the application.
+func (w *applicationWorker) onIngressChanged() error {
+	ingress, err := w.firewallerAPI.ExposedIngress(w.appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if w.ingressSet && reflect.DeepEqual(ingress, w.currentIngress) {
+		return nil
+	}
+	err = w.ingressUpdater.UpdateIngress(ingress)
+	if errors.IsNotFound(err) {
+		// The application has no opened ports yet; the ingress is
+		// created once it does.
+		w.logger.Debugf("not updating ingress for %q: %v", w.appName, err)
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "updating ingress for %q", w.appName)
+	}
+	w.ingressSet = true
+	w.currentIngress = ingress
+	return nil
+}
+
 func exposeService(app ServiceUpdater) error {
 	// TODO(embedded): implement expose once it's modelled.
 	// app.UpdateService()
@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
 	"github.com/juju/systems"
 	"github.com/juju/systems/channel"
 	jc "github.com/juju/testing/checkers"
@@ -38,9 +39,13 @@ type appWorkerSuite struct {
 
 	applicationChanges chan struct{}
 	portsChanges       chan []string
+	relationsChanges   chan []string
+	configChanges      chan struct{}
 
-	appsWatcher  watcher.NotifyWatcher
-	portsWatcher watcher.StringsWatcher
+	appsWatcher      watcher.NotifyWatcher
+	portsWatcher     watcher.StringsWatcher
+	relationsWatcher watcher.StringsWatcher
+	configWatcher    watcher.NotifyWatcher
 }
 
 var _ = gc.Suite(&appWorkerSuite{})
@@ -51,6 +56,8 @@ func (s *appWorkerSuite) SetUpTest(c *gc.C) {
 	s.appName = "app1"
 	s.applicationChanges = make(chan struct{})
 	s.portsChanges = make(chan []string)
+	s.relationsChanges = make(chan []string)
+	s.configChanges = make(chan struct{})
 }
 
 func (s *appWorkerSuite) getController(c *gc.C) *gomock.Controller {
@@ -58,6 +65,8 @@ func (s *appWorkerSuite) getController(c *gc.C) *gomock.Controller {
 
 	s.appsWatcher = watchertest.NewMockNotifyWatcher(s.applicationChanges)
 	s.portsWatcher = watchertest.NewMockStringsWatcher(s.portsChanges)
+	s.relationsWatcher = watchertest.NewMockStringsWatcher(s.relationsChanges)
+	s.configWatcher = watchertest.NewMockNotifyWatcher(s.configChanges)
 
 	s.firewallerAPI = mocks.NewMockCAASFirewallerAPI(ctrl)
 
@@ -83,13 +92,8 @@ func (s *appWorkerSuite) getWorker(c *gc.C) worker.Worker {
 	return w
 }
 
-func (s *appWorkerSuite) TestWorker(c *gc.C) {
-	ctrl := s.getController(c)
-	defer ctrl.Finish()
-
-	done := make(chan struct{})
-
-	appCharmInfo := &charmscommon.CharmInfo{
+func (s *appWorkerSuite) charmInfo() *charmscommon.CharmInfo {
+	return &charmscommon.CharmInfo{
 		Meta: &charm.Meta{
 			Name: "test",
 			Platforms: []charm.Platform{
@@ -109,6 +113,25 @@ func (s *appWorkerSuite) TestWorker(c *gc.C) {
 			},
 		},
 	}
+}
+
+func (s *appWorkerSuite) expectSetUp() []*gomock.Call {
+	return []*gomock.Call{
+		s.firewallerAPI.EXPECT().WatchApplication(s.appName).Return(s.appsWatcher, nil),
+		s.firewallerAPI.EXPECT().WatchOpenedPorts().Return(s.portsWatcher, nil),
+		s.firewallerAPI.EXPECT().WatchRelations(s.appName).Return(s.relationsWatcher, nil),
+		s.firewallerAPI.EXPECT().WatchForModelConfigChanges().Return(s.configWatcher, nil),
+		s.firewallerAPI.EXPECT().ApplicationCharmInfo(s.appName).Return(s.charmInfo(), nil),
+
+		s.broker.EXPECT().Application(s.appName, caas.DeploymentStateful).Return(s.brokerApp),
+	}
+}
+
+func (s *appWorkerSuite) TestWorker(c *gc.C) {
+	ctrl := s.getController(c)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
 
 	go func() {
 		s.portsChanges <- []string{"port changes"}
@@ -116,18 +139,121 @@ func (s *appWorkerSuite) TestWorker(c *gc.C) {
 		s.applicationChanges <- struct{}{}
 	}()
 
-	gomock.InOrder(
-		s.firewallerAPI.EXPECT().WatchApplication(s.appName).Return(s.appsWatcher, nil),
-		s.firewallerAPI.EXPECT().WatchOpenedPorts().Return(s.portsWatcher, nil),
-		s.firewallerAPI.EXPECT().ApplicationCharmInfo(s.appName).Return(appCharmInfo, nil),
+	gomock.InOrder(append(s.expectSetUp(),
+		s.firewallerAPI.EXPECT().ExposedIngress(s.appName).Return(nil, nil),
+		s.brokerApp.EXPECT().UpdateIngress(nil).Return(nil),
 
-		s.broker.EXPECT().Application(s.appName, caas.DeploymentStateful).Return(s.brokerApp),
+		s.firewallerAPI.EXPECT().IsExposed(s.appName).Return(false, nil),
+		s.firewallerAPI.EXPECT().ModelConfig().Return(testing.ModelConfig(c), nil),
+		// Network policies are disabled, so any existing policy is removed.
+		s.brokerApp.EXPECT().UpdateNetworkPolicy(nil).Return(nil),
+		// An unchanged ingress is not updated again.
+		s.firewallerAPI.EXPECT().ExposedIngress(s.appName).DoAndReturn(func(string) (*caas.Ingress, error) {
+			close(done)
+			return nil, nil
+		}),
+	)...)
+
+	w := s.getWorker(c)
+
+	select {
+	case <-done:
+	case <-time.After(testing.ShortWait):
+		c.Errorf("timed out waiting for worker")
+	}
+	workertest.CleanKill(c, w)
+}
+
+func (s *appWorkerSuite) TestWorkerNetworkPolicy(c *gc.C) {
+	ctrl := s.getController(c)
+	defer ctrl.Finish()
 
-		s.firewallerAPI.EXPECT().IsExposed(s.appName).DoAndReturn(func(_ string) (bool, error) {
+	done := make(chan struct{})
+
+	cfg, err := testing.ModelConfig(c).Apply(map[string]interface{}{
+		"network-policies": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	go func() {
+		s.configChanges <- struct{}{}
+		// An unchanged policy is not updated again.
+		s.configChanges <- struct{}{}
+		s.relationsChanges <- []string{"app1:db mariadb:db"}
+	}()
+
+	gomock.InOrder(append(s.expectSetUp(),
+		s.firewallerAPI.EXPECT().ModelConfig().Return(cfg, nil),
+		s.firewallerAPI.EXPECT().RelatedApplications(s.appName).Return(nil, nil),
+		s.firewallerAPI.EXPECT().ExposedCIDRs(s.appName).Return([]string{"10.0.0.0/24"}, nil),
+		s.brokerApp.EXPECT().UpdateNetworkPolicy(&caas.NetworkPolicy{
+			IngressCIDRs: []string{"10.0.0.0/24"},
+		}).Return(nil),
+
+		s.firewallerAPI.EXPECT().ModelConfig().Return(cfg, nil),
+		s.firewallerAPI.EXPECT().RelatedApplications(s.appName).Return(nil, nil),
+		s.firewallerAPI.EXPECT().ExposedCIDRs(s.appName).Return([]string{"10.0.0.0/24"}, nil),
+
+		s.firewallerAPI.EXPECT().ModelConfig().Return(cfg, nil),
+		s.firewallerAPI.EXPECT().RelatedApplications(s.appName).Return([]string{"mariadb"}, nil),
+		s.firewallerAPI.EXPECT().ExposedCIDRs(s.appName).Return([]string{"10.0.0.0/24"}, nil),
+		s.brokerApp.EXPECT().UpdateNetworkPolicy(&caas.NetworkPolicy{
+			RelatedApplications: []string{"mariadb"},
+			IngressCIDRs:        []string{"10.0.0.0/24"},
+		}).DoAndReturn(func(*caas.NetworkPolicy) error {
 			close(done)
-			return false, nil
+			return nil
 		}),
-	)
+	)...)
+
+	w := s.getWorker(c)
+
+	select {
+	case <-done:
+	case <-time.After(testing.ShortWait):
+		c.Errorf("timed out waiting for worker")
+	}
+	workertest.CleanKill(c, w)
+}
+
+func (s *appWorkerSuite) TestWorkerIngress(c *gc.C) {
+	ctrl := s.getController(c)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	ingress := &caas.Ingress{
+		Hostname:  "app1.example.com",
+		TLSSecret: "app1-tls",
+	}
+
+	go func() {
+		s.applicationChanges <- struct{}{}
+		s.portsChanges <- []string{"port changes"}
+		s.applicationChanges <- struct{}{}
+	}()
+
+	gomock.InOrder(append(s.expectSetUp(),
+		s.firewallerAPI.EXPECT().IsExposed(s.appName).Return(true, nil),
+		s.firewallerAPI.EXPECT().ModelConfig().Return(testing.ModelConfig(c), nil),
+		s.brokerApp.EXPECT().UpdateNetworkPolicy(nil).Return(nil),
+		// The ingress can't be created until a port is opened.
+		s.firewallerAPI.EXPECT().ExposedIngress(s.appName).Return(ingress, nil),
+		s.brokerApp.EXPECT().UpdateIngress(ingress).Return(errors.NotFoundf("opened ports")),
+		s.logger.EXPECT().Debugf(gomock.Any(), gomock.Any()),
+
+		s.firewallerAPI.EXPECT().ExposedIngress(s.appName).Return(ingress, nil),
+		s.brokerApp.EXPECT().UpdateIngress(ingress).Return(nil),
+
+		// Unexposing the application removes the ingress.
+		s.firewallerAPI.EXPECT().IsExposed(s.appName).Return(false, nil),
+		s.firewallerAPI.EXPECT().ModelConfig().Return(testing.ModelConfig(c), nil),
+		s.firewallerAPI.EXPECT().ExposedIngress(s.appName).Return(nil, nil),
+		s.brokerApp.EXPECT().UpdateIngress(nil).DoAndReturn(func(*caas.Ingress) error {
+			close(done)
+			return nil
+		}),
+	)...)
 
 	w := s.getWorker(c)
 
@@ -7,7 +7,7 @@ import (
 	"github.com/juju/juju/caas"
 )
 
-//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/broker_mock.go github.com/juju/juju/worker/caasfirewallerembedded CAASBroker,PortMutator,ServiceUpdater
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/broker_mock.go github.com/juju/juju/worker/caasfirewallerembedded CAASBroker,PortMutator,ServiceUpdater,NetworkPolicyUpdater
 
 // CAASBroker exposes CAAS broker functionality to a worker.
 type CAASBroker interface {
@@ -23,3 +23,14 @@ type PortMutator interface {
 type ServiceUpdater interface {
 	UpdateService(caas.ServiceParam) error
 }
+
+// NetworkPolicyUpdater exposes CAAS application functionality to a worker.
+type NetworkPolicyUpdater interface {
+	UpdateNetworkPolicy(*caas.NetworkPolicy) error
+}
+
+// IngressUpdater provides an interface for exposing an application
+// through an ingress.
+type IngressUpdater interface {
+	UpdateIngress(*caas.Ingress) error
+}
@@ -5,9 +5,11 @@ package caasfirewallerembedded
 
 import (
 	charmscommon "github.com/juju/juju/api/common/charms"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 )
 
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/client_mock.go github.com/juju/juju/worker/caasfirewallerembedded Client,CAASFirewallerAPI,LifeGetter
@@ -30,9 +32,15 @@ type CAASFirewallerAPI interface {
 	WatchApplications() (watcher.StringsWatcher, error)
 	WatchApplication(string) (watcher.NotifyWatcher, error)
 	WatchOpenedPorts() (watcher.StringsWatcher, error)
+	WatchRelations(string) (watcher.StringsWatcher, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
 
 	IsExposed(string) (bool, error)
+	ExposedCIDRs(string) ([]string, error)
+	ExposedIngress(string) (*caas.Ingress, error)
+	RelatedApplications(string) ([]string, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
+	ModelConfig() (*config.Config, error)
 
 	ApplicationCharmInfo(appName string) (*charmscommon.CharmInfo, error)
 }
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/worker/caasfirewallerembedded (interfaces: CAASBroker,PortMutator,ServiceUpdater)
+// Source: github.com/juju/juju/worker/caasfirewallerembedded (interfaces: CAASBroker,PortMutator,ServiceUpdater,NetworkPolicyUpdater)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -120,3 +120,40 @@ func (mr *MockServiceUpdaterMockRecorder) UpdateService(arg0 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockServiceUpdater)(nil).UpdateService), arg0)
 }
+
+// MockNetworkPolicyUpdater is a mock of NetworkPolicyUpdater interface
+type MockNetworkPolicyUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkPolicyUpdaterMockRecorder
+}
+
+// MockNetworkPolicyUpdaterMockRecorder is the mock recorder for MockNetworkPolicyUpdater
+type MockNetworkPolicyUpdaterMockRecorder struct {
+	mock *MockNetworkPolicyUpdater
+}
+
+// NewMockNetworkPolicyUpdater creates a new mock instance
+func NewMockNetworkPolicyUpdater(ctrl *gomock.Controller) *MockNetworkPolicyUpdater {
+	mock := &MockNetworkPolicyUpdater{ctrl: ctrl}
+	mock.recorder = &MockNetworkPolicyUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNetworkPolicyUpdater) EXPECT() *MockNetworkPolicyUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdateNetworkPolicy mocks base method
+func (m *MockNetworkPolicyUpdater) UpdateNetworkPolicy(arg0 *caas.NetworkPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNetworkPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNetworkPolicy indicates an expected call of UpdateNetworkPolicy
+func (mr *MockNetworkPolicyUpdaterMockRecorder) UpdateNetworkPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNetworkPolicy", reflect.TypeOf((*MockNetworkPolicyUpdater)(nil).UpdateNetworkPolicy), arg0)
+}
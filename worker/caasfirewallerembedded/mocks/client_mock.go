@@ -7,9 +7,11 @@ package mocks
 import (
 	gomock "github.com/golang/mock/gomock"
 	charms "github.com/juju/juju/api/common/charms"
+	caas "github.com/juju/juju/caas"
 	application "github.com/juju/juju/core/application"
 	life "github.com/juju/juju/core/life"
 	watcher "github.com/juju/juju/core/watcher"
+	config "github.com/juju/juju/environs/config"
 	reflect "reflect"
 )
 
@@ -66,6 +68,36 @@ func (mr *MockClientMockRecorder) ApplicationConfig(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationConfig", reflect.TypeOf((*MockClient)(nil).ApplicationConfig), arg0)
 }
 
+// ExposedCIDRs mocks base method
+func (m *MockClient) ExposedCIDRs(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExposedCIDRs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExposedCIDRs indicates an expected call of ExposedCIDRs
+func (mr *MockClientMockRecorder) ExposedCIDRs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExposedCIDRs", reflect.TypeOf((*MockClient)(nil).ExposedCIDRs), arg0)
+}
+
+// ExposedIngress mocks base method
+func (m *MockClient) ExposedIngress(arg0 string) (*caas.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExposedIngress", arg0)
+	ret0, _ := ret[0].(*caas.Ingress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExposedIngress indicates an expected call of ExposedIngress
+func (mr *MockClientMockRecorder) ExposedIngress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExposedIngress", reflect.TypeOf((*MockClient)(nil).ExposedIngress), arg0)
+}
+
 // IsExposed mocks base method
 func (m *MockClient) IsExposed(arg0 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +128,36 @@ func (mr *MockClientMockRecorder) Life(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Life", reflect.TypeOf((*MockClient)(nil).Life), arg0)
 }
 
+// ModelConfig mocks base method
+func (m *MockClient) ModelConfig() (*config.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModelConfig")
+	ret0, _ := ret[0].(*config.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModelConfig indicates an expected call of ModelConfig
+func (mr *MockClientMockRecorder) ModelConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelConfig", reflect.TypeOf((*MockClient)(nil).ModelConfig))
+}
+
+// RelatedApplications mocks base method
+func (m *MockClient) RelatedApplications(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelatedApplications", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelatedApplications indicates an expected call of RelatedApplications
+func (mr *MockClientMockRecorder) RelatedApplications(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelatedApplications", reflect.TypeOf((*MockClient)(nil).RelatedApplications), arg0)
+}
+
 // WatchApplication mocks base method
 func (m *MockClient) WatchApplication(arg0 string) (watcher.NotifyWatcher, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +188,21 @@ func (mr *MockClientMockRecorder) WatchApplications() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplications", reflect.TypeOf((*MockClient)(nil).WatchApplications))
 }
 
+// WatchForModelConfigChanges mocks base method
+func (m *MockClient) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchForModelConfigChanges")
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchForModelConfigChanges indicates an expected call of WatchForModelConfigChanges
+func (mr *MockClientMockRecorder) WatchForModelConfigChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchForModelConfigChanges", reflect.TypeOf((*MockClient)(nil).WatchForModelConfigChanges))
+}
+
 // WatchOpenedPorts mocks base method
 func (m *MockClient) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +218,21 @@ func (mr *MockClientMockRecorder) WatchOpenedPorts() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchOpenedPorts", reflect.TypeOf((*MockClient)(nil).WatchOpenedPorts))
 }
 
+// WatchRelations mocks base method
+func (m *MockClient) WatchRelations(arg0 string) (watcher.StringsWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchRelations", arg0)
+	ret0, _ := ret[0].(watcher.StringsWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchRelations indicates an expected call of WatchRelations
+func (mr *MockClientMockRecorder) WatchRelations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchRelations", reflect.TypeOf((*MockClient)(nil).WatchRelations), arg0)
+}
+
 // MockCAASFirewallerAPI is a mock of CAASFirewallerAPI interface
 type MockCAASFirewallerAPI struct {
 	ctrl     *gomock.Controller
@@ -194,6 +286,36 @@ func (mr *MockCAASFirewallerAPIMockRecorder) ApplicationConfig(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationConfig", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).ApplicationConfig), arg0)
 }
 
+// ExposedCIDRs mocks base method
+func (m *MockCAASFirewallerAPI) ExposedCIDRs(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExposedCIDRs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExposedCIDRs indicates an expected call of ExposedCIDRs
+func (mr *MockCAASFirewallerAPIMockRecorder) ExposedCIDRs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExposedCIDRs", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).ExposedCIDRs), arg0)
+}
+
+// ExposedIngress mocks base method
+func (m *MockCAASFirewallerAPI) ExposedIngress(arg0 string) (*caas.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExposedIngress", arg0)
+	ret0, _ := ret[0].(*caas.Ingress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExposedIngress indicates an expected call of ExposedIngress
+func (mr *MockCAASFirewallerAPIMockRecorder) ExposedIngress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExposedIngress", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).ExposedIngress), arg0)
+}
+
 // IsExposed mocks base method
 func (m *MockCAASFirewallerAPI) IsExposed(arg0 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -209,6 +331,36 @@ func (mr *MockCAASFirewallerAPIMockRecorder) IsExposed(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExposed", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).IsExposed), arg0)
 }
 
+// ModelConfig mocks base method
+func (m *MockCAASFirewallerAPI) ModelConfig() (*config.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModelConfig")
+	ret0, _ := ret[0].(*config.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModelConfig indicates an expected call of ModelConfig
+func (mr *MockCAASFirewallerAPIMockRecorder) ModelConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelConfig", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).ModelConfig))
+}
+
+// RelatedApplications mocks base method
+func (m *MockCAASFirewallerAPI) RelatedApplications(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelatedApplications", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelatedApplications indicates an expected call of RelatedApplications
+func (mr *MockCAASFirewallerAPIMockRecorder) RelatedApplications(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelatedApplications", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).RelatedApplications), arg0)
+}
+
 // WatchApplication mocks base method
 func (m *MockCAASFirewallerAPI) WatchApplication(arg0 string) (watcher.NotifyWatcher, error) {
 	m.ctrl.T.Helper()
@@ -239,6 +391,21 @@ func (mr *MockCAASFirewallerAPIMockRecorder) WatchApplications() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplications", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).WatchApplications))
 }
 
+// WatchForModelConfigChanges mocks base method
+func (m *MockCAASFirewallerAPI) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchForModelConfigChanges")
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchForModelConfigChanges indicates an expected call of WatchForModelConfigChanges
+func (mr *MockCAASFirewallerAPIMockRecorder) WatchForModelConfigChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchForModelConfigChanges", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).WatchForModelConfigChanges))
+}
+
 // WatchOpenedPorts mocks base method
 func (m *MockCAASFirewallerAPI) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	m.ctrl.T.Helper()
@@ -254,6 +421,21 @@ func (mr *MockCAASFirewallerAPIMockRecorder) WatchOpenedPorts() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchOpenedPorts", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).WatchOpenedPorts))
 }
 
+// WatchRelations mocks base method
+func (m *MockCAASFirewallerAPI) WatchRelations(arg0 string) (watcher.StringsWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchRelations", arg0)
+	ret0, _ := ret[0].(watcher.StringsWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchRelations indicates an expected call of WatchRelations
+func (mr *MockCAASFirewallerAPIMockRecorder) WatchRelations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchRelations", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).WatchRelations), arg0)
+}
+
 // MockLifeGetter is a mock of LifeGetter interface
 type MockLifeGetter struct {
 	ctrl     *gomock.Controller
@@ -224,6 +224,11 @@ func (m *mockExecutor) Copy(params exec.CopyParams, cancel <-chan struct{}) erro
 	return m.NextErr()
 }
 
+func (m *mockExecutor) Debug(params exec.DebugParams, cancel <-chan struct{}) error {
+	m.MethodCall(m, "Debug", params, cancel)
+	return m.NextErr()
+}
+
 func (m *mockExecutor) Exec(params exec.ExecParams, cancel <-chan struct{}) error {
 	m.MethodCall(m, "Exec", params, cancel)
 	return m.NextErr()
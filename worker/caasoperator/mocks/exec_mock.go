@@ -48,6 +48,20 @@ func (mr *MockExecutorMockRecorder) Copy(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockExecutor)(nil).Copy), arg0, arg1)
 }
 
+// Debug mocks base method
+func (m *MockExecutor) Debug(arg0 exec.DebugParams, arg1 <-chan struct{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Debug", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Debug indicates an expected call of Debug
+func (mr *MockExecutorMockRecorder) Debug(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debug", reflect.TypeOf((*MockExecutor)(nil).Debug), arg0, arg1)
+}
+
 // Exec mocks base method
 func (m *MockExecutor) Exec(arg0 exec.ExecParams, arg1 <-chan struct{}) error {
 	m.ctrl.T.Helper()
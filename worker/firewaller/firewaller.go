@@ -8,7 +8,6 @@ import (
 	"sort"
 	"time"
 
-	"github.com/EvilSuperstars/go-cidrman"
 	"github.com/juju/charm/v9"
 	"github.com/juju/clock"
 	"github.com/juju/collections/set"
@@ -868,12 +867,8 @@ func (fw *Firewaller) ingressRulesForNonExposedMachineUnit(appTag names.Applicat
 }
 
 func (fw *Firewaller) ingressRulesForExposedMachineUnit(machine *machineData, unit *unitData, openUnitPortRanges network.GroupedPortRanges) firewall.IngressRules {
-	var (
-		exposedEndpoints = unit.applicationd.exposedEndpoints
-		rules            firewall.IngressRules
-	)
-
-	for exposedEndpoint, exposeDetails := range exposedEndpoints {
+	exposedCIDRs := make(map[string]set.Strings)
+	for exposedEndpoint, exposeDetails := range unit.applicationd.exposedEndpoints {
 		// Collect the operator-provided CIDRs that should be able to
 		// access the port ranges opened for this endpoint; then resolve
 		// the CIDRs for the spaces specified in the expose details to
@@ -897,46 +892,12 @@ func (fw *Firewaller) ingressRulesForExposedMachineUnit(machine *machineData, un
 				srcCIDRs.Add(subnet.CIDR)
 			}
 		}
-
-		if len(srcCIDRs) == 0 {
-			continue // no rules required
-		}
-
-		// If this is a named (i.e. not the wildcard) endpoint, look up
-		// the port ranges opened for *all* endpoints as well as for
-		// that endpoint name specifically, and create ingress rules.
-		if exposedEndpoint != "" {
-			for _, portRange := range openUnitPortRanges[exposedEndpoint] { // ports opened for this endpoint
-				rules = append(rules, firewall.NewIngressRule(portRange, srcCIDRs.Values()...))
-			}
-			for _, portRange := range openUnitPortRanges[""] { // ports opened for ALL endpoints
-				rules = append(rules, firewall.NewIngressRule(portRange, srcCIDRs.Values()...))
-			}
-			continue
-		}
-
-		// Create ingress rules for all endpoints except the ones that
-		// have their own dedicated entry in the exposed endpoints map.
-		for endpointName, portRanges := range openUnitPortRanges {
-			// This non-wildcard endpoint has an entry in the exposed
-			// endpoints list that override the global expose-all
-			// entry so we should skip it.
-			if _, hasExposeOverride := exposedEndpoints[endpointName]; hasExposeOverride && endpointName != "" {
-				continue
-			}
-
-			for _, portRange := range portRanges {
-				rules = append(rules, firewall.NewIngressRule(portRange, srcCIDRs.Values()...))
-			}
-		}
+		exposedCIDRs[exposedEndpoint] = srcCIDRs
 	}
 
-	return rules
+	return firewall.ExposedIngressRules(openUnitPortRanges, exposedCIDRs)
 }
 
-// TODO(wallyworld) - consider making this configurable.
-const maxAllowedCIDRS = 20
-
 func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag) (set.Strings, error) {
 	fw.logger.Debugf("finding egress rules for %v", appTag)
 	// Now create the rules for any remote relations of which the
@@ -956,38 +917,13 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 	// If we have too many CIDRs to create a rule for, consolidate.
 	// If a firewall rule with a whitelist of CIDRs has been set up,
 	// use that, else open to the world.
-	if cidrs.Size() > maxAllowedCIDRS {
-		// First, try and merge the cidrs.
-		merged, err := cidrman.MergeCIDRs(cidrs.Values())
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		cidrs = set.NewStrings(merged...)
-	}
-
-	// If there's still too many after merging, look for any firewall whitelist.
-	if cidrs.Size() > maxAllowedCIDRS {
-		cidrs = make(set.Strings)
+	return firewall.ConsolidateRelationCIDRs(cidrs, func() ([]string, error) {
 		rules, err := fw.firewallerApi.FirewallRules("juju-application-offer")
-		if err != nil {
+		if err != nil || len(rules) == 0 {
 			return nil, errors.Trace(err)
 		}
-		if len(rules) > 0 {
-			rule := rules[0]
-			if len(rule.WhitelistCIDRS) > 0 {
-				for _, cidr := range rule.WhitelistCIDRS {
-					cidrs.Add(cidr)
-				}
-			}
-		}
-		// No relevant firewall rule exists, so go public.
-		if cidrs.Size() == 0 {
-			cidrs.Add(firewall.AllNetworksIPV4CIDR)
-			cidrs.Add(firewall.AllNetworksIPV6CIDR)
-		}
-	}
-
-	return cidrs, nil
+		return rules[0].WhitelistCIDRS, nil
+	})
 }
 
 // flushGlobalPorts opens and closes global ports in the environment.
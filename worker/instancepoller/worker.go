@@ -44,6 +44,11 @@ var (
 	LongPoll         = 15 * time.Minute
 )
 
+// lostInstanceThreshold is the number of consecutive polls for which the
+// provider must not know about a machine's instance before the instance
+// is reported as terminated.
+const lostInstanceThreshold = 3
+
 // Environ specifies the provider-specific methods needed by the instance
 // poller.
 type Environ interface {
@@ -119,6 +124,10 @@ type pollGroupEntry struct {
 
 	shortPollInterval time.Duration
 	shortPollAt       time.Time
+
+	// missingPolls counts the consecutive polls for which the provider
+	// did not return the machine's instance.
+	missingPolls int
 }
 
 func (e *pollGroupEntry) resetShortPollInterval(clk clock.Clock) {
@@ -365,21 +374,30 @@ func (u *updaterWorker) pollGroupMembers(groupType pollGroupType) error {
 		return errors.Trace(err)
 	}
 
-	for idx, info := range infoList {
-		// No details found for this instance. This most probably means
-		// that the unit has been killed and we haven't been notified
-		// yet. Log the error and keep going.
+	for idx, instID := range instList {
+		entry := u.instanceIDToGroupEntry[instID]
+
+		// No details found for this instance. Either the machine has
+		// been removed and we haven't been notified yet, or the instance
+		// has been lost; keep track of it until we know which.
+		var info instances.Instance
+		if idx < len(infoList) {
+			info = infoList[idx]
+		}
 		if info == nil {
-			u.config.Logger.Warningf("unable to retrieve instance information for instance: %q", instList[idx])
+			u.config.Logger.Warningf("unable to retrieve instance information for instance: %q", instID)
+			if err := u.processMissingInstance(groupType, entry); err != nil {
+				return errors.Trace(err)
+			}
 			continue
 		}
+		entry.missingPolls = 0
 
 		var ifList network.InterfaceInfos
 		if netList != nil {
 			ifList = netList[idx]
 		}
 
-		entry := u.instanceIDToGroupEntry[instList[idx]]
 		providerStatus, providerAddrCount, err := u.processProviderInfo(entry, info, ifList)
 		if err != nil {
 			return errors.Trace(err)
@@ -396,6 +414,48 @@ func (u *updaterWorker) pollGroupMembers(groupType pollGroupType) error {
 	return nil
 }
 
+// processMissingInstance handles an entry whose instance was not returned
+// by the provider. The entry is polled at short intervals until the
+// instance reappears; once it has been missing for lostInstanceThreshold
+// consecutive polls, the instance status of a live machine is set to
+// terminated so that the machine can be replaced if the model or its
+// applications ask for it.
+func (u *updaterWorker) processMissingInstance(curGroup pollGroupType, entry *pollGroupEntry) error {
+	entry.missingPolls++
+	if curGroup == longPollGroup {
+		u.moveEntryToPollGroup(shortPollGroup, entry)
+	} else {
+		entry.bumpShortPollInterval(u.config.Clock)
+	}
+	if entry.missingPolls < lostInstanceThreshold {
+		return nil
+	}
+	if entry.m.Life() != life.Alive {
+		return nil
+	}
+
+	curStatus, err := entry.m.InstanceStatus()
+	if err != nil {
+		u.config.Logger.Warningf("cannot get current instance status for machine %v (instance ID %q): %v", entry.m.Id(), entry.instanceID, err)
+		return nil
+	}
+	switch status.Status(curStatus.Status) {
+	case status.Terminated:
+		return nil
+	case status.Empty, status.Provisioning, status.ProvisioningError:
+		// The provider has never reported on this instance; it may
+		// simply not be visible yet.
+		return nil
+	}
+
+	u.config.Logger.Warningf("machine %q (instance ID %q) not found by the provider after %d polls", entry.m.Id(), entry.instanceID, entry.missingPolls)
+	if err := entry.m.SetInstanceStatus(status.Terminated, "instance not found by the provider", nil); err != nil {
+		u.config.Logger.Errorf("cannot set instance status on %q: %v", entry.m, err)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (u *updaterWorker) resolveInstanceID(entry *pollGroupEntry) error {
 	if entry.instanceID != "" {
 		return nil // already resolved
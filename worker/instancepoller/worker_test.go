@@ -567,6 +567,62 @@ func (s *workerSuite) TestLongPollNoMachineInGroupKnownByProvider(c *gc.C) {
 	})
 }
 
+func (s *workerSuite) TestMissingInstanceMarkedTerminated(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, _ := s.startWorker(c, ctrl)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+	updWorker.appendToShortPollGroup(machineTag, machine)
+	entry, _ := updWorker.lookupPolledMachine(machineTag)
+	updWorker.moveEntryToPollGroup(longPollGroup, entry)
+	entry.instanceID = "b0rk3d"
+
+	// The machine is moved to the short poll group as soon as its
+	// instance goes missing, but nothing is reported until the
+	// threshold is reached.
+	for i := 1; i < lostInstanceThreshold; i++ {
+		err := updWorker.processMissingInstance(longPollGroup, entry)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(updWorker.pollGroup[shortPollGroup], gc.HasLen, 1)
+		c.Assert(entry.missingPolls, gc.Equals, i)
+	}
+
+	machine.EXPECT().Id().Return("0").AnyTimes()
+	machine.EXPECT().Life().Return(life.Alive)
+	machine.EXPECT().InstanceStatus().Return(params.StatusResult{Status: string(status.Running)}, nil)
+	machine.EXPECT().SetInstanceStatus(status.Terminated, "instance not found by the provider", nil).Return(nil)
+	err := updWorker.processMissingInstance(shortPollGroup, entry)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workerSuite) TestMissingInstanceNeverReportedIsNotTerminated(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, _ := s.startWorker(c, ctrl)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+	updWorker.appendToShortPollGroup(machineTag, machine)
+	entry, _ := updWorker.lookupPolledMachine(machineTag)
+	entry.instanceID = "b0rk3d"
+	entry.missingPolls = lostInstanceThreshold - 1
+
+	// The provider has not yet reported on the instance since it was
+	// started, so it may not be visible yet.
+	machine.EXPECT().Life().Return(life.Alive)
+	machine.EXPECT().InstanceStatus().Return(params.StatusResult{Status: string(status.Provisioning)}, nil)
+	err := updWorker.processMissingInstance(shortPollGroup, entry)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *workerSuite) assertWorkerCompletesLoop(c *gc.C, w *updaterWorker, triggerFn func()) {
 	s.assertWorkerCompletesLoops(c, w, 1, triggerFn)
 }
@@ -6,4 +6,8 @@ package machiner
 var (
 	InterfaceAddrs           = &interfaceAddrs
 	GetObservedNetworkConfig = &getObservedNetworkConfig
+	ApplyNetworkTemplate     = &applyNetworkTemplate
+	InterfaceByName          = &interfaceByName
 )
+
+var NewNetworkTemplateWatcher = newNetworkTemplateWatcher
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/network/netplan"
 	jworker "github.com/juju/juju/worker"
 )
 
@@ -34,6 +35,23 @@ type Config struct {
 	// ClearMachineAddressesOnStart indicates whether or not to clear
 	// the machine's machine addresses when the worker starts.
 	ClearMachineAddressesOnStart bool
+
+	// NetworkTemplate, if set, describes bonds and VLANs to be created
+	// on the machine when the worker starts.
+	NetworkTemplate *netplan.Template
+
+	// FailedNetworkTemplateHash is the hash of a network template that
+	// could not be applied before. A template with the same hash is not
+	// applied again.
+	FailedNetworkTemplateHash string
+
+	// RecordFailedNetworkTemplate, if set, is called with the hash of a
+	// network template that could not be applied.
+	RecordFailedNetworkTemplate func(hash string) error
+
+	// CheckConnectivity reports whether the controller can still be
+	// reached once the network template has been applied.
+	CheckConnectivity func() error
 }
 
 // Validate reports whether or not the configuration is valid.
@@ -85,6 +103,16 @@ func (mr *Machiner) SetUp() (watcher.NotifyWatcher, error) {
 	}
 	mr.machine = m
 
+	// Create any bonds and VLANs before the machine's addresses are
+	// recorded, so that they are included.
+	var info string
+	if mr.config.NetworkTemplate != nil && m.Life() == corelife.Alive {
+		if err := mr.applyNetworkTemplate(); err != nil {
+			logger.Errorf("cannot apply network template: %v", err)
+			info = "network template not applied: " + err.Error()
+		}
+	}
+
 	switch {
 	case corelife.IsNotAlive(m.Life()):
 		// Can happen when the machiner is restarting after a failure in EnsureDead.
@@ -106,7 +134,7 @@ func (mr *Machiner) SetUp() (watcher.NotifyWatcher, error) {
 	}
 
 	// Mark the machine as started and log it.
-	if err := m.SetStatus(status.Started, "", nil); err != nil {
+	if err := m.SetStatus(status.Started, info, nil); err != nil {
 		return nil, errors.Annotatef(err, "%s failed to set status started", mr.config.Tag)
 	}
 	logger.Infof("%q started", mr.config.Tag)
@@ -114,6 +142,34 @@ func (mr *Machiner) SetUp() (watcher.NotifyWatcher, error) {
 	return m.Watch()
 }
 
+// applyNetworkTemplate applies the configured network template if any of
+// its devices are missing, unless it has failed before. A template that
+// fails is recorded, so that a network change that cost the machine its
+// connectivity is not retried every time the worker restarts.
+func (mr *Machiner) applyNetworkTemplate() error {
+	missing := missingDevices(mr.config.NetworkTemplate)
+	if len(missing) == 0 {
+		logger.Debugf("network template devices already exist")
+		return nil
+	}
+	logger.Debugf("network template devices missing: %v", missing)
+	hash, err := mr.config.NetworkTemplate.Hash()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if hash == mr.config.FailedNetworkTemplateHash {
+		return errors.New("template failed previously, change it to try again")
+	}
+	err = applyNetworkTemplate(mr.config.NetworkTemplate, mr.config.CheckConnectivity)
+	if err == nil || mr.config.RecordFailedNetworkTemplate == nil {
+		return errors.Trace(err)
+	}
+	if recordErr := mr.config.RecordFailedNetworkTemplate(hash); recordErr != nil {
+		logger.Errorf("cannot record failed network template: %v", recordErr)
+	}
+	return errors.Trace(err)
+}
+
 var interfaceAddrs = net.InterfaceAddrs
 
 // setMachineAddresses sets the addresses for this machine to all of the
@@ -8,12 +8,15 @@ import (
 	"net"
 	"path/filepath"
 	stdtesting "testing"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	"github.com/juju/worker/v2/workertest"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/common"
@@ -22,6 +25,7 @@ import (
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/network/netplan"
 	coretesting "github.com/juju/juju/testing"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/machiner"
@@ -56,6 +60,9 @@ func (s *MachinerSuite) SetUpTest(c *gc.C) {
 	s.PatchValue(machiner.GetObservedNetworkConfig, func(_ common.NetworkConfigSource) ([]params.NetworkConfig, error) {
 		return nil, nil
 	})
+	s.PatchValue(machiner.InterfaceByName, func(name string) (*net.Interface, error) {
+		return nil, errors.NotFoundf("interface %q", name)
+	})
 }
 
 func (s *MachinerSuite) TestMachinerConfigValidate(c *gc.C) {
@@ -83,7 +90,8 @@ func (s *MachinerSuite) TestMachinerSetUpMachineNotFound(c *gc.C) {
 		&params.Error{Code: params.CodeNotFound}, // Machine
 	)
 	w, err := machiner.NewMachiner(machiner.Config{
-		s.accessor, s.machineTag, false,
+		MachineAccessor: s.accessor,
+		Tag:             s.machineTag,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	err = stopWorker(w)
@@ -108,7 +116,8 @@ func (s *MachinerSuite) testMachinerMachineRefreshNotFoundOrUnauthorized(c *gc.C
 		&params.Error{Code: code}, // Refresh
 	)
 	w, err := machiner.NewMachiner(machiner.Config{
-		s.accessor, s.machineTag, false,
+		MachineAccessor: s.accessor,
+		Tag:             s.machineTag,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.accessor.machine.watcher.changes <- struct{}{}
@@ -308,6 +317,124 @@ func (s *MachinerSuite) TestStartSetsStatus(c *gc.C) {
 	)
 }
 
+func (s *MachinerSuite) TestStartAppliesNetworkTemplate(c *gc.C) {
+	template, err := netplan.ParseTemplate([]byte("vlans: {eno1.100: {id: 100, link: eno1}}"))
+	c.Assert(err, jc.ErrorIsNil)
+	checkConnectivity := func() error { return nil }
+	var applied bool
+	s.PatchValue(machiner.ApplyNetworkTemplate, func(t *netplan.Template, check func() error) error {
+		c.Check(t, gc.Equals, template)
+		c.Check(check, gc.NotNil)
+		// The template is applied before the machine addresses are set.
+		s.accessor.machine.CheckCallNames(c, "Life")
+		applied = true
+		return nil
+	})
+
+	w, err := machiner.NewMachiner(machiner.Config{
+		MachineAccessor:   s.accessor,
+		Tag:               s.machineTag,
+		NetworkTemplate:   template,
+		CheckConnectivity: checkConnectivity,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stopWorker(w), jc.ErrorIsNil)
+	c.Assert(applied, jc.IsTrue)
+	s.accessor.machine.CheckCallNames(c,
+		"Life",
+		"Life",
+		"SetMachineAddresses",
+		"SetStatus",
+		"Watch",
+	)
+	s.accessor.machine.CheckCall(
+		c, 3, "SetStatus",
+		status.Started, "", map[string]interface{}(nil),
+	)
+}
+
+func (s *MachinerSuite) TestStartNetworkTemplateError(c *gc.C) {
+	template, err := netplan.ParseTemplate([]byte("vlans: {eno1.100: {id: 100, link: eno1}}"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(machiner.ApplyNetworkTemplate, func(*netplan.Template, func() error) error {
+		return errors.New("network template rolled back: cannot reach controller")
+	})
+	var recorded string
+
+	w, err := machiner.NewMachiner(machiner.Config{
+		MachineAccessor: s.accessor,
+		Tag:             s.machineTag,
+		NetworkTemplate: template,
+		RecordFailedNetworkTemplate: func(hash string) error {
+			recorded = hash
+			return nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stopWorker(w), jc.ErrorIsNil)
+
+	// The machine still starts, reporting why the template was not applied.
+	s.accessor.machine.CheckCall(
+		c, 3, "SetStatus",
+		status.Started, "network template not applied: network template rolled back: cannot reach controller",
+		map[string]interface{}(nil),
+	)
+	hash, err := template.Hash()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorded, gc.Equals, hash)
+}
+
+func (s *MachinerSuite) TestStartNetworkTemplateDevicesExist(c *gc.C) {
+	template, err := netplan.ParseTemplate([]byte("vlans: {eno1.100: {id: 100, link: eno1}}"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(machiner.InterfaceByName, func(name string) (*net.Interface, error) {
+		return &net.Interface{Name: name}, nil
+	})
+	s.PatchValue(machiner.ApplyNetworkTemplate, func(*netplan.Template, func() error) error {
+		c.Fatalf("unexpected network template application")
+		return nil
+	})
+
+	w, err := machiner.NewMachiner(machiner.Config{
+		MachineAccessor: s.accessor,
+		Tag:             s.machineTag,
+		NetworkTemplate: template,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stopWorker(w), jc.ErrorIsNil)
+
+	s.accessor.machine.CheckCall(
+		c, 3, "SetStatus",
+		status.Started, "", map[string]interface{}(nil),
+	)
+}
+
+func (s *MachinerSuite) TestStartNetworkTemplateFailedPreviously(c *gc.C) {
+	template, err := netplan.ParseTemplate([]byte("vlans: {eno1.100: {id: 100, link: eno1}}"))
+	c.Assert(err, jc.ErrorIsNil)
+	hash, err := template.Hash()
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(machiner.ApplyNetworkTemplate, func(*netplan.Template, func() error) error {
+		c.Fatalf("unexpected network template application")
+		return nil
+	})
+
+	w, err := machiner.NewMachiner(machiner.Config{
+		MachineAccessor:           s.accessor,
+		Tag:                       s.machineTag,
+		NetworkTemplate:           template,
+		FailedNetworkTemplateHash: hash,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stopWorker(w), jc.ErrorIsNil)
+
+	s.accessor.machine.CheckCall(
+		c, 3, "SetStatus",
+		status.Started, "network template not applied: template failed previously, change it to try again",
+		map[string]interface{}(nil),
+	)
+}
+
 func (s *MachinerSuite) TestSetDead(c *gc.C) {
 	s.accessor.machine.life = life.Dying
 	mr := s.makeMachiner(c, false)
@@ -446,6 +573,56 @@ func (s *MachinerSuite) TestAliveErrorGetObservedNetworkConfig(c *gc.C) {
 	)
 }
 
+func (s *MachinerSuite) TestNetworkTemplateWatcherBouncesOnChange(c *gc.C) {
+	template, err := netplan.ParseTemplate([]byte("vlans: {eno1.100: {id: 100, link: eno1}}"))
+	c.Assert(err, jc.ErrorIsNil)
+	getter := &mockModelConfigGetter{
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"network-template": "vlans: {eno1.200: {id: 200, link: eno1}}",
+		}),
+		watcher: mockWatcher{changes: make(chan struct{})},
+	}
+	inner := workertest.NewErrorWorker(nil)
+
+	w, err := machiner.NewNetworkTemplateWatcher(getter, template, inner)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	select {
+	case getter.watcher.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending config change")
+	}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.Equals, dependency.ErrBounce)
+	workertest.CheckKilled(c, inner)
+	getter.CheckCallNames(c, "WatchForModelConfigChanges", "ModelConfig")
+}
+
+func (s *MachinerSuite) TestNetworkTemplateWatcherIgnoresOtherChanges(c *gc.C) {
+	template, err := netplan.ParseTemplate([]byte("vlans: {eno1.100: {id: 100, link: eno1}}"))
+	c.Assert(err, jc.ErrorIsNil)
+	getter := &mockModelConfigGetter{
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"network-template": "vlans: {eno1.100: {id: 100, link: eno1}}",
+		}),
+		watcher: mockWatcher{changes: make(chan struct{})},
+	}
+	inner := workertest.NewErrorWorker(nil)
+
+	w, err := machiner.NewNetworkTemplateWatcher(getter, template, inner)
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 2; i++ {
+		select {
+		case getter.watcher.changes <- struct{}{}:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out sending config change")
+		}
+	}
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+	workertest.CheckKilled(c, inner)
+}
+
 func (s *MachinerSuite) makeMachiner(
 	c *gc.C,
 	ignoreAddresses bool,
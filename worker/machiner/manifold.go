@@ -13,6 +13,7 @@ import (
 	apiagent "github.com/juju/juju/api/agent"
 	"github.com/juju/juju/api/base"
 	apimachiner "github.com/juju/juju/api/machiner"
+	"github.com/juju/juju/network/netplan"
 )
 
 // ManifoldConfig defines the names of the manifolds on which a
@@ -85,14 +86,41 @@ func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
 	if ignoreMachineAddresses {
 		logger.Infof("machine addresses not used, only addresses from provider")
 	}
+	// Bonds and VLANs are only created on the host, never in containers,
+	// and never on controllers, whose connectivity matters most.
+	_, isController := currentConfig.StateServingInfo()
+	applyTemplate := !names.IsContainerMachine(tag.Id()) && !isController
+	var networkTemplate *netplan.Template
+	if applyTemplate {
+		if networkTemplate, err = modelConfig.NetworkTemplate(); err != nil {
+			return nil, errors.Annotate(err, "cannot read network template")
+		}
+	}
+	apiAddrs, err := currentConfig.APIAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	accessor := APIMachineAccessor{apimachiner.NewState(apiCaller)}
 	w, err := NewMachiner(Config{
 		MachineAccessor:              accessor,
 		Tag:                          tag.(names.MachineTag),
 		ClearMachineAddressesOnStart: ignoreMachineAddresses,
+		NetworkTemplate:              networkTemplate,
+		FailedNetworkTemplateHash:    currentConfig.Value(agent.FailedNetworkTemplateHash),
+		RecordFailedNetworkTemplate: func(hash string) error {
+			return a.ChangeConfig(func(setter agent.ConfigSetter) error {
+				setter.SetValue(agent.FailedNetworkTemplateHash, hash)
+				return nil
+			})
+		},
+		CheckConnectivity: controllerConnectivityChecker(apiAddrs),
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start machiner worker")
 	}
-	return w, err
+	if !applyTemplate {
+		return w, nil
+	}
+	return newNetworkTemplateWatcher(agentFacade, networkTemplate, w)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/worker/machiner"
 )
 
@@ -86,3 +87,25 @@ func (a *mockMachineAccessor) Machine(tag names.MachineTag) (machiner.Machine, e
 	}
 	return &a.machine, nil
 }
+
+type mockModelConfigGetter struct {
+	gitjujutesting.Stub
+	config  *config.Config
+	watcher mockWatcher
+}
+
+func (g *mockModelConfigGetter) ModelConfig() (*config.Config, error) {
+	g.MethodCall(g, "ModelConfig")
+	if err := g.NextErr(); err != nil {
+		return nil, err
+	}
+	return g.config, nil
+}
+
+func (g *mockModelConfigGetter) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	g.MethodCall(g, "WatchForModelConfigChanges")
+	if err := g.NextErr(); err != nil {
+		return nil, err
+	}
+	return &g.watcher, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machiner
+
+import (
+	"net"
+	"os"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+	"github.com/juju/worker/v2/dependency"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network/netplan"
+)
+
+const (
+	netplanDirectory = "/etc/netplan"
+
+	// networkTemplateTimeout bounds the time taken to apply the
+	// network template.
+	networkTemplateTimeout = 5 * time.Minute
+)
+
+// applyNetworkTemplate creates the bonds and VLANs of the template that
+// do not yet exist on the machine. Machines that are not configured by
+// netplan are left alone.
+var applyNetworkTemplate = func(template *netplan.Template, checkConnectivity func() error) error {
+	if _, err := os.Stat(netplanDirectory); os.IsNotExist(err) {
+		logger.Warningf("network template not applied: %s not found", netplanDirectory)
+		return nil
+	}
+	result, err := netplan.ApplyTemplateAndActivate(netplan.TemplateActivationParams{
+		Clock:             clock.WallClock,
+		Template:          template,
+		Directory:         netplanDirectory,
+		Timeout:           networkTemplateTimeout,
+		CheckConnectivity: checkConnectivity,
+	})
+	if result != nil && result.Code != 0 {
+		logger.Errorf("netplan stdout\n%s\n", result.Stdout)
+		logger.Errorf("netplan stderr\n%s\n", result.Stderr)
+	}
+	return errors.Trace(err)
+}
+
+var interfaceByName = net.InterfaceByName
+
+// missingDevices returns the names of the bonds and VLANs of the template
+// that do not exist on the machine.
+func missingDevices(template *netplan.Template) []string {
+	var missing []string
+	for _, name := range template.DeviceNames() {
+		if _, err := interfaceByName(name); err != nil {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// controllerConnectivityChecker returns a function that reports whether
+// any of the given controller API addresses can be reached, retrying for
+// a while to allow the network to settle.
+func controllerConnectivityChecker(addrs []string) func() error {
+	return func() error {
+		if len(addrs) == 0 {
+			return errors.New("no controller addresses")
+		}
+		err := retry.Call(retry.CallArgs{
+			Func: func() error {
+				var err error
+				for _, addr := range addrs {
+					var conn net.Conn
+					if conn, err = net.DialTimeout("tcp", addr, 10*time.Second); err == nil {
+						_ = conn.Close()
+						return nil
+					}
+				}
+				return errors.Annotate(err, "cannot reach controller")
+			},
+			Attempts: 6,
+			Delay:    10 * time.Second,
+			Clock:    clock.WallClock,
+		})
+		return retry.LastError(err)
+	}
+}
+
+// ModelConfigGetter provides the model config, and notifies of changes
+// to it.
+type ModelConfigGetter interface {
+	ModelConfig() (*config.Config, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+}
+
+// networkTemplateHash returns the hash of the template, or the empty
+// string if there is no template.
+func networkTemplateHash(template *netplan.Template) (string, error) {
+	if template == nil {
+		return "", nil
+	}
+	return template.Hash()
+}
+
+// networkTemplateWatcher runs the machiner, and stops it with
+// dependency.ErrBounce when the model's network template changes, so that
+// the machiner is restarted with the new template.
+type networkTemplateWatcher struct {
+	catacomb catacomb.Catacomb
+	facade   ModelConfigGetter
+	hash     string
+}
+
+func newNetworkTemplateWatcher(
+	facade ModelConfigGetter, template *netplan.Template, machiner worker.Worker,
+) (worker.Worker, error) {
+	hash, err := networkTemplateHash(template)
+	if err != nil {
+		_ = worker.Stop(machiner)
+		return nil, errors.Trace(err)
+	}
+	w := &networkTemplateWatcher{
+		facade: facade,
+		hash:   hash,
+	}
+	err = catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{machiner},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *networkTemplateWatcher) loop() error {
+	configWatcher, err := w.facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			modelConfig, err := w.facade.ModelConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			template, err := modelConfig.NetworkTemplate()
+			if err != nil {
+				// An invalid template is rejected when it is set, so
+				// this should never happen; keep the current one.
+				logger.Errorf("cannot read network template: %v", err)
+				continue
+			}
+			hash, err := networkTemplateHash(template)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if hash != w.hash {
+				logger.Infof("network template changed, restarting machiner")
+				return dependency.ErrBounce
+			}
+		}
+	}
+}
+
+// Kill implements worker.Worker.
+func (w *networkTemplateWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *networkTemplateWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
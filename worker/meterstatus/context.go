@@ -108,6 +108,11 @@ func (ctx *limitedContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) ResetExecutionSetUnitStatus() {}
 
+// LogActionOutput implements runner.Context.
+func (ctx *limitedContext) LogActionOutput(stream, data string) error {
+	return jujuc.ErrRestrictedContext
+}
+
 // Id implements runner.Context.
 func (ctx *limitedContext) Id() string { return ctx.id }
 
@@ -117,6 +117,11 @@ func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
 
+// LogActionOutput implements runner.Context.
+func (ctx *hookContext) LogActionOutput(stream, data string) error {
+	return jujuc.ErrRestrictedContext
+}
+
 // Id implements runner.Context.
 func (ctx *hookContext) Id() string { return ctx.id }
 
@@ -0,0 +1,159 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package peergrouper
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/pubsub"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/pubsub/apiserver"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+// ipv6OnlyControllers starts an instance for each of the controllers
+// 10, 11 and 12 in an IPv6-only dummy environ, and adds the controllers
+// to the returned state with the instance addresses. The global IPv6
+// address of each instance is placed in the given HA space.
+// The initial replica-set does not use the instance addresses,
+// so that the worker must replace them.
+func ipv6OnlyControllers(c *gc.C, haSpace string) (*fakeState, map[string]string) {
+	attrs := dummy.SampleConfig().Merge(coretesting.Attrs{"ipv6-only": true})
+	cfg, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := environs.New(environs.OpenParams{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		Cloud:          dummy.SampleCloudSpec(),
+		Config:         cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	callCtx := context.NewCloudCallContext()
+	err = env.Create(callCtx, environs.CreateParams{ControllerUUID: coretesting.ControllerTag.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := NewFakeState()
+	haAddrs := make(map[string]string)
+	var ids []string
+	for i := 10; i < 13; i++ {
+		id := fmt.Sprint(i)
+		icfg, err := instancecfg.NewInstanceConfig(
+			coretesting.ControllerTag, id, "fake-nonce", imagemetadata.ReleasedStream, "focal",
+			&api.Info{Tag: names.NewMachineTag(id)},
+		)
+		c.Assert(err, jc.ErrorIsNil)
+		result, err := env.StartInstance(callCtx, environs.StartInstanceParams{
+			ControllerUUID: coretesting.ControllerTag.Id(),
+			InstanceConfig: icfg,
+			Tools: coretools.List{{
+				Version: version.MustParseBinary("2.9.0-focal-amd64"),
+			}},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		providerAddrs, err := result.Instance.Addresses(callCtx)
+		c.Assert(err, jc.ErrorIsNil)
+
+		addrs := make(network.SpaceAddresses, len(providerAddrs))
+		for j, addr := range providerAddrs {
+			c.Assert(addr.Type, gc.Not(gc.Equals), network.IPv4Address)
+			addrs[j] = network.SpaceAddress{MachineAddress: addr.MachineAddress}
+			if addr.Type == network.IPv6Address && addr.Scope != network.ScopeMachineLocal {
+				addrs[j].SpaceID = haSpace
+				haAddrs[id] = addr.Value
+			}
+		}
+		c.Assert(haAddrs[id], gc.Not(gc.Equals), "")
+
+		controller := st.addController(id, true)
+		controller.setAddresses(addrs...)
+		err = controller.SetHasVote(true)
+		c.Assert(err, jc.ErrorIsNil)
+		ids = append(ids, id)
+	}
+	st.setControllers(ids...)
+
+	err = st.session.Set(mkMembers("0v 1v 2v", testIPv6))
+	c.Assert(err, jc.ErrorIsNil)
+	st.session.setStatus(mkStatuses("0p 1s 2s", testIPv6))
+	st.setCheck(checkInvariants)
+	return st, haAddrs
+}
+
+func (s *workerSuite) TestIPv6OnlyUsesConfiguredHASpace(c *gc.C) {
+	s.AddCleanup(dummy.Reset)
+	st, haAddrs := ipv6OnlyControllers(c, "ha")
+	st.setHASpace("ha")
+
+	hub := pubsub.NewStructuredHub(nil)
+	event := make(chan apiserver.Details)
+	_, err := hub.Subscribe(apiserver.DetailsTopic, func(topic string, data apiserver.Details, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		event <- data
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.hub = hub
+
+	w := s.newWorker(c, st, st.session, nopAPIHostPortsSetter{}, true)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case details := <-event:
+		c.Assert(details.Servers, gc.HasLen, 3)
+		for id, server := range details.Servers {
+			internal := net.JoinHostPort(haAddrs[id], fmt.Sprint(apiPort))
+			c.Check(server.InternalAddress, gc.Equals, internal)
+			for _, addr := range server.Addresses {
+				host, _, err := net.SplitHostPort(addr)
+				c.Assert(err, jc.ErrorIsNil)
+				c.Check(network.DeriveAddressType(host), gc.Not(gc.Equals), network.IPv4Address)
+			}
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for event")
+	}
+
+	members, err := st.session.CurrentMembers()
+	c.Assert(err, jc.ErrorIsNil)
+	obtained := make([]string, len(members))
+	for i, m := range members {
+		obtained[i] = m.Address
+	}
+	sort.Strings(obtained)
+
+	var expected []string
+	for _, addr := range haAddrs {
+		expected = append(expected, net.JoinHostPort(addr, fmt.Sprint(mongoPort)))
+	}
+	sort.Strings(expected)
+	c.Check(obtained, gc.DeepEquals, expected)
+}
+
+func (s *workerSuite) TestIPv6OnlyWithoutHASpace(c *gc.C) {
+	s.AddCleanup(dummy.Reset)
+	st, _ := ipv6OnlyControllers(c, "")
+
+	// The DNS name and the global IPv6 address are both usable,
+	// so the worker cannot choose between them without an HA space.
+	err := s.newWorker(c, st, st.session, nopAPIHostPortsSetter{}, true).Wait()
+	errMsg := `computing desired peer group: updating member addresses: ` +
+		`juju-ha-space is not set and these nodes have more than one usable address: 1[012], 1[012], 1[012]` +
+		"\nrun \"juju controller-config juju-ha-space=<name>\" to set a space for Mongo peer communication"
+	c.Check(err, gc.ErrorMatches, errMsg)
+}
@@ -20,6 +20,10 @@ func (*dummyStorageAccessor) UnitStorageAttachments(_ names.UnitTag) ([]params.S
 	return nil, nil
 }
 
+func (*dummyStorageAccessor) DestroyUnitStorageAttachments(_ names.UnitTag) error {
+	return nil
+}
+
 type nopResolver struct{}
 
 func (nopResolver) NextOp(resolver.LocalState, remotestate.Snapshot, operation.Factory) (operation.Operation, error) {
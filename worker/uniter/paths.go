@@ -7,7 +7,9 @@ package uniter
 import (
 	"crypto/tls"
 	"fmt"
+	"net"
 	"path/filepath"
+	"strconv"
 
 	"github.com/juju/names/v4"
 	jujuos "github.com/juju/os/v2"
@@ -167,7 +169,7 @@ func NewWorkerPaths(dataDir string, unitTag names.UnitTag, worker string, socket
 			return SocketPair{
 				Client: sockets.Socket{
 					Network:   "tcp",
-					Address:   fmt.Sprintf("%s:%d", address, port),
+					Address:   net.JoinHostPort(address, strconv.Itoa(port)),
 					TLSConfig: socketConfig.TLSConfig,
 				},
 				Server: sockets.Socket{
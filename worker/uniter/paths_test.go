@@ -155,6 +155,22 @@ func (s *PathsSuite) TestTCPRemote(c *gc.C) {
 	})
 }
 
+func (s *PathsSuite) TestTCPRemoteIPv6(c *gc.C) {
+	unitTag := names.NewUnitTag("some-application/323")
+
+	socketConfig := uniter.SocketConfig{
+		ServiceAddress:  "2001:db8::1",
+		OperatorAddress: "2001:db8::2",
+		TLSConfig: &tls.Config{
+			ServerName: "test",
+		},
+	}
+
+	paths := uniter.NewPaths(c.MkDir(), unitTag, &socketConfig)
+	c.Check(paths.Runtime.RemoteJujuRunSocket.Client.Address, gc.Equals, "[2001:db8::1]:30666")
+	c.Check(paths.Runtime.RemoteJujucServerSocket.Client.Address, gc.Equals, "[2001:db8::2]:30323")
+}
+
 func (s *PathsSuite) TestWorkerPaths(c *gc.C) {
 	s.PatchValue(&jujuos.HostOS, func() jujuos.OSType { return jujuos.Unknown })
 
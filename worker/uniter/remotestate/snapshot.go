@@ -42,6 +42,10 @@ type Snapshot struct {
 	// hook execution errors.
 	ResolvedMode params.ResolvedMode
 
+	// Paused reports whether hook execution has been paused
+	// for the unit, in which case no new operations are started.
+	Paused bool
+
 	// ProviderID is the cloud container's provider ID.
 	ProviderID string
 
@@ -431,6 +431,11 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ResolvedMode, gc.Equals, params.ResolvedRetryHooks)
 
+	s.st.unit.paused = true
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().Paused, jc.IsTrue)
+
 	s.st.unit.addressesWatcher.changes <- []string{"addresseshash2"}
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().AddressesHash, gc.Equals, "addresseshash2")
@@ -63,6 +63,16 @@ func (s *uniterResolver) NextOp(
 	}
 	logger := s.config.Logger
 
+	if remoteState.Paused && remoteState.Life != life.Dying &&
+		localState.Kind == operation.Continue && !localState.Restart {
+		// Hook execution has been paused and there is no operation
+		// under way, so don't start a new one. Remote state changes
+		// keep accumulating and are acted on once the unit is resumed.
+		// A dying unit is not held back, so that it can be removed.
+		logger.Debugf("hook execution paused")
+		return nil, resolver.ErrNoOperation
+	}
+
 	// Operations for series-upgrade need to be resolved early,
 	// in particular because no other operations should be run when the unit
 	// has completed preparation and is waiting for upgrade completion.
@@ -127,6 +127,7 @@ type HookUnit interface {
 	ApplicationName() string
 	ConfigSettings() (charm.Settings, error)
 	LogActionMessage(names.ActionTag, string) error
+	LogActionOutput(tag names.ActionTag, stream, data string) error
 	Name() string
 	NetworkInfo(bindings []string, relationId *int) (map[string]params.NetworkInfoResult, error)
 	RequestReboot() error
@@ -824,6 +825,18 @@ func (ctx *HookContext) LogActionMessage(message string) error {
 	return ctx.unit.LogActionMessage(ctx.actionData.Tag, message)
 }
 
+// LogActionOutput records a chunk of output written by the Action to
+// its "stdout" or "stderr" stream.
+// Implements runner.Context.
+func (ctx *HookContext) LogActionOutput(stream, data string) error {
+	ctx.actionDataMu.Lock()
+	defer ctx.actionDataMu.Unlock()
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.unit.LogActionOutput(ctx.actionData.Tag, stream, data)
+}
+
 // SetActionMessage sets a message for the Action, usually an error message.
 // Implements jujuc.ActionHookContext.actionHookContext, part of runner.Context.
 func (ctx *HookContext) SetActionMessage(message string) error {
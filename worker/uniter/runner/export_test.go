@@ -12,6 +12,8 @@ var (
 	SearchHook              = discoverHookScript
 	HookCommand             = hookCommand
 	LookPath                = lookPath
+
+	ActionOutputFlushInterval = &actionOutputFlushInterval
 )
 
 func RunnerPaths(rnr Runner) context.Paths {
@@ -0,0 +1,127 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/kballard/go-shellquote"
+)
+
+// machineOutputPollInterval is how often the output of commands
+// run on the machine is copied to the streaming writers.
+var machineOutputPollInterval = 100 * time.Millisecond
+
+// machineOutput streams the output of commands run on the machine.
+// utils/exec collects the output of the commands it runs in buffers
+// which can't be read until the commands exit, so the commands are
+// made to write their output to files instead, which are copied to
+// the writers as they grow.
+type machineOutput struct {
+	dir     string
+	streams []*machineOutputStream
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+type machineOutputStream struct {
+	path string
+	file *os.File
+	w    io.Writer
+}
+
+// newMachineOutput returns a machineOutput which copies the standard
+// output and error of the commands to the supplied writers.
+func newMachineOutput(stdout, stderr io.Writer) (*machineOutput, error) {
+	dir, err := ioutil.TempDir("", "juju-exec-output")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := &machineOutput{dir: dir}
+	for _, s := range []struct {
+		name string
+		w    io.Writer
+	}{{"stdout", stdout}, {"stderr", stderr}} {
+		path := filepath.Join(dir, s.name)
+		f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0600)
+		if err != nil {
+			out.Close()
+			return nil, errors.Trace(err)
+		}
+		out.streams = append(out.streams, &machineOutputStream{path: path, file: f, w: s.w})
+	}
+	return out, nil
+}
+
+// Redirect returns the commands prefixed with the redirection
+// of their output to the files being streamed.
+func (o *machineOutput) Redirect(commands string) string {
+	return fmt.Sprintf("exec >>%s 2>>%s\n%s",
+		shellquote.Join(o.streams[0].path), shellquote.Join(o.streams[1].path), commands)
+}
+
+// Start starts copying the output to the writers.
+func (o *machineOutput) Start(clk clock.Clock) {
+	o.stop = make(chan struct{})
+	o.done = make(chan struct{})
+	go func() {
+		defer close(o.done)
+		for {
+			select {
+			case <-o.stop:
+				return
+			case <-clk.After(machineOutputPollInterval):
+				o.copy()
+			}
+		}
+	}()
+}
+
+// Stop stops copying the output once any output
+// not yet copied has been written to the writers.
+func (o *machineOutput) Stop() {
+	if o.stop != nil {
+		close(o.stop)
+		<-o.done
+		o.stop = nil
+	}
+	o.copy()
+}
+
+// Output returns the complete standard output and error of the commands.
+func (o *machineOutput) Output() ([]byte, []byte, error) {
+	stdout, err := ioutil.ReadFile(o.streams[0].path)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	stderr, err := ioutil.ReadFile(o.streams[1].path)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return stdout, stderr, nil
+}
+
+// Close removes the output files.
+func (o *machineOutput) Close() {
+	for _, s := range o.streams {
+		_ = s.file.Close()
+	}
+	_ = os.RemoveAll(o.dir)
+}
+
+func (o *machineOutput) copy() {
+	for _, s := range o.streams {
+		// Each copy carries on from where the last one reached
+		// the end of the file.
+		_, _ = io.Copy(s.w, s.file)
+	}
+}
@@ -115,6 +115,10 @@ type Context interface {
 	ResetExecutionSetUnitStatus()
 	ModelType() model.ModelType
 
+	// LogActionOutput records a chunk of output written by the running
+	// action to its "stdout" or "stderr" stream.
+	LogActionOutput(stream, data string) error
+
 	Prepare() error
 	Flush(badge string, failure error) error
 
@@ -149,8 +153,23 @@ type ExecParams struct {
 func execOnMachine(params ExecParams) (*utilexec.ExecResponse, error) {
 	hostEnv := os.Environ()
 	hostEnv = append(hostEnv, params.Env...)
+	commands := strings.Join(params.Commands, " ")
+
+	// Stream the output of the commands to the supplied writers
+	// while they run. Commands run with powershell on windows
+	// are not streamed.
+	var output *machineOutput
+	if params.Stdout != nil && params.Stderr != nil && jujuos.HostOS() != jujuos.Windows {
+		var err error
+		if output, err = newMachineOutput(params.Stdout, params.Stderr); err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer output.Close()
+		commands = output.Redirect(commands)
+	}
+
 	command := utilexec.RunParams{
-		Commands:    strings.Join(params.Commands, " "),
+		Commands:    commands,
 		WorkingDir:  params.WorkingDir,
 		Environment: hostEnv,
 		Clock:       params.Clock,
@@ -161,8 +180,25 @@ func execOnMachine(params ExecParams) (*utilexec.ExecResponse, error) {
 	}
 	// TODO: refactor kill process and implement kill for caas exec.
 	params.ProcessSetter(hookProcess{command.Process()})
-	// Block and wait for process to finish
-	return command.WaitWithCancel(params.Cancel)
+	if output == nil {
+		// Block and wait for process to finish
+		return command.WaitWithCancel(params.Cancel)
+	}
+
+	clk := params.Clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
+	output.Start(clk)
+	result, err := command.WaitWithCancel(params.Cancel)
+	output.Stop()
+	if result != nil {
+		var outputErr error
+		if result.Stdout, result.Stderr, outputErr = output.Output(); outputErr != nil {
+			return nil, errors.Trace(outputErr)
+		}
+	}
+	return result, err
 }
 
 // ExecFunc is the exec func type.
@@ -270,6 +306,17 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 		return nil, errors.Trace(err)
 	}
 	var stdout, stderr bytes.Buffer
+	var stdoutWriter, stderrWriter io.ReadWriter = &stdout, &stderr
+	// When running an action, also stream the output so
+	// that it can be watched while the commands run.
+	if actionData, err := runner.context.ActionData(); err == nil && actionData != nil {
+		outStreamer := runner.newActionOutputStreamer("stdout")
+		defer outStreamer.Stop()
+		errStreamer := runner.newActionOutputStreamer("stderr")
+		defer errStreamer.Stop()
+		stdoutWriter = teeReadWriter{&stdout, io.MultiWriter(&stdout, outStreamer)}
+		stderrWriter = teeReadWriter{&stderr, io.MultiWriter(&stderr, errStreamer)}
+	}
 	return executor(ExecParams{
 		Commands:      []string{commands},
 		Env:           env,
@@ -277,8 +324,8 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 		Clock:         clock,
 		ProcessSetter: runner.context.SetProcess,
 		Cancel:        cancel,
-		Stdout:        &stdout,
-		Stderr:        &stderr,
+		Stdout:        stdoutWriter,
+		Stderr:        stderrWriter,
 	})
 }
 
@@ -522,6 +569,119 @@ func (b *bufferAdaptor) Bytes() []byte {
 	return b.outCopy.Bytes()
 }
 
+// teeReadWriter reads from a buffer while writing to
+// both the buffer and elsewhere.
+type teeReadWriter struct {
+	io.Reader
+	io.Writer
+}
+
+// actionOutputFlushInterval is how often the output written by a running
+// action is recorded against the action.
+var actionOutputFlushInterval = time.Second
+
+// actionOutputStreamer implements MessageReceiver and io.Writer. It
+// collects the output written by a running action to one of its streams
+// and periodically records the complete lines against the action, so
+// that the output can be watched while the action runs.
+type actionOutputStreamer struct {
+	context Context
+	stream  string
+	logger  loggo.Logger
+
+	mu      sync.Mutex
+	pending bytes.Buffer
+	failed  bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func (runner *runner) newActionOutputStreamer(stream string) *actionOutputStreamer {
+	s := &actionOutputStreamer{
+		context: runner.context,
+		stream:  stream,
+		logger:  runner.logger(),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.loop(clock.WallClock)
+	return s
+}
+
+func (s *actionOutputStreamer) loop(clock clock.Clock) {
+	defer close(s.done)
+	for {
+		select {
+		case <-s.stop:
+			s.flush(true)
+			return
+		case <-clock.After(actionOutputFlushInterval):
+			s.flush(false)
+		}
+	}
+}
+
+// Write implements the io.Writer interface.
+func (s *actionOutputStreamer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pending.Write(p)
+}
+
+// Messagef implements the charmrunner MessageReceiver interface.
+func (s *actionOutputStreamer) Messagef(isPrefix bool, message string, args ...interface{}) {
+	formattedMessage := message
+	if len(args) > 0 {
+		formattedMessage = fmt.Sprintf(message, args...)
+	}
+	if !isPrefix {
+		formattedMessage += "\n"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending.WriteString(formattedMessage)
+}
+
+// Stop records any output not yet sent, including a trailing
+// partial line, and stops the streamer.
+func (s *actionOutputStreamer) Stop() {
+	select {
+	case <-s.stop:
+	default:
+		close(s.stop)
+	}
+	<-s.done
+}
+
+func (s *actionOutputStreamer) flush(all bool) {
+	s.mu.Lock()
+	if s.failed {
+		s.mu.Unlock()
+		return
+	}
+	data := s.pending.Bytes()
+	if !all {
+		data = data[:bytes.LastIndexByte(data, '\n')+1]
+	}
+	chunk := strings.ToValidUTF8(string(data), "\uFFFD")
+	s.pending.Next(len(data))
+	s.mu.Unlock()
+	if chunk == "" {
+		return
+	}
+
+	// Streaming is best effort; the complete output is
+	// recorded in the action results once it finishes.
+	if err := s.context.LogActionOutput(s.stream, chunk); err != nil {
+		s.logger.Debugf("cannot stream action %s: %v", s.stream, err)
+		s.mu.Lock()
+		s.failed = true
+		s.mu.Unlock()
+	}
+}
+
 func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, env []string) error {
 	var cancel <-chan struct{}
 	outReader, outWriter, err := os.Pipe()
@@ -547,16 +707,23 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 	if runningAction {
 		cancel = actionData.Cancel
 
+		outStreamer := runner.newActionOutputStreamer("stdout")
+		defer outStreamer.Stop()
+		hookOutLogger.AddReceiver(outStreamer)
+
 		errReader, errWriter, err := os.Pipe()
 		if err != nil {
 			return errors.Errorf("cannot make stderr logging pipe: %v", err)
 		}
 		defer func() { _ = errWriter.Close() }()
 
+		errStreamer := runner.newActionOutputStreamer("stderr")
+		defer errStreamer.Stop()
+
 		actionErr = &bufferAdaptor{ReadWriter: errWriter}
 		hookErrLogger = charmrunner.NewHookLogger(errReader,
 			&loggerAdaptor{Logger: runner.getLogger(hookName), level: loggo.WARNING},
-			actionErr,
+			actionErr, errStreamer,
 		)
 		defer hookErrLogger.Stop()
 		go hookErrLogger.Run()
@@ -632,6 +799,13 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 		actionErr = &bufferAdaptor{ReadWriter: errWriter}
 		hookErrLogger.AddReceiver(actionErr)
 		cancel = actionData.Cancel
+
+		outStreamer := runner.newActionOutputStreamer("stdout")
+		defer outStreamer.Stop()
+		hookOutLogger.AddReceiver(outStreamer)
+		errStreamer := runner.newActionOutputStreamer("stderr")
+		defer errStreamer.Stop()
+		hookErrLogger.AddReceiver(errStreamer)
 	}
 
 	err = ps.Start()
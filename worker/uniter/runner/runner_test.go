@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/charm/v9/hooks"
@@ -22,6 +23,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/model"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -229,6 +231,9 @@ type MockContext struct {
 	flushFailure    error
 	flushResult     error
 	modelType       model.ModelType
+
+	mu           sync.Mutex
+	actionOutput map[string]string
 }
 
 func (ctx *MockContext) GetLogger(module string) loggo.Logger {
@@ -283,6 +288,16 @@ func (ctx *MockContext) UpdateActionResults(keys []string, value interface{}) er
 	return nil
 }
 
+func (ctx *MockContext) LogActionOutput(stream, data string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.actionOutput == nil {
+		ctx.actionOutput = make(map[string]string)
+	}
+	ctx.actionOutput[stream] += data
+	return nil
+}
+
 func (ctx *MockContext) ModelType() model.ModelType {
 	if ctx.modelType == "" {
 		return model.IAAS
@@ -388,6 +403,9 @@ func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	c.Assert(ctx.actionResults, jc.DeepEquals, map[string]interface{}{
 		"return-code": 0, "stderr": "world\n", "stdout": "hello\n",
 	})
+	c.Assert(ctx.actionOutput, jc.DeepEquals, map[string]string{
+		"stderr": "world\n", "stdout": "hello\n",
+	})
 }
 
 func (s *RunMockContextSuite) TestRunActionFlushCharmActionsCAASSuccess(c *gc.C) {
@@ -510,6 +528,70 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 	c.Assert(ctx.actionResults["stderr"], gc.Equals, nil)
 }
 
+func (s *RunMockContextSuite) TestRunActionStreamsOutput(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("commands run with powershell are not streamed")
+	}
+	s.PatchValue(runner.ActionOutputFlushInterval, 10*time.Millisecond)
+	proceed := filepath.Join(c.MkDir(), "proceed")
+	params := map[string]interface{}{
+		"command": fmt.Sprintf("echo first\necho oops >&2\nwhile [ ! -f %s ]; do sleep 0.01; done\necho second", proceed),
+		"timeout": 0,
+	}
+	ctx := &MockContext{
+		actionData: &context.ActionData{
+			Params: params,
+		},
+		actionParams:  params,
+		actionResults: map[string]interface{}{},
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := runner.NewRunner(ctx, s.paths, nil).RunAction("juju-run")
+		done <- err
+	}()
+
+	// The first chunks of output arrive while the command is blocked.
+	actionOutput := func() map[string]string {
+		ctx.mu.Lock()
+		defer ctx.mu.Unlock()
+		output := make(map[string]string)
+		for stream, data := range ctx.actionOutput {
+			output[stream] = data
+		}
+		return output
+	}
+	for a := coretesting.LongAttempt.Start(); ; {
+		output := actionOutput()
+		if output["stdout"] == "first\n" && output["stderr"] == "oops\n" {
+			break
+		}
+		if !a.Next() {
+			c.Fatalf("output not streamed before the command finished: %v", output)
+		}
+	}
+	select {
+	case err := <-done:
+		c.Fatalf("command finished early: %v", err)
+	default:
+	}
+
+	err := ioutil.WriteFile(proceed, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the command to finish")
+	}
+	c.Assert(actionOutput(), jc.DeepEquals, map[string]string{
+		"stdout": "first\nsecond\n", "stderr": "oops\n",
+	})
+	c.Assert(ctx.actionResults, jc.DeepEquals, map[string]interface{}{
+		"return-code": 0, "stdout": "first\nsecond\n", "stderr": "oops\n",
+	})
+}
+
 func (s *RunMockContextSuite) TestRunActionError(c *gc.C) {
 	params := map[string]interface{}{
 		"command": "echo 1\nexit 3",
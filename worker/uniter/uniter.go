@@ -391,6 +391,9 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			// error state.
 			return nil
 		}
+		if watcher.Snapshot().Paused {
+			return setAgentStatus(u, status.Paused, "hook execution paused", nil)
+		}
 		return setAgentStatus(u, status.Idle, "", nil)
 	}
 